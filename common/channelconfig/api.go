@@ -194,6 +194,15 @@ type ApplicationCapabilities interface {
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
+
+	// ChaincodeDefinitionHistory returns true if the new chaincode lifecycle
+	// retains the chaincode definitions committed for each sequence (as
+	// introduced in v2.5).
+	ChaincodeDefinitionHistory() bool
+
+	// PurgePvtData returns true if chaincode may purge private data from
+	// the channel (as introduced in v2.5).
+	PurgePvtData() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -30,8 +30,16 @@ type Options struct {
 	Logger        Logger
 	ListenAddress string
 	TLS           TLS
+	// WriteTimeout is the maximum duration before timing out writes of a
+	// response. It defaults to 2 minutes when not set. Handlers that stream
+	// responses can extend it with SetWriteDeadline.
+	WriteTimeout time.Duration
 }
 
+// connKey is the request context key of the connection a request was
+// received on.
+type connKey struct{}
+
 type Server struct {
 	logger     Logger
 	options    Options
@@ -88,13 +96,45 @@ func (s *Server) Stop() error {
 }
 
 func (s *Server) initializeServer() {
+	writeTimeout := s.options.WriteTimeout
+	if writeTimeout == 0 {
+		writeTimeout = 2 * time.Minute
+	}
+
 	s.mux = http.NewServeMux()
 	s.httpServer = &http.Server{
-		Addr:         s.options.ListenAddress,
-		Handler:      s.mux,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 2 * time.Minute,
+		Addr:        s.options.ListenAddress,
+		Handler:     withWriteDeadline(s.mux, writeTimeout),
+		ReadTimeout: 10 * time.Second,
+		// The write timeout is applied by withWriteDeadline instead of the
+		// http.Server WriteTimeout, which cannot be extended by handlers.
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			return context.WithValue(ctx, connKey{}, c)
+		},
+	}
+}
+
+// withWriteDeadline sets the write deadline of the connection when the
+// handling of each request starts.
+func withWriteDeadline(h http.Handler, writeTimeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetWriteDeadline(r, time.Now().Add(writeTimeout))
+		h.ServeHTTP(w, r)
+	})
+}
+
+// SetWriteDeadline sets the deadline for writing the response to a request
+// received by a Server, replacing the deadline set from the server
+// WriteTimeout. Handlers that stream responses, such as server-sent events,
+// call it before each write so that the stream is only ended when a write
+// stalls, rather than after a fixed duration. It has no effect on requests
+// that were not received by a Server.
+func SetWriteDeadline(r *http.Request, t time.Time) error {
+	conn, ok := r.Context().Value(connKey{}).(net.Conn)
+	if !ok {
+		return nil
 	}
+	return conn.SetWriteDeadline(t)
 }
 
 func (s *Server) HandlerChain(h http.Handler, secure bool) http.Handler {
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/hyperledger/fabric/common/fabhttp"
 	"github.com/hyperledger/fabric/core/operations/fakes"
@@ -207,6 +208,51 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Context("when a handler writes after the write timeout", func() {
+		var extend bool
+
+		BeforeEach(func() {
+			extend = false
+			options.TLS.Enabled = false
+			options.WriteTimeout = 200 * time.Millisecond
+			server = fabhttp.NewServer(options)
+			server.RegisterHandler(AdditionalTestApiPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for i := 0; i < 3; i++ {
+					if extend {
+						fabhttp.SetWriteDeadline(r, time.Now().Add(options.WriteTimeout))
+					}
+					fmt.Fprintf(w, "event %d\n", i)
+					w.(http.Flusher).Flush()
+					time.Sleep(150 * time.Millisecond)
+				}
+			}), false)
+		})
+
+		It("ends the response", func() {
+			err := server.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := client.Get(fmt.Sprintf("http://%s%s", server.Addr(), AdditionalTestApiPath))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			_, err = ioutil.ReadAll(resp.Body)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("completes the response when the handler extends the write deadline", func() {
+			extend = true
+			err := server.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := client.Get(fmt.Sprintf("http://%s%s", server.Addr(), AdditionalTestApiPath))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			buff, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buff)).To(Equal("event 0\nevent 1\nevent 2\n"))
+		})
+	})
+
 	It("proxies Log to the provided logger", func() {
 		err := server.Log("key", "value")
 		Expect(err).NotTo(HaveOccurred())
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
@@ -159,6 +160,7 @@ func checkWithWrongInputs(t *testing.T, store *BlockStore, numBlocks int) {
 	require.Equal(t, peer.TxValidationCode(-1), txCode)
 	require.Equal(t, uint64(0), blkNum)
 	require.EqualError(t, err, "no such transaction ID [non-existent-txid] in index")
+	require.IsType(t, ledger.NotFoundInIndexErr(""), err)
 }
 
 func TestBlockStoreProvider(t *testing.T) {
@@ -55,3 +55,10 @@ type QueryResult interface{}
 
 // PrunePolicy - a general interface for supporting different pruning policies
 type PrunePolicy interface{}
+
+// NotFoundInIndexErr is used to indicate that an entry is not present in an index
+type NotFoundInIndexErr string
+
+func (e NotFoundInIndexErr) Error() string {
+	return string(e)
+}
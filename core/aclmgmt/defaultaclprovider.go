@@ -57,6 +57,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Lifecycle_CommitChaincodeDefinition] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinition] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinitions] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinitionHistory] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_CheckCommitReadiness] = CHANNELWRITERS
 
 	//-------------- snapshot ---------------
@@ -64,6 +65,21 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.pResourcePolicyMap[resources.Snapshot_cancelrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_listpending] = policy.Admins
 
+	//-------------- reconciliation ---------------
+	d.pResourcePolicyMap[resources.Reconciliation_reconcile] = policy.Admins
+	d.pResourcePolicyMap[resources.Reconciliation_pause] = policy.Admins
+	d.pResourcePolicyMap[resources.Reconciliation_resume] = policy.Admins
+	d.pResourcePolicyMap[resources.Reconciliation_status] = policy.Admins
+
+	//-------------- private data export ---------------
+	d.pResourcePolicyMap[resources.PvtData_export] = policy.Admins
+	d.pResourcePolicyMap[resources.PvtData_import] = policy.Admins
+
+	//-------------- transient store ---------------
+	d.pResourcePolicyMap[resources.TransientStore_list] = policy.Admins
+	d.pResourcePolicyMap[resources.TransientStore_stats] = policy.Admins
+	d.pResourcePolicyMap[resources.TransientStore_purge] = policy.Admins
+
 	//-------------- LSCC --------------
 	//p resources (implemented by the chaincode currently)
 	d.pResourcePolicyMap[resources.Lscc_Install] = policy.Admins
@@ -111,6 +127,10 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	// Gateway resources
 	d.cResourcePolicyMap[resources.Gateway_CommitStatus] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Gateway_ChaincodeEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_BlockEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_FilteredBlockEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_BlockAndPrivateDataEvents] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Gateway_SubmitWithRetry] = CHANNELWRITERS
 
 	return d
 }
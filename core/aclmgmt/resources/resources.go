@@ -21,6 +21,7 @@ const (
 	Lifecycle_CommitChaincodeDefinition          = "_lifecycle/CommitChaincodeDefinition"
 	Lifecycle_QueryChaincodeDefinition           = "_lifecycle/QueryChaincodeDefinition"
 	Lifecycle_QueryChaincodeDefinitions          = "_lifecycle/QueryChaincodeDefinitions"
+	Lifecycle_QueryChaincodeDefinitionHistory    = "_lifecycle/QueryChaincodeDefinitionHistory"
 	Lifecycle_CheckCommitReadiness               = "_lifecycle/CheckCommitReadiness"
 
 	// snapshot resources
@@ -28,6 +29,21 @@ const (
 	Snapshot_cancelrequest = "snapshot/cancelrequest"
 	Snapshot_listpending   = "snapshot/listpending"
 
+	// private data reconciliation resources
+	Reconciliation_reconcile = "reconciliation/reconcile"
+	Reconciliation_pause     = "reconciliation/pause"
+	Reconciliation_resume    = "reconciliation/resume"
+	Reconciliation_status    = "reconciliation/status"
+
+	// private data export resources
+	PvtData_export = "pvtdata/export"
+	PvtData_import = "pvtdata/import"
+
+	// transient store resources
+	TransientStore_list  = "transientstore/list"
+	TransientStore_stats = "transientstore/stats"
+	TransientStore_purge = "transientstore/purge"
+
 	// Lscc resources
 	Lscc_Install                   = "lscc/Install"
 	Lscc_Deploy                    = "lscc/Deploy"
@@ -63,6 +79,10 @@ const (
 	Event_FilteredBlock = "event/FilteredBlock"
 
 	// Gateway resources
-	Gateway_CommitStatus    = "gateway/CommitStatus"
-	Gateway_ChaincodeEvents = "gateway/ChaincodeEvents"
+	Gateway_CommitStatus              = "gateway/CommitStatus"
+	Gateway_ChaincodeEvents           = "gateway/ChaincodeEvents"
+	Gateway_BlockEvents               = "gateway/BlockEvents"
+	Gateway_FilteredBlockEvents       = "gateway/FilteredBlockEvents"
+	Gateway_BlockAndPrivateDataEvents = "gateway/BlockAndPrivateDataEvents"
+	Gateway_SubmitWithRetry           = "gateway/SubmitWithRetry"
 )
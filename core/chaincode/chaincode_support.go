@@ -67,8 +67,10 @@ type ChaincodeSupport struct {
 	HandlerMetrics         *HandlerMetrics
 	HandlerRegistry        *HandlerRegistry
 	Keepalive              time.Duration
+	KeepaliveTimeout       time.Duration
 	Launcher               Launcher
 	Lifecycle              Lifecycle
+	MaxBatchSize           int
 	Peer                   *peer.Peer
 	Runtime                Runtime
 	TotalQueryLimit        int
@@ -113,6 +115,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 	handler := &Handler{
 		Invoker:                cs,
 		Keepalive:              cs.Keepalive,
+		KeepaliveTimeout:       cs.KeepaliveTimeout,
 		Registry:               cs.HandlerRegistry,
 		ACLProvider:            cs.ACLProvider,
 		TXContexts:             NewTransactionContexts(),
@@ -126,6 +129,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		AppConfig:              cs.AppConfig,
 		Metrics:                cs.HandlerMetrics,
 		TotalQueryLimit:        cs.TotalQueryLimit,
+		MaxBatchSize:           cs.MaxBatchSize,
 	}
 
 	return handler.ProcessStream(stream)
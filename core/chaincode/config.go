@@ -12,25 +12,33 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 const (
 	defaultExecutionTimeout = 30 * time.Second
 	minimumStartupTimeout   = 5 * time.Second
+	defaultMaxBatchSize     = 1000
+	defaultInitialBackoff   = time.Second
+	defaultMaxBackoff       = time.Minute
 )
 
 type Config struct {
-	TotalQueryLimit int
-	TLSEnabled      bool
-	Keepalive       time.Duration
-	ExecuteTimeout  time.Duration
-	InstallTimeout  time.Duration
-	StartupTimeout  time.Duration
-	LogFormat       string
-	LogLevel        string
-	ShimLogLevel    string
-	SCCAllowlist    map[string]bool
+	TotalQueryLimit  int
+	MaxBatchSize     int
+	TLSEnabled       bool
+	Keepalive        time.Duration
+	KeepaliveTimeout time.Duration
+	ExecuteTimeout   time.Duration
+	InstallTimeout   time.Duration
+	StartupTimeout   time.Duration
+	LogFormat        string
+	LogLevel         string
+	ShimLogLevel     string
+	SCCAllowlist     map[string]bool
+	Restart          *RestartPolicy
+	HealthCheck      bool
 }
 
 func GlobalConfig() *Config {
@@ -48,6 +56,7 @@ func (c *Config) load() {
 	c.TLSEnabled = viper.GetBool("peer.tls.enabled")
 
 	c.Keepalive = toSeconds(viper.GetString("chaincode.keepalive"), 0)
+	c.KeepaliveTimeout = viper.GetDuration("chaincode.keepaliveTimeout")
 	c.ExecuteTimeout = viper.GetDuration("chaincode.executetimeout")
 	if c.ExecuteTimeout < time.Second {
 		c.ExecuteTimeout = defaultExecutionTimeout
@@ -71,6 +80,45 @@ func (c *Config) load() {
 	if viper.IsSet("ledger.state.totalQueryLimit") {
 		c.TotalQueryLimit = viper.GetInt("ledger.state.totalQueryLimit")
 	}
+
+	c.MaxBatchSize = defaultMaxBatchSize
+	if viper.IsSet("chaincode.batch.maxSize") {
+		c.MaxBatchSize = viper.GetInt("chaincode.batch.maxSize")
+	}
+	if viper.IsSet("chaincode.batch.enabled") && !viper.GetBool("chaincode.batch.enabled") {
+		c.MaxBatchSize = 0
+	}
+
+	c.HealthCheck = viper.GetBool("chaincode.healthCheck")
+
+	if viper.GetBool("chaincode.restart.enabled") {
+		c.Restart = &RestartPolicy{
+			InitialBackoff: viper.GetDuration("chaincode.restart.initialBackoff"),
+			MaxBackoff:     viper.GetDuration("chaincode.restart.maxBackoff"),
+			MaxAttempts:    viper.GetInt("chaincode.restart.maxAttempts"),
+		}
+		if c.Restart.InitialBackoff <= 0 {
+			c.Restart.InitialBackoff = defaultInitialBackoff
+		}
+		if c.Restart.MaxBackoff <= 0 {
+			c.Restart.MaxBackoff = defaultMaxBackoff
+		}
+		if c.Restart.MaxBackoff < c.Restart.InitialBackoff {
+			c.Restart.MaxBackoff = c.Restart.InitialBackoff
+		}
+	}
+}
+
+// Validate checks that the configuration values are consistent with each
+// other.
+func (c *Config) Validate() error {
+	// The keepalive timeout is checked when a keepalive message is due, so a
+	// timeout that does not exceed the keepalive interval would end the
+	// stream of a chaincode that is idle but healthy.
+	if c.Keepalive > 0 && c.KeepaliveTimeout != 0 && c.KeepaliveTimeout <= c.Keepalive {
+		return errors.Errorf("chaincode.keepaliveTimeout (%s) must be greater than chaincode.keepalive (%s)", c.KeepaliveTimeout, c.Keepalive)
+	}
+	return nil
 }
 
 func parseBool(s string) bool {
@@ -37,6 +37,14 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.logging.level", "warning")
 			viper.Set("chaincode.logging.shim", "warning")
 			viper.Set("chaincode.system.somecc", true)
+			viper.Set("chaincode.batch.enabled", true)
+			viper.Set("chaincode.batch.maxSize", 250)
+			viper.Set("chaincode.keepaliveTimeout", "2m")
+			viper.Set("chaincode.restart.enabled", true)
+			viper.Set("chaincode.restart.initialBackoff", "2s")
+			viper.Set("chaincode.restart.maxBackoff", "3m")
+			viper.Set("chaincode.restart.maxAttempts", 7)
+			viper.Set("chaincode.healthCheck", true)
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
@@ -48,6 +56,51 @@ var _ = Describe("Config", func() {
 			Expect(config.LogLevel).To(Equal("warn"))
 			Expect(config.ShimLogLevel).To(Equal("warn"))
 			Expect(config.SCCAllowlist).To(Equal(map[string]bool{"somecc": true}))
+			Expect(config.MaxBatchSize).To(Equal(250))
+			Expect(config.KeepaliveTimeout).To(Equal(2 * time.Minute))
+			Expect(config.Restart).To(Equal(&chaincode.RestartPolicy{
+				InitialBackoff: 2 * time.Second,
+				MaxBackoff:     3 * time.Minute,
+				MaxAttempts:    7,
+			}))
+			Expect(config.HealthCheck).To(BeTrue())
+		})
+
+		Context("when automatic restart is disabled", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.restart.enabled", false)
+			})
+
+			It("does not return a restart policy", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Restart).To(BeNil())
+			})
+		})
+
+		Context("when the restart backoff is not configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.restart.enabled", true)
+			})
+
+			It("falls back to the default backoff", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Restart).To(Equal(&chaincode.RestartPolicy{
+					InitialBackoff: time.Second,
+					MaxBackoff:     time.Minute,
+				}))
+			})
+		})
+
+		Context("when batched state access is disabled", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.batch.enabled", false)
+				viper.Set("chaincode.batch.maxSize", 250)
+			})
+
+			It("does not allow batches", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.MaxBatchSize).To(Equal(0))
+			})
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -97,6 +150,23 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("Validate", func() {
+		It("accepts a keepalive timeout greater than the keepalive interval", func() {
+			config := &chaincode.Config{Keepalive: 10 * time.Second, KeepaliveTimeout: 30 * time.Second}
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("accepts any keepalive timeout when keepalive is off", func() {
+			config := &chaincode.Config{KeepaliveTimeout: time.Second}
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("rejects a keepalive timeout that does not exceed the keepalive interval", func() {
+			config := &chaincode.Config{Keepalive: 10 * time.Second, KeepaliveTimeout: 10 * time.Second}
+			Expect(config.Validate()).To(MatchError("chaincode.keepaliveTimeout (10s) must be greater than chaincode.keepalive (10s)"))
+		})
+	})
+
 	Describe("IsDevMode", func() {
 		It("returns true when iff the mode equals 'dev'", func() {
 			viper.Set("chaincode.mode", chaincode.DevModeUserRunsChaincode)
@@ -116,13 +186,21 @@ func capture() (restore func()) {
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	config := map[string]string{
-		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
-		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
-		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
-		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
-		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
-		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
-		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"peer.tls.enabled":                 viper.GetString("peer.tls.enabled"),
+		"chaincode.keepalive":              viper.GetString("chaincode.keepalive"),
+		"chaincode.executetimeout":         viper.GetString("chaincode.executetimeout"),
+		"chaincode.startuptimeout":         viper.GetString("chaincode.startuptimeout"),
+		"chaincode.logging.format":         viper.GetString("chaincode.logging.format"),
+		"chaincode.logging.level":          viper.GetString("chaincode.logging.level"),
+		"chaincode.logging.shim":           viper.GetString("chaincode.logging.shim"),
+		"chaincode.batch.enabled":          viper.GetString("chaincode.batch.enabled"),
+		"chaincode.batch.maxSize":          viper.GetString("chaincode.batch.maxSize"),
+		"chaincode.keepaliveTimeout":       viper.GetString("chaincode.keepaliveTimeout"),
+		"chaincode.restart.enabled":        viper.GetString("chaincode.restart.enabled"),
+		"chaincode.restart.initialBackoff": viper.GetString("chaincode.restart.initialBackoff"),
+		"chaincode.restart.maxBackoff":     viper.GetString("chaincode.restart.maxBackoff"),
+		"chaincode.restart.maxAttempts":    viper.GetString("chaincode.restart.maxAttempts"),
+		"chaincode.healthCheck":            viper.GetString("chaincode.healthCheck"),
 	}
 
 	return func() {
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/pkg/chaincode/messages"
 	"github.com/pkg/errors"
 )
 
@@ -100,9 +101,17 @@ const (
 type Handler struct {
 	// Keepalive specifies the interval at which keep-alive messages are sent.
 	Keepalive time.Duration
+	// KeepaliveTimeout specifies how long the chaincode may go without
+	// sending any message, including responses to keep-alive messages,
+	// before it is considered unresponsive and its stream is terminated.
+	// The check is disabled when it or Keepalive is zero.
+	KeepaliveTimeout time.Duration
 	// TotalQueryLimit specifies the maximum number of results to return for
 	// chaincode queries.
 	TotalQueryLimit int
+	// MaxBatchSize specifies the maximum number of keys in a batched state
+	// message. Batched state messages are not supported when it is zero.
+	MaxBatchSize int
 	// Invoker is used to invoke chaincode.
 	Invoker Invoker
 	// Registry is used to track active handlers.
@@ -152,7 +161,7 @@ type Handler struct {
 
 // handleMessage is called by ProcessStream to dispatch messages.
 func (h *Handler) handleMessage(msg *pb.ChaincodeMessage) error {
-	chaincodeLogger.Debugf("[%s] Fabric side handling ChaincodeMessage of type: %s in state %s", shorttxid(msg.Txid), msg.Type, h.state)
+	chaincodeLogger.Debugf("[%s] Fabric side handling ChaincodeMessage of type: %s in state %s", shorttxid(msg.Txid), messages.TypeName(msg.Type), h.state)
 
 	if msg.Type == pb.ChaincodeMessage_KEEPALIVE {
 		return nil
@@ -173,7 +182,7 @@ func (h *Handler) handleMessageCreatedState(msg *pb.ChaincodeMessage) error {
 	case pb.ChaincodeMessage_REGISTER:
 		h.HandleRegister(msg)
 	default:
-		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in created state", msg.Txid, msg.Type)
+		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in created state", msg.Txid, messages.TypeName(msg.Type))
 	}
 	return nil
 }
@@ -188,6 +197,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandlePutState)
 	case pb.ChaincodeMessage_DEL_STATE:
 		go h.HandleTransaction(msg, h.HandleDelState)
+	case pb.ChaincodeMessage_PURGE_PRIVATE_DATA:
+		go h.HandleTransaction(msg, h.HandlePurgePrivateData)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
 	case pb.ChaincodeMessage_GET_STATE:
@@ -208,8 +219,14 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetStateMetadata)
 	case pb.ChaincodeMessage_PUT_STATE_METADATA:
 		go h.HandleTransaction(msg, h.HandlePutStateMetadata)
+	case messages.GetStateMultipleType:
+		go h.HandleTransaction(msg, h.HandleGetStateMultiple)
+	case messages.PutStateMultipleType:
+		go h.HandleTransaction(msg, h.HandlePutStateMultiple)
+	case messages.DelStateMultipleType:
+		go h.HandleTransaction(msg, h.HandleDelStateMultiple)
 	default:
-		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in ready state", msg.Txid, msg.Type)
+		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in ready state", msg.Txid, messages.TypeName(msg.Type))
 	}
 
 	return nil
@@ -226,7 +243,7 @@ type handleFunc func(*pb.ChaincodeMessage, *TransactionContext) (*pb.ChaincodeMe
 // returned by the delegate are sent to the chat stream. Any errors returned by the
 // delegate are packaged as chaincode error messages.
 func (h *Handler) HandleTransaction(msg *pb.ChaincodeMessage, delegate handleFunc) {
-	chaincodeLogger.Debugf("[%s] handling %s from chaincode", shorttxid(msg.Txid), msg.Type.String())
+	chaincodeLogger.Debugf("[%s] handling %s from chaincode", shorttxid(msg.Txid), messages.TypeName(msg.Type))
 	if !h.registerTxid(msg) {
 		return
 	}
@@ -241,7 +258,7 @@ func (h *Handler) HandleTransaction(msg *pb.ChaincodeMessage, delegate handleFun
 	}
 
 	meterLabels := []string{
-		"type", msg.Type.String(),
+		"type", messages.TypeName(msg.Type),
 		"channel", msg.ChannelId,
 		"chaincode", h.chaincodeID,
 	}
@@ -253,12 +270,12 @@ func (h *Handler) HandleTransaction(msg *pb.ChaincodeMessage, delegate handleFun
 	}
 
 	if err != nil {
-		err = errors.Wrapf(err, "%s failed: transaction ID: %s", msg.Type, msg.Txid)
-		chaincodeLogger.Errorf("[%s] Failed to handle %s. error: %+v", shorttxid(msg.Txid), msg.Type, err)
+		err = errors.Wrapf(err, "%s failed: transaction ID: %s", messages.TypeName(msg.Type), msg.Txid)
+		chaincodeLogger.Errorf("[%s] Failed to handle %s. error: %+v", shorttxid(msg.Txid), messages.TypeName(msg.Type), err)
 		resp = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid, ChannelId: msg.ChannelId}
 	}
 
-	chaincodeLogger.Debugf("[%s] Completed %s. Sending %s", shorttxid(msg.Txid), msg.Type, resp.Type)
+	chaincodeLogger.Debugf("[%s] Completed %s. Sending %s", shorttxid(msg.Txid), messages.TypeName(msg.Type), resp.Type)
 	h.ActiveTransactions.Remove(msg.ChannelId, msg.Txid)
 	h.serialSendAsync(resp)
 
@@ -401,10 +418,12 @@ func (h *Handler) ProcessStream(stream ccintf.ChaincodeStream) error {
 		msgAvail <- &recvMsg{in, err}
 	}
 
+	lastReceived := time.Now()
 	go receiveMessage()
 	for {
 		select {
 		case rmsg := <-msgAvail:
+			lastReceived = time.Now()
 			switch {
 			// Defer the deregistering of the this handler.
 			case rmsg.err == io.EOF:
@@ -434,6 +453,12 @@ func (h *Handler) ProcessStream(stream ccintf.ChaincodeStream) error {
 			chaincodeLogger.Errorf("%s", err)
 			return err
 		case <-keepaliveCh:
+			if h.KeepaliveTimeout != 0 && time.Since(lastReceived) > h.KeepaliveTimeout {
+				h.Metrics.KeepaliveTimeouts.With("chaincode", h.chaincodeID).Add(1)
+				err := errors.Errorf("no message received from chaincode %s in %s, ending chaincode support stream", h.chaincodeID, h.KeepaliveTimeout)
+				chaincodeLogger.Errorf("%s", err)
+				return err
+			}
 			// if no error message from serialSend, KEEPALIVE happy, and don't care about error
 			// (maybe it'll work later)
 			h.serialSendAsync(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE})
@@ -501,8 +526,22 @@ func (h *Handler) HandleRegister(msg *pb.ChaincodeMessage) {
 		return
 	}
 
+	registered := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}
+	if h.MaxBatchSize > 0 {
+		// Advertise batched state support. Older shims ignore the payload
+		// of the REGISTERED message.
+		registered.Payload, err = proto.Marshal(&messages.PeerCapabilities{
+			BatchState:   true,
+			MaxBatchSize: uint32(h.MaxBatchSize),
+		})
+		if err != nil {
+			h.notifyRegistry(errors.Wrap(err, "failed to marshal peer capabilities"))
+			return
+		}
+	}
+
 	chaincodeLogger.Debugf("Got %s for chaincodeID = %s, sending back %s", pb.ChaincodeMessage_REGISTER, h.chaincodeID, pb.ChaincodeMessage_REGISTERED)
-	if err := h.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}); err != nil {
+	if err := h.serialSend(registered); err != nil {
 		chaincodeLogger.Errorf("error sending %s: %s", pb.ChaincodeMessage_REGISTERED, err)
 		h.notifyRegistry(err)
 		return
@@ -529,7 +568,7 @@ func (h *Handler) Notify(msg *pb.ChaincodeMessage) {
 	startTime := time.Now()
 	tctx := h.TXContexts.Get(msg.ChannelId, msg.Txid)
 	if tctx == nil {
-		chaincodeLogger.Debugf("notifier Txid:%s, channelID:%s does not exist for handling message %s", msg.Txid, msg.ChannelId, msg.Type)
+		chaincodeLogger.Debugf("notifier Txid:%s, channelID:%s does not exist for handling message %s", msg.Txid, msg.ChannelId, messages.TypeName(msg.Type))
 		return
 	}
 	h.Metrics.ChaincodeProposalTransactionGetContext.With(labels...).Observe(time.Since(startTime).Seconds())
@@ -577,6 +616,18 @@ func (h *Handler) checkMetadataCap(msg *pb.ChaincodeMessage) error {
 	return nil
 }
 
+func (h *Handler) checkPurgePvtDataCap(msg *pb.ChaincodeMessage) error {
+	ac, exists := h.AppConfig.GetApplicationConfig(msg.ChannelId)
+	if !exists {
+		return errors.Errorf("application config does not exist for %s", msg.ChannelId)
+	}
+
+	if !ac.Capabilities().PurgePvtData() {
+		return errors.New("purging private data is not enabled, channel application capability of V2_5 or later is required")
+	}
+	return nil
+}
+
 func errorIfCreatorHasNoReadPermission(chaincodeName, collection string, txContext *TransactionContext) error {
 	rwPermission, err := getReadWritePermission(chaincodeName, collection, txContext)
 	if err != nil {
@@ -658,6 +709,57 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// checkBatchSize ensures batched state messages are supported and the number
+// of keys does not exceed the advertised maximum.
+func (h *Handler) checkBatchSize(size int) error {
+	if h.MaxBatchSize <= 0 {
+		return errors.New("batched state messages are not enabled")
+	}
+	if size > h.MaxBatchSize {
+		return errors.Errorf("batch size %d exceeds the maximum of %d", size, h.MaxBatchSize)
+	}
+	return nil
+}
+
+// Handles query to ledger to get the state of multiple keys
+func (h *Handler) HandleGetStateMultiple(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateMultiple := &messages.GetStateMultiple{}
+	err := proto.Unmarshal(msg.Payload, getStateMultiple)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if err := h.checkBatchSize(len(getStateMultiple.Keys)); err != nil {
+		return nil, err
+	}
+
+	var values [][]byte
+	namespaceID := txContext.NamespaceID
+	collection := getStateMultiple.Collection
+	chaincodeLogger.Debugf("[%s] getting state for chaincode %s, %d keys, channel %s", shorttxid(msg.Txid), namespaceID, len(getStateMultiple.Keys), txContext.ChannelID)
+
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if err := errorIfCreatorHasNoReadPermission(namespaceID, collection, txContext); err != nil {
+			return nil, err
+		}
+		values, err = txContext.TXSimulator.GetPrivateDataMultipleKeys(namespaceID, collection, getStateMultiple.Keys)
+	} else {
+		values, err = txContext.TXSimulator.GetStateMultipleKeys(namespaceID, getStateMultiple.Keys)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	payloadBytes, err := proto.Marshal(&messages.GetStateMultipleResult{Values: values})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	getState := &pb.GetState{}
 	err := proto.Unmarshal(msg.Payload, getState)
@@ -1103,6 +1205,110 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// HandlePurgePrivateData deletes a private data key and purges its historical versions once the transaction commits.
+func (h *Handler) HandlePurgePrivateData(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	purgePrivateState := &pb.PurgePrivateState{}
+	err := proto.Unmarshal(msg.Payload, purgePrivateState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	namespaceID := txContext.NamespaceID
+	collection := purgePrivateState.Collection
+	if !isCollectionSet(collection) {
+		return nil, errors.New("only private data can be purged")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	if err := h.checkPurgePvtDataCap(msg); err != nil {
+		return nil, err
+	}
+	if err := errorIfCreatorHasNoWritePermission(namespaceID, collection, txContext); err != nil {
+		return nil, err
+	}
+	if err := txContext.TXSimulator.PurgePrivateData(namespaceID, collection, purgePrivateState.Key); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Send response msg back to chaincode.
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+func (h *Handler) HandlePutStateMultiple(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	putStateMultiple := &messages.PutStateMultiple{}
+	err := proto.Unmarshal(msg.Payload, putStateMultiple)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if err := h.checkBatchSize(len(putStateMultiple.Kvs)); err != nil {
+		return nil, err
+	}
+
+	kvs := make(map[string][]byte, len(putStateMultiple.Kvs))
+	for _, kv := range putStateMultiple.Kvs {
+		if _, exists := kvs[kv.Key]; exists {
+			return nil, errors.Errorf("duplicate key [%s] in batch", kv.Key)
+		}
+		kvs[kv.Key] = kv.Value
+	}
+
+	namespaceID := txContext.NamespaceID
+	collection := putStateMultiple.Collection
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if err := errorIfCreatorHasNoWritePermission(namespaceID, collection, txContext); err != nil {
+			return nil, err
+		}
+		err = txContext.TXSimulator.SetPrivateDataMultipleKeys(namespaceID, collection, kvs)
+	} else {
+		err = txContext.TXSimulator.SetStateMultipleKeys(namespaceID, kvs)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+func (h *Handler) HandleDelStateMultiple(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	delStateMultiple := &messages.DelStateMultiple{}
+	err := proto.Unmarshal(msg.Payload, delStateMultiple)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if err := h.checkBatchSize(len(delStateMultiple.Keys)); err != nil {
+		return nil, err
+	}
+
+	namespaceID := txContext.NamespaceID
+	collection := delStateMultiple.Collection
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if err := errorIfCreatorHasNoWritePermission(namespaceID, collection, txContext); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range delStateMultiple.Keys {
+		if isCollectionSet(collection) {
+			err = txContext.TXSimulator.DeletePrivateData(namespaceID, collection, key)
+		} else {
+			err = txContext.TXSimulator.DeleteState(namespaceID, key)
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	// Send response msg back to chaincode.
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
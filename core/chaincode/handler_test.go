@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/util"
 	ar "github.com/hyperledger/fabric/core/aclmgmt/resources"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/pkg/chaincode/messages"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -46,6 +48,7 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestsCompleted      *metricsfakes.Counter
 		fakeShimRequestDuration        *metricsfakes.Histogram
 		fakeExecuteTimeouts            *metricsfakes.Counter
+		fakeKeepaliveTimeouts          *metricsfakes.Counter
 		fakeCapabilites                *mock.ApplicationCapabilities
 
 		responseNotifier chan *pb.ChaincodeMessage
@@ -88,6 +91,7 @@ var _ = Describe("Handler", func() {
 		fakeApplicationConfig := &mock.ApplicationConfig{}
 		fakeCapabilites = &mock.ApplicationCapabilities{}
 		fakeCapabilites.KeyLevelEndorsementReturns(true)
+		fakeCapabilites.PurgePvtDataReturns(true)
 		fakeApplicationConfig.CapabilitiesReturns(fakeCapabilites)
 		fakeApplicationConfigRetriever = &fake.ApplicationConfigRetriever{}
 		fakeApplicationConfigRetriever.GetApplicationConfigReturns(fakeApplicationConfig, true)
@@ -100,15 +104,17 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestDuration.WithReturns(fakeShimRequestDuration)
 		fakeExecuteTimeouts = &metricsfakes.Counter{}
 		fakeExecuteTimeouts.WithReturns(fakeExecuteTimeouts)
+		fakeKeepaliveTimeouts = &metricsfakes.Counter{}
+		fakeKeepaliveTimeouts.WithReturns(fakeKeepaliveTimeouts)
 
 		builtinSCCs = map[string]struct{}{}
 
-		chaincodeMetrics := &chaincode.HandlerMetrics{
-			ShimRequestsReceived:  fakeShimRequestsReceived,
-			ShimRequestsCompleted: fakeShimRequestsCompleted,
-			ShimRequestDuration:   fakeShimRequestDuration,
-			ExecuteTimeouts:       fakeExecuteTimeouts,
-		}
+		chaincodeMetrics := chaincode.NewHandlerMetrics(&disabled.Provider{})
+		chaincodeMetrics.ShimRequestsReceived = fakeShimRequestsReceived
+		chaincodeMetrics.ShimRequestsCompleted = fakeShimRequestsCompleted
+		chaincodeMetrics.ShimRequestDuration = fakeShimRequestDuration
+		chaincodeMetrics.ExecuteTimeouts = fakeExecuteTimeouts
+		chaincodeMetrics.KeepaliveTimeouts = fakeKeepaliveTimeouts
 
 		handler = &chaincode.Handler{
 			ACLProvider:          fakeACLProvider,
@@ -489,6 +495,20 @@ var _ = Describe("Handler", func() {
 					ChannelId: "channel-id",
 				}))
 			})
+
+			Context("when the message is a batched state message", func() {
+				BeforeEach(func() {
+					incomingMessage.Type = messages.PutStateMultipleType
+				})
+
+				It("names the message type in the error response", func() {
+					handler.HandleTransaction(incomingMessage, fakeMessageHandler.Handle)
+
+					Eventually(fakeChatStream.SendCallCount).Should(Equal(1))
+					msg := fakeChatStream.SendArgsForCall(0)
+					Expect(string(msg.Payload)).To(Equal("PUT_STATE_MULTIPLE failed: transaction ID: tx-id: watermelon-swirl"))
+				})
+			})
 		})
 	})
 
@@ -897,6 +917,481 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePurgePrivateData", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.PurgePrivateState
+
+		BeforeEach(func() {
+			request = &pb.PurgePrivateState{
+				Key:        "purge-key",
+				Collection: "collection-name",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_PURGE_PRIVATE_DATA,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+			fakeCollectionStore.RetrieveReadWritePermissionReturns(false, true, nil)
+		})
+
+		It("calls PurgePrivateData on the transaction simulator and returns a response message", func() {
+			resp, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.PurgePrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("purge-key"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when collection is not set", func() {
+			BeforeEach(func() {
+				request.Collection = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("only private data can be purged"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when PurgePrivateData fails due to ledger error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.PurgePrivateDataReturns(errors.New("mango"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("mango"))
+			})
+		})
+
+		Context("when called from an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when the application config cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeApplicationConfigRetriever.GetApplicationConfigReturns(nil, false)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("application config does not exist for channel-id"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the PurgePvtData capability is not enabled", func() {
+			BeforeEach(func() {
+				fakeCapabilites.PurgePvtDataReturns(false)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("purging private data is not enabled, channel application capability of V2_5 or later is required"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the creator has no write access permission", func() {
+			BeforeEach(func() {
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+			})
+
+			It("returns the error from errorIfCreatorHasNoWriteAccess", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("tx creator does not have write access" +
+					" permission on privatedata in chaincodeName:cc-instance-name" +
+					" collectionName: collection-name"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("HandleGetStateMultiple", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			request         *messages.GetStateMultiple
+		)
+
+		BeforeEach(func() {
+			handler.MaxBatchSize = 2
+			request = &messages.GetStateMultiple{
+				Keys: []string{"key1", "key2"},
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      messages.GetStateMultipleType,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeTxSimulator.GetStateMultipleKeysReturns([][]byte{[]byte("value1"), nil}, nil)
+			fakeTxSimulator.GetPrivateDataMultipleKeysReturns([][]byte{nil, []byte("private-value2")}, nil)
+		})
+
+		It("returns the values in a response message", func() {
+			resp, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Txid).To(Equal("tx-id"))
+			Expect(resp.ChannelId).To(Equal("channel-id"))
+
+			result := &messages.GetStateMultipleResult{}
+			err = proto.Unmarshal(resp.Payload, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Values).To(HaveLen(2))
+			Expect(result.Values[0]).To(Equal([]byte("value1")))
+			Expect(result.Values[1]).To(BeEmpty())
+
+			Expect(fakeTxSimulator.GetStateMultipleKeysCallCount()).To(Equal(1))
+			ccname, keys := fakeTxSimulator.GetStateMultipleKeysArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(keys).To(Equal([]string{"key1", "key2"}))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when batched state messages are not enabled", func() {
+			BeforeEach(func() {
+				handler.MaxBatchSize = 0
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("batched state messages are not enabled"))
+				Expect(fakeTxSimulator.GetStateMultipleKeysCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the batch is too large", func() {
+			BeforeEach(func() {
+				handler.MaxBatchSize = 1
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("batch size 2 exceeds the maximum of 1"))
+				Expect(fakeTxSimulator.GetStateMultipleKeysCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when GetStateMultipleKeys fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateMultipleKeysReturns(nil, errors.New("tangerine"))
+			})
+
+			It("returns the error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("tangerine"))
+			})
+		})
+
+		Context("when the collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(true, false, nil)
+			})
+
+			It("calls GetPrivateDataMultipleKeys on the transaction simulator", func() {
+				resp, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTxSimulator.GetPrivateDataMultipleKeysCallCount()).To(Equal(1))
+				ccname, collection, keys := fakeTxSimulator.GetPrivateDataMultipleKeysArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(keys).To(Equal([]string{"key1", "key2"}))
+
+				result := &messages.GetStateMultipleResult{}
+				err = proto.Unmarshal(resp.Payload, result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Values).To(HaveLen(2))
+				Expect(result.Values[1]).To(Equal([]byte("private-value2")))
+			})
+
+			Context("and the creator has no read access permission", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have read access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+					Expect(fakeTxSimulator.GetPrivateDataMultipleKeysCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the transaction is an Init transaction", func() {
+				BeforeEach(func() {
+					txContext.IsInitTransaction = true
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				})
+			})
+		})
+	})
+
+	Describe("HandlePutStateMultiple", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			request         *messages.PutStateMultiple
+		)
+
+		BeforeEach(func() {
+			handler.MaxBatchSize = 2
+			request = &messages.PutStateMultiple{
+				Kvs: []*messages.KV{
+					{Key: "key1", Value: []byte("value1")},
+					{Key: "key2", Value: []byte("value2")},
+				},
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      messages.PutStateMultipleType,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("calls SetStateMultipleKeys and returns a response message", func() {
+			resp, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.SetStateMultipleKeysCallCount()).To(Equal(1))
+			ccname, kvs := fakeTxSimulator.SetStateMultipleKeysArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(kvs).To(Equal(map[string][]byte{
+				"key1": []byte("value1"),
+				"key2": []byte("value2"),
+			}))
+		})
+
+		Context("when the batch is too large", func() {
+			BeforeEach(func() {
+				handler.MaxBatchSize = 1
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("batch size 2 exceeds the maximum of 1"))
+				Expect(fakeTxSimulator.SetStateMultipleKeysCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the batch contains duplicate keys", func() {
+			BeforeEach(func() {
+				request.Kvs[1].Key = "key1"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("duplicate key [key1] in batch"))
+				Expect(fakeTxSimulator.SetStateMultipleKeysCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when SetStateMultipleKeys fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.SetStateMultipleKeysReturns(errors.New("clementine"))
+			})
+
+			It("returns the error", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("clementine"))
+			})
+		})
+
+		Context("when the collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(false, true, nil)
+			})
+
+			It("calls SetPrivateDataMultipleKeys on the transaction simulator", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTxSimulator.SetPrivateDataMultipleKeysCallCount()).To(Equal(1))
+				ccname, collection, kvs := fakeTxSimulator.SetPrivateDataMultipleKeysArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(kvs).To(Equal(map[string][]byte{
+					"key1": []byte("value1"),
+					"key2": []byte("value2"),
+				}))
+			})
+
+			Context("and the creator has no write access permission", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have write access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+					Expect(fakeTxSimulator.SetPrivateDataMultipleKeysCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Describe("HandleDelStateMultiple", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			request         *messages.DelStateMultiple
+		)
+
+		BeforeEach(func() {
+			handler.MaxBatchSize = 2
+			request = &messages.DelStateMultiple{
+				Keys: []string{"key1", "key2"},
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      messages.DelStateMultipleType,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("deletes each key and returns a response message", func() {
+			resp, err := handler.HandleDelStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(2))
+			ccname, key := fakeTxSimulator.DeleteStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("key1"))
+			_, key = fakeTxSimulator.DeleteStateArgsForCall(1)
+			Expect(key).To(Equal("key2"))
+		})
+
+		Context("when DeleteState fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.DeleteStateReturns(errors.New("satsuma"))
+			})
+
+			It("returns the error", func() {
+				_, err := handler.HandleDelStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("satsuma"))
+				Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(false, true, nil)
+			})
+
+			It("calls DeletePrivateData for each key", func() {
+				_, err := handler.HandleDelStateMultiple(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTxSimulator.DeletePrivateDataCallCount()).To(Equal(2))
+				ccname, collection, key := fakeTxSimulator.DeletePrivateDataArgsForCall(1)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(key).To(Equal("key2"))
+			})
+
+			Context("and the creator has no write access permission", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleDelStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have write access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+					Expect(fakeTxSimulator.DeletePrivateDataCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -2650,6 +3145,26 @@ var _ = Describe("Handler", func() {
 			}))
 		})
 
+		Context("when batched state access is enabled", func() {
+			BeforeEach(func() {
+				handler.MaxBatchSize = 500
+			})
+
+			It("advertises the capability in the registered message", func() {
+				handler.HandleRegister(incomingMessage)
+
+				Eventually(fakeChatStream.SendCallCount).Should(Equal(2))
+				registeredMessage := fakeChatStream.SendArgsForCall(0)
+				Expect(registeredMessage.Type).To(Equal(pb.ChaincodeMessage_REGISTERED))
+
+				capabilities := &messages.PeerCapabilities{}
+				err := proto.Unmarshal(registeredMessage.Payload, capabilities)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(capabilities.BatchState).To(BeTrue())
+				Expect(capabilities.MaxBatchSize).To(Equal(uint32(500)))
+			})
+		})
+
 		Context("when sending the ready message fails", func() {
 			BeforeEach(func() {
 				fakeChatStream.SendReturnsOnCall(1, errors.New("carrot"))
@@ -2787,8 +3302,9 @@ var _ = Describe("Handler", func() {
 
 			BeforeEach(func() {
 				recvChan = make(chan *pb.ChaincodeMessage, 1)
+				shadowRecvChan := recvChan // shadow to avoid race
 				fakeChatStream.RecvStub = func() (*pb.ChaincodeMessage, error) {
-					msg := <-recvChan
+					msg := <-shadowRecvChan
 					return msg, nil
 				}
 
@@ -2809,6 +3325,42 @@ var _ = Describe("Handler", func() {
 				}
 			})
 
+			Context("when the chaincode does not respond within the keepalive timeout", func() {
+				BeforeEach(func() {
+					handler.KeepaliveTimeout = 120 * time.Millisecond
+				})
+
+				It("ends the stream", func() {
+					errChan := make(chan error, 1)
+					go func() { errChan <- handler.ProcessStream(fakeChatStream) }()
+
+					Eventually(errChan).Should(Receive(MatchError("no message received from chaincode test-handler-name:1.0 in 120ms, ending chaincode support stream")))
+					Expect(fakeKeepaliveTimeouts.WithCallCount()).To(Equal(1))
+					Expect(fakeKeepaliveTimeouts.WithArgsForCall(0)).To(Equal([]string{"chaincode", "test-handler-name:1.0"}))
+					Expect(fakeKeepaliveTimeouts.AddCallCount()).To(Equal(1))
+				})
+
+				It("keeps the stream while the chaincode responds", func() {
+					stopResponding := make(chan struct{})
+					fakeChatStream.SendStub = func(msg *pb.ChaincodeMessage) error {
+						select {
+						case <-stopResponding:
+						default:
+							recvChan <- msg
+						}
+						return nil
+					}
+
+					errChan := make(chan error, 1)
+					go func() { errChan <- handler.ProcessStream(fakeChatStream) }()
+
+					Consistently(errChan, 500*time.Millisecond).ShouldNot(Receive())
+					close(stopResponding)
+					recvChan <- nil
+					Eventually(errChan).Should(Receive(MatchError("received nil message, ending chaincode support stream")))
+				})
+			})
+
 			Context("when keepalive is disabled", func() {
 				BeforeEach(func() {
 					handler.Keepalive = 0
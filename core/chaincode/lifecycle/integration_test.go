@@ -58,6 +58,7 @@ var _ = Describe("Integration", func() {
 		fakeChannelConfigSource = &mock.ChannelConfigSource{}
 		fakeChannelConfig = &mock.ChannelConfig{}
 		fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
+		resources.ChannelConfigSource = fakeChannelConfigSource
 		fakeApplicationConfig = &mock.ApplicationConfig{}
 		fakeChannelConfig.ApplicationConfigReturns(fakeApplicationConfig, true)
 		fakeCapabilities = &mock.ApplicationCapabilities{}
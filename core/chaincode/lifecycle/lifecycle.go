@@ -41,6 +41,11 @@ const (
 	// at some network resource). This namespace is only populated in the org implicit collection.
 	ChaincodeSourcesName = "chaincode-sources"
 
+	// ChaincodeHistoryName is the namespace reserved for storing the chaincode
+	// definitions committed for each sequence of a chaincode. This namespace is
+	// only populated in the public state.
+	ChaincodeHistoryName = "chaincode-history"
+
 	// ChaincodeLocalPackageType is the name of the type of chaincode-sources which may be serialized
 	// into the org's private data collection
 	ChaincodeLocalPackageType = "ChaincodeLocalPackage"
@@ -85,6 +90,11 @@ var DefaultEndorsementPolicyBytes = protoutil.MarshalOrPanic(&pb.ApplicationPoli
 // namespaces/fields/mycc/ValidationInfo:      {ValidationPlugin: "builtin", ValidationParameter: <application-policy>}
 // namespaces/fields/mycc/Collections          {<collection info>}
 //
+// Every committed definition is additionally retained in the public state
+// keyed by its sequence number, so that prior definitions remain queryable:
+// chaincode-history/metadata/<namespace>#<sequence_number> -> "ChaincodeDefinition"
+// chaincode-history/fields/<namespace>#<sequence_number>/<field> -> field of the definition
+//
 // Private/Org Scope Implcit Collection layout looks like the following
 // namespaces/metadata/<namespace>#<sequence_number> -> namespace metadata, including type
 // namespaces/fields/<namespace>#<sequence_number>/<field>  -> field of namespace type
@@ -296,6 +306,22 @@ func (r *Resources) LifecycleEndorsementPolicyAsBytes(channelID string) ([]byte,
 	}), nil
 }
 
+// chaincodeDefinitionHistoryEnabled returns whether the capabilities of the
+// channel allow the chaincode definition history to be retained and queried.
+func (r *Resources) chaincodeDefinitionHistoryEnabled(channelID string) (bool, error) {
+	channelConfig := r.ChannelConfigSource.GetStableChannelConfig(channelID)
+	if channelConfig == nil {
+		return false, errors.Errorf("could not get channel config for channel '%s'", channelID)
+	}
+
+	ac, ok := channelConfig.ApplicationConfig()
+	if !ok {
+		return false, errors.Errorf("could not get application config for channel '%s'", channelID)
+	}
+
+	return ac.Capabilities().ChaincodeDefinitionHistory(), nil
+}
+
 // ExternalFunctions is intended primarily to support the SCC functions.
 // In general, its methods signatures produce writes (which must be commmitted
 // as part of an endorsement flow), or return human readable errors (for
@@ -342,7 +368,8 @@ func (ef *ExternalFunctions) CheckCommitReadiness(chname, ccname string, cd *Cha
 // CommitChaincodeDefinition takes a chaincode definition, checks that its
 // sequence number is the next allowable sequence number, checks which
 // organizations have approved the definition, and applies the definition to
-// the public world state. It is the responsibility of the caller to check
+// the public world state. When the channel capabilities allow it, the definition
+// is also retained in the definition history. It is the responsibility of the caller to check
 // the approvals to determine if the result is valid (typically, this means
 // checking that the peer's own org has approved the definition).
 func (ef *ExternalFunctions) CommitChaincodeDefinition(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (map[string]bool, error) {
@@ -351,13 +378,68 @@ func (ef *ExternalFunctions) CommitChaincodeDefinition(chname, ccname string, cd
 		return nil, err
 	}
 
+	// the history changes the write set of the commit, so it may only be
+	// retained once every peer of the channel does so
+	historyEnabled, err := ef.Resources.chaincodeDefinitionHistoryEnabled(chname)
+	if err != nil {
+		return nil, err
+	}
+
+	var unrecordedDefinition *ChaincodeDefinition
+	if historyEnabled {
+		// definitions committed before the history was retained are recorded
+		// when they are replaced
+		unrecordedDefinition, err = ef.unrecordedDefinition(ccname, cd.Sequence-1, publicState)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err = ef.Resources.Serializer.Serialize(NamespacesName, ccname, cd, publicState); err != nil {
 		return nil, errors.WithMessage(err, "could not serialize chaincode definition")
 	}
 
+	if !historyEnabled {
+		return approvals, nil
+	}
+
+	for _, definition := range []*ChaincodeDefinition{unrecordedDefinition, cd} {
+		if definition == nil {
+			continue
+		}
+		historyName := fmt.Sprintf("%s#%d", ccname, definition.Sequence)
+		if err = ef.Resources.Serializer.Serialize(ChaincodeHistoryName, historyName, definition, publicState); err != nil {
+			return nil, errors.WithMessage(err, "could not serialize chaincode definition history")
+		}
+	}
+
 	return approvals, nil
 }
 
+// unrecordedDefinition returns the committed definition for the sequence when
+// it is missing from the history, or nil otherwise.
+func (ef *ExternalFunctions) unrecordedDefinition(ccname string, sequence int64, publicState ReadableState) (*ChaincodeDefinition, error) {
+	if sequence == 0 {
+		return nil, nil
+	}
+
+	historyName := fmt.Sprintf("%s#%d", ccname, sequence)
+	_, ok, err := ef.Resources.Serializer.DeserializeMetadata(ChaincodeHistoryName, historyName, publicState)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not fetch history metadata for %s", historyName)
+	}
+	if ok {
+		return nil, nil
+	}
+
+	definition, err := ef.QueryChaincodeDefinition(ccname, publicState)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not fetch current chaincode definition")
+	}
+
+	return definition, nil
+}
+
 // DefaultEndorsementPolicyAsBytes returns a marshalled version
 // of the default chaincode endorsement policy in the supplied channel
 func (ef *ExternalFunctions) DefaultEndorsementPolicyAsBytes(channelID string) ([]byte, error) {
@@ -638,6 +720,36 @@ func (ef *ExternalFunctions) QueryChaincodeDefinition(name string, publicState R
 	return definedChaincode, nil
 }
 
+// QueryChaincodeDefinitionHistory returns the chaincode definitions committed
+// for the given name in ascending sequence order. Sequences which were committed
+// before the history was retained and have since been replaced are omitted.
+func (ef *ExternalFunctions) QueryChaincodeDefinitionHistory(name string, publicState ReadableState) ([]*ChaincodeDefinition, error) {
+	currentDefinition, err := ef.QueryChaincodeDefinition(name, publicState)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make([]*ChaincodeDefinition, 0, currentDefinition.Sequence)
+	for sequence := int64(1); sequence < currentDefinition.Sequence; sequence++ {
+		historyName := fmt.Sprintf("%s#%d", name, sequence)
+		metadata, ok, err := ef.Resources.Serializer.DeserializeMetadata(ChaincodeHistoryName, historyName, publicState)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not fetch history metadata for %s", historyName)
+		}
+		if !ok {
+			continue
+		}
+
+		definition := &ChaincodeDefinition{}
+		if err := ef.Resources.Serializer.Deserialize(ChaincodeHistoryName, historyName, metadata, definition, publicState); err != nil {
+			return nil, errors.WithMessagef(err, "could not deserialize chaincode definition history for %s", historyName)
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return append(definitions, currentDefinition), nil
+}
+
 // QueryOrgApprovals returns a map containing the orgs whose orgStates were
 // provided and whether or not they have approved a chaincode definition with
 // the specified parameters.
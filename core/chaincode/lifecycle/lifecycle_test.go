@@ -276,6 +276,7 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeChannelConfigSource *mock.ChannelConfigSource
 		fakeChannelConfig       *mock.ChannelConfig
 		fakeApplicationConfig   *mock.ApplicationConfig
+		fakeCapabilities        *mock.ApplicationCapabilities
 		fakeOrgConfigs          []*mock.ApplicationOrgConfig
 		fakePolicyManager       *mock.PolicyManager
 	)
@@ -291,6 +292,9 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
 		fakeApplicationConfig = &mock.ApplicationConfig{}
 		fakeChannelConfig.ApplicationConfigReturns(fakeApplicationConfig, true)
+		fakeCapabilities = &mock.ApplicationCapabilities{}
+		fakeCapabilities.ChaincodeDefinitionHistoryReturns(true)
+		fakeApplicationConfig.CapabilitiesReturns(fakeCapabilities)
 		fakeOrgConfigs = []*mock.ApplicationOrgConfig{{}, {}}
 		fakeOrgConfigs[0].MSPIDReturns("first-mspid")
 		fakeOrgConfigs[1].MSPIDReturns("second-mspid")
@@ -1461,6 +1465,59 @@ var _ = Describe("ExternalFunctions", func() {
 			}))
 		})
 
+		It("records the new and the replaced definition in the history", func() {
+			_, err := ef.CommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+			Expect(err).NotTo(HaveOccurred())
+
+			definitions, err := ef.QueryChaincodeDefinitionHistory("cc-name", fakePublicState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definitions).To(HaveLen(2))
+			Expect(definitions[0].Sequence).To(Equal(int64(4)))
+			Expect(definitions[1].Sequence).To(Equal(int64(5)))
+			Expect(publicKVS).To(HaveKey("chaincode-history/metadata/cc-name#4"))
+			Expect(publicKVS).To(HaveKey("chaincode-history/metadata/cc-name#5"))
+		})
+
+		Context("when the channel does not retain the chaincode definition history", func() {
+			BeforeEach(func() {
+				fakeCapabilities.ChaincodeDefinitionHistoryReturns(false)
+			})
+
+			It("applies the chaincode definition without recording the history", func() {
+				approvals, err := ef.CommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal(map[string]bool{
+					"org0": true,
+					"org1": false,
+				}))
+				for key := range publicKVS {
+					Expect(key).NotTo(HavePrefix("chaincode-history/"))
+				}
+			})
+		})
+
+		Context("when the replaced definition is already in the history", func() {
+			BeforeEach(func() {
+				resources.Serializer.Serialize("chaincode-history", "cc-name#4", &lifecycle.ChaincodeDefinition{
+					Sequence: 4,
+					EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+						Version: "recorded-version",
+					},
+					ValidationInfo: &lb.ChaincodeValidationInfo{},
+				}, publicKVS)
+			})
+
+			It("does not record it again", func() {
+				_, err := ef.CommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+
+				definitions, err := ef.QueryChaincodeDefinitionHistory("cc-name", fakePublicState)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(definitions).To(HaveLen(2))
+				Expect(definitions[0].EndorsementInfo.Version).To(Equal("recorded-version"))
+			})
+		})
+
 		Context("when IsSerialized fails", func() {
 			BeforeEach(func() {
 				fakeOrgStates[0].GetStateHashReturns(nil, errors.New("bad bad failure"))
@@ -1591,6 +1648,86 @@ var _ = Describe("ExternalFunctions", func() {
 		})
 	})
 
+	Describe("QueryChaincodeDefinitionHistory", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			publicKVS       MapLedgerShim
+		)
+
+		definition := func(sequence int64, version string) *lifecycle.ChaincodeDefinition {
+			return &lifecycle.ChaincodeDefinition{
+				Sequence: sequence,
+				EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+					Version:           version,
+					EndorsementPlugin: "endorsement-plugin",
+				},
+				ValidationInfo: &lb.ChaincodeValidationInfo{
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				},
+				Collections: &pb.CollectionConfigPackage{},
+			}
+		}
+
+		BeforeEach(func() {
+			publicKVS = MapLedgerShim(map[string][]byte{})
+			fakePublicState = &mock.ReadWritableState{}
+			fakePublicState.GetStateStub = publicKVS.GetState
+
+			resources.Serializer.Serialize("namespaces", "cc-name", definition(4, "version-4"), publicKVS)
+			resources.Serializer.Serialize("chaincode-history", "cc-name#2", definition(2, "version-2"), publicKVS)
+			resources.Serializer.Serialize("chaincode-history", "cc-name#3", definition(3, "version-3"), publicKVS)
+			resources.Serializer.Serialize("chaincode-history", "cc-name#4", definition(4, "version-4"), publicKVS)
+		})
+
+		It("returns the recorded definitions in sequence order", func() {
+			definitions, err := ef.QueryChaincodeDefinitionHistory("cc-name", fakePublicState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(definitions).To(HaveLen(3))
+			for i, version := range []string{"version-2", "version-3", "version-4"} {
+				Expect(definitions[i].Sequence).To(Equal(int64(i + 2)))
+				Expect(definitions[i].EndorsementInfo.Version).To(Equal(version))
+			}
+		})
+
+		Context("when the current definition has not been recorded", func() {
+			BeforeEach(func() {
+				resources.Serializer.Serialize("namespaces", "cc-name", definition(5, "version-5"), publicKVS)
+			})
+
+			It("includes the current definition", func() {
+				definitions, err := ef.QueryChaincodeDefinitionHistory("cc-name", fakePublicState)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(definitions).To(HaveLen(4))
+				Expect(definitions[3].Sequence).To(Equal(int64(5)))
+				Expect(definitions[3].EndorsementInfo.Version).To(Equal("version-5"))
+			})
+		})
+
+		Context("when the chaincode is not defined", func() {
+			It("returns an error", func() {
+				_, err := ef.QueryChaincodeDefinitionHistory("other-name", fakePublicState)
+				Expect(err).To(MatchError("namespace other-name is not defined"))
+			})
+		})
+
+		Context("when the history cannot be read", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateStub = func(key string) ([]byte, error) {
+					if key == "chaincode-history/metadata/cc-name#2" {
+						return nil, errors.New("getstate-error")
+					}
+					return publicKVS.GetState(key)
+				}
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := ef.QueryChaincodeDefinitionHistory("cc-name", fakePublicState)
+				Expect(err).To(MatchError("could not fetch history metadata for cc-name#2: could not query metadata for namespace chaincode-history/cc-name#2: getstate-error"))
+			})
+		})
+	})
+
 	Describe("QueryChaincodeDefinition", func() {
 		var (
 			fakePublicState *mock.ReadWritableState
@@ -16,6 +16,16 @@ type ApplicationCapabilities struct {
 	aCLsReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	ChaincodeDefinitionHistoryStub        func() bool
+	chaincodeDefinitionHistoryMutex       sync.RWMutex
+	chaincodeDefinitionHistoryArgsForCall []struct {
+	}
+	chaincodeDefinitionHistoryReturns struct {
+		result1 bool
+	}
+	chaincodeDefinitionHistoryReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	CollectionUpgradeStub        func() bool
 	collectionUpgradeMutex       sync.RWMutex
 	collectionUpgradeArgsForCall []struct {
@@ -76,6 +86,16 @@ type ApplicationCapabilities struct {
 	privateChannelDataReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PurgePvtDataStub        func() bool
+	purgePvtDataMutex       sync.RWMutex
+	purgePvtDataArgsForCall []struct {
+	}
+	purgePvtDataReturns struct {
+		result1 bool
+	}
+	purgePvtDataReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	StorePvtDataOfInvalidTxStub        func() bool
 	storePvtDataOfInvalidTxMutex       sync.RWMutex
 	storePvtDataOfInvalidTxArgsForCall []struct {
@@ -192,6 +212,58 @@ func (fake *ApplicationCapabilities) ACLsReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *ApplicationCapabilities) ChaincodeDefinitionHistory() bool {
+	fake.chaincodeDefinitionHistoryMutex.Lock()
+	ret, specificReturn := fake.chaincodeDefinitionHistoryReturnsOnCall[len(fake.chaincodeDefinitionHistoryArgsForCall)]
+	fake.chaincodeDefinitionHistoryArgsForCall = append(fake.chaincodeDefinitionHistoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ChaincodeDefinitionHistory", []interface{}{})
+	fake.chaincodeDefinitionHistoryMutex.Unlock()
+	if fake.ChaincodeDefinitionHistoryStub != nil {
+		return fake.ChaincodeDefinitionHistoryStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.chaincodeDefinitionHistoryReturns
+	return fakeReturns.result1
+}
+
+func (fake *ApplicationCapabilities) ChaincodeDefinitionHistoryCallCount() int {
+	fake.chaincodeDefinitionHistoryMutex.RLock()
+	defer fake.chaincodeDefinitionHistoryMutex.RUnlock()
+	return len(fake.chaincodeDefinitionHistoryArgsForCall)
+}
+
+func (fake *ApplicationCapabilities) ChaincodeDefinitionHistoryCalls(stub func() bool) {
+	fake.chaincodeDefinitionHistoryMutex.Lock()
+	defer fake.chaincodeDefinitionHistoryMutex.Unlock()
+	fake.ChaincodeDefinitionHistoryStub = stub
+}
+
+func (fake *ApplicationCapabilities) ChaincodeDefinitionHistoryReturns(result1 bool) {
+	fake.chaincodeDefinitionHistoryMutex.Lock()
+	defer fake.chaincodeDefinitionHistoryMutex.Unlock()
+	fake.ChaincodeDefinitionHistoryStub = nil
+	fake.chaincodeDefinitionHistoryReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ApplicationCapabilities) ChaincodeDefinitionHistoryReturnsOnCall(i int, result1 bool) {
+	fake.chaincodeDefinitionHistoryMutex.Lock()
+	defer fake.chaincodeDefinitionHistoryMutex.Unlock()
+	fake.ChaincodeDefinitionHistoryStub = nil
+	if fake.chaincodeDefinitionHistoryReturnsOnCall == nil {
+		fake.chaincodeDefinitionHistoryReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.chaincodeDefinitionHistoryReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ApplicationCapabilities) CollectionUpgrade() bool {
 	fake.collectionUpgradeMutex.Lock()
 	ret, specificReturn := fake.collectionUpgradeReturnsOnCall[len(fake.collectionUpgradeArgsForCall)]
@@ -504,6 +576,58 @@ func (fake *ApplicationCapabilities) PrivateChannelDataReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *ApplicationCapabilities) PurgePvtData() bool {
+	fake.purgePvtDataMutex.Lock()
+	ret, specificReturn := fake.purgePvtDataReturnsOnCall[len(fake.purgePvtDataArgsForCall)]
+	fake.purgePvtDataArgsForCall = append(fake.purgePvtDataArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PurgePvtData", []interface{}{})
+	fake.purgePvtDataMutex.Unlock()
+	if fake.PurgePvtDataStub != nil {
+		return fake.PurgePvtDataStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgePvtDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataCallCount() int {
+	fake.purgePvtDataMutex.RLock()
+	defer fake.purgePvtDataMutex.RUnlock()
+	return len(fake.purgePvtDataArgsForCall)
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataCalls(stub func() bool) {
+	fake.purgePvtDataMutex.Lock()
+	defer fake.purgePvtDataMutex.Unlock()
+	fake.PurgePvtDataStub = stub
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataReturns(result1 bool) {
+	fake.purgePvtDataMutex.Lock()
+	defer fake.purgePvtDataMutex.Unlock()
+	fake.PurgePvtDataStub = nil
+	fake.purgePvtDataReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataReturnsOnCall(i int, result1 bool) {
+	fake.purgePvtDataMutex.Lock()
+	defer fake.purgePvtDataMutex.Unlock()
+	fake.PurgePvtDataStub = nil
+	if fake.purgePvtDataReturnsOnCall == nil {
+		fake.purgePvtDataReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.purgePvtDataReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	fake.storePvtDataOfInvalidTxMutex.Lock()
 	ret, specificReturn := fake.storePvtDataOfInvalidTxReturnsOnCall[len(fake.storePvtDataOfInvalidTxArgsForCall)]
@@ -821,6 +945,8 @@ func (fake *ApplicationCapabilities) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.aCLsMutex.RLock()
 	defer fake.aCLsMutex.RUnlock()
+	fake.chaincodeDefinitionHistoryMutex.RLock()
+	defer fake.chaincodeDefinitionHistoryMutex.RUnlock()
 	fake.collectionUpgradeMutex.RLock()
 	defer fake.collectionUpgradeMutex.RUnlock()
 	fake.forbidDuplicateTXIdInBlockMutex.RLock()
@@ -833,6 +959,8 @@ func (fake *ApplicationCapabilities) Invocations() map[string][][]interface{} {
 	defer fake.metadataLifecycleMutex.RUnlock()
 	fake.privateChannelDataMutex.RLock()
 	defer fake.privateChannelDataMutex.RUnlock()
+	fake.purgePvtDataMutex.RLock()
+	defer fake.purgePvtDataMutex.RUnlock()
 	fake.storePvtDataOfInvalidTxMutex.RLock()
 	defer fake.storePvtDataOfInvalidTxMutex.RUnlock()
 	fake.supportedMutex.RLock()
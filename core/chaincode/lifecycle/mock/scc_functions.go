@@ -116,6 +116,20 @@ type SCCFunctions struct {
 		result1 *lifecycle.ChaincodeDefinition
 		result2 error
 	}
+	QueryChaincodeDefinitionHistoryStub        func(string, lifecycle.ReadableState) ([]*lifecycle.ChaincodeDefinition, error)
+	queryChaincodeDefinitionHistoryMutex       sync.RWMutex
+	queryChaincodeDefinitionHistoryArgsForCall []struct {
+		arg1 string
+		arg2 lifecycle.ReadableState
+	}
+	queryChaincodeDefinitionHistoryReturns struct {
+		result1 []*lifecycle.ChaincodeDefinition
+		result2 error
+	}
+	queryChaincodeDefinitionHistoryReturnsOnCall map[int]struct {
+		result1 []*lifecycle.ChaincodeDefinition
+		result2 error
+	}
 	QueryInstalledChaincodeStub        func(string) (*chaincode.InstalledChaincode, error)
 	queryInstalledChaincodeMutex       sync.RWMutex
 	queryInstalledChaincodeArgsForCall []struct {
@@ -642,6 +656,70 @@ func (fake *SCCFunctions) QueryChaincodeDefinitionReturnsOnCall(i int, result1 *
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistory(arg1 string, arg2 lifecycle.ReadableState) ([]*lifecycle.ChaincodeDefinition, error) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	ret, specificReturn := fake.queryChaincodeDefinitionHistoryReturnsOnCall[len(fake.queryChaincodeDefinitionHistoryArgsForCall)]
+	fake.queryChaincodeDefinitionHistoryArgsForCall = append(fake.queryChaincodeDefinitionHistoryArgsForCall, struct {
+		arg1 string
+		arg2 lifecycle.ReadableState
+	}{arg1, arg2})
+	fake.recordInvocation("QueryChaincodeDefinitionHistory", []interface{}{arg1, arg2})
+	fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	if fake.QueryChaincodeDefinitionHistoryStub != nil {
+		return fake.QueryChaincodeDefinitionHistoryStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryChaincodeDefinitionHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryCallCount() int {
+	fake.queryChaincodeDefinitionHistoryMutex.RLock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.RUnlock()
+	return len(fake.queryChaincodeDefinitionHistoryArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryCalls(stub func(string, lifecycle.ReadableState) ([]*lifecycle.ChaincodeDefinition, error)) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	fake.QueryChaincodeDefinitionHistoryStub = stub
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryArgsForCall(i int) (string, lifecycle.ReadableState) {
+	fake.queryChaincodeDefinitionHistoryMutex.RLock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.RUnlock()
+	argsForCall := fake.queryChaincodeDefinitionHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryReturns(result1 []*lifecycle.ChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	fake.QueryChaincodeDefinitionHistoryStub = nil
+	fake.queryChaincodeDefinitionHistoryReturns = struct {
+		result1 []*lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryReturnsOnCall(i int, result1 []*lifecycle.ChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	fake.QueryChaincodeDefinitionHistoryStub = nil
+	if fake.queryChaincodeDefinitionHistoryReturnsOnCall == nil {
+		fake.queryChaincodeDefinitionHistoryReturnsOnCall = make(map[int]struct {
+			result1 []*lifecycle.ChaincodeDefinition
+			result2 error
+		})
+	}
+	fake.queryChaincodeDefinitionHistoryReturnsOnCall[i] = struct {
+		result1 []*lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) QueryInstalledChaincode(arg1 string) (*chaincode.InstalledChaincode, error) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	ret, specificReturn := fake.queryInstalledChaincodeReturnsOnCall[len(fake.queryInstalledChaincodeArgsForCall)]
@@ -907,6 +985,8 @@ func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	defer fake.queryApprovedChaincodeDefinitionMutex.RUnlock()
 	fake.queryChaincodeDefinitionMutex.RLock()
 	defer fake.queryChaincodeDefinitionMutex.RUnlock()
+	fake.queryChaincodeDefinitionHistoryMutex.RLock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.RUnlock()
 	fake.queryInstalledChaincodeMutex.RLock()
 	defer fake.queryInstalledChaincodeMutex.RUnlock()
 	fake.queryInstalledChaincodesMutex.RLock()
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/pkg/lifecycle/history"
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
 )
@@ -71,6 +72,10 @@ const (
 	// QueryChaincodeDefinitionsFuncName is the chaincode function name used to
 	// query the committed chaincode definitions in a channel.
 	QueryChaincodeDefinitionsFuncName = "QueryChaincodeDefinitions"
+
+	// QueryChaincodeDefinitionHistoryFuncName is the chaincode function name
+	// used to query the committed definitions of a chaincode across sequences.
+	QueryChaincodeDefinitionHistoryFuncName = "QueryChaincodeDefinitionHistory"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -109,6 +114,10 @@ type SCCFunctions interface {
 	// state.
 	QueryChaincodeDefinition(name string, publicState ReadableState) (*ChaincodeDefinition, error)
 
+	// QueryChaincodeDefinitionHistory returns the chaincode definitions
+	// committed for a chaincode in ascending sequence order.
+	QueryChaincodeDefinitionHistory(name string, publicState ReadableState) ([]*ChaincodeDefinition, error)
+
 	// QueryOrgApprovals returns a map containing the orgs whose orgStates were
 	// supplied and whether or not they have approved a chaincode definition with
 	// the specified parameters.
@@ -635,6 +644,44 @@ func (i *Invocation) QueryChaincodeDefinitions(input *lb.QueryChaincodeDefinitio
 	}, nil
 }
 
+// QueryChaincodeDefinitionHistory is a SCC function that may be dispatched
+// to which routes to the underlying lifecycle implementation.
+func (i *Invocation) QueryChaincodeDefinitionHistory(input *history.QueryChaincodeDefinitionHistoryArgs) (proto.Message, error) {
+	logger.Debugf("received invocation of QueryChaincodeDefinitionHistory on channel '%s' for chaincode '%s'",
+		i.Stub.GetChannelID(),
+		input.Name,
+	)
+
+	if i.ApplicationConfig == nil {
+		return nil, errors.Errorf("no application config for channel '%s'", i.Stub.GetChannelID())
+	}
+	if !i.ApplicationConfig.Capabilities().ChaincodeDefinitionHistory() {
+		return nil, errors.Errorf("cannot query chaincode definition history for channel '%s' as it does not have the required capabilities enabled", i.Stub.GetChannelID())
+	}
+
+	definitions, err := i.SCC.Functions.QueryChaincodeDefinitionHistory(input.Name, i.Stub)
+	if err != nil {
+		return nil, err
+	}
+
+	chaincodeDefinitions := make([]*history.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		chaincodeDefinitions = append(chaincodeDefinitions, &history.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+			Sequence:            definition.Sequence,
+			Version:             definition.EndorsementInfo.Version,
+			EndorsementPlugin:   definition.EndorsementInfo.EndorsementPlugin,
+			ValidationPlugin:    definition.ValidationInfo.ValidationPlugin,
+			ValidationParameter: definition.ValidationInfo.ValidationParameter,
+			InitRequired:        definition.EndorsementInfo.InitRequired,
+			Collections:         definition.Collections,
+		})
+	}
+
+	return &history.QueryChaincodeDefinitionHistoryResult{
+		ChaincodeDefinitions: chaincodeDefinitions,
+	}, nil
+}
+
 var (
 	// NOTE the chaincode name/version regular expressions should stay in sync
 	// with those defined in core/scc/lscc/lscc.go until LSCC has been removed.
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/pkg/lifecycle/history"
 	"github.com/pkg/errors"
 
 	. "github.com/onsi/ginkgo"
@@ -1648,6 +1649,116 @@ var _ = Describe("SCC", func() {
 				})
 			})
 		})
+
+		Describe("QueryChaincodeDefinitionHistory", func() {
+			var (
+				arg          *history.QueryChaincodeDefinitionHistoryArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &history.QueryChaincodeDefinitionHistoryArgs{
+					Name: "cc-name",
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryChaincodeDefinitionHistory"), marshaledArg})
+				definition := func(sequence int64, version string) *lifecycle.ChaincodeDefinition {
+					return &lifecycle.ChaincodeDefinition{
+						Sequence: sequence,
+						EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+							Version:           version,
+							EndorsementPlugin: "endorsement-plugin",
+							InitRequired:      true,
+						},
+						ValidationInfo: &lb.ChaincodeValidationInfo{
+							ValidationPlugin:    "validation-plugin",
+							ValidationParameter: []byte("validation-parameter"),
+						},
+						Collections: &pb.CollectionConfigPackage{},
+					}
+				}
+				fakeSCCFuncs.QueryChaincodeDefinitionHistoryReturns([]*lifecycle.ChaincodeDefinition{
+					definition(1, "version-1"),
+					definition(2, "version-2"),
+				}, nil)
+				fakeCapabilities.ChaincodeDefinitionHistoryReturns(true)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &history.QueryChaincodeDefinitionHistoryResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(payload, &history.QueryChaincodeDefinitionHistoryResult{
+					ChaincodeDefinitions: []*history.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+						{
+							Sequence:            1,
+							Version:             "version-1",
+							EndorsementPlugin:   "endorsement-plugin",
+							ValidationPlugin:    "validation-plugin",
+							ValidationParameter: []byte("validation-parameter"),
+							InitRequired:        true,
+							Collections:         &pb.CollectionConfigPackage{},
+						},
+						{
+							Sequence:            2,
+							Version:             "version-2",
+							EndorsementPlugin:   "endorsement-plugin",
+							ValidationPlugin:    "validation-plugin",
+							ValidationParameter: []byte("validation-parameter"),
+							InitRequired:        true,
+							Collections:         &pb.CollectionConfigPackage{},
+						},
+					},
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.QueryChaincodeDefinitionHistoryCallCount()).To(Equal(1))
+				name, _ := fakeSCCFuncs.QueryChaincodeDefinitionHistoryArgsForCall(0)
+				Expect(name).To(Equal("cc-name"))
+			})
+
+			Context("when the chaincode is not defined", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionHistoryReturns(nil, lifecycle.ErrNamespaceNotDefined{Namespace: "cc-name"})
+				})
+
+				It("returns a not found error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(404)))
+					Expect(res.Message).To(Equal("namespace cc-name is not defined"))
+				})
+			})
+
+			Context("when the channel does not retain the chaincode definition history", func() {
+				BeforeEach(func() {
+					fakeCapabilities.ChaincodeDefinitionHistoryReturns(false)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'QueryChaincodeDefinitionHistory': cannot query chaincode definition history for channel 'test-channel' as it does not have the required capabilities enabled"))
+					Expect(fakeSCCFuncs.QueryChaincodeDefinitionHistoryCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionHistoryReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'QueryChaincodeDefinitionHistory': underlying-error"))
+				})
+			})
+		})
 	})
 })
 
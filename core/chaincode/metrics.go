@@ -33,6 +33,20 @@ var (
 		LabelNames:   []string{"chaincode"},
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
+	runtimeRestarts = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "runtime_restarts",
+		Help:         "The number of automatic restarts of chaincode runtimes that exited.",
+		LabelNames:   []string{"chaincode", "success"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{success}",
+	}
+	runtimeUnavailable = metrics.GaugeOpts{
+		Namespace:    "chaincode",
+		Name:         "runtime_unavailable",
+		Help:         "Whether a chaincode runtime launched by the peer exited without being stopped by the peer and has not been launched again.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
 
 	shimRequestsReceived = metrics.CounterOpts{
 		Namespace:    "chaincode",
@@ -62,6 +76,13 @@ var (
 		LabelNames:   []string{"chaincode"},
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
+	keepaliveTimeouts = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "keepalive_timeouts",
+		Help:         "The number of chaincode streams terminated because the chaincode was unresponsive.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
 	chaincodeInvokeDuration = metrics.HistogramOpts{
 		Namespace:    "chaincode",
 		Name:         "chaincode_invoke_duration",
@@ -162,6 +183,7 @@ type HandlerMetrics struct {
 	ShimRequestsCompleted metrics.Counter
 	ShimRequestDuration   metrics.Histogram
 	ExecuteTimeouts       metrics.Counter
+	KeepaliveTimeouts     metrics.Counter
 	// Luat add metrics
 	ChaincodeInvokeDuration  metrics.Histogram
 	ChaincodeCheckInvocation metrics.Histogram
@@ -188,6 +210,7 @@ func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 		ShimRequestsCompleted: p.NewCounter(shimRequestsCompleted),
 		ShimRequestDuration:   p.NewHistogram(shimRequestDuration),
 		ExecuteTimeouts:       p.NewCounter(executeTimeouts),
+		KeepaliveTimeouts:     p.NewCounter(keepaliveTimeouts),
 
 		// Luat add metrics
 		ChaincodeInvokeDuration:  p.NewHistogram(chaincodeInvokeDuration),
@@ -210,15 +233,19 @@ func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 }
 
 type LaunchMetrics struct {
-	LaunchDuration metrics.Histogram
-	LaunchFailures metrics.Counter
-	LaunchTimeouts metrics.Counter
+	LaunchDuration     metrics.Histogram
+	LaunchFailures     metrics.Counter
+	LaunchTimeouts     metrics.Counter
+	RuntimeRestarts    metrics.Counter
+	RuntimeUnavailable metrics.Gauge
 }
 
 func NewLaunchMetrics(p metrics.Provider) *LaunchMetrics {
 	return &LaunchMetrics{
-		LaunchDuration: p.NewHistogram(launchDuration),
-		LaunchFailures: p.NewCounter(launchFailures),
-		LaunchTimeouts: p.NewCounter(launchTimeouts),
+		LaunchDuration:     p.NewHistogram(launchDuration),
+		LaunchFailures:     p.NewCounter(launchFailures),
+		LaunchTimeouts:     p.NewCounter(launchTimeouts),
+		RuntimeRestarts:    p.NewCounter(runtimeRestarts),
+		RuntimeUnavailable: p.NewGauge(runtimeUnavailable),
 	}
 }
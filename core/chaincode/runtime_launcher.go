@@ -7,7 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"context"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
@@ -37,6 +41,47 @@ type RuntimeLauncher struct {
 	CACert            []byte
 	CertGenerator     CertGenerator
 	ConnectionHandler ConnectionHandler
+	// Restart controls the automatic restart of peer launched runtimes. When
+	// it is nil, a runtime that exits is only launched again on demand.
+	Restart *RestartPolicy
+
+	mutex    sync.Mutex
+	runtimes map[string]*runtimeStatus
+}
+
+// RestartPolicy controls the automatic restart of chaincode runtimes launched
+// by the peer that exit without being stopped by the peer.
+type RestartPolicy struct {
+	// InitialBackoff is the delay before the first restart attempt. The delay
+	// doubles with every consecutive attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between restart attempts. A runtime that
+	// runs for longer than MaxBackoff before exiting resets the attempt count.
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of consecutive restart attempts after which
+	// the runtime is left stopped. Zero means there is no limit.
+	MaxAttempts int
+}
+
+func (p *RestartPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// runtimeStatus tracks the restarts of a runtime launched by the peer.
+type runtimeStatus struct {
+	stopped     bool
+	pending     bool
+	restarting  bool
+	unavailable bool
+	attempts    int
+	err         error
 }
 
 // CertGenerator generates client certificates for chaincode.
@@ -81,7 +126,9 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 			// go through the build process to obtain connecion information
 			ccservinfo, err := r.Runtime.Build(ccid)
 			if err != nil {
-				startFailCh <- errors.WithMessage(err, "error building chaincode")
+				err = errors.WithMessage(err, "error building chaincode")
+				startFailCh <- err
+				r.restartFailed(ccid, streamHandler, err)
 				return
 			}
 
@@ -108,14 +155,19 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 				return
 			}
 			if err = r.Runtime.Start(ccid, ccinfo); err != nil {
-				startFailCh <- errors.WithMessage(err, "error starting container")
+				err = errors.WithMessage(err, "error starting container")
+				startFailCh <- err
+				r.restartFailed(ccid, streamHandler, err)
 				return
 			}
+			startedTime := time.Now()
 			exitCode, err := r.Runtime.Wait(ccid)
+			exitErr := errors.Errorf("container exited with %d", exitCode)
 			if err != nil {
-				launchState.Notify(errors.Wrap(err, "failed to wait on container exit"))
+				exitErr = errors.Wrap(err, "failed to wait on container exit")
 			}
-			launchState.Notify(errors.Errorf("container exited with %d", exitCode))
+			launchState.Notify(exitErr)
+			r.exited(ccid, streamHandler, time.Since(startedTime), exitErr)
 		}()
 	}
 
@@ -138,6 +190,9 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 		chaincodeLogger.Debugf("stopping due to error while launching: %+v", err)
 		defer r.Registry.Deregister(ccid)
 	}
+	if err == nil {
+		r.launched(ccid)
+	}
 
 	r.Metrics.LaunchDuration.With(
 		"chaincode", ccid,
@@ -149,6 +204,10 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 }
 
 func (r *RuntimeLauncher) Stop(ccid string) error {
+	r.mutex.Lock()
+	r.status(ccid).stopped = true
+	r.mutex.Unlock()
+
 	err := r.Runtime.Stop(ccid)
 	if err != nil {
 		return errors.WithMessagef(err, "failed to stop chaincode %s", ccid)
@@ -156,3 +215,135 @@ func (r *RuntimeLauncher) Stop(ccid string) error {
 
 	return nil
 }
+
+// HealthCheck reports the runtimes launched by the peer that exited without
+// being stopped by the peer and have not been launched again. It implements
+// the healthz.HealthChecker interface.
+func (r *RuntimeLauncher) HealthCheck(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var unavailable []string
+	for ccid, status := range r.runtimes {
+		if status.unavailable {
+			unavailable = append(unavailable, ccid+": "+status.err.Error())
+		}
+	}
+	if len(unavailable) == 0 {
+		return nil
+	}
+
+	sort.Strings(unavailable)
+	return errors.Errorf("chaincode runtimes unavailable: %s", strings.Join(unavailable, "; "))
+}
+
+// status returns the status of the runtime. The caller must hold the mutex.
+func (r *RuntimeLauncher) status(ccid string) *runtimeStatus {
+	if r.runtimes == nil {
+		r.runtimes = map[string]*runtimeStatus{}
+	}
+	status, ok := r.runtimes[ccid]
+	if !ok {
+		status = &runtimeStatus{}
+		r.runtimes[ccid] = status
+	}
+	return status
+}
+
+func (r *RuntimeLauncher) launched(ccid string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	status, ok := r.runtimes[ccid]
+	if !ok {
+		status = r.status(ccid)
+	}
+	status.stopped = false
+	status.restarting = false
+	status.err = nil
+	if !ok || status.unavailable {
+		status.unavailable = false
+		r.Metrics.RuntimeUnavailable.With("chaincode", ccid).Set(0)
+	}
+}
+
+// exited records that a runtime exited and, when it was not stopped by the
+// peer, schedules its restart according to the restart policy.
+func (r *RuntimeLauncher) exited(ccid string, streamHandler extcc.StreamHandler, uptime time.Duration, exitErr error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	status := r.status(ccid)
+	if status.stopped {
+		delete(r.runtimes, ccid)
+		r.Metrics.RuntimeUnavailable.With("chaincode", ccid).Set(0)
+		return
+	}
+	if status.pending {
+		return
+	}
+
+	status.err = exitErr
+	if !status.unavailable {
+		status.unavailable = true
+		r.Metrics.RuntimeUnavailable.With("chaincode", ccid).Set(1)
+	}
+	if r.Restart == nil {
+		chaincodeLogger.Warningf("chaincode %s exited: %s", ccid, exitErr)
+		return
+	}
+
+	if uptime > r.Restart.MaxBackoff {
+		status.attempts = 0
+	}
+	if r.Restart.MaxAttempts > 0 && status.attempts >= r.Restart.MaxAttempts {
+		status.restarting = false
+		chaincodeLogger.Errorf("chaincode %s exited: %s; giving up after %d restart attempts", ccid, exitErr, status.attempts)
+		return
+	}
+
+	status.attempts++
+	status.pending = true
+	status.restarting = true
+	backoff := r.Restart.backoff(status.attempts)
+	chaincodeLogger.Warningf("chaincode %s exited: %s; restarting in %s (attempt %d)", ccid, exitErr, backoff, status.attempts)
+	time.AfterFunc(backoff, func() { r.restart(ccid, streamHandler) })
+}
+
+// restartFailed schedules another restart attempt when a runtime that is
+// being restarted could not be built or started.
+func (r *RuntimeLauncher) restartFailed(ccid string, streamHandler extcc.StreamHandler, err error) {
+	r.mutex.Lock()
+	status, ok := r.runtimes[ccid]
+	restarting := ok && status.restarting
+	r.mutex.Unlock()
+
+	if restarting {
+		r.exited(ccid, streamHandler, 0, err)
+	}
+}
+
+func (r *RuntimeLauncher) restart(ccid string, streamHandler extcc.StreamHandler) {
+	r.mutex.Lock()
+	status, ok := r.runtimes[ccid]
+	if ok {
+		status.pending = false
+	}
+	stopped := !ok || status.stopped
+	r.mutex.Unlock()
+	if stopped {
+		return
+	}
+
+	// Launch returns without starting the runtime again if it was launched
+	// on demand in the meantime.
+
+	err := r.Launch(ccid, streamHandler)
+	r.Metrics.RuntimeRestarts.With(
+		"chaincode", ccid,
+		"success", strconv.FormatBool(err == nil),
+	).Add(1)
+	if err != nil {
+		chaincodeLogger.Warningf("failed to restart chaincode %s: %s", ccid, err)
+	}
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"context"
 	"time"
 
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
@@ -30,6 +31,8 @@ var _ = Describe("RuntimeLauncher", func() {
 		fakeLaunchDuration *metricsfakes.Histogram
 		fakeLaunchFailures *metricsfakes.Counter
 		fakeLaunchTimeouts *metricsfakes.Counter
+		fakeRestarts       *metricsfakes.Counter
+		fakeUnavailable    *metricsfakes.Gauge
 		fakeCertGenerator  *mock.CertGenerator
 		exitedCh           chan int
 		extCCConnExited    chan struct{}
@@ -72,11 +75,17 @@ var _ = Describe("RuntimeLauncher", func() {
 		fakeLaunchFailures.WithReturns(fakeLaunchFailures)
 		fakeLaunchTimeouts = &metricsfakes.Counter{}
 		fakeLaunchTimeouts.WithReturns(fakeLaunchTimeouts)
+		fakeRestarts = &metricsfakes.Counter{}
+		fakeRestarts.WithReturns(fakeRestarts)
+		fakeUnavailable = &metricsfakes.Gauge{}
+		fakeUnavailable.WithReturns(fakeUnavailable)
 
 		launchMetrics := &chaincode.LaunchMetrics{
-			LaunchDuration: fakeLaunchDuration,
-			LaunchFailures: fakeLaunchFailures,
-			LaunchTimeouts: fakeLaunchTimeouts,
+			LaunchDuration:     fakeLaunchDuration,
+			LaunchFailures:     fakeLaunchFailures,
+			LaunchTimeouts:     fakeLaunchTimeouts,
+			RuntimeRestarts:    fakeRestarts,
+			RuntimeUnavailable: fakeUnavailable,
 		}
 		fakeCertGenerator = &mock.CertGenerator{}
 		fakeCertGenerator.GenerateReturns(&accesscontrol.CertAndPrivKeyPair{Cert: []byte("cert"), Key: []byte("key")}, nil)
@@ -91,6 +100,14 @@ var _ = Describe("RuntimeLauncher", func() {
 		}
 	})
 
+	unavailable := func() float64 {
+		n := fakeUnavailable.SetCallCount()
+		if n == 0 {
+			return -1
+		}
+		return fakeUnavailable.SetArgsForCall(n - 1)
+	}
+
 	AfterEach(func() {
 		close(exitedCh)
 		close(extCCConnExited)
@@ -456,6 +473,130 @@ var _ = Describe("RuntimeLauncher", func() {
 		})
 	})
 
+	Context("when a restart policy is configured", func() {
+		BeforeEach(func() {
+			runtimeLauncher.Restart = &chaincode.RestartPolicy{
+				InitialBackoff: 10 * time.Millisecond,
+				MaxBackoff:     time.Second,
+				MaxAttempts:    2,
+			}
+		})
+
+		AfterEach(func() {
+			// prevent restarts from outliving the test
+			runtimeLauncher.Stop("chaincode-name:chaincode-version")
+		})
+
+		It("restarts the runtime when it exits", func() {
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRuntime.StartCallCount()).To(Equal(1))
+
+			exitedCh <- 2
+			Eventually(fakeRuntime.StartCallCount).Should(Equal(2))
+			Eventually(fakeRestarts.AddCallCount).Should(Equal(1))
+			Expect(fakeRestarts.WithArgsForCall(0)).To(Equal([]string{
+				"chaincode", "chaincode-name:chaincode-version",
+				"success", "true",
+			}))
+			Eventually(unavailable).Should(Equal(0.0))
+			Expect(fakeUnavailable.SetCallCount()).To(Equal(3))
+		})
+
+		It("reports the runtime as unavailable until it is restarted", func() {
+			runtimeLauncher.Restart.InitialBackoff = time.Minute
+
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unavailable()).To(Equal(0.0))
+			Expect(fakeUnavailable.WithArgsForCall(0)).To(Equal([]string{"chaincode", "chaincode-name:chaincode-version"}))
+
+			Expect(runtimeLauncher.HealthCheck(context.Background())).To(Succeed())
+
+			exitedCh <- 2
+			Eventually(unavailable).Should(Equal(1.0))
+			Expect(fakeRuntime.StartCallCount()).To(Equal(1))
+			Expect(runtimeLauncher.HealthCheck(context.Background())).To(MatchError("chaincode runtimes unavailable: chaincode-name:chaincode-version: container exited with 2"))
+		})
+
+		It("does not restart a runtime that was stopped", func() {
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = runtimeLauncher.Stop("chaincode-name:chaincode-version")
+			Expect(err).NotTo(HaveOccurred())
+
+			exitedCh <- 0
+			Consistently(fakeRuntime.StartCallCount).Should(Equal(1))
+			Expect(unavailable()).To(Equal(0.0))
+			Expect(runtimeLauncher.HealthCheck(context.Background())).To(Succeed())
+		})
+
+		Context("when the runtime keeps exiting", func() {
+			BeforeEach(func() {
+				fakeRuntime.WaitStub = nil
+				fakeRuntime.WaitReturns(3, nil)
+			})
+
+			It("gives up after the maximum number of attempts", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(fakeRuntime.StartCallCount).Should(Equal(3))
+				Consistently(fakeRuntime.StartCallCount).Should(Equal(3))
+				Expect(fakeRestarts.AddCallCount()).To(Equal(2))
+				Expect(unavailable()).To(Equal(1.0))
+				Expect(runtimeLauncher.HealthCheck(context.Background())).To(MatchError("chaincode runtimes unavailable: chaincode-name:chaincode-version: container exited with 3"))
+			})
+		})
+
+		Context("when restarting the runtime fails", func() {
+			BeforeEach(func() {
+				fakeRegistry.LaunchingStub = func(string) (*chaincode.LaunchState, bool) {
+					if fakeRegistry.LaunchingCallCount() == 1 {
+						return launchState, false
+					}
+					return chaincode.NewLaunchState(), false
+				}
+			})
+
+			It("retries the restart", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeRuntime.StartStub = nil
+				fakeRuntime.StartReturns(errors.New("mango"))
+				exitedCh <- 2
+
+				Eventually(fakeRuntime.StartCallCount).Should(Equal(3))
+				Eventually(fakeRestarts.AddCallCount).Should(Equal(2))
+				Expect(fakeRestarts.WithArgsForCall(1)).To(Equal([]string{
+					"chaincode", "chaincode-name:chaincode-version",
+					"success", "false",
+				}))
+				Expect(unavailable()).To(Equal(1.0))
+			})
+		})
+	})
+
+	Context("when no restart policy is configured", func() {
+		It("reports the runtime as unavailable until it is launched again", func() {
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unavailable()).To(Equal(0.0))
+
+			exitedCh <- 2
+			Eventually(unavailable).Should(Equal(1.0))
+			Consistently(fakeRuntime.StartCallCount).Should(Equal(1))
+			Expect(runtimeLauncher.HealthCheck(context.Background())).To(MatchError("chaincode runtimes unavailable: chaincode-name:chaincode-version: container exited with 2"))
+
+			err = runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unavailable()).To(Equal(0.0))
+			Expect(runtimeLauncher.HealthCheck(context.Background())).To(Succeed())
+		})
+	})
+
 	It("stops the runtime for the chaincode", func() {
 		err := runtimeLauncher.Stop("chaincode-name:chaincode-version")
 		Expect(err).NotTo(HaveOccurred())
@@ -23,6 +23,20 @@ func (_m *ApplicationCapabilities) ACLs() bool {
 	return r0
 }
 
+// ChaincodeDefinitionHistory provides a mock function with given fields:
+func (_m *ApplicationCapabilities) ChaincodeDefinitionHistory() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // CollectionUpgrade provides a mock function with given fields:
 func (_m *ApplicationCapabilities) CollectionUpgrade() bool {
 	ret := _m.Called()
@@ -107,6 +121,20 @@ func (_m *ApplicationCapabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// PurgePvtData provides a mock function with given fields:
+func (_m *ApplicationCapabilities) PurgePvtData() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // StorePvtDataOfInvalidTx provides a mock function with given fields:
 func (_m *ApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	ret := _m.Called()
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/common/validation"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protoutil"
@@ -342,6 +343,16 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 				return
 			}
 
+			// Purges of private data are only allowed once the channel capability is enabled
+			if containsPurges(d) && !v.ChannelResources.Capabilities().PurgePvtData() {
+				logger.Errorf("Transaction %s purges private data but the PurgePvtData capability is not enabled", txID)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_ILLEGAL_WRITESET,
+				}
+				return
+			}
+
 			// Validate tx with vscc and policy
 			logger.Debug("Validating transaction vscc tx validate")
 			cde, err := v.Vscc.VSCCValidateTx(tIdx, payload, d, block)
@@ -600,6 +611,20 @@ func (v *TxValidator) getUpgradeTxInstance(channelID string, cdsBytes []byte) (*
 	}, nil
 }
 
+// containsPurges returns true if the endorser transaction envelope contains a purge of private data.
+// Malformed transactions are reported as not containing purges and are left to VSCC validation.
+func containsPurges(envBytes []byte) bool {
+	respPayload, err := protoutil.GetActionFromEnvelope(envBytes)
+	if err != nil {
+		return false
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return false
+	}
+	return txRWSet.ContainsPurges()
+}
+
 type dynamicDeserializer struct {
 	cr ChannelResources
 }
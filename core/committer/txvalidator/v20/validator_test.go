@@ -57,6 +57,7 @@ func v20Capabilities() *tmocks.ApplicationCapabilities {
 	ac.On("V2_0Validation").Return(true)
 	ac.On("PrivateChannelData").Return(true)
 	ac.On("KeyLevelEndorsement").Return(true)
+	ac.On("PurgePvtData").Return(false)
 	return ac
 }
 
@@ -655,6 +656,26 @@ func testCCEventGoodPath(t *testing.T, v txvalidator.Validator, ccID string) {
 	assertValid(b, t)
 }
 
+func TestInvokeNOKPurgeWithoutCapability(t *testing.T) {
+	ccID := "mycc"
+
+	v, _, _, _ := setupValidator()
+
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ccID, "mycollection", "somekey")
+	rwset, err := rwsetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	rwsetBytes, err := rwset.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	tx := getEnv(ccID, nil, rwsetBytes, t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err = v.Validate(b)
+	require.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
 func TestInvokeOKPvtDataOnly(t *testing.T) {
 	ccID := "mycc"
 
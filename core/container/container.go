@@ -34,6 +34,13 @@ type ExternalBuilder interface {
 	Build(ccid string, metadata []byte, codePackageStream io.Reader) (Instance, error)
 }
 
+//go:generate counterfeiter -o mock/inproc_builder.go --fake-name InProcBuilder . InProcBuilder
+
+// InProcBuilder is what is exposed by the inproccontroller
+type InProcBuilder interface {
+	Build(ccid string) (Instance, error)
+}
+
 //go:generate counterfeiter -o mock/instance.go --fake-name Instance . Instance
 
 // Instance represents a built chaincode instance, because of the docker legacy, calling this a
@@ -72,6 +79,7 @@ type PackageProvider interface {
 }
 
 type Router struct {
+	InProcBuilder   InProcBuilder
 	ExternalBuilder ExternalBuilder
 	DockerBuilder   DockerBuilder
 	containers      map[string]Instance
@@ -97,7 +105,17 @@ func (r *Router) getInstance(ccid string) Instance {
 func (r *Router) Build(ccid string) error {
 	var instance Instance
 
-	if r.ExternalBuilder != nil {
+	if r.InProcBuilder != nil {
+		// in-process chaincodes are registered by package ID and do not
+		// require the chaincode package to be retrieved or built
+		var err error
+		instance, err = r.InProcBuilder.Build(ccid)
+		if err != nil {
+			return errors.WithMessage(err, "in-process builder failed")
+		}
+	}
+
+	if instance == nil && r.ExternalBuilder != nil {
 		// for now, the package ID we retrieve from the FS is always the ccid
 		// the chaincode uses for registration
 		_, mdBytes, codeStream, err := r.PackageProvider.GetChaincodePackage(ccid)
@@ -164,6 +164,50 @@ var _ = Describe("Router", func() {
 				Expect(fakeDockerBuilder.BuildCallCount()).To(Equal(1))
 			})
 		})
+
+		Context("when an in-process builder is provided", func() {
+			var fakeInProcBuilder *mock.InProcBuilder
+
+			BeforeEach(func() {
+				fakeInProcBuilder = &mock.InProcBuilder{}
+				fakeInProcBuilder.BuildReturns(fakeInstance, nil)
+				router.InProcBuilder = fakeInProcBuilder
+			})
+
+			It("uses the in-process instance without retrieving the package", func() {
+				err := router.Build("package-id")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeInProcBuilder.BuildCallCount()).To(Equal(1))
+				Expect(fakeInProcBuilder.BuildArgsForCall(0)).To(Equal("package-id"))
+				Expect(fakePackageProvider.GetChaincodePackageCallCount()).To(Equal(0))
+				Expect(fakeExternalBuilder.BuildCallCount()).To(Equal(0))
+				Expect(fakeDockerBuilder.BuildCallCount()).To(Equal(0))
+			})
+
+			Context("when the in-process builder returns an error", func() {
+				BeforeEach(func() {
+					fakeInProcBuilder.BuildReturns(nil, errors.New("fake-inproc-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Build("package-id")
+					Expect(err).To(MatchError("in-process builder failed: fake-inproc-error"))
+				})
+			})
+
+			Context("when the in-process builder returns a nil instance", func() {
+				BeforeEach(func() {
+					fakeInProcBuilder.BuildReturns(nil, nil)
+				})
+
+				It("falls back to the external builder", func() {
+					err := router.Build("package-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeExternalBuilder.BuildCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 
 	Describe("Post-build operations", func() {
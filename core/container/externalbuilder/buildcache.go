@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/build_cache.go --fake-name BuildCache . BuildCache
+
+// BuildCache stores the archived build output of external builders so that
+// peers with identical packages and builders can reuse the output instead of
+// running the build again. Entries are keyed by package ID and builder name.
+type BuildCache interface {
+	// Get returns the gzip-ed tar archive of the build output along with the
+	// HMAC-SHA256 recorded when it was stored. A nil reader is returned when
+	// no entry exists.
+	Get(ccid, builderName string) (archive io.ReadCloser, mac []byte, err error)
+	// Put stores the gzip-ed tar archive of the build output along with its
+	// HMAC-SHA256.
+	Put(ccid, builderName string, archive io.Reader, mac []byte) error
+}
+
+// DirectoryBuildCache is a BuildCache backed by a file system directory. The
+// directory may be shared between peers, for example over a network file
+// system. Entries are authenticated with a key held by the peers, so anyone
+// able to write to the directory without the key cannot plant build output.
+type DirectoryBuildCache struct {
+	Path string
+}
+
+const (
+	buildCacheArchive = "bld.tar.gz"
+	buildCacheMAC     = "bld.tar.gz.hmac"
+)
+
+func (d *DirectoryBuildCache) entryPath(ccid, builderName string) string {
+	return filepath.Join(d.Path, SanitizeCCIDPath(ccid), SanitizeCCIDPath(builderName))
+}
+
+// Get returns the cached build output for the package and builder.
+func (d *DirectoryBuildCache) Get(ccid, builderName string) (io.ReadCloser, []byte, error) {
+	entryPath := d.entryPath(ccid, builderName)
+
+	macPath := filepath.Join(entryPath, buildCacheMAC)
+	hexMAC, err := ioutil.ReadFile(macPath)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "could not read authentication code '%s'", macPath)
+	}
+
+	mac, err := hex.DecodeString(strings.TrimSpace(string(hexMAC)))
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "malformed authentication code at '%s'", macPath)
+	}
+
+	archivePath := filepath.Join(entryPath, buildCacheArchive)
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "could not open cached build output '%s'", archivePath)
+	}
+
+	return archive, mac, nil
+}
+
+// Put stores the build output for the package and builder. The entry is
+// written to a temporary location first and moved into place so that
+// concurrent readers never observe a partial entry.
+func (d *DirectoryBuildCache) Put(ccid, builderName string, archive io.Reader, mac []byte) error {
+	entryPath := d.entryPath(ccid, builderName)
+	if err := os.MkdirAll(filepath.Dir(entryPath), 0o755); err != nil {
+		return errors.WithMessagef(err, "could not create build cache dir '%s'", filepath.Dir(entryPath))
+	}
+
+	tempPath, err := ioutil.TempDir(filepath.Dir(entryPath), ".tmp-")
+	if err != nil {
+		return errors.WithMessage(err, "could not create temporary build cache entry")
+	}
+	defer os.RemoveAll(tempPath)
+
+	f, err := os.OpenFile(filepath.Join(tempPath, buildCacheArchive), os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.WithMessage(err, "could not create cached build output")
+	}
+	_, err = io.Copy(f, archive)
+	f.Close()
+	if err != nil {
+		return errors.WithMessage(err, "could not write cached build output")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(tempPath, buildCacheMAC), []byte(hex.EncodeToString(mac)), 0o644)
+	if err != nil {
+		return errors.WithMessage(err, "could not write cached build output authentication code")
+	}
+
+	if err := os.Chmod(tempPath, 0o755); err != nil {
+		return errors.WithMessage(err, "could not set permissions on build cache entry")
+	}
+
+	err = os.Rename(tempPath, entryPath)
+	if err != nil {
+		if _, statErr := os.Stat(entryPath); statErr == nil {
+			// another peer stored the same entry first
+			return nil
+		}
+		return errors.WithMessagef(err, "could not move build cache entry into place at '%s'", entryPath)
+	}
+
+	return nil
+}
+
+// restoreCachedBuild extracts the cached build output for the builder into
+// bldDir after authenticating it with the key. It returns false when no entry
+// is available.
+func restoreCachedBuild(cache BuildCache, key []byte, ccid, builderName, bldDir string) (bool, error) {
+	archive, expected, err := cache.Get(ccid, builderName)
+	if err != nil {
+		return false, errors.WithMessage(err, "could not retrieve cached build output")
+	}
+	if archive == nil {
+		return false, nil
+	}
+	defer archive.Close()
+
+	buffer := &bytes.Buffer{}
+	h := hmac.New(sha256.New, key)
+	if _, err := io.Copy(io.MultiWriter(buffer, h), archive); err != nil {
+		return false, errors.WithMessage(err, "could not read cached build output")
+	}
+
+	if !hmac.Equal(h.Sum(nil), expected) {
+		return false, errors.New("cached build output failed authentication")
+	}
+
+	if err := Untar(buffer, bldDir); err != nil {
+		return false, errors.WithMessage(err, "could not extract cached build output")
+	}
+
+	return true, nil
+}
+
+// storeCachedBuild archives bldDir and stores it for the builder along with
+// its authentication code computed with the key.
+func storeCachedBuild(cache BuildCache, key []byte, ccid, builderName, bldDir string) error {
+	buffer := &bytes.Buffer{}
+	h := hmac.New(sha256.New, key)
+	if err := Tar(bldDir, io.MultiWriter(buffer, h)); err != nil {
+		return errors.WithMessage(err, "could not archive build output")
+	}
+
+	return cache.Put(ccid, builderName, buffer, h.Sum(nil))
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilder_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirectoryBuildCache", func() {
+	var (
+		cachePath  string
+		buildCache *externalbuilder.DirectoryBuildCache
+	)
+
+	BeforeEach(func() {
+		var err error
+		cachePath, err = ioutil.TempDir("", "build-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		buildCache = &externalbuilder.DirectoryBuildCache{Path: cachePath}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cachePath)
+	})
+
+	It("stores and retrieves build output by package ID and builder name", func() {
+		err := buildCache.Put("package:id", "builder", bytes.NewBufferString("archive"), []byte{1, 2, 3})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Join(cachePath, "package-id", "builder", "bld.tar.gz")).To(BeARegularFile())
+		Expect(filepath.Join(cachePath, "package-id", "builder", "bld.tar.gz.hmac")).To(BeARegularFile())
+
+		archive, mac, err := buildCache.Get("package:id", "builder")
+		Expect(err).NotTo(HaveOccurred())
+		defer archive.Close()
+		Expect(mac).To(Equal([]byte{1, 2, 3}))
+		contents, err := ioutil.ReadAll(archive)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(Equal([]byte("archive")))
+
+		archive, _, err = buildCache.Get("package:id", "other-builder")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(archive).To(BeNil())
+	})
+
+	It("returns a nil archive when nothing has been cached", func() {
+		archive, mac, err := buildCache.Get("package-id", "builder")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(archive).To(BeNil())
+		Expect(mac).To(BeNil())
+	})
+
+	It("keeps the existing entry when the output is stored again", func() {
+		err := buildCache.Put("package-id", "builder", bytes.NewBufferString("first"), []byte{1})
+		Expect(err).NotTo(HaveOccurred())
+		err = buildCache.Put("package-id", "builder", bytes.NewBufferString("second"), []byte{2})
+		Expect(err).NotTo(HaveOccurred())
+
+		archive, mac, err := buildCache.Get("package-id", "builder")
+		Expect(err).NotTo(HaveOccurred())
+		defer archive.Close()
+		Expect(mac).To(Equal([]byte{1}))
+	})
+
+	Context("when the authentication code is malformed", func() {
+		BeforeEach(func() {
+			err := os.MkdirAll(filepath.Join(cachePath, "package-id", "builder"), 0o755)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(cachePath, "package-id", "builder", "bld.tar.gz.hmac"), []byte("not-hex"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			_, _, err := buildCache.Get("package-id", "builder")
+			Expect(err).To(MatchError(ContainSubstring("malformed authentication code at")))
+		})
+	})
+})
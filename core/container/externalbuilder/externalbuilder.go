@@ -46,6 +46,14 @@ type Detector struct {
 	DurablePath string
 	// Builders are the builders that detect and build processing will use.
 	Builders []*Builder
+	// BuildCache, when set, is consulted for the build output of a detected
+	// builder before running its build and is populated after a successful
+	// build.
+	BuildCache BuildCache
+	// BuildCacheKey is the key used to authenticate the entries of the
+	// BuildCache. It must be shared by the peers sharing the cache and kept
+	// out of the cache itself.
+	BuildCacheKey []byte
 }
 
 // CachedBuild returns a build instance that was already built or nil when no
@@ -93,6 +101,9 @@ func (d *Detector) CachedBuild(ccid string) (*Instance, error) {
 // durable path for the results of a previous build for the provided package.
 // If found, the detect and build process is skipped and the existing instance
 // is returned.
+//
+// When a build cache is configured, build output cached for the package and
+// detected builder is imported in place of running the build.
 func (d *Detector) Build(ccid string, mdBytes []byte, codeStream io.Reader) (*Instance, error) {
 	// A small optimization: prevent exploding the build package out into the
 	// file system unless there are external builders defined.
@@ -121,8 +132,8 @@ func (d *Detector) Build(ccid string, mdBytes []byte, codeStream io.Reader) (*In
 		return nil, nil
 	}
 
-	if err := builder.Build(buildContext); err != nil {
-		return nil, errors.WithMessage(err, "external builder failed to build")
+	if err := d.build(builder, buildContext); err != nil {
+		return nil, err
 	}
 
 	if err := builder.Release(buildContext); err != nil {
@@ -171,6 +182,45 @@ func (d *Detector) Build(ccid string, mdBytes []byte, codeStream io.Reader) (*In
 	}, nil
 }
 
+func (d *Detector) build(builder *Builder, buildContext *BuildContext) error {
+	if d.BuildCache == nil {
+		if err := builder.Build(buildContext); err != nil {
+			return errors.WithMessage(err, "external builder failed to build")
+		}
+		return nil
+	}
+
+	ccid := buildContext.CCID
+	restored, err := restoreCachedBuild(d.BuildCache, d.BuildCacheKey, ccid, builder.Name, buildContext.BldDir)
+	if err != nil {
+		logger.Warningf("Could not use cached build output for %s from builder '%s', building instead: %s", ccid, builder.Name, err)
+		if err := resetDir(buildContext.BldDir); err != nil {
+			return errors.WithMessage(err, "could not reset build output directory")
+		}
+	}
+	if restored {
+		logger.Infof("Using cached build output for %s from builder '%s'", ccid, builder.Name)
+		return nil
+	}
+
+	if err := builder.Build(buildContext); err != nil {
+		return errors.WithMessage(err, "external builder failed to build")
+	}
+
+	if err := storeCachedBuild(d.BuildCache, d.BuildCacheKey, ccid, builder.Name, buildContext.BldDir); err != nil {
+		logger.Warningf("Could not cache build output for %s from builder '%s': %s", ccid, builder.Name, err)
+	}
+
+	return nil
+}
+
+func resetDir(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(dir, 0o700)
+}
+
 func (d *Detector) detect(buildContext *BuildContext) *Builder {
 	for _, builder := range d.Builders {
 		if builder.Detect(buildContext) {
@@ -7,7 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package externalbuilder_test
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -16,9 +20,11 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/hyperledger/fabric/core/container/externalbuilder/mock"
 	"github.com/hyperledger/fabric/core/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -156,6 +162,109 @@ var _ = Describe("externalbuilder", func() {
 					Expect(err).To(MatchError("could not create dir 'path/to/nowhere/fake-package-id' to persist build output: mkdir path/to/nowhere/fake-package-id: no such file or directory"))
 				})
 			})
+
+			Context("when a build cache is configured", func() {
+				var (
+					fakeBuildCache *mock.BuildCache
+					archive        []byte
+					mac            []byte
+				)
+
+				BeforeEach(func() {
+					bldDir, err := ioutil.TempDir("", "cached-bld")
+					Expect(err).NotTo(HaveOccurred())
+					defer os.RemoveAll(bldDir)
+					err = ioutil.WriteFile(filepath.Join(bldDir, "cached.file"), []byte("cached"), 0o600)
+					Expect(err).NotTo(HaveOccurred())
+
+					buf := &bytes.Buffer{}
+					err = externalbuilder.Tar(bldDir, buf)
+					Expect(err).NotTo(HaveOccurred())
+					archive = buf.Bytes()
+					h := hmac.New(sha256.New, []byte("cache-key"))
+					h.Write(archive)
+					mac = h.Sum(nil)
+
+					fakeBuildCache = &mock.BuildCache{}
+					fakeBuildCache.GetStub = func(string, string) (io.ReadCloser, []byte, error) {
+						return ioutil.NopCloser(bytes.NewReader(archive)), mac, nil
+					}
+					detector.BuildCache = fakeBuildCache
+					detector.BuildCacheKey = []byte("cache-key")
+				})
+
+				It("imports the cached build output for the detected builder", func() {
+					instance, err := detector.Build("fake-package-id", md, codePackage)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(instance.Builder.Name).To(Equal("goodbuilder"))
+
+					Expect(fakeBuildCache.GetCallCount()).To(Equal(1))
+					ccid, builderName := fakeBuildCache.GetArgsForCall(0)
+					Expect(ccid).To(Equal("fake-package-id"))
+					Expect(builderName).To(Equal("goodbuilder"))
+					Expect(fakeBuildCache.PutCallCount()).To(Equal(0))
+
+					Expect(filepath.Join(durablePath, "fake-package-id", "bld", "cached.file")).To(BeARegularFile())
+				})
+
+				Context("when nothing has been cached", func() {
+					BeforeEach(func() {
+						fakeBuildCache.GetStub = nil
+						fakeBuildCache.GetReturns(nil, nil, nil)
+					})
+
+					It("builds and caches the build output", func() {
+						_, err := detector.Build("fake-package-id", md, codePackage)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeBuildCache.PutCallCount()).To(Equal(1))
+						ccid, builderName, archive, mac := fakeBuildCache.PutArgsForCall(0)
+						Expect(ccid).To(Equal("fake-package-id"))
+						Expect(builderName).To(Equal("goodbuilder"))
+						archiveBytes, err := ioutil.ReadAll(archive)
+						Expect(err).NotTo(HaveOccurred())
+						h := hmac.New(sha256.New, []byte("cache-key"))
+						h.Write(archiveBytes)
+						Expect(mac).To(Equal(h.Sum(nil)))
+					})
+
+					It("ignores failures to cache the build output", func() {
+						fakeBuildCache.PutReturns(errors.New("fake-put-error"))
+						_, err := detector.Build("fake-package-id", md, codePackage)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(filepath.Join(durablePath, "fake-package-id", "bld")).To(BeADirectory())
+					})
+				})
+
+				Context("when the cached build output fails authentication", func() {
+					BeforeEach(func() {
+						sum := sha256.Sum256(archive)
+						mac = sum[:]
+					})
+
+					It("builds instead of importing the cached output", func() {
+						_, err := detector.Build("fake-package-id", md, codePackage)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(filepath.Join(durablePath, "fake-package-id", "bld", "cached.file")).NotTo(BeAnExistingFile())
+						Expect(fakeBuildCache.PutCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the build cache cannot be read", func() {
+					BeforeEach(func() {
+						fakeBuildCache.GetStub = nil
+						fakeBuildCache.GetReturns(nil, nil, errors.New("fake-get-error"))
+					})
+
+					It("builds instead of importing the cached output", func() {
+						instance, err := detector.Build("fake-package-id", md, codePackage)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(instance.Builder.Name).To(Equal("goodbuilder"))
+						Expect(fakeBuildCache.PutCallCount()).To(Equal(1))
+					})
+				})
+			})
 		})
 
 		Describe("CachedBuild", func() {
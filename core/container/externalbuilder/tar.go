@@ -73,6 +73,63 @@ func Untar(buffer io.Reader, dst string) error {
 	}
 }
 
+// Tar writes the contents of src to w as a gzip-ed tar archive. Paths in the
+// archive are relative to src. It returns an error if src contains any files
+// whose type is not a regular file or directory as Untar would not be able to
+// extract them.
+func Tar(src string, w io.Writer) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if !info.Mode().IsDir() && !info.Mode().IsRegular() {
+			return errors.Errorf("invalid file type '%v' for file '%s'", info.Mode()&os.ModeType, rel)
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return errors.WithMessagef(err, "could not create tar header for '%s'", rel)
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.WithMessagef(err, "could not write tar header for '%s'", rel)
+		}
+
+		if info.Mode().IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.WithMessagef(err, "could not open file '%s'", rel)
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
 // ValidPath checks to see if the path is absolute, or if it is a
 // relative path higher in the tree.  In these cases it returns false.
 func ValidPath(uncleanPath string) bool {
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package externalbuilder_test
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -102,4 +104,49 @@ var _ = Describe("Tar", func() {
 			Expect(externalbuilder.ValidPath("/an/absolute/path")).To(BeFalse())
 		})
 	})
+
+	Describe("Tar", func() {
+		var src, dst string
+
+		BeforeEach(func() {
+			var err error
+			src, err = ioutil.TempDir("", "tar-test")
+			Expect(err).NotTo(HaveOccurred())
+			dst, err = ioutil.TempDir("", "untar-test")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(src)
+			os.RemoveAll(dst)
+		})
+
+		It("creates an archive that can be extracted", func() {
+			err := os.MkdirAll(filepath.Join(src, "a", "b"), 0o700)
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(src, "a", "b", "test.file"), []byte("contents"), 0o600)
+			Expect(err).NotTo(HaveOccurred())
+
+			buf := &bytes.Buffer{}
+			err = externalbuilder.Tar(src, buf)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = externalbuilder.Untar(buf, dst)
+			Expect(err).NotTo(HaveOccurred())
+			contents, err := ioutil.ReadFile(filepath.Join(dst, "a", "b", "test.file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(Equal([]byte("contents")))
+		})
+
+		Context("when the source contains an odd file type", func() {
+			It("returns an error", func() {
+				err := os.Symlink("target", filepath.Join(src, "link"))
+				Expect(err).NotTo(HaveOccurred())
+
+				err = externalbuilder.Tar(src, &bytes.Buffer{})
+				Expect(err).To(MatchError("invalid file type 'L---------' for file 'link'"))
+			})
+		})
+	})
+
 })
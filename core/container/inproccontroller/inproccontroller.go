@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("chaincode.inproccontroller")
+
+// pluginFactory is the function a chaincode plugin must export to provide
+// its chaincode implementation.
+const pluginFactory = "NewChaincode"
+
+// Registry tracks the Go chaincode implementations which are served from
+// within the peer process rather than from a container or an external
+// process. Chaincodes are registered by the package ID the peer would
+// otherwise use to build and launch them.
+type Registry struct {
+	mutex      sync.Mutex
+	chaincodes map[string]shim.Chaincode
+	instances  map[string]*Instance
+}
+
+// Register associates the chaincode implementation with the package ID.
+// A package ID may only be registered once.
+func (r *Registry) Register(packageID string, cc shim.Chaincode) error {
+	if packageID == "" {
+		return errors.New("package ID must be specified")
+	}
+	if cc == nil {
+		return errors.Errorf("chaincode for package ID '%s' must not be nil", packageID)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.chaincodes == nil {
+		r.chaincodes = map[string]shim.Chaincode{}
+	}
+	if _, ok := r.chaincodes[packageID]; ok {
+		return errors.Errorf("chaincode for package ID '%s' is already registered", packageID)
+	}
+	r.chaincodes[packageID] = cc
+
+	return nil
+}
+
+// Registered returns whether an in-process chaincode has been registered for
+// the package ID.
+func (r *Registry) Registered(packageID string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, ok := r.chaincodes[packageID]
+	return ok
+}
+
+// Build returns the in-process instance for the package ID. When no chaincode
+// has been registered for the package ID, a nil instance is returned so that
+// the caller may fall back to other builders.
+func (r *Registry) Build(ccid string) (container.Instance, error) {
+	instance := r.instance(ccid)
+	if instance == nil {
+		return nil, nil
+	}
+	return instance, nil
+}
+
+func (r *Registry) instance(ccid string) *Instance {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cc, ok := r.chaincodes[ccid]
+	if !ok {
+		return nil
+	}
+
+	if r.instances == nil {
+		r.instances = map[string]*Instance{}
+	}
+	instance, ok := r.instances[ccid]
+	if !ok {
+		instance = &Instance{
+			PackageID: ccid,
+			Chaincode: cc,
+		}
+		r.instances[ccid] = instance
+	}
+
+	return instance
+}
+
+//go:generate counterfeiter -o mock/external_connection_handler.go --fake-name ExternalConnectionHandler . ExternalConnectionHandler
+
+// ExternalConnectionHandler handles the `Chaincode` client connection for
+// chaincodes which are not served in-process.
+type ExternalConnectionHandler interface {
+	Stream(ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler extcc.StreamHandler) error
+}
+
+// ConnectionHandler connects the peer to in-process chaincodes and delegates
+// all other connections to the external connection handler.
+type ConnectionHandler struct {
+	Registry                  *Registry
+	ExternalConnectionHandler ExternalConnectionHandler
+}
+
+// Stream serves the chaincode stream for the package ID. In-process chaincodes
+// are served over an in-memory stream that is processed by the same stream
+// handler used for chaincode servers.
+func (c *ConnectionHandler) Stream(ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler extcc.StreamHandler) error {
+	if instance := c.Registry.instance(ccid); instance != nil {
+		return instance.Stream(sHandler)
+	}
+
+	if c.ExternalConnectionHandler == nil {
+		return errors.Errorf("no in-process chaincode registered for %s", ccid)
+	}
+	return c.ExternalConnectionHandler.Stream(ccid, ccinfo, sHandler)
+}
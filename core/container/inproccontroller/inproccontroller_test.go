@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller_test
+
+import (
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/chaincode/extcc/mock"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	inprocmock "github.com/hyperledger/fabric/core/container/inproccontroller/mock"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type echoChaincode struct{}
+
+func (echoChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (echoChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success([]byte(stub.GetTxID()))
+}
+
+var _ = Describe("Registry", func() {
+	var registry *inproccontroller.Registry
+
+	BeforeEach(func() {
+		registry = &inproccontroller.Registry{}
+	})
+
+	Describe("Register", func() {
+		It("registers the chaincode", func() {
+			err := registry.Register("package-id", echoChaincode{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Registered("package-id")).To(BeTrue())
+			Expect(registry.Registered("other-package-id")).To(BeFalse())
+		})
+
+		It("rejects an empty package ID", func() {
+			err := registry.Register("", echoChaincode{})
+			Expect(err).To(MatchError("package ID must be specified"))
+		})
+
+		It("rejects a nil chaincode", func() {
+			err := registry.Register("package-id", nil)
+			Expect(err).To(MatchError("chaincode for package ID 'package-id' must not be nil"))
+		})
+
+		It("rejects duplicate registrations", func() {
+			err := registry.Register("package-id", echoChaincode{})
+			Expect(err).NotTo(HaveOccurred())
+			err = registry.Register("package-id", echoChaincode{})
+			Expect(err).To(MatchError("chaincode for package ID 'package-id' is already registered"))
+		})
+	})
+
+	Describe("Build", func() {
+		BeforeEach(func() {
+			err := registry.Register("package-id", echoChaincode{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the same instance for a registered chaincode", func() {
+			instance, err := registry.Build("package-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).NotTo(BeNil())
+
+			again, err := registry.Build("package-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).To(BeIdenticalTo(instance))
+
+			ccinfo, err := instance.ChaincodeServerInfo()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ccinfo).To(Equal(&ccintf.ChaincodeServerInfo{Address: "inproc:package-id"}))
+		})
+
+		It("returns a nil instance for an unknown chaincode", func() {
+			instance, err := registry.Build("unknown-package-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("ConnectionHandler", func() {
+	var (
+		registry          *inproccontroller.Registry
+		fakeExternal      *inprocmock.ExternalConnectionHandler
+		fakeStreamHandler *mock.StreamHandler
+		connectionHandler *inproccontroller.ConnectionHandler
+	)
+
+	BeforeEach(func() {
+		registry = &inproccontroller.Registry{}
+		err := registry.Register("package-id", echoChaincode{})
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeExternal = &inprocmock.ExternalConnectionHandler{}
+		fakeStreamHandler = &mock.StreamHandler{}
+		connectionHandler = &inproccontroller.ConnectionHandler{
+			Registry:                  registry,
+			ExternalConnectionHandler: fakeExternal,
+		}
+	})
+
+	It("serves registered chaincodes over an in-process stream", func() {
+		var response *pb.ChaincodeMessage
+		fakeStreamHandler.HandleChaincodeStreamStub = func(stream ccintf.ChaincodeStream) error {
+			msg, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msg.Type).To(Equal(pb.ChaincodeMessage_REGISTER))
+			chaincodeID := &pb.ChaincodeID{}
+			Expect(proto.Unmarshal(msg.Payload, chaincodeID)).To(Succeed())
+			Expect(chaincodeID.Name).To(Equal("package-id"))
+
+			Expect(stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED})).To(Succeed())
+			Expect(stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_READY})).To(Succeed())
+
+			input, err := proto.Marshal(&pb.ChaincodeInput{Args: [][]byte{[]byte("function")}})
+			Expect(err).NotTo(HaveOccurred())
+			err = stream.Send(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_TRANSACTION,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+				Payload:   input,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			return nil
+		}
+
+		err := connectionHandler.Stream("package-id", nil, fakeStreamHandler)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeExternal.StreamCallCount()).To(Equal(0))
+
+		Expect(response.Type).To(Equal(pb.ChaincodeMessage_COMPLETED))
+		Expect(response.Txid).To(Equal("tx-id"))
+		resp := &pb.Response{}
+		Expect(proto.Unmarshal(response.Payload, resp)).To(Succeed())
+		Expect(resp.Status).To(Equal(int32(shim.OK)))
+		Expect(resp.Payload).To(Equal([]byte("tx-id")))
+	})
+
+	It("terminates the stream when the instance is stopped", func() {
+		instance, err := registry.Build("package-id")
+		Expect(err).NotTo(HaveOccurred())
+
+		registered := make(chan struct{})
+		fakeStreamHandler.HandleChaincodeStreamStub = func(stream ccintf.ChaincodeStream) error {
+			_, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			close(registered)
+
+			_, err = stream.Recv()
+			Expect(err).To(Equal(io.EOF))
+			return err
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- connectionHandler.Stream("package-id", nil, fakeStreamHandler) }()
+
+		Eventually(registered).Should(BeClosed())
+		Expect(instance.Stop()).To(Succeed())
+		Eventually(done).Should(Receive(BeNil()))
+
+		exitCode, err := instance.Wait()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exitCode).To(Equal(0))
+	})
+
+	It("delegates unregistered chaincodes to the external connection handler", func() {
+		fakeExternal.StreamReturns(errors.New("fake-stream-error"))
+		ccinfo := &ccintf.ChaincodeServerInfo{Address: "chaincode-address"}
+
+		err := connectionHandler.Stream("other-package-id", ccinfo, fakeStreamHandler)
+		Expect(err).To(MatchError("fake-stream-error"))
+
+		Expect(fakeExternal.StreamCallCount()).To(Equal(1))
+		ccid, info, handler := fakeExternal.StreamArgsForCall(0)
+		Expect(ccid).To(Equal("other-package-id"))
+		Expect(info).To(Equal(ccinfo))
+		Expect(handler).To(Equal(fakeStreamHandler))
+		Expect(fakeStreamHandler.HandleChaincodeStreamCallCount()).To(Equal(0))
+	})
+
+	Context("when no external connection handler is configured", func() {
+		BeforeEach(func() {
+			connectionHandler.ExternalConnectionHandler = nil
+		})
+
+		It("returns an error for unregistered chaincodes", func() {
+			err := connectionHandler.Stream("other-package-id", nil, fakeStreamHandler)
+			Expect(err).To(MatchError("no in-process chaincode registered for other-package-id"))
+		})
+	})
+})
+
+var _ = Describe("Instance", func() {
+	var instance *inproccontroller.Instance
+
+	BeforeEach(func() {
+		instance = &inproccontroller.Instance{
+			PackageID: "package-id",
+			Chaincode: echoChaincode{},
+		}
+	})
+
+	It("cannot be started as a client", func() {
+		err := instance.Start(&ccintf.PeerConnection{Address: "peer-address"})
+		Expect(err).To(MatchError("in-process chaincode 'package-id' cannot be started as a client"))
+	})
+
+	It("cannot be stopped or waited on before streaming", func() {
+		Expect(instance.Stop()).To(MatchError("in-process chaincode 'package-id' has not been started"))
+		_, err := instance.Wait()
+		Expect(err).To(MatchError("in-process chaincode 'package-id' was not successfully started"))
+	})
+})
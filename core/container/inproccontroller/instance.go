@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// Instance is a chaincode served from within the peer process.
+type Instance struct {
+	PackageID string
+	Chaincode shim.Chaincode
+
+	mutex   sync.Mutex
+	session *session
+}
+
+// session represents a single in-memory chaincode stream.
+type session struct {
+	toChaincode *pipe
+	toPeer      *pipe
+	done        chan struct{}
+}
+
+func (s *session) stop() {
+	s.toChaincode.close()
+	s.toPeer.close()
+}
+
+// ChaincodeServerInfo returns non-nil server information so that the peer
+// initiates the chaincode stream as it does for chaincode servers. The
+// connection handler serves the stream in-process instead of dialing.
+func (i *Instance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
+	return &ccintf.ChaincodeServerInfo{Address: "inproc:" + i.PackageID}, nil
+}
+
+// Start is not supported as in-process chaincodes never connect to the peer.
+func (i *Instance) Start(peerConnection *ccintf.PeerConnection) error {
+	return errors.Errorf("in-process chaincode '%s' cannot be started as a client", i.PackageID)
+}
+
+// Stream runs the chaincode against the stream handler until either side
+// terminates the stream.
+func (i *Instance) Stream(sHandler extcc.StreamHandler) error {
+	s := &session{
+		toChaincode: newPipe(),
+		toPeer:      newPipe(),
+		done:        make(chan struct{}),
+	}
+
+	i.mutex.Lock()
+	if i.session != nil {
+		select {
+		case <-i.session.done:
+		default:
+			i.mutex.Unlock()
+			return errors.Errorf("in-process chaincode '%s' is already running", i.PackageID)
+		}
+	}
+	i.session = s
+	i.mutex.Unlock()
+
+	chaincodeStream := &stream{recv: s.toChaincode, send: s.toPeer}
+	peerStream := &stream{recv: s.toPeer, send: s.toChaincode}
+
+	go func() {
+		defer close(s.done)
+		err := shim.StartInProc(i.PackageID, chaincodeStream, i.Chaincode)
+		logger.Debugf("in-process chaincode %s exited: %v", i.PackageID, err)
+	}()
+
+	logger.Debugf("starting in-process chaincode stream: %s", i.PackageID)
+	sHandler.HandleChaincodeStream(peerStream)
+
+	// the peer has stopped processing the stream, make sure the chaincode
+	// does not outlive it
+	s.stop()
+	<-s.done
+
+	logger.Debugf("in-process chaincode stream %s terminated", i.PackageID)
+
+	return nil
+}
+
+// Stop terminates the running chaincode stream.
+func (i *Instance) Stop() error {
+	i.mutex.Lock()
+	s := i.session
+	i.mutex.Unlock()
+
+	if s == nil {
+		return errors.Errorf("in-process chaincode '%s' has not been started", i.PackageID)
+	}
+
+	s.stop()
+	<-s.done
+
+	return nil
+}
+
+// Wait blocks until the running chaincode stream terminates.
+func (i *Instance) Wait() (int, error) {
+	i.mutex.Lock()
+	s := i.session
+	i.mutex.Unlock()
+
+	if s == nil {
+		return -1, errors.Errorf("in-process chaincode '%s' was not successfully started", i.PackageID)
+	}
+
+	<-s.done
+	return 0, nil
+}
@@ -0,0 +1,38 @@
+//go:build !noplugin && cgo
+// +build !noplugin,cgo
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller
+
+import (
+	"plugin"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/pkg/errors"
+)
+
+// RegisterPlugin loads the Go plugin at the given path and registers the
+// chaincode it provides for the package ID. The plugin must export a
+// NewChaincode function returning the shim.Chaincode implementation.
+func (r *Registry) RegisterPlugin(packageID, pluginPath string) error {
+	p, err := plugin.Open(pluginPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open chaincode plugin at %s", pluginPath)
+	}
+
+	constructorSymbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		return errors.Wrapf(err, "chaincode plugin at %s does not export %s", pluginPath, pluginFactory)
+	}
+	constructor, ok := constructorSymbol.(func() shim.Chaincode)
+	if !ok {
+		return errors.Errorf("chaincode plugin at %s must define %s as func() shim.Chaincode", pluginPath, pluginFactory)
+	}
+
+	return r.Register(packageID, constructor())
+}
@@ -0,0 +1,18 @@
+//go:build noplugin || !cgo
+// +build noplugin !cgo
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller
+
+import "github.com/pkg/errors"
+
+// RegisterPlugin is not supported when the peer is built without plugin
+// support.
+func (r *Registry) RegisterPlugin(packageID, pluginPath string) error {
+	return errors.Errorf("cannot load chaincode plugin at %s for package ID '%s': the peer was built without plugin support", pluginPath, packageID)
+}
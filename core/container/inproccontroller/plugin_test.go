@@ -0,0 +1,59 @@
+//go:build !noplugin && cgo
+// +build !noplugin,cgo
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RegisterPlugin", func() {
+	var (
+		registry *inproccontroller.Registry
+		tempDir  string
+	)
+
+	BeforeEach(func() {
+		registry = &inproccontroller.Registry{}
+
+		var err error
+		tempDir, err = ioutil.TempDir("", "inproc-plugin")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("registers the chaincode provided by the plugin", func() {
+		pluginPath := filepath.Join(tempDir, "chaincode.so")
+		cmd := exec.Command("go", "build", "-buildmode=plugin", "-o", pluginPath, "./testdata/chaincodeplugin")
+		output, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+
+		err = registry.RegisterPlugin("package-id", pluginPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(registry.Registered("package-id")).To(BeTrue())
+	})
+
+	It("fails when the plugin cannot be opened", func() {
+		pluginPath := filepath.Join(tempDir, "missing.so")
+		err := registry.RegisterPlugin("package-id", pluginPath)
+		Expect(err).To(MatchError(ContainSubstring("failed to open chaincode plugin at " + pluginPath)))
+		Expect(registry.Registered("package-id")).To(BeFalse())
+	})
+})
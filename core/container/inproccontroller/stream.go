@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller
+
+import (
+	"io"
+	"sync"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// pipe carries chaincode messages in a single direction.
+type pipe struct {
+	msgs   chan *pb.ChaincodeMessage
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newPipe() *pipe {
+	return &pipe{
+		msgs:   make(chan *pb.ChaincodeMessage, 10),
+		closed: make(chan struct{}),
+	}
+}
+
+func (p *pipe) close() {
+	p.once.Do(func() { close(p.closed) })
+}
+
+// stream is an in-memory chaincode stream. It satisfies both the peer's
+// ccintf.ChaincodeStream and the shim's ClientStream.
+type stream struct {
+	recv *pipe
+	send *pipe
+}
+
+func (s *stream) Send(msg *pb.ChaincodeMessage) error {
+	select {
+	case <-s.send.closed:
+		return errors.New("stream closed")
+	default:
+	}
+
+	select {
+	case s.send.msgs <- msg:
+		return nil
+	case <-s.send.closed:
+		return errors.New("stream closed")
+	}
+}
+
+func (s *stream) Recv() (*pb.ChaincodeMessage, error) {
+	select {
+	case msg := <-s.recv.msgs:
+		return msg, nil
+	case <-s.recv.closed:
+		// deliver the messages sent before the pipe was closed
+		select {
+		case msg := <-s.recv.msgs:
+			return msg, nil
+		default:
+			return nil, io.EOF
+		}
+	}
+}
+
+// CloseSend signals the receiving side that no more messages will be sent.
+func (s *stream) CloseSend() error {
+	s.send.close()
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inproccontroller
+
+import (
+	"io"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stream", func() {
+	It("delivers the messages sent before the stream was closed", func() {
+		for i := 0; i < 100; i++ {
+			p := newPipe()
+			sender := &stream{recv: newPipe(), send: p}
+			receiver := &stream{recv: p, send: newPipe()}
+
+			Expect(sender.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION})).To(Succeed())
+			Expect(sender.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED})).To(Succeed())
+			Expect(sender.CloseSend()).To(Succeed())
+
+			msg, err := receiver.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msg.Type).To(Equal(pb.ChaincodeMessage_TRANSACTION))
+			msg, err = receiver.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msg.Type).To(Equal(pb.ChaincodeMessage_COMPLETED))
+			_, err = receiver.Recv()
+			Expect(err).To(Equal(io.EOF))
+		}
+	})
+})
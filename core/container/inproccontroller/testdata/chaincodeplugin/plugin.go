@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+type chaincode struct{}
+
+func (chaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (chaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success([]byte(stub.GetTxID()))
+}
+
+// NewChaincode returns the chaincode served by the peer.
+func NewChaincode() shim.Chaincode {
+	return chaincode{}
+}
+
+func main() {}
@@ -9,7 +9,9 @@ package kvledger
 import (
 	"bytes"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -48,7 +50,7 @@ func constructValidAndInvalidPvtData(
 		if pvtdata.BlockNum <= lastBlockInBootSnapshot {
 			validData, invalidData, err = verifyHashesViaBootKVHashes(pvtdata, pvtdataStore)
 		} else {
-			validData, invalidData, err = verifyHashesFromBlockStore(pvtdata, blockStore)
+			validData, invalidData, err = verifyHashesFromBlockStore(pvtdata, blockStore, pvtdataStore)
 		}
 		if err != nil {
 			return nil, nil, err
@@ -61,7 +63,7 @@ func constructValidAndInvalidPvtData(
 	return validPvtData, invalidPvtData, nil
 }
 
-func verifyHashesFromBlockStore(reconciledPvtdata *ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore) (
+func verifyHashesFromBlockStore(reconciledPvtdata *ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore, pvtdataStore *pvtdatastorage.Store) (
 	[]*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	var validPvtData []*ledger.TxPvtData
@@ -77,7 +79,10 @@ func verifyHashesFromBlockStore(reconciledPvtdata *ledger.ReconciledPvtdata, blo
 		// (2) validate passed pvtData against the pvtData hash in the tx rwset.
 		logger.Debugf("Constructing valid and invalid pvtData using rwset of blockNum:[%d], txNum:[%d]",
 			reconciledPvtdata.BlockNum, txPvtData.SeqInBlock)
-		validData, invalidData := findValidAndInvalidTxPvtData(txPvtData, txRWSet, reconciledPvtdata.BlockNum)
+		validData, invalidData, err := findValidAndInvalidTxPvtData(txPvtData, txRWSet, reconciledPvtdata.BlockNum, pvtdataStore)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		// (3) append validData to validPvtDataPvt list of this block and
 		// invalidData to invalidPvtData list
@@ -108,8 +113,8 @@ func retrieveRwsetForTx(blkNum uint64, txNum uint64, blockStore *blkstorage.Bloc
 	return txRWSet, nil
 }
 
-func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetutil.TxRwSet, blkNum uint64) (
-	*ledger.TxPvtData, []*ledger.PvtdataHashMismatch,
+func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetutil.TxRwSet, blkNum uint64, pvtdataStore *pvtdatastorage.Store) (
+	*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	var invalidPvtData []*ledger.PvtdataHashMismatch
 	var toDeleteNsColl []*nsColl
@@ -117,7 +122,10 @@ func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetuti
 	// find valid and invalid pvt data
 	for _, nsRwset := range txPvtData.WriteSet.NsPvtRwset {
 		txNum := txPvtData.SeqInBlock
-		invalidData, invalidNsColl := findInvalidNsPvtData(nsRwset, txRWSet, blkNum, txNum)
+		invalidData, invalidNsColl, err := findInvalidNsPvtData(nsRwset, txRWSet, blkNum, txNum, pvtdataStore)
+		if err != nil {
+			return nil, nil, err
+		}
 		invalidPvtData = append(invalidPvtData, invalidData...)
 		toDeleteNsColl = append(toDeleteNsColl, invalidNsColl...)
 	}
@@ -127,9 +135,9 @@ func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetuti
 	if len(txPvtData.WriteSet.NsPvtRwset) == 0 {
 		// denotes that all namespaces had
 		// invalid pvt data
-		return nil, invalidPvtData
+		return nil, invalidPvtData, nil
 	}
-	return txPvtData, invalidPvtData
+	return txPvtData, invalidPvtData, nil
 }
 
 // Remove removes the rwset for the given <ns, coll> tuple. If after this removal,
@@ -163,8 +171,8 @@ type nsColl struct {
 	ns, coll string
 }
 
-func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet, blkNum, txNum uint64) (
-	[]*ledger.PvtdataHashMismatch, []*nsColl,
+func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet, blkNum, txNum uint64, pvtdataStore *pvtdatastorage.Store) (
+	[]*ledger.PvtdataHashMismatch, []*nsColl, error,
 ) {
 	var invalidPvtData []*ledger.PvtdataHashMismatch
 	var invalidNsColl []*nsColl
@@ -181,6 +189,14 @@ func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.T
 		}
 
 		if !bytes.Equal(util.ComputeSHA256(collPvtRwset.Rwset), rwsetHash) {
+			trimmed, err := isTrimmedByPurge(collPvtRwset, txRWSet, ns, blkNum, txNum, pvtdataStore)
+			if err != nil {
+				return nil, nil, err
+			}
+			if trimmed {
+				// the supplier has purged some of the keys, the remaining keys match their hashes
+				continue
+			}
 			invalidPvtData = append(invalidPvtData, &ledger.PvtdataHashMismatch{
 				BlockNum:   blkNum,
 				TxNum:      txNum,
@@ -190,7 +206,65 @@ func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.T
 			invalidNsColl = append(invalidNsColl, &nsColl{ns, coll})
 		}
 	}
-	return invalidPvtData, invalidNsColl
+	return invalidPvtData, invalidNsColl, nil
+}
+
+// isTrimmedByPurge returns true if the collection write-set differs from the one committed in the block only because
+// the keys explicitly purged since have been removed from it. Each supplied write has to match its hashed write in the
+// block and each omitted hashed write has to be purged locally as well; this allows the reconciliation of the missing
+// pvt data from peers that have already performed the purge.
+func isTrimmedByPurge(collPvtRwset *rwset.CollectionPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet, ns string, blkNum, txNum uint64,
+	pvtdataStore *pvtdatastorage.Store) (bool, error) {
+	coll := collPvtRwset.CollectionName
+	collHashedRwSet := getCollHashedRwSet(txRWSet, ns, coll)
+	if collHashedRwSet == nil {
+		return false, nil
+	}
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtRwset.Rwset, kvRWSet); err != nil {
+		return false, nil
+	}
+
+	hashedWrites := map[string]*kvrwset.KVWriteHash{}
+	for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+		hashedWrites[string(hashedWrite.KeyHash)] = hashedWrite
+	}
+	for _, w := range kvRWSet.Writes {
+		keyHash := string(util.ComputeSHA256([]byte(w.Key)))
+		hashedWrite, ok := hashedWrites[keyHash]
+		if !ok || hashedWrite.IsDelete != w.IsDelete {
+			return false, nil
+		}
+		if !w.IsDelete && !bytes.Equal(util.ComputeSHA256(w.Value), hashedWrite.ValueHash) {
+			return false, nil
+		}
+		delete(hashedWrites, keyHash)
+	}
+	if len(hashedWrites) == 0 {
+		return false, nil
+	}
+
+	for _, hashedWrite := range hashedWrites {
+		purged, err := pvtdataStore.IsPurged(ns, coll, hashedWrite.KeyHash, blkNum, txNum)
+		if err != nil || !purged {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func getCollHashedRwSet(txRWSet *rwsetutil.TxRwSet, ns, coll string) *rwsetutil.CollHashedRwSet {
+	for _, nsRwSet := range txRWSet.NsRwSets {
+		if nsRwSet.NameSpace != ns {
+			continue
+		}
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName == coll {
+				return collHashedRwSet
+			}
+		}
+	}
+	return nil
 }
 
 func verifyHashesViaBootKVHashes(reconciledPvtdata *ledger.ReconciledPvtdata, pvtdataStore *pvtdatastorage.Store) (
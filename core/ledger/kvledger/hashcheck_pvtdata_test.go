@@ -462,6 +462,70 @@ func TestConstructValidInvalidBlocksPvtData(t *testing.T) {
 		)
 		require.Len(t, hashMismatches, 0)
 	})
+
+	t.Run("for-data-after-snapshot:data-trimmed-by-purge-is-accepted", func(t *testing.T) {
+		lgr := bootstrappedLedger
+		pvtdata := pvtdataCopy()
+		pvtdata[1], _ = produceSamplePvtdata(t, 1,
+			[][4]string{
+				{"ns-1", "coll-1", "tx1-key-1", "tx1-val-1"},
+				{"ns-2", "coll-2", "tx1-key-2", "tx1-val-2"},
+			},
+		)
+		reconciledPvtdata := []*ledger.ReconciledPvtdata{
+			{
+				BlockNum:  3,
+				WriteSets: pvtdata,
+			},
+		}
+
+		_, hashMismatches, err := constructValidAndInvalidPvtData(reconciledPvtdata, lgr.blockStore, lgr.pvtdataStore, 2)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]*ledger.PvtdataHashMismatch{
+				{
+					BlockNum:   3,
+					TxNum:      1,
+					Namespace:  "ns-2",
+					Collection: "coll-2",
+				},
+			},
+			hashMismatches,
+		)
+
+		// commit block-4 that purges the keys missing from the supplied data
+		builder := rwsetutil.NewRWSetBuilder()
+		for _, key := range []string{"tx1-key-3", "tx1-key-4", "tx1-key-5"} {
+			builder.AddToPvtAndHashedWriteSetForPurge("ns-2", "coll-2", key)
+		}
+		simRes, err := builder.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimResBytes, err := proto.Marshal(simRes.PubSimulationResults)
+		require.NoError(t, err)
+		require.NoError(t, lgr.commit(
+			&ledger.BlockAndPvtData{
+				Block: blocksGenerator.NextBlock([][]byte{pubSimResBytes}),
+				PvtData: ledger.TxPvtDataMap{
+					0: {SeqInBlock: 0, WriteSet: simRes.PvtSimulationResults},
+				},
+			},
+			&ledger.CommitOptions{},
+		))
+
+		blocksValidPvtData, hashMismatches, err := constructValidAndInvalidPvtData(reconciledPvtdata, lgr.blockStore, lgr.pvtdataStore, 2)
+		require.NoError(t, err)
+		verifyBlocksPvtdata(t,
+			map[uint64][]*ledger.TxPvtData{
+				3: {
+					pvtdata[0],
+					pvtdata[1],
+				},
+			},
+			blocksValidPvtData,
+		)
+		require.Len(t, hashMismatches, 0)
+	})
 }
 
 func verifyBlocksPvtdata(t *testing.T, expected, actual map[uint64][]*ledger.TxPvtData) {
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -710,7 +711,8 @@ func (l *kvLedger) commitToPvtAndBlockStore(blockAndPvtdata *ledger.BlockAndPvtD
 		// too in the pvtdataStore as we do for the publicdata in the case of blockStore.
 		// Hence, we pass all pvtData present in the block to the pvtdataStore committer.
 		pvtData, missingPvtData := constructPvtDataAndMissingData(blockAndPvtdata)
-		if err := l.pvtdataStore.Commit(blockNum, pvtData, missingPvtData); err != nil {
+		purgeMarkers := constructPurgeMarkers(blockAndPvtdata.Block)
+		if err := l.pvtdataStore.Commit(blockNum, pvtData, missingPvtData, purgeMarkers); err != nil {
 			return err
 		}
 	} else {
@@ -769,6 +771,18 @@ func (l *kvLedger) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledge
 	return l.pvtdataStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most recent
+// `maxBlock` blocks in the given range which miss at least a private data of an eligible collection
+// accepted by the filter.
+func (l *kvLedger) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int, filter func(ns, coll string) bool) (ledger.MissingPvtDataInfo, error) {
+	// as in GetMissingPvtDataInfoForMostRecentBlocks, the missing pvtData info cannot be
+	// returned while the pvtdataStore is ahead of the blockStore
+	if l.isPvtstoreAheadOfBlkstore.Load().(bool) {
+		return nil, nil
+	}
+	return l.pvtdataStore.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, maxBlock, filter)
+}
+
 func (l *kvLedger) addBlockCommitHash(block *common.Block, updateBatchBytes []byte) {
 	var valueBytes []byte
 
@@ -1133,3 +1147,64 @@ func constructPvtDataAndMissingData(blockAndPvtData *ledger.BlockAndPvtData) ([]
 	}
 	return pvtData, missingPvtData
 }
+
+// constructPurgeMarkers returns a purge marker for each private data key explicitly purged by a valid
+// transaction in the block. The block is expected to carry the validation flags set during the state validation.
+// The transaction validator invalidates purges on channels without the PurgePvtData capability, so no markers are
+// constructed for such channels.
+func constructPurgeMarkers(block *common.Block) []*pvtdatastorage.PurgeMarker {
+	var purgeMarkers []*pvtdatastorage.PurgeMarker
+	blockNum := block.Header.Number
+	txsFilter := txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+
+	for txNum, envBytes := range block.Data.Data {
+		if !txsFilter.IsValid(txNum) {
+			continue
+		}
+		txRWSet, err := getTxRWSetFromEnvelopeBytes(envBytes)
+		if err != nil {
+			logger.Debugf("Skipping tx [%d] of block [%d] while looking for purged keys: %s", txNum, blockNum, err)
+			continue
+		}
+		for _, nsRwSet := range txRWSet.NsRwSets {
+			for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+				for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+					if !hashedWrite.IsPurge {
+						continue
+					}
+					purgeMarkers = append(purgeMarkers, &pvtdatastorage.PurgeMarker{
+						Ns:         nsRwSet.NameSpace,
+						Coll:       collHashedRwSet.CollectionName,
+						PvtkeyHash: hashedWrite.KeyHash,
+						BlkNum:     blockNum,
+						TxNum:      uint64(txNum),
+					})
+				}
+			}
+		}
+	}
+	return purgeMarkers
+}
+
+func getTxRWSetFromEnvelopeBytes(envBytes []byte) (*rwsetutil.TxRwSet, error) {
+	env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return nil, err
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, errors.New("not an endorser transaction")
+	}
+	respPayload, err := protoutil.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, err
+	}
+	return txRWSet, nil
+}
@@ -158,6 +158,7 @@ func (p *Provider) initPvtDataStoreProvider() error {
 	privateDataConfig := &pvtdatastorage.PrivateDataConfig{
 		PrivateDataConfig: p.initializer.Config.PrivateDataConfig,
 		StorePath:         PvtDataStorePath(p.initializer.Config.RootFSPath),
+		Encryptor:         p.initializer.PvtDataEncryptor,
 	}
 	ledgerIDs, err := p.idStore.getActiveAndInactiveLedgerIDs()
 	if err != nil {
@@ -229,6 +230,7 @@ func (p *Provider) initStateDBProvider() error {
 	stateDBConfig := &privacyenabledstate.StateDBConfig{
 		StateDBConfig: p.initializer.Config.StateDBConfig,
 		LevelDBPath:   StateDBPath(p.initializer.Config.RootFSPath),
+		Encryptor:     p.initializer.PvtDataEncryptor,
 	}
 	sysNamespaces := p.initializer.DeployedChaincodeInfoProvider.Namespaces()
 	p.dbProvider, err = privacyenabledstate.NewDBProvider(
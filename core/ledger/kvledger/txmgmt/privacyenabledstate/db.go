@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/pkg/errors"
 )
@@ -41,6 +42,9 @@ type StateDBConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.StateDBConfig and not exposed to other components.
 	LevelDBPath string
+	// Encryptor encrypts the private data values before they are stored in the stateDB.
+	// The private data is stored in plaintext if it is nil.
+	Encryptor ledger.PvtDataEncryptor
 }
 
 // DBProvider encapsulates other providers such as VersionedDBProvider and
@@ -49,6 +53,7 @@ type DBProvider struct {
 	VersionedDBProvider statedb.VersionedDBProvider
 	HealthCheckRegistry ledger.HealthCheckRegistry
 	bookkeepingProvider *bookkeeping.Provider
+	encryptor           ledger.PvtDataEncryptor
 }
 
 // NewDBProvider constructs an instance of DBProvider
@@ -77,6 +82,9 @@ func NewDBProvider(
 		HealthCheckRegistry: healthCheckRegistry,
 		bookkeepingProvider: bookkeeperProvider,
 	}
+	if stateDBConf != nil {
+		dbProvider.encryptor = stateDBConf.Encryptor
+	}
 
 	err = dbProvider.RegisterHealthChecker()
 	if err != nil {
@@ -107,7 +115,12 @@ func (p *DBProvider) GetDBHandle(id string, chInfoProvider channelInfoProvider)
 	if err != nil {
 		return nil, err
 	}
-	return NewDB(vdb, id, metadataHint)
+	db, err := NewDB(vdb, id, metadataHint)
+	if err != nil {
+		return nil, err
+	}
+	db.encryptor = p.encryptor
+	return db, nil
 }
 
 // Close closes all the VersionedDB instances and releases any resources held by VersionedDBProvider
@@ -124,12 +137,13 @@ func (p *DBProvider) Drop(ledgerid string) error {
 type DB struct {
 	statedb.VersionedDB
 	metadataHint *metadataHint
+	encryptor    ledger.PvtDataEncryptor
 }
 
 // NewDB wraps a VersionedDB instance. The public data is managed directly by the wrapped versionedDB.
 // For managing the hashed data and private data, this implementation creates separate namespaces in the wrapped db
 func NewDB(vdb statedb.VersionedDB, ledgerid string, metadataHint *metadataHint) (*DB, error) {
-	return &DB{vdb, metadataHint}, nil
+	return &DB{VersionedDB: vdb, metadataHint: metadataHint}, nil
 }
 
 // IsBulkOptimizable checks whether the underlying statedb implements statedb.BulkOptimizable
@@ -189,7 +203,11 @@ func (s *DB) GetChaincodeEventListener() cceventmgmt.ChaincodeLifecycleEventList
 
 // GetPrivateData gets the value of a private data item identified by a tuple <namespace, collection, key>
 func (s *DB) GetPrivateData(namespace, collection, key string) (*statedb.VersionedValue, error) {
-	return s.GetState(derivePvtDataNs(namespace, collection), key)
+	vv, err := s.GetState(derivePvtDataNs(namespace, collection), key)
+	if err != nil {
+		return nil, err
+	}
+	return s.decryptPvtValue(vv)
 }
 
 // GetPrivateDataHash gets the hash of the value of a private data item identified by a tuple <namespace, collection, key>
@@ -231,17 +249,34 @@ func (s *DB) GetCachedKeyHashVersion(namespace, collection string, keyHash []byt
 
 // GetPrivateDataMultipleKeys gets the values for the multiple private data items in a single call
 func (s *DB) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([]*statedb.VersionedValue, error) {
-	return s.GetStateMultipleKeys(derivePvtDataNs(namespace, collection), keys)
+	vvs, err := s.GetStateMultipleKeys(derivePvtDataNs(namespace, collection), keys)
+	if err != nil {
+		return nil, err
+	}
+	for i, vv := range vvs {
+		if vvs[i], err = s.decryptPvtValue(vv); err != nil {
+			return nil, err
+		}
+	}
+	return vvs, nil
 }
 
 // GetPrivateDataRangeScanIterator returns an iterator that contains all the key-values between given key ranges.
 // startKey is included in the results and endKey is excluded.
 func (s *DB) GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (statedb.ResultsIterator, error) {
-	return s.GetStateRangeScanIterator(derivePvtDataNs(namespace, collection), startKey, endKey)
+	itr, err := s.GetStateRangeScanIterator(derivePvtDataNs(namespace, collection), startKey, endKey)
+	if err != nil || s.encryptor == nil {
+		return itr, err
+	}
+	return &decryptingResultsItr{itr, s}, nil
 }
 
 // ExecuteQueryOnPrivateData executes the given query and returns an iterator that contains results of type specific to the underlying data store.
+// Queries are not supported when the private data is encrypted, as the values cannot be interpreted by the underlying data store.
 func (s DB) ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error) {
+	if s.encryptor != nil {
+		return nil, errors.New("queries on private data are not supported when the encryption of private data is enabled")
+	}
 	return s.ExecuteQuery(derivePvtDataNs(namespace, collection), query)
 }
 
@@ -255,7 +290,9 @@ func (s *DB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) er
 func (s *DB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error {
 	// combinedUpdates includes both updates to public db and private db, which are partitioned by a separate namespace
 	combinedUpdates := updates.PubUpdates
-	addPvtUpdates(combinedUpdates, updates.PvtUpdates)
+	if err := addPvtUpdates(combinedUpdates, updates.PvtUpdates, s.encryptor); err != nil {
+		return err
+	}
 	addHashedUpdates(combinedUpdates, updates.HashUpdates, !s.BytesKeySupported())
 	if err := s.metadataHint.setMetadataUsedFlag(updates); err != nil {
 		return err
@@ -372,14 +409,58 @@ func isHashedDataNs(namespace string) bool {
 	return strings.Contains(namespace, nsJoiner+hashDataPrefix)
 }
 
-func addPvtUpdates(pubUpdateBatch *PubUpdateBatch, pvtUpdateBatch *PvtUpdateBatch) {
+func addPvtUpdates(pubUpdateBatch *PubUpdateBatch, pvtUpdateBatch *PvtUpdateBatch, encryptor ledger.PvtDataEncryptor) error {
 	for ns, nsBatch := range pvtUpdateBatch.UpdateMap {
 		for _, coll := range nsBatch.GetCollectionNames() {
 			for key, vv := range nsBatch.GetUpdates(coll) {
+				if encryptor != nil && !vv.IsDelete() {
+					encryptedValue, err := encryptor.Encrypt(vv.Value)
+					if err != nil {
+						return errors.WithMessagef(err, "error while encrypting private data of collection [%s:%s]", ns, coll)
+					}
+					vv = &statedb.VersionedValue{Value: encryptedValue, Metadata: vv.Metadata, Version: vv.Version}
+				}
 				pubUpdateBatch.Update(derivePvtDataNs(ns, coll), key, vv)
 			}
 		}
 	}
+	return nil
+}
+
+// decryptPvtValue decrypts the value of a private data item, if the private data is encrypted
+func (s *DB) decryptPvtValue(vv *statedb.VersionedValue) (*statedb.VersionedValue, error) {
+	if vv == nil || vv.Value == nil {
+		return vv, nil
+	}
+	if s.encryptor == nil {
+		if pvtdataencryption.IsEncrypted(vv.Value) {
+			return nil, errors.New("private data is encrypted but the encryption of private data is not enabled")
+		}
+		return vv, nil
+	}
+	value, err := s.encryptor.Decrypt(vv.Value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while decrypting private data")
+	}
+	return &statedb.VersionedValue{Value: value, Metadata: vv.Metadata, Version: vv.Version}, nil
+}
+
+// decryptingResultsItr decrypts the values of the private data items returned by the underlying iterator
+type decryptingResultsItr struct {
+	statedb.ResultsIterator
+	db *DB
+}
+
+func (itr *decryptingResultsItr) Next() (*statedb.VersionedKV, error) {
+	kv, err := itr.ResultsIterator.Next()
+	if err != nil || kv == nil {
+		return kv, err
+	}
+	vv, err := itr.db.decryptPvtValue(kv.VersionedValue)
+	if err != nil {
+		return nil, err
+	}
+	return &statedb.VersionedKV{CompositeKey: kv.CompositeKey, VersionedValue: vv}, nil
 }
 
 func addHashedUpdates(pubUpdateBatch *PubUpdateBatch, hashedUpdateBatch *HashedUpdateBatch, base64Key bool) {
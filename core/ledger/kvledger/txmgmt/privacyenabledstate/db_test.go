@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -23,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
 )
@@ -255,6 +257,94 @@ func testGetStateRangeScanIterator(t *testing.T, env TestEnv) {
 	testItr(t, pvtItr4, []string{"key5", "key6"})
 }
 
+func TestPvtDataEncryption(t *testing.T) {
+	env := &LevelDBTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+
+	csp, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(t, err)
+	keyID, err := pvtdataencryption.GenerateKey(csp)
+	require.NoError(t, err)
+	encryptor, err := pvtdataencryption.New(csp, keyID)
+	require.NoError(t, err)
+	env.provider.encryptor = encryptor
+
+	ledgerID := generateLedgerID(t)
+	db := env.GetDBHandle(ledgerID)
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key2", []byte("pvt_value2"), version.NewHeight(1, 2))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key3", []byte("pvt_value3"), version.NewHeight(1, 3))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 3)))
+
+	// the updates held by the batch are left in plaintext
+	require.Equal(t, []byte("pvt_value1"), updates.PvtUpdates.Get("ns1", "coll1", "key1").Value)
+
+	// public data and hashes are stored in plaintext
+	vv, err := db.GetState("ns1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), vv.Value)
+	vv, err = db.GetPrivateDataHash("ns1", "coll1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, util.ComputeHash([]byte("pvt_value1")), vv.Value)
+
+	// private data is stored encrypted
+	vv, err = db.GetState(derivePvtDataNs("ns1", "coll1"), "key1")
+	require.NoError(t, err)
+	require.True(t, pvtdataencryption.IsEncrypted(vv.Value))
+	require.NotContains(t, string(vv.Value), "pvt_value1")
+
+	vv, err = db.GetPrivateData("ns1", "coll1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("pvt_value1"), Version: version.NewHeight(1, 1)}, vv)
+
+	vvs, err := db.GetPrivateDataMultipleKeys("ns1", "coll1", []string{"key2", "key4"})
+	require.NoError(t, err)
+	require.Equal(t,
+		[]*statedb.VersionedValue{
+			{Value: []byte("pvt_value2"), Version: version.NewHeight(1, 2)},
+			nil,
+		},
+		vvs,
+	)
+
+	itr, err := db.GetPrivateDataRangeScanIterator("ns1", "coll1", "", "")
+	require.NoError(t, err)
+	testQueryItr(t, itr, []string{"key1", "key2", "key3"}, []string{"pvt_value1"}, []string{"pvt_value2"}, []string{"pvt_value3"})
+
+	_, err = db.ExecuteQueryOnPrivateData("ns1", "coll1", `{"selector":{}}`)
+	require.EqualError(t, err, "queries on private data are not supported when the encryption of private data is enabled")
+
+	updates = NewUpdateBatch()
+	deletePvtUpdates(t, updates, "ns1", "coll1", "key3", version.NewHeight(2, 1))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 1)))
+	vv, err = db.GetPrivateData("ns1", "coll1", "key3")
+	require.NoError(t, err)
+	require.Nil(t, vv)
+
+	t.Run("encryption not enabled", func(t *testing.T) {
+		plaintextDB, err := NewDB(db.VersionedDB, ledgerID, db.metadataHint)
+		require.NoError(t, err)
+		_, err = plaintextDB.GetPrivateData("ns1", "coll1", "key1")
+		require.EqualError(t, err, "private data is encrypted but the encryption of private data is not enabled")
+	})
+
+	t.Run("data stored before the encryption was enabled", func(t *testing.T) {
+		plaintextDB, err := NewDB(db.VersionedDB, ledgerID, db.metadataHint)
+		require.NoError(t, err)
+		updates := NewUpdateBatch()
+		putPvtUpdates(t, updates, "ns1", "coll2", "key1", []byte("plaintext_value"), version.NewHeight(3, 1))
+		require.NoError(t, plaintextDB.ApplyPrivacyAwareUpdates(updates, version.NewHeight(3, 1)))
+
+		vv, err := db.GetPrivateData("ns1", "coll2", "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("plaintext_value"), vv.Value)
+	})
+}
+
 func TestQueryOnCouchDB(t *testing.T) {
 	for _, env := range testEnvs {
 		_, ok := env.(*CouchDBTestEnv)
@@ -58,8 +58,8 @@ func (env *LevelDBTestEnv) Init(t testing.TB) {
 		&disabled.Provider{},
 		&mock.HealthCheckRegistry{},
 		&StateDBConfig{
-			&ledger.StateDBConfig{},
-			dbPath,
+			StateDBConfig: &ledger.StateDBConfig{},
+			LevelDBPath:   dbPath,
 		},
 		[]string{"lscc", "_lifecycle"},
 	)
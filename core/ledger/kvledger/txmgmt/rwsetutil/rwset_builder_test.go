@@ -429,3 +429,44 @@ func TestNilOrZeroLengthByteArrayValueConvertedToDelete(t *testing.T) {
 		})
 	})
 }
+
+func TestPurgeAddedToPvtAndHashedWriteSets(t *testing.T) {
+	rwsetBuilder := NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key1", []byte("value1"))
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", "key1")
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge("ns", "coll", "key2")
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key2", []byte("value2"))
+
+	simulationResults, err := rwsetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+
+	hashedRWSet := &kvrwset.HashedRWSet{}
+	require.NoError(
+		t,
+		proto.Unmarshal(simulationResults.PubSimulationResults.NsRwset[0].CollectionHashedRwset[0].HashedRwset, hashedRWSet),
+	)
+	require.True(t, proto.Equal(
+		&kvrwset.HashedRWSet{
+			HashedWrites: []*kvrwset.KVWriteHash{
+				{KeyHash: util.ComputeStringHash("key1"), IsDelete: true, IsPurge: true},
+				{KeyHash: util.ComputeStringHash("key2"), ValueHash: util.ComputeHash([]byte("value2"))},
+			},
+		},
+		hashedRWSet,
+	))
+
+	pvtWSet := &kvrwset.KVRWSet{}
+	require.NoError(
+		t,
+		proto.Unmarshal(simulationResults.PvtSimulationResults.NsPvtRwset[0].CollectionPvtRwset[0].Rwset, pvtWSet),
+	)
+	require.True(t, proto.Equal(
+		&kvrwset.KVRWSet{
+			Writes: []*kvrwset.KVWrite{
+				{Key: "key1", IsDelete: true},
+				{Key: "key2", Value: []byte("value2")},
+			},
+		},
+		pvtWSet,
+	))
+}
@@ -249,6 +249,23 @@ func (txRwSet *TxRwSet) NumCollections() int {
 	return numColls
 }
 
+// ContainsPurges returns true if the TxRwSet contains a hashed write that purges a private data key
+func (txRwSet *TxRwSet) ContainsPurges() bool {
+	if txRwSet == nil {
+		return false
+	}
+	for _, nsRwset := range txRwSet.NsRwSets {
+		for _, collHashedRwSet := range nsRwset.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+				if hashedWrite.IsPurge {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // functions for private read-write set
 ///////////////////////////////////////////////////////////////////////////////
@@ -133,6 +133,18 @@ func TestNumCollections(t *testing.T) {
 	require.Equal(t, 4, sampleTxRwSet().NumCollections()) // sample TxRwSet
 }
 
+func TestContainsPurges(t *testing.T) {
+	var txRwSet *TxRwSet
+	require.False(t, txRwSet.ContainsPurges())         // nil TxRwSet
+	require.False(t, (&TxRwSet{}).ContainsPurges())    // empty TxRwSet
+	require.False(t, sampleTxRwSet().ContainsPurges()) // sample TxRwSet without purges
+
+	txRwSet = sampleTxRwSet()
+	hashedWrites := txRwSet.NsRwSets[1].CollHashedRwSets[0].HashedRwSet.HashedWrites
+	hashedWrites[0].IsPurge = true
+	require.True(t, txRwSet.ContainsPurges())
+}
+
 func sampleTxRwSet() *TxRwSet {
 	txRwSet := &TxRwSet{}
 	txRwSet.NsRwSets = append(txRwSet.NsRwSets, sampleNsRwSet("ns-1"))
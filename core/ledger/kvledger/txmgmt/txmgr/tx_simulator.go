@@ -118,6 +118,19 @@ func (s *txSimulator) DeletePrivateData(ns, coll, key string) error {
 	return s.SetPrivateData(ns, coll, key, nil)
 }
 
+// PurgePrivateData implements method in interface `ledger.TxSimulator`
+func (s *txSimulator) PurgePrivateData(ns, coll, key string) error {
+	if err := s.queryExecutor.validateCollName(ns, coll); err != nil {
+		return err
+	}
+	if err := s.checkWritePrecondition(key, nil); err != nil {
+		return err
+	}
+	s.writePerformed = true
+	s.rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ns, coll, key)
+	return s.checkPrivateStateMetadata(ns, coll, key)
+}
+
 // SetPrivateDataMultipleKeys implements method in interface `ledger.TxSimulator`
 func (s *txSimulator) SetPrivateDataMultipleKeys(ns, coll string, kvs map[string][]byte) error {
 	for k, v := range kvs {
@@ -1530,6 +1530,65 @@ func testTxWithPvtdataMetadata(t *testing.T, env testEnv, ns, coll string) {
 	qe.Done()
 }
 
+func TestTxWithPurgedPvtdata(t *testing.T) {
+	ledgerid, ns, coll := "testtxwithpurgedpvtdata", "ns", "coll"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns", "coll"}: 1000,
+		},
+	)
+	for _, testEnv := range testEnvs {
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testEnv.init(t, ledgerid, btlPolicy)
+		testTxWithPurgedPvtdata(t, testEnv, ns, coll)
+		testEnv.cleanup()
+	}
+}
+
+func testTxWithPurgedPvtdata(t *testing.T, env testEnv, ns, coll string) {
+	ledgerid := "testtxwithpurgedpvtdata"
+	txMgr := env.getTxMgr()
+	bg, _ := testutil.NewBlockGenerator(t, ledgerid, false)
+
+	populateCollConfigForTest(t, txMgr, []collConfigkey{{"ns", "coll"}}, version.NewHeight(1, 1))
+
+	// Simulate and commit tx1 - set values for key1 and key2
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	require.NoError(t, s1.SetPrivateData(ns, coll, "key1", []byte("value1")))
+	require.NoError(t, s1.SetPrivateData(ns, coll, "key2", []byte("value2")))
+	s1.Done()
+
+	blkAndPvtdata1, _ := prepareNextBlockForTestFromSimulator(t, bg, s1)
+	_, _, err := txMgr.ValidateAndPrepare(blkAndPvtdata1, true)
+	require.NoError(t, err)
+	require.NoError(t, txMgr.Commit())
+
+	// Simulate and commit tx2 - purge key1
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	require.NoError(t, s2.PurgePrivateData(ns, coll, "key1"))
+	s2.Done()
+
+	blkAndPvtdata2, simRes := prepareNextBlockForTestFromSimulator(t, bg, s2)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	require.NoError(t, err)
+	hashedWrites := txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedWrites
+	require.Len(t, hashedWrites, 1)
+	require.True(t, hashedWrites[0].IsPurge)
+
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata2, true)
+	require.NoError(t, err)
+	require.NoError(t, txMgr.Commit())
+
+	// Run query - key1 should be removed from both the private data and the hashes
+	qe, _ := txMgr.NewQueryExecutor("test_tx3")
+	defer qe.Done()
+	checkPvtdataTestQueryResults(t, qe, ns, coll, "key1", nil, nil)
+	checkPvtdataTestQueryResults(t, qe, ns, coll, "key2", []byte("value2"), nil)
+	hash, err := qe.GetPrivateDataHash(ns, coll, "key1")
+	require.NoError(t, err)
+	require.Nil(t, hash)
+}
+
 func prepareNextBlockForTest(t *testing.T, txMgr *LockBasedTxMgr, bg *testutil.BlockGenerator,
 	txid string, pubKVs map[string]string, pvtKVs map[string]string, isMissing bool) *ledger.BlockAndPvtData {
 	simulator, _ := txMgr.NewTxSimulator(txid)
@@ -38,6 +38,7 @@ type Initializer struct {
 	Config                          *Config
 	CustomTxProcessors              map[common.HeaderType]CustomTxProcessor
 	HashProvider                    HashProvider
+	PvtDataEncryptor                PvtDataEncryptor
 }
 
 // Config is a structure used to configure a ledger provider.
@@ -322,6 +323,9 @@ type TxSimulator interface {
 	SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error
 	// DeletePrivateData deletes the given tuple <namespace, collection, key> from private data
 	DeletePrivateData(namespace, collection, key string) error
+	// PurgePrivateData deletes the given tuple <namespace, collection, key> from private data and, once the transaction
+	// is committed, purges all the historical versions of the key from the private data held by the peer
+	PurgePrivateData(namespace, collection, key string) error
 	// SetPrivateDataMetadata sets the metadata associated with an existing key-tuple <namespace, collection, key>
 	SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error
 	// DeletePrivateDataMetadata deletes the metadata associated with an existing key-tuple <namespace, collection, key>
@@ -604,6 +608,10 @@ type ConfigHistoryRetriever interface {
 // MissingPvtDataTracker allows getting information about the private data that is not missing on the peer
 type MissingPvtDataTracker interface {
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most recent
+	// `maxBlocks` blocks, between `startBlock` and `endBlock` inclusive, which miss at least a private data of
+	// an eligible collection accepted by the filter. A nil filter accepts all collections.
+	GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlocks int, filter func(ns, coll string) bool) (MissingPvtDataInfo, error)
 }
 
 // MissingPvtDataInfo is a map of block number to MissingBlockPvtdataInfo
@@ -797,6 +805,17 @@ type HashProvider interface {
 	GetHash(opts bccsp.HashOpts) (hash.Hash, error)
 }
 
+// PvtDataEncryptor encrypts the private data values before they are persisted by the ledger components,
+// and decrypts them when they are read. The hashes of the private data are computed on the plaintext values
+// and are not affected by the encryption. A nil PvtDataEncryptor means that the private data is stored in plaintext.
+type PvtDataEncryptor interface {
+	// Encrypt returns the encrypted form of the value
+	Encrypt(value []byte) ([]byte, error)
+	// Decrypt returns the plaintext of an encrypted value. The values persisted before the encryption
+	// was enabled are returned unchanged.
+	Decrypt(value []byte) ([]byte, error)
+}
+
 // CommitNotification is sent on each block commit to the channel returned by PeerLedger.CommitNotificationsChannel().
 // TxsInfo field contains the info about individual transactions in the block in the order the transactions appear in the block
 // The transactions with a unique and non-empty txID are included in the notification
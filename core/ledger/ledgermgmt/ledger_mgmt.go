@@ -58,6 +58,7 @@ type Initializer struct {
 	HealthCheckRegistry             ledger.HealthCheckRegistry
 	Config                          *ledger.Config
 	HashProvider                    ledger.HashProvider
+	PvtDataEncryptor                ledger.PvtDataEncryptor
 	EbMetadataProvider              MetadataProvider
 }
 
@@ -79,6 +80,7 @@ func NewLedgerMgr(initializer *Initializer) *LedgerMgr {
 			Config:                          initializer.Config,
 			CustomTxProcessors:              initializer.CustomTxProcessors,
 			HashProvider:                    initializer.HashProvider,
+			PvtDataEncryptor:                initializer.PvtDataEncryptor,
 		},
 	)
 	if err != nil {
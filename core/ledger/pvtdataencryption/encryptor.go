@@ -0,0 +1,241 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("pvtdataencryption")
+
+const (
+	envelopeVersion = byte(1)
+	dataKeySize     = 32
+)
+
+// envelopePrefix marks the values encrypted by an Encryptor. A protobuf message cannot start with a nil byte,
+// so that the private data values stored before the encryption was enabled are not mistaken for envelopes.
+var envelopePrefix = []byte{0x00, 0xfe, 'p', 'v', 't', 'e', 'n', 'c'}
+
+// Encryptor implements the envelope encryption of the private data values persisted by the peer.
+// Each value is encrypted with AES-GCM using a fresh data key. The data key is in turn encrypted
+// (wrapped) with a key encryption key managed by BCCSP, and stored along with the value, together
+// with the identifier (SKI) of the key encryption key. Rotating the key encryption key only requires
+// to configure the SKI of a new key: the values encrypted with the previous keys remain readable
+// as long as these keys are available in BCCSP.
+type Encryptor struct {
+	csp   bccsp.BCCSP
+	keyID []byte
+
+	lock sync.RWMutex
+	keys map[string]bccsp.Key
+}
+
+// New returns an Encryptor that wraps the data keys of new values with the key identified by the given SKI.
+func New(csp bccsp.BCCSP, keyID []byte) (*Encryptor, error) {
+	if csp == nil {
+		return nil, errors.New("a BCCSP instance is required")
+	}
+	if len(keyID) == 0 {
+		return nil, errors.New("the identifier of the key encryption key is not set")
+	}
+	if len(keyID) > 255 {
+		return nil, errors.Errorf("the identifier of the key encryption key is too long [%d bytes]", len(keyID))
+	}
+
+	e := &Encryptor{
+		csp:   csp,
+		keyID: keyID,
+		keys:  map[string]bccsp.Key{},
+	}
+	if _, err := e.key(keyID); err != nil {
+		return nil, err
+	}
+	logger.Infof("Encryption of private data enabled with key [%x]", keyID)
+	return e, nil
+}
+
+// GenerateKey generates a new persistent key encryption key in BCCSP and returns its identifier
+func GenerateKey(csp bccsp.BCCSP) ([]byte, error) {
+	k, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: false})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed generating key encryption key")
+	}
+	return k.SKI(), nil
+}
+
+// IsEncrypted returns true if the value has been encrypted by an Encryptor
+func IsEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, envelopePrefix)
+}
+
+// Encrypt encrypts the value with a fresh data key, and returns the envelope to be persisted
+func (e *Encryptor) Encrypt(value []byte) ([]byte, error) {
+	kek, err := e.key(e.keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, errors.Wrap(err, "failed generating data key")
+	}
+	wrappedKey, err := e.csp.Encrypt(kek, dataKey, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed wrapping data key")
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed generating nonce")
+	}
+
+	header := encodeHeader(e.keyID, wrappedKey, nonce)
+	return aead.Seal(header, nonce, value, header), nil
+}
+
+// Decrypt returns the value sealed in the envelope. The values that are not envelopes, i.e. the values
+// persisted before the encryption was enabled, are returned unchanged.
+func (e *Encryptor) Decrypt(value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, wrappedKey, nonce, headerLen, err := decodeHeader(value)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := e.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	// the software BCCSP decrypts in place, so the envelope is preserved by decrypting a copy of the wrapped key
+	dataKey, err := e.csp.Decrypt(kek, append([]byte{}, wrappedKey...), &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed unwrapping data key with key [%x]", keyID)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.Errorf("invalid nonce size [%d]", len(nonce))
+	}
+	plaintext, err := aead.Open(nil, nonce, value[headerLen:], value[:headerLen])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decrypting private data value")
+	}
+	return plaintext, nil
+}
+
+// key returns the key encryption key with the given identifier
+func (e *Encryptor) key(keyID []byte) (bccsp.Key, error) {
+	e.lock.RLock()
+	k, ok := e.keys[string(keyID)]
+	e.lock.RUnlock()
+	if ok {
+		return k, nil
+	}
+
+	k, err := e.csp.GetKey(keyID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed retrieving key encryption key [%x]", keyID)
+	}
+	if !k.Symmetric() {
+		return nil, errors.Errorf("key [%x] is not a symmetric key", keyID)
+	}
+
+	e.lock.Lock()
+	e.keys[string(keyID)] = k
+	e.lock.Unlock()
+	return k, nil
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid data key")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating GCM cipher")
+	}
+	return aead, nil
+}
+
+// encodeHeader encodes the header of an envelope as
+// prefix | version | len(keyID) | keyID | len(wrappedKey) | wrappedKey | len(nonce) | nonce
+// The header is authenticated along with the encrypted value.
+func encodeHeader(keyID, wrappedKey, nonce []byte) []byte {
+	header := append([]byte{}, envelopePrefix...)
+	header = append(header, envelopeVersion, byte(len(keyID)))
+	header = append(header, keyID...)
+	header = append(header, encodeUint16(uint16(len(wrappedKey)))...)
+	header = append(header, wrappedKey...)
+	header = append(header, byte(len(nonce)))
+	return append(header, nonce...)
+}
+
+func decodeHeader(envelope []byte) (keyID, wrappedKey, nonce []byte, headerLen int, err error) {
+	r := &headerReader{b: envelope, pos: len(envelopePrefix)}
+	version := r.next(1)
+	if version == nil {
+		return nil, nil, nil, 0, errors.New("truncated private data envelope")
+	}
+	if version[0] != envelopeVersion {
+		return nil, nil, nil, 0, errors.Errorf("unsupported private data envelope version [%d]", version[0])
+	}
+	if l := r.next(1); l != nil {
+		keyID = r.next(int(l[0]))
+	}
+	if l := r.next(2); l != nil {
+		wrappedKey = r.next(int(binary.BigEndian.Uint16(l)))
+	}
+	if l := r.next(1); l != nil {
+		nonce = r.next(int(l[0]))
+	}
+	if nonce == nil || r.err {
+		return nil, nil, nil, 0, errors.New("truncated private data envelope")
+	}
+	return keyID, wrappedKey, nonce, r.pos, nil
+}
+
+func encodeUint16(n uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, n)
+	return b
+}
+
+type headerReader struct {
+	b   []byte
+	pos int
+	err bool
+}
+
+func (r *headerReader) next(n int) []byte {
+	if r.err || r.pos+n > len(r.b) {
+		r.err = true
+		return nil
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdataencryption
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCSP(t *testing.T, keystorePath string) bccsp.BCCSP {
+	csp, err := sw.NewDefaultSecurityLevel(keystorePath)
+	require.NoError(t, err)
+	return csp
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	csp := newTestCSP(t, t.TempDir())
+	keyID, err := GenerateKey(csp)
+	require.NoError(t, err)
+	e, err := New(csp, keyID)
+	require.NoError(t, err)
+
+	for _, value := range [][]byte{[]byte("private value"), {}, {0x00, 0x0a, 0x01}} {
+		encrypted, err := e.Encrypt(value)
+		require.NoError(t, err)
+		require.True(t, IsEncrypted(encrypted))
+		if len(value) > 0 {
+			require.NotContains(t, string(encrypted), string(value))
+		}
+
+		decrypted, err := e.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, string(value), string(decrypted))
+	}
+
+	t.Run("fresh data key for each value", func(t *testing.T) {
+		encrypted1, err := e.Encrypt([]byte("value"))
+		require.NoError(t, err)
+		encrypted2, err := e.Encrypt([]byte("value"))
+		require.NoError(t, err)
+		require.NotEqual(t, encrypted1, encrypted2)
+	})
+
+	t.Run("plaintext values are returned unchanged", func(t *testing.T) {
+		decrypted, err := e.Decrypt([]byte("written before the encryption was enabled"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("written before the encryption was enabled"), decrypted)
+	})
+
+	t.Run("tampered value", func(t *testing.T) {
+		encrypted, err := e.Encrypt([]byte("value"))
+		require.NoError(t, err)
+		encrypted[len(encrypted)-1] ^= 0xff
+		_, err = e.Decrypt(encrypted)
+		require.EqualError(t, err, "failed decrypting private data value: cipher: message authentication failed")
+	})
+
+	t.Run("truncated value", func(t *testing.T) {
+		encrypted, err := e.Encrypt([]byte("value"))
+		require.NoError(t, err)
+		_, err = e.Decrypt(encrypted[:len(envelopePrefix)+3])
+		require.EqualError(t, err, "truncated private data envelope")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		encrypted, err := e.Encrypt([]byte("value"))
+		require.NoError(t, err)
+		encrypted[len(envelopePrefix)] = 2
+		_, err = e.Decrypt(encrypted)
+		require.EqualError(t, err, "unsupported private data envelope version [2]")
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	keystorePath := t.TempDir()
+	csp := newTestCSP(t, keystorePath)
+	oldKeyID, err := GenerateKey(csp)
+	require.NoError(t, err)
+	oldEncryptor, err := New(csp, oldKeyID)
+	require.NoError(t, err)
+	encryptedWithOldKey, err := oldEncryptor.Encrypt([]byte("old value"))
+	require.NoError(t, err)
+
+	// restart with a new key, the keys are retrieved from the keystore
+	csp = newTestCSP(t, keystorePath)
+	newKeyID, err := GenerateKey(csp)
+	require.NoError(t, err)
+	newEncryptor, err := New(csp, newKeyID)
+	require.NoError(t, err)
+	encryptedWithNewKey, err := newEncryptor.Encrypt([]byte("new value"))
+	require.NoError(t, err)
+
+	decrypted, err := newEncryptor.Decrypt(encryptedWithOldKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("old value"), decrypted)
+	decrypted, err = newEncryptor.Decrypt(encryptedWithNewKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("new value"), decrypted)
+
+	// the values encrypted with a key that is no longer available cannot be decrypted
+	otherCSP := newTestCSP(t, t.TempDir())
+	otherKeyID, err := GenerateKey(otherCSP)
+	require.NoError(t, err)
+	otherEncryptor, err := New(otherCSP, otherKeyID)
+	require.NoError(t, err)
+	_, err = otherEncryptor.Decrypt(encryptedWithOldKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed retrieving key encryption key")
+}
+
+func TestNewErrors(t *testing.T) {
+	csp := newTestCSP(t, t.TempDir())
+
+	_, err := New(nil, []byte("ski"))
+	require.EqualError(t, err, "a BCCSP instance is required")
+
+	_, err = New(csp, nil)
+	require.EqualError(t, err, "the identifier of the key encryption key is not set")
+
+	_, err = New(csp, make([]byte, 256))
+	require.EqualError(t, err, "the identifier of the key encryption key is too long [256 bytes]")
+
+	_, err = New(csp, []byte("unknown"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed retrieving key encryption key [756e6b6e6f776e]")
+
+	ecKey, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	_, err = New(csp, ecKey.SKI())
+	require.EqualError(t, err, fmt.Sprintf("key [%x] is not a symmetric key", ecKey.SKI()))
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatagrpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/pkg/errors"
+)
+
+// exportFileFormat is the first byte of an export file. An export file contains a PvtDataExportHeader, followed
+// by the records, each one preceded by a non-zero marker, and ends with a zero marker followed by the number of
+// records, so that a truncated file is detected.
+const exportFileFormat byte = 1
+
+// ExportFileWriter writes the records of a private data export to a file
+type ExportFileWriter struct {
+	fileWriter *snapshot.FileWriter
+	count      uint64
+}
+
+// CreateExportFile creates a new export file, and writes the given header to it. This function returns an error
+// if the file already exists.
+func CreateExportFile(filePath string, header *PvtDataExportHeader) (*ExportFileWriter, error) {
+	fileWriter, err := snapshot.CreateFile(filePath, exportFileFormat, func() (hash.Hash, error) {
+		return sha256.New(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := fileWriter.EncodeProtoMessage(header); err != nil {
+		fileWriter.Close()
+		return nil, err
+	}
+	return &ExportFileWriter{fileWriter: fileWriter}, nil
+}
+
+// Write appends a record to the export file
+func (w *ExportFileWriter) Write(record *PvtDataRecord) error {
+	if err := w.fileWriter.EncodeUVarint(1); err != nil {
+		return err
+	}
+	if err := w.fileWriter.EncodeProtoMessage(record); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Count returns the number of records written so far
+func (w *ExportFileWriter) Count() uint64 {
+	return w.count
+}
+
+// Done completes and closes the export file, and returns the SHA256 hash of its content
+func (w *ExportFileWriter) Done() ([]byte, error) {
+	if err := w.fileWriter.EncodeUVarint(0); err != nil {
+		return nil, err
+	}
+	if err := w.fileWriter.EncodeUVarint(w.count); err != nil {
+		return nil, err
+	}
+	return w.fileWriter.Done()
+}
+
+// Close closes the export file, if not already done. It is intended to abandon the export on error.
+func (w *ExportFileWriter) Close() error {
+	return w.fileWriter.Close()
+}
+
+// ExportFileReader reads the records of a private data export from a file
+type ExportFileReader struct {
+	fileReader *snapshot.FileReader
+	count      uint64
+	done       bool
+}
+
+// OpenExportFile opens an export file, and returns a reader of its records along with its header
+func OpenExportFile(filePath string) (*ExportFileReader, *PvtDataExportHeader, error) {
+	fileReader, err := snapshot.OpenFile(filePath, exportFileFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := &PvtDataExportHeader{}
+	if err := fileReader.DecodeProtoMessage(header); err != nil {
+		fileReader.Close()
+		return nil, nil, errors.WithMessage(err, "failed to read the header of the export file")
+	}
+	return &ExportFileReader{fileReader: fileReader}, header, nil
+}
+
+// Next returns the next record of the export file, or nil once all the records have been read. It returns an
+// error if the file is truncated or if the hash of a record doesn't match its write set.
+func (r *ExportFileReader) Next() (*PvtDataRecord, error) {
+	if r.done {
+		return nil, nil
+	}
+	marker, err := r.fileReader.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	if marker == 0 {
+		count, err := r.fileReader.DecodeUVarInt()
+		if err != nil {
+			return nil, err
+		}
+		if count != r.count {
+			return nil, errors.Errorf("the export file contains %d records, while %d records were expected", r.count, count)
+		}
+		r.done = true
+		return nil, nil
+	}
+
+	record := &PvtDataRecord{}
+	if err := r.fileReader.DecodeProtoMessage(record); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(util.ComputeSHA256(record.Rwset), record.RwsetHash) {
+		return nil, errors.Errorf("the hash of the record of block %d, transaction %d doesn't match its write set", record.BlockNum, record.TxNum)
+	}
+	r.count++
+	return record, nil
+}
+
+// Close closes the export file
+func (r *ExportFileReader) Close() error {
+	return r.fileReader.Close()
+}
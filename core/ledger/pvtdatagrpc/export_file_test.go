@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatagrpc
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFile(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "pvtdatagrpc")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	header := &PvtDataExportHeader{
+		ChannelId:      "testchannel",
+		ChaincodeName:  "cc",
+		CollectionName: "coll",
+		StartBlock:     1,
+		EndBlock:       10,
+		StateOnly:      true,
+	}
+	var records []*PvtDataRecord
+	for i := uint64(0); i < 5; i++ {
+		rwset := []byte{byte(i)}
+		records = append(records, &PvtDataRecord{
+			BlockNum:       i,
+			TxNum:          i,
+			ChaincodeName:  "cc",
+			CollectionName: "coll",
+			Rwset:          rwset,
+			RwsetHash:      util.ComputeSHA256(rwset),
+		})
+	}
+
+	filePath := filepath.Join(testDir, "export")
+	w, err := CreateExportFile(filePath, header)
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, w.Write(record))
+	}
+	require.Equal(t, uint64(5), w.Count())
+	hash, err := w.Done()
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	expectedHash := sha256.Sum256(content)
+	require.Equal(t, expectedHash[:], hash)
+
+	_, err = CreateExportFile(filePath, header)
+	require.Contains(t, err.Error(), "error while creating the snapshot file")
+
+	readAll := func(filePath string) (*PvtDataExportHeader, []*PvtDataRecord, error) {
+		r, header, err := OpenExportFile(filePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer r.Close()
+		var records []*PvtDataRecord
+		for {
+			record, err := r.Next()
+			if err != nil {
+				return nil, nil, err
+			}
+			if record == nil {
+				return header, records, nil
+			}
+			records = append(records, record)
+		}
+	}
+
+	t.Run("read", func(t *testing.T) {
+		readHeader, readRecords, err := readAll(filePath)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(header, readHeader))
+		require.Len(t, readRecords, len(records))
+		for i := range records {
+			require.True(t, proto.Equal(records[i], readRecords[i]))
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, size := range []int{len(content) - 1, len(content) - 2, len(content) - 10} {
+			truncatedPath := filepath.Join(testDir, "truncated")
+			require.NoError(t, ioutil.WriteFile(truncatedPath, content[:size], 0o644))
+			_, _, err := readAll(truncatedPath)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "EOF")
+			require.NoError(t, os.Remove(truncatedPath))
+		}
+	})
+
+	t.Run("corrupted record", func(t *testing.T) {
+		corruptedPath := filepath.Join(testDir, "corrupted")
+		w, err := CreateExportFile(corruptedPath, header)
+		require.NoError(t, err)
+		require.NoError(t, w.Write(&PvtDataRecord{BlockNum: 1, TxNum: 2, Rwset: []byte("rwset"), RwsetHash: []byte("hash")}))
+		_, err = w.Done()
+		require.NoError(t, err)
+
+		_, _, err = readAll(corruptedPath)
+		require.EqualError(t, err, "the hash of the record of block 1, transaction 2 doesn't match its write set")
+	})
+
+	t.Run("wrong format", func(t *testing.T) {
+		wrongPath := filepath.Join(testDir, "wrong")
+		require.NoError(t, ioutil.WriteFile(wrongPath, []byte{2, 0}, 0o644))
+		_, _, err := OpenExportFile(wrongPath)
+		require.EqualError(t, err, "unexpected data format: 2")
+	})
+}
@@ -0,0 +1,681 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: core/ledger/pvtdatagrpc/pvtdata.proto
+
+package pvtdatagrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedPvtDataRequest contains a serialized PvtDataRequest message, and a
+// digital signature for the serialized request message.
+type SignedPvtDataRequest struct {
+	// Serialized PvtDataRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedPvtDataRequest) Reset()         { *m = SignedPvtDataRequest{} }
+func (m *SignedPvtDataRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedPvtDataRequest) ProtoMessage()    {}
+func (*SignedPvtDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf7bd49253c19217, []int{0}
+}
+
+func (m *SignedPvtDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedPvtDataRequest.Unmarshal(m, b)
+}
+func (m *SignedPvtDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedPvtDataRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedPvtDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedPvtDataRequest.Merge(m, src)
+}
+func (m *SignedPvtDataRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedPvtDataRequest.Size(m)
+}
+func (m *SignedPvtDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedPvtDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedPvtDataRequest proto.InternalMessageInfo
+
+func (m *SignedPvtDataRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedPvtDataRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PvtDataRequest identifies the channel and, for Export, the collection and
+// the range of blocks to export or, for Import, the records to import.
+type PvtDataRequest struct {
+	// The signature header that contains creator identity and nonce.
+	SignatureHeader *common.SignatureHeader `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	// The name of the channel.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The name of the chaincode of the collection to export.
+	ChaincodeName string `protobuf:"bytes,3,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	// The name of the collection to export.
+	CollectionName string `protobuf:"bytes,4,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// The first block to export.
+	StartBlock uint64 `protobuf:"varint,5,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	// The last block to export. Defaults to the last committed block if zero.
+	EndBlock uint64 `protobuf:"varint,6,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	// Whether to export only the records that hold the current value of at
+	// least one of the keys they write, instead of the whole history.
+	StateOnly bool `protobuf:"varint,7,opt,name=state_only,json=stateOnly,proto3" json:"state_only,omitempty"`
+	// The records to import.
+	Records              []*PvtDataRecord `protobuf:"bytes,8,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PvtDataRequest) Reset()         { *m = PvtDataRequest{} }
+func (m *PvtDataRequest) String() string { return proto.CompactTextString(m) }
+func (*PvtDataRequest) ProtoMessage()    {}
+func (*PvtDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf7bd49253c19217, []int{1}
+}
+
+func (m *PvtDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataRequest.Unmarshal(m, b)
+}
+func (m *PvtDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataRequest.Marshal(b, m, deterministic)
+}
+func (m *PvtDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataRequest.Merge(m, src)
+}
+func (m *PvtDataRequest) XXX_Size() int {
+	return xxx_messageInfo_PvtDataRequest.Size(m)
+}
+func (m *PvtDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataRequest proto.InternalMessageInfo
+
+func (m *PvtDataRequest) GetSignatureHeader() *common.SignatureHeader {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *PvtDataRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PvtDataRequest) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *PvtDataRequest) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *PvtDataRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *PvtDataRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *PvtDataRequest) GetStateOnly() bool {
+	if m != nil {
+		return m.StateOnly
+	}
+	return false
+}
+
+func (m *PvtDataRequest) GetRecords() []*PvtDataRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// PvtDataRecord is the private write set of a collection written by a
+// transaction.
+type PvtDataRecord struct {
+	// The number of the block of the transaction.
+	BlockNum uint64 `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	// The position of the transaction in the block.
+	TxNum          uint64 `protobuf:"varint,2,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	ChaincodeName  string `protobuf:"bytes,3,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	CollectionName string `protobuf:"bytes,4,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// The serialized KVRWSet of the collection.
+	Rwset []byte `protobuf:"bytes,5,opt,name=rwset,proto3" json:"rwset,omitempty"`
+	// The SHA256 hash of rwset, which is committed in the transaction unless
+	// some of the keys of the write set have since been purged.
+	RwsetHash            []byte   `protobuf:"bytes,6,opt,name=rwset_hash,json=rwsetHash,proto3" json:"rwset_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataRecord) Reset()         { *m = PvtDataRecord{} }
+func (m *PvtDataRecord) String() string { return proto.CompactTextString(m) }
+func (*PvtDataRecord) ProtoMessage()    {}
+func (*PvtDataRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf7bd49253c19217, []int{2}
+}
+
+func (m *PvtDataRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataRecord.Unmarshal(m, b)
+}
+func (m *PvtDataRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataRecord.Marshal(b, m, deterministic)
+}
+func (m *PvtDataRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataRecord.Merge(m, src)
+}
+func (m *PvtDataRecord) XXX_Size() int {
+	return xxx_messageInfo_PvtDataRecord.Size(m)
+}
+func (m *PvtDataRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataRecord proto.InternalMessageInfo
+
+func (m *PvtDataRecord) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *PvtDataRecord) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *PvtDataRecord) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *PvtDataRecord) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *PvtDataRecord) GetRwset() []byte {
+	if m != nil {
+		return m.Rwset
+	}
+	return nil
+}
+
+func (m *PvtDataRecord) GetRwsetHash() []byte {
+	if m != nil {
+		return m.RwsetHash
+	}
+	return nil
+}
+
+// PvtDataExportHeader is the first message of an export file, and is followed
+// by the exported records.
+type PvtDataExportHeader struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ChaincodeName        string   `protobuf:"bytes,2,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	CollectionName       string   `protobuf:"bytes,3,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	StartBlock           uint64   `protobuf:"varint,4,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64   `protobuf:"varint,5,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	StateOnly            bool     `protobuf:"varint,6,opt,name=state_only,json=stateOnly,proto3" json:"state_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataExportHeader) Reset()         { *m = PvtDataExportHeader{} }
+func (m *PvtDataExportHeader) String() string { return proto.CompactTextString(m) }
+func (*PvtDataExportHeader) ProtoMessage()    {}
+func (*PvtDataExportHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf7bd49253c19217, []int{3}
+}
+
+func (m *PvtDataExportHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataExportHeader.Unmarshal(m, b)
+}
+func (m *PvtDataExportHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataExportHeader.Marshal(b, m, deterministic)
+}
+func (m *PvtDataExportHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataExportHeader.Merge(m, src)
+}
+func (m *PvtDataExportHeader) XXX_Size() int {
+	return xxx_messageInfo_PvtDataExportHeader.Size(m)
+}
+func (m *PvtDataExportHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataExportHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataExportHeader proto.InternalMessageInfo
+
+func (m *PvtDataExportHeader) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PvtDataExportHeader) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *PvtDataExportHeader) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *PvtDataExportHeader) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *PvtDataExportHeader) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *PvtDataExportHeader) GetStateOnly() bool {
+	if m != nil {
+		return m.StateOnly
+	}
+	return false
+}
+
+// ImportResponse reports the outcome of an import.
+type ImportResponse struct {
+	// The number of records that were committed.
+	Imported uint64 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	// The records that were rejected.
+	Rejected             []*RejectedRecord `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ImportResponse) Reset()         { *m = ImportResponse{} }
+func (m *ImportResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportResponse) ProtoMessage()    {}
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf7bd49253c19217, []int{4}
+}
+
+func (m *ImportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportResponse.Unmarshal(m, b)
+}
+func (m *ImportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportResponse.Merge(m, src)
+}
+func (m *ImportResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportResponse.Size(m)
+}
+func (m *ImportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportResponse proto.InternalMessageInfo
+
+func (m *ImportResponse) GetImported() uint64 {
+	if m != nil {
+		return m.Imported
+	}
+	return 0
+}
+
+func (m *ImportResponse) GetRejected() []*RejectedRecord {
+	if m != nil {
+		return m.Rejected
+	}
+	return nil
+}
+
+// RejectedRecord identifies a record that was rejected, either because the
+// transaction didn't write to the collection or because the record doesn't
+// match the hash committed in the block.
+type RejectedRecord struct {
+	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxNum                uint64   `protobuf:"varint,2,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	ChaincodeName        string   `protobuf:"bytes,3,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	CollectionName       string   `protobuf:"bytes,4,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Reason               string   `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectedRecord) Reset()         { *m = RejectedRecord{} }
+func (m *RejectedRecord) String() string { return proto.CompactTextString(m) }
+func (*RejectedRecord) ProtoMessage()    {}
+func (*RejectedRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf7bd49253c19217, []int{5}
+}
+
+func (m *RejectedRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectedRecord.Unmarshal(m, b)
+}
+func (m *RejectedRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectedRecord.Marshal(b, m, deterministic)
+}
+func (m *RejectedRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectedRecord.Merge(m, src)
+}
+func (m *RejectedRecord) XXX_Size() int {
+	return xxx_messageInfo_RejectedRecord.Size(m)
+}
+func (m *RejectedRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectedRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectedRecord proto.InternalMessageInfo
+
+func (m *RejectedRecord) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *RejectedRecord) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *RejectedRecord) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *RejectedRecord) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *RejectedRecord) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SignedPvtDataRequest)(nil), "pvtdatagrpc.SignedPvtDataRequest")
+	proto.RegisterType((*PvtDataRequest)(nil), "pvtdatagrpc.PvtDataRequest")
+	proto.RegisterType((*PvtDataRecord)(nil), "pvtdatagrpc.PvtDataRecord")
+	proto.RegisterType((*PvtDataExportHeader)(nil), "pvtdatagrpc.PvtDataExportHeader")
+	proto.RegisterType((*ImportResponse)(nil), "pvtdatagrpc.ImportResponse")
+	proto.RegisterType((*RejectedRecord)(nil), "pvtdatagrpc.RejectedRecord")
+}
+
+func init() {
+	proto.RegisterFile("core/ledger/pvtdatagrpc/pvtdata.proto", fileDescriptor_bf7bd49253c19217)
+}
+
+var fileDescriptor_bf7bd49253c19217 = []byte{
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x95, 0xd3, 0xc4, 0xb5, 0x6f, 0xda, 0x14, 0x4d, 0x0b, 0x58, 0x29, 0x15, 0xc1, 0x52, 0x45,
+	0x56, 0x09, 0x2a, 0xa0, 0xee, 0x2b, 0x90, 0x9a, 0x4d, 0x40, 0xd3, 0x1d, 0x1b, 0x6b, 0x32, 0xbe,
+	0xc4, 0x06, 0x7b, 0xc6, 0x8c, 0x27, 0x25, 0xf9, 0x19, 0xbe, 0x80, 0x0f, 0xe1, 0x0b, 0xd8, 0xf3,
+	0x27, 0xc8, 0x63, 0x3b, 0xad, 0xab, 0x12, 0xb2, 0x60, 0xc1, 0xca, 0x73, 0xcf, 0x39, 0x9a, 0xc7,
+	0x39, 0xd7, 0x17, 0x4e, 0xb9, 0x54, 0x38, 0x4e, 0x30, 0x9c, 0xa3, 0x1a, 0x67, 0xd7, 0x3a, 0x64,
+	0x9a, 0xcd, 0x55, 0xc6, 0xeb, 0xf5, 0x28, 0x53, 0x52, 0x4b, 0xd2, 0xbd, 0x45, 0xf5, 0x0f, 0xb9,
+	0x4c, 0x53, 0x29, 0xc6, 0xe5, 0xa7, 0x54, 0xf8, 0x53, 0x38, 0xba, 0x8a, 0xe7, 0x02, 0xc3, 0xf7,
+	0xd7, 0xfa, 0x0d, 0xd3, 0x8c, 0xe2, 0x97, 0x05, 0xe6, 0x9a, 0x78, 0xb0, 0xab, 0xca, 0xa5, 0x67,
+	0x0d, 0xac, 0xe1, 0x1e, 0xad, 0x4b, 0xf2, 0x04, 0xdc, 0x3c, 0x9e, 0x0b, 0xa6, 0x17, 0x0a, 0xbd,
+	0x96, 0xe1, 0x6e, 0x00, 0xff, 0x67, 0x0b, 0x7a, 0x77, 0xb6, 0xba, 0x80, 0x07, 0x6b, 0x3e, 0x88,
+	0x90, 0x85, 0xa8, 0xcc, 0x9e, 0xdd, 0xb3, 0xc7, 0xa3, 0xea, 0x2e, 0x57, 0x35, 0x7f, 0x69, 0x68,
+	0x7a, 0x90, 0x37, 0x01, 0x72, 0x02, 0xc0, 0x23, 0x26, 0x04, 0x26, 0x41, 0x1c, 0x9a, 0x53, 0x5d,
+	0xea, 0x56, 0xc8, 0x24, 0x24, 0xa7, 0xd0, 0xe3, 0x11, 0x8b, 0x05, 0x97, 0x21, 0x06, 0x82, 0xa5,
+	0xe8, 0xed, 0x18, 0xc9, 0xfe, 0x1a, 0x9d, 0xb2, 0x14, 0xc9, 0x73, 0x38, 0xe0, 0x32, 0x49, 0x90,
+	0xeb, 0x58, 0x8a, 0x52, 0xd7, 0x36, 0xba, 0xde, 0x0d, 0x6c, 0x84, 0x4f, 0xa1, 0x9b, 0x6b, 0xa6,
+	0x74, 0x30, 0x4b, 0x24, 0xff, 0xec, 0x75, 0x06, 0xd6, 0xb0, 0x4d, 0xc1, 0x40, 0x17, 0x05, 0x42,
+	0x8e, 0xc1, 0x45, 0x11, 0x56, 0xb4, 0x6d, 0x68, 0x07, 0x45, 0x58, 0x92, 0x27, 0x50, 0x48, 0x35,
+	0x06, 0x52, 0x24, 0x2b, 0x6f, 0x77, 0x60, 0x0d, 0x1d, 0xea, 0x1a, 0xe4, 0x9d, 0x48, 0x56, 0xe4,
+	0x55, 0x61, 0x2d, 0x97, 0x2a, 0xcc, 0x3d, 0x67, 0xb0, 0x33, 0xec, 0x9e, 0xf5, 0x47, 0xb7, 0x62,
+	0x1a, 0xad, 0xdd, 0x2b, 0x24, 0xb4, 0x96, 0xfa, 0x3f, 0x2c, 0xd8, 0x6f, 0x50, 0xc5, 0x1d, 0xcc,
+	0xf9, 0x81, 0x58, 0xa4, 0xc6, 0xd0, 0x36, 0x75, 0x0c, 0x30, 0x5d, 0xa4, 0xe4, 0x21, 0xd8, 0x7a,
+	0x69, 0x98, 0x96, 0x61, 0x3a, 0x7a, 0x59, 0xc0, 0xff, 0xda, 0xa8, 0x23, 0xe8, 0xa8, 0xaf, 0x39,
+	0x6a, 0x63, 0xd1, 0x1e, 0x2d, 0x8b, 0xc2, 0x00, 0xb3, 0x08, 0x22, 0x96, 0x47, 0xc6, 0x9e, 0x3d,
+	0xea, 0x1a, 0xe4, 0x92, 0xe5, 0x91, 0xff, 0xcb, 0x82, 0xc3, 0xea, 0x29, 0x6f, 0x97, 0x99, 0x54,
+	0xfa, 0xde, 0x90, 0xad, 0xbf, 0x87, 0xdc, 0xda, 0xf2, 0xee, 0x3b, 0xdb, 0x84, 0xdc, 0xde, 0x1c,
+	0x72, 0x67, 0x63, 0xc8, 0xf6, 0x9d, 0x90, 0x7d, 0x84, 0xde, 0x24, 0x2d, 0xde, 0x46, 0x31, 0xcf,
+	0xa4, 0xc8, 0x91, 0xf4, 0xc1, 0x89, 0x0d, 0x82, 0x61, 0x9d, 0x56, 0x5d, 0x93, 0x73, 0x70, 0x14,
+	0x7e, 0x42, 0x5e, 0x70, 0x2d, 0xd3, 0x13, 0xc7, 0x8d, 0x9e, 0xa0, 0x15, 0x59, 0x35, 0xc5, 0x5a,
+	0xec, 0x7f, 0xb7, 0xa0, 0xd7, 0x24, 0xff, 0x8b, 0xb6, 0x78, 0x04, 0xb6, 0x42, 0x96, 0x4b, 0x61,
+	0x6c, 0x73, 0x69, 0x55, 0x9d, 0x7d, 0xb3, 0x60, 0xb7, 0x4a, 0x9e, 0x4c, 0xc0, 0x2e, 0xd3, 0x27,
+	0xcf, 0x1a, 0x6f, 0xbd, 0x6f, 0x1c, 0xf5, 0x37, 0xfc, 0x22, 0x2f, 0x2c, 0x72, 0x09, 0xf6, 0x24,
+	0xdd, 0x76, 0xab, 0xa6, 0xb3, 0xcd, 0x90, 0x2e, 0xce, 0x3f, 0xbc, 0x9e, 0xc7, 0x3a, 0x5a, 0xcc,
+	0x8a, 0xc9, 0x34, 0x8e, 0x56, 0x19, 0xaa, 0x6a, 0xc8, 0x7e, 0x64, 0x33, 0x15, 0xf3, 0xf1, 0x1f,
+	0xe6, 0xee, 0xcc, 0x36, 0xe3, 0xf4, 0xe5, 0xef, 0x01, 0x00, 0x55, 0x60, 0x49, 0x3c, 0x99, 0x05,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PvtDataClient is the client API for PvtData service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PvtDataClient interface {
+	// Export streams the private data records of a collection committed in a
+	// range of blocks.
+	Export(ctx context.Context, in *SignedPvtDataRequest, opts ...grpc.CallOption) (PvtData_ExportClient, error)
+	// Import commits the private data records of the request as reconciled
+	// private data. The records are verified against the hashes committed in
+	// the blocks, and the records that don't match are rejected.
+	Import(ctx context.Context, in *SignedPvtDataRequest, opts ...grpc.CallOption) (*ImportResponse, error)
+}
+
+type pvtDataClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPvtDataClient(cc grpc.ClientConnInterface) PvtDataClient {
+	return &pvtDataClient{cc}
+}
+
+func (c *pvtDataClient) Export(ctx context.Context, in *SignedPvtDataRequest, opts ...grpc.CallOption) (PvtData_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PvtData_serviceDesc.Streams[0], "/pvtdatagrpc.PvtData/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pvtDataExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PvtData_ExportClient interface {
+	Recv() (*PvtDataRecord, error)
+	grpc.ClientStream
+}
+
+type pvtDataExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *pvtDataExportClient) Recv() (*PvtDataRecord, error) {
+	m := new(PvtDataRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pvtDataClient) Import(ctx context.Context, in *SignedPvtDataRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, "/pvtdatagrpc.PvtData/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PvtDataServer is the server API for PvtData service.
+type PvtDataServer interface {
+	// Export streams the private data records of a collection committed in a
+	// range of blocks.
+	Export(*SignedPvtDataRequest, PvtData_ExportServer) error
+	// Import commits the private data records of the request as reconciled
+	// private data. The records are verified against the hashes committed in
+	// the blocks, and the records that don't match are rejected.
+	Import(context.Context, *SignedPvtDataRequest) (*ImportResponse, error)
+}
+
+// UnimplementedPvtDataServer can be embedded to have forward compatible implementations.
+type UnimplementedPvtDataServer struct {
+}
+
+func (*UnimplementedPvtDataServer) Export(req *SignedPvtDataRequest, srv PvtData_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (*UnimplementedPvtDataServer) Import(ctx context.Context, req *SignedPvtDataRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+
+func RegisterPvtDataServer(s *grpc.Server, srv PvtDataServer) {
+	s.RegisterService(&_PvtData_serviceDesc, srv)
+}
+
+func _PvtData_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedPvtDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PvtDataServer).Export(m, &pvtDataExportServer{stream})
+}
+
+type PvtData_ExportServer interface {
+	Send(*PvtDataRecord) error
+	grpc.ServerStream
+}
+
+type pvtDataExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *pvtDataExportServer) Send(m *PvtDataRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PvtData_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedPvtDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PvtDataServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pvtdatagrpc.PvtData/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PvtDataServer).Import(ctx, req.(*SignedPvtDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PvtData_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pvtdatagrpc.PvtData",
+	HandlerType: (*PvtDataServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Import",
+			Handler:    _PvtData_Import_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _PvtData_Export_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "core/ledger/pvtdatagrpc/pvtdata.proto",
+}
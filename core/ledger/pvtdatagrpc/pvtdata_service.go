@@ -0,0 +1,418 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatagrpc
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("pvtdatagrpc")
+
+const (
+	rejectedNotWritten   = "the transaction didn't write to the collection"
+	rejectedHashMismatch = "the write set doesn't match the hash committed in the block"
+)
+
+// PvtDataService implements the PvtDataServer grpc interface
+type PvtDataService struct {
+	LedgerGetter LedgerGetter
+	ACLProvider  ACLProvider
+}
+
+// LedgerGetter gets the PeerLedger associated with a channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ACLProvider checks ACL for a channelless resource
+type ACLProvider interface {
+	CheckACLNoChannel(resName string, idinfo interface{}) error
+}
+
+// Export streams the private data records of a collection committed in a range of blocks. When only the
+// state is exported, the records are visited in decreasing order of commit, and a record is exported if it
+// holds the current value of a key that isn't written by a more recent record of the range.
+func (s *PvtDataService) Export(signedRequest *SignedPvtDataRequest, stream PvtData_ExportServer) error {
+	request, lgr, err := s.authorize(resources.PvtData_export, signedRequest)
+	if err != nil {
+		return err
+	}
+
+	if request.ChaincodeName == "" || request.CollectionName == "" {
+		return errors.New("missing chaincode name or collection name")
+	}
+
+	bcInfo, err := lgr.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	lastBlock := bcInfo.Height - 1
+	endBlock := request.EndBlock
+	if endBlock == 0 {
+		endBlock = lastBlock
+	}
+	if endBlock > lastBlock {
+		return errors.Errorf("end block %d is beyond the last committed block %d", endBlock, lastBlock)
+	}
+	if endBlock < request.StartBlock {
+		return errors.Errorf("end block %d is lower than start block %d", endBlock, request.StartBlock)
+	}
+
+	filter := ledger.NewPvtNsCollFilter()
+	filter.Add(request.ChaincodeName, request.CollectionName)
+
+	var exported int
+	exportBlock := func(blockNum uint64, selector *currentStateSelector) error {
+		records, err := s.blockRecords(lgr, blockNum, filter)
+		if err != nil {
+			return err
+		}
+		for i := range records {
+			record := records[i]
+			if selector != nil {
+				// the transactions of the block are visited in decreasing order as well
+				record = records[len(records)-1-i]
+				selected, err := selector.selectRecord(record)
+				if err != nil {
+					return err
+				}
+				if !selected {
+					continue
+				}
+			}
+			if err := stream.Send(record); err != nil {
+				return err
+			}
+			exported++
+		}
+		return nil
+	}
+
+	if !request.StateOnly {
+		for blockNum := request.StartBlock; blockNum <= endBlock; blockNum++ {
+			if err := exportBlock(blockNum, nil); err != nil {
+				return err
+			}
+		}
+	} else {
+		selector := &currentStateSelector{lgr: lgr, visitedKeys: map[string]struct{}{}}
+		for blockNum := endBlock; ; blockNum-- {
+			if err := exportBlock(blockNum, selector); err != nil {
+				return err
+			}
+			if blockNum == request.StartBlock {
+				break
+			}
+		}
+	}
+
+	logger.Infof("Exported %d private data records of collection [%s:%s] in blocks [%d - %d] of channel [%s]",
+		exported, request.ChaincodeName, request.CollectionName, request.StartBlock, endBlock, request.ChannelId)
+	return nil
+}
+
+// Import commits the private data records of the request as reconciled private data
+func (s *PvtDataService) Import(ctx context.Context, signedRequest *SignedPvtDataRequest) (*ImportResponse, error) {
+	request, lgr, err := s.authorize(resources.PvtData_import, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.Records) == 0 {
+		return nil, errors.New("no records to import")
+	}
+
+	bcInfo, err := lgr.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	var lastBlockInBootSnapshot uint64
+	if bcInfo.BootstrappingSnapshotInfo != nil {
+		lastBlockInBootSnapshot = bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot
+	}
+
+	response := &ImportResponse{}
+	recordsByBlock := map[uint64][]*PvtDataRecord{}
+	for _, record := range request.Records {
+		if record.ChaincodeName == "" || record.CollectionName == "" {
+			return nil, errors.Errorf("record of block %d, transaction %d is missing its chaincode name or collection name", record.BlockNum, record.TxNum)
+		}
+		if record.BlockNum >= bcInfo.Height {
+			return nil, errors.Errorf("record of block %d, transaction %d is beyond the last committed block %d", record.BlockNum, record.TxNum, bcInfo.Height-1)
+		}
+		recordsByBlock[record.BlockNum] = append(recordsByBlock[record.BlockNum], record)
+	}
+
+	var blockNums []uint64
+	for blockNum := range recordsByBlock {
+		blockNums = append(blockNums, blockNum)
+	}
+	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] < blockNums[j] })
+
+	var reconciledPvtdata []*ledger.ReconciledPvtdata
+	for _, blockNum := range blockNums {
+		records := recordsByBlock[blockNum]
+		if bcInfo.BootstrappingSnapshotInfo == nil || blockNum > lastBlockInBootSnapshot {
+			// the blocks of the boot snapshot aren't available, the ledger checks their records against the hashes of the snapshot
+			if records, err = s.rejectRecordsNotWritten(lgr, blockNum, records, response); err != nil {
+				return nil, err
+			}
+		}
+		if len(records) == 0 {
+			continue
+		}
+		pvtdata, err := reconciledPvtdataOf(blockNum, records)
+		if err != nil {
+			return nil, err
+		}
+		reconciledPvtdata = append(reconciledPvtdata, pvtdata)
+	}
+
+	accepted := len(request.Records) - len(response.Rejected)
+	if len(reconciledPvtdata) > 0 {
+		mismatches, err := lgr.CommitPvtDataOfOldBlocks(reconciledPvtdata, nil)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to commit private data")
+		}
+		for _, mismatch := range mismatches {
+			response.Rejected = append(response.Rejected, &RejectedRecord{
+				BlockNum:       mismatch.BlockNum,
+				TxNum:          mismatch.TxNum,
+				ChaincodeName:  mismatch.Namespace,
+				CollectionName: mismatch.Collection,
+				Reason:         rejectedHashMismatch,
+			})
+		}
+		accepted -= len(mismatches)
+	}
+	response.Imported = uint64(accepted)
+
+	logger.Infof("Imported %d private data records in channel [%s], %d records were rejected",
+		response.Imported, request.ChannelId, len(response.Rejected))
+	return response, nil
+}
+
+// blockRecords returns the records of the private data of a block that pass the filter
+func (s *PvtDataService) blockRecords(lgr ledger.PeerLedger, blockNum uint64, filter ledger.PvtNsCollFilter) ([]*PvtDataRecord, error) {
+	txsPvtData, err := lgr.GetPvtDataByNum(blockNum, filter)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to retrieve the private data of block %d", blockNum)
+	}
+
+	var records []*PvtDataRecord
+	for _, txPvtData := range txsPvtData {
+		if txPvtData.WriteSet == nil {
+			continue
+		}
+		for _, nsPvtRwset := range txPvtData.WriteSet.NsPvtRwset {
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				if !filter.Has(nsPvtRwset.Namespace, collPvtRwset.CollectionName) {
+					continue
+				}
+				records = append(records, &PvtDataRecord{
+					BlockNum:       blockNum,
+					TxNum:          txPvtData.SeqInBlock,
+					ChaincodeName:  nsPvtRwset.Namespace,
+					CollectionName: collPvtRwset.CollectionName,
+					Rwset:          collPvtRwset.Rwset,
+					RwsetHash:      util.ComputeSHA256(collPvtRwset.Rwset),
+				})
+			}
+		}
+	}
+	return records, nil
+}
+
+// rejectRecordsNotWritten adds the records of a block whose transaction didn't write to the collection to the
+// rejected records of the response, and returns the other records
+func (s *PvtDataService) rejectRecordsNotWritten(lgr ledger.PeerLedger, blockNum uint64, records []*PvtDataRecord, response *ImportResponse) ([]*PvtDataRecord, error) {
+	block, err := lgr.GetBlockByNumber(blockNum)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to retrieve block %d", blockNum)
+	}
+
+	txRWSets := map[uint64]*rwsetutil.TxRwSet{}
+	var written []*PvtDataRecord
+	for _, record := range records {
+		txRWSet, ok := txRWSets[record.TxNum]
+		if !ok && record.TxNum < uint64(len(block.Data.Data)) {
+			txRWSet = txRWSetOf(block.Data.Data[record.TxNum])
+			txRWSets[record.TxNum] = txRWSet
+		}
+		if txRWSet == nil || txRWSet.GetPvtDataHash(record.ChaincodeName, record.CollectionName) == nil {
+			response.Rejected = append(response.Rejected, &RejectedRecord{
+				BlockNum:       record.BlockNum,
+				TxNum:          record.TxNum,
+				ChaincodeName:  record.ChaincodeName,
+				CollectionName: record.CollectionName,
+				Reason:         rejectedNotWritten,
+			})
+			continue
+		}
+		written = append(written, record)
+	}
+	return written, nil
+}
+
+// txRWSetOf returns the read-write set of an endorser transaction, or nil if the envelope isn't one
+func txRWSetOf(envelopeBytes []byte) *rwsetutil.TxRwSet {
+	envelope, err := protoutil.GetEnvelopeFromBlock(envelopeBytes)
+	if err != nil {
+		return nil
+	}
+	action, err := protoutil.GetActionFromEnvelopeMsg(envelope)
+	if err != nil {
+		return nil
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(action.Results); err != nil {
+		return nil
+	}
+	return txRWSet
+}
+
+// reconciledPvtdataOf groups the records of a block by transaction
+func reconciledPvtdataOf(blockNum uint64, records []*PvtDataRecord) (*ledger.ReconciledPvtdata, error) {
+	pvtdata := &ledger.ReconciledPvtdata{
+		BlockNum:  blockNum,
+		WriteSets: ledger.TxPvtDataMap{},
+	}
+	for _, record := range records {
+		txPvtData, ok := pvtdata.WriteSets[record.TxNum]
+		if !ok {
+			txPvtData = &ledger.TxPvtData{
+				SeqInBlock: record.TxNum,
+				WriteSet:   &rwset.TxPvtReadWriteSet{DataModel: rwset.TxReadWriteSet_KV},
+			}
+			pvtdata.WriteSets[record.TxNum] = txPvtData
+		}
+
+		var nsPvtRwset *rwset.NsPvtReadWriteSet
+		for _, ns := range txPvtData.WriteSet.NsPvtRwset {
+			if ns.Namespace == record.ChaincodeName {
+				nsPvtRwset = ns
+				break
+			}
+		}
+		if nsPvtRwset == nil {
+			nsPvtRwset = &rwset.NsPvtReadWriteSet{Namespace: record.ChaincodeName}
+			txPvtData.WriteSet.NsPvtRwset = append(txPvtData.WriteSet.NsPvtRwset, nsPvtRwset)
+		}
+
+		for _, coll := range nsPvtRwset.CollectionPvtRwset {
+			if coll.CollectionName == record.CollectionName {
+				return nil, errors.Errorf("duplicate record of collection [%s:%s] in block %d, transaction %d",
+					record.ChaincodeName, record.CollectionName, blockNum, record.TxNum)
+			}
+		}
+		nsPvtRwset.CollectionPvtRwset = append(nsPvtRwset.CollectionPvtRwset, &rwset.CollectionPvtReadWriteSet{
+			CollectionName: record.CollectionName,
+			Rwset:          record.Rwset,
+		})
+	}
+	return pvtdata, nil
+}
+
+// currentStateSelector selects the records that hold the current value of a key. The records are expected in
+// decreasing order of commit, so that only the most recent write of a key is considered.
+type currentStateSelector struct {
+	lgr         ledger.PeerLedger
+	visitedKeys map[string]struct{}
+}
+
+func (s *currentStateSelector) selectRecord(record *PvtDataRecord) (bool, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(record.Rwset, kvRWSet); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal the private write set of block %d, transaction %d", record.BlockNum, record.TxNum)
+	}
+
+	// the query executor is released for each record, so that the commit of blocks isn't held for the whole export
+	qe, err := s.lgr.NewQueryExecutor()
+	if err != nil {
+		return false, err
+	}
+	defer qe.Done()
+
+	selected := false
+	for _, write := range kvRWSet.Writes {
+		if _, ok := s.visitedKeys[write.Key]; ok {
+			continue
+		}
+		s.visitedKeys[write.Key] = struct{}{}
+		if write.IsDelete {
+			continue
+		}
+		value, err := qe.GetPrivateData(record.ChaincodeName, record.CollectionName, write.Key)
+		if err != nil {
+			return false, err
+		}
+		if value != nil && bytes.Equal(value, write.Value) {
+			selected = true
+		}
+	}
+	return selected, nil
+}
+
+// authorize checks that the creator of the request is allowed to access the resource and returns the request, along
+// with the ledger of the requested channel
+func (s *PvtDataService) authorize(resName string, signedRequest *SignedPvtDataRequest) (*PvtDataRequest, ledger.PeerLedger, error) {
+	request := &PvtDataRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal private data request")
+	}
+
+	if err := s.checkACL(resName, request, signedRequest); err != nil {
+		return nil, nil, err
+	}
+
+	if request.ChannelId == "" {
+		return nil, nil, errors.New("missing channel ID")
+	}
+
+	lgr := s.LedgerGetter.GetLedger(request.ChannelId)
+	if lgr == nil {
+		return nil, nil, errors.Errorf("cannot find ledger for channel %s", request.ChannelId)
+	}
+
+	return request, lgr, nil
+}
+
+func (s *PvtDataService) checkACL(resName string, request *PvtDataRequest, signedRequest *SignedPvtDataRequest) error {
+	signatureHdr := request.SignatureHeader
+	if signatureHdr == nil {
+		return errors.New("missing signature header")
+	}
+
+	expirationTime := crypto.ExpiresAt(signatureHdr.Creator)
+	if !expirationTime.IsZero() && time.Now().After(expirationTime) {
+		return errors.New("client identity expired")
+	}
+
+	return s.ACLProvider.CheckACLNoChannel(
+		resName,
+		[]*protoutil.SignedData{{
+			Identity:  signatureHdr.Creator,
+			Data:      signedRequest.Request,
+			Signature: signedRequest.Signature,
+		}},
+	)
+}
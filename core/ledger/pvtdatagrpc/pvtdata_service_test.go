@@ -0,0 +1,422 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatagrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatagrpc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+//go:generate counterfeiter -o mock/ledger_getter.go -fake-name LedgerGetter . ledgerGetter
+//go:generate counterfeiter -o mock/acl_provider.go -fake-name ACLProvider . aclProvider
+//go:generate counterfeiter -o mock/peer_ledger.go -fake-name PeerLedger . peerLedger
+//go:generate counterfeiter -o mock/query_executor.go -fake-name QueryExecutor . queryExecutor
+
+type ledgerGetter interface {
+	LedgerGetter
+}
+
+type aclProvider interface {
+	ACLProvider
+}
+
+type peerLedger interface {
+	ledger.PeerLedger
+}
+
+type queryExecutor interface {
+	ledger.QueryExecutor
+}
+
+type exportStream struct {
+	grpc.ServerStream
+	records []*PvtDataRecord
+}
+
+func (s *exportStream) Send(record *PvtDataRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+type testTx struct {
+	writes map[string]map[string][]byte // collection -> key -> value
+}
+
+// newTestLedger creates a fake ledger with a block of two transactions followed by a block of one transaction, which
+// write to the collections of chaincode cc
+func newTestLedger(t *testing.T) (*mock.PeerLedger, map[uint64][]*ledger.TxPvtData) {
+	blocksTxs := [][]testTx{
+		{
+			{writes: map[string]map[string][]byte{"coll": {"key1": []byte("v1"), "key2": []byte("v2")}}},
+			{writes: map[string]map[string][]byte{"coll": {"key1": []byte("v3")}, "other": {"key": []byte("x")}}},
+		},
+		{
+			{writes: map[string]map[string][]byte{"coll": {"key2": []byte("v4")}}},
+		},
+	}
+
+	blocks := map[uint64]*common.Block{}
+	pvtData := map[uint64][]*ledger.TxPvtData{}
+	for i, txs := range blocksTxs {
+		blockNum := uint64(i + 1)
+		var pubSimulationResults [][]byte
+		for txNum, tx := range txs {
+			builder := rwsetutil.NewRWSetBuilder()
+			for coll, writes := range tx.writes {
+				for key, value := range writes {
+					builder.AddToPvtAndHashedWriteSet("cc", coll, key, value)
+				}
+			}
+			simulationResults, err := builder.GetTxSimulationResults()
+			require.NoError(t, err)
+			pubSimulationResultsBytes, err := simulationResults.GetPubSimulationBytes()
+			require.NoError(t, err)
+			pubSimulationResults = append(pubSimulationResults, pubSimulationResultsBytes)
+			pvtData[blockNum] = append(pvtData[blockNum], &ledger.TxPvtData{
+				SeqInBlock: uint64(txNum),
+				WriteSet:   simulationResults.PvtSimulationResults,
+			})
+		}
+		blocks[blockNum] = testutil.ConstructBlock(t, blockNum, nil, pubSimulationResults, false)
+	}
+
+	state := map[string][]byte{"key1": []byte("v3"), "key2": []byte("v4")}
+
+	fakeLedger := &mock.PeerLedger{}
+	fakeLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 3}, nil)
+	fakeLedger.GetBlockByNumberStub = func(blockNum uint64) (*common.Block, error) {
+		return blocks[blockNum], nil
+	}
+	fakeLedger.GetPvtDataByNumStub = func(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+		var res []*ledger.TxPvtData
+		for _, txPvtData := range pvtData[blockNum] {
+			writeSet := &rwset.TxPvtReadWriteSet{DataModel: txPvtData.WriteSet.DataModel}
+			for _, ns := range txPvtData.WriteSet.NsPvtRwset {
+				nsPvtRwset := &rwset.NsPvtReadWriteSet{Namespace: ns.Namespace}
+				for _, coll := range ns.CollectionPvtRwset {
+					if filter.Has(ns.Namespace, coll.CollectionName) {
+						nsPvtRwset.CollectionPvtRwset = append(nsPvtRwset.CollectionPvtRwset, coll)
+					}
+				}
+				if len(nsPvtRwset.CollectionPvtRwset) > 0 {
+					writeSet.NsPvtRwset = append(writeSet.NsPvtRwset, nsPvtRwset)
+				}
+			}
+			if len(writeSet.NsPvtRwset) > 0 {
+				res = append(res, &ledger.TxPvtData{SeqInBlock: txPvtData.SeqInBlock, WriteSet: writeSet})
+			}
+		}
+		return res, nil
+	}
+	fakeLedger.NewQueryExecutorStub = func() (ledger.QueryExecutor, error) {
+		fakeQueryExecutor := &mock.QueryExecutor{}
+		fakeQueryExecutor.GetPrivateDataStub = func(ns, coll, key string) ([]byte, error) {
+			return state[key], nil
+		}
+		return fakeQueryExecutor, nil
+	}
+
+	return fakeLedger, pvtData
+}
+
+func collRwset(t *testing.T, pvtData map[uint64][]*ledger.TxPvtData, blockNum, txNum uint64, coll string) []byte {
+	for _, ns := range pvtData[blockNum][txNum].WriteSet.NsPvtRwset {
+		for _, collPvtRwset := range ns.CollectionPvtRwset {
+			if collPvtRwset.CollectionName == coll {
+				return collPvtRwset.Rwset
+			}
+		}
+	}
+	require.FailNow(t, "collection not found")
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	fakeLedger, pvtData := newTestLedger(t)
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeLedgerGetter.GetLedgerReturns(fakeLedger)
+	fakeACLProvider := &mock.ACLProvider{}
+	pvtDataSvc := &PvtDataService{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}
+
+	record := func(blockNum, txNum uint64) *PvtDataRecord {
+		rwset := collRwset(t, pvtData, blockNum, txNum, "coll")
+		return &PvtDataRecord{
+			BlockNum:       blockNum,
+			TxNum:          txNum,
+			ChaincodeName:  "cc",
+			CollectionName: "coll",
+			Rwset:          rwset,
+			RwsetHash:      util.ComputeSHA256(rwset),
+		}
+	}
+
+	t.Run("history", func(t *testing.T) {
+		stream := &exportStream{}
+		err := pvtDataSvc.Export(createSignedRequest("testchannel", func(r *PvtDataRequest) {
+			r.ChaincodeName = "cc"
+			r.CollectionName = "coll"
+		}), stream)
+		require.NoError(t, err)
+		require.Equal(t, []*PvtDataRecord{record(1, 0), record(1, 1), record(2, 0)}, stream.records)
+
+		resName, _ := fakeACLProvider.CheckACLNoChannelArgsForCall(0)
+		require.Equal(t, "pvtdata/export", resName)
+		require.Equal(t, "testchannel", fakeLedgerGetter.GetLedgerArgsForCall(0))
+
+		// the exported records can be checked against the hashes committed in the blocks
+		block, err := fakeLedger.GetBlockByNumber(2)
+		require.NoError(t, err)
+		require.Equal(t, record(2, 0).RwsetHash, txRWSetOf(block.Data.Data[0]).GetPvtDataHash("cc", "coll"))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		stream := &exportStream{}
+		err := pvtDataSvc.Export(createSignedRequest("testchannel", func(r *PvtDataRequest) {
+			r.ChaincodeName = "cc"
+			r.CollectionName = "other"
+			r.StartBlock = 1
+			r.EndBlock = 1
+		}), stream)
+		require.NoError(t, err)
+		require.Len(t, stream.records, 1)
+		require.Equal(t, uint64(1), stream.records[0].BlockNum)
+		require.Equal(t, uint64(1), stream.records[0].TxNum)
+		require.Equal(t, "other", stream.records[0].CollectionName)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		stream := &exportStream{}
+		err := pvtDataSvc.Export(createSignedRequest("testchannel", func(r *PvtDataRequest) {
+			r.ChaincodeName = "cc"
+			r.CollectionName = "coll"
+			r.StateOnly = true
+		}), stream)
+		require.NoError(t, err)
+		require.Equal(t, []*PvtDataRecord{record(2, 0), record(1, 1)}, stream.records)
+	})
+
+	tests := []struct {
+		name     string
+		complete func(*PvtDataRequest)
+		errMsg   string
+	}{
+		{
+			name:     "missing collection",
+			complete: func(r *PvtDataRequest) { r.ChaincodeName = "cc" },
+			errMsg:   "missing chaincode name or collection name",
+		},
+		{
+			name: "end block beyond last block",
+			complete: func(r *PvtDataRequest) {
+				r.ChaincodeName = "cc"
+				r.CollectionName = "coll"
+				r.EndBlock = 3
+			},
+			errMsg: "end block 3 is beyond the last committed block 2",
+		},
+		{
+			name: "end block lower than start block",
+			complete: func(r *PvtDataRequest) {
+				r.ChaincodeName = "cc"
+				r.CollectionName = "coll"
+				r.StartBlock = 2
+				r.EndBlock = 1
+			},
+			errMsg: "end block 1 is lower than start block 2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := pvtDataSvc.Export(createSignedRequest("testchannel", test.complete), &exportStream{})
+			require.EqualError(t, err, test.errMsg)
+		})
+	}
+
+	t.Run("ledger error", func(t *testing.T) {
+		fakeLedger.GetPvtDataByNumStub = nil
+		fakeLedger.GetPvtDataByNumReturns(nil, errors.New("boom"))
+		err := pvtDataSvc.Export(createSignedRequest("testchannel", func(r *PvtDataRequest) {
+			r.ChaincodeName = "cc"
+			r.CollectionName = "coll"
+		}), &exportStream{})
+		require.EqualError(t, err, "failed to retrieve the private data of block 0: boom")
+	})
+}
+
+func TestImport(t *testing.T) {
+	fakeLedger, pvtData := newTestLedger(t)
+	fakeLedger.CommitPvtDataOfOldBlocksReturns([]*ledger.PvtdataHashMismatch{
+		{BlockNum: 2, TxNum: 0, Namespace: "cc", Collection: "coll"},
+	}, nil)
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeLedgerGetter.GetLedgerReturns(fakeLedger)
+	fakeACLProvider := &mock.ACLProvider{}
+	pvtDataSvc := &PvtDataService{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}
+
+	record := func(blockNum, txNum uint64, coll string, rwset []byte) *PvtDataRecord {
+		return &PvtDataRecord{BlockNum: blockNum, TxNum: txNum, ChaincodeName: "cc", CollectionName: coll, Rwset: rwset}
+	}
+
+	resp, err := pvtDataSvc.Import(context.Background(), createSignedRequest("testchannel", func(r *PvtDataRequest) {
+		r.Records = []*PvtDataRecord{
+			record(2, 0, "coll", []byte("tampered")),
+			record(1, 1, "other", collRwset(t, pvtData, 1, 1, "other")),
+			record(1, 0, "other", []byte("not written")),
+			record(1, 1, "coll", collRwset(t, pvtData, 1, 1, "coll")),
+			record(1, 5, "coll", []byte("no such transaction")),
+		}
+	}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), resp.Imported)
+	require.Equal(t, []*RejectedRecord{
+		{BlockNum: 1, TxNum: 0, ChaincodeName: "cc", CollectionName: "other", Reason: rejectedNotWritten},
+		{BlockNum: 1, TxNum: 5, ChaincodeName: "cc", CollectionName: "coll", Reason: rejectedNotWritten},
+		{BlockNum: 2, TxNum: 0, ChaincodeName: "cc", CollectionName: "coll", Reason: rejectedHashMismatch},
+	}, resp.Rejected)
+
+	resName, _ := fakeACLProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, "pvtdata/import", resName)
+
+	require.Equal(t, 1, fakeLedger.CommitPvtDataOfOldBlocksCallCount())
+	reconciledPvtdata, unreconciled := fakeLedger.CommitPvtDataOfOldBlocksArgsForCall(0)
+	require.Nil(t, unreconciled)
+	require.Len(t, reconciledPvtdata, 2)
+	require.Equal(t, uint64(1), reconciledPvtdata[0].BlockNum)
+	require.Len(t, reconciledPvtdata[0].WriteSets, 1)
+	require.Equal(t, &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
+			Namespace: "cc",
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+				{CollectionName: "other", Rwset: collRwset(t, pvtData, 1, 1, "other")},
+				{CollectionName: "coll", Rwset: collRwset(t, pvtData, 1, 1, "coll")},
+			},
+		}},
+	}, reconciledPvtdata[0].WriteSets[1].WriteSet)
+	require.Equal(t, uint64(2), reconciledPvtdata[1].BlockNum)
+
+	tests := []struct {
+		name    string
+		records []*PvtDataRecord
+		errMsg  string
+	}{
+		{
+			name:   "no records",
+			errMsg: "no records to import",
+		},
+		{
+			name:    "missing collection",
+			records: []*PvtDataRecord{{BlockNum: 1, ChaincodeName: "cc"}},
+			errMsg:  "record of block 1, transaction 0 is missing its chaincode name or collection name",
+		},
+		{
+			name:    "block beyond last block",
+			records: []*PvtDataRecord{record(3, 0, "coll", nil)},
+			errMsg:  "record of block 3, transaction 0 is beyond the last committed block 2",
+		},
+		{
+			name:    "duplicate record",
+			records: []*PvtDataRecord{record(1, 1, "coll", nil), record(1, 1, "coll", nil)},
+			errMsg:  "duplicate record of collection [cc:coll] in block 1, transaction 1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := pvtDataSvc.Import(context.Background(), createSignedRequest("testchannel", func(r *PvtDataRequest) {
+				r.Records = test.records
+			}))
+			require.EqualError(t, err, test.errMsg)
+		})
+	}
+
+	t.Run("commit error", func(t *testing.T) {
+		fakeLedger.CommitPvtDataOfOldBlocksReturns(nil, errors.New("boom"))
+		_, err := pvtDataSvc.Import(context.Background(), createSignedRequest("testchannel", func(r *PvtDataRequest) {
+			r.Records = []*PvtDataRecord{record(1, 1, "coll", nil)}
+		}))
+		require.EqualError(t, err, "failed to commit private data: boom")
+	})
+}
+
+func TestAuthorization(t *testing.T) {
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeACLProvider := &mock.ACLProvider{}
+	pvtDataSvc := &PvtDataService{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}
+
+	tests := []struct {
+		name          string
+		signedRequest *SignedPvtDataRequest
+		aclErr        error
+		errMsg        string
+	}{
+		{
+			name:          "unmarshal error",
+			signedRequest: &SignedPvtDataRequest{Request: []byte("dummy")},
+			errMsg:        "failed to unmarshal private data request: proto: can't skip unknown wire type 4",
+		},
+		{
+			name:          "missing signature header",
+			signedRequest: &SignedPvtDataRequest{Request: protoutil.MarshalOrPanic(&PvtDataRequest{ChannelId: "testchannel"})},
+			errMsg:        "missing signature header",
+		},
+		{
+			name:          "access denied",
+			signedRequest: createSignedRequest("testchannel", nil),
+			aclErr:        errors.New("access denied"),
+			errMsg:        "access denied",
+		},
+		{
+			name:          "missing channel ID",
+			signedRequest: createSignedRequest("", nil),
+			errMsg:        "missing channel ID",
+		},
+		{
+			name:          "cannot find ledger",
+			signedRequest: createSignedRequest("testchannel", nil),
+			errMsg:        "cannot find ledger for channel testchannel",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeACLProvider.CheckACLNoChannelReturns(test.aclErr)
+			err := pvtDataSvc.Export(test.signedRequest, &exportStream{})
+			require.EqualError(t, err, test.errMsg)
+			_, err = pvtDataSvc.Import(context.Background(), test.signedRequest)
+			require.EqualError(t, err, test.errMsg)
+		})
+	}
+}
+
+func createSignedRequest(channelID string, complete func(*PvtDataRequest)) *SignedPvtDataRequest {
+	request := &PvtDataRequest{
+		SignatureHeader: &common.SignatureHeader{
+			Creator: []byte("creator"),
+			Nonce:   []byte("nonce-ignored"),
+		},
+		ChannelId: channelID,
+	}
+	if complete != nil {
+		complete(request)
+	}
+	return &SignedPvtDataRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("not-a-signature"),
+	}
+}
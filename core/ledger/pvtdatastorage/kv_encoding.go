@@ -33,6 +33,7 @@ var (
 	hashedIndexKeyPrefix             = []byte{'b'}
 	purgeMarkerKeyPrefix             = []byte{'c'}
 	purgeMarkerCollKeyPrefix         = []byte{'d'}
+	pendingPurgeKeyPrefix            = []byte{'e'}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -302,6 +303,28 @@ func encodePurgeMarkerKey(k *purgeMarkerKey) []byte {
 	return encKey
 }
 
+func encodePendingPurgeKey(k *purgeMarkerKey) []byte {
+	encKey := append(pendingPurgeKeyPrefix, []byte(k.ns)...)
+	encKey = append(encKey, nilByte)
+	encKey = append(encKey, []byte(k.coll)...)
+	encKey = append(encKey, nilByte)
+	encKey = append(encKey, k.pvtkeyHash...)
+	return encKey
+}
+
+func getPendingPurgeKeysForRangeScan() ([]byte, []byte) {
+	return pendingPurgeKeyPrefix, []byte{pendingPurgeKeyPrefix[0] + 1}
+}
+
+// getHashedIndexKeysForRangeScan returns the range of the hashed index keys of all the versions of the key
+// identified by an encoded pending purge key
+func getHashedIndexKeysForRangeScan(encPendingPurgeKey []byte) ([]byte, []byte) {
+	startKey := append([]byte{}, hashedIndexKeyPrefix...)
+	startKey = append(startKey, encPendingPurgeKey[len(pendingPurgeKeyPrefix):]...)
+	endKey := append([]byte{}, startKey...)
+	return startKey, append(endKey, 0xff)
+}
+
 func encodePurgeMarkerVal(v *purgeMarkerVal) []byte {
 	return version.NewHeight(v.blkNum, v.txNum).ToBytes()
 }
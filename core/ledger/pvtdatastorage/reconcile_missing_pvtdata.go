@@ -83,6 +83,11 @@ func (p *oldBlockDataProcessor) prepareDataAndExpiryEntries(blocksPvtData map[ui
 		nsCollBlk := dataEntry.key.nsCollBlk
 		txNum := dataEntry.key.txNum
 
+		// the keys purged after this block was committed must not be brought back by the reconciliation
+		if err := p.removePurgedDataFromCollPvtRWset(dataEntry.key, dataEntry.value); err != nil {
+			return err
+		}
+
 		expKey, err := p.constructExpiryKey(dataEntry)
 		if err != nil {
 			return err
@@ -275,7 +280,7 @@ func (p *oldBlockDataProcessor) getDeprioMissingDataFromEntriesOrStore(nsCollBlk
 func (p *oldBlockDataProcessor) constructDBUpdateBatch() (*leveldbhelper.UpdateBatch, error) {
 	batch := p.db.NewUpdateBatch()
 
-	if err := p.entries.addDataEntriesTo(batch); err != nil {
+	if err := p.entries.addDataEntriesTo(batch, p.encryptDataValue); err != nil {
 		return nil, errors.WithMessage(err, "error while adding data entries to the update batch")
 	}
 
@@ -304,7 +309,10 @@ type entriesForPvtDataOfOldBlocks struct {
 	bootKVHashesDeletions           []*bootKVHashesKey
 }
 
-func (e *entriesForPvtDataOfOldBlocks) addDataEntriesTo(batch *leveldbhelper.UpdateBatch) error {
+func (e *entriesForPvtDataOfOldBlocks) addDataEntriesTo(
+	batch *leveldbhelper.UpdateBatch,
+	encodeDataValue func(*rwset.CollectionPvtReadWriteSet) ([]byte, error),
+) error {
 	var key, val []byte
 	var err error
 
@@ -314,6 +322,9 @@ func (e *entriesForPvtDataOfOldBlocks) addDataEntriesTo(batch *leveldbhelper.Upd
 			return errors.Wrap(err, "error while encoding data value")
 		}
 		batch.Put(key, val)
+		if err := addHashedIndexEntriesInto(batch, &dataKey, pvtData); err != nil {
+			return errors.WithMessage(err, "error while adding hashed index entries")
+		}
 	}
 	return nil
 }
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package pvtdatastorage
 
 import (
+	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/pkg/errors"
@@ -43,6 +46,9 @@ type PrivateDataConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.PrivateDataConfig and not exposed to other components.
 	StorePath string
+	// Encryptor encrypts the private data values before they are stored.
+	// The private data is stored in plaintext if it is nil.
+	Encryptor ledger.PvtDataEncryptor
 }
 
 // Store manages the permanent storage of private write sets for a ledger
@@ -53,6 +59,7 @@ type Store struct {
 	batchesInterval int
 	maxBatchSize    int
 	purgeInterval   uint64
+	encryptor       ledger.PvtDataEncryptor
 
 	isEmpty            bool
 	lastCommittedBlock uint64
@@ -215,6 +222,7 @@ func (p *Provider) OpenStore(ledgerid string) (*Store, error) {
 		batchesInterval:                     p.pvtData.BatchesInterval,
 		maxBatchSize:                        p.pvtData.MaxBatchSize,
 		purgeInterval:                       uint64(p.pvtData.PurgeInterval),
+		encryptor:                           p.pvtData.Encryptor,
 		deprioritizedDataReconcilerInterval: p.pvtData.DeprioritizedDataReconcilerInterval,
 		accessDeprioMissingDataAfter:        time.Now().Add(p.pvtData.DeprioritizedDataReconcilerInterval),
 		collElgProcSync: &collElgProcSync{
@@ -293,8 +301,10 @@ func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 // Commit commits the pvt data as well as both the eligible and ineligible
 // missing private data --- `eligible` denotes that the missing private data belongs to a collection
 // for which this peer is a member; `ineligible` denotes that the missing private data belong to a
-// collection for which this peer is not a member.
-func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtData) error {
+// collection for which this peer is not a member. The purge markers record the keys explicitly purged
+// by the valid transactions of the block; the historical versions of these keys are filtered out from
+// the retrieved pvt data straight away and removed from the store by the next scheduled purge.
+func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtData, purgeMarkers []*PurgeMarker) error {
 	expectedBlockNum := s.nextBlockNum()
 	if expectedBlockNum != blockNum {
 		return errors.Errorf("expected block number=%d, received block number=%d", expectedBlockNum, blockNum)
@@ -311,7 +321,7 @@ func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtD
 
 	for _, dataEntry := range storeEntries.dataEntries {
 		key = encodeDataKey(dataEntry.key)
-		if val, err = encodeDataValue(dataEntry.value); err != nil {
+		if val, err = s.encryptDataValue(dataEntry.value); err != nil {
 			return err
 		}
 		batch.Put(key, val)
@@ -348,6 +358,10 @@ func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtD
 		batch.Put(key, val)
 	}
 
+	for _, purgeMarker := range purgeMarkers {
+		addPurgeMarkerEntriesInto(batch, purgeMarker)
+	}
+
 	committingBlockNum := s.nextBlockNum()
 	logger.Debugf("Committing private data for block [%d]", committingBlockNum)
 	batch.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(committingBlockNum))
@@ -431,6 +445,19 @@ func (s *Store) ResetLastUpdatedOldBlocksList() error {
 
 func (s *Store) addPurgeMarkers(p *PurgeMarker) error {
 	b := s.db.NewUpdateBatch()
+	addPurgeMarkerEntriesInto(b, p)
+	return s.db.WriteBatch(b, true)
+}
+
+// addPurgeMarkerEntriesInto adds the purge marker of the key, the latest purge height of the collection and
+// the pending purge entry that schedules the removal of the historical versions of the key
+func addPurgeMarkerEntriesInto(b *leveldbhelper.UpdateBatch, p *PurgeMarker) {
+	val := encodePurgeMarkerVal(
+		&purgeMarkerVal{
+			blkNum: p.BlkNum,
+			txNum:  p.TxNum,
+		},
+	)
 	b.Put(
 		encodePurgeMarkerCollKey(
 			&purgeMarkerCollKey{
@@ -438,29 +465,15 @@ func (s *Store) addPurgeMarkers(p *PurgeMarker) error {
 				coll: p.Coll,
 			},
 		),
-		encodePurgeMarkerVal(
-			&purgeMarkerVal{
-				blkNum: p.BlkNum,
-				txNum:  p.TxNum,
-			},
-		),
-	)
-	b.Put(
-		encodePurgeMarkerKey(
-			&purgeMarkerKey{
-				ns:         p.Ns,
-				coll:       p.Coll,
-				pvtkeyHash: p.PvtkeyHash,
-			},
-		),
-		encodePurgeMarkerVal(
-			&purgeMarkerVal{
-				blkNum: p.BlkNum,
-				txNum:  p.TxNum,
-			},
-		),
+		val,
 	)
-	return s.db.WriteBatch(b, true)
+	key := &purgeMarkerKey{
+		ns:         p.Ns,
+		coll:       p.Coll,
+		pvtkeyHash: p.PvtkeyHash,
+	}
+	b.Put(encodePurgeMarkerKey(key), val)
+	b.Put(encodePendingPurgeKey(key), val)
 }
 
 // GetPvtDataByBlockNum returns only the pvt data  corresponding to the given block number
@@ -515,7 +528,7 @@ func (s *Store) GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFil
 			currentTxWsetAssember = newTxPvtdataAssembler(blockNum, currentTxNum)
 		}
 
-		dataValue, err := decodeDataValue(dataValueBytes)
+		dataValue, err := s.decryptDataValue(dataValueBytes)
 		if err != nil {
 			return nil, err
 		}
@@ -620,6 +633,24 @@ func (s *Store) removePurgedDataFromCollPvtRWset(k *dataKey, v *rwset.Collection
 	return nil
 }
 
+// IsPurged returns true if the version of the private data key, identified by its hash, that was committed
+// at the given height has been explicitly purged
+func (s *Store) IsPurged(ns, coll string, pvtkeyHash []byte, blkNum, txNum uint64) (bool, error) {
+	encPurgeMarkerVal, err := s.db.Get(encodePurgeMarkerKey(&purgeMarkerKey{
+		ns:         ns,
+		coll:       coll,
+		pvtkeyHash: pvtkeyHash,
+	}))
+	if err != nil || encPurgeMarkerVal == nil {
+		return false, err
+	}
+	purgeMarkerHt, err := decodePurgeMarkerVal(encPurgeMarkerVal)
+	if err != nil {
+		return false, err
+	}
+	return version.NewHeight(blkNum, txNum).Compare(purgeMarkerHt) <= 0, nil
+}
+
 // GetMissingPvtDataInfoForMostRecentBlocks returns the missing private data information for the
 // most recent `maxBlock` blocks which miss at least a private data of a eligible collection.
 func (s *Store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.MissingPvtDataInfo, error) {
@@ -639,7 +670,50 @@ func (s *Store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	return s.getMissingData(elgPrioritizedMissingDataGroup, maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most recent
+// `maxBlock` blocks between `startBlock` and `endBlock` inclusive which miss at least a private data of an
+// eligible collection accepted by the filter. Unlike GetMissingPvtDataInfoForMostRecentBlocks, both the
+// prioritized and the deprioritized missing data are returned, so that a caller can page through all of
+// the missing data in the range by lowering `endBlock` below the blocks already returned.
+func (s *Store) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int, filter func(ns, coll string) bool) (ledger.MissingPvtDataInfo, error) {
+	if maxBlock < 1 || endBlock < startBlock {
+		return nil, nil
+	}
+
+	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	for _, group := range [][]byte{elgPrioritizedMissingDataGroup, elgDeprioritizedMissingDataGroup} {
+		groupMissingPvtDataInfo, err := s.getMissingDataInRange(group, startBlock, endBlock, maxBlock, filter)
+		if err != nil {
+			return nil, err
+		}
+		for blkNum, blockPvtDataInfo := range groupMissingPvtDataInfo {
+			for txNum, collectionPvtDataInfo := range blockPvtDataInfo {
+				for _, pvtDataInfo := range collectionPvtDataInfo {
+					missingPvtDataInfo.Add(blkNum, txNum, pvtDataInfo.Namespace, pvtDataInfo.Collection)
+				}
+			}
+		}
+	}
+
+	// each group contributes up to maxBlock blocks, so retain only the most recent maxBlock blocks of both
+	if len(missingPvtDataInfo) > maxBlock {
+		blkNums := make([]uint64, 0, len(missingPvtDataInfo))
+		for blkNum := range missingPvtDataInfo {
+			blkNums = append(blkNums, blkNum)
+		}
+		sort.Slice(blkNums, func(i, j int) bool { return blkNums[i] > blkNums[j] })
+		for _, blkNum := range blkNums[maxBlock:] {
+			delete(missingPvtDataInfo, blkNum)
+		}
+	}
+	return missingPvtDataInfo, nil
+}
+
 func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	return s.getMissingDataInRange(group, 0, math.MaxUint64, maxBlock, nil)
+}
+
+func (s *Store) getMissingDataInRange(group []byte, startBlock, endBlock uint64, maxBlock int, filter func(ns, coll string) bool) (ledger.MissingPvtDataInfo, error) {
 	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
 	numberOfBlockProcessed := 0
 	lastProcessedBlock := uint64(0)
@@ -650,7 +724,11 @@ func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDat
 	// changed. To ensure consistency, we atomically load the lastCommittedBlock value
 	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
 
-	startKey, endKey := createRangeScanKeysForElgMissingData(lastCommittedBlock, group)
+	scanFromBlock := lastCommittedBlock
+	if endBlock < scanFromBlock {
+		scanFromBlock = endBlock
+	}
+	startKey, endKey := createRangeScanKeysForElgMissingData(scanFromBlock, group)
 	dbItr, err := s.db.GetIterator(startKey, endKey)
 	if err != nil {
 		return nil, err
@@ -661,12 +739,21 @@ func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDat
 		missingDataKeyBytes := dbItr.Key()
 		missingDataKey := decodeElgMissingDataKey(missingDataKeyBytes)
 
+		if missingDataKey.blkNum < startBlock {
+			// entries are in decreasing order of block number
+			break
+		}
+
 		if isMaxBlockLimitReached && (missingDataKey.blkNum != lastProcessedBlock) {
 			// ensures that exactly maxBlock number
 			// of blocks' entries are processed
 			break
 		}
 
+		if filter != nil && !filter(missingDataKey.ns, missingDataKey.coll) {
+			continue
+		}
+
 		// check whether the entry is expired. If so, move to the next item.
 		// As we may use the old lastCommittedBlock value, there is a possibility that
 		// this missing data is actually expired but we may get the stale information.
@@ -774,6 +861,10 @@ func (s *Store) performPurgeIfScheduled(latestCommittedBlk uint64) {
 		if err != nil {
 			logger.Warningf("Could not purge data from pvtdata store:%s", err)
 		}
+		err = s.deleteDataMarkedForPurge()
+		if err != nil {
+			logger.Warningf("Could not delete data marked for purge from pvtdata store:%s", err)
+		}
 		logger.Debug("Purger finished")
 	}()
 }
@@ -831,6 +922,107 @@ func (s *Store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 	return nil
 }
 
+// deleteDataMarkedForPurge removes the historical versions of the keys explicitly purged since the last run. The
+// hashed index is used to locate the data entries that contain a version of a key committed at or below the height
+// of its purge marker.
+func (s *Store) deleteDataMarkedForPurge() error {
+	startKey, endKey := getPendingPurgeKeysForRangeScan()
+	itr, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	numKeysPurged := 0
+	for itr.Next() {
+		pendingPurgeKey := itr.Key()
+		purgeMarkerHt, err := decodePurgeMarkerVal(itr.Value())
+		if err != nil {
+			return err
+		}
+
+		batch := s.db.NewUpdateBatch()
+		if err := s.addDeletesForPurgedKeyInto(batch, pendingPurgeKey, purgeMarkerHt); err != nil {
+			return err
+		}
+		batch.Delete(pendingPurgeKey)
+		if err := s.db.WriteBatch(batch, false); err != nil {
+			return err
+		}
+		numKeysPurged++
+	}
+
+	if numKeysPurged > 0 {
+		logger.Infof("[%s] - [%d] Keys marked for purge deleted from private data storage", s.ledgerid, numKeysPurged)
+	}
+	return nil
+}
+
+// addDeletesForPurgedKeyInto rewrites the data entries that contain a version of the purged key without the key, and
+// deletes the corresponding hashed index entries
+func (s *Store) addDeletesForPurgedKeyInto(batch *leveldbhelper.UpdateBatch, pendingPurgeKey []byte, purgeMarkerHt *version.Height) error {
+	startKey, endKey := getHashedIndexKeysForRangeScan(pendingPurgeKey)
+	itr, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	for itr.Next() {
+		encHashedIndexKey := itr.Key()
+		purgedKey := string(itr.Value())
+		encDataKey, err := deriveDataKeyFromEncodedHashedIndexKey(encHashedIndexKey)
+		if err != nil {
+			return err
+		}
+		dataKey, err := decodeDatakey(encDataKey)
+		if err != nil {
+			return err
+		}
+		keyHt := version.NewHeight(dataKey.blkNum, dataKey.txNum)
+		if keyHt.Compare(purgeMarkerHt) > 0 {
+			// the key was written again after the purge
+			continue
+		}
+
+		encDataVal, err := s.db.Get(encDataKey)
+		if err != nil {
+			return err
+		}
+		if encDataVal != nil {
+			dataVal, err := s.decryptDataValue(encDataVal)
+			if err != nil {
+				return err
+			}
+			if err := removeKeyFromCollPvtRWset(dataVal, purgedKey); err != nil {
+				return err
+			}
+			if encDataVal, err = s.encryptDataValue(dataVal); err != nil {
+				return err
+			}
+			batch.Put(encDataKey, encDataVal)
+		}
+		batch.Delete(encHashedIndexKey)
+	}
+	return nil
+}
+
+func removeKeyFromCollPvtRWset(v *rwset.CollectionPvtReadWriteSet, key string) error {
+	collRWSet, err := rwsetutil.CollPvtRwSetFromProtoMsg(v)
+	if err != nil {
+		return err
+	}
+	filterInKVWrites := []*kvrwset.KVWrite{}
+	for _, w := range collRWSet.KvRwSet.Writes {
+		if w.Key != key {
+			filterInKVWrites = append(filterInKVWrites, w)
+		}
+	}
+	collRWSet.KvRwSet.Writes = filterInKVWrites
+	v.Rwset, err = proto.Marshal(collRWSet.KvRwSet)
+	return err
+}
+
 func (s *Store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEntry, error) {
 	startKey, endKey := getExpiryKeysForRangeScan(minBlkNum, maxBlkNum)
 	logger.Debugf("retrieveExpiryEntries(): startKey=%#v, endKey=%#v", startKey, endKey)
@@ -865,7 +1057,7 @@ func (s *Store) retrieveDataEntries(dataKeys []*dataKey) ([]*dataEntry, error) {
 			return nil, err
 		}
 
-		collWS, err := decodeDataValue(v)
+		collWS, err := s.decryptDataValue(v)
 		if err != nil {
 			return nil, err
 		}
@@ -879,6 +1071,30 @@ func (s *Store) retrieveDataEntries(dataKeys []*dataKey) ([]*dataEntry, error) {
 	return dataEntries, nil
 }
 
+// encryptDataValue encodes the private write set of a collection and encrypts it, if an encryptor is configured
+func (s *Store) encryptDataValue(collData *rwset.CollectionPvtReadWriteSet) ([]byte, error) {
+	val, err := encodeDataValue(collData)
+	if err != nil || s.encryptor == nil {
+		return val, err
+	}
+	return s.encryptor.Encrypt(val)
+}
+
+// decryptDataValue decrypts the private write set of a collection, if an encryptor is configured, and decodes it
+func (s *Store) decryptDataValue(val []byte) (*rwset.CollectionPvtReadWriteSet, error) {
+	if s.encryptor == nil {
+		if pvtdataencryption.IsEncrypted(val) {
+			return nil, errors.New("data value is encrypted but the encryption of private data is not enabled")
+		}
+		return decodeDataValue(val)
+	}
+	val, err := s.encryptor.Decrypt(val)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while decrypting data value")
+	}
+	return decodeDataValue(val)
+}
+
 func (s *Store) launchCollElgProc() {
 	go func() {
 		if err := s.processCollElgEvents(); err != nil {
@@ -9,6 +9,7 @@ package pvtdatastorage
 import (
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"strings"
 	"testing"
@@ -17,9 +18,11 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
@@ -80,10 +83,10 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// no pvt data with block 0
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 
 	// pvt data with block 1 - commit
-	require.NoError(t, store.Commit(1, testData, blk1MissingData))
+	require.NoError(t, store.Commit(1, testData, blk1MissingData, nil))
 
 	// pvt data retrieval for block 0 should return nil
 	var nilFilter ledger.PvtNsCollFilter
@@ -120,7 +123,7 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	require.Nil(t, retrievedData)
 
 	// pvt data with block 2 - commit
-	require.NoError(t, store.Commit(2, testData, blk2MissingData))
+	require.NoError(t, store.Commit(2, testData, blk2MissingData, nil))
 
 	// retrieve the stored missing entries using GetMissingPvtDataInfoForMostRecentBlocks
 	// Only the code path of eligible entries would be covered in this unit-test. For
@@ -154,11 +157,76 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
 }
 
+func TestStoreEncryption(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	csp, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(t, err)
+	keyID, err := pvtdataencryption.GenerateKey(csp)
+	require.NoError(t, err)
+	encryptor, err := pvtdataencryption.New(csp, keyID)
+	require.NoError(t, err)
+
+	env := NewTestStoreEnv(t, "TestStoreEncryption", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+
+	// block 1 is committed before the encryption is enabled
+	blk1Data := []*ledger.TxPvtData{produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"})}
+	require.NoError(t, env.TestStore.Commit(0, nil, nil, nil))
+	require.NoError(t, env.TestStore.Commit(1, blk1Data, nil, nil))
+
+	env.conf.Encryptor = encryptor
+	env.CloseAndReopen()
+	store := env.TestStore
+
+	blk2Data := []*ledger.TxPvtData{produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"})}
+	blk2MissingData := make(ledger.TxMissingPvtData)
+	blk2MissingData.Add(2, "ns-1", "coll-2", true)
+	require.NoError(t, store.Commit(2, blk2Data, blk2MissingData, nil))
+	require.NoError(t, store.CommitPvtDataOfOldBlocks(
+		map[uint64][]*ledger.TxPvtData{2: {produceSamplePvtdata(t, 2, []string{"ns-1:coll-2"})}},
+		nil,
+	))
+
+	assertEncrypted := func(blkNum, txNum uint64, ns, coll string, expectEncrypted bool) {
+		val, err := store.db.Get(encodeDataKey(&dataKey{nsCollBlk: nsCollBlk{ns: ns, coll: coll, blkNum: blkNum}, txNum: txNum}))
+		require.NoError(t, err)
+		require.NotNil(t, val)
+		require.Equal(t, expectEncrypted, pvtdataencryption.IsEncrypted(val))
+		require.Equal(t, !expectEncrypted, strings.Contains(string(val), fmt.Sprintf("value-%s-%s", ns, coll)))
+	}
+	assertEncrypted(1, 1, "ns-1", "coll-1", false)
+	assertEncrypted(2, 1, "ns-1", "coll-1", true)
+	assertEncrypted(2, 2, "ns-1", "coll-2", true)
+
+	// the data is decrypted transparently, along with the data stored before the encryption was enabled
+	retrievedData, err := store.GetPvtDataByBlockNum(1, nil)
+	require.NoError(t, err)
+	require.Len(t, retrievedData, 1)
+	require.True(t, proto.Equal(blk1Data[0].WriteSet, retrievedData[0].WriteSet))
+
+	retrievedData, err = store.GetPvtDataByBlockNum(2, nil)
+	require.NoError(t, err)
+	require.Len(t, retrievedData, 2)
+	require.True(t, proto.Equal(blk2Data[0].WriteSet, retrievedData[0].WriteSet))
+	require.True(t, proto.Equal(produceSamplePvtdata(t, 2, []string{"ns-1:coll-2"}).WriteSet, retrievedData[1].WriteSet))
+
+	// the encrypted data cannot be read once the encryption is disabled
+	env.conf.Encryptor = nil
+	env.CloseAndReopen()
+	_, err = env.TestStore.GetPvtDataByBlockNum(2, nil)
+	require.EqualError(t, err, "data value is encrypted but the encryption of private data is not enabled")
+}
+
 func TestStoreIteratorError(t *testing.T) {
 	env := NewTestStoreEnv(t, "TestStoreIteratorError", nil, pvtDataConf())
 	defer env.Cleanup()
 	store := env.TestStore
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 	env.TestStoreProvider.Close()
 	errStr := "internal leveldb error while obtaining db iterator: leveldb: closed"
 
@@ -214,8 +282,8 @@ func TestGetMissingDataInfo(t *testing.T) {
 		blk1MissingData.Add(1, "ns-1", "coll-1", true)
 		blk1MissingData.Add(1, "ns-1", "coll-2", true)
 
-		require.NoError(t, store.Commit(0, nil, nil))
-		require.NoError(t, store.Commit(1, nil, blk1MissingData))
+		require.NoError(t, store.Commit(0, nil, nil, nil))
+		require.NoError(t, store.Commit(1, nil, blk1MissingData, nil))
 
 		deprioritizedList := ledger.MissingPvtDataInfo{
 			1: ledger.MissingBlockPvtdataInfo{
@@ -297,6 +365,60 @@ func TestGetMissingDataInfo(t *testing.T) {
 	})
 }
 
+func TestGetMissingDataInfoForBlockRange(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestGetMissingDataInfoForBlockRange", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	store := env.TestStore
+
+	require.NoError(t, store.Commit(0, nil, nil, nil))
+	for blkNum := uint64(1); blkNum <= 4; blkNum++ {
+		missingData := make(ledger.TxMissingPvtData)
+		missingData.Add(1, "ns-1", "coll-1", true)
+		missingData.Add(1, "ns-1", "coll-2", true)
+		require.NoError(t, store.Commit(blkNum, nil, missingData, nil))
+	}
+	// move the missing data of block 3 for coll-2 to the deprioritized list
+	require.NoError(t, store.CommitPvtDataOfOldBlocks(nil, ledger.MissingPvtDataInfo{
+		3: ledger.MissingBlockPvtdataInfo{
+			1: {{Namespace: "ns-1", Collection: "coll-2"}},
+		},
+	}))
+
+	onlyColl2 := func(ns, coll string) bool { return coll == "coll-2" }
+
+	t.Run("range and filter", func(t *testing.T) {
+		missingPvtDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(2, 3, 10, onlyColl2)
+		require.NoError(t, err)
+		expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+		expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-2")
+		expectedMissingPvtDataInfo.Add(2, 1, "ns-1", "coll-2")
+		require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
+	})
+
+	t.Run("max blocks retains the most recent blocks", func(t *testing.T) {
+		missingPvtDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(1, math.MaxUint64, 2, nil)
+		require.NoError(t, err)
+		expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+		expectedMissingPvtDataInfo.Add(4, 1, "ns-1", "coll-1")
+		expectedMissingPvtDataInfo.Add(4, 1, "ns-1", "coll-2")
+		expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-1")
+		expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-2")
+		require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		missingPvtDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(3, 2, 10, nil)
+		require.NoError(t, err)
+		require.Empty(t, missingPvtDataInfo)
+	})
+}
+
 func TestExpiryDataNotIncluded(t *testing.T) {
 	ledgerid := "TestExpiryDataNotIncluded"
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -329,21 +451,21 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	blk2MissingData.Add(1, "ns-1", "coll-2", true)
 
 	// no pvt data with block 0
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 
 	// write pvt data for block 1
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(t, store.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(t, store.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// write pvt data for block 2
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 5, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(t, store.Commit(2, testDataForBlk2, blk2MissingData))
+	require.NoError(t, store.Commit(2, testDataForBlk2, blk2MissingData, nil))
 
 	retrievedData, _ := store.GetPvtDataByBlockNum(1, nil)
 	// block 1 data should still be not expired
@@ -367,7 +489,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 3 with no pvtdata
-	require.NoError(t, store.Commit(3, nil, nil))
+	require.NoError(t, store.Commit(3, nil, nil, nil))
 
 	// After committing block 3, the data for "ns-1:coll1" of block 1 should have expired and should not be returned by the store
 	expectedPvtdataFromBlock1 := []*ledger.TxPvtData{
@@ -390,7 +512,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	require.Equal(t, expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 4 with no pvtdata
-	require.NoError(t, store.Commit(4, nil, nil))
+	require.NoError(t, store.Commit(4, nil, nil, nil))
 
 	// After committing block 4, the data for "ns-2:coll2" of block 1 should also have expired and should not be returned by the store
 	expectedPvtdataFromBlock1 = []*ledger.TxPvtData{
@@ -438,7 +560,7 @@ func TestStorePurge(t *testing.T) {
 	s := env.TestStore
 
 	// no pvt data with block 0
-	require.NoError(t, s.Commit(0, nil, nil))
+	require.NoError(t, s.Commit(0, nil, nil, nil))
 
 	// construct missing data for block 1
 	blk1MissingData := make(ledger.TxMissingPvtData)
@@ -457,10 +579,10 @@ func TestStorePurge(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(t, s.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(t, s.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// write pvt data for block 2
-	require.NoError(t, s.Commit(2, nil, nil))
+	require.NoError(t, s.Commit(2, nil, nil, nil))
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store
 	ns1Coll1 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}
 	ns2Coll2 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-2", coll: "coll-2", blkNum: 1}, txNum: 2}
@@ -519,7 +641,7 @@ func TestStorePurge(t *testing.T) {
 	require.NoError(t, s.CommitPvtDataOfOldBlocks(nil, deprioritizedList))
 
 	// write pvt data for block 3
-	require.NoError(t, s.Commit(3, nil, nil))
+	require.NoError(t, s.Commit(3, nil, nil, nil))
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store (because purger should not be launched at block 3)
 	testWaitForPurgerRoutineToFinish(s)
 	require.True(t, testDataKeyExists(t, s, ns1Coll1))
@@ -539,7 +661,7 @@ func TestStorePurge(t *testing.T) {
 	require.True(t, testInelgMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 4
-	require.NoError(t, s.Commit(4, nil, nil))
+	require.NoError(t, s.Commit(4, nil, nil, nil))
 	// data for ns-1:coll-1 should not exist in store (because purger should be launched at block 4)
 	// but ns-2:coll-2 should exist because it expires at block 5
 	testWaitForPurgerRoutineToFinish(s)
@@ -559,7 +681,7 @@ func TestStorePurge(t *testing.T) {
 	require.True(t, testInelgMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 5
-	require.NoError(t, s.Commit(5, nil, nil))
+	require.NoError(t, s.Commit(5, nil, nil, nil))
 	// ns-2:coll-2 should exist because though the data expires at block 5 but purger is launched every second block
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(t, testDataKeyExists(t, s, ns1Coll1))
@@ -569,7 +691,7 @@ func TestStorePurge(t *testing.T) {
 	require.True(t, testHashedIndexExists(t, s, ns2Coll2Blk1Tx2HI))
 
 	// write pvt data for block 6
-	require.NoError(t, s.Commit(6, nil, nil))
+	require.NoError(t, s.Commit(6, nil, nil, nil))
 	// ns-2:coll-2 should not exists now (because purger should be launched at block 6)
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(t, testDataKeyExists(t, s, ns1Coll1))
@@ -606,7 +728,7 @@ func TestStoreState(t *testing.T) {
 	}
 
 	require.EqualError(t,
-		store.Commit(1, testData, nil),
+		store.Commit(1, testData, nil, nil),
 		"expected block number=0, received block number=1",
 	)
 }
@@ -722,10 +844,10 @@ func TestDrop(t *testing.T) {
 	blk1MissingData.Add(1, "ns-2", "coll-2", true)
 
 	// no pvt data with block 0
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 
 	// pvt data with block 1 - commit
-	require.NoError(t, store.Commit(1, testData, blk1MissingData))
+	require.NoError(t, store.Commit(1, testData, blk1MissingData, nil))
 
 	// pvt data retrieval for block 0 should return nil
 	var nilFilter ledger.PvtNsCollFilter
@@ -784,7 +906,7 @@ func TestStoreFilterPurgedKeys(t *testing.T) {
 	}
 
 	// no pvt data with block 0
-	require.NoError(t, s.Commit(0, nil, nil))
+	require.NoError(t, s.Commit(0, nil, nil, nil))
 
 	txWriteSet := &rwsetutil.TxPvtRwSet{
 		NsPvtRwSet: []*rwsetutil.NsPvtRwSet{
@@ -832,7 +954,7 @@ func TestStoreFilterPurgedKeys(t *testing.T) {
 			WriteSet:   txWriteSetProto,
 		},
 	}
-	require.NoError(t, s.Commit(1, testDataForBlk1, nil))
+	require.NoError(t, s.Commit(1, testDataForBlk1, nil, nil))
 
 	pvtdata, err := s.GetPvtDataByBlockNum(1, nil)
 	require.NoError(t, err)
@@ -966,6 +1088,95 @@ func TestStoreFilterPurgedKeys(t *testing.T) {
 	)
 }
 
+func TestStoreDeleteDataMarkedForPurge(t *testing.T) {
+	ledgerid := "TestStoreDeleteDataMarkedForPurge"
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	s := env.TestStore
+
+	require.NoError(t, s.Commit(0, nil, nil, nil))
+
+	commitKeys := func(blkNum, txNum uint64, keys ...string) {
+		builder := rwsetutil.NewRWSetBuilder()
+		for _, key := range keys {
+			builder.AddToPvtAndHashedWriteSet("ns-1", "coll-1", key, []byte("value-of-"+key))
+		}
+		simRes, err := builder.GetTxSimulationResults()
+		require.NoError(t, err)
+		pvtData := []*ledger.TxPvtData{{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}}
+		require.NoError(t, s.Commit(blkNum, pvtData, nil, nil))
+	}
+	hashedIndex := func(key string, blkNum, txNum uint64) *hashedIndexKey {
+		return &hashedIndexKey{
+			ns:         "ns-1",
+			coll:       "coll-1",
+			pvtkeyHash: util.ComputeStringHash(key),
+			blkNum:     blkNum,
+			txNum:      txNum,
+		}
+	}
+	retrievedKeys := func(blkNum uint64) []string {
+		pvtdata, err := s.GetPvtDataByBlockNum(blkNum, nil)
+		require.NoError(t, err)
+		require.Len(t, pvtdata, 1)
+		txPvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(pvtdata[0].WriteSet)
+		require.NoError(t, err)
+		keys := []string{}
+		for _, w := range txPvtRWSet.NsPvtRwSet[0].CollPvtRwSets[0].KvRwSet.Writes {
+			keys = append(keys, w.Key)
+		}
+		return keys
+	}
+
+	commitKeys(1, 1, "key-1", "key-2")
+	commitKeys(2, 1, "key-1")
+
+	// block 3 purges key-1 in tx 2
+	purgeMarker := &PurgeMarker{
+		Ns:         "ns-1",
+		Coll:       "coll-1",
+		PvtkeyHash: util.ComputeStringHash("key-1"),
+		BlkNum:     3,
+		TxNum:      2,
+	}
+	require.NoError(t, s.Commit(3, nil, nil, []*PurgeMarker{purgeMarker}))
+	commitKeys(4, 1, "key-1")
+
+	// the purged versions are filtered out before the purge is performed
+	require.Equal(t, []string{"key-2"}, retrievedKeys(1))
+	require.Empty(t, retrievedKeys(2))
+	require.True(t, testHashedIndexExists(t, s, hashedIndex("key-1", 1, 1)))
+
+	require.NoError(t, s.deleteDataMarkedForPurge())
+
+	require.False(t, testHashedIndexExists(t, s, hashedIndex("key-1", 1, 1)))
+	require.False(t, testHashedIndexExists(t, s, hashedIndex("key-1", 2, 1)))
+	require.True(t, testHashedIndexExists(t, s, hashedIndex("key-2", 1, 1)))
+	require.True(t, testHashedIndexExists(t, s, hashedIndex("key-1", 4, 1)), "key written after the purge is retained")
+
+	encDataVal, err := s.db.Get(encodeDataKey(&dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 1}))
+	require.NoError(t, err)
+	dataVal, err := decodeDataValue(encDataVal)
+	require.NoError(t, err)
+	collPvtRWSet, err := rwsetutil.CollPvtRwSetFromProtoMsg(dataVal)
+	require.NoError(t, err)
+	require.Len(t, collPvtRWSet.KvRwSet.Writes, 1)
+	require.Equal(t, "key-2", collPvtRWSet.KvRwSet.Writes[0].Key)
+
+	require.Equal(t, []string{"key-1"}, retrievedKeys(4))
+
+	pendingPurgeKeyVal, err := s.db.Get(encodePendingPurgeKey(
+		&purgeMarkerKey{ns: "ns-1", coll: "coll-1", pvtkeyHash: util.ComputeStringHash("key-1")},
+	))
+	require.NoError(t, err)
+	require.Nil(t, pendingPurgeKeyVal)
+}
+
 func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	ledgerid := "TestCollElgEnabled"
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -983,7 +1194,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	// Initial state: eligible for {ns-1:coll-1 and ns-2:coll-1 }
 
 	// no pvt data with block 0
-	require.NoError(t, testStore.Commit(0, nil, nil))
+	require.NoError(t, testStore.Commit(0, nil, nil, nil))
 
 	// construct and commit block 1
 	blk1MissingData := make(ledger.TxMissingPvtData)
@@ -994,7 +1205,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
 	}
-	require.NoError(t, testStore.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(t, testStore.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// construct and commit block 2
 	blk2MissingData := make(ledger.TxMissingPvtData)
@@ -1004,7 +1215,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}),
 	}
-	require.NoError(t, testStore.Commit(2, testDataForBlk2, blk2MissingData))
+	require.NoError(t, testStore.Commit(2, testDataForBlk2, blk2MissingData, nil))
 
 	// Retrieve and verify missing data reported
 	// Expected missing data should be only blk1-tx1 (because, the other missing data is marked as ineliigible)
@@ -48,6 +48,13 @@ type ExternalBuilder struct {
 	Path                 string   `yaml:"path"`
 }
 
+// InProcChaincode represents the configuration structure of a Go
+// chaincode plugin served from within the peer process
+type InProcChaincode struct {
+	PackageID string `yaml:"packageID"`
+	Library   string `yaml:"library"`
+}
+
 // Config is the struct that defines the Peer configurations.
 type Config struct {
 	// LocalMSPID is the identifier of the local MSP.
@@ -166,6 +173,16 @@ type Config struct {
 	// chaincode. The external builder detection processing will iterate over the
 	// builders in the order specified below.
 	ExternalBuilders []ExternalBuilder
+	// ExternalBuilderCachePath is the directory used to share external builder
+	// output between peers. When empty, build output is not cached.
+	ExternalBuilderCachePath string
+	// ExternalBuilderCacheKeyFile is the file holding the key used to
+	// authenticate the entries of the external builder cache. It is required
+	// when ExternalBuilderCachePath is set.
+	ExternalBuilderCacheKeyFile string
+	// InProcChaincodes represents the Go chaincode plugins which are served
+	// from within the peer process instead of being built and launched.
+	InProcChaincodes []InProcChaincode
 
 	// ----- Operations config -----
 	// TODO: create separate sub-struct for Operations config.
@@ -280,6 +297,9 @@ func (c *Config) load() error {
 	}
 
 	c.GatewayOptions = gatewayconfig.GetOptions(viper.GetViper())
+	if err := c.GatewayOptions.Validate(); err != nil {
+		return errors.WithMessage(err, "invalid gateway configuration")
+	}
 
 	c.VMEndpoint = viper.GetString("vm.endpoint")
 	c.VMDockerTLSEnabled = viper.GetBool("vm.docker.tls.enabled")
@@ -310,6 +330,26 @@ func (c *Config) load() error {
 			c.ExternalBuilders[builderIndex].PropagateEnvironment = builder.Environment
 		}
 	}
+	c.ExternalBuilderCachePath = config.GetPath("chaincode.externalBuilderCache.path")
+	c.ExternalBuilderCacheKeyFile = config.GetPath("chaincode.externalBuilderCache.keyFile")
+	if c.ExternalBuilderCachePath != "" && c.ExternalBuilderCacheKeyFile == "" {
+		return fmt.Errorf("external builder cache at %s has no keyFile attribute", c.ExternalBuilderCachePath)
+	}
+
+	var inProcChaincodes []InProcChaincode
+	err = viper.UnmarshalKey("chaincode.inProcChaincodes", &inProcChaincodes, viper.DecodeHook(viperutil.YamlStringToStructHook(inProcChaincodes)))
+	if err != nil {
+		return err
+	}
+	for _, cc := range inProcChaincodes {
+		if cc.PackageID == "" {
+			return fmt.Errorf("invalid in-process chaincode configuration, packageID attribute missing in one or more chaincodes")
+		}
+		if cc.Library == "" {
+			return fmt.Errorf("in-process chaincode %s has no library attribute", cc.PackageID)
+		}
+	}
+	c.InProcChaincodes = inProcChaincodes
 
 	c.OperationsListenAddress = viper.GetString("operations.listenAddress")
 	c.OperationsTLSEnabled = viper.GetBool("operations.tls.enabled")
@@ -321,6 +321,14 @@ func TestGlobalConfig(t *testing.T) {
 			Name: "absolute",
 		},
 	})
+	viper.Set("chaincode.externalBuilderCache.path", "relative/cache_dir")
+	viper.Set("chaincode.externalBuilderCache.keyFile", "/absolute/cache.key")
+	viper.Set("chaincode.inProcChaincodes", &[]InProcChaincode{
+		{
+			PackageID: "mycc_1.0:abcdef",
+			Library:   "/plugins/mycc.so",
+		},
+	})
 
 	coreConfig, err := GlobalConfig()
 	require.NoError(t, err)
@@ -364,6 +372,14 @@ func TestGlobalConfig(t *testing.T) {
 				Name: "absolute",
 			},
 		},
+		ExternalBuilderCachePath:    filepath.Join(cwd, "relative/cache_dir"),
+		ExternalBuilderCacheKeyFile: "/absolute/cache.key",
+		InProcChaincodes: []InProcChaincode{
+			{
+				PackageID: "mycc_1.0:abcdef",
+				Library:   "/plugins/mycc.so",
+			},
+		},
 		OperationsListenAddress:         "127.0.0.1:9443",
 		OperationsTLSEnabled:            false,
 		OperationsTLSCertFile:           filepath.Join(cwd, "test/tls/cert/file"),
@@ -385,9 +401,33 @@ func TestGlobalConfig(t *testing.T) {
 		DockerCA:   filepath.Join(cwd, "test/vm/tls/ca/file"),
 
 		GatewayOptions: config.Options{
-			Enabled:            true,
-			EndorsementTimeout: 10 * time.Second,
-			DialTimeout:        60 * time.Second,
+			Enabled:                 true,
+			EndorsementTimeout:      10 * time.Second,
+			DialTimeout:             60 * time.Second,
+			EndorserSelection:       config.HeightFirst,
+			EndorserHeightTolerance: 2,
+			HTTP: config.HTTPOptions{
+				ListenAddress: "0.0.0.0:7080",
+				WriteTimeout:  2 * time.Minute,
+			},
+			Resubmit: config.ResubmitOptions{
+				MaxAttempts: 5,
+			},
+			Orderer: config.OrdererOptions{
+				ParallelSubmit:   1,
+				FailureThreshold: 3,
+				ResetTimeout:     30 * time.Second,
+			},
+			Checkpoints: config.CheckpointOptions{
+				Enabled:        false,
+				MaxPerIdentity: 100,
+				Expiry:         30 * 24 * time.Hour,
+			},
+			RateLimit: config.RateLimitOptions{
+				MSP:       config.RateLimit{Rate: 0, Burst: 0},
+				Identity:  config.RateLimit{Rate: 0, Burst: 0},
+				Chaincode: config.RateLimit{Rate: 0, Burst: 0},
+			},
 		},
 	}
 
@@ -499,6 +539,42 @@ func TestMissingExternalBuilderPath(t *testing.T) {
 	require.EqualError(t, err, "invalid external builder configuration, path attribute missing in one or more builders")
 }
 
+func TestMissingInProcChaincodeAttributes(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("chaincode.inProcChaincodes", &[]InProcChaincode{
+		{
+			Library: "/plugins/mycc.so",
+		},
+	})
+	_, err := GlobalConfig()
+	require.EqualError(t, err, "invalid in-process chaincode configuration, packageID attribute missing in one or more chaincodes")
+
+	viper.Set("chaincode.inProcChaincodes", &[]InProcChaincode{
+		{
+			PackageID: "mycc_1.0:abcdef",
+		},
+	})
+	_, err = GlobalConfig()
+	require.EqualError(t, err, "in-process chaincode mycc_1.0:abcdef has no library attribute")
+}
+
+func TestMissingExternalBuilderCacheKeyFile(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("chaincode.externalBuilderCache.path", "/absolute/cache_dir")
+	_, err := GlobalConfig()
+	require.EqualError(t, err, "external builder cache at /absolute/cache_dir has no keyFile attribute")
+}
+
+func TestInvalidGatewayOptions(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("peer.gateway.endorserSelection", "fastest")
+	_, err := GlobalConfig()
+	require.EqualError(t, err, "invalid gateway configuration: unknown endorser selection strategy: fastest")
+}
+
 func TestMissingExternalBuilderName(t *testing.T) {
 	defer viper.Reset()
 	viper.Set("peer.address", "localhost:8080")
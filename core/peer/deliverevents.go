@@ -183,7 +183,20 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 		return nil, errors.New("wrong chain type")
 	}
 
-	pvtData, err := channel.Ledger().GetPvtDataByNum(block.Header.Number, nil)
+	return eligiblePrivateData(block, channel.Ledger(), channelID, signedData, bprs.CollectionPolicyChecker, bprs.IdentityDeserializerManager)
+}
+
+// eligiblePrivateData returns the private data for the block from the
+// collections that the creator of the signed data is eligible to read
+func eligiblePrivateData(
+	block *common.Block,
+	ledger ledger.PeerLedger,
+	channelID string,
+	signedData *protoutil.SignedData,
+	collectionPolicyChecker CollectionPolicyChecker,
+	identityDeserializerMgr IdentityDeserializerManager,
+) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	pvtData, err := ledger.GetPvtDataByNum(block.Header.Number, nil)
 	if err != nil {
 		logger.Errorf("Error getting private data by block number %d on channel %s", block.Header.Number, channelID)
 		return nil, errors.Wrapf(err, "error getting private data by block number %d", block.Header.Number)
@@ -191,12 +204,12 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 
 	seqs2Namespaces := aggregatedCollections(make(map[seqAndDataModel]map[string][]*rwset.CollectionPvtReadWriteSet))
 
-	configHistoryRetriever, err := channel.Ledger().GetConfigHistoryRetriever()
+	configHistoryRetriever, err := ledger.GetConfigHistoryRetriever()
 	if err != nil {
 		return nil, err
 	}
 
-	identityDeserializer, err := bprs.IdentityDeserializerManager.Deserializer(channelID)
+	identityDeserializer, err := identityDeserializerMgr.Deserializer(channelID)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +224,7 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 			for _, col := range ns.CollectionPvtRwset {
 				logger.Debugf("Checking policy for namespace %s, collection %s", ns.Namespace, col.CollectionName)
 
-				eligible, err := bprs.CollectionPolicyChecker.CheckCollectionPolicy(block.Header.Number,
+				eligible, err := collectionPolicyChecker.CheckCollectionPolicy(block.Header.Number,
 					ns.Namespace, col.CollectionName, configHistoryRetriever, identityDeserializer, signedData)
 				if err != nil {
 					return nil, err
@@ -228,6 +241,43 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 	return seqs2Namespaces.asPrivateDataMap(), nil
 }
 
+// PrivateDataFilter provides the private data of committed blocks that a
+// client is eligible to read, in the same way as the DeliverWithPrivateData
+// service.
+type PrivateDataFilter struct {
+	Peer                    *Peer
+	CollectionPolicyChecker CollectionPolicyChecker
+	IdentityDeserializerMgr IdentityDeserializerManager
+}
+
+// NewPrivateDataFilter creates a PrivateDataFilter for the channels of the
+// peer that checks collection eligibility using the channel MSPs.
+func NewPrivateDataFilter(peer *Peer) *PrivateDataFilter {
+	return &PrivateDataFilter{
+		Peer:                    peer,
+		CollectionPolicyChecker: &collPolicyChecker{},
+		IdentityDeserializerMgr: &identityDeserializerMgr{},
+	}
+}
+
+// PrivateData returns the private data for the block from the collections
+// that the creator of the signed data is eligible to read.
+func (f *PrivateDataFilter) PrivateData(channelID string, block *common.Block, signedData *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	channel := f.Peer.Channel(channelID)
+	if channel == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+
+	return eligiblePrivateData(block, channel.Ledger(), channelID, signedData, f.CollectionPolicyChecker, f.IdentityDeserializerMgr)
+}
+
+// FilteredBlock returns the filtered representation of the block, as sent
+// by the DeliverFiltered service.
+func FilteredBlock(block *common.Block) (*peer.FilteredBlock, error) {
+	b := blockEvent(*block)
+	return b.toFilteredBlock()
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
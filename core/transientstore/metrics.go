@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstore
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	entriesOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "entries",
+		Help:         "Number of private write sets held in the transient store.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	purgedEntriesOpts = metrics.CounterOpts{
+		Namespace:    "transientstore",
+		Subsystem:    "",
+		Name:         "purged_entries",
+		Help:         "Number of private write sets purged from the transient store, by reason of the purge: txid, height or age.",
+		LabelNames:   []string{"channel", "reason"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{reason}",
+	}
+)
+
+type stats struct {
+	entries       metrics.Gauge
+	purgedEntries metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		entries:       metricsProvider.NewGauge(entriesOpts),
+		purgedEntries: metricsProvider.NewCounter(purgedEntriesOpts),
+	}
+}
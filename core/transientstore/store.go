@@ -8,14 +8,19 @@ package transientstore
 
 import (
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
@@ -53,6 +58,42 @@ type RWSetScanner interface {
 	Close()
 }
 
+// PurgedKey identifies, by its hash, a private data key that has been explicitly purged from a collection
+type PurgedKey struct {
+	Namespace  string
+	Collection string
+	KeyHash    []byte
+}
+
+// EntryInfo describes a private write set held in the transient store, without its content
+type EntryInfo struct {
+	TxID                  string
+	UUID                  string
+	ReceivedAtBlockHeight uint64
+	// PersistTime is zero for the private write sets persisted before the persist time was recorded
+	PersistTime time.Time
+	// Size is the number of bytes the private write set takes in the store
+	Size        int
+	Collections []*EntryCollection
+}
+
+// EntryCollection identifies a collection a private write set holds data for
+type EntryCollection struct {
+	Namespace  string
+	Collection string
+}
+
+// StoreStats summarizes the content of the transient store of a channel
+type StoreStats struct {
+	Entries uint64
+	// Size is the number of bytes the private write sets take in the store
+	Size           uint64
+	MinBlockHeight uint64
+	MaxBlockHeight uint64
+	// OldestPersistTime is the persist time of the oldest private write set, or zero if no persist time was recorded
+	OldestPersistTime time.Time
+}
+
 // EndorserPvtSimulationResults captures the details of the simulation results specific to an endorser
 type EndorserPvtSimulationResults struct {
 	ReceivedAtBlockHeight          uint64
@@ -69,23 +110,46 @@ type EndorserPvtSimulationResults struct {
 type storeProvider struct {
 	dbProvider *leveldbhelper.Provider
 	fileLock   *leveldbhelper.FileLock
+	encryptor  ledger.PvtDataEncryptor
+	stats      *stats
 }
 
 // store holds an instance of a levelDB.
 type Store struct {
-	db       *leveldbhelper.DBHandle
-	ledgerID string
+	db        *leveldbhelper.DBHandle
+	ledgerID  string
+	encryptor ledger.PvtDataEncryptor
+	stats     *stats
+
+	// purgeLock serializes the purges so that each removed private write set is counted once
+	purgeLock    sync.Mutex
+	entriesMutex sync.Mutex
+	entries      int64
 }
 
 // RwsetScanner helps iterating over results
 type RwsetScanner struct {
-	txid   string
-	dbItr  iterator.Iterator
-	filter ledger.PvtNsCollFilter
+	txid      string
+	dbItr     iterator.Iterator
+	filter    ledger.PvtNsCollFilter
+	encryptor ledger.PvtDataEncryptor
 }
 
 // NewStoreProvider instantiates TransientStoreProvider
 func NewStoreProvider(path string) (StoreProvider, error) {
+	return NewStoreProviderWithEncryptor(path, nil)
+}
+
+// NewStoreProviderWithEncryptor instantiates TransientStoreProvider. The private write sets are
+// encrypted by the given encryptor before they are stored, unless it is nil.
+func NewStoreProviderWithEncryptor(path string, encryptor ledger.PvtDataEncryptor) (StoreProvider, error) {
+	return NewStoreProviderWithMetrics(path, encryptor, &disabled.Provider{})
+}
+
+// NewStoreProviderWithMetrics instantiates TransientStoreProvider, which reports the number of private
+// write sets held by each store to the given metrics provider. The private write sets are encrypted
+// by the given encryptor before they are stored, unless it is nil.
+func NewStoreProviderWithMetrics(path string, encryptor ledger.PvtDataEncryptor, metricsProvider metrics.Provider) (StoreProvider, error) {
 	// Ensure the routine is invoked while the peer is down.
 	lockPath := filepath.Join(filepath.Dir(path), transientStorageLockName)
 	lock := leveldbhelper.NewFileLock(lockPath)
@@ -99,6 +163,8 @@ func NewStoreProvider(path string) (StoreProvider, error) {
 		lock.Unlock()
 		return nil, errors.WithMessagef(err, "could not construct storage provider in folder [%s]", path)
 	}
+	provider.encryptor = encryptor
+	provider.stats = newStats(metricsProvider)
 
 	return provider, nil
 }
@@ -131,7 +197,19 @@ func newStoreProvider(providerPath string, fileLock *leveldbhelper.FileLock) (*s
 // OpenStore returns a handle to a ledgerId in Store
 func (provider *storeProvider) OpenStore(ledgerID string) (*Store, error) {
 	dbHandle := provider.dbProvider.GetDBHandle(ledgerID)
-	return &Store{db: dbHandle, ledgerID: ledgerID}, nil
+	s := &Store{db: dbHandle, ledgerID: ledgerID, encryptor: provider.encryptor, stats: provider.stats}
+	if s.stats == nil {
+		s.stats = newStats(&disabled.Provider{})
+	}
+
+	// Count the private write sets already held by the store, so that the
+	// count can be maintained while they are persisted and purged
+	entries, err := s.countEntries()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while counting the entries of transient store [%s]", ledgerID)
+	}
+	s.addEntries(entries)
+	return s, nil
 }
 
 // Close closes the TransientStoreProvider
@@ -323,6 +401,7 @@ func (s *Store) Persist(txid string, blockHeight uint64,
 	// Due to the fact that the txid may have multiple private write sets persisted from different
 	// endorsers (via Gossip), we postfix an uuid with the txid to avoid collision.
 	uuid := util.GenerateUUID()
+	persistTime := uint64(time.Now().UnixNano())
 	compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
 	privateSimulationResultsWithConfigBytes, err := proto.Marshal(privateSimulationResultsWithConfig)
 	if err != nil {
@@ -335,22 +414,28 @@ func (s *Store) Persist(txid string, blockHeight uint64,
 	// as a marshaled message can never start with a nil byte. In v1.3, we can avoid prepending the
 	// nil byte.
 	value := append([]byte{nilByte}, privateSimulationResultsWithConfigBytes...)
+	if s.encryptor != nil {
+		if value, err = s.encryptor.Encrypt(value); err != nil {
+			return errors.WithMessage(err, "error while encrypting private write set")
+		}
+	}
 	dbBatch.Put(compositeKeyPvtRWSet, value)
 
-	// Create two index: (i) by txid, and (ii) by height
+	// Create three index: (i) by txid, (ii) by height, and (iii) by age. The indexes by txid and
+	// by height hold the persist time as value, so that the index by age can be found from them.
 
 	// Create compositeKey for purge index by height with appropriate prefix, blockHeight,
-	// txid, uuid and store the compositeKey (purge index) with a nil byte as value. Note that
+	// txid, uuid and store the compositeKey (purge index) with the persist time as value. Note that
 	// the purge index is used to remove orphan entries in the transient store (which are not removed
 	// by PurgeTxids()) using BTL policy by PurgeBelowHeight(). Note that orphan entries are due to transaction
 	// that gets endorsed but not submitted by the client for commit)
 	compositeKeyPurgeIndexByHeight := createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid)
-	dbBatch.Put(compositeKeyPurgeIndexByHeight, emptyValue)
+	dbBatch.Put(compositeKeyPurgeIndexByHeight, encodePersistTime(persistTime))
 
 	// Create compositeKey for purge index by txid with appropriate prefix, txid, uuid,
-	// blockHeight and store the compositeKey (purge index) with a nil byte as value.
+	// blockHeight and store the compositeKey (purge index) with the persist time as value.
 	// Though compositeKeyPvtRWSet itself can be used to purge private write set by txid,
-	// we create a separate composite key with a nil byte as value. The reason is that
+	// we create a separate composite key with the persist time as value. The reason is that
 	// if we use compositeKeyPvtRWSet, we unnecessarily read (potentially large) private write
 	// set associated with the key from db. Note that this purge index is used to remove non-orphan
 	// entries in the transient store and is used by PurgeTxids()
@@ -358,9 +443,19 @@ func (s *Store) Persist(txid string, blockHeight uint64,
 	// with purgeIndexByTxidPrefix. For code readability and to be expressive, we use a
 	// createCompositeKeyForPurgeIndexByTxid() instead.
 	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
-	dbBatch.Put(compositeKeyPurgeIndexByTxid, emptyValue)
+	dbBatch.Put(compositeKeyPurgeIndexByTxid, encodePersistTime(persistTime))
 
-	return s.db.WriteBatch(dbBatch, true)
+	// Create compositeKey for purge index by age with appropriate prefix, persistTime, txid, uuid,
+	// blockHeight. This purge index is used to remove the entries older than a given time by
+	// PurgeOlderThan().
+	compositeKeyPurgeIndexByAge := createCompositeKeyForPurgeIndexByAge(persistTime, txid, uuid, blockHeight)
+	dbBatch.Put(compositeKeyPurgeIndexByAge, emptyValue)
+
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.addEntries(1)
+	return nil
 }
 
 // GetTxPvtRWSetByTxid returns an iterator due to the fact that the txid may have multiple private
@@ -376,25 +471,46 @@ func (s *Store) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter)
 	if err != nil {
 		return nil, err
 	}
-	return &RwsetScanner{txid, iter, filter}, nil
+	return &RwsetScanner{txid: txid, dbItr: iter, filter: filter, encryptor: s.encryptor}, nil
 }
 
 // PurgeByTxids removes private write sets of a given set of transactions from the
 // transient store. PurgeByTxids() is expected to be called by coordinator after
 // committing a block to ledger.
 func (s *Store) PurgeByTxids(txids []string) error {
+	_, err := s.purgeByTxids(txids)
+	return err
+}
+
+// PurgeEntriesByTxids removes private write sets of a given set of transactions from the
+// transient store, like PurgeByTxids(), and returns the number of private write sets removed.
+func (s *Store) PurgeEntriesByTxids(txids []string) (int, error) {
+	return s.purgeByTxids(txids)
+}
+
+func (s *Store) purgeByTxids(txids []string) (int, error) {
 	logger.Debug("Purging private data from transient store for committed txids")
 
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
 	dbBatch := s.db.NewUpdateBatch()
+	purged := 0
+	visited := map[string]struct{}{}
 
 	for _, txid := range txids {
+		if _, ok := visited[txid]; ok {
+			continue
+		}
+		visited[txid] = struct{}{}
+
 		// Construct startKey and endKey to do an range query
 		startKey := createPurgeIndexByTxidRangeStartKey(txid)
 		endKey := createPurgeIndexByTxidRangeEndKey(txid)
 
 		iter, err := s.db.GetIterator(startKey, endKey)
 		if err != nil {
-			return err
+			return 0, err
 		}
 
 		// Get all txid and uuid from above result and remove it from transient store (both
@@ -408,7 +524,13 @@ func (s *Store) PurgeByTxids(txids []string) error {
 			// with  prwsetPrefix. For code readability and to be expressive, we split and create again.
 			uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByTxid(compositeKeyPurgeIndexByTxid)
 			if err != nil {
-				return err
+				iter.Release()
+				return 0, err
+			}
+			persistTime, err := decodePersistTime(iter.Value())
+			if err != nil {
+				iter.Release()
+				return 0, err
 			}
 			compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
 			dbBatch.Delete(compositeKeyPvtRWSet)
@@ -417,13 +539,98 @@ func (s *Store) PurgeByTxids(txids []string) error {
 			compositeKeyPurgeIndexByHeight := createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid)
 			dbBatch.Delete(compositeKeyPurgeIndexByHeight)
 
+			// Remove purge index -- purgeIndexByAge, if the persist time was recorded
+			if persistTime != 0 {
+				dbBatch.Delete(createCompositeKeyForPurgeIndexByAge(persistTime, txid, uuid, blockHeight))
+			}
+
 			// Remove purge index -- purgeIndexByTxid
 			dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+			purged++
 		}
 		iter.Release()
 	}
 	// If peer fails before/while writing the batch to golevelDB, these entries will be
 	// removed as per BTL policy later by PurgeBelowHeight()
+	return purged, s.writePurgeBatch(dbBatch, purged, "txid")
+}
+
+// PurgeKeys removes the given private data keys from all the private write sets held in the
+// transient store. PurgeKeys() is expected to be called by coordinator after committing a block
+// that explicitly purges private data, so that the purged values cannot be disseminated any further.
+func (s *Store) PurgeKeys(keys []*PurgedKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	logger.Debugf("Purging [%d] explicitly purged keys from transient store", len(keys))
+
+	toPurge := map[string]map[string]map[string]struct{}{}
+	for _, k := range keys {
+		colls, ok := toPurge[k.Namespace]
+		if !ok {
+			colls = map[string]map[string]struct{}{}
+			toPurge[k.Namespace] = colls
+		}
+		keyHashes, ok := colls[k.Collection]
+		if !ok {
+			keyHashes = map[string]struct{}{}
+			colls[k.Collection] = keyHashes
+		}
+		keyHashes[string(k.KeyHash)] = struct{}{}
+	}
+
+	iter, err := s.db.GetIterator([]byte{prwsetPrefix, compositeKeySep}, []byte{prwsetPrefix, compositeKeySep + 1})
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	dbBatch := s.db.NewUpdateBatch()
+	for iter.Next() {
+		dbVal, err := decryptValue(iter.Value(), s.encryptor)
+		if err != nil {
+			return err
+		}
+		txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+		txPvtRWSet := &rwset.TxPvtReadWriteSet{}
+		newProto := len(dbVal) > 0 && dbVal[0] == nilByte
+		if newProto {
+			if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+				return err
+			}
+			txPvtRWSet = txPvtRWSetWithConfig.GetPvtRwset()
+		} else if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+			return err
+		}
+
+		modified, err := removeKeysFromPvtWSet(txPvtRWSet, toPurge)
+		if err != nil {
+			return err
+		}
+		if !modified {
+			continue
+		}
+
+		var value []byte
+		if newProto {
+			txPvtRWSetWithConfigBytes, err := proto.Marshal(txPvtRWSetWithConfig)
+			if err != nil {
+				return err
+			}
+			value = append([]byte{nilByte}, txPvtRWSetWithConfigBytes...)
+		} else if value, err = proto.Marshal(txPvtRWSet); err != nil {
+			return err
+		}
+		if s.encryptor != nil {
+			if value, err = s.encryptor.Encrypt(value); err != nil {
+				return errors.WithMessage(err, "error while encrypting private write set")
+			}
+		}
+		dbBatch.Put(append([]byte{}, iter.Key()...), value)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
 	return s.db.WriteBatch(dbBatch, true)
 }
 
@@ -436,6 +643,9 @@ func (s *Store) PurgeByTxids(txids []string) error {
 func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 	logger.Debugf("Purging orphaned private data from transient store received prior to block [%d]", maxBlockNumToRetain)
 
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
 	// Do a range query with 0 as startKey and maxBlockNumToRetain-1 as endKey
 	startKey := createPurgeIndexByHeightRangeStartKey(0)
 	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockNumToRetain - 1)
@@ -444,7 +654,10 @@ func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 		return err
 	}
 
+	defer iter.Release()
+
 	dbBatch := s.db.NewUpdateBatch()
+	purged := 0
 
 	// Get all txid and uuid from above result and remove it from transient store (both
 	// write set and the corresponding index.
@@ -457,6 +670,10 @@ func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 		if err != nil {
 			return err
 		}
+		persistTime, err := decodePersistTime(iter.Value())
+		if err != nil {
+			return err
+		}
 		logger.Debugf("Purging from transient store private data simulated at block [%d]: txid [%s] uuid [%s]", blockHeight, txid, uuid)
 
 		compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
@@ -466,12 +683,242 @@ func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 		compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
 		dbBatch.Delete(compositeKeyPurgeIndexByTxid)
 
+		// Remove purge index -- purgeIndexByAge, if the persist time was recorded
+		if persistTime != 0 {
+			dbBatch.Delete(createCompositeKeyForPurgeIndexByAge(persistTime, txid, uuid, blockHeight))
+		}
+
 		// Remove purge index -- purgeIndexByHeight
 		dbBatch.Delete(compositeKeyPurgeIndexByHeight)
+		purged++
+	}
+	if err := iter.Error(); err != nil {
+		return err
 	}
-	iter.Release()
 
-	return s.db.WriteBatch(dbBatch, true)
+	return s.writePurgeBatch(dbBatch, purged, "height")
+}
+
+// PurgeOlderThan removes private write sets persisted before the given time, and returns the
+// number of private write sets removed. Like PurgeBelowHeight(), PurgeOlderThan() removes orphan
+// entries, based on their age rather than on the block height they were received at. The private
+// write sets persisted before the persist time was recorded are only removed by PurgeBelowHeight().
+func (s *Store) PurgeOlderThan(t time.Time) (int, error) {
+	logger.Debugf("Purging orphaned private data from transient store persisted before [%s]", t)
+
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
+	startKey := []byte{purgeIndexByAgePrefix, compositeKeySep}
+	endKey := createPurgeIndexByAgeRangeEndKey(uint64(t.UnixNano()))
+	iter, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Release()
+
+	dbBatch := s.db.NewUpdateBatch()
+	purged := 0
+
+	for iter.Next() {
+		// For each entry, remove the private read-write set and corresponding indexes
+		compositeKeyPurgeIndexByAge := iter.Key()
+		txid, uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByAge(compositeKeyPurgeIndexByAge)
+		if err != nil {
+			return 0, err
+		}
+		logger.Debugf("Purging from transient store expired private data simulated at block [%d]: txid [%s] uuid [%s]", blockHeight, txid, uuid)
+
+		dbBatch.Delete(createCompositeKeyForPvtRWSet(txid, uuid, blockHeight))
+		dbBatch.Delete(createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight))
+		dbBatch.Delete(createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid))
+		dbBatch.Delete(compositeKeyPurgeIndexByAge)
+		purged++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+
+	return purged, s.writePurgeBatch(dbBatch, purged, "age")
+}
+
+// writePurgeBatch writes the batch removing the given number of private write sets and updates the count of entries
+func (s *Store) writePurgeBatch(dbBatch *leveldbhelper.UpdateBatch, purged int, reason string) error {
+	if purged == 0 {
+		return nil
+	}
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.stats.purgedEntries.With("channel", s.ledgerID, "reason", reason).Add(float64(purged))
+	s.addEntries(-int64(purged))
+	return nil
+}
+
+// addEntries updates the count of private write sets held in the store
+func (s *Store) addEntries(delta int64) {
+	s.entriesMutex.Lock()
+	defer s.entriesMutex.Unlock()
+	s.entries += delta
+	s.stats.entries.With("channel", s.ledgerID).Set(float64(s.entries))
+}
+
+// countEntries counts the private write sets held in the store using the purge index by height
+func (s *Store) countEntries() (int64, error) {
+	iter, err := s.db.GetIterator([]byte{purgeIndexByHeightPrefix, compositeKeySep}, []byte{purgeIndexByHeightPrefix, compositeKeySep + 1})
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Release()
+
+	var entries int64
+	for iter.Next() {
+		entries++
+	}
+	return entries, iter.Error()
+}
+
+// EntriesByTxid returns the description of the private write sets of the given transaction held in the store
+func (s *Store) EntriesByTxid(txid string) ([]*EntryInfo, error) {
+	iter, err := s.db.GetIterator(createPurgeIndexByTxidRangeStartKey(txid), createPurgeIndexByTxidRangeEndKey(txid))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	var entries []*EntryInfo
+	for iter.Next() {
+		uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByTxid(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		entry, err := s.entryInfo(txid, uuid, blockHeight, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, iter.Error()
+}
+
+// EntriesByHeight returns the description of the private write sets held in the store that were received at a
+// block height within the given range, in increasing order of block height. There is no upper bound if endHeight
+// is zero, and no limit on the number of private write sets returned if limit is zero.
+func (s *Store) EntriesByHeight(startHeight, endHeight uint64, limit int) ([]*EntryInfo, error) {
+	startKey := createPurgeIndexByHeightRangeStartKey(startHeight)
+	endKey := []byte{purgeIndexByHeightPrefix, compositeKeySep + 1}
+	if endHeight != 0 {
+		endKey = createPurgeIndexByHeightRangeEndKey(endHeight)
+	}
+	iter, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	var entries []*EntryInfo
+	for iter.Next() && (limit == 0 || len(entries) < limit) {
+		txid, uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByHeight(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		entry, err := s.entryInfo(txid, uuid, blockHeight, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, iter.Error()
+}
+
+// entryInfo reads the private write set identified by the given txid, uuid and block height and describes it.
+// It returns nil if the private write set is not in the store.
+func (s *Store) entryInfo(txid, uuid string, blockHeight uint64, indexValue []byte) (*EntryInfo, error) {
+	persistTime, err := decodePersistTime(indexValue)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.db.Get(createCompositeKeyForPvtRWSet(txid, uuid, blockHeight))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	dbVal, err := decryptValue(value, s.encryptor)
+	if err != nil {
+		return nil, err
+	}
+	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
+	if len(dbVal) > 0 && dbVal[0] == nilByte {
+		txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+		if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+			return nil, err
+		}
+		txPvtRWSet = txPvtRWSetWithConfig.GetPvtRwset()
+	} else if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+		return nil, err
+	}
+
+	entry := &EntryInfo{
+		TxID:                  txid,
+		UUID:                  uuid,
+		ReceivedAtBlockHeight: blockHeight,
+		Size:                  len(value),
+	}
+	if persistTime != 0 {
+		entry.PersistTime = time.Unix(0, int64(persistTime))
+	}
+	for _, ns := range txPvtRWSet.GetNsPvtRwset() {
+		for _, coll := range ns.CollectionPvtRwset {
+			entry.Collections = append(entry.Collections, &EntryCollection{Namespace: ns.Namespace, Collection: coll.CollectionName})
+		}
+	}
+	return entry, nil
+}
+
+// Stats returns a summary of the private write sets held in the store
+func (s *Store) Stats() (*StoreStats, error) {
+	stats := &StoreStats{}
+
+	iter, err := s.db.GetIterator([]byte{prwsetPrefix, compositeKeySep}, []byte{prwsetPrefix, compositeKeySep + 1})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	for iter.Next() {
+		_, blockHeight, err := splitCompositeKeyOfPvtRWSet(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if stats.Entries == 0 || blockHeight < stats.MinBlockHeight {
+			stats.MinBlockHeight = blockHeight
+		}
+		if blockHeight > stats.MaxBlockHeight {
+			stats.MaxBlockHeight = blockHeight
+		}
+		stats.Entries++
+		stats.Size += uint64(len(iter.Value()))
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	// The first key of the purge index by age holds the oldest persist time
+	ageIter, err := s.db.GetIterator([]byte{purgeIndexByAgePrefix, compositeKeySep}, []byte{purgeIndexByAgePrefix, compositeKeySep + 1})
+	if err != nil {
+		return nil, err
+	}
+	defer ageIter.Release()
+	if ageIter.Next() {
+		persistTime, err := splitPersistTimeOfPurgeIndexByAge(ageIter.Key())
+		if err != nil {
+			return nil, err
+		}
+		stats.OldestPersistTime = time.Unix(0, int64(persistTime))
+	}
+	return stats, ageIter.Error()
 }
 
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
@@ -509,11 +956,14 @@ func (scanner *RwsetScanner) Next() (*EndorserPvtSimulationResults, error) {
 		return nil, nil
 	}
 	dbKey := scanner.dbItr.Key()
-	dbVal := scanner.dbItr.Value()
 	_, blockHeight, err := splitCompositeKeyOfPvtRWSet(dbKey)
 	if err != nil {
 		return nil, err
 	}
+	dbVal, err := decryptValue(scanner.dbItr.Value(), scanner.encryptor)
+	if err != nil {
+		return nil, err
+	}
 
 	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
 	txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
@@ -553,3 +1003,18 @@ func (scanner *RwsetScanner) Next() (*EndorserPvtSimulationResults, error) {
 func (scanner *RwsetScanner) Close() {
 	scanner.dbItr.Release()
 }
+
+// decryptValue decrypts a private write set read from the store, if the private data is encrypted
+func decryptValue(dbVal []byte, encryptor ledger.PvtDataEncryptor) ([]byte, error) {
+	if encryptor == nil {
+		if pvtdataencryption.IsEncrypted(dbVal) {
+			return nil, errors.New("private write set is encrypted but the encryption of private data is not enabled")
+		}
+		return dbVal, nil
+	}
+	val, err := encryptor.Decrypt(dbVal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while decrypting private write set")
+	}
+	return val, nil
+}
@@ -10,9 +10,12 @@ import (
 	"bytes"
 	"errors"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 )
 
@@ -20,6 +23,7 @@ var (
 	prwsetPrefix             = []byte("P")[0] // key prefix for storing private write set in transient store.
 	purgeIndexByHeightPrefix = []byte("H")[0] // key prefix for storing index on private write set using received at block height.
 	purgeIndexByTxidPrefix   = []byte("T")[0] // key prefix for storing index on private write set using txid
+	purgeIndexByAgePrefix    = []byte("A")[0] // key prefix for storing index on private write set using persist time
 	compositeKeySep          = byte(0x00)
 )
 
@@ -74,6 +78,20 @@ func createCompositeKeyForPurgeIndexByHeight(blockHeight uint64, txid string, uu
 	return compositeKey
 }
 
+// createCompositeKeyForPurgeIndexByAge creates a key to index private write set based on
+// the time it was persisted at such that purge based on age can be achieved. The structure
+// of the key is <purgeIndexByAgePrefix>~persistTime~txid~uuid~blockHeight.
+func createCompositeKeyForPurgeIndexByAge(persistTime uint64, txid string, uuid string, blockHeight uint64) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, purgeIndexByAgePrefix)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(persistTime)...)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, createCompositeKeyWithoutPrefixForTxid(txid, uuid, blockHeight)...)
+
+	return compositeKey
+}
+
 // splitCompositeKeyOfPvtRWSet splits the compositeKey (<prwsetPrefix>~txid~uuid~blockHeight)
 // into uuid and blockHeight.
 func splitCompositeKeyOfPvtRWSet(compositeKey []byte) (uuid string, blockHeight uint64, err error) {
@@ -100,6 +118,26 @@ func splitCompositeKeyOfPurgeIndexByHeight(compositeKey []byte) (txid string, uu
 	return
 }
 
+// splitCompositeKeyOfPurgeIndexByAge splits the compositeKey (<purgeIndexByAgePrefix>~persistTime~txid~uuid~blockHeight)
+// into txid, uuid and blockHeight.
+func splitCompositeKeyOfPurgeIndexByAge(compositeKey []byte) (txid string, uuid string, blockHeight uint64, err error) {
+	_, n, err := util.DecodeOrderPreservingVarUint64(compositeKey[2:])
+	if err != nil {
+		return
+	}
+	compositeKeyForTxid := compositeKey[n+3:]
+	txid = string(compositeKeyForTxid[:bytes.IndexByte(compositeKeyForTxid, compositeKeySep)])
+	uuid, blockHeight, err = splitCompositeKeyWithoutPrefixForTxid(compositeKeyForTxid)
+	return
+}
+
+// splitPersistTimeOfPurgeIndexByAge returns the persistTime of the compositeKey
+// (<purgeIndexByAgePrefix>~persistTime~txid~uuid~blockHeight).
+func splitPersistTimeOfPurgeIndexByAge(compositeKey []byte) (uint64, error) {
+	persistTime, _, err := util.DecodeOrderPreservingVarUint64(compositeKey[2:])
+	return persistTime, err
+}
+
 // splitCompositeKeyWithoutPrefixForTxid splits the composite key txid~uuid~blockHeight into
 // uuid and blockHeight
 func splitCompositeKeyWithoutPrefixForTxid(compositeKey []byte) (uuid string, blockHeight uint64, err error) {
@@ -155,6 +193,32 @@ func createPurgeIndexByHeightRangeEndKey(blockHeight uint64) []byte {
 	return endKey
 }
 
+// createPurgeIndexByAgeRangeEndKey returns an endKey to do a range query on index stored in transient store
+// using persist time, such that the range only includes private write sets persisted before the given time
+func createPurgeIndexByAgeRangeEndKey(persistTime uint64) []byte {
+	var endKey []byte
+	endKey = append(endKey, purgeIndexByAgePrefix)
+	endKey = append(endKey, compositeKeySep)
+	endKey = append(endKey, util.EncodeOrderPreservingVarUint64(persistTime)...)
+	return endKey
+}
+
+// encodePersistTime encodes the time a private write set was persisted at, which is stored as value of the
+// purge indexes by height and txid
+func encodePersistTime(persistTime uint64) []byte {
+	return util.EncodeOrderPreservingVarUint64(persistTime)
+}
+
+// decodePersistTime decodes the value of a purge index by height or txid. The indexes of private write sets
+// persisted before the persist time was recorded have an empty value, for which zero is returned.
+func decodePersistTime(value []byte) (uint64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	persistTime, _, err := util.DecodeOrderPreservingVarUint64(value)
+	return persistTime, err
+}
+
 // createPurgeIndexByTxidRangeStartKey returns a startKey to do a range query on index stored in transient store
 // using txid
 func createPurgeIndexByTxidRangeStartKey(txid string) []byte {
@@ -235,3 +299,38 @@ func trimPvtCollectionConfigs(configs map[string]*peer.CollectionConfigPackage,
 	}
 	return result, nil
 }
+
+// removeKeysFromPvtWSet removes the writes of the given keys, identified by namespace, collection and key hash,
+// from the collections of `pvtWSet` and returns true if any write has been removed
+func removeKeysFromPvtWSet(pvtWSet *rwset.TxPvtReadWriteSet, keys map[string]map[string]map[string]struct{}) (bool, error) {
+	modified := false
+	for _, ns := range pvtWSet.GetNsPvtRwset() {
+		for _, coll := range ns.CollectionPvtRwset {
+			keyHashes, ok := keys[ns.Namespace][coll.CollectionName]
+			if !ok {
+				continue
+			}
+			kvRWSet := &kvrwset.KVRWSet{}
+			if err := proto.Unmarshal(coll.Rwset, kvRWSet); err != nil {
+				return false, err
+			}
+			var writes []*kvrwset.KVWrite
+			for _, w := range kvRWSet.Writes {
+				if _, purged := keyHashes[string(commonutil.ComputeSHA256([]byte(w.Key)))]; !purged {
+					writes = append(writes, w)
+				}
+			}
+			if len(writes) == len(kvRWSet.Writes) {
+				continue
+			}
+			kvRWSet.Writes = writes
+			rwsetBytes, err := proto.Marshal(kvRWSet)
+			if err != nil {
+				return false, err
+			}
+			coll.Rwset = rwsetBytes
+			modified = true
+		}
+	}
+	return modified, nil
+}
@@ -7,21 +7,27 @@ SPDX-License-Identifier: Apache-2.0
 package transientstore
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/policydsl"
 	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
 )
@@ -93,6 +99,32 @@ func TestPurgeIndexKeyCodingEncoding(t *testing.T) {
 	}
 }
 
+func TestPurgeIndexByAgeKeyCodingEncoding(t *testing.T) {
+	require := require.New(t)
+	persistTimes := []uint64{0, 10, uint64(time.Now().UnixNano())}
+	blkHts := []uint64{0, 10, 20000}
+	for _, persistTime := range persistTimes {
+		for _, blkHt := range blkHts {
+			testCase := fmt.Sprintf("persistTime=%d,blkHt=%d", persistTime, blkHt)
+			t.Run(testCase, func(t *testing.T) {
+				purgeIndexKey := createCompositeKeyForPurgeIndexByAge(persistTime, "txid", "uuid", blkHt)
+				txid, uuid, blkHt1, err := splitCompositeKeyOfPurgeIndexByAge(purgeIndexKey)
+				require.NoError(err)
+				require.Equal("txid", txid)
+				require.Equal("uuid", uuid)
+				require.Equal(blkHt, blkHt1)
+				persistTime1, err := splitPersistTimeOfPurgeIndexByAge(purgeIndexKey)
+				require.NoError(err)
+				require.Equal(persistTime, persistTime1)
+
+				// keys persisted before a given time sort before the end key of the range for that time
+				require.True(bytes.Compare(purgeIndexKey, createPurgeIndexByAgeRangeEndKey(persistTime+1)) < 0)
+				require.True(bytes.Compare(purgeIndexKey, createPurgeIndexByAgeRangeEndKey(persistTime)) > 0)
+			})
+		}
+	}
+}
+
 func TestRWSetKeyCodingEncoding(t *testing.T) {
 	require := require.New(t)
 	blkHts := []uint64{0, 10, 20000}
@@ -504,6 +536,141 @@ func TestTransientStorePurgeBelowHeight(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestTransientStorePurgeKeys(t *testing.T) {
+	env := initTestEnv(t)
+	defer env.cleanup()
+	testStore := env.store
+	require := require.New(t)
+
+	kvRWSetBytes := func(keys ...string) []byte {
+		kvRWSet := &kvrwset.KVRWSet{}
+		for _, key := range keys {
+			kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte("value-of-" + key)})
+		}
+		b, err := proto.Marshal(kvRWSet)
+		require.NoError(err)
+		return b
+	}
+	pvtRWSet := func(ns1Coll1Keys, ns1Coll2Keys []string) *rwset.TxPvtReadWriteSet {
+		return &rwset.TxPvtReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace: "ns-1",
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						{CollectionName: "coll-1", Rwset: kvRWSetBytes(ns1Coll1Keys...)},
+						{CollectionName: "coll-2", Rwset: kvRWSetBytes(ns1Coll2Keys...)},
+					},
+				},
+			},
+		}
+	}
+
+	require.NoError(testStore.Persist("txid-1", 10, &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: pvtRWSet([]string{"key-1", "key-2"}, []string{"key-1"}),
+	}))
+	require.NoError(testStore.persistOldProto("txid-2", 10, pvtRWSet([]string{"key-1"}, []string{"key-2"})))
+
+	require.NoError(testStore.PurgeKeys([]*PurgedKey{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key-1")},
+		{Namespace: "ns-2", Collection: "coll-2", KeyHash: util.ComputeStringHash("key-2")},
+	}))
+
+	retrieve := func(txid string) *rwset.TxPvtReadWriteSet {
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(err)
+		defer iter.Close()
+		result, err := iter.Next()
+		require.NoError(err)
+		require.NotNil(result)
+		return result.PvtSimulationResultsWithConfig.PvtRwset
+	}
+	require.True(proto.Equal(pvtRWSet([]string{"key-2"}, []string{"key-1"}), retrieve("txid-1")))
+	require.True(proto.Equal(pvtRWSet([]string{}, []string{"key-2"}), retrieve("txid-2")))
+}
+
+func TestTransientStoreEncryption(t *testing.T) {
+	require := require.New(t)
+	csp, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(err)
+	keyID, err := pvtdataencryption.GenerateKey(csp)
+	require.NoError(err)
+	encryptor, err := pvtdataencryption.New(csp, keyID)
+	require.NoError(err)
+
+	storedir := filepath.Join(t.TempDir(), "transientstore")
+	storeProvider, err := NewStoreProviderWithEncryptor(storedir, encryptor)
+	require.NoError(err)
+	testStore, err := storeProvider.OpenStore("TestStore")
+	require.NoError(err)
+
+	pvtRWSet := func(keys ...string) *rwset.TxPvtReadWriteSet {
+		kvRWSet := &kvrwset.KVRWSet{}
+		for _, key := range keys {
+			kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte("RandomBytes-PvtRWSet-" + key)})
+		}
+		kvRWSetBytes, err := proto.Marshal(kvRWSet)
+		require.NoError(err)
+		return &rwset.TxPvtReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace:          "ns-1",
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "coll-1", Rwset: kvRWSetBytes}},
+				},
+			},
+		}
+	}
+	samplePvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset:          pvtRWSet("key-1", "key-2"),
+		CollectionConfigs: samplePvtDataWithConfigInfo(t).CollectionConfigs,
+	}
+	require.NoError(testStore.Persist("txid-1", 10, samplePvtRWSetWithConfig))
+	// entries persisted before the encryption was enabled
+	require.NoError(testStore.persistOldProto("txid-2", 10, pvtRWSet("key-3")))
+
+	iter, err := testStore.db.GetIterator([]byte{prwsetPrefix, compositeKeySep}, []byte{prwsetPrefix, compositeKeySep + 1})
+	require.NoError(err)
+	var encrypted []bool
+	for iter.Next() {
+		encrypted = append(encrypted, pvtdataencryption.IsEncrypted(iter.Value()))
+		if pvtdataencryption.IsEncrypted(iter.Value()) {
+			require.NotContains(string(iter.Value()), "RandomBytes-PvtRWSet")
+		}
+	}
+	iter.Release()
+	require.Equal([]bool{true, false}, encrypted)
+
+	retrieve := func(s *Store, txid string) (*EndorserPvtSimulationResults, error) {
+		iter, err := s.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(err)
+		defer iter.Close()
+		return iter.Next()
+	}
+	result, err := retrieve(testStore, "txid-1")
+	require.NoError(err)
+	require.True(proto.Equal(samplePvtRWSetWithConfig, result.PvtSimulationResultsWithConfig))
+	result, err = retrieve(testStore, "txid-2")
+	require.NoError(err)
+	require.True(proto.Equal(pvtRWSet("key-3"), result.PvtSimulationResultsWithConfig.PvtRwset))
+
+	// the private write sets remain encrypted once keys are purged from them
+	require.NoError(testStore.PurgeKeys([]*PurgedKey{{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key-1")}}))
+	result, err = retrieve(testStore, "txid-1")
+	require.NoError(err)
+	require.True(proto.Equal(pvtRWSet("key-2"), result.PvtSimulationResultsWithConfig.PvtRwset))
+
+	// the encrypted entries cannot be read once the encryption is disabled
+	storeProvider.Close()
+	storeProvider, err = NewStoreProvider(storedir)
+	require.NoError(err)
+	defer storeProvider.Close()
+	testStore, err = storeProvider.OpenStore("TestStore")
+	require.NoError(err)
+	_, err = retrieve(testStore, "txid-1")
+	require.EqualError(err, "private write set is encrypted but the encryption of private data is not enabled")
+}
+
 func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 	env := initTestEnv(t)
 	defer env.cleanup()
@@ -565,6 +732,210 @@ func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 	}
 }
 
+func TestTransientStorePurgeOlderThan(t *testing.T) {
+	env := initTestEnv(t)
+	defer env.cleanup()
+	testStore := env.store
+	require := require.New(t)
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	require.NoError(testStore.Persist("txid-1", 10, samplePvtRWSetWithConfig))
+	require.NoError(testStore.Persist("txid-2", 10, samplePvtRWSetWithConfig))
+	time.Sleep(time.Millisecond)
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	require.NoError(testStore.Persist("txid-3", 11, samplePvtRWSetWithConfig))
+	require.NoError(testStore.Persist("txid-4", 12, samplePvtRWSetWithConfig))
+	// an entry persisted before the persist time was recorded is not purged based on its age
+	require.NoError(testStore.persistOldProto("txid-5", 9, samplePvtData(t)))
+
+	purged, err := testStore.PurgeOlderThan(cutoff)
+	require.NoError(err)
+	require.Equal(2, purged)
+
+	for txid, expected := range map[string]int{"txid-1": 0, "txid-2": 0, "txid-3": 1, "txid-4": 1, "txid-5": 1} {
+		entries, err := testStore.EntriesByTxid(txid)
+		require.NoError(err)
+		require.Len(entries, expected, txid)
+	}
+
+	// the index by age is removed along with the entries purged by txid or by height
+	require.NoError(testStore.PurgeByTxids([]string{"txid-3"}))
+	require.NoError(testStore.PurgeBelowHeight(12))
+	purged, err = testStore.PurgeOlderThan(time.Now())
+	require.NoError(err)
+	require.Equal(1, purged)
+
+	stats, err := testStore.Stats()
+	require.NoError(err)
+	require.Equal(&StoreStats{}, stats)
+
+	purged, err = testStore.PurgeOlderThan(time.Now())
+	require.NoError(err)
+	require.Equal(0, purged)
+}
+
+func TestTransientStoreEntriesAndStats(t *testing.T) {
+	env := initTestEnv(t)
+	defer env.cleanup()
+	testStore := env.store
+	require := require.New(t)
+
+	stats, err := testStore.Stats()
+	require.NoError(err)
+	require.Equal(&StoreStats{}, stats)
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	samplePvtRWSetWithConfigBytes, err := proto.Marshal(samplePvtRWSetWithConfig)
+	require.NoError(err)
+	samplePvtRWSetBytes, err := proto.Marshal(samplePvtData(t))
+	require.NoError(err)
+
+	start := time.Now()
+	require.NoError(testStore.persistOldProto("txid-1", 9, samplePvtData(t)))
+	require.NoError(testStore.Persist("txid-2", 10, samplePvtRWSetWithConfig))
+	require.NoError(testStore.Persist("txid-2", 11, samplePvtRWSetWithConfig))
+	require.NoError(testStore.Persist("txid-3", 12, samplePvtRWSetWithConfig))
+	end := time.Now()
+
+	expectedCollections := []*EntryCollection{
+		{Namespace: "ns-1", Collection: "coll-1"},
+		{Namespace: "ns-1", Collection: "coll-2"},
+		{Namespace: "ns-2", Collection: "coll-1"},
+		{Namespace: "ns-2", Collection: "coll-2"},
+	}
+	checkEntry := func(entry *EntryInfo, txid string, blockHeight uint64) {
+		require.Equal(txid, entry.TxID)
+		require.NotEmpty(entry.UUID)
+		require.Equal(blockHeight, entry.ReceivedAtBlockHeight)
+		require.Equal(expectedCollections, entry.Collections)
+		if txid == "txid-1" {
+			require.True(entry.PersistTime.IsZero())
+			require.Equal(len(samplePvtRWSetBytes), entry.Size)
+			return
+		}
+		require.False(entry.PersistTime.Before(start))
+		require.False(entry.PersistTime.After(end))
+		require.Equal(len(samplePvtRWSetWithConfigBytes)+1, entry.Size)
+	}
+
+	entries, err := testStore.EntriesByTxid("txid-2")
+	require.NoError(err)
+	require.Len(entries, 2)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ReceivedAtBlockHeight < entries[j].ReceivedAtBlockHeight })
+	checkEntry(entries[0], "txid-2", 10)
+	checkEntry(entries[1], "txid-2", 11)
+
+	entries, err = testStore.EntriesByTxid("txid-1")
+	require.NoError(err)
+	require.Len(entries, 1)
+	checkEntry(entries[0], "txid-1", 9)
+
+	entries, err = testStore.EntriesByTxid("txid-4")
+	require.NoError(err)
+	require.Empty(entries)
+
+	entries, err = testStore.EntriesByHeight(0, 0, 0)
+	require.NoError(err)
+	require.Len(entries, 4)
+	for i, txid := range []string{"txid-1", "txid-2", "txid-2", "txid-3"} {
+		checkEntry(entries[i], txid, uint64(9+i))
+	}
+
+	entries, err = testStore.EntriesByHeight(10, 11, 0)
+	require.NoError(err)
+	require.Len(entries, 2)
+	checkEntry(entries[0], "txid-2", 10)
+	checkEntry(entries[1], "txid-2", 11)
+
+	entries, err = testStore.EntriesByHeight(10, 0, 1)
+	require.NoError(err)
+	require.Len(entries, 1)
+	checkEntry(entries[0], "txid-2", 10)
+
+	stats, err = testStore.Stats()
+	require.NoError(err)
+	require.Equal(uint64(4), stats.Entries)
+	require.Equal(uint64(len(samplePvtRWSetBytes)+3*(len(samplePvtRWSetWithConfigBytes)+1)), stats.Size)
+	require.Equal(uint64(9), stats.MinBlockHeight)
+	require.Equal(uint64(12), stats.MaxBlockHeight)
+	require.False(stats.OldestPersistTime.Before(start))
+	require.False(stats.OldestPersistTime.After(end))
+
+	purged, err := testStore.PurgeEntriesByTxids([]string{"txid-2", "txid-2", "txid-4"})
+	require.NoError(err)
+	require.Equal(2, purged)
+
+	stats, err = testStore.Stats()
+	require.NoError(err)
+	require.Equal(uint64(2), stats.Entries)
+	require.Equal(uint64(9), stats.MinBlockHeight)
+	require.Equal(uint64(12), stats.MaxBlockHeight)
+}
+
+func TestTransientStoreEntriesMetrics(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "ts")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	storedir := filepath.Join(tempdir, "transientstore")
+
+	fakeEntriesGauge := &metricsfakes.Gauge{}
+	fakeEntriesGauge.WithReturns(fakeEntriesGauge)
+	fakePurgedEntriesCounter := &metricsfakes.Counter{}
+	fakePurgedEntriesCounter.WithReturns(fakePurgedEntriesCounter)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewGaugeReturns(fakeEntriesGauge)
+	fakeProvider.NewCounterReturns(fakePurgedEntriesCounter)
+
+	storeProvider, err := NewStoreProviderWithMetrics(storedir, nil, fakeProvider)
+	require.NoError(t, err)
+	require.Equal(t, entriesOpts, fakeProvider.NewGaugeArgsForCall(0))
+	require.Equal(t, purgedEntriesOpts, fakeProvider.NewCounterArgsForCall(0))
+
+	store, err := storeProvider.OpenStore("testchannel")
+	require.NoError(t, err)
+	require.Equal(t, []string{"channel", "testchannel"}, fakeEntriesGauge.WithArgsForCall(0))
+	require.Equal(t, float64(0), fakeEntriesGauge.SetArgsForCall(0))
+
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Persist(fmt.Sprintf("txid-%d", i), uint64(10+i), samplePvtRWSetWithConfig))
+		require.Equal(t, float64(i+1), fakeEntriesGauge.SetArgsForCall(i+1))
+	}
+
+	require.NoError(t, store.PurgeByTxids([]string{"txid-0"}))
+	require.Equal(t, float64(2), fakeEntriesGauge.SetArgsForCall(4))
+	require.Equal(t, []string{"channel", "testchannel", "reason", "txid"}, fakePurgedEntriesCounter.WithArgsForCall(0))
+	require.Equal(t, float64(1), fakePurgedEntriesCounter.AddArgsForCall(0))
+
+	require.NoError(t, store.PurgeBelowHeight(12))
+	require.Equal(t, float64(1), fakeEntriesGauge.SetArgsForCall(5))
+	require.Equal(t, []string{"channel", "testchannel", "reason", "height"}, fakePurgedEntriesCounter.WithArgsForCall(1))
+	require.Equal(t, float64(1), fakePurgedEntriesCounter.AddArgsForCall(1))
+
+	// purges that do not remove anything do not update the metrics
+	require.NoError(t, store.PurgeBelowHeight(12))
+	_, err = store.PurgeOlderThan(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 6, fakeEntriesGauge.SetCallCount())
+	require.Equal(t, 2, fakePurgedEntriesCounter.AddCallCount())
+
+	// the count is restored when the store is opened again
+	storeProvider.Close()
+	storeProvider, err = NewStoreProviderWithMetrics(storedir, nil, fakeProvider)
+	require.NoError(t, err)
+	defer storeProvider.Close()
+	store, err = storeProvider.OpenStore("testchannel")
+	require.NoError(t, err)
+	require.Equal(t, float64(1), fakeEntriesGauge.SetArgsForCall(6))
+
+	purged, err := store.PurgeOlderThan(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	require.Equal(t, float64(0), fakeEntriesGauge.SetArgsForCall(7))
+	require.Equal(t, []string{"channel", "testchannel", "reason", "age"}, fakePurgedEntriesCounter.WithArgsForCall(2))
+}
+
 func sortResults(res []*EndorserPvtSimulationResults) {
 	// Results are sorted by ascending order of received at block height. When the block
 	// heights are same, we sort by comparing the hash of private write set.
@@ -731,6 +1102,15 @@ func TestIteratorErrorCases(t *testing.T) {
 
 	require.EqualError(t, testStore.PurgeBelowHeight(0), errStr)
 	require.EqualError(t, testStore.PurgeByTxids([]string{"tx1"}), errStr)
+
+	_, err = testStore.PurgeOlderThan(time.Now())
+	require.EqualError(t, err, errStr)
+	_, err = testStore.EntriesByTxid("tx1")
+	require.EqualError(t, err, errStr)
+	_, err = testStore.EntriesByHeight(0, 0, 0)
+	require.EqualError(t, err, errStr)
+	_, err = testStore.Stats()
+	require.EqualError(t, err, errStr)
 }
 
 func TestDeleteTransientStore(t *testing.T) {
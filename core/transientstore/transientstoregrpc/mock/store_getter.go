@@ -0,0 +1,108 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/transientstore"
+)
+
+type StoreGetter struct {
+	StoreForChannelStub        func(string) *transientstore.Store
+	storeForChannelMutex       sync.RWMutex
+	storeForChannelArgsForCall []struct {
+		arg1 string
+	}
+	storeForChannelReturns struct {
+		result1 *transientstore.Store
+	}
+	storeForChannelReturnsOnCall map[int]struct {
+		result1 *transientstore.Store
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *StoreGetter) StoreForChannel(arg1 string) *transientstore.Store {
+	fake.storeForChannelMutex.Lock()
+	ret, specificReturn := fake.storeForChannelReturnsOnCall[len(fake.storeForChannelArgsForCall)]
+	fake.storeForChannelArgsForCall = append(fake.storeForChannelArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("StoreForChannel", []interface{}{arg1})
+	fake.storeForChannelMutex.Unlock()
+	if fake.StoreForChannelStub != nil {
+		return fake.StoreForChannelStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.storeForChannelReturns
+	return fakeReturns.result1
+}
+
+func (fake *StoreGetter) StoreForChannelCallCount() int {
+	fake.storeForChannelMutex.RLock()
+	defer fake.storeForChannelMutex.RUnlock()
+	return len(fake.storeForChannelArgsForCall)
+}
+
+func (fake *StoreGetter) StoreForChannelCalls(stub func(string) *transientstore.Store) {
+	fake.storeForChannelMutex.Lock()
+	defer fake.storeForChannelMutex.Unlock()
+	fake.StoreForChannelStub = stub
+}
+
+func (fake *StoreGetter) StoreForChannelArgsForCall(i int) string {
+	fake.storeForChannelMutex.RLock()
+	defer fake.storeForChannelMutex.RUnlock()
+	argsForCall := fake.storeForChannelArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *StoreGetter) StoreForChannelReturns(result1 *transientstore.Store) {
+	fake.storeForChannelMutex.Lock()
+	defer fake.storeForChannelMutex.Unlock()
+	fake.StoreForChannelStub = nil
+	fake.storeForChannelReturns = struct {
+		result1 *transientstore.Store
+	}{result1}
+}
+
+func (fake *StoreGetter) StoreForChannelReturnsOnCall(i int, result1 *transientstore.Store) {
+	fake.storeForChannelMutex.Lock()
+	defer fake.storeForChannelMutex.Unlock()
+	fake.StoreForChannelStub = nil
+	if fake.storeForChannelReturnsOnCall == nil {
+		fake.storeForChannelReturnsOnCall = make(map[int]struct {
+			result1 *transientstore.Store
+		})
+	}
+	fake.storeForChannelReturnsOnCall[i] = struct {
+		result1 *transientstore.Store
+	}{result1}
+}
+
+func (fake *StoreGetter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.storeForChannelMutex.RLock()
+	defer fake.storeForChannelMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *StoreGetter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
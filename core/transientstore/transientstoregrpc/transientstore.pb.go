@@ -0,0 +1,698 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: core/transientstore/transientstoregrpc/transientstore.proto
+
+package transientstoregrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedTransientStoreRequest contains a serialized TransientStoreRequest
+// message, and a digital signature for the serialized request message.
+type SignedTransientStoreRequest struct {
+	// Serialized TransientStoreRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedTransientStoreRequest) Reset()         { *m = SignedTransientStoreRequest{} }
+func (m *SignedTransientStoreRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedTransientStoreRequest) ProtoMessage()    {}
+func (*SignedTransientStoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{0}
+}
+
+func (m *SignedTransientStoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedTransientStoreRequest.Unmarshal(m, b)
+}
+func (m *SignedTransientStoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedTransientStoreRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedTransientStoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedTransientStoreRequest.Merge(m, src)
+}
+func (m *SignedTransientStoreRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedTransientStoreRequest.Size(m)
+}
+func (m *SignedTransientStoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedTransientStoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedTransientStoreRequest proto.InternalMessageInfo
+
+func (m *SignedTransientStoreRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedTransientStoreRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TransientStoreRequest identifies the channel whose transient store is
+// accessed and, for List and Purge, the private write sets concerned.
+type TransientStoreRequest struct {
+	// The signature header that contains creator identity and nonce.
+	SignatureHeader *common.SignatureHeader `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	// The name of the channel.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The transactions whose private write sets are listed or purged.
+	TxIds []string `protobuf:"bytes,3,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
+	// The range of block heights the listed private write sets were received
+	// at, when no transaction is given. There is no upper bound if zero.
+	StartHeight uint64 `protobuf:"varint,4,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	EndHeight   uint64 `protobuf:"varint,5,opt,name=end_height,json=endHeight,proto3" json:"end_height,omitempty"`
+	// The maximum number of private write sets listed by block height. There
+	// is no limit if zero.
+	Limit uint32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	// The minimum age of the purged private write sets, when no transaction
+	// is given.
+	OlderThan            *duration.Duration `protobuf:"bytes,7,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TransientStoreRequest) Reset()         { *m = TransientStoreRequest{} }
+func (m *TransientStoreRequest) String() string { return proto.CompactTextString(m) }
+func (*TransientStoreRequest) ProtoMessage()    {}
+func (*TransientStoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{1}
+}
+
+func (m *TransientStoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransientStoreRequest.Unmarshal(m, b)
+}
+func (m *TransientStoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransientStoreRequest.Marshal(b, m, deterministic)
+}
+func (m *TransientStoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransientStoreRequest.Merge(m, src)
+}
+func (m *TransientStoreRequest) XXX_Size() int {
+	return xxx_messageInfo_TransientStoreRequest.Size(m)
+}
+func (m *TransientStoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransientStoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransientStoreRequest proto.InternalMessageInfo
+
+func (m *TransientStoreRequest) GetSignatureHeader() *common.SignatureHeader {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *TransientStoreRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *TransientStoreRequest) GetTxIds() []string {
+	if m != nil {
+		return m.TxIds
+	}
+	return nil
+}
+
+func (m *TransientStoreRequest) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *TransientStoreRequest) GetEndHeight() uint64 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+func (m *TransientStoreRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *TransientStoreRequest) GetOlderThan() *duration.Duration {
+	if m != nil {
+		return m.OlderThan
+	}
+	return nil
+}
+
+// ListResponse describes private write sets held in the transient store.
+type ListResponse struct {
+	Entries              []*TransientStoreEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{2}
+}
+
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
+}
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+}
+func (m *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(m, src)
+}
+func (m *ListResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResponse.Size(m)
+}
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+
+func (m *ListResponse) GetEntries() []*TransientStoreEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// TransientStoreEntry describes a private write set held in the transient
+// store.
+type TransientStoreEntry struct {
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// Identifies the private write set among the private write sets of the
+	// transaction received from different endorsers.
+	Uuid string `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// The block height of the ledger when the private write set was received.
+	ReceivedAtBlockHeight uint64 `protobuf:"varint,3,opt,name=received_at_block_height,json=receivedAtBlockHeight,proto3" json:"received_at_block_height,omitempty"`
+	// The time the private write set was persisted at. Not set for the private
+	// write sets persisted before the persist time was recorded.
+	PersistTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=persist_time,json=persistTime,proto3" json:"persist_time,omitempty"`
+	// The number of bytes the private write set takes in the store.
+	Size uint64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// The collections the private write set holds data for.
+	Collections          []*CollectionReference `protobuf:"bytes,6,rep,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *TransientStoreEntry) Reset()         { *m = TransientStoreEntry{} }
+func (m *TransientStoreEntry) String() string { return proto.CompactTextString(m) }
+func (*TransientStoreEntry) ProtoMessage()    {}
+func (*TransientStoreEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{3}
+}
+
+func (m *TransientStoreEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransientStoreEntry.Unmarshal(m, b)
+}
+func (m *TransientStoreEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransientStoreEntry.Marshal(b, m, deterministic)
+}
+func (m *TransientStoreEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransientStoreEntry.Merge(m, src)
+}
+func (m *TransientStoreEntry) XXX_Size() int {
+	return xxx_messageInfo_TransientStoreEntry.Size(m)
+}
+func (m *TransientStoreEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransientStoreEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransientStoreEntry proto.InternalMessageInfo
+
+func (m *TransientStoreEntry) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TransientStoreEntry) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *TransientStoreEntry) GetReceivedAtBlockHeight() uint64 {
+	if m != nil {
+		return m.ReceivedAtBlockHeight
+	}
+	return 0
+}
+
+func (m *TransientStoreEntry) GetPersistTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PersistTime
+	}
+	return nil
+}
+
+func (m *TransientStoreEntry) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TransientStoreEntry) GetCollections() []*CollectionReference {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+// CollectionReference identifies a private data collection of a chaincode.
+type CollectionReference struct {
+	ChaincodeName        string   `protobuf:"bytes,1,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	CollectionName       string   `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionReference) Reset()         { *m = CollectionReference{} }
+func (m *CollectionReference) String() string { return proto.CompactTextString(m) }
+func (*CollectionReference) ProtoMessage()    {}
+func (*CollectionReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{4}
+}
+
+func (m *CollectionReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionReference.Unmarshal(m, b)
+}
+func (m *CollectionReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionReference.Marshal(b, m, deterministic)
+}
+func (m *CollectionReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionReference.Merge(m, src)
+}
+func (m *CollectionReference) XXX_Size() int {
+	return xxx_messageInfo_CollectionReference.Size(m)
+}
+func (m *CollectionReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionReference proto.InternalMessageInfo
+
+func (m *CollectionReference) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *CollectionReference) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+// StatsResponse summarizes the private write sets held in the transient
+// store of a channel.
+type StatsResponse struct {
+	// The number of private write sets.
+	Entries uint64 `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	// The number of bytes the private write sets take in the store.
+	Size uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// The range of block heights the private write sets were received at.
+	MinBlockHeight uint64 `protobuf:"varint,3,opt,name=min_block_height,json=minBlockHeight,proto3" json:"min_block_height,omitempty"`
+	MaxBlockHeight uint64 `protobuf:"varint,4,opt,name=max_block_height,json=maxBlockHeight,proto3" json:"max_block_height,omitempty"`
+	// The persist time of the oldest private write set. Not set if no persist
+	// time was recorded.
+	OldestPersistTime    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=oldest_persist_time,json=oldestPersistTime,proto3" json:"oldest_persist_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{5}
+}
+
+func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatsResponse.Unmarshal(m, b)
+}
+func (m *StatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatsResponse.Marshal(b, m, deterministic)
+}
+func (m *StatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsResponse.Merge(m, src)
+}
+func (m *StatsResponse) XXX_Size() int {
+	return xxx_messageInfo_StatsResponse.Size(m)
+}
+func (m *StatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsResponse proto.InternalMessageInfo
+
+func (m *StatsResponse) GetEntries() uint64 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetMinBlockHeight() uint64 {
+	if m != nil {
+		return m.MinBlockHeight
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetMaxBlockHeight() uint64 {
+	if m != nil {
+		return m.MaxBlockHeight
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetOldestPersistTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OldestPersistTime
+	}
+	return nil
+}
+
+// PurgeResponse reports the outcome of a purge.
+type PurgeResponse struct {
+	// The number of private write sets removed.
+	Purged               uint64   `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgeResponse) Reset()         { *m = PurgeResponse{} }
+func (m *PurgeResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeResponse) ProtoMessage()    {}
+func (*PurgeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a72e6f09280bd584, []int{6}
+}
+
+func (m *PurgeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgeResponse.Unmarshal(m, b)
+}
+func (m *PurgeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgeResponse.Marshal(b, m, deterministic)
+}
+func (m *PurgeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeResponse.Merge(m, src)
+}
+func (m *PurgeResponse) XXX_Size() int {
+	return xxx_messageInfo_PurgeResponse.Size(m)
+}
+func (m *PurgeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgeResponse proto.InternalMessageInfo
+
+func (m *PurgeResponse) GetPurged() uint64 {
+	if m != nil {
+		return m.Purged
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SignedTransientStoreRequest)(nil), "transientstoregrpc.SignedTransientStoreRequest")
+	proto.RegisterType((*TransientStoreRequest)(nil), "transientstoregrpc.TransientStoreRequest")
+	proto.RegisterType((*ListResponse)(nil), "transientstoregrpc.ListResponse")
+	proto.RegisterType((*TransientStoreEntry)(nil), "transientstoregrpc.TransientStoreEntry")
+	proto.RegisterType((*CollectionReference)(nil), "transientstoregrpc.CollectionReference")
+	proto.RegisterType((*StatsResponse)(nil), "transientstoregrpc.StatsResponse")
+	proto.RegisterType((*PurgeResponse)(nil), "transientstoregrpc.PurgeResponse")
+}
+
+func init() {
+	proto.RegisterFile("core/transientstore/transientstoregrpc/transientstore.proto", fileDescriptor_a72e6f09280bd584)
+}
+
+var fileDescriptor_a72e6f09280bd584 = []byte{
+	// 702 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdb, 0x6e, 0xdb, 0x38,
+	0x10, 0x85, 0xaf, 0x81, 0xc7, 0x97, 0x64, 0xe9, 0xcd, 0xae, 0xd6, 0x7b, 0x73, 0x0c, 0x2c, 0xe2,
+	0x27, 0x0b, 0xf0, 0x3e, 0xec, 0x02, 0x45, 0x1f, 0x92, 0xa6, 0x45, 0x52, 0x14, 0x45, 0x2a, 0xa7,
+	0x0f, 0x6d, 0x1e, 0x04, 0x5a, 0x9a, 0x48, 0x44, 0x25, 0xca, 0x25, 0xa9, 0xc2, 0xe9, 0x7f, 0xf4,
+	0x0b, 0x8a, 0x7e, 0x56, 0xff, 0xa5, 0x20, 0x25, 0xd9, 0x56, 0xec, 0xde, 0x80, 0x3e, 0x99, 0x73,
+	0xe6, 0xcc, 0x50, 0x73, 0xce, 0x58, 0x82, 0x7b, 0x5e, 0x22, 0xd0, 0x56, 0x82, 0x72, 0xc9, 0x90,
+	0x2b, 0xa9, 0xb6, 0xc3, 0x40, 0x2c, 0xbc, 0x3b, 0xd0, 0x64, 0x21, 0x12, 0x95, 0x10, 0xb2, 0x4d,
+	0x1c, 0xfc, 0x15, 0x24, 0x49, 0x10, 0xa1, 0x6d, 0x18, 0xf3, 0xf4, 0xc6, 0xf6, 0x53, 0x41, 0x15,
+	0x4b, 0x78, 0x56, 0x33, 0xf8, 0xfb, 0x6e, 0x5e, 0xb1, 0x18, 0xa5, 0xa2, 0xf1, 0x22, 0x27, 0xf4,
+	0xbd, 0x24, 0x8e, 0x13, 0x6e, 0x67, 0x3f, 0x19, 0x38, 0x7a, 0x0e, 0xbf, 0xcf, 0x58, 0xc0, 0xd1,
+	0xbf, 0x2a, 0x6e, 0x9c, 0xe9, 0x1b, 0x1d, 0x7c, 0x9d, 0xa2, 0x54, 0xc4, 0x82, 0x3d, 0x91, 0x1d,
+	0xad, 0xca, 0xb0, 0x32, 0xee, 0x38, 0x45, 0x48, 0xfe, 0x80, 0x96, 0x64, 0x01, 0xa7, 0x2a, 0x15,
+	0x68, 0x55, 0x4d, 0x6e, 0x0d, 0x8c, 0xde, 0x57, 0xe1, 0x70, 0x77, 0xc7, 0x53, 0x38, 0x58, 0xd1,
+	0xdc, 0x10, 0xa9, 0x8f, 0xc2, 0xb4, 0x6e, 0x4f, 0x7f, 0x9d, 0xe4, 0x4f, 0x36, 0x2b, 0xf2, 0xe7,
+	0x26, 0xed, 0xec, 0xcb, 0x32, 0x40, 0xfe, 0x04, 0xf0, 0x42, 0xca, 0x39, 0x46, 0x2e, 0xf3, 0xcd,
+	0xe5, 0x2d, 0xa7, 0x95, 0x23, 0x17, 0x3e, 0x39, 0x84, 0xa6, 0x5a, 0xba, 0xcc, 0x97, 0x56, 0x6d,
+	0x58, 0x1b, 0xb7, 0x9c, 0x86, 0x5a, 0x5e, 0xf8, 0x92, 0x1c, 0x41, 0x47, 0x2a, 0x2a, 0x94, 0x1b,
+	0x22, 0x0b, 0x42, 0x65, 0xd5, 0x87, 0x95, 0x71, 0xdd, 0x69, 0x1b, 0xec, 0xdc, 0x40, 0xba, 0x31,
+	0x72, 0xbf, 0x20, 0x34, 0x0c, 0xa1, 0x85, 0xdc, 0xcf, 0xd3, 0x3f, 0x43, 0x23, 0x62, 0x31, 0x53,
+	0x56, 0x73, 0x58, 0x19, 0x77, 0x9d, 0x2c, 0x20, 0xff, 0x03, 0x24, 0x91, 0x8f, 0xc2, 0x55, 0x21,
+	0xe5, 0xd6, 0x9e, 0x99, 0xe5, 0xb7, 0x49, 0xe6, 0xc6, 0xa4, 0x70, 0x63, 0x72, 0x96, 0xbb, 0xe5,
+	0xb4, 0x0c, 0xf9, 0x2a, 0xa4, 0x7c, 0xf4, 0x0c, 0x3a, 0x4f, 0x98, 0x54, 0x0e, 0xca, 0x45, 0xc2,
+	0x25, 0x92, 0x13, 0xd8, 0x43, 0xae, 0x04, 0x43, 0x69, 0x55, 0x86, 0xb5, 0x71, 0x7b, 0x7a, 0x3c,
+	0xd9, 0x5e, 0x84, 0x49, 0x59, 0xd7, 0x87, 0x5c, 0x89, 0x5b, 0xa7, 0xa8, 0x1b, 0xbd, 0xab, 0x42,
+	0x7f, 0x07, 0x81, 0xf4, 0xa1, 0x61, 0x34, 0x31, 0x5a, 0xb7, 0x9c, 0xba, 0x96, 0x84, 0x10, 0xa8,
+	0xa7, 0xe9, 0x4a, 0x41, 0x73, 0x26, 0xff, 0x81, 0x25, 0xd0, 0x43, 0xf6, 0x06, 0x7d, 0x97, 0x2a,
+	0x77, 0x1e, 0x25, 0xde, 0xab, 0x42, 0x90, 0x9a, 0x11, 0xe4, 0xb0, 0xc8, 0x9f, 0xa8, 0x53, 0x9d,
+	0xcd, 0xc5, 0xb9, 0x0f, 0x9d, 0x05, 0x0a, 0xc9, 0xa4, 0x72, 0xf5, 0xe6, 0x19, 0x79, 0xdb, 0xd3,
+	0xc1, 0x96, 0x10, 0x57, 0xc5, 0x5a, 0x3a, 0xed, 0x9c, 0xaf, 0x11, 0xfd, 0x2c, 0x92, 0xbd, 0xc5,
+	0x5c, 0x74, 0x73, 0x26, 0x17, 0xd0, 0xf6, 0x92, 0x28, 0x42, 0x4f, 0x0b, 0x27, 0xad, 0xe6, 0xe7,
+	0x35, 0x79, 0xb0, 0xa2, 0x39, 0x78, 0x83, 0x02, 0xb9, 0x87, 0xce, 0x66, 0xed, 0x08, 0xa1, 0xbf,
+	0x83, 0x43, 0xfe, 0x81, 0x9e, 0x17, 0x52, 0xc6, 0xbd, 0xc4, 0x47, 0x97, 0xd3, 0x18, 0x73, 0x7d,
+	0xba, 0x2b, 0xf4, 0x29, 0x8d, 0x91, 0x1c, 0xc3, 0xfe, 0xba, 0x59, 0xc6, 0xcb, 0x34, 0xeb, 0xad,
+	0x61, 0x4d, 0x1c, 0x7d, 0xac, 0x40, 0x77, 0xa6, 0xa8, 0x92, 0x2b, 0x4f, 0xad, 0x4d, 0x4f, 0xf5,
+	0x68, 0x45, 0xb8, 0x9a, 0xb8, 0xba, 0x31, 0xf1, 0x18, 0x0e, 0x62, 0xc6, 0x77, 0xa9, 0xde, 0x8b,
+	0x19, 0xdf, 0x94, 0x5b, 0x33, 0xe9, 0xb2, 0xcc, 0xac, 0xe7, 0x4c, 0xba, 0xdc, 0x64, 0x3e, 0x86,
+	0xbe, 0x5e, 0x39, 0xa9, 0xdc, 0x92, 0x3f, 0x8d, 0xaf, 0xfa, 0xf3, 0x53, 0x56, 0x76, 0xb9, 0x76,
+	0x69, 0x74, 0x0c, 0xdd, 0xcb, 0x54, 0x04, 0xb8, 0x1a, 0xef, 0x17, 0x68, 0x2e, 0x34, 0xe0, 0xe7,
+	0xd3, 0xe5, 0xd1, 0xf4, 0x43, 0x15, 0x7a, 0xe5, 0x3d, 0x24, 0x2f, 0xa0, 0xae, 0xb7, 0x9d, 0xd8,
+	0xbb, 0x0c, 0xfc, 0xc2, 0x4b, 0x68, 0x30, 0xdc, 0x55, 0x50, 0xfa, 0xe3, 0x5c, 0x43, 0xc3, 0xa8,
+	0xfe, 0xfd, 0xbd, 0x8f, 0x76, 0x16, 0x94, 0x1c, 0xbc, 0x86, 0x86, 0x99, 0xf9, 0x07, 0x35, 0x2f,
+	0xe9, 0x77, 0xfa, 0xe8, 0xe5, 0x59, 0xc0, 0x54, 0x98, 0xce, 0xf5, 0xcb, 0xcf, 0x0e, 0x6f, 0x17,
+	0x28, 0x22, 0xf4, 0x03, 0x14, 0xf6, 0x0d, 0x9d, 0x0b, 0xe6, 0xd9, 0xdf, 0xf6, 0x19, 0x99, 0x37,
+	0x8d, 0x7f, 0xff, 0x7e, 0x1a, 0x00, 0xf2, 0xcd, 0xe3, 0xb8, 0x77, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TransientStoreClient is the client API for TransientStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TransientStoreClient interface {
+	// List describes the private write sets of some transactions, or the
+	// private write sets received within a range of block heights. The
+	// content of the private write sets is not returned.
+	List(ctx context.Context, in *SignedTransientStoreRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Stats summarizes the private write sets held in the transient store.
+	Stats(ctx context.Context, in *SignedTransientStoreRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// Purge removes the private write sets of some transactions, or the
+	// private write sets older than a given age.
+	Purge(ctx context.Context, in *SignedTransientStoreRequest, opts ...grpc.CallOption) (*PurgeResponse, error)
+}
+
+type transientStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransientStoreClient(cc grpc.ClientConnInterface) TransientStoreClient {
+	return &transientStoreClient{cc}
+}
+
+func (c *transientStoreClient) List(ctx context.Context, in *SignedTransientStoreRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/transientstoregrpc.TransientStore/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transientStoreClient) Stats(ctx context.Context, in *SignedTransientStoreRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/transientstoregrpc.TransientStore/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transientStoreClient) Purge(ctx context.Context, in *SignedTransientStoreRequest, opts ...grpc.CallOption) (*PurgeResponse, error) {
+	out := new(PurgeResponse)
+	err := c.cc.Invoke(ctx, "/transientstoregrpc.TransientStore/Purge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransientStoreServer is the server API for TransientStore service.
+type TransientStoreServer interface {
+	// List describes the private write sets of some transactions, or the
+	// private write sets received within a range of block heights. The
+	// content of the private write sets is not returned.
+	List(context.Context, *SignedTransientStoreRequest) (*ListResponse, error)
+	// Stats summarizes the private write sets held in the transient store.
+	Stats(context.Context, *SignedTransientStoreRequest) (*StatsResponse, error)
+	// Purge removes the private write sets of some transactions, or the
+	// private write sets older than a given age.
+	Purge(context.Context, *SignedTransientStoreRequest) (*PurgeResponse, error)
+}
+
+// UnimplementedTransientStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedTransientStoreServer struct {
+}
+
+func (*UnimplementedTransientStoreServer) List(ctx context.Context, req *SignedTransientStoreRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedTransientStoreServer) Stats(ctx context.Context, req *SignedTransientStoreRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (*UnimplementedTransientStoreServer) Purge(ctx context.Context, req *SignedTransientStoreRequest) (*PurgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+
+func RegisterTransientStoreServer(s *grpc.Server, srv TransientStoreServer) {
+	s.RegisterService(&_TransientStore_serviceDesc, srv)
+}
+
+func _TransientStore_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedTransientStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransientStoreServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transientstoregrpc.TransientStore/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransientStoreServer).List(ctx, req.(*SignedTransientStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransientStore_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedTransientStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransientStoreServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transientstoregrpc.TransientStore/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransientStoreServer).Stats(ctx, req.(*SignedTransientStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransientStore_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedTransientStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransientStoreServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/transientstoregrpc.TransientStore/Purge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransientStoreServer).Purge(ctx, req.(*SignedTransientStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TransientStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "transientstoregrpc.TransientStore",
+	HandlerType: (*TransientStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _TransientStore_List_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _TransientStore_Stats_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _TransientStore_Purge_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "core/transientstore/transientstoregrpc/transientstore.proto",
+}
@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstoregrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// TransientStoreService implements the TransientStoreServer grpc interface
+type TransientStoreService struct {
+	StoreGetter StoreGetter
+	ACLProvider ACLProvider
+}
+
+// StoreGetter gets the transient store associated with a channel.
+type StoreGetter interface {
+	StoreForChannel(channelID string) *transientstore.Store
+}
+
+// ACLProvider checks ACL for a channelless resource
+type ACLProvider interface {
+	CheckACLNoChannel(resName string, idinfo interface{}) error
+}
+
+// List describes the private write sets of the requested transactions or, if none, the private
+// write sets received within the requested range of block heights.
+func (s *TransientStoreService) List(ctx context.Context, signedRequest *SignedTransientStoreRequest) (*ListResponse, error) {
+	request, store, err := s.authorize(resources.TransientStore_list, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*transientstore.EntryInfo
+	if len(request.TxIds) > 0 {
+		for _, txID := range request.TxIds {
+			txEntries, err := store.EntriesByTxid(txID)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to list the entries of transaction %s", txID)
+			}
+			entries = append(entries, txEntries...)
+		}
+	} else {
+		if request.EndHeight != 0 && request.EndHeight < request.StartHeight {
+			return nil, errors.Errorf("end height %d is lower than start height %d", request.EndHeight, request.StartHeight)
+		}
+		entries, err = store.EntriesByHeight(request.StartHeight, request.EndHeight, int(request.Limit))
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list the entries")
+		}
+	}
+
+	response := &ListResponse{}
+	for _, entry := range entries {
+		responseEntry := &TransientStoreEntry{
+			TxId:                  entry.TxID,
+			Uuid:                  entry.UUID,
+			ReceivedAtBlockHeight: entry.ReceivedAtBlockHeight,
+			PersistTime:           timestampProto(entry.PersistTime),
+			Size:                  uint64(entry.Size),
+		}
+		for _, collection := range entry.Collections {
+			responseEntry.Collections = append(responseEntry.Collections, &CollectionReference{
+				ChaincodeName:  collection.Namespace,
+				CollectionName: collection.Collection,
+			})
+		}
+		response.Entries = append(response.Entries, responseEntry)
+	}
+
+	return response, nil
+}
+
+// Stats summarizes the private write sets held in the transient store of a channel.
+func (s *TransientStoreService) Stats(ctx context.Context, signedRequest *SignedTransientStoreRequest) (*StatsResponse, error) {
+	_, store, err := s.authorize(resources.TransientStore_stats, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := store.Stats()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to compute the statistics of the transient store")
+	}
+
+	return &StatsResponse{
+		Entries:           stats.Entries,
+		Size:              stats.Size,
+		MinBlockHeight:    stats.MinBlockHeight,
+		MaxBlockHeight:    stats.MaxBlockHeight,
+		OldestPersistTime: timestampProto(stats.OldestPersistTime),
+	}, nil
+}
+
+// Purge removes the private write sets of the requested transactions or, if none, the private
+// write sets older than the requested age.
+func (s *TransientStoreService) Purge(ctx context.Context, signedRequest *SignedTransientStoreRequest) (*PurgeResponse, error) {
+	request, store, err := s.authorize(resources.TransientStore_purge, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(request.TxIds) > 0 && request.OlderThan != nil:
+		return nil, errors.New("transactions and age cannot be both specified")
+	case len(request.TxIds) > 0:
+		purged, err := store.PurgeEntriesByTxids(request.TxIds)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to purge the entries of the transactions")
+		}
+		return &PurgeResponse{Purged: uint64(purged)}, nil
+	case request.OlderThan != nil:
+		age, err := ptypes.Duration(request.OlderThan)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid age")
+		}
+		if age < 0 {
+			return nil, errors.Errorf("invalid age %s, it must not be negative", age)
+		}
+		purged, err := store.PurgeOlderThan(time.Now().Add(-age))
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to purge the entries older than the age")
+		}
+		return &PurgeResponse{Purged: uint64(purged)}, nil
+	default:
+		return nil, errors.New("either transactions or an age must be specified")
+	}
+}
+
+// authorize checks that the creator of the request is allowed to access the resource and returns the request, along
+// with the transient store of the requested channel
+func (s *TransientStoreService) authorize(resName string, signedRequest *SignedTransientStoreRequest) (*TransientStoreRequest, *transientstore.Store, error) {
+	request := &TransientStoreRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal transient store request")
+	}
+
+	if err := s.checkACL(resName, request, signedRequest); err != nil {
+		return nil, nil, err
+	}
+
+	if request.ChannelId == "" {
+		return nil, nil, errors.New("missing channel ID")
+	}
+
+	store := s.StoreGetter.StoreForChannel(request.ChannelId)
+	if store == nil {
+		return nil, nil, errors.Errorf("cannot find transient store for channel %s", request.ChannelId)
+	}
+
+	return request, store, nil
+}
+
+func (s *TransientStoreService) checkACL(resName string, request *TransientStoreRequest, signedRequest *SignedTransientStoreRequest) error {
+	signatureHdr := request.SignatureHeader
+	if signatureHdr == nil {
+		return errors.New("missing signature header")
+	}
+
+	expirationTime := crypto.ExpiresAt(signatureHdr.Creator)
+	if !expirationTime.IsZero() && time.Now().After(expirationTime) {
+		return errors.New("client identity expired")
+	}
+
+	return s.ACLProvider.CheckACLNoChannel(
+		resName,
+		[]*protoutil.SignedData{{
+			Identity:  signatureHdr.Creator,
+			Data:      signedRequest.Request,
+			Signature: signedRequest.Signature,
+		}},
+	)
+}
+
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
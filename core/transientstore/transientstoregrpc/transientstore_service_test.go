@@ -0,0 +1,309 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstoregrpc
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	tspb "github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/core/transientstore/transientstoregrpc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/store_getter.go -fake-name StoreGetter . storeGetter
+//go:generate counterfeiter -o mock/acl_provider.go -fake-name ACLProvider . aclProvider
+
+type storeGetter interface {
+	StoreGetter
+}
+
+type aclProvider interface {
+	ACLProvider
+}
+
+type testService struct {
+	service     *TransientStoreService
+	store       *transientstore.Store
+	aclProvider *mock.ACLProvider
+}
+
+func newTestService(t *testing.T) *testService {
+	tempdir, err := ioutil.TempDir("", "transientstoregrpc")
+	require.NoError(t, err)
+	storeProvider, err := transientstore.NewStoreProvider(tempdir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		storeProvider.Close()
+		os.RemoveAll(tempdir)
+	})
+	store, err := storeProvider.OpenStore("mychannel")
+	require.NoError(t, err)
+
+	getter := &mock.StoreGetter{}
+	getter.StoreForChannelStub = func(channelID string) *transientstore.Store {
+		if channelID == "mychannel" {
+			return store
+		}
+		return nil
+	}
+	aclProvider := &mock.ACLProvider{}
+	return &testService{
+		service:     &TransientStoreService{StoreGetter: getter, ACLProvider: aclProvider},
+		store:       store,
+		aclProvider: aclProvider,
+	}
+}
+
+func (ts *testService) persist(t *testing.T, txID string, blockHeight uint64) {
+	err := ts.store.Persist(txID, blockHeight, &tspb.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: &rwset.TxPvtReadWriteSet{
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace:          "cc",
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "coll", Rwset: []byte("rwset")}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func signedRequest(request *TransientStoreRequest) *SignedTransientStoreRequest {
+	return &SignedTransientStoreRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("signature"),
+	}
+}
+
+var signatureHdr = &common.SignatureHeader{Creator: []byte("creator")}
+
+func TestList(t *testing.T) {
+	test := newTestService(t)
+	test.persist(t, "tx1", 10)
+	test.persist(t, "tx1", 11)
+	test.persist(t, "tx2", 12)
+
+	checkEntry := func(entry *TransientStoreEntry, txID string, blockHeight uint64) {
+		require.Equal(t, txID, entry.TxId)
+		require.NotEmpty(t, entry.Uuid)
+		require.Equal(t, blockHeight, entry.ReceivedAtBlockHeight)
+		require.NotNil(t, entry.PersistTime)
+		require.NotZero(t, entry.Size)
+		require.Len(t, entry.Collections, 1)
+		require.Equal(t, "cc", entry.Collections[0].ChaincodeName)
+		require.Equal(t, "coll", entry.Collections[0].CollectionName)
+	}
+
+	t.Run("by transaction", func(t *testing.T) {
+		response, err := test.service.List(context.Background(), signedRequest(&TransientStoreRequest{
+			SignatureHeader: signatureHdr,
+			ChannelId:       "mychannel",
+			TxIds:           []string{"tx2", "tx3"},
+		}))
+		require.NoError(t, err)
+		require.Len(t, response.Entries, 1)
+		checkEntry(response.Entries[0], "tx2", 12)
+
+		resName, idinfo := test.aclProvider.CheckACLNoChannelArgsForCall(0)
+		require.Equal(t, resources.TransientStore_list, resName)
+		signedData := idinfo.([]*protoutil.SignedData)
+		require.Equal(t, []byte("creator"), signedData[0].Identity)
+		require.Equal(t, []byte("signature"), signedData[0].Signature)
+	})
+
+	t.Run("by height", func(t *testing.T) {
+		response, err := test.service.List(context.Background(), signedRequest(&TransientStoreRequest{
+			SignatureHeader: signatureHdr,
+			ChannelId:       "mychannel",
+			StartHeight:     11,
+		}))
+		require.NoError(t, err)
+		require.Len(t, response.Entries, 2)
+		checkEntry(response.Entries[0], "tx1", 11)
+		checkEntry(response.Entries[1], "tx2", 12)
+
+		response, err = test.service.List(context.Background(), signedRequest(&TransientStoreRequest{
+			SignatureHeader: signatureHdr,
+			ChannelId:       "mychannel",
+			EndHeight:       11,
+			Limit:           1,
+		}))
+		require.NoError(t, err)
+		require.Len(t, response.Entries, 1)
+		checkEntry(response.Entries[0], "tx1", 10)
+	})
+
+	tests := []struct {
+		name    string
+		request *TransientStoreRequest
+		aclErr  error
+		errMsg  string
+	}{
+		{
+			name:    "missing signature header",
+			request: &TransientStoreRequest{ChannelId: "mychannel"},
+			errMsg:  "missing signature header",
+		},
+		{
+			name:    "access denied",
+			request: &TransientStoreRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel"},
+			aclErr:  errors.New("access denied"),
+			errMsg:  "access denied",
+		},
+		{
+			name:    "missing channel",
+			request: &TransientStoreRequest{SignatureHeader: signatureHdr},
+			errMsg:  "missing channel ID",
+		},
+		{
+			name:    "unknown channel",
+			request: &TransientStoreRequest{SignatureHeader: signatureHdr, ChannelId: "otherchannel"},
+			errMsg:  "cannot find transient store for channel otherchannel",
+		},
+		{
+			name:    "invalid height range",
+			request: &TransientStoreRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel", StartHeight: 20, EndHeight: 10},
+			errMsg:  "end height 10 is lower than start height 20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.aclProvider.CheckACLNoChannelReturns(tt.aclErr)
+			_, err := test.service.List(context.Background(), signedRequest(tt.request))
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		_, err := test.service.List(context.Background(), &SignedTransientStoreRequest{Request: []byte("garbage")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal transient store request")
+	})
+}
+
+func TestStats(t *testing.T) {
+	test := newTestService(t)
+	request := signedRequest(&TransientStoreRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel"})
+
+	response, err := test.service.Stats(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, &StatsResponse{}, response)
+
+	start := time.Now()
+	test.persist(t, "tx1", 10)
+	test.persist(t, "tx2", 12)
+
+	response, err = test.service.Stats(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), response.Entries)
+	require.NotZero(t, response.Size)
+	require.Equal(t, uint64(10), response.MinBlockHeight)
+	require.Equal(t, uint64(12), response.MaxBlockHeight)
+	oldest, err := ptypes.Timestamp(response.OldestPersistTime)
+	require.NoError(t, err)
+	require.False(t, oldest.Before(start))
+
+	resName, _ := test.aclProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, resources.TransientStore_stats, resName)
+
+	test.aclProvider.CheckACLNoChannelReturns(errors.New("access denied"))
+	_, err = test.service.Stats(context.Background(), request)
+	require.EqualError(t, err, "access denied")
+}
+
+func TestPurge(t *testing.T) {
+	test := newTestService(t)
+	test.persist(t, "tx1", 10)
+	test.persist(t, "tx1", 11)
+	test.persist(t, "tx2", 12)
+
+	response, err := test.service.Purge(context.Background(), signedRequest(&TransientStoreRequest{
+		SignatureHeader: signatureHdr,
+		ChannelId:       "mychannel",
+		TxIds:           []string{"tx1"},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), response.Purged)
+
+	resName, _ := test.aclProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, resources.TransientStore_purge, resName)
+
+	response, err = test.service.Purge(context.Background(), signedRequest(&TransientStoreRequest{
+		SignatureHeader: signatureHdr,
+		ChannelId:       "mychannel",
+		OlderThan:       ptypes.DurationProto(time.Hour),
+	}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), response.Purged)
+
+	response, err = test.service.Purge(context.Background(), signedRequest(&TransientStoreRequest{
+		SignatureHeader: signatureHdr,
+		ChannelId:       "mychannel",
+		OlderThan:       ptypes.DurationProto(0),
+	}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), response.Purged)
+
+	stats, err := test.store.Stats()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), stats.Entries)
+
+	tests := []struct {
+		name    string
+		request *TransientStoreRequest
+		errMsg  string
+	}{
+		{
+			name:    "nothing to purge",
+			request: &TransientStoreRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel"},
+			errMsg:  "either transactions or an age must be specified",
+		},
+		{
+			name: "transactions and age",
+			request: &TransientStoreRequest{
+				SignatureHeader: signatureHdr,
+				ChannelId:       "mychannel",
+				TxIds:           []string{"tx1"},
+				OlderThan:       ptypes.DurationProto(time.Hour),
+			},
+			errMsg: "transactions and age cannot be both specified",
+		},
+		{
+			name: "negative age",
+			request: &TransientStoreRequest{
+				SignatureHeader: signatureHdr,
+				ChannelId:       "mychannel",
+				OlderThan:       ptypes.DurationProto(-time.Hour),
+			},
+			errMsg: "invalid age -1h0m0s, it must not be negative",
+		},
+		{
+			name:    "unknown channel",
+			request: &TransientStoreRequest{SignatureHeader: signatureHdr, ChannelId: "otherchannel", TxIds: []string{"tx1"}},
+			errMsg:  "cannot find transient store for channel otherchannel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := test.service.Purge(context.Background(), signedRequest(tt.request))
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
@@ -50,6 +50,9 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// Connections returns the state of the connections to remote peers
+	Connections() []ConnectionState
+
 	// Stop stops the module
 	Stop()
 }
@@ -60,6 +63,16 @@ type RemotePeer struct {
 	PKIID    common.PKIidType
 }
 
+// ConnectionState describes a connection to a remote peer
+type ConnectionState struct {
+	Endpoint string
+	PKIID    common.PKIidType
+	// Outbound is true if the connection was initiated by this peer
+	Outbound bool
+	// PendingMessages is the number of messages waiting to be sent to the remote peer
+	PendingMessages int
+}
+
 // SendResult defines a result of a send to a remote peer
 type SendResult struct {
 	error
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
@@ -80,6 +81,7 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 		connTimeout:     config.ConnTimeout,
 		recvBuffSize:    config.RecvBuffSize,
 		sendBuffSize:    config.SendBuffSize,
+		compressor:      newCompressor(config, commMetrics),
 	}
 
 	connConfig := ConnConfig{
@@ -100,6 +102,12 @@ type CommConfig struct {
 	ConnTimeout  time.Duration // Connection timeout
 	RecvBuffSize int           // Buffer size of received messages
 	SendBuffSize int           // Buffer size of sending messages
+	// CompressionEnabled advertises support for compression when establishing connections,
+	// and compresses large data, state response and private data messages sent
+	// to remote peers that advertised support for compression too.
+	CompressionEnabled bool
+	// CompressionThreshold is the minimum payload size of a message for it to be compressed
+	CompressionThreshold int
 }
 
 type commImpl struct {
@@ -126,6 +134,7 @@ type commImpl struct {
 	connTimeout     time.Duration
 	recvBuffSize    int
 	sendBuffSize    int
+	compressor      *compressor
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -163,6 +172,9 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 	}
 
 	ctx, cancel = context.WithCancel(context.Background())
+	if c.compressor != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, compressionMetadataKey, gzipCompression)
+	}
 	if stream, err = cl.GossipStream(ctx); err == nil {
 		connInfo, err = c.authenticateRemotePeer(stream, true, false)
 		if err == nil {
@@ -193,6 +205,10 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 			conn.info = connInfo
 			conn.logger = c.logger
 			conn.cancel = cancel
+			// The remote peer advertises support for compression in the headers of its response
+			if header, err := stream.Header(); err == nil && c.compressor != nil && compressionSupported(header) {
+				conn.compressor = c.compressor
+			}
 
 			h := func(m *protoext.SignedGossipMessage) {
 				c.logger.Debug("Got message:", m)
@@ -368,6 +384,10 @@ func (c *commImpl) CloseConn(peer *RemotePeer) {
 	c.connStore.closeConnByPKIid(peer.PKIID)
 }
 
+func (c *commImpl) Connections() []ConnectionState {
+	return c.connStore.connStates()
+}
+
 func (c *commImpl) closeSubscriptions() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -561,6 +581,14 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	if c.isStopping() {
 		return errors.New("shutting down")
 	}
+	// Advertise support for compression back to the remote peer, if it advertised it too
+	var compressor *compressor
+	if c.compressor != nil && compressionRequested(stream.Context()) {
+		if err := stream.SetHeader(metadata.Pairs(compressionMetadataKey, gzipCompression)); err == nil {
+			compressor = c.compressor
+		}
+	}
+
 	connInfo, err := c.authenticateRemotePeer(stream, false, false)
 
 	if err == errProbe {
@@ -574,7 +602,7 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	}
 	c.logger.Debug("Servicing", extractRemoteAddress(stream))
 
-	conn := c.connStore.onConnected(stream, connInfo, c.metrics)
+	conn := c.connStore.onConnected(stream, connInfo, c.metrics, compressor)
 
 	h := func(m *protoext.SignedGossipMessage) {
 		c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
@@ -525,6 +525,36 @@ func TestGetConnectionInfo(t *testing.T) {
 	}
 }
 
+func TestConnections(t *testing.T) {
+	comm1, port1 := newCommInstance(t, naiveSec)
+	comm2, _ := newCommInstance(t, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	require.Empty(t, comm2.Connections())
+
+	m1 := comm1.Accept(acceptAll)
+	comm2.Send(createGossipMsg(), remotePeer(port1))
+	select {
+	case <-time.After(time.Second * 10):
+		t.Fatal("Didn't receive a message in time")
+	case <-m1:
+	}
+
+	outbound := comm2.Connections()
+	require.Len(t, outbound, 1)
+	require.Equal(t, comm1.GetPKIid(), outbound[0].PKIID)
+	require.Equal(t, fmt.Sprintf("127.0.0.1:%d", port1), outbound[0].Endpoint)
+	require.True(t, outbound[0].Outbound)
+
+	inbound := comm1.Connections()
+	require.Len(t, inbound, 1)
+	require.Equal(t, comm2.GetPKIid(), inbound[0].PKIID)
+	require.False(t, inbound[0].Outbound)
+
+	comm2.CloseConn(&RemotePeer{PKIID: comm1.GetPKIid()})
+	require.Empty(t, comm2.Connections())
+}
+
 func TestCloseConn(t *testing.T) {
 	comm1, port1 := newCommInstance(t, naiveSec)
 	defer comm1.Stop()
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefCompressionThreshold is the default minimum payload size of a message for it to be compressed
+	DefCompressionThreshold = 4096
+
+	// compressionMetadataKey is the gRPC metadata key through which peers advertise
+	// the compression algorithms they support when establishing a gossip stream.
+	// Peers that don't know about it ignore it, and keep sending and receiving uncompressed messages.
+	compressionMetadataKey = "gossip-compression"
+	gzipCompression        = "gzip"
+
+	// maxDecompressedSize bounds the size of a decompressed payload
+	maxDecompressedSize = 100 * 1024 * 1024
+)
+
+// gzipMagic prefixes every gzip stream. Since 0x1f is a protobuf tag with an invalid wire type,
+// a payload that starts with it can't be a marshaled GossipMessage, and is thus a compressed one.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// compressor compresses the payloads of large data, state response and private data messages
+// sent over connections on which both peers advertised support for compression
+type compressor struct {
+	threshold int
+	metrics   *metrics.CommMetrics
+}
+
+func newCompressor(config CommConfig, metrics *metrics.CommMetrics) *compressor {
+	if !config.CompressionEnabled {
+		return nil
+	}
+	threshold := config.CompressionThreshold
+	if threshold <= 0 {
+		threshold = DefCompressionThreshold
+	}
+	return &compressor{threshold: threshold, metrics: metrics}
+}
+
+// compress returns an envelope of the given message with a compressed payload, or the envelope
+// of the message itself if it isn't eligible to compression or if compression doesn't reduce its size
+func (c *compressor) compress(msg *protoext.SignedGossipMessage) *proto.Envelope {
+	envelope := msg.Envelope
+	msgType := compressibleType(msg.GossipMessage)
+	if msgType == "" || len(envelope.Payload) < c.threshold {
+		return envelope
+	}
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(envelope.Payload); err != nil {
+		return envelope
+	}
+	if err := w.Close(); err != nil {
+		return envelope
+	}
+	if buf.Len() >= len(envelope.Payload) {
+		return envelope
+	}
+
+	c.metrics.CompressionRatio.With("message_type", msgType).Observe(float64(buf.Len()) / float64(len(envelope.Payload)))
+	c.metrics.CompressionSavedBytes.With("message_type", msgType).Add(float64(len(envelope.Payload) - buf.Len()))
+
+	return &proto.Envelope{
+		Payload:        buf.Bytes(),
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}
+}
+
+// decompress returns the envelope with the original payload of the given envelope,
+// or the given envelope if its payload isn't compressed
+func decompress(envelope *proto.Envelope) (*proto.Envelope, error) {
+	if !bytes.HasPrefix(envelope.Payload, gzipMagic) {
+		return envelope, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(envelope.Payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing message")
+	}
+	payload, err := ioutil.ReadAll(io.LimitReader(r, maxDecompressedSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing message")
+	}
+	if len(payload) > maxDecompressedSize {
+		return nil, errors.Errorf("decompressed message exceeds %d bytes", maxDecompressedSize)
+	}
+
+	return &proto.Envelope{
+		Payload:        payload,
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}, nil
+}
+
+// compressibleType returns the type of the given message as reported in the compression metrics,
+// or an empty string if the message isn't eligible to compression
+func compressibleType(msg *proto.GossipMessage) string {
+	switch {
+	case msg.GetDataMsg() != nil:
+		return "data"
+	case msg.GetStateResponse() != nil:
+		return "state_response"
+	case msg.GetPrivateData() != nil, msg.GetPrivateRes() != nil:
+		return "private_data"
+	default:
+		return ""
+	}
+}
+
+// compressionSupported returns whether the given gRPC metadata advertises support for compression
+func compressionSupported(md metadata.MD) bool {
+	for _, algorithm := range md.Get(compressionMetadataKey) {
+		if algorithm == gzipCompression {
+			return true
+		}
+	}
+	return false
+}
+
+// compressionRequested returns whether the remote peer that opened the stream
+// of the given context advertised support for compression
+func compressionRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && compressionSupported(md)
+}
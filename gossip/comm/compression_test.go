@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/metrics/mocks"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressionCommInstance(t *testing.T, commMetrics *metrics.CommMetrics, compressionEnabled bool) (Comm, int) {
+	port, gRPCServer, certs, secureDialOpts, dialOpts := util.CreateGRPCLayer()
+	_, portString, err := net.SplitHostPort(gRPCServer.Address())
+	require.NoError(t, err)
+
+	id := []byte(fmt.Sprintf("127.0.0.1:%s", portString))
+	identityMapper := identity.NewIdentityMapper(naiveSec, id, noopPurgeIdentity, naiveSec)
+
+	config := testCommConfig
+	config.CompressionEnabled = compressionEnabled
+	config.CompressionThreshold = 1024
+	commInst, err := NewCommInstance(gRPCServer.Server(), certs, identityMapper, id, secureDialOpts,
+		naiveSec, commMetrics, config, dialOpts...)
+	require.NoError(t, err)
+
+	go gRPCServer.Start()
+
+	return &commGRPC{commInst.(*commImpl), gRPCServer}, port
+}
+
+func createDataMsg(payloadSize int) *protoext.SignedGossipMessage {
+	msg, _ := protoext.NoopSign(&proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: util.RandomUInt64(),
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{
+				Payload: &proto.Payload{
+					SeqNum: 1,
+					Data:   bytes.Repeat([]byte("block"), payloadSize/5),
+				},
+			},
+		},
+	})
+	return msg
+}
+
+func TestCompression(t *testing.T) {
+	c := &compressor{threshold: 1024, metrics: disabledMetrics}
+
+	t.Run("large data message", func(t *testing.T) {
+		msg := createDataMsg(10000)
+		envelope := c.compress(msg)
+		require.Less(t, len(envelope.Payload), len(msg.Envelope.Payload))
+		require.Equal(t, msg.Envelope.Signature, envelope.Signature)
+
+		decompressed, err := decompress(envelope)
+		require.NoError(t, err)
+		require.Equal(t, msg.Envelope.Payload, decompressed.Payload)
+		require.Equal(t, msg.Envelope.Signature, decompressed.Signature)
+	})
+
+	t.Run("small message", func(t *testing.T) {
+		msg := createDataMsg(100)
+		require.Equal(t, msg.Envelope, c.compress(msg))
+	})
+
+	t.Run("ineligible message type", func(t *testing.T) {
+		msg, _ := protoext.NoopSign(&proto.GossipMessage{
+			Content: &proto.GossipMessage_AliveMsg{
+				AliveMsg: &proto.AliveMessage{
+					Membership: &proto.Member{Metadata: bytes.Repeat([]byte{1}, 10000)},
+				},
+			},
+		})
+		require.Equal(t, msg.Envelope, c.compress(msg))
+	})
+
+	t.Run("incompressible message", func(t *testing.T) {
+		data := make([]byte, 10000)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+		msg, _ := protoext.NoopSign(&proto.GossipMessage{
+			Content: &proto.GossipMessage_PrivateData{
+				PrivateData: &proto.PrivateDataMessage{
+					Payload: &proto.PrivatePayload{PrivateRwset: data},
+				},
+			},
+		})
+		require.Equal(t, msg.Envelope, c.compress(msg))
+	})
+
+	t.Run("uncompressed envelope", func(t *testing.T) {
+		msg := createDataMsg(100)
+		envelope, err := decompress(msg.Envelope)
+		require.NoError(t, err)
+		require.Equal(t, msg.Envelope, envelope)
+	})
+
+	t.Run("corrupted envelope", func(t *testing.T) {
+		envelope := c.compress(createDataMsg(10000))
+		envelope.Payload = envelope.Payload[:len(envelope.Payload)/2]
+		_, err := decompress(envelope)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed decompressing message")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		require.Nil(t, newCompressor(CommConfig{}, disabledMetrics))
+		require.Equal(t, DefCompressionThreshold, newCompressor(CommConfig{CompressionEnabled: true}, disabledMetrics).threshold)
+	})
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	// Scenario: comm1 and comm2 support compression, while comm3 doesn't.
+	// Large data messages are compressed between comm1 and comm2 in both directions,
+	// while messages are sent uncompressed between comm1 and comm3.
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	commMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).CommMetrics
+
+	comm1, port1 := newCompressionCommInstance(t, commMetrics, true)
+	defer comm1.Stop()
+	comm2, port2 := newCompressionCommInstance(t, commMetrics, true)
+	defer comm2.Stop()
+	comm3, port3 := newCompressionCommInstance(t, disabledMetrics, false)
+	defer comm3.Stop()
+
+	inc1 := comm1.Accept(acceptAll)
+	inc2 := comm2.Accept(acceptAll)
+	inc3 := comm3.Accept(acceptAll)
+
+	sendAndReceive := func(from Comm, to *RemotePeer, inc <-chan protoext.ReceivedMessage, msg *protoext.SignedGossipMessage) {
+		from.Send(msg, to)
+		select {
+		case m := <-inc:
+			require.Equal(t, msg.Envelope.Payload, m.GetSourceEnvelope().Payload)
+			require.Equal(t, msg.GetDataMsg().Payload.Data, m.GetGossipMessage().GetDataMsg().Payload.Data)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "didn't receive message")
+		}
+	}
+
+	// comm1 opens the connection to comm2, and compresses the message
+	sendAndReceive(comm1, remotePeer(port2), inc2, createDataMsg(10000))
+	require.Equal(t, 1, testMetricProvider.FakeCompressionRatio.ObserveCallCount())
+	require.Less(t, testMetricProvider.FakeCompressionRatio.ObserveArgsForCall(0), 0.1)
+	require.Equal(t, []string{"message_type", "data"}, testMetricProvider.FakeCompressionRatio.WithArgsForCall(0))
+	require.Equal(t, 1, testMetricProvider.FakeCompressionSavedBytes.AddCallCount())
+
+	// comm2 responds over the same connection, and compresses the message too
+	sendAndReceive(comm2, remotePeer(port1), inc1, createDataMsg(10000))
+	require.Equal(t, 2, testMetricProvider.FakeCompressionRatio.ObserveCallCount())
+
+	// Small messages aren't compressed
+	sendAndReceive(comm1, remotePeer(port2), inc2, createDataMsg(100))
+	require.Equal(t, 2, testMetricProvider.FakeCompressionRatio.ObserveCallCount())
+
+	// comm3 doesn't support compression, so messages are sent uncompressed in both directions
+	sendAndReceive(comm1, remotePeer(port3), inc3, createDataMsg(10000))
+	sendAndReceive(comm3, remotePeer(port1), inc1, createDataMsg(10000))
+	sendAndReceive(comm3, remotePeer(port2), inc2, createDataMsg(10000))
+	sendAndReceive(comm2, remotePeer(port3), inc3, createDataMsg(10000))
+	require.Equal(t, 2, testMetricProvider.FakeCompressionRatio.ObserveCallCount())
+}
@@ -144,6 +144,24 @@ func (cs *connectionStore) connNum() int {
 	return len(cs.pki2Conn)
 }
 
+func (cs *connectionStore) connStates() []ConnectionState {
+	cs.RLock()
+	defer cs.RUnlock()
+	states := make([]ConnectionState, 0, len(cs.pki2Conn))
+	for _, conn := range cs.pki2Conn {
+		state := ConnectionState{
+			PKIID:           conn.pkiID,
+			Outbound:        conn.conn != nil,
+			PendingMessages: len(conn.outBuff),
+		}
+		if conn.info != nil {
+			state.Endpoint = conn.info.Endpoint
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
 func (cs *connectionStore) shutdown() {
 	cs.shutdownOnce.Do(func() {
 		cs.Lock()
@@ -161,7 +179,7 @@ func (cs *connectionStore) shutdown() {
 // onConnected closes any connection to the remote peer and creates a new connection object to it in order to have only
 // one single bi-directional connection between a pair of peers
 func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamServer,
-	connInfo *protoext.ConnectionInfo, metrics *metrics.CommMetrics) *connection {
+	connInfo *protoext.ConnectionInfo, metrics *metrics.CommMetrics, compressor *compressor) *connection {
 	cs.Lock()
 	defer cs.Unlock()
 
@@ -173,6 +191,7 @@ func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamSer
 	conn.pkiID = connInfo.ID
 	conn.info = connInfo
 	conn.logger = cs.logger
+	conn.compressor = compressor
 	cs.pki2Conn[string(connInfo.ID)] = conn
 	return conn
 }
@@ -219,6 +238,7 @@ type connection struct {
 	gossipStream stream             // there can only be one
 	stopChan     chan struct{}      // a method to stop the server-side gRPC call from a different go-routine
 	stopOnce     sync.Once          // once to ensure close is called only once
+	compressor   *compressor        // compresses outgoing messages, nil if the remote peer doesn't support compression
 }
 
 func (conn *connection) close() {
@@ -236,8 +256,12 @@ func (conn *connection) close() {
 }
 
 func (conn *connection) send(msg *protoext.SignedGossipMessage, onErr func(error), shouldBlock blockingBehavior) {
+	envelope := msg.Envelope
+	if conn.compressor != nil {
+		envelope = conn.compressor.compress(msg)
+	}
 	m := &msgSending{
-		envelope: msg.Envelope,
+		envelope: envelope,
 		onErr:    onErr,
 	}
 
@@ -316,6 +340,14 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *protoex
 				return
 			}
 			conn.metrics.ReceivedMessages.Add(1)
+			if conn.compressor != nil {
+				envelope, err = decompress(envelope)
+				if err != nil {
+					errChan <- err
+					conn.logger.Warningf("Got error, aborting: %v", err)
+					return
+				}
+			}
 			msg, err := protoext.EnvelopeToGossipMessage(envelope)
 			if err != nil {
 				errChan <- err
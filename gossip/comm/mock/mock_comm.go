@@ -189,6 +189,11 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// Connections returns the state of the connections to remote peers
+func (mock *commMock) Connections() []comm.ConnectionState {
+	return nil
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
@@ -962,6 +962,13 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 	return response
 }
 
+func (d *gossipDiscoveryImpl) GetDeadMembership() []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	return d.copyLastSeen(d.deadLastTS)
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -901,6 +901,16 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	waitUntilOrFail(t, func() bool {
+		deadEndpoints := map[string]struct{}{}
+		for _, member := range instances[0].GetDeadMembership() {
+			deadEndpoints[member.Endpoint] = struct{}{}
+		}
+		_, d4Dead := deadEndpoints[instances[nodeNum-2].Self().Endpoint]
+		_, d5Dead := deadEndpoints[instances[nodeNum-1].Self().Endpoint]
+		return len(deadEndpoints) == 2 && d4Dead && d5Dead
+	})
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
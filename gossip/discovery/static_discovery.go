@@ -0,0 +1,490 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+)
+
+// staticMember is a member of a static membership, along with
+// the means to identify it again if it changes its identity
+type staticMember struct {
+	NetworkMember
+	id        identifier
+	alive     bool
+	aliveTime *proto.PeerTime
+	lastSeen  time.Time
+}
+
+// staticDiscoveryImpl is a discovery module with a fixed membership.
+// Members are only added through Connect, and are never learned from alive messages
+// or membership responses. Alive messages are only exchanged point-to-point between
+// the members, in order to convey their metadata and their signed envelopes.
+type staticDiscoveryImpl struct {
+	incTime uint64
+	seqNum  uint64
+	self    NetworkMember
+
+	members    map[string]*staticMember // PKI-ID to member
+	connecting map[string]struct{}      // endpoints being identified
+
+	comm             CommService
+	crypt            CryptoService
+	disclosurePolicy DisclosurePolicy
+	lock             *sync.RWMutex
+	toDieChan        chan struct{}
+	logger           util.Logger
+
+	aliveTimeInterval            time.Duration
+	aliveExpirationTimeout       time.Duration
+	aliveExpirationCheckInterval time.Duration
+	reconnectInterval            time.Duration
+}
+
+// NewStaticDiscoveryService returns a new discovery service whose membership consists only
+// of the peers it is explicitly connected to
+func NewStaticDiscoveryService(self NetworkMember, comm CommService, crypt CryptoService, disPol DisclosurePolicy,
+	config DiscoveryConfig, logger util.Logger) Discovery {
+	d := &staticDiscoveryImpl{
+		self:                         self,
+		incTime:                      uint64(time.Now().UnixNano()),
+		members:                      make(map[string]*staticMember),
+		connecting:                   make(map[string]struct{}),
+		comm:                         comm,
+		crypt:                        crypt,
+		disclosurePolicy:             disPol,
+		lock:                         &sync.RWMutex{},
+		toDieChan:                    make(chan struct{}),
+		logger:                       logger,
+		aliveTimeInterval:            config.AliveTimeInterval,
+		aliveExpirationTimeout:       config.AliveExpirationTimeout,
+		aliveExpirationCheckInterval: config.AliveExpirationCheckInterval,
+		reconnectInterval:            config.ReconnectInterval,
+	}
+
+	go d.periodicalSendAlive()
+	if d.aliveExpirationTimeout > 0 {
+		go d.periodicalCheckAlive()
+	}
+	go d.periodicalReconnectToDead()
+	go d.handleMessages()
+	go d.handleEvents()
+
+	return d
+}
+
+// Lookup returns a network member, or nil if not found
+func (d *staticDiscoveryImpl) Lookup(PKIID common.PKIidType) *NetworkMember {
+	if equalPKIid(PKIID, d.self.PKIid) {
+		return &d.self
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	member, exists := d.members[string(PKIID)]
+	if !exists {
+		return nil
+	}
+	return copyNetworkMember(&member.NetworkMember)
+}
+
+// Connect identifies the given member and adds it to the membership.
+// Identification is retried until it succeeds or the instance is stopped.
+func (d *staticDiscoveryImpl) Connect(member NetworkMember, id identifier) {
+	for _, endpoint := range []string{member.InternalEndpoint, member.Endpoint} {
+		if d.isMyOwnEndpoint(endpoint) {
+			d.logger.Debug("Skipping connecting to myself")
+			return
+		}
+	}
+
+	endpoint := member.PreferredEndpoint()
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, exists := d.connecting[endpoint]; exists {
+		d.logger.Debug("Already connecting to", endpoint)
+		return
+	}
+	for _, m := range d.members {
+		if m.PreferredEndpoint() == endpoint {
+			d.logger.Debug(endpoint, "is already a member")
+			return
+		}
+	}
+	d.connecting[endpoint] = struct{}{}
+
+	go d.identify(member, id)
+}
+
+func (d *staticDiscoveryImpl) identify(member NetworkMember, id identifier) {
+	endpoint := member.PreferredEndpoint()
+	defer func() {
+		d.lock.Lock()
+		delete(d.connecting, endpoint)
+		d.lock.Unlock()
+	}()
+
+	for !d.toDie() {
+		peerID, err := id()
+		if err == nil {
+			d.addMember(member, peerID.ID, id)
+			return
+		}
+		d.logger.Warningf("Could not connect to %v : %v", member, err)
+		select {
+		case <-time.After(d.reconnectInterval):
+		case <-d.toDieChan:
+		}
+	}
+}
+
+func (d *staticDiscoveryImpl) addMember(member NetworkMember, pkiID common.PKIidType, id identifier) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, exists := d.members[string(pkiID)]; exists {
+		return
+	}
+	d.logger.Info("Adding", member.PreferredEndpoint(), "with PKI-ID", pkiID, "to the static membership")
+	d.members[string(pkiID)] = &staticMember{
+		NetworkMember: NetworkMember{
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			PKIid:            pkiID,
+		},
+		id:       id,
+		alive:    true,
+		lastSeen: time.Now(),
+	}
+}
+
+func (d *staticDiscoveryImpl) isMyOwnEndpoint(endpoint string) bool {
+	return endpoint == d.self.InternalEndpoint || endpoint == d.self.Endpoint
+}
+
+// InitiateSync does nothing, as the membership isn't synchronized with other peers
+func (d *staticDiscoveryImpl) InitiateSync(peerNum int) {
+}
+
+// GetMembership returns the alive members in the view
+func (d *staticDiscoveryImpl) GetMembership() []NetworkMember {
+	return d.membersByLiveness(true)
+}
+
+// GetDeadMembership returns the members in the view that are considered dead
+func (d *staticDiscoveryImpl) GetDeadMembership() []NetworkMember {
+	return d.membersByLiveness(false)
+}
+
+func (d *staticDiscoveryImpl) membersByLiveness(alive bool) []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	res := []NetworkMember{}
+	for _, member := range d.members {
+		if member.alive == alive {
+			res = append(res, member.NetworkMember)
+		}
+	}
+	return res
+}
+
+func (d *staticDiscoveryImpl) handleMessages() {
+	defer d.logger.Debug("Stopped")
+
+	in := d.comm.Accept()
+	for {
+		select {
+		case m := <-in:
+			d.handleMsgFromComm(m)
+		case <-d.toDieChan:
+			return
+		}
+	}
+}
+
+func (d *staticDiscoveryImpl) handleMsgFromComm(msg protoext.ReceivedMessage) {
+	if msg == nil {
+		return
+	}
+	m := msg.GetGossipMessage()
+	if m.GetAliveMsg() == nil {
+		d.logger.Debug("Discarding", m.GossipMessage, "as membership is static")
+		return
+	}
+
+	pkiID := m.GetAliveMsg().Membership.PkiId
+	if !equalPKIid(pkiID, msg.GetConnectionInfo().ID) {
+		d.logger.Debug("Discarding alive message of", pkiID, "not sent by its originator")
+		return
+	}
+
+	d.lock.RLock()
+	member, exists := d.members[string(pkiID)]
+	d.lock.RUnlock()
+	if !exists {
+		d.logger.Debug("Discarding alive message of", pkiID, "which isn't a member")
+		return
+	}
+
+	if !d.crypt.ValidateAliveMsg(m) {
+		d.logger.Warning("Alive message of", pkiID, "isn't valid")
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	ts := m.GetAliveMsg().Timestamp
+	if member.aliveTime != nil && !before(&timestamp{incTime: tsToTime(member.aliveTime.IncNum), seqNum: member.aliveTime.SeqNum}, ts) {
+		d.logger.Debug("Discarding stale alive message of", pkiID)
+		return
+	}
+	member.aliveTime = ts
+	member.lastSeen = time.Now()
+	member.Metadata = m.GetAliveMsg().Membership.Metadata
+	member.Envelope = m.Envelope
+}
+
+func (d *staticDiscoveryImpl) handleEvents() {
+	defer d.logger.Debug("Stopped")
+
+	for {
+		select {
+		case deadPeer := <-d.comm.PresumedDead():
+			d.expireMember(deadPeer)
+		case changedPKIID := <-d.comm.IdentitySwitch():
+			// If a peer changed its PKI-ID, identify it again
+			d.lock.Lock()
+			member, exists := d.members[string(changedPKIID)]
+			delete(d.members, string(changedPKIID))
+			d.lock.Unlock()
+			if exists {
+				d.Connect(member.NetworkMember, member.id)
+			}
+		case <-d.toDieChan:
+			return
+		}
+	}
+}
+
+func (d *staticDiscoveryImpl) expireMember(pkiID common.PKIidType) {
+	d.lock.Lock()
+	member, exists := d.members[string(pkiID)]
+	if !exists || !member.alive {
+		d.lock.Unlock()
+		return
+	}
+	d.logger.Warning("Static member", member.NetworkMember, "is presumed dead")
+	member.alive = false
+	deadMember := member.NetworkMember
+	d.lock.Unlock()
+
+	d.comm.CloseConn(&deadMember)
+}
+
+// periodicalCheckAlive expires the alive members that haven't sent an alive message
+// for longer than the alive expiration timeout
+func (d *staticDiscoveryImpl) periodicalCheckAlive() {
+	defer d.logger.Debug("Stopped")
+
+	checkInterval := d.aliveExpirationCheckInterval
+	if checkInterval <= 0 {
+		checkInterval = d.aliveExpirationTimeout / 10
+	}
+	for !d.toDie() {
+		select {
+		case <-time.After(checkInterval):
+		case <-d.toDieChan:
+			return
+		}
+		for _, pkiID := range d.getDeadMembers() {
+			d.expireMember(pkiID)
+		}
+	}
+}
+
+func (d *staticDiscoveryImpl) getDeadMembers() []common.PKIidType {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	dead := []common.PKIidType{}
+	for id, member := range d.members {
+		if !member.alive {
+			continue
+		}
+		elapsedNonAliveTime := time.Since(member.lastSeen)
+		if elapsedNonAliveTime > d.aliveExpirationTimeout {
+			d.logger.Warning("Haven't heard from", []byte(id), "for", elapsedNonAliveTime)
+			dead = append(dead, common.PKIidType(id))
+		}
+	}
+	return dead
+}
+
+func (d *staticDiscoveryImpl) periodicalReconnectToDead() {
+	defer d.logger.Debug("Stopped")
+
+	for !d.toDie() {
+		wg := &sync.WaitGroup{}
+
+		for _, member := range d.GetDeadMembership() {
+			wg.Add(1)
+			go func(member NetworkMember) {
+				defer wg.Done()
+				if !d.comm.Ping(&member) {
+					d.logger.Debug(member, "is still dead")
+					return
+				}
+				d.logger.Info(member, "is responding again")
+				d.lock.Lock()
+				if m, exists := d.members[string(member.PKIid)]; exists {
+					m.alive = true
+					m.lastSeen = time.Now()
+				}
+				d.lock.Unlock()
+			}(member)
+		}
+
+		wg.Wait()
+		select {
+		case <-time.After(d.reconnectInterval):
+		case <-d.toDieChan:
+		}
+	}
+}
+
+func (d *staticDiscoveryImpl) periodicalSendAlive() {
+	defer d.logger.Debug("Stopped")
+
+	for !d.toDie() {
+		d.sendAlive()
+		select {
+		case <-time.After(d.aliveTimeInterval):
+		case <-d.toDieChan:
+		}
+	}
+}
+
+// sendAlive sends the alive message of this instance directly to each alive member
+// that is eligible of knowing about it
+func (d *staticDiscoveryImpl) sendAlive() {
+	members := d.GetMembership()
+	if len(members) == 0 {
+		return
+	}
+	msg, err := d.createSignedAliveMessage()
+	if err != nil {
+		d.logger.Warningf("Failed creating alive message: %+v", errors.WithStack(err))
+		return
+	}
+	for _, member := range members {
+		member := member
+		sieve, omitConcealedFields := d.disclosurePolicy(&member)
+		if !sieve(msg) {
+			continue
+		}
+		d.comm.SendToPeer(&member, &protoext.SignedGossipMessage{
+			GossipMessage: msg.GossipMessage,
+			Envelope:      omitConcealedFields(msg),
+		})
+	}
+}
+
+func (d *staticDiscoveryImpl) aliveMsgAndInternalEndpoint() (*proto.GossipMessage, string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.seqNum++
+	msg := &proto.GossipMessage{
+		Tag: proto.GossipMessage_EMPTY,
+		Content: &proto.GossipMessage_AliveMsg{
+			AliveMsg: &proto.AliveMessage{
+				Membership: &proto.Member{
+					Endpoint: d.self.Endpoint,
+					Metadata: d.self.Metadata,
+					PkiId:    d.self.PKIid,
+				},
+				Timestamp: &proto.PeerTime{
+					IncNum: d.incTime,
+					SeqNum: d.seqNum,
+				},
+			},
+		},
+	}
+	return msg, d.self.InternalEndpoint
+}
+
+func (d *staticDiscoveryImpl) createSignedAliveMessage() (*protoext.SignedGossipMessage, error) {
+	msg, internalEndpoint := d.aliveMsgAndInternalEndpoint()
+	envp := d.crypt.SignMessage(msg, internalEndpoint)
+	if envp == nil {
+		return nil, errors.New("Failed signing message")
+	}
+	return &protoext.SignedGossipMessage{
+		GossipMessage: msg,
+		Envelope:      envp,
+	}, nil
+}
+
+// UpdateMetadata updates this instance's metadata
+func (d *staticDiscoveryImpl) UpdateMetadata(md []byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.self.Metadata = md
+}
+
+// UpdateEndpoint updates this instance's endpoint
+func (d *staticDiscoveryImpl) UpdateEndpoint(endpoint string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.self.Endpoint = endpoint
+}
+
+// Self returns this instance's membership information
+func (d *staticDiscoveryImpl) Self() NetworkMember {
+	var env *proto.Envelope
+	msg, _ := d.aliveMsgAndInternalEndpoint()
+	sMsg, err := protoext.NoopSign(msg)
+	if err != nil {
+		d.logger.Warning("Failed creating SignedGossipMessage:", err)
+	} else {
+		env = sMsg.Envelope
+	}
+	mem := msg.GetAliveMsg().Membership
+	return NetworkMember{
+		Endpoint: mem.Endpoint,
+		Metadata: mem.Metadata,
+		PKIid:    mem.PkiId,
+		Envelope: env,
+	}
+}
+
+func (d *staticDiscoveryImpl) toDie() bool {
+	select {
+	case <-d.toDieChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stop stops this instance
+func (d *staticDiscoveryImpl) Stop() {
+	select {
+	case <-d.toDieChan:
+	default:
+		close(d.toDieChan)
+		d.logger.Info("Stopped")
+	}
+}
@@ -0,0 +1,296 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type staticCommMock struct {
+	lock           sync.Mutex
+	sent           map[string]int
+	closed         map[string]int
+	pingable       bool
+	incMsgs        chan protoext.ReceivedMessage
+	presumedDead   chan common.PKIidType
+	identitySwitch chan common.PKIidType
+}
+
+func newStaticCommMock() *staticCommMock {
+	return &staticCommMock{
+		sent:           make(map[string]int),
+		closed:         make(map[string]int),
+		incMsgs:        make(chan protoext.ReceivedMessage),
+		presumedDead:   make(chan common.PKIidType),
+		identitySwitch: make(chan common.PKIidType),
+	}
+}
+
+func (c *staticCommMock) Gossip(msg *protoext.SignedGossipMessage) {
+	panic("should not be called")
+}
+
+func (c *staticCommMock) Forward(msg protoext.ReceivedMessage) {
+	panic("should not be called")
+}
+
+func (c *staticCommMock) SendToPeer(peer *NetworkMember, msg *protoext.SignedGossipMessage) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if msg.GetAliveMsg() != nil {
+		c.sent[string(peer.PKIid)]++
+	}
+}
+
+func (c *staticCommMock) sentCount(pkiID string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.sent[pkiID]
+}
+
+func (c *staticCommMock) Ping(peer *NetworkMember) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.pingable
+}
+
+func (c *staticCommMock) setPingable(pingable bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pingable = pingable
+}
+
+func (c *staticCommMock) Accept() <-chan protoext.ReceivedMessage {
+	return c.incMsgs
+}
+
+func (c *staticCommMock) PresumedDead() <-chan common.PKIidType {
+	return c.presumedDead
+}
+
+func (c *staticCommMock) CloseConn(peer *NetworkMember) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed[string(peer.PKIid)]++
+}
+
+func (c *staticCommMock) IdentitySwitch() <-chan common.PKIidType {
+	return c.identitySwitch
+}
+
+func (c *staticCommMock) ValidateAliveMsg(message *protoext.SignedGossipMessage) bool {
+	return string(message.GetAliveMsg().Membership.Metadata) != "invalid"
+}
+
+func (c *staticCommMock) SignMessage(m *proto.GossipMessage, internalEndpoint string) *proto.Envelope {
+	sMsg, _ := protoext.NoopSign(m)
+	return sMsg.Envelope
+}
+
+func staticAliveMsg(pkiID string, metadata string, incNum, seqNum uint64) protoext.ReceivedMessage {
+	sMsg, _ := protoext.NoopSign(&proto.GossipMessage{
+		Tag: proto.GossipMessage_EMPTY,
+		Content: &proto.GossipMessage_AliveMsg{
+			AliveMsg: &proto.AliveMessage{
+				Membership: &proto.Member{
+					Endpoint: pkiID,
+					Metadata: []byte(metadata),
+					PkiId:    common.PKIidType(pkiID),
+				},
+				Timestamp: &proto.PeerTime{IncNum: incNum, SeqNum: seqNum},
+			},
+		},
+	})
+	return &dummyReceivedMessage{
+		msg:  sMsg,
+		info: &protoext.ConnectionInfo{ID: common.PKIidType(pkiID)},
+	}
+}
+
+func TestStaticDiscovery(t *testing.T) {
+	comm := newStaticCommMock()
+	self := NetworkMember{Endpoint: "p0:7051", InternalEndpoint: "p0:7051", PKIid: common.PKIidType("p0")}
+	config := DiscoveryConfig{
+		AliveTimeInterval: 100 * time.Millisecond,
+		ReconnectInterval: 100 * time.Millisecond,
+	}
+	d := NewStaticDiscoveryService(self, comm, comm, noopPolicy, config, util.GetLogger(util.DiscoveryLogger, "p0"))
+	defer d.Stop()
+
+	var p2Attempts uint32
+	identifierOf := func(pkiID string) identifier {
+		return func() (*PeerIdentification, error) {
+			if pkiID == "p2" && atomic.AddUint32(&p2Attempts, 1) < 3 {
+				return nil, errors.New("connection refused")
+			}
+			return &PeerIdentification{ID: common.PKIidType(pkiID), SelfOrg: true}, nil
+		}
+	}
+
+	membership := func() []string {
+		var res []string
+		for _, member := range d.GetMembership() {
+			res = append(res, string(member.PKIid))
+		}
+		return res
+	}
+
+	// Connecting to self is skipped, and p2 is only admitted once it can be identified
+	d.Connect(NetworkMember{Endpoint: "p0:7051", InternalEndpoint: "p0:7051"}, identifierOf("p0"))
+	d.Connect(NetworkMember{Endpoint: "p1:7051", InternalEndpoint: "p1:7051"}, identifierOf("p1"))
+	d.Connect(NetworkMember{Endpoint: "p2:7051", InternalEndpoint: "p2:7051"}, identifierOf("p2"))
+	require.Eventually(t, func() bool { return len(membership()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []string{"p1", "p2"}, membership())
+	require.Equal(t, uint32(3), atomic.LoadUint32(&p2Attempts))
+	require.Empty(t, d.GetDeadMembership())
+	require.Equal(t, "p1:7051", d.Lookup(common.PKIidType("p1")).Endpoint)
+	require.Nil(t, d.Lookup(common.PKIidType("p3")))
+	require.Equal(t, self.PKIid, d.Lookup(self.PKIid).PKIid)
+
+	// Connecting again to a member doesn't add it twice
+	d.Connect(NetworkMember{Endpoint: "p1:7051", InternalEndpoint: "p1:7051"}, identifierOf("p1"))
+	time.Sleep(200 * time.Millisecond)
+	require.Len(t, membership(), 2)
+
+	// Alive messages are sent to the members
+	require.Eventually(t, func() bool { return comm.sentCount("p1") > 0 && comm.sentCount("p2") > 0 }, 5*time.Second, 10*time.Millisecond)
+
+	t.Run("alive messages", func(t *testing.T) {
+		comm.incMsgs <- staticAliveMsg("p1", "md1", 1, 2)
+		require.Eventually(t, func() bool {
+			member := d.Lookup(common.PKIidType("p1"))
+			return string(member.Metadata) == "md1" && member.Envelope != nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Stale alive messages are ignored
+		comm.incMsgs <- staticAliveMsg("p1", "md0", 1, 1)
+		// Alive messages of non members don't add them to the membership
+		comm.incMsgs <- staticAliveMsg("p3", "md3", 1, 1)
+		// Alive messages not sent by their originator are ignored
+		forwarded := staticAliveMsg("p1", "md4", 1, 3)
+		forwarded.GetConnectionInfo().ID = common.PKIidType("p2")
+		comm.incMsgs <- forwarded
+		// Membership messages are ignored
+		memReq, _ := protoext.NoopSign(&proto.GossipMessage{Content: &proto.GossipMessage_MemReq{MemReq: &proto.MembershipRequest{}}})
+		comm.incMsgs <- &dummyReceivedMessage{msg: memReq, info: &protoext.ConnectionInfo{ID: common.PKIidType("p3")}}
+		// Invalid alive messages are ignored
+		comm.incMsgs <- staticAliveMsg("p1", "invalid", 1, 4)
+		// Flush the messages
+		comm.incMsgs <- staticAliveMsg("p2", "md2", 1, 1)
+		require.Eventually(t, func() bool {
+			return string(d.Lookup(common.PKIidType("p2")).Metadata) == "md2"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.Equal(t, "md1", string(d.Lookup(common.PKIidType("p1")).Metadata))
+		require.ElementsMatch(t, []string{"p1", "p2"}, membership())
+	})
+
+	t.Run("presumed dead", func(t *testing.T) {
+		comm.presumedDead <- common.PKIidType("p1")
+		require.Eventually(t, func() bool { return len(d.GetDeadMembership()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, []string{"p2"}, membership())
+		require.Equal(t, common.PKIidType("p1"), d.GetDeadMembership()[0].PKIid)
+		comm.lock.Lock()
+		require.Equal(t, 1, comm.closed["p1"])
+		comm.lock.Unlock()
+
+		// Once it responds to pings, it is alive again
+		comm.setPingable(true)
+		require.Eventually(t, func() bool { return len(membership()) == 2 }, 5*time.Second, 10*time.Millisecond)
+		require.Empty(t, d.GetDeadMembership())
+	})
+
+	t.Run("identity switch", func(t *testing.T) {
+		// The member is identified again, and has no metadata until it sends an alive message
+		comm.identitySwitch <- common.PKIidType("p2")
+		require.Eventually(t, func() bool {
+			member := d.Lookup(common.PKIidType("p2"))
+			return member != nil && len(member.Metadata) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		require.ElementsMatch(t, []string{"p1", "p2"}, membership())
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		d.Stop()
+		require.Empty(t, d.GetMembership())
+		require.Empty(t, d.GetDeadMembership())
+	})
+}
+
+func TestStaticDiscoveryAliveExpiration(t *testing.T) {
+	comm := newStaticCommMock()
+	self := NetworkMember{Endpoint: "p0:7051", InternalEndpoint: "p0:7051", PKIid: common.PKIidType("p0")}
+	config := DiscoveryConfig{
+		AliveTimeInterval:            100 * time.Millisecond,
+		AliveExpirationTimeout:       500 * time.Millisecond,
+		AliveExpirationCheckInterval: 50 * time.Millisecond,
+		ReconnectInterval:            100 * time.Millisecond,
+	}
+	d := NewStaticDiscoveryService(self, comm, comm, noopPolicy, config, util.GetLogger(util.DiscoveryLogger, "p0"))
+	defer d.Stop()
+
+	identifierOf := func(pkiID string) identifier {
+		return func() (*PeerIdentification, error) {
+			return &PeerIdentification{ID: common.PKIidType(pkiID), SelfOrg: true}, nil
+		}
+	}
+	d.Connect(NetworkMember{Endpoint: "p1:7051", InternalEndpoint: "p1:7051"}, identifierOf("p1"))
+	d.Connect(NetworkMember{Endpoint: "p2:7051", InternalEndpoint: "p2:7051"}, identifierOf("p2"))
+	require.Eventually(t, func() bool { return len(d.GetMembership()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// p2 keeps sending alive messages, while p1 is silent
+	stopAlive := make(chan struct{})
+	aliveDone := make(chan struct{})
+	go func() {
+		defer close(aliveDone)
+		for seqNum := uint64(1); ; seqNum++ {
+			select {
+			case comm.incMsgs <- staticAliveMsg("p2", "md2", 1, seqNum):
+			case <-stopAlive:
+				return
+			}
+			select {
+			case <-time.After(100 * time.Millisecond):
+			case <-stopAlive:
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(stopAlive)
+		<-aliveDone
+	}()
+
+	require.Eventually(t, func() bool { return len(d.GetDeadMembership()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, common.PKIidType("p1"), d.GetDeadMembership()[0].PKIid)
+	require.Len(t, d.GetMembership(), 1)
+	require.Equal(t, common.PKIidType("p2"), d.GetMembership()[0].PKIid)
+	comm.lock.Lock()
+	require.Equal(t, 1, comm.closed["p1"])
+	require.Zero(t, comm.closed["p2"])
+	comm.lock.Unlock()
+
+	// Once it responds to pings, it is alive again until it expires anew
+	comm.setPingable(true)
+	require.Eventually(t, func() bool { return len(d.GetMembership()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	comm.setPingable(false)
+	require.Eventually(t, func() bool {
+		comm.lock.Lock()
+		defer comm.lock.Unlock()
+		return comm.closed["p1"] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -43,6 +43,14 @@ func (pi *peerImpl) ID() peerID {
 	return peerID(pi.member.PKIid)
 }
 
+func (pi *peerImpl) Weight() uint32 {
+	md, err := MetadataFromBytes(pi.member.Metadata)
+	if err != nil {
+		return 0
+	}
+	return md.Weight
+}
+
 type gossip interface {
 	// PeersOfChannel returns the NetworkMembers considered alive in a channel
 	PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember
@@ -18,7 +18,7 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing weights, and then IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -50,12 +50,15 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
-//			become a follower
+// 			a better candidate, become a follower
+//			If a leadership proposal was received from
+//			a peer with a higher weight, yield
 //		Else, you're a follower:
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
+//			If a leadership declaration was received from
+//			a peer with a lower weight, gossip a leadership proposal
 //
 // LeaderElection():
 // 	Gossip leadership proposal message
@@ -63,9 +66,12 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// A peer is a better candidate than another if it has a higher weight,
+// or if both have the same weight and it has a lower ID.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -99,6 +105,10 @@ type LeaderElectionService interface {
 	// Yield relinquishes the leadership until a new leader is elected,
 	// or a timeout expires
 	Yield()
+
+	// Leader returns the ID of the peer that is currently known as the leader,
+	// or nil if no leader is known
+	Leader() []byte
 }
 
 type peerID []byte
@@ -114,6 +124,8 @@ func (p peerID) String() string {
 type Peer interface {
 	// ID returns the ID of the peer
 	ID() peerID
+	// Weight returns the election weight of the peer
+	Weight() uint32
 }
 
 // Msg describes a message sent from a remote peer
@@ -141,6 +153,9 @@ type ElectionConfig struct {
 	MembershipSampleInterval time.Duration
 	LeaderAliveThreshold     time.Duration
 	LeaderElectionDuration   time.Duration
+	// Weight is the election weight of the peer.
+	// Peers with a higher weight are preferred as leaders.
+	Weight uint32
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
@@ -184,6 +199,10 @@ type leaderElectionSvcImpl struct {
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
 	config        ElectionConfig
+	// leaderID is the ID of the peer that sent the last leadership declaration,
+	// and leaderDeclarationTime is the time it was received
+	leaderID              peerID
+	leaderDeclarationTime time.Time
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -223,14 +242,26 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 
 	if msg.IsProposal() {
 		le.proposals.Add(string(msg.SenderID()))
+		// A peer with a higher weight takes over the leadership
+		if le.IsLeader() && le.weightOf(msg.SenderID()) > le.config.Weight {
+			le.logger.Info(le.id, ": Yielding to", msg.SenderID(), "which has a higher weight")
+			le.yieldLocked()
+		}
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
+		le.leaderID = msg.SenderID()
+		le.leaderDeclarationTime = time.Now()
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.isBetterCandidate(msg.SenderID()) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
+		// Challenge a leader with a lower weight, unless we are yielding
+		if !le.IsLeader() && !le.isYielding() && le.weightOf(msg.SenderID()) < le.config.Weight {
+			le.logger.Debug(le.id, ": Challenging", msg.SenderID(), "which has a lower weight")
+			le.propose()
+		}
 	} else {
 		// We shouldn't get here
 		le.logger.Error("Got a message that's not a proposal and not a declaration")
@@ -310,7 +341,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
 		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		if le.isBetterCandidate(peerID(id)) {
 			return
 		}
 	}
@@ -383,6 +414,27 @@ func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	return false
 }
 
+// weightOf returns the election weight of the peer of given id,
+// or 0 if the peer isn't considered alive
+func (le *leaderElectionSvcImpl) weightOf(id peerID) uint32 {
+	for _, p := range le.adapter.Peers() {
+		if bytes.Equal(p.ID(), id) {
+			return p.Weight()
+		}
+	}
+	return 0
+}
+
+// isBetterCandidate returns whether the peer of given id is a better candidate
+// than this peer for being a leader
+func (le *leaderElectionSvcImpl) isBetterCandidate(id peerID) bool {
+	weight := le.weightOf(id)
+	if weight != le.config.Weight {
+		return weight > le.config.Weight
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
 func (le *leaderElectionSvcImpl) isLeaderExists() bool {
 	return atomic.LoadInt32(&le.leaderExists) == int32(1)
 }
@@ -432,6 +484,11 @@ func (le *leaderElectionSvcImpl) stopYielding() {
 func (le *leaderElectionSvcImpl) Yield() {
 	le.Lock()
 	defer le.Unlock()
+	le.yieldLocked()
+}
+
+// yieldLocked relinquishes the leadership, and must be called while holding the lock
+func (le *leaderElectionSvcImpl) yieldLocked() {
 	if !le.IsLeader() || le.isYielding() {
 		return
 	}
@@ -447,6 +504,20 @@ func (le *leaderElectionSvcImpl) Yield() {
 	})
 }
 
+// Leader returns the ID of the peer that is currently known as the leader,
+// or nil if no leader declared itself within the leader alive threshold
+func (le *leaderElectionSvcImpl) Leader() []byte {
+	if atomic.LoadInt32(&le.isLeader) == int32(1) {
+		return le.id
+	}
+	le.Lock()
+	defer le.Unlock()
+	if le.leaderID == nil || time.Since(le.leaderDeclarationTime) > le.config.LeaderAliveThreshold {
+		return nil
+	}
+	return le.leaderID
+}
+
 // Stop stops the LeaderElectionService
 func (le *leaderElectionSvcImpl) Stop() {
 	select {
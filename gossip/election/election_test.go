@@ -53,6 +53,7 @@ type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
 	id                 string
+	weight             uint32
 	peers              map[string]*peer
 	sharedLock         *sync.RWMutex
 	msgChan            chan Msg
@@ -73,6 +74,10 @@ func (p *peer) ID() peerID {
 	return peerID(p.id)
 }
 
+func (p *peer) Weight() uint32 {
+	return p.weight
+}
+
 func (p *peer) Gossip(m Msg) {
 	p.sharedLock.RLock()
 	defer p.sharedLock.RUnlock()
@@ -115,8 +120,8 @@ func (p *peer) Peers() []Peer {
 	}
 
 	var peers []Peer
-	for id := range p.peers {
-		peers = append(peers, &peer{id: id})
+	for id, remotePeer := range p.peers {
+		peers = append(peers, &peer{id: id, weight: remotePeer.weight})
 	}
 	return peers
 }
@@ -159,15 +164,20 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 }
 
 func createPeerWithCostumeMetrics(id int, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
+	return createWeightedPeerWithCostumeMetrics(id, 0, peerMap, l, f)
+}
+
+func createWeightedPeerWithCostumeMetrics(id int, weight uint32, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
+	p := &peer{id: idStr, weight: weight, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
 	p.On("ReportMetrics", mock.Anything).Run(f)
 	config := ElectionConfig{
 		StartupGracePeriod:       testStartupGracePeriod,
 		MembershipSampleInterval: testMembershipSampleInterval,
 		LeaderAliveThreshold:     testLeaderAliveThreshold,
 		LeaderElectionDuration:   testLeaderElectionDuration,
+		Weight:                   weight,
 	}
 	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback, config)
 	l.Lock()
@@ -180,6 +190,10 @@ func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
 	return createPeerWithCostumeMetrics(id, peerMap, l, func(mock.Arguments) {})
 }
 
+func createWeightedPeer(id int, weight uint32, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+	return createWeightedPeerWithCostumeMetrics(id, weight, peerMap, l, func(mock.Arguments) {})
+}
+
 func waitForMultipleLeadersElection(t *testing.T, peers []*peer, leadersNum int) []string {
 	end := time.Now().Add(testTimeout)
 	for time.Now().Before(end) {
@@ -250,6 +264,10 @@ func TestInitPeersAtSameTime(t *testing.T) {
 	require.True(t, isP0leader, "p0 isn't a leader. Leaders are: %v", leaders)
 	require.Len(t, leaders, 1, "More than 1 leader elected")
 	waitForBoolFunc(t, peers[len(peers)-1].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[len(peers)-1].id)
+	for _, p := range peers {
+		p := p
+		waitForBoolFunc(t, func() bool { return string(p.Leader()) == "p0" }, true, "Wrong leader reported by ", p.id)
+	}
 }
 
 func TestInitPeersStartAtIntervals(t *testing.T) {
@@ -351,6 +369,10 @@ func TestLeadershipTakeover(t *testing.T) {
 	leaders = waitForLeaderElection(t, peers[1:])
 	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
 	require.Equal(t, "p2", leaders[0])
+	for _, p := range peers[1:] {
+		p := p
+		waitForBoolFunc(t, func() bool { return string(p.Leader()) == "p2" }, true, "Wrong leader reported by ", p.id)
+	}
 }
 
 func TestYield(t *testing.T) {
@@ -414,6 +436,64 @@ func TestYieldAllPeers(t *testing.T) {
 	require.Equal(t, "p0", leaders[0])
 }
 
+func TestWeightedElection(t *testing.T) {
+	// Scenario: Peers spawn at the same time, and the peer with the highest ID
+	// has the highest weight.
+	// Expected outcome: the peer with the highest weight is elected,
+	// and among peers of the same weight, the one with the lowest ID takes over
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	var peers []*peer
+	for id, weight := range []uint32{0, 0, 5, 5} {
+		peers = append(peers, createWeightedPeer(id, weight, peerMap, l))
+	}
+	leaders := waitForLeaderElection(t, peers)
+	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	require.Equal(t, "p2", leaders[0])
+
+	peers[2].Stop()
+	time.Sleep(testLeadershipDeclarationInterval + testLeaderAliveThreshold*3)
+	remaining := []*peer{peers[0], peers[1], peers[3]}
+	leaders = waitForLeaderElection(t, remaining)
+	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	require.Equal(t, "p3", leaders[0])
+}
+
+func TestWeightedTakeover(t *testing.T) {
+	// Scenario: Peers spawn and a leader is elected.
+	// Then, a peer with a higher weight spawns.
+	// Expected outcome: the leader yields and the peer with the higher weight takes over.
+	// Once the peer with the higher weight yields, another peer takes over.
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	peers := []*peer{createPeer(0, peerMap, l), createPeer(1, peerMap, l)}
+	leaders := waitForLeaderElection(t, peers)
+	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	require.Equal(t, "p0", leaders[0])
+
+	peers = append(peers, createWeightedPeer(2, 10, peerMap, l))
+	waitForBoolFunc(t, func() bool {
+		return peers[2].IsLeader() && !peers[0].IsLeader() && !peers[1].IsLeader()
+	}, true, "The peer with the highest weight should have taken over")
+	for _, p := range peers {
+		p := p
+		waitForBoolFunc(t, func() bool { return string(p.Leader()) == "p2" }, true, "Wrong leader reported by ", p.id)
+	}
+
+	// The peer with the highest weight yields, and doesn't challenge the new leader while yielding
+	peers[2].Yield()
+	waitForBoolFunc(t, func() bool {
+		return peers[0].IsLeader() && !peers[1].IsLeader() && !peers[2].IsLeader()
+	}, true, "The peer with the lowest ID should have taken over")
+	time.Sleep(testLeaderAliveThreshold * 2)
+	require.False(t, peers[2].IsLeader())
+
+	// Once it stops yielding, it takes back the leadership
+	waitForBoolFunc(t, func() bool {
+		return peers[2].IsLeader() && !peers[0].IsLeader() && !peers[1].IsLeader()
+	}, true, "The peer with the highest weight should have taken back the leadership")
+}
+
 func TestPartition(t *testing.T) {
 	// Scenario: peers spawn together, and then after a while a network partition occurs
 	// and no peer can communicate with another peer
@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package election
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Metadata is the part of the membership metadata of a peer that is used by leader election
+type Metadata struct {
+	// Weight is the election weight of the peer
+	Weight uint32 `json:"election_weight,omitempty"`
+}
+
+// Bytes returns the membership metadata that conveys the given metadata
+func (md Metadata) Bytes() []byte {
+	b, _ := json.Marshal(md)
+	return b
+}
+
+// MetadataFromBytes parses the leader election metadata out of the membership metadata of a peer.
+// Peers that don't publish any membership metadata have a weight of 0.
+func MetadataFromBytes(b []byte) (Metadata, error) {
+	md := Metadata{}
+	if len(b) == 0 {
+		return md, nil
+	}
+	if err := json.Unmarshal(b, &md); err != nil {
+		return md, errors.Wrap(err, "failed unmarshaling leader election metadata")
+	}
+	return md, nil
+}
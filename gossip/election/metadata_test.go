@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package election
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	md, err := MetadataFromBytes(Metadata{Weight: 10}.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, uint32(10), md.Weight)
+
+	md, err = MetadataFromBytes(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), md.Weight)
+
+	md, err = MetadataFromBytes([]byte(`{"something_else":1}`))
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), md.Weight)
+
+	_, err = MetadataFromBytes([]byte{1, 2, 3})
+	require.EqualError(t, err, "failed unmarshaling leader election metadata: invalid character '\\x01' looking for beginning of value")
+}
+
+func TestPeerWeight(t *testing.T) {
+	p := &peerImpl{member: discovery.NetworkMember{Metadata: Metadata{Weight: 3}.Bytes()}}
+	require.Equal(t, uint32(3), p.Weight())
+
+	p = &peerImpl{member: discovery.NetworkMember{Metadata: []byte{1, 2, 3}}}
+	require.Equal(t, uint32(0), p.Weight())
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
@@ -81,6 +82,11 @@ type Config struct {
 	RecvBuffSize int
 	// SendBuffSize is the buffer size of sending message.
 	SendBuffSize int
+	// CompressionEnabled enables the compression of large data, state response and private data messages
+	// sent to peers that support compression too.
+	CompressionEnabled bool
+	// CompressionThreshold is the minimum payload size of a message for it to be compressed.
+	CompressionThreshold int
 
 	// MsgExpirationTimeout indicate leadership message expiration timeout.
 	MsgExpirationTimeout time.Duration
@@ -97,6 +103,22 @@ type Config struct {
 	MsgExpirationFactor int
 	// MaxConnectionAttempts is the max number of attempts to connect to a peer (wait for alive ack)
 	MaxConnectionAttempts int
+
+	// StaticMembership makes the membership fixed: it only consists of the StaticPeers and,
+	// if StaticMembershipAnchorPeers is set, of the anchor peers of the channels.
+	StaticMembership bool
+	// StaticMembershipAnchorPeers controls whether the anchor peers of the channels are part of the static membership.
+	StaticMembershipAnchorPeers bool
+	// StaticPeers are the peers of the static membership.
+	StaticPeers []StaticPeer
+}
+
+// StaticPeer is a peer of the static membership
+type StaticPeer struct {
+	// Endpoint is the endpoint of the peer.
+	Endpoint string
+	// Identity is the expected identity of the peer. If empty, any valid identity is accepted.
+	Identity api.PeerIdentityType
 }
 
 // GlobalConfig builds a Config from the given endpoint, certificate and bootstrap peers.
@@ -141,6 +163,8 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ConnTimeout = util.GetDurationOrDefault("peer.gossip.connTimeout", comm.DefConnTimeout)
 	c.RecvBuffSize = util.GetIntOrDefault("peer.gossip.recvBuffSize", comm.DefRecvBuffSize)
 	c.SendBuffSize = util.GetIntOrDefault("peer.gossip.sendBuffSize", comm.DefSendBuffSize)
+	c.CompressionEnabled = viper.GetBool("peer.gossip.compression.enabled")
+	c.CompressionThreshold = util.GetIntOrDefault("peer.gossip.compression.threshold", comm.DefCompressionThreshold)
 	c.MsgExpirationTimeout = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold) * 10
 	c.AliveTimeInterval = util.GetDurationOrDefault("peer.gossip.aliveTimeInterval", discovery.DefAliveTimeInterval)
 	c.AliveExpirationTimeout = util.GetDurationOrDefault("peer.gossip.aliveExpirationTimeout", 5*c.AliveTimeInterval)
@@ -148,6 +172,8 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ReconnectInterval = util.GetDurationOrDefault("peer.gossip.reconnectInterval", c.AliveExpirationTimeout)
 	c.MaxConnectionAttempts = util.GetIntOrDefault("peer.gossip.maxConnectionAttempts", discovery.DefMaxConnectionAttempts)
 	c.MsgExpirationFactor = util.GetIntOrDefault("peer.gossip.msgExpirationFactor", discovery.DefMsgExpirationFactor)
+	c.StaticMembership = viper.GetBool("peer.gossip.staticMembership.enabled")
+	c.StaticMembershipAnchorPeers = viper.GetBool("peer.gossip.staticMembership.anchorPeers")
 
 	return nil
 }
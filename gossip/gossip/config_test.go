@@ -50,12 +50,16 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.connTimeout", "16s")
 	viper.Set("peer.gossip.recvBuffSize", 17)
 	viper.Set("peer.gossip.sendBuffSize", 18)
+	viper.Set("peer.gossip.compression.enabled", true)
+	viper.Set("peer.gossip.compression.threshold", 1024)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "19s")
 	viper.Set("peer.gossip.aliveTimeInterval", "20s")
 	viper.Set("peer.gossip.aliveExpirationTimeout", "21s")
 	viper.Set("peer.gossip.reconnectInterval", "22s")
 	viper.Set("peer.gossip.maxConnectionAttempts", "100")
 	viper.Set("peer.gossip.msgExpirationFactor", "10")
+	viper.Set("peer.gossip.staticMembership.enabled", true)
+	viper.Set("peer.gossip.staticMembership.anchorPeers", true)
 
 	coreConfig, err := gossip.GlobalConfig(endpoint, nil, bootstrap...)
 	require.NoError(t, err)
@@ -91,6 +95,8 @@ func TestGlobalConfig(t *testing.T) {
 		ConnTimeout:                  16 * time.Second,
 		RecvBuffSize:                 17,
 		SendBuffSize:                 18,
+		CompressionEnabled:           true,
+		CompressionThreshold:         1024,
 		MsgExpirationTimeout:         19 * time.Second * 10, // LeaderAliveThreshold * 10
 		AliveTimeInterval:            20 * time.Second,
 		AliveExpirationTimeout:       21 * time.Second,
@@ -98,6 +104,8 @@ func TestGlobalConfig(t *testing.T) {
 		ReconnectInterval:            22 * time.Second,
 		MaxConnectionAttempts:        100,
 		MsgExpirationFactor:          10,
+		StaticMembership:             true,
+		StaticMembershipAnchorPeers:  true,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -145,6 +153,7 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		ConnTimeout:                  comm.DefConnTimeout,
 		RecvBuffSize:                 comm.DefRecvBuffSize,
 		SendBuffSize:                 comm.DefSendBuffSize,
+		CompressionThreshold:         comm.DefCompressionThreshold,
 		MsgExpirationTimeout:         election.DefLeaderAliveThreshold * 10,
 		AliveTimeInterval:            discovery.DefAliveTimeInterval,
 		AliveExpirationTimeout:       5 * discovery.DefAliveTimeInterval,
@@ -101,10 +101,12 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}, sa)
 
 	commConfig := comm.CommConfig{
-		DialTimeout:  conf.DialTimeout,
-		ConnTimeout:  conf.ConnTimeout,
-		RecvBuffSize: conf.RecvBuffSize,
-		SendBuffSize: conf.SendBuffSize,
+		DialTimeout:          conf.DialTimeout,
+		ConnTimeout:          conf.ConnTimeout,
+		RecvBuffSize:         conf.RecvBuffSize,
+		SendBuffSize:         conf.SendBuffSize,
+		CompressionEnabled:   conf.CompressionEnabled,
+		CompressionThreshold: conf.CompressionThreshold,
 	}
 	g.comm, err = comm.NewCommInstance(s, conf.TLSCerts, g.idMapper, selfIdentity, secureDialOpts, sa,
 		gossipMetrics.CommMetrics, commConfig)
@@ -133,8 +135,13 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}
 	self := g.selfNetworkMember()
 	logger := util.GetLogger(util.DiscoveryLogger, self.InternalEndpoint)
-	g.disc = discovery.NewDiscoveryService(self, g.discAdapter, g.disSecAdap, g.disclosurePolicy,
-		discoveryConfig, anchorPeerTracker, logger)
+	if conf.StaticMembership {
+		g.disc = discovery.NewStaticDiscoveryService(self, g.discAdapter, g.disSecAdap, g.disclosurePolicy,
+			discoveryConfig, logger)
+	} else {
+		g.disc = discovery.NewDiscoveryService(self, g.discAdapter, g.disSecAdap, g.disclosurePolicy,
+			discoveryConfig, anchorPeerTracker, logger)
+	}
 	g.logger.Infof("Creating gossip service with self membership of %s", g.selfNetworkMember())
 
 	g.certPuller = g.createCertStorePuller()
@@ -147,7 +154,14 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	// acceptMessages goRoutines to block on Wait
 	g.stopSignal.Add(2)
 	go g.start()
-	go g.connect2BootstrapPeers()
+	if conf.StaticMembership {
+		if len(conf.BootstrapPeers) != 0 {
+			g.logger.Warning("Static membership is enabled, ignoring bootstrap peers", conf.BootstrapPeers)
+		}
+		go g.connect2StaticPeers()
+	} else {
+		go g.connect2BootstrapPeers()
+	}
 
 	return g
 }
@@ -193,6 +207,10 @@ func (g *Node) JoinChan(joinMsg api.JoinChannelMessage, channelID common.Channel
 	g.chanState.joinChannel(joinMsg, channelID, g.gossipMetrics.MembershipMetrics)
 
 	g.logger.Info("Joining gossip network of channel", channelID, "with", len(joinMsg.Members()), "organizations")
+	if g.conf.StaticMembership && !g.conf.StaticMembershipAnchorPeers {
+		g.logger.Debug("Static membership is enabled, not learning about the anchor peers of channel", channelID)
+		return
+	}
 	for _, org := range joinMsg.Members() {
 		g.learnAnchorPeers(string(channelID), org, joinMsg.AnchorPeersOf(org))
 	}
@@ -712,6 +730,16 @@ func (g *Node) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// DeadPeers returns the NetworkMembers considered dead
+func (g *Node) DeadPeers() []discovery.NetworkMember {
+	return g.disc.GetDeadMembership()
+}
+
+// Connections returns the state of the connections to remote peers
+func (g *Node) Connections() []comm.ConnectionState {
+	return g.comm.Connections()
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *Node) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember {
@@ -1180,6 +1208,37 @@ func (g *Node) connect2BootstrapPeers() {
 	}
 }
 
+// connect2StaticPeers adds the configured static peers to the membership.
+// A peer with a configured identity is only admitted if it presents that identity.
+func (g *Node) connect2StaticPeers() {
+	for _, peer := range g.conf.StaticPeers {
+		endpoint := peer.Endpoint
+		var expectedPKIID common.PKIidType
+		if len(peer.Identity) != 0 {
+			expectedPKIID = g.mcs.GetPKIidOfCert(peer.Identity)
+		}
+		identifier := func() (*discovery.PeerIdentification, error) {
+			remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			pkiID := g.mcs.GetPKIidOfCert(remotePeerIdentity)
+			if len(pkiID) == 0 {
+				return nil, errors.Errorf("Wasn't able to extract PKI-ID of remote peer with identity of %v", remotePeerIdentity)
+			}
+			if len(expectedPKIID) != 0 && !bytes.Equal(expectedPKIID, pkiID) {
+				return nil, errors.Errorf("%s presented an identity that doesn't match its configured identity", endpoint)
+			}
+			sameOrg := bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(remotePeerIdentity))
+			return &discovery.PeerIdentification{ID: pkiID, SelfOrg: sameOrg}, nil
+		}
+		g.disc.Connect(discovery.NetworkMember{
+			InternalEndpoint: endpoint,
+			Endpoint:         endpoint,
+		}, identifier)
+	}
+}
+
 func (g *Node) hasExternalEndpoint(PKIID common.PKIidType) bool {
 	if nm := g.disc.Lookup(PKIID); nm != nil {
 		return nm.Endpoint != ""
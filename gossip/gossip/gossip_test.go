@@ -900,6 +900,18 @@ func TestMembershipConvergence(t *testing.T) {
 
 	waitUntilOrFail(t, ensureForget, "waiting to ensure we evicted stopped connector")
 
+	ensureDead := func() bool {
+		for i := 0; i < 15; i++ {
+			dead := peers[i].DeadPeers()
+			if len(dead) != 1 || dead[0].InternalEndpoint != endpoint15 {
+				return false
+			}
+		}
+		return true
+	}
+
+	waitUntilOrFail(t, ensureDead, "waiting for all instances to consider the stopped connector as dead")
+
 	port15, grpc15, certs15, secDialOpts15, _ = util.CreateGRPCLayer()
 	connectorPeer = newGossipInstanceWithGRPC(15, port15, grpc15, certs15, secDialOpts15, 100, ports...)
 	endpoint15 = fmt.Sprintf("127.0.0.1:%d", port15)
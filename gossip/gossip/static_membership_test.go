@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/util"
+	corecomm "github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/stretchr/testify/require"
+)
+
+func newStaticGossipInstance(id int, port int, gRPCServer *corecomm.GRPCServer, certs *common.TLSCertificates,
+	secureDialOpts api.PeerSecureDialOpts, anchorPeers bool, staticPeers ...StaticPeer) *gossipGRPC {
+	conf := &Config{
+		ID:                           fmt.Sprintf("p%d", id),
+		MaxBlockCountToStore:         100,
+		MaxPropagationBurstLatency:   time.Duration(500) * time.Millisecond,
+		MaxPropagationBurstSize:      20,
+		PropagateIterations:          1,
+		PropagatePeerNum:             3,
+		PullInterval:                 time.Duration(4) * time.Second,
+		PullPeerNum:                  5,
+		InternalEndpoint:             fmt.Sprintf("127.0.0.1:%d", port),
+		ExternalEndpoint:             fmt.Sprintf("127.0.0.1:%d", port),
+		PublishCertPeriod:            time.Duration(4) * time.Second,
+		PublishStateInfoInterval:     time.Duration(1) * time.Second,
+		RequestStateInfoInterval:     time.Duration(1) * time.Second,
+		TimeForMembershipTracker:     5 * time.Second,
+		TLSCerts:                     certs,
+		DigestWaitTime:               algo.DefDigestWaitTime,
+		RequestWaitTime:              algo.DefRequestWaitTime,
+		ResponseWaitTime:             algo.DefResponseWaitTime,
+		DialTimeout:                  comm.DefDialTimeout,
+		ConnTimeout:                  comm.DefConnTimeout,
+		RecvBuffSize:                 comm.DefRecvBuffSize,
+		SendBuffSize:                 comm.DefSendBuffSize,
+		MsgExpirationTimeout:         channel.DefMsgExpirationTimeout,
+		AliveTimeInterval:            discoveryConfig.AliveTimeInterval,
+		AliveExpirationTimeout:       discoveryConfig.AliveExpirationTimeout,
+		AliveExpirationCheckInterval: discoveryConfig.AliveExpirationCheckInterval,
+		ReconnectInterval:            discoveryConfig.ReconnectInterval,
+		MaxConnectionAttempts:        discoveryConfig.MaxConnectionAttempts,
+		MsgExpirationFactor:          discoveryConfig.MsgExpirationFactor,
+		StaticMembership:             true,
+		StaticMembershipAnchorPeers:  anchorPeers,
+		StaticPeers:                  staticPeers,
+	}
+	selfID := api.PeerIdentityType(conf.InternalEndpoint)
+	g := New(conf, gRPCServer.Server(), &orgCryptoService{}, &naiveCryptoService{}, selfID,
+		secureDialOpts, metrics.NewGossipMetrics(&disabled.Provider{}), nil)
+	go func() {
+		gRPCServer.Start()
+	}()
+	return &gossipGRPC{Node: g, grpc: gRPCServer}
+}
+
+func TestStaticMembership(t *testing.T) {
+	// Scenario: p0 and p1 have a static membership made of each other,
+	// and p1 also includes the anchor peers of the channel in its static membership.
+	// p2 has a static membership made of p0, but pinned to the wrong identity.
+	// p3 uses dynamic membership and bootstraps from p0, and is the anchor peer of the channel.
+	// Ensure that p0 only sees p1, that p1 sees p0 and p3, that p2 sees no one,
+	// and that blocks are disseminated between p0 and p1.
+	port0, grpc0, certs0, secDialOpts0, _ := util.CreateGRPCLayer()
+	port1, grpc1, certs1, secDialOpts1, _ := util.CreateGRPCLayer()
+	port2, grpc2, certs2, secDialOpts2, _ := util.CreateGRPCLayer()
+	port3, grpc3, certs3, secDialOpts3, _ := util.CreateGRPCLayer()
+	endpoint0 := fmt.Sprintf("127.0.0.1:%d", port0)
+	endpoint1 := fmt.Sprintf("127.0.0.1:%d", port1)
+	endpoint3 := fmt.Sprintf("127.0.0.1:%d", port3)
+
+	jcm := &joinChanMsg{members2AnchorPeers: map[string][]api.AnchorPeer{
+		string(orgInChannelA): {{Host: "127.0.0.1", Port: port3}},
+	}}
+	channelA := common.ChannelID("A")
+
+	p0 := newStaticGossipInstance(0, port0, grpc0, certs0, secDialOpts0, false, StaticPeer{Endpoint: endpoint1})
+	defer p0.Stop()
+	p1 := newStaticGossipInstance(1, port1, grpc1, certs1, secDialOpts1, true,
+		StaticPeer{Endpoint: endpoint0, Identity: api.PeerIdentityType(endpoint0)})
+	defer p1.Stop()
+	p2 := newStaticGossipInstance(2, port2, grpc2, certs2, secDialOpts2, false,
+		StaticPeer{Endpoint: endpoint0, Identity: api.PeerIdentityType(endpoint1)})
+	defer p2.Stop()
+	p3 := newGossipInstanceWithGRPC(3, port3, grpc3, certs3, secDialOpts3, 100, port0)
+	defer p3.Stop()
+
+	for _, p := range []*gossipGRPC{p0, p1, p2, p3} {
+		p.JoinChan(jcm, channelA)
+		p.UpdateLedgerHeight(1, channelA)
+	}
+
+	waitUntilOrFail(t, func() bool {
+		return len(p0.Peers()) == 1 && len(p1.Peers()) == 2
+	}, "waiting for the static members to see each other")
+	require.Equal(t, common.PKIidType(endpoint1), p0.Peers()[0].PKIid)
+	p1Peers := p1.Peers()
+	require.ElementsMatch(t, []common.PKIidType{common.PKIidType(endpoint0), common.PKIidType(endpoint3)},
+		[]common.PKIidType{p1Peers[0].PKIid, p1Peers[1].PKIid})
+
+	waitUntilOrFail(t, func() bool {
+		return len(p0.PeersOfChannel(channelA)) == 1 && len(p1.PeersOfChannel(channelA)) == 2
+	}, "waiting for the static members to form the channel membership")
+
+	// The alive messages of the static members convey their signed envelopes
+	waitUntilOrFail(t, func() bool {
+		peers := p0.Peers()
+		return len(peers) == 1 && peers[0].Envelope != nil
+	}, "waiting for the alive message of p1")
+
+	acceptChan, _ := p1.Accept(acceptData, false)
+	p0.Gossip(createDataMsg(1, []byte{}, channelA))
+	select {
+	case msg := <-acceptChan:
+		require.Equal(t, uint64(1), msg.GetDataMsg().Payload.SeqNum)
+	case <-time.After(timeout):
+		require.Fail(t, "p1 didn't receive the block")
+	}
+
+	// Neither the anchor peer nor the dynamic peer that bootstraps from p0 are part of the membership of p0,
+	// and p2 doesn't admit p0 as it presents an identity that isn't the configured one
+	time.Sleep(discoveryConfig.AliveTimeInterval * 3)
+	require.Len(t, p0.Peers(), 1)
+	require.Len(t, p1.Peers(), 2)
+	require.Empty(t, p2.Peers())
+	require.Empty(t, p2.PeersOfChannel(channelA))
+
+	// Once p1 stops, it is considered dead by p0
+	p1.Stop()
+	waitUntilOrFail(t, func() bool {
+		return len(p0.Peers()) == 0 && len(p0.DeadPeers()) == 1
+	}, "waiting for p1 to be considered dead")
+	require.Empty(t, p0.PeersOfChannel(channelA))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+//go:generate counterfeiter -o mocks/introspector.go -fake-name Introspector . Introspector
+
+// Introspector provides a snapshot of the gossip membership and topology
+type Introspector interface {
+	Introspect() *Report
+}
+
+// ErrorResponse is the body of an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler returns a handler that serves the gossip introspection report of the peer
+func NewHandler(introspector Introspector) *Handler {
+	return &Handler{
+		Introspector: introspector,
+		Logger:       flogging.MustGetLogger("gossip.introspection"),
+	}
+}
+
+// Handler serves the gossip introspection report as JSON. If the channel query parameter is set,
+// the report only includes the membership of the given channel.
+type Handler struct {
+	Introspector Introspector
+	Logger       *flogging.FabricLogger
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.Header().Set("Allow", http.MethodGet)
+		h.sendResponse(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	report := h.Introspector.Introspect()
+	if channelID := req.URL.Query().Get("channel"); channelID != "" {
+		channel := report.Channel(channelID)
+		if channel == nil {
+			h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s not found", channelID))
+			return
+		}
+		report.Channels = []Channel{*channel}
+	}
+	h.sendResponse(resp, http.StatusOK, report)
+}
+
+func (h *Handler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	sendResponse(h.Logger, resp, code, payload)
+}
+
+func sendResponse(logger *flogging.FabricLogger, resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		logger.Errorw("failed to encode payload", "error", err)
+	}
+}
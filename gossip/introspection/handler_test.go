@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package introspection_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+	"github.com/hyperledger/fabric/gossip/introspection/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	newReport := func() *introspection.Report {
+		return &introspection.Report{
+			Self:           introspection.Member{Endpoint: "peer0:7051", PKIID: "0a", MSPID: "Org1MSP"},
+			BootstrapPeers: []string{"peer1:7051"},
+			AliveMembers:   []introspection.Member{{Endpoint: "peer1:7051", PKIID: "0b", MSPID: "Org1MSP"}},
+			DeadMembers:    []introspection.Member{{Endpoint: "peer2:7051", PKIID: "0c", MSPID: "Org1MSP"}},
+			Connections:    []introspection.Connection{{Endpoint: "peer1:7051", PKIID: "0b", Outbound: true}},
+			Channels: []introspection.Channel{
+				{Name: "ch1", Leader: "0a", IsLeader: true},
+				{Name: "ch2", Leader: "0b", AnchorPeers: []string{"peer1:7051"}},
+			},
+		}
+	}
+	introspector := &mocks.Introspector{}
+	introspector.IntrospectStub = newReport
+	handler := introspection.NewHandler(introspector)
+
+	t.Run("full report", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+		report := &introspection.Report{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), report))
+		require.Equal(t, newReport(), report)
+	})
+
+	t.Run("single channel", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip?channel=ch2", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		report := &introspection.Report{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), report))
+		expected := newReport()
+		expected.Channels = expected.Channels[1:]
+		require.Equal(t, expected, report)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip?channel=ch3", nil))
+		require.Equal(t, http.StatusNotFound, resp.Code)
+		require.JSONEq(t, `{"error":"channel ch3 not found"}`, resp.Body.String())
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, "/gossip", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+		require.Equal(t, http.MethodGet, resp.Header().Get("Allow"))
+		require.JSONEq(t, `{"error":"invalid request method: PUT"}`, resp.Body.String())
+	})
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package introspection
+
+// Report is a snapshot of the gossip membership and topology, as seen by the peer.
+// PKI-IDs are hex encoded.
+type Report struct {
+	// Self is the membership information of the peer
+	Self Member `json:"self"`
+	// BootstrapPeers are the endpoints the peer connects to at startup
+	BootstrapPeers []string `json:"bootstrapPeers"`
+	// AliveMembers are the remote peers considered alive
+	AliveMembers []Member `json:"aliveMembers"`
+	// DeadMembers are the remote peers considered dead
+	DeadMembers []Member `json:"deadMembers"`
+	// Connections are the connections to remote peers
+	Connections []Connection `json:"connections"`
+	// Channels is the membership of the channels the peer has joined
+	Channels []Channel `json:"channels"`
+}
+
+// Member describes a peer of the gossip network
+type Member struct {
+	Endpoint         string `json:"endpoint,omitempty"`
+	InternalEndpoint string `json:"internalEndpoint,omitempty"`
+	PKIID            string `json:"pkiID"`
+	MSPID            string `json:"mspID,omitempty"`
+}
+
+// Connection describes a connection to a remote peer
+type Connection struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	PKIID    string `json:"pkiID"`
+	// Outbound is true if the connection was initiated by the peer
+	Outbound bool `json:"outbound"`
+	// PendingMessages is the number of messages waiting to be sent to the remote peer
+	PendingMessages int `json:"pendingMessages"`
+}
+
+// Channel describes the membership of a channel
+type Channel struct {
+	Name string `json:"name"`
+	// Self is the information the peer publishes in the channel
+	Self ChannelMember `json:"self"`
+	// Peers are the remote peers of the channel that are considered alive
+	Peers []ChannelMember `json:"peers"`
+	// Leader is the PKI-ID of the peer that is known as the leader of the organization
+	// in the channel, empty if no leader is known
+	Leader string `json:"leader,omitempty"`
+	// IsLeader is true if the peer is the leader of its organization in the channel
+	IsLeader bool `json:"isLeader"`
+	// StaticLeader is true if the leader is statically configured instead of elected
+	StaticLeader bool `json:"staticLeader"`
+	// AnchorPeers are the endpoints of the anchor peers of the channel
+	AnchorPeers []string `json:"anchorPeers"`
+}
+
+// ChannelMember describes a peer of a channel
+type ChannelMember struct {
+	Member
+	LedgerHeight uint64      `json:"ledgerHeight"`
+	Chaincodes   []Chaincode `json:"chaincodes,omitempty"`
+	LeftChannel  bool        `json:"leftChannel,omitempty"`
+}
+
+// Chaincode describes a chaincode installed on a peer
+type Chaincode struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Channel returns the membership of the given channel, or nil if the peer hasn't joined it
+func (r *Report) Channel(name string) *Channel {
+	for i := range r.Channels {
+		if r.Channels[i].Name == name {
+			return &r.Channels[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+)
+
+type Introspector struct {
+	IntrospectStub        func() *introspection.Report
+	introspectMutex       sync.RWMutex
+	introspectArgsForCall []struct {
+	}
+	introspectReturns struct {
+		result1 *introspection.Report
+	}
+	introspectReturnsOnCall map[int]struct {
+		result1 *introspection.Report
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Introspector) Introspect() *introspection.Report {
+	fake.introspectMutex.Lock()
+	ret, specificReturn := fake.introspectReturnsOnCall[len(fake.introspectArgsForCall)]
+	fake.introspectArgsForCall = append(fake.introspectArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Introspect", []interface{}{})
+	fake.introspectMutex.Unlock()
+	if fake.IntrospectStub != nil {
+		return fake.IntrospectStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.introspectReturns
+	return fakeReturns.result1
+}
+
+func (fake *Introspector) IntrospectCallCount() int {
+	fake.introspectMutex.RLock()
+	defer fake.introspectMutex.RUnlock()
+	return len(fake.introspectArgsForCall)
+}
+
+func (fake *Introspector) IntrospectCalls(stub func() *introspection.Report) {
+	fake.introspectMutex.Lock()
+	defer fake.introspectMutex.Unlock()
+	fake.IntrospectStub = stub
+}
+
+func (fake *Introspector) IntrospectReturns(result1 *introspection.Report) {
+	fake.introspectMutex.Lock()
+	defer fake.introspectMutex.Unlock()
+	fake.IntrospectStub = nil
+	fake.introspectReturns = struct {
+		result1 *introspection.Report
+	}{result1}
+}
+
+func (fake *Introspector) IntrospectReturnsOnCall(i int, result1 *introspection.Report) {
+	fake.introspectMutex.Lock()
+	defer fake.introspectMutex.Unlock()
+	fake.IntrospectStub = nil
+	if fake.introspectReturnsOnCall == nil {
+		fake.introspectReturnsOnCall = make(map[int]struct {
+			result1 *introspection.Report
+		})
+	}
+	fake.introspectReturnsOnCall[i] = struct {
+		result1 *introspection.Report
+	}{result1}
+}
+
+func (fake *Introspector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.introspectMutex.RLock()
+	defer fake.introspectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Introspector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ introspection.Introspector = new(Introspector)
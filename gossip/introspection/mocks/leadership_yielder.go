@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+)
+
+type LeadershipYielder struct {
+	YieldLeadershipStub        func(string) error
+	yieldLeadershipMutex       sync.RWMutex
+	yieldLeadershipArgsForCall []struct {
+		arg1 string
+	}
+	yieldLeadershipReturns struct {
+		result1 error
+	}
+	yieldLeadershipReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *LeadershipYielder) YieldLeadership(arg1 string) error {
+	fake.yieldLeadershipMutex.Lock()
+	ret, specificReturn := fake.yieldLeadershipReturnsOnCall[len(fake.yieldLeadershipArgsForCall)]
+	fake.yieldLeadershipArgsForCall = append(fake.yieldLeadershipArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("YieldLeadership", []interface{}{arg1})
+	fake.yieldLeadershipMutex.Unlock()
+	if fake.YieldLeadershipStub != nil {
+		return fake.YieldLeadershipStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.yieldLeadershipReturns
+	return fakeReturns.result1
+}
+
+func (fake *LeadershipYielder) YieldLeadershipCallCount() int {
+	fake.yieldLeadershipMutex.RLock()
+	defer fake.yieldLeadershipMutex.RUnlock()
+	return len(fake.yieldLeadershipArgsForCall)
+}
+
+func (fake *LeadershipYielder) YieldLeadershipCalls(stub func(string) error) {
+	fake.yieldLeadershipMutex.Lock()
+	defer fake.yieldLeadershipMutex.Unlock()
+	fake.YieldLeadershipStub = stub
+}
+
+func (fake *LeadershipYielder) YieldLeadershipArgsForCall(i int) string {
+	fake.yieldLeadershipMutex.RLock()
+	defer fake.yieldLeadershipMutex.RUnlock()
+	argsForCall := fake.yieldLeadershipArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *LeadershipYielder) YieldLeadershipReturns(result1 error) {
+	fake.yieldLeadershipMutex.Lock()
+	defer fake.yieldLeadershipMutex.Unlock()
+	fake.YieldLeadershipStub = nil
+	fake.yieldLeadershipReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *LeadershipYielder) YieldLeadershipReturnsOnCall(i int, result1 error) {
+	fake.yieldLeadershipMutex.Lock()
+	defer fake.yieldLeadershipMutex.Unlock()
+	fake.YieldLeadershipStub = nil
+	if fake.yieldLeadershipReturnsOnCall == nil {
+		fake.yieldLeadershipReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.yieldLeadershipReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *LeadershipYielder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.yieldLeadershipMutex.RLock()
+	defer fake.yieldLeadershipMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *LeadershipYielder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ introspection.LeadershipYielder = new(LeadershipYielder)
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+//go:generate counterfeiter -o mocks/leadership_yielder.go -fake-name LeadershipYielder . LeadershipYielder
+
+// LeadershipYielder relinquishes the leadership of the peer in a channel
+type LeadershipYielder interface {
+	YieldLeadership(channelID string) error
+}
+
+// NewYieldHandler returns a handler that makes the peer yield its leadership in a channel
+func NewYieldHandler(yielder LeadershipYielder) *YieldHandler {
+	return &YieldHandler{
+		Yielder: yielder,
+		Logger:  flogging.MustGetLogger("gossip.introspection"),
+	}
+}
+
+// YieldHandler makes the peer relinquish its leadership in the channel given by the channel
+// query parameter, so that another peer of its organization takes over.
+type YieldHandler struct {
+	Yielder LeadershipYielder
+	Logger  *flogging.FabricLogger
+}
+
+func (h *YieldHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.Header().Set("Allow", http.MethodPost)
+		sendResponse(h.Logger, resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	channelID := req.URL.Query().Get("channel")
+	if channelID == "" {
+		sendResponse(h.Logger, resp, http.StatusBadRequest, fmt.Errorf("channel is required"))
+		return
+	}
+
+	if err := h.Yielder.YieldLeadership(channelID); err != nil {
+		sendResponse(h.Logger, resp, http.StatusBadRequest, err)
+		return
+	}
+	resp.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package introspection_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+	"github.com/hyperledger/fabric/gossip/introspection/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYieldHandler(t *testing.T) {
+	yielder := &mocks.LeadershipYielder{}
+	handler := introspection.NewYieldHandler(yielder)
+
+	t.Run("success", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/leadership/yield?channel=ch1", nil))
+		require.Equal(t, http.StatusAccepted, resp.Code)
+		require.Equal(t, 1, yielder.YieldLeadershipCallCount())
+		require.Equal(t, "ch1", yielder.YieldLeadershipArgsForCall(0))
+	})
+
+	t.Run("yield failure", func(t *testing.T) {
+		yielder.YieldLeadershipReturns(errors.New("peer is not the leader of channel ch1"))
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/leadership/yield?channel=ch1", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error":"peer is not the leader of channel ch1"}`, resp.Body.String())
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/leadership/yield", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error":"channel is required"}`, resp.Body.String())
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/leadership/yield?channel=ch1", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+		require.Equal(t, http.MethodPost, resp.Header().Get("Allow"))
+		require.JSONEq(t, `{"error":"invalid request method: GET"}`, resp.Body.String())
+	})
+}
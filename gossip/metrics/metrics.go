@@ -32,6 +32,8 @@ type StateMetrics struct {
 	Height            metrics.Gauge
 	CommitDuration    metrics.Histogram
 	PayloadBufferSize metrics.Gauge
+	TransferredBlocks metrics.Counter
+	SourceThroughput  metrics.Gauge
 }
 
 func newStateMetrics(p metrics.Provider) *StateMetrics {
@@ -39,6 +41,8 @@ func newStateMetrics(p metrics.Provider) *StateMetrics {
 		Height:            p.NewGauge(HeightOpts),
 		CommitDuration:    p.NewHistogram(CommitDurationOpts),
 		PayloadBufferSize: p.NewGauge(PayloadBufferSizeOpts),
+		TransferredBlocks: p.NewCounter(TransferredBlocksOpts),
+		SourceThroughput:  p.NewGauge(SourceThroughputOpts),
 	}
 }
 
@@ -69,6 +73,24 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	TransferredBlocksOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "transferred_blocks",
+		Help:         "Number of blocks received via state transfer from a peer",
+		LabelNames:   []string{"channel", "source"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{source}",
+	}
+
+	SourceThroughputOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "source_throughput",
+		Help:         "Observed throughput of state transfer from a peer in blocks per second",
+		LabelNames:   []string{"channel", "source"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{source}",
+	}
 )
 
 // ElectionMetrics encapsulates gossip leader election related metrics
@@ -93,16 +115,20 @@ var LeaderDeclerationOpts = metrics.GaugeOpts{
 
 // CommMetrics encapsulates gossip communication related metrics
 type CommMetrics struct {
-	SentMessages     metrics.Counter
-	BufferOverflow   metrics.Counter
-	ReceivedMessages metrics.Counter
+	SentMessages          metrics.Counter
+	BufferOverflow        metrics.Counter
+	ReceivedMessages      metrics.Counter
+	CompressionRatio      metrics.Histogram
+	CompressionSavedBytes metrics.Counter
 }
 
 func newCommMetrics(p metrics.Provider) *CommMetrics {
 	return &CommMetrics{
-		SentMessages:     p.NewCounter(SentMessagesOpts),
-		BufferOverflow:   p.NewCounter(BufferOverflowOpts),
-		ReceivedMessages: p.NewCounter(ReceivedMessagesOpts),
+		SentMessages:          p.NewCounter(SentMessagesOpts),
+		BufferOverflow:        p.NewCounter(BufferOverflowOpts),
+		ReceivedMessages:      p.NewCounter(ReceivedMessagesOpts),
+		CompressionRatio:      p.NewHistogram(CompressionRatioOpts),
+		CompressionSavedBytes: p.NewCounter(CompressionSavedBytesOpts),
 	}
 }
 
@@ -130,6 +156,25 @@ var (
 		Help:         "Number of messages received",
 		StatsdFormat: "%{#fqname}",
 	}
+
+	CompressionRatioOpts = metrics.HistogramOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "compression_ratio",
+		Help:         "Ratio between the compressed and the uncompressed payload sizes of compressed messages",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+		Buckets:      []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+	}
+
+	CompressionSavedBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "compression_saved_bytes",
+		Help:         "Number of bytes saved by compressing messages",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+	}
 )
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
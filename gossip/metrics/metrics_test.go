@@ -33,6 +33,8 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.StateMetrics.Height)
 	require.NotNil(t, gossipMetrics.StateMetrics.CommitDuration)
 	require.NotNil(t, gossipMetrics.StateMetrics.PayloadBufferSize)
+	require.NotNil(t, gossipMetrics.StateMetrics.TransferredBlocks)
+	require.NotNil(t, gossipMetrics.StateMetrics.SourceThroughput)
 
 	require.NotNil(t, gossipMetrics.ElectionMetrics)
 	require.NotNil(t, gossipMetrics.ElectionMetrics.Declaration)
@@ -41,6 +43,8 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.CommMetrics.SentMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.BufferOverflow)
+	require.NotNil(t, gossipMetrics.CommMetrics.CompressionRatio)
+	require.NotNil(t, gossipMetrics.CommMetrics.CompressionSavedBytes)
 
 	require.NotNil(t, gossipMetrics.MembershipMetrics)
 	require.NotNil(t, gossipMetrics.MembershipMetrics.Total)
@@ -25,6 +25,9 @@ type TestMetricProvider struct {
 	FakeBufferOverflow   *metricsfakes.Counter
 	FakeReceivedMessages *metricsfakes.Counter
 
+	FakeCompressionRatio      *metricsfakes.Histogram
+	FakeCompressionSavedBytes *metricsfakes.Counter
+
 	FakeTotalGauge *metricsfakes.Gauge
 
 	FakeValidationDuration             *metricsfakes.Histogram
@@ -51,6 +54,9 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeBufferOverflow := testUtilConstructCounter()
 	fakeReceivedMessages := testUtilConstructCounter()
 
+	fakeCompressionRatio := testUtilConstructHist()
+	fakeCompressionSavedBytes := testUtilConstructCounter()
+
 	fakeTotalGauge := testUtilConstructGauge()
 
 	fakeValidationDuration := testUtilConstructHist()
@@ -71,6 +77,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.CompressionSavedBytesOpts.Name:
+			return fakeCompressionSavedBytes
 		}
 		return nil
 	}
@@ -78,6 +86,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		switch opts.Name {
 		case gmetrics.CommitDurationOpts.Name:
 			return fakeCommitDurationHist
+		case gmetrics.CompressionRatioOpts.Name:
+			return fakeCompressionRatio
 		case gmetrics.ValidationDurationOpts.Name:
 			return fakeValidationDuration
 		case gmetrics.ListMissingPrivateDataDurationOpts.Name:
@@ -122,6 +132,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeSentMessages,
 		fakeBufferOverflow,
 		fakeReceivedMessages,
+		fakeCompressionRatio,
+		fakeCompressionSavedBytes,
 		fakeTotalGauge,
 		fakeValidationDuration,
 		fakeListMissingPrivateDataDuration,
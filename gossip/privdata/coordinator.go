@@ -110,6 +110,9 @@ type CoordinatorConfig struct {
 	// TransientBlockRetention indicates the number of blocks to retain in the transient store
 	// when purging below height on committing every TransientBlockRetention-th block
 	TransientBlockRetention uint64
+	// TransientMaxAge indicates the maximum time to retain private data in the transient store,
+	// which is purged when older on committing every block. It is not purged based on its age if zero.
+	TransientMaxAge time.Duration
 	// PullRetryThreshold indicates the max duration an attempted fetch from a remote peer will retry
 	// for before giving up and leaving the private data as missing
 	PullRetryThreshold time.Duration
@@ -124,6 +127,7 @@ type coordinator struct {
 	Support
 	store                          *transientstore.Store
 	transientBlockRetention        uint64
+	transientMaxAge                time.Duration
 	logger                         util.Logger
 	metrics                        *metrics.PrivdataMetrics
 	pullRetryThreshold             time.Duration
@@ -140,6 +144,7 @@ func NewCoordinator(mspID string, support Support, store *transientstore.Store,
 		store:                          store,
 		selfSignedData:                 selfSignedData,
 		transientBlockRetention:        config.TransientBlockRetention,
+		transientMaxAge:                config.TransientMaxAge,
 		logger:                         logger.With("channel", support.ChainID),
 		metrics:                        metrics,
 		pullRetryThreshold:             config.PullRetryThreshold,
@@ -198,6 +203,7 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		pullRetryThreshold:                      c.pullRetryThreshold,
 		prefetchedPvtdata:                       privateDataSets,
 		transientBlockRetention:                 c.transientBlockRetention,
+		transientMaxAge:                         c.transientMaxAge,
 		channelID:                               c.ChainID,
 		blockNum:                                block.Header.Number,
 		storePvtdataOfInvalidTx:                 c.Support.CapabilityProvider.Capabilities().StorePvtDataOfInvalidTx(),
@@ -230,7 +236,8 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		return errors.Wrap(err, "commit failed")
 	}
 
-	// Purge transactions
+	// Purge transactions, as well as the keys explicitly purged by the block
+	retrievedPvtdata.purgedKeys = c.purgedKeys(block)
 	go retrievedPvtdata.Purge()
 
 	return nil
@@ -473,6 +480,47 @@ func getTxInfoFromTransactionBytes(envBytes []byte) (*txInfo, error) {
 	return txInfo, nil
 }
 
+// purgedKeys returns the private data keys explicitly purged by a committed block. Transactions that purge private
+// data are invalidated unless the PurgePvtData capability is enabled, so no keys are returned without it.
+func (c *coordinator) purgedKeys(block *common.Block) []*transientstore.PurgedKey {
+	purgedKeys := getPurgedKeysFromBlock(block)
+	if len(purgedKeys) == 0 || c.Support.CapabilityProvider.Capabilities().PurgePvtData() {
+		return purgedKeys
+	}
+	c.logger.Warningf("Ignoring %d private data keys purged by block [%d] as the PurgePvtData capability is not enabled", len(purgedKeys), block.Header.Number)
+	return nil
+}
+
+// getPurgedKeysFromBlock returns the private data keys explicitly purged by the valid transactions of a committed block
+func getPurgedKeysFromBlock(block *common.Block) []*transientstore.PurgedKey {
+	var purgedKeys []*transientstore.PurgedKey
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for seqInBlock, txEnvBytes := range block.Data.Data {
+		if seqInBlock >= len(txsFilter) || txsFilter[seqInBlock] != uint8(peer.TxValidationCode_VALID) {
+			continue
+		}
+		txInfo, err := getTxInfoFromTransactionBytes(txEnvBytes)
+		if err != nil {
+			continue
+		}
+		for _, ns := range txInfo.txRWSet.NsRwSets {
+			for _, hashedCollection := range ns.CollHashedRwSets {
+				for _, hashedWrite := range hashedCollection.HashedRwSet.GetHashedWrites() {
+					if !hashedWrite.IsPurge {
+						continue
+					}
+					purgedKeys = append(purgedKeys, &transientstore.PurgedKey{
+						Namespace:  ns.NameSpace,
+						Collection: hashedCollection.CollectionName,
+						KeyHash:    hashedWrite.KeyHash,
+					})
+				}
+			}
+		}
+	}
+	return purgedKeys
+}
+
 // containsWrites checks whether the given CollHashedRwSet contains writes
 func containsWrites(txID string, namespace string, colHashedRWSet *rwsetutil.CollHashedRwSet) bool {
 	if colHashedRWSet.HashedRwSet == nil {
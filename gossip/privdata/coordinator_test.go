@@ -1815,6 +1815,55 @@ func TestContainsWrites(t *testing.T) {
 	require.True(t, containsWrites("tx", "ns", col))
 }
 
+func TestGetPurgedKeysFromBlock(t *testing.T) {
+	bf := &blockFactory{
+		channelID: "testchannelid",
+	}
+	block := bf.AddTxn("tx1", "ns1", []byte("hash"), "c1").
+		AddPurgeTxn("tx2", "ns1", []byte("key-1-hash"), "c1", "c2").
+		AddPurgeTxn("tx3", "ns2", []byte("key-2-hash"), "c1").
+		withInvalidTxns(2).
+		create()
+
+	require.Equal(t,
+		[]*transientstore.PurgedKey{
+			{Namespace: "ns1", Collection: "c1", KeyHash: []byte("key-1-hash")},
+			{Namespace: "ns1", Collection: "c2", KeyHash: []byte("key-1-hash")},
+		},
+		getPurgedKeysFromBlock(block),
+	)
+}
+
+func TestPurgedKeysRequirePurgePvtDataCapability(t *testing.T) {
+	bf := &blockFactory{
+		channelID: "testchannelid",
+	}
+	block := bf.AddPurgeTxn("tx1", "ns1", []byte("key-1-hash"), "c1").create()
+
+	for _, enabled := range []bool{true, false} {
+		capabilityProvider := &privdatamocks.CapabilityProvider{}
+		appCapability := &privdatamocks.AppCapabilities{}
+		capabilityProvider.On("Capabilities").Return(appCapability)
+		appCapability.On("PurgePvtData").Return(enabled)
+
+		store := newTransientStore(t)
+		coordinator := NewCoordinator("Org1MSP", Support{
+			ChainID:            "testchannelid",
+			CapabilityProvider: capabilityProvider,
+		}, store.store, protoutil.SignedData{}, metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, testConfig, nil).(*coordinator)
+
+		if enabled {
+			require.Equal(t,
+				[]*transientstore.PurgedKey{{Namespace: "ns1", Collection: "c1", KeyHash: []byte("key-1-hash")}},
+				coordinator.purgedKeys(block),
+			)
+		} else {
+			require.Nil(t, coordinator.purgedKeys(block))
+		}
+		store.tearDown()
+	}
+}
+
 func TestIgnoreReadOnlyColRWSets(t *testing.T) {
 	// Scenario: The transaction has some ColRWSets that have only reads and no writes,
 	// These should be ignored and not considered as missing private data that needs to be retrieved
@@ -47,6 +47,8 @@ type RetrievedPvtdata struct {
 	purgeDurationHistogram  metrics.Histogram
 	blockNum                uint64
 	transientBlockRetention uint64
+	transientMaxAge         time.Duration
+	purgedKeys              []*transientstore.PurgedKey
 }
 
 // GetBlockPvtdata returns the BlockPvtdata
@@ -56,6 +58,7 @@ func (r *RetrievedPvtdata) GetBlockPvtdata() *ledger.BlockPvtdata {
 
 // Purge purges private data for transactions in the block from the transient store.
 // Transactions older than the retention period are considered orphaned and also purged.
+// The keys explicitly purged by the block are removed from the remaining transactions.
 func (r *RetrievedPvtdata) Purge() {
 	purgeStart := time.Now()
 
@@ -66,6 +69,13 @@ func (r *RetrievedPvtdata) Purge() {
 		}
 	}
 
+	if len(r.purgedKeys) > 0 {
+		// Remove the explicitly purged keys from the private data of the pending transactions
+		if err := r.transientStore.PurgeKeys(r.purgedKeys); err != nil {
+			r.logger.Errorf("Purging explicitly purged keys from transient store failed: %s", err)
+		}
+	}
+
 	blockNum := r.blockNum
 	if blockNum%r.transientBlockRetention == 0 && blockNum > r.transientBlockRetention {
 		err := r.transientStore.PurgeBelowHeight(blockNum - r.transientBlockRetention)
@@ -74,6 +84,12 @@ func (r *RetrievedPvtdata) Purge() {
 		}
 	}
 
+	if r.transientMaxAge > 0 {
+		if _, err := r.transientStore.PurgeOlderThan(time.Now().Add(-r.transientMaxAge)); err != nil {
+			r.logger.Errorf("Failed purging expired data from transient store at block [%d]: %s", blockNum, err)
+		}
+	}
+
 	r.purgeDurationHistogram.Observe(time.Since(purgeStart).Seconds())
 }
 
@@ -163,6 +179,7 @@ type PvtdataProvider struct {
 	pullRetryThreshold                      time.Duration
 	prefetchedPvtdata                       util.PvtDataCollections
 	transientBlockRetention                 uint64
+	transientMaxAge                         time.Duration
 	channelID                               string
 	blockNum                                uint64
 	storePvtdataOfInvalidTx                 bool
@@ -183,6 +200,7 @@ func (pdp *PvtdataProvider) RetrievePvtdata(pvtdataToRetrieve []*ledger.TxPvtdat
 		purgeDurationHistogram:  pdp.purgeDurationHistogram,
 		blockNum:                pdp.blockNum,
 		transientBlockRetention: pdp.transientBlockRetention,
+		transientMaxAge:         pdp.transientMaxAge,
 	}
 
 	listMissingStart := time.Now()
@@ -1241,6 +1241,61 @@ func TestRetrievedPvtdataPurgeBelowHeight(t *testing.T) {
 	}
 }
 
+func TestRetrievedPvtdataPurgeOlderThan(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "ts")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+	storeProvider, err := transientstore.NewStoreProvider(tempdir)
+	require.NoError(t, err)
+	defer storeProvider.Close()
+	store, err := storeProvider.OpenStore("testchannelid")
+	require.NoError(t, err)
+
+	persist := func(txID string) {
+		err := store.Persist(txID, 1, &tspb.TxPvtReadWriteSetWithConfigInfo{
+			PvtRwset: &rwset.TxPvtReadWriteSet{
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{
+						Namespace:          "ns1",
+						CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "c1", Rwset: []byte("rws-pre-image")}},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+	persist("tx1")
+	time.Sleep(100 * time.Millisecond)
+	cutoff := time.Now().Add(-50 * time.Millisecond)
+	persist("tx2")
+
+	retrievedPvtdata := &RetrievedPvtdata{
+		blockPvtdata:            &ledger.BlockPvtdata{},
+		transientStore:          store,
+		logger:                  logger,
+		purgeDurationHistogram:  metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics.PurgeDuration,
+		blockNum:                2,
+		transientBlockRetention: 1000,
+		transientMaxAge:         time.Since(cutoff),
+	}
+	retrievedPvtdata.Purge()
+
+	// only the private data older than the maximum age is purged
+	entries, err := store.EntriesByTxid("tx1")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	entries, err = store.EntriesByTxid("tx2")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// private data is not purged based on its age when the maximum age is zero
+	retrievedPvtdata.transientMaxAge = 0
+	retrievedPvtdata.Purge()
+	entries, err = store.EntriesByTxid("tx2")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
 func TestFetchStats(t *testing.T) {
 	fetchStats := fetchStats{
 		fromLocalCache:     1,
@@ -53,6 +53,72 @@ type PvtDataReconciler interface {
 	Start()
 	// Stop function stops reconciler
 	Stop()
+	// Reconcile triggers a reconciliation of the missing private data in the given scope without waiting for
+	// the scheduler. A nil scope reconciles all missing private data.
+	Reconcile(scope *ReconciliationScope) error
+	// Pause suspends reconciliation until Resume is called. A reconciliation in progress stops after its current batch.
+	// The paused state is held in memory and does not survive a restart of the peer.
+	Pause() error
+	// Resume resumes a paused reconciliation
+	Resume() error
+	// Status returns the progress of the reconciliation
+	Status() (*ReconciliationStatus, error)
+}
+
+// ReconciliationCollection identifies a collection of a chaincode
+type ReconciliationCollection struct {
+	Namespace  string
+	Collection string
+}
+
+// ReconciliationScope restricts an on-demand reconciliation to the missing private data of some collections,
+// within a range of blocks
+type ReconciliationScope struct {
+	// Collections to reconcile, all collections are reconciled if empty
+	Collections []ReconciliationCollection
+	// StartBlock is the first block to reconcile
+	StartBlock uint64
+	// EndBlock is the last block to reconcile, there is no upper bound if zero
+	EndBlock uint64
+}
+
+// includesCollection returns true if the private data of the collection is in scope
+func (s *ReconciliationScope) includesCollection(namespace string, collection string) bool {
+	if len(s.Collections) == 0 {
+		return true
+	}
+	for _, c := range s.Collections {
+		if c.Namespace == namespace && c.Collection == collection {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconciliationCycle describes a single pass of the reconciler over the missing private data
+type ReconciliationCycle struct {
+	// Scope of an on-demand reconciliation, nil for scheduled reconciliations
+	Scope     *ReconciliationScope
+	StartTime time.Time
+	// EndTime is zero while the cycle is in progress
+	EndTime time.Time
+	// Missing is the number of missing private data items reported by the MissingPvtDataTracker
+	Missing int
+	// Reconciled is the number of missing private data items fetched from other peers
+	Reconciled int
+	MinBlock   uint64
+	MaxBlock   uint64
+	Err        error
+}
+
+// ReconciliationStatus reports the state of the reconciler of a channel
+type ReconciliationStatus struct {
+	Paused     bool
+	InProgress bool
+	// Cycle is the cycle in progress or, if none, the last completed cycle. It is nil if no cycle ran yet.
+	Cycle *ReconciliationCycle
+	// TotalReconciled is the number of private data items reconciled since the reconciler started
+	TotalReconciled int
 }
 
 type Reconciler struct {
@@ -62,12 +128,22 @@ type Reconciler struct {
 	ReconcileSleepInterval time.Duration
 	ReconcileBatchSize     int
 	stopChan               chan struct{}
+	triggerChan            chan *ReconciliationScope
 	startOnce              sync.Once
 	stopOnce               sync.Once
+
+	lock            sync.Mutex
+	paused          bool
+	inProgress      bool
+	cycle           *ReconciliationCycle
+	totalReconciled int
+
 	ReconciliationFetcher
 	committer.Committer
 }
 
+var errReconciliationDisabled = errors.New("private data reconciliation is disabled")
+
 // NoOpReconciler non functional reconciler to be used
 // in case reconciliation has been disabled
 type NoOpReconciler struct{}
@@ -81,6 +157,22 @@ func (*NoOpReconciler) Stop() {
 	// do nothing
 }
 
+func (*NoOpReconciler) Reconcile(*ReconciliationScope) error {
+	return errReconciliationDisabled
+}
+
+func (*NoOpReconciler) Pause() error {
+	return errReconciliationDisabled
+}
+
+func (*NoOpReconciler) Resume() error {
+	return errReconciliationDisabled
+}
+
+func (*NoOpReconciler) Status() (*ReconciliationStatus, error) {
+	return nil, errReconciliationDisabled
+}
+
 // NewReconciler creates a new instance of reconciler
 func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer.Committer,
 	fetcher ReconciliationFetcher, config *PrivdataConfig) *Reconciler {
@@ -95,6 +187,7 @@ func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer
 		Committer:              c,
 		ReconciliationFetcher:  fetcher,
 		stopChan:               make(chan struct{}),
+		triggerChan:            make(chan *ReconciliationScope, 1),
 	}
 }
 
@@ -110,22 +203,122 @@ func (r *Reconciler) Start() {
 	})
 }
 
+// Reconcile triggers a reconciliation of the missing private data in the given scope, which runs as soon as the
+// reconciliation in progress, if any, completes
+func (r *Reconciler) Reconcile(scope *ReconciliationScope) error {
+	if r.isPaused() {
+		return errors.New("private data reconciliation is paused")
+	}
+	select {
+	case r.triggerChan <- scope:
+		return nil
+	default:
+		return errors.New("a private data reconciliation is already pending")
+	}
+}
+
+// Pause suspends reconciliation until Resume is called
+func (r *Reconciler) Pause() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.paused = true
+	r.logger.Info("Private data reconciliation paused")
+	return nil
+}
+
+// Resume resumes a paused reconciliation
+func (r *Reconciler) Resume() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.paused = false
+	r.logger.Info("Private data reconciliation resumed")
+	return nil
+}
+
+// Status returns the progress of the reconciliation
+func (r *Reconciler) Status() (*ReconciliationStatus, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status := &ReconciliationStatus{
+		Paused:          r.paused,
+		InProgress:      r.inProgress,
+		TotalReconciled: r.totalReconciled,
+	}
+	if r.cycle != nil {
+		cycle := *r.cycle
+		status.Cycle = &cycle
+	}
+	return status, nil
+}
+
+func (r *Reconciler) isPaused() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.paused
+}
+
 func (r *Reconciler) run() {
 	for {
 		select {
 		case <-r.stopChan:
 			return
+		case scope := <-r.triggerChan:
+			r.logger.Debug("Start on-demand reconcile missing private info")
+			r.runCycle(scope)
 		case <-time.After(r.ReconcileSleepInterval):
-			r.logger.Debug("Start reconcile missing private info")
-			if err := r.reconcile(); err != nil {
-				r.logger.Error("Failed to reconcile missing private info, error: ", err.Error())
+			if r.isPaused() {
+				r.logger.Debug("Private data reconciliation is paused, skipping")
+				continue
 			}
+			r.logger.Debug("Start reconcile missing private info")
+			r.runCycle(nil)
 		}
 	}
 }
 
-// returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
+func (r *Reconciler) runCycle(scope *ReconciliationScope) {
+	r.lock.Lock()
+	r.inProgress = true
+	r.cycle = &ReconciliationCycle{Scope: scope, StartTime: time.Now(), MinBlock: math.MaxUint64}
+	r.lock.Unlock()
+
+	err := r.reconcileInScope(scope)
+	if err != nil {
+		r.logger.Error("Failed to reconcile missing private info, error: ", err.Error())
+	}
+
+	r.lock.Lock()
+	r.inProgress = false
+	r.cycle.EndTime = time.Now()
+	r.cycle.Err = err
+	r.lock.Unlock()
+}
+
+// recordBatch adds the outcome of a reconciled batch to the cycle in progress
+func (r *Reconciler) recordBatch(missing, reconciled int, minBlock, maxBlock uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.totalReconciled += reconciled
+	if r.cycle == nil {
+		return
+	}
+	r.cycle.Missing += missing
+	r.cycle.Reconciled += reconciled
+	if minBlock < r.cycle.MinBlock {
+		r.cycle.MinBlock = minBlock
+	}
+	if maxBlock > r.cycle.MaxBlock {
+		r.cycle.MaxBlock = maxBlock
+	}
+}
+
 func (r *Reconciler) reconcile() error {
+	return r.reconcileInScope(nil)
+}
+
+// reconcileInScope reconciles the missing private data in the given scope, or all of it if the scope is nil
+func (r *Reconciler) reconcileInScope(scope *ReconciliationScope) error {
 	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
 	if err != nil {
 		r.logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
@@ -139,8 +332,46 @@ func (r *Reconciler) reconcile() error {
 
 	defer r.reportReconciliationDuration(time.Now())
 
+	nextBatch := func() (ledger.MissingPvtDataInfo, error) {
+		return missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.ReconcileBatchSize)
+	}
+	if scope != nil {
+		// The missing private data out of scope remains in the tracker, and so does private data that could
+		// not be reconciled, so the scope is paged through from its most recent block downwards.
+		startBlock, endBlock, done := scope.StartBlock, scope.EndBlock, false
+		if endBlock == 0 {
+			endBlock = math.MaxUint64
+		}
+		nextBatch = func() (ledger.MissingPvtDataInfo, error) {
+			if done {
+				return nil, nil
+			}
+			batch, err := missingPvtDataTracker.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, r.ReconcileBatchSize, scope.includesCollection)
+			if err != nil || len(batch) == 0 {
+				return nil, err
+			}
+			minBlock := uint64(math.MaxUint64)
+			for blockNum := range batch {
+				if blockNum < minBlock {
+					minBlock = blockNum
+				}
+			}
+			if minBlock <= startBlock {
+				done = true
+			} else {
+				endBlock = minBlock - 1
+			}
+			return batch, nil
+		}
+	}
+
 	for {
-		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.ReconcileBatchSize)
+		if r.isPaused() {
+			r.logger.Infof("Reconciliation cycle paused after reconciling %d private data keys", totalReconciled)
+			return nil
+		}
+
+		missingPvtDataInfo, err := nextBatch()
 		if err != nil {
 			r.logger.Error("reconciliation error when trying to get missing pvt data info recent blocks:", err)
 			return err
@@ -178,6 +409,7 @@ func (r *Reconciler) reconcile() error {
 			maxBlock = maxB
 		}
 		totalReconciled += len(fetchedData.AvailableElements)
+		r.recordBatch(len(dig2collectionCfg), len(fetchedData.AvailableElements), minB, maxB)
 	}
 }
 
@@ -8,6 +8,8 @@ package privdata
 
 import (
 	"errors"
+	"math"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -541,6 +543,192 @@ func TestFailuresWhileReconcilingMissingPvtData(t *testing.T) {
 	require.Contains(t, "failed get missing pvt data for recent blocks", err.Error())
 }
 
+func TestReconciliationInScope(t *testing.T) {
+	// Scenario: an on-demand reconciliation restricted to a collection and a range of blocks.
+	// only the missing private data in scope should be fetched, in batches of the most recent blocks.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := ledger.MissingPvtDataInfo{}
+	for blockNum := uint64(1); blockNum <= 6; blockNum++ {
+		missingInfo.Add(blockNum, 1, "ns1", "col1")
+		missingInfo.Add(blockNum, 2, "ns1", "col2")
+	}
+
+	collectionConfigInfo := ledger.CollectionConfigInfo{
+		CollectionConfig: &peer.CollectionConfigPackage{
+			Config: []*peer.CollectionConfig{
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &peer.StaticCollectionConfig{Name: "col1"},
+				}},
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &peer.StaticCollectionConfig{Name: "col2"},
+				}},
+			},
+		},
+		CommittingBlockNum: 1,
+	}
+
+	// the tracker returns the most recent missing private data in range that passes the filter, as the ledger does
+	var requestedRanges [][2]uint64
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(startBlock, endBlock uint64, maxBlocks int, filter func(string, string) bool) ledger.MissingPvtDataInfo {
+			requestedRanges = append(requestedRanges, [2]uint64{startBlock, endBlock})
+			batch := ledger.MissingPvtDataInfo{}
+			for blockNum := endBlock; blockNum >= startBlock && len(batch) < maxBlocks; blockNum-- {
+				for seqInBlock, collections := range missingInfo[blockNum] {
+					for _, c := range collections {
+						if filter(c.Namespace, c.Collection) {
+							batch.Add(blockNum, seqInBlock, c.Namespace, c.Collection)
+						}
+					}
+				}
+			}
+			return batch
+		}, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&collectionConfigInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything, mock.Anything).Return(nil, nil)
+
+	var fetchedBatches [][]privdatacommon.DigKey
+	fetcher.On("FetchReconciledItems", mock.Anything).Run(func(args mock.Arguments) {
+		var batch []privdatacommon.DigKey
+		for digest := range args.Get(0).(privdatacommon.Dig2CollectionConfig) {
+			batch = append(batch, digest)
+		}
+		fetchedBatches = append(fetchedBatches, batch)
+	}).Return(&privdatacommon.FetchedPvtDataContainer{}, nil)
+
+	r := &Reconciler{
+		channel:                "mychannel",
+		logger:                 logger.With("channel", "mychannel"),
+		metrics:                metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		ReconcileSleepInterval: time.Minute,
+		ReconcileBatchSize:     2,
+		ReconciliationFetcher:  fetcher, Committer: committer,
+	}
+	err := r.reconcileInScope(&ReconciliationScope{
+		Collections: []ReconciliationCollection{{Namespace: "ns1", Collection: "col1"}},
+		StartBlock:  2,
+		EndBlock:    4,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, [][]privdatacommon.DigKey{
+		{{Namespace: "ns1", Collection: "col1", BlockSeq: 4, SeqInBlock: 1}, {Namespace: "ns1", Collection: "col1", BlockSeq: 3, SeqInBlock: 1}},
+		{{Namespace: "ns1", Collection: "col1", BlockSeq: 2, SeqInBlock: 1}},
+	}, sortBatches(fetchedBatches))
+	require.Equal(t, [][2]uint64{{2, 4}, {2, 2}}, requestedRanges, "pages through the scope from its most recent block")
+	missingPvtDataTracker.AssertNotCalled(t, "GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything)
+}
+
+func sortBatches(batches [][]privdatacommon.DigKey) [][]privdatacommon.DigKey {
+	for _, batch := range batches {
+		sort.Slice(batch, func(i, j int) bool {
+			return batch[i].BlockSeq > batch[j].BlockSeq
+		})
+	}
+	return batches
+}
+
+func TestReconcilerControl(t *testing.T) {
+	// Scenario: reconciliation is triggered on demand, paused and resumed, and its status reflects its progress.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := ledger.MissingPvtDataInfo{}
+	missingInfo.Add(3, 1, "ns1", "col1")
+
+	collectionConfigInfo := ledger.CollectionConfigInfo{
+		CollectionConfig: &peer.CollectionConfigPackage{
+			Config: []*peer.CollectionConfig{
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &peer.StaticCollectionConfig{Name: "col1"},
+				}},
+			},
+		},
+		CommittingBlockNum: 1,
+	}
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(missingInfo, nil).Once()
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(nil, nil)
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&collectionConfigInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything, mock.Anything).Return(nil, nil)
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(&privdatacommon.FetchedPvtDataContainer{
+		AvailableElements: []*gossip2.PvtDataElement{{
+			Digest:  &gossip2.PvtDataDigest{BlockSeq: 3, SeqInBlock: 1, Namespace: "ns1", Collection: "col1"},
+			Payload: [][]byte{[]byte("rws-pre-image")},
+		}},
+	}, nil)
+
+	r := NewReconciler(
+		"mychannel",
+		metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics,
+		committer,
+		fetcher,
+		&PrivdataConfig{
+			ReconcileSleepInterval: time.Hour,
+			ReconcileBatchSize:     1,
+			ReconciliationEnabled:  true,
+		})
+	r.Start()
+	defer r.Stop()
+
+	status, err := r.Status()
+	require.NoError(t, err)
+	require.Equal(t, &ReconciliationStatus{}, status)
+
+	require.NoError(t, r.Reconcile(nil))
+	require.Eventually(t, func() bool {
+		status, err := r.Status()
+		return err == nil && status.Cycle != nil && !status.InProgress
+	}, 5*time.Second, 10*time.Millisecond)
+
+	status, err = r.Status()
+	require.NoError(t, err)
+	require.Equal(t, 1, status.TotalReconciled)
+	require.Nil(t, status.Cycle.Scope)
+	require.Equal(t, 1, status.Cycle.Missing)
+	require.Equal(t, 1, status.Cycle.Reconciled)
+	require.Equal(t, uint64(3), status.Cycle.MinBlock)
+	require.Equal(t, uint64(3), status.Cycle.MaxBlock)
+	require.False(t, status.Cycle.EndTime.IsZero())
+	require.NoError(t, status.Cycle.Err)
+
+	require.NoError(t, r.Pause())
+	status, err = r.Status()
+	require.NoError(t, err)
+	require.True(t, status.Paused)
+	require.EqualError(t, r.Reconcile(nil), "private data reconciliation is paused")
+
+	require.NoError(t, r.Resume())
+	status, err = r.Status()
+	require.NoError(t, err)
+	require.False(t, status.Paused)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(1), uint64(math.MaxUint64), 1, mock.Anything).Return(nil, nil)
+	require.NoError(t, r.Reconcile(&ReconciliationScope{StartBlock: 1}))
+	require.Eventually(t, func() bool {
+		status, err := r.Status()
+		return err == nil && status.Cycle.Scope != nil && !status.InProgress
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNoOpReconcilerControl(t *testing.T) {
+	r := &NoOpReconciler{}
+	require.EqualError(t, r.Reconcile(nil), "private data reconciliation is disabled")
+	require.EqualError(t, r.Pause(), "private data reconciliation is disabled")
+	require.EqualError(t, r.Resume(), "private data reconciliation is disabled")
+	_, err := r.Status()
+	require.EqualError(t, err, "private data reconciliation is disabled")
+}
+
 func TestConstructUnreconciledMissingData(t *testing.T) {
 	requestedMissingData := privdatacommon.Dig2CollectionConfig{
 		privdatacommon.DigKey{
@@ -0,0 +1,363 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/privdata"
+)
+
+type PvtDataReconciler struct {
+	PauseStub        func() error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReconcileStub        func(*privdata.ReconciliationScope) error
+	reconcileMutex       sync.RWMutex
+	reconcileArgsForCall []struct {
+		arg1 *privdata.ReconciliationScope
+	}
+	reconcileReturns struct {
+		result1 error
+	}
+	reconcileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeStub        func() error
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+	}
+	resumeReturns struct {
+		result1 error
+	}
+	resumeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StartStub        func()
+	startMutex       sync.RWMutex
+	startArgsForCall []struct {
+	}
+	StatusStub        func() (*privdata.ReconciliationStatus, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+	}
+	statusReturns struct {
+		result1 *privdata.ReconciliationStatus
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 *privdata.ReconciliationStatus
+		result2 error
+	}
+	StopStub        func()
+	stopMutex       sync.RWMutex
+	stopArgsForCall []struct {
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PvtDataReconciler) Pause() error {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pauseReturns
+	return fakeReturns.result1
+}
+
+func (fake *PvtDataReconciler) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *PvtDataReconciler) PauseCalls(stub func() error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = stub
+}
+
+func (fake *PvtDataReconciler) PauseReturns(result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PvtDataReconciler) PauseReturnsOnCall(i int, result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PvtDataReconciler) Reconcile(arg1 *privdata.ReconciliationScope) error {
+	fake.reconcileMutex.Lock()
+	ret, specificReturn := fake.reconcileReturnsOnCall[len(fake.reconcileArgsForCall)]
+	fake.reconcileArgsForCall = append(fake.reconcileArgsForCall, struct {
+		arg1 *privdata.ReconciliationScope
+	}{arg1})
+	fake.recordInvocation("Reconcile", []interface{}{arg1})
+	fake.reconcileMutex.Unlock()
+	if fake.ReconcileStub != nil {
+		return fake.ReconcileStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.reconcileReturns
+	return fakeReturns.result1
+}
+
+func (fake *PvtDataReconciler) ReconcileCallCount() int {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return len(fake.reconcileArgsForCall)
+}
+
+func (fake *PvtDataReconciler) ReconcileCalls(stub func(*privdata.ReconciliationScope) error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = stub
+}
+
+func (fake *PvtDataReconciler) ReconcileArgsForCall(i int) *privdata.ReconciliationScope {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	argsForCall := fake.reconcileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PvtDataReconciler) ReconcileReturns(result1 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	fake.reconcileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PvtDataReconciler) ReconcileReturnsOnCall(i int, result1 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	if fake.reconcileReturnsOnCall == nil {
+		fake.reconcileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reconcileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PvtDataReconciler) Resume() error {
+	fake.resumeMutex.Lock()
+	ret, specificReturn := fake.resumeReturnsOnCall[len(fake.resumeArgsForCall)]
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Resume", []interface{}{})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resumeReturns
+	return fakeReturns.result1
+}
+
+func (fake *PvtDataReconciler) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *PvtDataReconciler) ResumeCalls(stub func() error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = stub
+}
+
+func (fake *PvtDataReconciler) ResumeReturns(result1 error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PvtDataReconciler) ResumeReturnsOnCall(i int, result1 error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = nil
+	if fake.resumeReturnsOnCall == nil {
+		fake.resumeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PvtDataReconciler) Start() {
+	fake.startMutex.Lock()
+	fake.startArgsForCall = append(fake.startArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Start", []interface{}{})
+	fake.startMutex.Unlock()
+	if fake.StartStub != nil {
+		fake.StartStub()
+	}
+}
+
+func (fake *PvtDataReconciler) StartCallCount() int {
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	return len(fake.startArgsForCall)
+}
+
+func (fake *PvtDataReconciler) StartCalls(stub func()) {
+	fake.startMutex.Lock()
+	defer fake.startMutex.Unlock()
+	fake.StartStub = stub
+}
+
+func (fake *PvtDataReconciler) Status() (*privdata.ReconciliationStatus, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PvtDataReconciler) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *PvtDataReconciler) StatusCalls(stub func() (*privdata.ReconciliationStatus, error)) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *PvtDataReconciler) StatusReturns(result1 *privdata.ReconciliationStatus, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 *privdata.ReconciliationStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PvtDataReconciler) StatusReturnsOnCall(i int, result1 *privdata.ReconciliationStatus, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 *privdata.ReconciliationStatus
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 *privdata.ReconciliationStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PvtDataReconciler) Stop() {
+	fake.stopMutex.Lock()
+	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Stop", []interface{}{})
+	fake.stopMutex.Unlock()
+	if fake.StopStub != nil {
+		fake.StopStub()
+	}
+}
+
+func (fake *PvtDataReconciler) StopCallCount() int {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return len(fake.stopArgsForCall)
+}
+
+func (fake *PvtDataReconciler) StopCalls(stub func()) {
+	fake.stopMutex.Lock()
+	defer fake.stopMutex.Unlock()
+	fake.StopStub = stub
+}
+
+func (fake *PvtDataReconciler) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	fake.startMutex.RLock()
+	defer fake.startMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PvtDataReconciler) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,108 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/privdata"
+)
+
+type ReconcilerGetter struct {
+	PvtDataReconcilerStub        func(string) privdata.PvtDataReconciler
+	pvtDataReconcilerMutex       sync.RWMutex
+	pvtDataReconcilerArgsForCall []struct {
+		arg1 string
+	}
+	pvtDataReconcilerReturns struct {
+		result1 privdata.PvtDataReconciler
+	}
+	pvtDataReconcilerReturnsOnCall map[int]struct {
+		result1 privdata.PvtDataReconciler
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ReconcilerGetter) PvtDataReconciler(arg1 string) privdata.PvtDataReconciler {
+	fake.pvtDataReconcilerMutex.Lock()
+	ret, specificReturn := fake.pvtDataReconcilerReturnsOnCall[len(fake.pvtDataReconcilerArgsForCall)]
+	fake.pvtDataReconcilerArgsForCall = append(fake.pvtDataReconcilerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PvtDataReconciler", []interface{}{arg1})
+	fake.pvtDataReconcilerMutex.Unlock()
+	if fake.PvtDataReconcilerStub != nil {
+		return fake.PvtDataReconcilerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pvtDataReconcilerReturns
+	return fakeReturns.result1
+}
+
+func (fake *ReconcilerGetter) PvtDataReconcilerCallCount() int {
+	fake.pvtDataReconcilerMutex.RLock()
+	defer fake.pvtDataReconcilerMutex.RUnlock()
+	return len(fake.pvtDataReconcilerArgsForCall)
+}
+
+func (fake *ReconcilerGetter) PvtDataReconcilerCalls(stub func(string) privdata.PvtDataReconciler) {
+	fake.pvtDataReconcilerMutex.Lock()
+	defer fake.pvtDataReconcilerMutex.Unlock()
+	fake.PvtDataReconcilerStub = stub
+}
+
+func (fake *ReconcilerGetter) PvtDataReconcilerArgsForCall(i int) string {
+	fake.pvtDataReconcilerMutex.RLock()
+	defer fake.pvtDataReconcilerMutex.RUnlock()
+	argsForCall := fake.pvtDataReconcilerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ReconcilerGetter) PvtDataReconcilerReturns(result1 privdata.PvtDataReconciler) {
+	fake.pvtDataReconcilerMutex.Lock()
+	defer fake.pvtDataReconcilerMutex.Unlock()
+	fake.PvtDataReconcilerStub = nil
+	fake.pvtDataReconcilerReturns = struct {
+		result1 privdata.PvtDataReconciler
+	}{result1}
+}
+
+func (fake *ReconcilerGetter) PvtDataReconcilerReturnsOnCall(i int, result1 privdata.PvtDataReconciler) {
+	fake.pvtDataReconcilerMutex.Lock()
+	defer fake.pvtDataReconcilerMutex.Unlock()
+	fake.PvtDataReconcilerStub = nil
+	if fake.pvtDataReconcilerReturnsOnCall == nil {
+		fake.pvtDataReconcilerReturnsOnCall = make(map[int]struct {
+			result1 privdata.PvtDataReconciler
+		})
+	}
+	fake.pvtDataReconcilerReturnsOnCall[i] = struct {
+		result1 privdata.PvtDataReconciler
+	}{result1}
+}
+
+func (fake *ReconcilerGetter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pvtDataReconcilerMutex.RLock()
+	defer fake.pvtDataReconcilerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ReconcilerGetter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,635 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: gossip/privdata/reconcilegrpc/reconcile.proto
+
+package reconcilegrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedReconciliationRequest contains a serialized ReconciliationRequest
+// message, and a digital signature for the serialized request message.
+type SignedReconciliationRequest struct {
+	// Serialized ReconciliationRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedReconciliationRequest) Reset()         { *m = SignedReconciliationRequest{} }
+func (m *SignedReconciliationRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedReconciliationRequest) ProtoMessage()    {}
+func (*SignedReconciliationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_185565e3af9787d6, []int{0}
+}
+
+func (m *SignedReconciliationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedReconciliationRequest.Unmarshal(m, b)
+}
+func (m *SignedReconciliationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedReconciliationRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedReconciliationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedReconciliationRequest.Merge(m, src)
+}
+func (m *SignedReconciliationRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedReconciliationRequest.Size(m)
+}
+func (m *SignedReconciliationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedReconciliationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedReconciliationRequest proto.InternalMessageInfo
+
+func (m *SignedReconciliationRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedReconciliationRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ReconciliationRequest identifies the channel whose reconciliation is
+// controlled and, for Reconcile, the scope of the reconciliation.
+type ReconciliationRequest struct {
+	// The signature header that contains creator identity and nonce.
+	SignatureHeader *common.SignatureHeader `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	// The name of the channel.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The collections to reconcile. All collections are reconciled if empty.
+	Collections []*CollectionReference `protobuf:"bytes,3,rep,name=collections,proto3" json:"collections,omitempty"`
+	// The first block to reconcile.
+	StartBlock uint64 `protobuf:"varint,4,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	// The last block to reconcile. There is no upper bound if zero.
+	EndBlock             uint64   `protobuf:"varint,5,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconciliationRequest) Reset()         { *m = ReconciliationRequest{} }
+func (m *ReconciliationRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconciliationRequest) ProtoMessage()    {}
+func (*ReconciliationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_185565e3af9787d6, []int{1}
+}
+
+func (m *ReconciliationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconciliationRequest.Unmarshal(m, b)
+}
+func (m *ReconciliationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconciliationRequest.Marshal(b, m, deterministic)
+}
+func (m *ReconciliationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconciliationRequest.Merge(m, src)
+}
+func (m *ReconciliationRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconciliationRequest.Size(m)
+}
+func (m *ReconciliationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconciliationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconciliationRequest proto.InternalMessageInfo
+
+func (m *ReconciliationRequest) GetSignatureHeader() *common.SignatureHeader {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *ReconciliationRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ReconciliationRequest) GetCollections() []*CollectionReference {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *ReconciliationRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *ReconciliationRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+// CollectionReference identifies a private data collection of a chaincode.
+type CollectionReference struct {
+	ChaincodeName        string   `protobuf:"bytes,1,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	CollectionName       string   `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionReference) Reset()         { *m = CollectionReference{} }
+func (m *CollectionReference) String() string { return proto.CompactTextString(m) }
+func (*CollectionReference) ProtoMessage()    {}
+func (*CollectionReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_185565e3af9787d6, []int{2}
+}
+
+func (m *CollectionReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionReference.Unmarshal(m, b)
+}
+func (m *CollectionReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionReference.Marshal(b, m, deterministic)
+}
+func (m *CollectionReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionReference.Merge(m, src)
+}
+func (m *CollectionReference) XXX_Size() int {
+	return xxx_messageInfo_CollectionReference.Size(m)
+}
+func (m *CollectionReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionReference proto.InternalMessageInfo
+
+func (m *CollectionReference) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *CollectionReference) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+// ReconciliationStatusResponse reports the progress of the reconciliation of
+// a channel.
+type ReconciliationStatusResponse struct {
+	// Whether the reconciliation is paused.
+	Paused bool `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Whether a reconciliation cycle is in progress.
+	InProgress bool `protobuf:"varint,2,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	// The cycle in progress or, if none, the last completed cycle. Not set if
+	// no cycle ran yet.
+	Cycle *ReconciliationCycle `protobuf:"bytes,3,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	// The number of private data items reconciled since the peer started.
+	TotalReconciled      uint64   `protobuf:"varint,4,opt,name=total_reconciled,json=totalReconciled,proto3" json:"total_reconciled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconciliationStatusResponse) Reset()         { *m = ReconciliationStatusResponse{} }
+func (m *ReconciliationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ReconciliationStatusResponse) ProtoMessage()    {}
+func (*ReconciliationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_185565e3af9787d6, []int{3}
+}
+
+func (m *ReconciliationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconciliationStatusResponse.Unmarshal(m, b)
+}
+func (m *ReconciliationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconciliationStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ReconciliationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconciliationStatusResponse.Merge(m, src)
+}
+func (m *ReconciliationStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ReconciliationStatusResponse.Size(m)
+}
+func (m *ReconciliationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconciliationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconciliationStatusResponse proto.InternalMessageInfo
+
+func (m *ReconciliationStatusResponse) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *ReconciliationStatusResponse) GetInProgress() bool {
+	if m != nil {
+		return m.InProgress
+	}
+	return false
+}
+
+func (m *ReconciliationStatusResponse) GetCycle() *ReconciliationCycle {
+	if m != nil {
+		return m.Cycle
+	}
+	return nil
+}
+
+func (m *ReconciliationStatusResponse) GetTotalReconciled() uint64 {
+	if m != nil {
+		return m.TotalReconciled
+	}
+	return 0
+}
+
+// ReconciliationCycle describes a single pass of the reconciler over the
+// missing private data.
+type ReconciliationCycle struct {
+	// Whether the cycle was triggered on demand.
+	OnDemand  bool                 `protobuf:"varint,1,opt,name=on_demand,json=onDemand,proto3" json:"on_demand,omitempty"`
+	StartTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Not set while the cycle is in progress.
+	EndTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The number of missing private data items the cycle tried to reconcile.
+	Missing uint64 `protobuf:"varint,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	// The number of missing private data items that were reconciled.
+	Reconciled uint64 `protobuf:"varint,5,opt,name=reconciled,proto3" json:"reconciled,omitempty"`
+	// The range of blocks with missing private data.
+	MinBlock uint64 `protobuf:"varint,6,opt,name=min_block,json=minBlock,proto3" json:"min_block,omitempty"`
+	MaxBlock uint64 `protobuf:"varint,7,opt,name=max_block,json=maxBlock,proto3" json:"max_block,omitempty"`
+	// The error that ended the cycle, if any.
+	Error                string   `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconciliationCycle) Reset()         { *m = ReconciliationCycle{} }
+func (m *ReconciliationCycle) String() string { return proto.CompactTextString(m) }
+func (*ReconciliationCycle) ProtoMessage()    {}
+func (*ReconciliationCycle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_185565e3af9787d6, []int{4}
+}
+
+func (m *ReconciliationCycle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconciliationCycle.Unmarshal(m, b)
+}
+func (m *ReconciliationCycle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconciliationCycle.Marshal(b, m, deterministic)
+}
+func (m *ReconciliationCycle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconciliationCycle.Merge(m, src)
+}
+func (m *ReconciliationCycle) XXX_Size() int {
+	return xxx_messageInfo_ReconciliationCycle.Size(m)
+}
+func (m *ReconciliationCycle) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconciliationCycle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconciliationCycle proto.InternalMessageInfo
+
+func (m *ReconciliationCycle) GetOnDemand() bool {
+	if m != nil {
+		return m.OnDemand
+	}
+	return false
+}
+
+func (m *ReconciliationCycle) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *ReconciliationCycle) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *ReconciliationCycle) GetMissing() uint64 {
+	if m != nil {
+		return m.Missing
+	}
+	return 0
+}
+
+func (m *ReconciliationCycle) GetReconciled() uint64 {
+	if m != nil {
+		return m.Reconciled
+	}
+	return 0
+}
+
+func (m *ReconciliationCycle) GetMinBlock() uint64 {
+	if m != nil {
+		return m.MinBlock
+	}
+	return 0
+}
+
+func (m *ReconciliationCycle) GetMaxBlock() uint64 {
+	if m != nil {
+		return m.MaxBlock
+	}
+	return 0
+}
+
+func (m *ReconciliationCycle) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SignedReconciliationRequest)(nil), "reconcilegrpc.SignedReconciliationRequest")
+	proto.RegisterType((*ReconciliationRequest)(nil), "reconcilegrpc.ReconciliationRequest")
+	proto.RegisterType((*CollectionReference)(nil), "reconcilegrpc.CollectionReference")
+	proto.RegisterType((*ReconciliationStatusResponse)(nil), "reconcilegrpc.ReconciliationStatusResponse")
+	proto.RegisterType((*ReconciliationCycle)(nil), "reconcilegrpc.ReconciliationCycle")
+}
+
+func init() {
+	proto.RegisterFile("gossip/privdata/reconcilegrpc/reconcile.proto", fileDescriptor_185565e3af9787d6)
+}
+
+var fileDescriptor_185565e3af9787d6 = []byte{
+	// 646 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x6d, 0x6b, 0x13, 0x4b,
+	0x14, 0x26, 0x4d, 0xf3, 0x76, 0x72, 0xfb, 0xc2, 0xf4, 0xde, 0xde, 0x25, 0xed, 0xbd, 0x0d, 0x01,
+	0xb1, 0x2a, 0x66, 0x21, 0x22, 0x28, 0xe2, 0x97, 0xb6, 0x82, 0xa2, 0x68, 0x99, 0xea, 0x17, 0xbf,
+	0x2c, 0x93, 0xd9, 0xd3, 0xcd, 0xe0, 0xee, 0xcc, 0x3a, 0x33, 0x91, 0xf6, 0x4f, 0xf9, 0x0b, 0xfc,
+	0x2f, 0xfe, 0x13, 0x91, 0x9d, 0xd9, 0x4d, 0xba, 0x35, 0x54, 0x84, 0x7e, 0x4a, 0xce, 0xf3, 0x9c,
+	0xf7, 0xf3, 0xec, 0xc0, 0xc3, 0x44, 0x19, 0x23, 0xf2, 0x30, 0xd7, 0xe2, 0x4b, 0xcc, 0x2c, 0x0b,
+	0x35, 0x72, 0x25, 0xb9, 0x48, 0x31, 0xd1, 0x39, 0x5f, 0x5a, 0xe3, 0x5c, 0x2b, 0xab, 0xc8, 0x46,
+	0x8d, 0x1e, 0xec, 0x25, 0x4a, 0x25, 0x29, 0x86, 0x8e, 0x9c, 0xce, 0xcf, 0x43, 0xcc, 0x72, 0x7b,
+	0xe9, 0x7d, 0x07, 0x07, 0xd7, 0x49, 0x2b, 0x32, 0x34, 0x96, 0x65, 0x79, 0xe9, 0xb0, 0xc3, 0x55,
+	0x96, 0x29, 0x19, 0xfa, 0x1f, 0x0f, 0x8e, 0x3e, 0xc0, 0xde, 0x99, 0x48, 0x24, 0xc6, 0xb4, 0xac,
+	0x24, 0x98, 0x15, 0x4a, 0x52, 0xfc, 0x3c, 0x47, 0x63, 0x49, 0x00, 0x1d, 0xed, 0xff, 0x06, 0x8d,
+	0x61, 0xe3, 0xf0, 0x2f, 0x5a, 0x99, 0x64, 0x1f, 0x7a, 0x46, 0x24, 0x92, 0xd9, 0xb9, 0xc6, 0x60,
+	0xcd, 0x71, 0x4b, 0x60, 0xf4, 0xa3, 0x01, 0xff, 0xac, 0xce, 0x78, 0x04, 0xdb, 0x0b, 0xb7, 0x68,
+	0x86, 0x2c, 0x46, 0xed, 0x52, 0xf7, 0x27, 0xff, 0x8e, 0xcb, 0xce, 0xce, 0x2a, 0xfe, 0xa5, 0xa3,
+	0xe9, 0x96, 0xa9, 0x03, 0xe4, 0x3f, 0x00, 0x3e, 0x63, 0x52, 0x62, 0x1a, 0x89, 0xd8, 0x15, 0xef,
+	0xd1, 0x5e, 0x89, 0xbc, 0x8a, 0xc9, 0x09, 0xf4, 0xb9, 0x4a, 0x53, 0xe4, 0x45, 0x5d, 0x13, 0x34,
+	0x87, 0xcd, 0xc3, 0xfe, 0x64, 0x34, 0xae, 0xed, 0x72, 0x7c, 0xbc, 0xf0, 0xa0, 0x78, 0x8e, 0x1a,
+	0x25, 0x47, 0x7a, 0x35, 0x8c, 0x1c, 0x40, 0xdf, 0x58, 0xa6, 0x6d, 0x34, 0x4d, 0x15, 0xff, 0x14,
+	0xac, 0x0f, 0x1b, 0x87, 0xeb, 0x14, 0x1c, 0x74, 0x54, 0x20, 0x64, 0x0f, 0x7a, 0x28, 0xe3, 0x92,
+	0x6e, 0x39, 0xba, 0x8b, 0x32, 0x76, 0xe4, 0x08, 0x61, 0x67, 0x45, 0x05, 0x72, 0x07, 0x36, 0xf9,
+	0x8c, 0x09, 0xc9, 0x55, 0x8c, 0x91, 0x64, 0x19, 0xba, 0xd9, 0x7b, 0x74, 0x63, 0x81, 0xbe, 0x65,
+	0x19, 0x92, 0xbb, 0xb0, 0xb5, 0x6c, 0xc5, 0xfb, 0xf9, 0x29, 0x37, 0x97, 0x70, 0xe1, 0x38, 0xfa,
+	0xd6, 0x80, 0xfd, 0xfa, 0x9e, 0xcf, 0x2c, 0xb3, 0x73, 0x43, 0xd1, 0xe4, 0x4a, 0x1a, 0x24, 0xbb,
+	0xd0, 0xce, 0xd9, 0xdc, 0x60, 0xec, 0x0a, 0x75, 0x69, 0x69, 0x15, 0xd3, 0x09, 0x19, 0xe5, 0x5a,
+	0x25, 0x1a, 0x8d, 0x71, 0xd9, 0xbb, 0x14, 0x84, 0x3c, 0x2d, 0x11, 0xf2, 0x04, 0x5a, 0xfc, 0x92,
+	0xa7, 0x18, 0x34, 0x87, 0x8d, 0x15, 0xeb, 0xab, 0x17, 0x3d, 0x2e, 0x3c, 0xa9, 0x0f, 0x20, 0xf7,
+	0x60, 0xdb, 0x2a, 0xcb, 0xd2, 0x68, 0x11, 0x11, 0x97, 0xdb, 0xdb, 0x72, 0x38, 0x5d, 0xc0, 0xa3,
+	0xaf, 0x6b, 0xb0, 0xb3, 0x22, 0x53, 0xb1, 0x5a, 0x25, 0xa3, 0x18, 0x33, 0x26, 0xab, 0xc6, 0xbb,
+	0x4a, 0x9e, 0x38, 0x9b, 0x3c, 0x05, 0x7f, 0x85, 0xa8, 0x10, 0xb8, 0xeb, 0xbc, 0x3f, 0x19, 0x8c,
+	0xbd, 0xfa, 0xc7, 0x95, 0xfa, 0xc7, 0xef, 0x2b, 0xf5, 0xd3, 0x9e, 0xf3, 0x2e, 0x6c, 0xf2, 0x18,
+	0x8a, 0x0b, 0xf9, 0xc0, 0xe6, 0x6f, 0x03, 0x3b, 0x28, 0x63, 0x17, 0x16, 0x40, 0x27, 0x13, 0xc6,
+	0x08, 0x99, 0x94, 0x83, 0x54, 0x26, 0xf9, 0x1f, 0xe0, 0xca, 0x94, 0x5e, 0x04, 0x57, 0x90, 0x62,
+	0x90, 0x4c, 0xc8, 0x52, 0x23, 0x6d, 0xaf, 0x91, 0x4c, 0xc8, 0x85, 0x80, 0x32, 0x76, 0x51, 0x92,
+	0x9d, 0x92, 0x64, 0x17, 0x9e, 0xfc, 0x1b, 0x5a, 0xa8, 0xb5, 0xd2, 0x41, 0xd7, 0x1d, 0xde, 0x1b,
+	0x93, 0xef, 0x6b, 0xb0, 0x59, 0x5f, 0x18, 0x79, 0x07, 0xbd, 0x0a, 0x41, 0x72, 0xff, 0xda, 0x99,
+	0x6e, 0xf8, 0xb6, 0x07, 0xbb, 0xbf, 0x8c, 0xfe, 0xa2, 0x78, 0x4e, 0xc8, 0x6b, 0x68, 0x9d, 0x16,
+	0x22, 0xb9, 0x95, 0x64, 0x6f, 0xa0, 0x4d, 0xd1, 0xcc, 0xb3, 0xdb, 0xc9, 0xc6, 0xa0, 0xed, 0xf5,
+	0xfd, 0x47, 0xd9, 0x1e, 0xdc, 0xa8, 0xdd, 0xfa, 0x07, 0x73, 0xf4, 0xfc, 0xe3, 0xb3, 0x44, 0xd8,
+	0xd9, 0x7c, 0x5a, 0xbc, 0x46, 0xe1, 0xec, 0x32, 0x47, 0x9d, 0x62, 0x9c, 0xa0, 0x0e, 0xcf, 0xd9,
+	0x54, 0x0b, 0x1e, 0xde, 0xf8, 0x82, 0x4f, 0xdb, 0xae, 0xe3, 0x47, 0x3f, 0x07, 0x00, 0xb7, 0xc1,
+	0x1f, 0x09, 0xe9, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ReconciliationClient is the client API for Reconciliation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReconciliationClient interface {
+	// Reconcile triggers a reconciliation of the missing private data of the
+	// channel without waiting for the reconciliation interval. It can be
+	// restricted to some collections and to a range of blocks.
+	Reconcile(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Pause suspends the reconciliation of the channel until it is resumed.
+	Pause(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Resume resumes a paused reconciliation.
+	Resume(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Status returns the progress of the reconciliation of the channel.
+	Status(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*ReconciliationStatusResponse, error)
+}
+
+type reconciliationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReconciliationClient(cc grpc.ClientConnInterface) ReconciliationClient {
+	return &reconciliationClient{cc}
+}
+
+func (c *reconciliationClient) Reconcile(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/reconcilegrpc.Reconciliation/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconciliationClient) Pause(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/reconcilegrpc.Reconciliation/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconciliationClient) Resume(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/reconcilegrpc.Reconciliation/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconciliationClient) Status(ctx context.Context, in *SignedReconciliationRequest, opts ...grpc.CallOption) (*ReconciliationStatusResponse, error) {
+	out := new(ReconciliationStatusResponse)
+	err := c.cc.Invoke(ctx, "/reconcilegrpc.Reconciliation/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReconciliationServer is the server API for Reconciliation service.
+type ReconciliationServer interface {
+	// Reconcile triggers a reconciliation of the missing private data of the
+	// channel without waiting for the reconciliation interval. It can be
+	// restricted to some collections and to a range of blocks.
+	Reconcile(context.Context, *SignedReconciliationRequest) (*empty.Empty, error)
+	// Pause suspends the reconciliation of the channel until it is resumed.
+	Pause(context.Context, *SignedReconciliationRequest) (*empty.Empty, error)
+	// Resume resumes a paused reconciliation.
+	Resume(context.Context, *SignedReconciliationRequest) (*empty.Empty, error)
+	// Status returns the progress of the reconciliation of the channel.
+	Status(context.Context, *SignedReconciliationRequest) (*ReconciliationStatusResponse, error)
+}
+
+// UnimplementedReconciliationServer can be embedded to have forward compatible implementations.
+type UnimplementedReconciliationServer struct {
+}
+
+func (*UnimplementedReconciliationServer) Reconcile(ctx context.Context, req *SignedReconciliationRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (*UnimplementedReconciliationServer) Pause(ctx context.Context, req *SignedReconciliationRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (*UnimplementedReconciliationServer) Resume(ctx context.Context, req *SignedReconciliationRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (*UnimplementedReconciliationServer) Status(ctx context.Context, req *SignedReconciliationRequest) (*ReconciliationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+
+func RegisterReconciliationServer(s *grpc.Server, srv ReconciliationServer) {
+	s.RegisterService(&_Reconciliation_serviceDesc, srv)
+}
+
+func _Reconciliation_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reconcilegrpc.Reconciliation/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServer).Reconcile(ctx, req.(*SignedReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reconciliation_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reconcilegrpc.Reconciliation/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServer).Pause(ctx, req.(*SignedReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reconciliation_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reconcilegrpc.Reconciliation/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServer).Resume(ctx, req.(*SignedReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reconciliation_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/reconcilegrpc.Reconciliation/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServer).Status(ctx, req.(*SignedReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Reconciliation_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "reconcilegrpc.Reconciliation",
+	HandlerType: (*ReconciliationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reconcile",
+			Handler:    _Reconciliation_Reconcile_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Reconciliation_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Reconciliation_Resume_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Reconciliation_Status_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gossip/privdata/reconcilegrpc/reconcile.proto",
+}
@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reconcilegrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// ReconciliationService implements the ReconciliationServer grpc interface
+type ReconciliationService struct {
+	ReconcilerGetter ReconcilerGetter
+	ACLProvider      ACLProvider
+}
+
+// ReconcilerGetter gets the private data reconciler associated with a channel.
+type ReconcilerGetter interface {
+	PvtDataReconciler(channelID string) privdata.PvtDataReconciler
+}
+
+// ACLProvider checks ACL for a channelless resource
+type ACLProvider interface {
+	CheckACLNoChannel(resName string, idinfo interface{}) error
+}
+
+// Reconcile triggers a reconciliation of the missing private data of a channel.
+func (s *ReconciliationService) Reconcile(ctx context.Context, signedRequest *SignedReconciliationRequest) (*empty.Empty, error) {
+	request, reconciler, err := s.authorize(resources.Reconciliation_reconcile, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.EndBlock != 0 && request.EndBlock < request.StartBlock {
+		return nil, errors.Errorf("end block %d is lower than start block %d", request.EndBlock, request.StartBlock)
+	}
+
+	var scope *privdata.ReconciliationScope
+	if len(request.Collections) > 0 || request.StartBlock != 0 || request.EndBlock != 0 {
+		scope = &privdata.ReconciliationScope{
+			StartBlock: request.StartBlock,
+			EndBlock:   request.EndBlock,
+		}
+		for _, collection := range request.Collections {
+			if collection.ChaincodeName == "" || collection.CollectionName == "" {
+				return nil, errors.New("collections must specify both a chaincode name and a collection name")
+			}
+			scope.Collections = append(scope.Collections, privdata.ReconciliationCollection{
+				Namespace:  collection.ChaincodeName,
+				Collection: collection.CollectionName,
+			})
+		}
+	}
+
+	if err := reconciler.Reconcile(scope); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Pause suspends the reconciliation of a channel.
+func (s *ReconciliationService) Pause(ctx context.Context, signedRequest *SignedReconciliationRequest) (*empty.Empty, error) {
+	_, reconciler, err := s.authorize(resources.Reconciliation_pause, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reconciler.Pause(); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Resume resumes a paused reconciliation of a channel.
+func (s *ReconciliationService) Resume(ctx context.Context, signedRequest *SignedReconciliationRequest) (*empty.Empty, error) {
+	_, reconciler, err := s.authorize(resources.Reconciliation_resume, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reconciler.Resume(); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Status returns the progress of the reconciliation of a channel.
+func (s *ReconciliationService) Status(ctx context.Context, signedRequest *SignedReconciliationRequest) (*ReconciliationStatusResponse, error) {
+	_, reconciler, err := s.authorize(resources.Reconciliation_status, signedRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := reconciler.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReconciliationStatusResponse{
+		Paused:          status.Paused,
+		InProgress:      status.InProgress,
+		TotalReconciled: uint64(status.TotalReconciled),
+	}
+	if cycle := status.Cycle; cycle != nil {
+		response.Cycle = &ReconciliationCycle{
+			OnDemand:   cycle.Scope != nil,
+			StartTime:  timestampProto(cycle.StartTime),
+			EndTime:    timestampProto(cycle.EndTime),
+			Missing:    uint64(cycle.Missing),
+			Reconciled: uint64(cycle.Reconciled),
+		}
+		if cycle.Missing > 0 {
+			response.Cycle.MinBlock = cycle.MinBlock
+			response.Cycle.MaxBlock = cycle.MaxBlock
+		}
+		if cycle.Err != nil {
+			response.Cycle.Error = cycle.Err.Error()
+		}
+	}
+
+	return response, nil
+}
+
+// authorize checks that the creator of the request is allowed to access the resource and returns the request, along
+// with the reconciler of the requested channel
+func (s *ReconciliationService) authorize(resName string, signedRequest *SignedReconciliationRequest) (*ReconciliationRequest, privdata.PvtDataReconciler, error) {
+	request := &ReconciliationRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal reconciliation request")
+	}
+
+	if err := s.checkACL(resName, request, signedRequest); err != nil {
+		return nil, nil, err
+	}
+
+	if request.ChannelId == "" {
+		return nil, nil, errors.New("missing channel ID")
+	}
+
+	reconciler := s.ReconcilerGetter.PvtDataReconciler(request.ChannelId)
+	if reconciler == nil {
+		return nil, nil, errors.Errorf("cannot find private data reconciler for channel %s", request.ChannelId)
+	}
+
+	return request, reconciler, nil
+}
+
+func (s *ReconciliationService) checkACL(resName string, request *ReconciliationRequest, signedRequest *SignedReconciliationRequest) error {
+	signatureHdr := request.SignatureHeader
+	if signatureHdr == nil {
+		return errors.New("missing signature header")
+	}
+
+	expirationTime := crypto.ExpiresAt(signatureHdr.Creator)
+	if !expirationTime.IsZero() && time.Now().After(expirationTime) {
+		return errors.New("client identity expired")
+	}
+
+	return s.ACLProvider.CheckACLNoChannel(
+		resName,
+		[]*protoutil.SignedData{{
+			Identity:  signatureHdr.Creator,
+			Data:      signedRequest.Request,
+			Signature: signedRequest.Signature,
+		}},
+	)
+}
+
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reconcilegrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/privdata/reconcilegrpc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/reconciler_getter.go -fake-name ReconcilerGetter . reconcilerGetter
+//go:generate counterfeiter -o mock/acl_provider.go -fake-name ACLProvider . aclProvider
+//go:generate counterfeiter -o mock/pvt_data_reconciler.go -fake-name PvtDataReconciler . pvtDataReconciler
+
+type reconcilerGetter interface {
+	ReconcilerGetter
+}
+
+type aclProvider interface {
+	ACLProvider
+}
+
+type pvtDataReconciler interface {
+	privdata.PvtDataReconciler
+}
+
+type testService struct {
+	service     *ReconciliationService
+	reconciler  *mock.PvtDataReconciler
+	getter      *mock.ReconcilerGetter
+	aclProvider *mock.ACLProvider
+}
+
+func newTestService() *testService {
+	reconciler := &mock.PvtDataReconciler{}
+	getter := &mock.ReconcilerGetter{}
+	getter.PvtDataReconcilerStub = func(channelID string) privdata.PvtDataReconciler {
+		if channelID == "mychannel" {
+			return reconciler
+		}
+		return nil
+	}
+	aclProvider := &mock.ACLProvider{}
+	return &testService{
+		service:     &ReconciliationService{ReconcilerGetter: getter, ACLProvider: aclProvider},
+		reconciler:  reconciler,
+		getter:      getter,
+		aclProvider: aclProvider,
+	}
+}
+
+func signedRequest(request *ReconciliationRequest) *SignedReconciliationRequest {
+	return &SignedReconciliationRequest{
+		Request:   protoutil.MarshalOrPanic(request),
+		Signature: []byte("signature"),
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	signatureHdr := &common.SignatureHeader{Creator: []byte("creator")}
+
+	t.Run("reconciles all missing private data", func(t *testing.T) {
+		test := newTestService()
+		_, err := test.service.Reconcile(context.Background(), signedRequest(&ReconciliationRequest{
+			SignatureHeader: signatureHdr,
+			ChannelId:       "mychannel",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, 1, test.reconciler.ReconcileCallCount())
+		require.Nil(t, test.reconciler.ReconcileArgsForCall(0))
+
+		resName, idinfo := test.aclProvider.CheckACLNoChannelArgsForCall(0)
+		require.Equal(t, resources.Reconciliation_reconcile, resName)
+		signedData := idinfo.([]*protoutil.SignedData)
+		require.Equal(t, []byte("creator"), signedData[0].Identity)
+		require.Equal(t, []byte("signature"), signedData[0].Signature)
+	})
+
+	t.Run("reconciles scope", func(t *testing.T) {
+		test := newTestService()
+		_, err := test.service.Reconcile(context.Background(), signedRequest(&ReconciliationRequest{
+			SignatureHeader: signatureHdr,
+			ChannelId:       "mychannel",
+			Collections:     []*CollectionReference{{ChaincodeName: "cc", CollectionName: "coll"}},
+			StartBlock:      10,
+			EndBlock:        20,
+		}))
+		require.NoError(t, err)
+		require.Equal(t, &privdata.ReconciliationScope{
+			Collections: []privdata.ReconciliationCollection{{Namespace: "cc", Collection: "coll"}},
+			StartBlock:  10,
+			EndBlock:    20,
+		}, test.reconciler.ReconcileArgsForCall(0))
+	})
+
+	tests := []struct {
+		name         string
+		request      *ReconciliationRequest
+		aclErr       error
+		reconcileErr error
+		errMsg       string
+	}{
+		{
+			name:    "missing signature header",
+			request: &ReconciliationRequest{ChannelId: "mychannel"},
+			errMsg:  "missing signature header",
+		},
+		{
+			name:    "access denied",
+			request: &ReconciliationRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel"},
+			aclErr:  errors.New("access denied"),
+			errMsg:  "access denied",
+		},
+		{
+			name:    "missing channel",
+			request: &ReconciliationRequest{SignatureHeader: signatureHdr},
+			errMsg:  "missing channel ID",
+		},
+		{
+			name:    "unknown channel",
+			request: &ReconciliationRequest{SignatureHeader: signatureHdr, ChannelId: "otherchannel"},
+			errMsg:  "cannot find private data reconciler for channel otherchannel",
+		},
+		{
+			name:    "invalid block range",
+			request: &ReconciliationRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel", StartBlock: 20, EndBlock: 10},
+			errMsg:  "end block 10 is lower than start block 20",
+		},
+		{
+			name: "incomplete collection",
+			request: &ReconciliationRequest{
+				SignatureHeader: signatureHdr,
+				ChannelId:       "mychannel",
+				Collections:     []*CollectionReference{{ChaincodeName: "cc"}},
+			},
+			errMsg: "collections must specify both a chaincode name and a collection name",
+		},
+		{
+			name:         "reconciler error",
+			request:      &ReconciliationRequest{SignatureHeader: signatureHdr, ChannelId: "mychannel"},
+			reconcileErr: errors.New("private data reconciliation is paused"),
+			errMsg:       "private data reconciliation is paused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := newTestService()
+			test.aclProvider.CheckACLNoChannelReturns(tt.aclErr)
+			test.reconciler.ReconcileReturns(tt.reconcileErr)
+
+			_, err := test.service.Reconcile(context.Background(), signedRequest(tt.request))
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		test := newTestService()
+		_, err := test.service.Reconcile(context.Background(), &SignedReconciliationRequest{Request: []byte("garbage")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal reconciliation request")
+	})
+}
+
+func TestPauseAndResume(t *testing.T) {
+	test := newTestService()
+	request := signedRequest(&ReconciliationRequest{
+		SignatureHeader: &common.SignatureHeader{Creator: []byte("creator")},
+		ChannelId:       "mychannel",
+	})
+
+	_, err := test.service.Pause(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, 1, test.reconciler.PauseCallCount())
+	resName, _ := test.aclProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, resources.Reconciliation_pause, resName)
+
+	_, err = test.service.Resume(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, 1, test.reconciler.ResumeCallCount())
+	resName, _ = test.aclProvider.CheckACLNoChannelArgsForCall(1)
+	require.Equal(t, resources.Reconciliation_resume, resName)
+
+	test.reconciler.PauseReturns(errors.New("private data reconciliation is disabled"))
+	_, err = test.service.Pause(context.Background(), request)
+	require.EqualError(t, err, "private data reconciliation is disabled")
+}
+
+func TestStatus(t *testing.T) {
+	test := newTestService()
+	request := signedRequest(&ReconciliationRequest{
+		SignatureHeader: &common.SignatureHeader{Creator: []byte("creator")},
+		ChannelId:       "mychannel",
+	})
+
+	startTime := time.Unix(1000, 0)
+	test.reconciler.StatusReturns(&privdata.ReconciliationStatus{
+		Paused:     true,
+		InProgress: true,
+		Cycle: &privdata.ReconciliationCycle{
+			Scope:      &privdata.ReconciliationScope{StartBlock: 5},
+			StartTime:  startTime,
+			Missing:    10,
+			Reconciled: 7,
+			MinBlock:   5,
+			MaxBlock:   9,
+			Err:        errors.New("fetch failed"),
+		},
+		TotalReconciled: 42,
+	}, nil)
+
+	response, err := test.service.Status(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, response.Paused)
+	require.True(t, response.InProgress)
+	require.EqualValues(t, 42, response.TotalReconciled)
+	require.True(t, response.Cycle.OnDemand)
+	require.EqualValues(t, 1000, response.Cycle.StartTime.Seconds)
+	require.Nil(t, response.Cycle.EndTime)
+	require.EqualValues(t, 10, response.Cycle.Missing)
+	require.EqualValues(t, 7, response.Cycle.Reconciled)
+	require.EqualValues(t, 5, response.Cycle.MinBlock)
+	require.EqualValues(t, 9, response.Cycle.MaxBlock)
+	require.Equal(t, "fetch failed", response.Cycle.Error)
+
+	resName, _ := test.aclProvider.CheckACLNoChannelArgsForCall(0)
+	require.Equal(t, resources.Reconciliation_status, resName)
+
+	test.reconciler.StatusReturns(&privdata.ReconciliationStatus{}, nil)
+	response, err = test.service.Status(context.Background(), request)
+	require.NoError(t, err)
+	require.Nil(t, response.Cycle)
+
+	test.reconciler.StatusReturns(nil, errors.New("private data reconciliation is disabled"))
+	_, err = test.service.Status(context.Background(), request)
+	require.EqualError(t, err, "private data reconciliation is disabled")
+}
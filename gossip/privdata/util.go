@@ -39,15 +39,36 @@ func (bf *blockFactory) AddReadOnlyTxn(txID string, nsName string, hash []byte,
 }
 
 func (bf *blockFactory) AddTxnWithEndorsement(txID string, nsName string, hash []byte, org string, hasWrites bool, collections ...string) *blockFactory {
+	nsRWSet := sampleNsRwSet(nsName, hash, collections...)
+	if !hasWrites {
+		nsRWSet = sampleReadOnlyNsRwSet(nsName, hash, collections...)
+	}
+	return bf.addTxnWithNsRwSet(txID, nsRWSet, org)
+}
+
+// AddPurgeTxn adds a transaction that purges the key with the given hash from the collections
+func (bf *blockFactory) AddPurgeTxn(txID string, nsName string, keyHash []byte, collections ...string) *blockFactory {
+	nsRWSet := &rwsetutil.NsRwSet{
+		NameSpace: nsName,
+		KvRwSet:   &kvrwset.KVRWSet{},
+	}
+	for _, col := range collections {
+		nsRWSet.CollHashedRwSets = append(nsRWSet.CollHashedRwSets, &rwsetutil.CollHashedRwSet{
+			CollectionName: col,
+			HashedRwSet: &kvrwset.HashedRWSet{
+				HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: keyHash, IsDelete: true, IsPurge: true}},
+			},
+		})
+	}
+	return bf.addTxnWithNsRwSet(txID, nsRWSet, "")
+}
+
+func (bf *blockFactory) addTxnWithNsRwSet(txID string, nsRWSet *rwsetutil.NsRwSet, org string) *blockFactory {
 	txn := &peer.Transaction{
 		Actions: []*peer.TransactionAction{
 			{},
 		},
 	}
-	nsRWSet := sampleNsRwSet(nsName, hash, collections...)
-	if !hasWrites {
-		nsRWSet = sampleReadOnlyNsRwSet(nsName, hash, collections...)
-	}
 	txrws := rwsetutil.TxRwSet{
 		NsRwSets: []*rwsetutil.NsRwSet{nsRWSet},
 	}
@@ -16,6 +16,9 @@ import (
 const (
 	btlPullMarginDefault           = 10
 	transientBlockRetentionDefault = 1000
+	// transientMaxAgeMin is the minimum age of the private data purged from the transient store. It spans
+	// many batch timeouts, so that the private data of the transactions waiting to be ordered isn't purged.
+	transientMaxAgeMin = 10 * time.Minute
 )
 
 // ServiceConfig is the config struct for gossip services
@@ -40,6 +43,9 @@ type ServiceConfig struct {
 	// ElectionLeaderElectionDuration is the time passes since last declaration message before peer decides to perform
 	// leader election (unit: second).
 	ElectionLeaderElectionDuration time.Duration
+	// ElectionWeight is the weight of the peer in leader election. Among the alive peers of an organization,
+	// the peer with the highest weight is elected, and the peer with the lowest PKI-ID breaks ties.
+	ElectionWeight uint32
 	// PvtDataPullRetryThreshold determines the maximum duration of time private data corresponding for
 	// a given block.
 	PvtDataPullRetryThreshold time.Duration
@@ -52,6 +58,9 @@ type ServiceConfig struct {
 	// TransientstoreMaxBlockRetention defines the maximum difference between the current ledger's height upon commit,
 	// and the private data residing inside the transient store that is guaranteed not to be purged.
 	TransientstoreMaxBlockRetention uint64
+	// TransientstoreMaxAge defines the maximum time private data resides inside the transient store before
+	// it is purged. Private data is not purged based on its age if zero. It is at least 10 minutes otherwise.
+	TransientstoreMaxAge time.Duration
 	// SkipPullingInvalidTransactionsDuringCommit is a flag that indicates whether pulling of invalid
 	// transaction's private data from other peers need to be skipped during the commit time and pulled
 	// only through reconciler.
@@ -75,6 +84,9 @@ func (c *ServiceConfig) loadGossipConfig() {
 	c.ElectionMembershipSampleInterval = util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", election.DefMembershipSampleInterval)
 	c.ElectionLeaderAliveThreshold = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold)
 	c.ElectionLeaderElectionDuration = util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration)
+	if weight := viper.GetInt("peer.gossip.election.weight"); weight > 0 {
+		c.ElectionWeight = uint32(weight)
+	}
 
 	c.PvtDataPushAckTimeout = viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	c.PvtDataPullRetryThreshold = viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold")
@@ -93,4 +105,11 @@ func (c *ServiceConfig) loadGossipConfig() {
 		logger.Warning("Configuration key peer.gossip.pvtData.transientstoreMaxBlockRetention isn't set, defaulting to", transientBlockRetentionDefault)
 		c.TransientstoreMaxBlockRetention = transientBlockRetentionDefault
 	}
+	c.TransientstoreMaxAge = viper.GetDuration("peer.gossip.pvtData.transientstoreMaxAge")
+	if c.TransientstoreMaxAge > 0 && c.TransientstoreMaxAge < transientMaxAgeMin {
+		logger.Warningf("Configuration key peer.gossip.pvtData.transientstoreMaxAge is set to %s, which would purge "+
+			"the private data of transactions that are not committed yet, using the minimum of %s instead",
+			c.TransientstoreMaxAge, transientMaxAgeMin)
+		c.TransientstoreMaxAge = transientMaxAgeMin
+	}
 }
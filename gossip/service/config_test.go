@@ -28,8 +28,10 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.orgLeader", true)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "10m")
 	viper.Set("peer.gossip.election.leaderElectionDuration", "5s")
+	viper.Set("peer.gossip.election.weight", 3)
 	viper.Set("peer.gossip.pvtData.btlPullMargin", 15)
 	viper.Set("peer.gossip.pvtData.transientstoreMaxBlockRetention", 1000)
+	viper.Set("peer.gossip.pvtData.transientstoreMaxAge", "24h")
 	viper.Set("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit", false)
 
 	coreConfig := service.GlobalConfig()
@@ -46,10 +48,26 @@ func TestGlobalConfig(t *testing.T) {
 		ElectionLeaderElectionDuration:             5 * time.Second,
 		ElectionStartupGracePeriod:                 election.DefStartupGracePeriod,
 		ElectionMembershipSampleInterval:           election.DefMembershipSampleInterval,
+		ElectionWeight:                             3,
 		BtlPullMargin:                              15,
 		TransientstoreMaxBlockRetention:            uint64(1000),
+		TransientstoreMaxAge:                       24 * time.Hour,
 		SkipPullingInvalidTransactionsDuringCommit: false,
 	}
 
 	require.Equal(t, coreConfig, expectedConfig)
 }
+
+func TestGlobalConfigTransientstoreMaxAge(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("peer.gossip.pvtData.transientstoreMaxAge", "0s")
+	require.Zero(t, service.GlobalConfig().TransientstoreMaxAge)
+
+	// Ages below the minimum would purge the private data of transactions being ordered
+	viper.Set("peer.gossip.pvtData.transientstoreMaxAge", "2s")
+	require.Equal(t, 10*time.Minute, service.GlobalConfig().TransientstoreMaxAge)
+
+	viper.Set("peer.gossip.pvtData.transientstoreMaxAge", "72h")
+	require.Equal(t, 72*time.Hour, service.GlobalConfig().TransientstoreMaxAge)
+}
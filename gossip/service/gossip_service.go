@@ -8,6 +8,7 @@ package service
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	gproto "github.com/hyperledger/fabric-protos-go/gossip"
@@ -56,6 +57,12 @@ type gossipSvc interface {
 	// GetPeers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
 
+	// DeadPeers returns the NetworkMembers considered dead
+	DeadPeers() []discovery.NetworkMember
+
+	// Connections returns the state of the connections to remote peers
+	Connections() []comm.ConnectionState
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChannelID) []discovery.NetworkMember
@@ -173,6 +180,7 @@ type GossipService struct {
 	serviceConfig     *ServiceConfig
 	privdataConfig    *gossipprivdata.PrivdataConfig
 	anchorPeerTracker *anchorPeerTracker
+	bootstrapPeers    []string
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -214,6 +222,18 @@ func (t *anchorPeerTracker) update(channelName string, endpoints map[string]stru
 	t.allEndpoints[channelName] = endpoints
 }
 
+// anchorPeersOf returns the anchor peer endpoints of the channel
+func (t *anchorPeerTracker) anchorPeersOf(channelName string) []string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	endpoints := []string{}
+	for endpoint := range t.allEndpoints[channelName] {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
 // IsAnchorPeer checks if an endpoint is an anchor peer in any channel
 func (t *anchorPeerTracker) IsAnchorPeer(endpoint string) bool {
 	t.mutex.RLock()
@@ -262,6 +282,11 @@ func New(
 		anchorPeerTracker,
 	)
 
+	if serviceConfig.UseLeaderElection && serviceConfig.ElectionWeight > 0 {
+		logger.Infof("Publishing leader election weight %d", serviceConfig.ElectionWeight)
+		gossipComponent.UpdateMetadata(election.Metadata{Weight: serviceConfig.ElectionWeight}.Bytes())
+	}
+
 	return &GossipService{
 		gossipSvc:       gossipComponent,
 		mcs:             mcs,
@@ -280,6 +305,7 @@ func New(
 		serviceConfig:     serviceConfig,
 		privdataConfig:    privdataConfig,
 		anchorPeerTracker: anchorPeerTracker,
+		bootstrapPeers:    gossipConfig.BootstrapPeers,
 	}, nil
 }
 
@@ -337,6 +363,7 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 
 	coordinatorConfig := gossipprivdata.CoordinatorConfig{
 		TransientBlockRetention:        g.serviceConfig.TransientstoreMaxBlockRetention,
+		TransientMaxAge:                g.serviceConfig.TransientstoreMaxAge,
 		PullRetryThreshold:             g.serviceConfig.PvtDataPullRetryThreshold,
 		SkipPullingInvalidTransactions: g.serviceConfig.SkipPullingInvalidTransactionsDuringCommit,
 	}
@@ -464,6 +491,17 @@ func (g *GossipService) AddPayload(channelID string, payload *gproto.Payload) er
 	return g.chains[channelID].AddPayload(payload)
 }
 
+// PvtDataReconciler returns the private data reconciler of the given channel, or nil if the peer hasn't joined it
+func (g *GossipService) PvtDataReconciler(channelID string) gossipprivdata.PvtDataReconciler {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	handler, exists := g.privateHandlers[channelID]
+	if !exists {
+		return nil
+	}
+	return handler.reconciler
+}
+
 // Stop stops the gossip component
 func (g *GossipService) Stop() {
 	g.lock.Lock()
@@ -485,6 +523,27 @@ func (g *GossipService) Stop() {
 	g.gossipSvc.Stop()
 }
 
+// YieldLeadership makes the peer relinquish its leadership in the given channel,
+// so that another peer of its organization takes over pulling blocks from the ordering service.
+func (g *GossipService) YieldLeadership(channelID string) error {
+	g.lock.RLock()
+	_, joined := g.chains[channelID]
+	le, exists := g.leaderElection[channelID]
+	g.lock.RUnlock()
+	if !joined {
+		return errors.Errorf("channel %s doesn't exist", channelID)
+	}
+	if !exists {
+		return errors.Errorf("leader election is disabled for channel %s", channelID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer is not the leader of channel %s", channelID)
+	}
+	logger.Infof("Yielding leadership of channel %s", channelID)
+	le.Yield()
+	return nil
+}
+
 func (g *GossipService) newLeaderElectionComponent(channelID string, callback func(bool),
 	electionMetrics *gossipmetrics.ElectionMetrics) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
@@ -494,6 +553,7 @@ func (g *GossipService) newLeaderElectionComponent(channelID string, callback fu
 		MembershipSampleInterval: g.serviceConfig.ElectionMembershipSampleInterval,
 		LeaderAliveThreshold:     g.serviceConfig.ElectionLeaderAliveThreshold,
 		LeaderElectionDuration:   g.serviceConfig.ElectionLeaderElectionDuration,
+		Weight:                   g.serviceConfig.ElectionWeight,
 	}
 	return election.NewLeaderElectionService(adapter, string(PKIid), callback, config)
 }
@@ -8,6 +8,7 @@ package service
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -220,6 +221,45 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 
 	require.Equal(t, 1, startsNum, "Only for one peer delivery client should start")
 
+	// All the peers report the elected leader and the membership of the channel
+	var leaderPKIID string
+	for i := 0; i < n; i++ {
+		if services[i].IsLeader() {
+			leaderPKIID = hex.EncodeToString(gossips[i].SelfMembershipInfo().PKIid)
+		}
+	}
+	for i := 0; i < n; i++ {
+		report := gossips[i].Introspect()
+		require.Equal(t, hex.EncodeToString(gossips[i].SelfMembershipInfo().PKIid), report.Self.PKIID)
+		require.Len(t, report.BootstrapPeers, 5)
+		require.Len(t, report.AliveMembers, n-1)
+		require.Empty(t, report.DeadMembers)
+		require.Len(t, report.Channels, 1)
+		channel := report.Channel(channelName)
+		require.NotNil(t, channel)
+		require.Len(t, channel.Peers, n-1)
+		require.False(t, channel.StaticLeader)
+		require.Equal(t, services[i].IsLeader(), channel.IsLeader)
+		require.Eventually(t, func() bool {
+			return gossips[i].Introspect().Channel(channelName).Leader == leaderPKIID
+		}, time.Second*30, time.Millisecond*500, "peer %d should report the elected leader", i)
+	}
+
+	// Only the leader can yield its leadership
+	for i := 0; i < n; i++ {
+		require.EqualError(t, gossips[i].YieldLeadership("chanB"), "channel chanB doesn't exist")
+		if !services[i].IsLeader() {
+			require.EqualError(t, gossips[i].YieldLeadership(channelName), "peer is not the leader of channel chanA")
+		}
+	}
+	for i := 0; i < n; i++ {
+		if services[i].IsLeader() {
+			require.NoError(t, gossips[i].YieldLeadership(channelName))
+			require.False(t, services[i].IsLeader())
+			require.False(t, gossips[i].deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer should be stopped once the leader yields")
+		}
+	}
+
 	stopPeers(gossips)
 }
 
@@ -269,6 +309,7 @@ func TestWithStaticDeliverClientLeader(t *testing.T) {
 	for i := 0; i < n; i++ {
 		require.NotNil(t, gossips[i].deliveryService[channelName], "Delivery service for channel %s not initiated in peer %d", channelName, i)
 		require.True(t, gossips[i].deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer not started for peer %d", i)
+		require.EqualError(t, gossips[i].YieldLeadership(channelName), "leader election is disabled for channel chanA")
 	}
 
 	channelName = "chanB"
@@ -774,11 +815,13 @@ func newGossipInstance(serviceConfig *ServiceConfig, port int, id int, gRPCServe
 		deliveryFactory: &deliveryFactoryImpl{
 			credentialSupport: comm.NewCredentialSupport(),
 		},
-		peerIdentity:   api.PeerIdentityType(conf.InternalEndpoint),
-		secAdv:         secAdv,
-		metrics:        metrics,
-		serviceConfig:  serviceConfig,
-		privdataConfig: privdata.GlobalConfig(),
+		peerIdentity:      api.PeerIdentityType(conf.InternalEndpoint),
+		secAdv:            secAdv,
+		metrics:           metrics,
+		serviceConfig:     serviceConfig,
+		privdataConfig:    privdata.GlobalConfig(),
+		anchorPeerTracker: &anchorPeerTracker{allEndpoints: map[string]map[string]struct{}{}},
+		bootstrapPeers:    conf.BootstrapPeers,
 	}
 
 	return &gossipGRPC{GossipService: gossipService, grpc: gRPCServer}
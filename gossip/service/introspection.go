@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/hex"
+	"sort"
+
+	gproto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/introspection"
+)
+
+// Introspect returns a snapshot of the gossip membership and topology, as seen by this peer
+func (g *GossipService) Introspect() *introspection.Report {
+	orgs := map[string]string{}
+	for _, identity := range g.IdentityInfo() {
+		orgs[string(identity.PKIId)] = string(identity.Organization)
+	}
+	self := g.SelfMembershipInfo()
+	orgs[string(self.PKIid)] = string(g.secAdv.OrgByPeerIdentity(g.peerIdentity))
+
+	report := &introspection.Report{
+		Self:           newMember(self, orgs),
+		BootstrapPeers: append([]string{}, g.bootstrapPeers...),
+		AliveMembers:   newMembers(g.Peers(), orgs),
+		DeadMembers:    newMembers(g.DeadPeers(), orgs),
+		Connections:    []introspection.Connection{},
+		Channels:       []introspection.Channel{},
+	}
+	for _, conn := range g.Connections() {
+		report.Connections = append(report.Connections, introspection.Connection{
+			Endpoint:        conn.Endpoint,
+			PKIID:           hex.EncodeToString(conn.PKIID),
+			Outbound:        conn.Outbound,
+			PendingMessages: conn.PendingMessages,
+		})
+	}
+	sort.Slice(report.Connections, func(i, j int) bool {
+		return report.Connections[i].PKIID < report.Connections[j].PKIID
+	})
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	for channelID := range g.chains {
+		channel := introspection.Channel{
+			Name:        channelID,
+			Self:        introspection.ChannelMember{Member: newMember(self, orgs)},
+			Peers:       []introspection.ChannelMember{},
+			AnchorPeers: g.anchorPeerTracker.anchorPeersOf(channelID),
+		}
+		if stateInfo := g.SelfChannelInfo(common.ChannelID(channelID)); stateInfo != nil {
+			channel.Self = newChannelMember(self, stateInfo.GetStateInfo().GetProperties(), orgs)
+		}
+		for _, member := range g.PeersOfChannel(common.ChannelID(channelID)) {
+			channel.Peers = append(channel.Peers, newChannelMember(member, member.Properties, orgs))
+		}
+		sort.Slice(channel.Peers, func(i, j int) bool {
+			return channel.Peers[i].PKIID < channel.Peers[j].PKIID
+		})
+
+		if le, exists := g.leaderElection[channelID]; exists {
+			channel.Leader = hex.EncodeToString(le.Leader())
+			channel.IsLeader = le.IsLeader()
+		} else {
+			channel.StaticLeader = true
+			if g.serviceConfig.OrgLeader {
+				channel.Leader = hex.EncodeToString(self.PKIid)
+				channel.IsLeader = true
+			}
+		}
+		report.Channels = append(report.Channels, channel)
+	}
+	sort.Slice(report.Channels, func(i, j int) bool {
+		return report.Channels[i].Name < report.Channels[j].Name
+	})
+
+	return report
+}
+
+func newMember(member discovery.NetworkMember, orgs map[string]string) introspection.Member {
+	return introspection.Member{
+		Endpoint:         member.Endpoint,
+		InternalEndpoint: member.InternalEndpoint,
+		PKIID:            hex.EncodeToString(member.PKIid),
+		MSPID:            orgs[string(member.PKIid)],
+	}
+}
+
+func newMembers(members []discovery.NetworkMember, orgs map[string]string) []introspection.Member {
+	res := []introspection.Member{}
+	for _, member := range members {
+		res = append(res, newMember(member, orgs))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].PKIID < res[j].PKIID
+	})
+	return res
+}
+
+func newChannelMember(member discovery.NetworkMember, properties *gproto.Properties, orgs map[string]string) introspection.ChannelMember {
+	channelMember := introspection.ChannelMember{
+		Member:       newMember(member, orgs),
+		LedgerHeight: properties.GetLedgerHeight(),
+		LeftChannel:  properties.GetLeftChannel(),
+	}
+	for _, cc := range properties.GetChaincodes() {
+		channelMember.Chaincodes = append(channelMember.Chaincodes, introspection.Chaincode{
+			Name:    cc.Name,
+			Version: cc.Version,
+		})
+	}
+	return channelMember
+}
@@ -20,6 +20,7 @@ const (
 	DefStateBlockBufferSize = 20
 	DefStateChannelSize     = 100
 	DefStateEnabled         = false
+	DefStateParallelism     = 4
 )
 
 type StateConfig struct {
@@ -30,6 +31,7 @@ type StateConfig struct {
 	StateBlockBufferSize int
 	StateChannelSize     int
 	StateEnabled         bool
+	StateParallelism     int
 	UseLeaderElection    bool
 	OrgLeader            bool
 }
@@ -69,8 +71,20 @@ func (c *StateConfig) loadStateConfig() {
 	if viper.IsSet("peer.gossip.state.enabled") {
 		c.StateEnabled = viper.GetBool("peer.gossip.state.enabled")
 	}
+	c.StateParallelism = DefStateParallelism
+	if viper.IsSet("peer.gossip.state.parallelism") {
+		c.StateParallelism = viper.GetInt("peer.gossip.state.parallelism")
+	}
 	// The below two configuration parameters are used for straggler() which warns
 	// if our peer is lagging behind the rest and has no way to catch up.
 	c.UseLeaderElection = viper.GetBool("peer.gossip.useLeaderElection")
 	c.OrgLeader = viper.GetBool("peer.gossip.orgLeader")
 }
+
+// parallelism returns the maximum number of peers that blocks are requested from concurrently
+func (c *StateConfig) parallelism() int {
+	if c.StateParallelism < 1 {
+		return 1
+	}
+	return c.StateParallelism
+}
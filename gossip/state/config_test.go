@@ -24,6 +24,7 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.state.blockBufferSize", 5)
 	viper.Set("peer.gossip.state.channelSize", 6)
 	viper.Set("peer.gossip.state.enabled", true)
+	viper.Set("peer.gossip.state.parallelism", 7)
 
 	coreConfig := state.GlobalConfig()
 
@@ -35,6 +36,7 @@ func TestGlobalConfig(t *testing.T) {
 		StateBlockBufferSize: 5,
 		StateChannelSize:     6,
 		StateEnabled:         true,
+		StateParallelism:     7,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -53,6 +55,7 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		StateBlockBufferSize: 20,
 		StateChannelSize:     100,
 		StateEnabled:         false,
+		StateParallelism:     4,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
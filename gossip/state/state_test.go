@@ -431,6 +431,7 @@ func newPeerNodeWithGossipWithValidatorWithMetrics(logger gossiputil.Logger, id
 		StateMaxRetries:      DefStateMaxRetries,
 		StateBlockBufferSize: DefStateBlockBufferSize,
 		StateChannelSize:     DefStateChannelSize,
+		StateParallelism:     DefStateParallelism,
 		StateEnabled:         true,
 	}
 	sp := NewGossipStateProvider(logger, "testchannelid", servicesAdapater, coord, gossipMetrics.StateMetrics, blocking, stateConfig)
@@ -661,6 +662,84 @@ func TestLargeBlockGap(t *testing.T) {
 	}
 }
 
+func TestMultiSourceBlockGap(t *testing.T) {
+	// Scenario: the peer knows of several peers with ledger heights much higher
+	// than its own. The missing blocks should be requested from all of them, without
+	// requesting from a peer blocks it doesn't have, and be committed in order.
+	mc := &mockCommitter{Mock: &mock.Mock{}}
+	blocksPassedToLedger := make(chan uint64, 200)
+	mc.On("CommitLegacy", mock.Anything).Run(func(arg mock.Arguments) {
+		blocksPassedToLedger <- arg.Get(0).(*pcomm.Block).Header.Number
+	})
+	msgsFromPeer := make(chan protoext.ReceivedMessage)
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+	mc.On("DoesPvtDataInfoExistInLedger", mock.Anything).Return(false, nil)
+	g := &mocks.GossipMock{}
+	heights := map[string]uint64{"a": 200, "b": 200, "c": 50}
+	var membership []discovery.NetworkMember
+	for endpoint, height := range heights {
+		membership = append(membership, discovery.NetworkMember{
+			PKIid:    common.PKIidType(endpoint),
+			Endpoint: endpoint,
+			Properties: &proto.Properties{
+				LedgerHeight: height,
+			},
+		})
+	}
+	var lock sync.Mutex
+	requestsPerPeer := map[string]int{}
+	var invalidRequests []string
+	g.On("PeersOfChannel", mock.Anything).Return(membership)
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, msgsFromPeer)
+	g.On("Send", mock.Anything, mock.Anything).Run(func(arguments mock.Arguments) {
+		msg := arguments.Get(0).(*proto.GossipMessage)
+		peer := arguments.Get(1).([]*comm.RemotePeer)[0]
+		req := msg.GetStateRequest()
+		lock.Lock()
+		requestsPerPeer[peer.Endpoint]++
+		if req.EndSeqNum >= heights[peer.Endpoint] || req.EndSeqNum-req.StartSeqNum >= DefStateBatchSize {
+			invalidRequests = append(invalidRequests, fmt.Sprintf("[%d...%d] from %s", req.StartSeqNum, req.EndSeqNum, peer.Endpoint))
+		}
+		lock.Unlock()
+
+		res := &proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Channel: []byte("testchannelid"),
+			Content: &proto.GossipMessage_StateResponse{
+				StateResponse: &proto.RemoteStateResponse{},
+			},
+		}
+		for seq := req.StartSeqNum; seq <= req.EndSeqNum; seq++ {
+			rawblock := protoutil.NewBlock(seq, []byte{})
+			b, _ := pb.Marshal(rawblock)
+			res.GetStateResponse().Payloads = append(res.GetStateResponse().Payloads, &proto.Payload{
+				SeqNum: seq,
+				Data:   b,
+			})
+		}
+		sMsg, _ := protoext.NoopSign(res)
+		msgsFromPeer <- &comm.ReceivedMessageImpl{
+			SignedGossipMessage: sMsg,
+		}
+	})
+	p := newPeerNodeWithGossip(0, mc, noopPeerIdentityAcceptor, g)
+	defer p.shutdown()
+
+	for expectedSequence := 1; expectedSequence < 200; expectedSequence++ {
+		blockSeq := <-blocksPassedToLedger
+		require.Equal(t, expectedSequence, int(blockSeq))
+		require.True(t, p.s.payloads.Size() <= defMaxBlockDistance*2+defAntiEntropyBatchSize, "payload buffer size is %d", p.s.payloads.Size())
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Empty(t, invalidRequests)
+	require.NotZero(t, requestsPerPeer["a"])
+	require.NotZero(t, requestsPerPeer["b"])
+	require.NotZero(t, requestsPerPeer["c"])
+}
+
 func TestOverPopulation(t *testing.T) {
 	// Scenario: Add to the state provider blocks
 	// with a gap in between, and ensure that the payload buffer
@@ -1449,6 +1528,7 @@ func TestTransferOfPrivateRWSet(t *testing.T) {
 		StateMaxRetries:      DefStateMaxRetries,
 		StateBlockBufferSize: DefStateBlockBufferSize,
 		StateChannelSize:     DefStateChannelSize,
+		StateParallelism:     DefStateParallelism,
 		StateEnabled:         true,
 	}
 	logger := flogging.MustGetLogger(gossiputil.StateLogger)
@@ -1695,6 +1775,7 @@ func TestTransferOfPvtDataBetweenPeers(t *testing.T) {
 		StateMaxRetries:      DefStateMaxRetries,
 		StateBlockBufferSize: DefStateBlockBufferSize,
 		StateChannelSize:     DefStateChannelSize,
+		StateParallelism:     DefStateParallelism,
 		StateEnabled:         true,
 	}
 	logger := flogging.MustGetLogger(gossiputil.StateLogger)
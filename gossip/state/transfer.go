@@ -0,0 +1,362 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/pkg/errors"
+)
+
+// throughputSmoothing is the weight of the latest observation in the throughput estimate of a source
+const throughputSmoothing = 0.5
+
+// blockRange is a range of block sequence numbers [start...end] to be requested via state transfer
+type blockRange struct {
+	start    uint64
+	end      uint64
+	failures int
+}
+
+// blockSource tracks a peer that blocks are requested from during state transfer
+type blockSource struct {
+	peer       *comm.RemotePeer
+	height     uint64
+	batchSize  uint64
+	throughput float64 // blocks per second
+	tried      bool
+	busy       bool
+}
+
+// transferResult is the outcome of a single state request sent to a source
+type transferResult struct {
+	source  *blockSource
+	request *blockRange
+	lastSeq uint64
+	elapsed time.Duration
+	err     error
+}
+
+// stateTransfer fetches a range of missing blocks by splitting it across several sources. Requests are sent
+// concurrently to distinct sources and their responses are pushed into the payloads buffer as they arrive, so that
+// the blocks are committed while the following ones are still being fetched. The number of blocks requested ahead of
+// the next block to be committed is bounded by the capacity of the payloads buffer.
+type stateTransfer struct {
+	s *GossipStateProviderImpl
+
+	next    uint64
+	end     uint64
+	retries []*blockRange
+
+	sources  map[string]*blockSource
+	inFlight int
+	results  chan *transferResult
+
+	lock    sync.Mutex
+	waiters map[uint64]chan protoext.ReceivedMessage
+	done    chan struct{}
+}
+
+func newStateTransfer(s *GossipStateProviderImpl, start uint64, end uint64) *stateTransfer {
+	return &stateTransfer{
+		s:       s,
+		next:    start,
+		end:     end,
+		sources: map[string]*blockSource{},
+		results: make(chan *transferResult, s.config.parallelism()),
+		waiters: map[uint64]chan protoext.ReceivedMessage{},
+		done:    make(chan struct{}),
+	}
+}
+
+// run fetches the blocks in range until all of them are received, a range exceeds the maximum number of retries,
+// no source is available, or the state provider is stopped.
+func (t *stateTransfer) run() {
+	defer close(t.done)
+	go t.dispatchResponses()
+
+	for t.hasPendingWork() || t.inFlight > 0 {
+		if err := t.schedule(); err != nil {
+			if t.inFlight == 0 {
+				t.s.logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v", t.pendingStart(), t.end, err)
+				return
+			}
+		}
+
+		if t.inFlight == 0 {
+			// The payloads buffer is full, wait for blocks to be committed
+			select {
+			case <-t.s.stopCh:
+				return
+			case <-time.After(enqueueRetryInterval):
+			}
+			continue
+		}
+
+		select {
+		case <-t.s.stopCh:
+			return
+		case res := <-t.results:
+			t.inFlight--
+			if !t.processResult(res) {
+				return
+			}
+		}
+	}
+}
+
+func (t *stateTransfer) hasPendingWork() bool {
+	return len(t.retries) > 0 || t.next <= t.end
+}
+
+func (t *stateTransfer) pendingStart() uint64 {
+	if len(t.retries) > 0 {
+		return t.retries[0].start
+	}
+	return t.next
+}
+
+// schedule sends requests to idle sources until the maximum parallelism is reached, or there are no more blocks that
+// can be requested yet
+func (t *stateTransfer) schedule() error {
+	t.refreshSources()
+
+	for t.inFlight < t.s.config.parallelism() {
+		nextToCommit := t.s.payloads.Next()
+		t.skipReceived(nextToCommit)
+		if !t.hasPendingWork() {
+			return nil
+		}
+		start := t.pendingStart()
+		limit := nextToCommit + 2*uint64(t.s.config.StateBlockBufferSize) - 1
+		if start > limit {
+			return nil
+		}
+
+		source := t.selectSource(start)
+		if source == nil {
+			if t.inFlight == 0 {
+				return errors.New("there are no peers to ask for missing blocks from")
+			}
+			return nil
+		}
+
+		r := t.takeRange(min(min(t.end, limit), source.height-1), source.batchSize)
+		source.busy = true
+		t.inFlight++
+
+		t.s.logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], for chainID %s",
+			source.peer.Endpoint, r.start, r.end, t.s.chainID)
+		go t.fetch(source, r)
+	}
+	return nil
+}
+
+// refreshSources updates the sources from the current membership of the channel
+func (t *stateTransfer) refreshSources() {
+	for _, member := range t.s.mediator.PeersOfChannel(common2.ChannelID(t.s.chainID)) {
+		if member.Properties == nil {
+			continue
+		}
+		key := string(member.PKIid)
+		source, exists := t.sources[key]
+		if !exists {
+			source = &blockSource{
+				peer:      &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid},
+				batchSize: t.s.config.StateBatchSize,
+			}
+			t.sources[key] = source
+		}
+		source.height = member.Properties.LedgerHeight
+	}
+}
+
+// skipReceived drops the parts of pending ranges that are already delivered for commit
+func (t *stateTransfer) skipReceived(nextToCommit uint64) {
+	retries := t.retries[:0]
+	for _, r := range t.retries {
+		if r.end < nextToCommit {
+			continue
+		}
+		if r.start < nextToCommit {
+			r.start = nextToCommit
+		}
+		retries = append(retries, r)
+	}
+	t.retries = retries
+	if t.next < nextToCommit {
+		t.next = nextToCommit
+	}
+}
+
+// selectSource returns the idle source which has the block with the given sequence number and the highest
+// observed throughput. Sources which haven't been tried yet are preferred, so that all of them get measured.
+func (t *stateTransfer) selectSource(seqNum uint64) *blockSource {
+	var candidates []*blockSource
+	for _, source := range t.sources {
+		if !source.busy && source.height > seqNum {
+			candidates = append(candidates, source)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].tried != candidates[j].tried {
+			return !candidates[i].tried
+		}
+		return candidates[i].throughput > candidates[j].throughput
+	})
+	return candidates[0]
+}
+
+// takeRange removes from the pending work the next range of at most batchSize blocks, not beyond maxSeq
+func (t *stateTransfer) takeRange(maxSeq uint64, batchSize uint64) *blockRange {
+	if len(t.retries) > 0 {
+		r := t.retries[0]
+		end := min(min(r.end, maxSeq), r.start+batchSize-1)
+		if end == r.end {
+			t.retries = t.retries[1:]
+			return r
+		}
+		taken := &blockRange{start: r.start, end: end, failures: r.failures}
+		r.start = end + 1
+		return taken
+	}
+
+	r := &blockRange{start: t.next, end: min(maxSeq, t.next+batchSize-1)}
+	t.next = r.end + 1
+	return r
+}
+
+// fetch sends a state request for the given range to the source and waits for the response
+func (t *stateTransfer) fetch(source *blockSource, r *blockRange) {
+	gossipMsg := t.s.stateRequestMessage(r.start, r.end)
+	responses := t.register(gossipMsg.Nonce)
+	defer t.unregister(gossipMsg.Nonce)
+
+	res := &transferResult{source: source, request: r}
+	start := time.Now()
+	t.s.mediator.Send(gossipMsg, source.peer)
+
+	select {
+	case msg := <-responses:
+		res.lastSeq, res.err = t.s.handleStateResponse(msg)
+	case <-time.After(t.s.config.StateResponseTimeout):
+		res.err = errors.Errorf("timed out waiting for a response from %s", source.peer.Endpoint)
+	case <-t.done:
+		return
+	}
+	res.elapsed = time.Since(start)
+
+	select {
+	case t.results <- res:
+	case <-t.done:
+	}
+}
+
+// processResult updates the source of the result and the pending work, and returns false if the state transfer
+// has to be aborted
+func (t *stateTransfer) processResult(res *transferResult) bool {
+	source, r := res.source, res.request
+	source.busy = false
+	source.tried = true
+
+	if res.err != nil || res.lastSeq < r.start {
+		source.batchSize = max(1, source.batchSize/2)
+		source.throughput *= 1 - throughputSmoothing
+		r.failures++
+		if r.failures > t.s.config.StateMaxRetries {
+			t.s.logger.Warningf("Wasn't able to get blocks in range [%d...%d], after %d retries", r.start, r.end, r.failures)
+			return false
+		}
+		if res.err != nil {
+			t.s.logger.Warningf("Wasn't able to process state response for blocks [%d...%d] from %s, due to %+v",
+				r.start, r.end, source.peer.Endpoint, errors.WithStack(res.err))
+		}
+		t.retries = append([]*blockRange{r}, t.retries...)
+		return true
+	}
+
+	received := min(res.lastSeq, r.end) - r.start + 1
+	throughput := float64(received) / res.elapsed.Seconds()
+	if source.throughput == 0 {
+		source.throughput = throughput
+	} else {
+		source.throughput = throughputSmoothing*throughput + (1-throughputSmoothing)*source.throughput
+	}
+	t.s.stateMetrics.TransferredBlocks.With("channel", t.s.chainID, "source", source.peer.Endpoint).Add(float64(received))
+	t.s.stateMetrics.SourceThroughput.With("channel", t.s.chainID, "source", source.peer.Endpoint).Set(source.throughput)
+
+	// Aim for responses to arrive well within the timeout: grow the batches of sources that respond quickly,
+	// and shrink the batches of the slow ones.
+	if res.elapsed < t.s.config.StateResponseTimeout/4 {
+		source.batchSize = min(t.s.config.StateBatchSize, source.batchSize*2)
+	} else if res.elapsed > t.s.config.StateResponseTimeout/2 {
+		source.batchSize = max(1, source.batchSize/2)
+	}
+
+	if res.lastSeq < r.end {
+		t.retries = append([]*blockRange{{start: res.lastSeq + 1, end: r.end}}, t.retries...)
+	}
+	return true
+}
+
+func (t *stateTransfer) register(nonce uint64) <-chan protoext.ReceivedMessage {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	responses := make(chan protoext.ReceivedMessage, 1)
+	t.waiters[nonce] = responses
+	return responses
+}
+
+func (t *stateTransfer) unregister(nonce uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.waiters, nonce)
+}
+
+// dispatchResponses routes the state responses to the requests they correspond to
+func (t *stateTransfer) dispatchResponses() {
+	for {
+		select {
+		case <-t.done:
+			return
+		case msg, stillOpen := <-t.s.stateResponseCh:
+			if !stillOpen {
+				return
+			}
+			t.lock.Lock()
+			responses, exists := t.waiters[msg.GetGossipMessage().Nonce]
+			t.lock.Unlock()
+			if !exists {
+				continue
+			}
+			select {
+			case responses <- msg:
+			default:
+			}
+		}
+	}
+}
+
+func max(a uint64, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
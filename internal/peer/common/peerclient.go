@@ -13,6 +13,9 @@ import (
 	"time"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatagrpc"
+	"github.com/hyperledger/fabric/core/transientstore/transientstoregrpc"
+	"github.com/hyperledger/fabric/gossip/privdata/reconcilegrpc"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -203,6 +206,69 @@ func GetSnapshotClient(address, tlsRootCertFile string) (pb.SnapshotClient, erro
 	return peerClient.SnapshotClient()
 }
 
+// ReconciliationClient returns a client for the private data reconciliation service
+func (pc *PeerClient) ReconciliationClient() (reconcilegrpc.ReconciliationClient, error) {
+	conn, err := pc.CommonClient.clientConfig.Dial(pc.address)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "reconciliation client failed to connect to %s", pc.address)
+	}
+	return reconcilegrpc.NewReconciliationClient(conn), nil
+}
+
+// GetReconciliationClient returns a new private data reconciliation client. If
+// both the address and tlsRootCertFile are not provided, the target values for
+// the client are taken from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetReconciliationClient(address, tlsRootCertFile string) (reconcilegrpc.ReconciliationClient, error) {
+	peerClient, err := newPeerClient(address, tlsRootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.ReconciliationClient()
+}
+
+// PvtDataClient returns a client for the private data export service
+func (pc *PeerClient) PvtDataClient() (pvtdatagrpc.PvtDataClient, error) {
+	conn, err := pc.CommonClient.clientConfig.Dial(pc.address)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "private data client failed to connect to %s", pc.address)
+	}
+	return pvtdatagrpc.NewPvtDataClient(conn), nil
+}
+
+// GetPvtDataClient returns a new private data export client. If both the
+// address and tlsRootCertFile are not provided, the target values for the
+// client are taken from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetPvtDataClient(address, tlsRootCertFile string) (pvtdatagrpc.PvtDataClient, error) {
+	peerClient, err := newPeerClient(address, tlsRootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.PvtDataClient()
+}
+
+// TransientStoreClient returns a client for the transient store service
+func (pc *PeerClient) TransientStoreClient() (transientstoregrpc.TransientStoreClient, error) {
+	conn, err := pc.CommonClient.clientConfig.Dial(pc.address)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "transient store client failed to connect to %s", pc.address)
+	}
+	return transientstoregrpc.NewTransientStoreClient(conn), nil
+}
+
+// GetTransientStoreClient returns a new transient store client. If both the
+// address and tlsRootCertFile are not provided, the target values for the
+// client are taken from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetTransientStoreClient(address, tlsRootCertFile string) (transientstoregrpc.TransientStoreClient, error) {
+	peerClient, err := newPeerClient(address, tlsRootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.TransientStoreClient()
+}
+
 func newPeerClient(address, tlsRootCertFile string) (*PeerClient, error) {
 	if address != "" {
 		return NewPeerClientForAddress(address, tlsRootCertFile)
@@ -43,6 +43,7 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 	chaincodeCmd.AddCommand(CheckCommitReadinessCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(CommitCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryCommittedCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(RollbackCmd(nil, cryptoProvider))
 
 	return chaincodeCmd
 }
@@ -69,14 +70,17 @@ var (
 	packageID             string
 	sequence              int
 	initRequired          bool
+	rollbackSequence      int
+	rollbackCommit        bool
+	history               bool
 	output                string
 	outputDirectory       string
 )
 
 var chaincodeCmd = &cobra.Command{
 	Use:   "chaincode",
-	Short: "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
-	Long:  "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
+	Short: "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted|rollback",
+	Long:  "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|calculatepackageid|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted|rollback",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -118,6 +122,9 @@ func ResetFlags() {
 	flags.StringVarP(&packageID, "package-id", "", "", "The identifier of the chaincode install package")
 	flags.IntVarP(&sequence, "sequence", "", 0, "The sequence number of the chaincode definition for the channel")
 	flags.BoolVarP(&initRequired, "init-required", "", false, "Whether the chaincode requires invoking 'init'")
+	flags.IntVarP(&rollbackSequence, "rollback-sequence", "", 0, "The sequence number of the committed chaincode definition to roll back to")
+	flags.BoolVarP(&rollbackCommit, "commit", "", false, "Commit the rollback definition instead of approving it for my organization")
+	flags.BoolVarP(&history, "history", "", false, "Query the definitions committed for the chaincode across all sequences. Requires a chaincode name")
 	flags.StringVarP(&output, "output", "O", "", "The output format for query results. Default is human-readable plain-text. json is currently the only supported format.")
 	flags.StringVarP(&outputDirectory, "output-directory", "", "", "The output directory to use when writing a chaincode install package to disk. Default is the current working directory.")
 }
@@ -19,6 +19,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/bccsp"
+	lh "github.com/hyperledger/fabric/pkg/lifecycle/history"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -38,6 +39,7 @@ type CommittedQuerier struct {
 type CommittedQueryInput struct {
 	ChannelID    string
 	Name         string
+	History      bool
 	OutputFormat string
 }
 
@@ -48,7 +50,7 @@ func QueryCommittedCmd(c *CommittedQuerier, cryptoProvider bccsp.BCCSP) *cobra.C
 	chaincodeQueryCommittedCmd := &cobra.Command{
 		Use:   "querycommitted",
 		Short: "Query the committed chaincode definitions by channel on a peer.",
-		Long:  "Query the committed chaincode definitions by channel on a peer. Optional: provide a chaincode name to query a specific definition.",
+		Long:  "Query the committed chaincode definitions by channel on a peer. Optional: provide a chaincode name to query a specific definition, and --history to query the definitions committed for it across all sequences. The history requires the V2_5 application capability on the channel.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if c == nil {
 				ccInput := &ClientConnectionsInput{
@@ -69,6 +71,7 @@ func QueryCommittedCmd(c *CommittedQuerier, cryptoProvider bccsp.BCCSP) *cobra.C
 				cqInput := &CommittedQueryInput{
 					ChannelID:    channelID,
 					Name:         chaincodeName,
+					History:      history,
 					OutputFormat: output,
 				}
 
@@ -87,6 +90,7 @@ func QueryCommittedCmd(c *CommittedQuerier, cryptoProvider bccsp.BCCSP) *cobra.C
 	flagList := []string{
 		"channelID",
 		"name",
+		"history",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -144,6 +148,9 @@ func (c *CommittedQuerier) Query() error {
 }
 
 func (c *CommittedQuerier) printResponseAsJSON(proposalResponse *pb.ProposalResponse) error {
+	if c.Input.History {
+		return printResponseAsJSON(proposalResponse, &lh.QueryChaincodeDefinitionHistoryResult{}, c.Writer)
+	}
 	if c.Input.Name != "" {
 		return printResponseAsJSON(proposalResponse, &lb.QueryChaincodeDefinitionResult{}, c.Writer)
 	}
@@ -153,6 +160,20 @@ func (c *CommittedQuerier) printResponseAsJSON(proposalResponse *pb.ProposalResp
 // printResponse prints the information included in the response
 // from the server as human readable plain-text.
 func (c *CommittedQuerier) printResponse(proposalResponse *pb.ProposalResponse) error {
+	if c.Input.History {
+		result := &lh.QueryChaincodeDefinitionHistoryResult{}
+		err := proto.Unmarshal(proposalResponse.Response.Payload, result)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+		}
+		fmt.Fprintf(c.Writer, "Committed chaincode definition history for chaincode '%s' on channel '%s':\n", c.Input.Name, c.Input.ChannelID)
+		for _, cd := range result.ChaincodeDefinitions {
+			c.printSingleChaincodeDefinition(cd)
+			fmt.Fprintf(c.Writer, "\n")
+		}
+		return nil
+	}
+
 	if c.Input.Name != "" {
 		result := &lb.QueryChaincodeDefinitionResult{}
 		err := proto.Unmarshal(proposalResponse.Response.Payload, result)
@@ -213,6 +234,10 @@ func (c *CommittedQuerier) validateInput() error {
 		return errors.New("channel name must be specified")
 	}
 
+	if c.Input.History && c.Input.Name == "" {
+		return errors.New("chaincode name must be specified when querying the definition history")
+	}
+
 	return nil
 }
 
@@ -220,12 +245,18 @@ func (c *CommittedQuerier) createProposal() (*pb.Proposal, error) {
 	var function string
 	var args proto.Message
 
-	if c.Input.Name != "" {
+	switch {
+	case c.Input.History:
+		function = "QueryChaincodeDefinitionHistory"
+		args = &lh.QueryChaincodeDefinitionHistoryArgs{
+			Name: c.Input.Name,
+		}
+	case c.Input.Name != "":
 		function = "QueryChaincodeDefinition"
 		args = &lb.QueryChaincodeDefinitionArgs{
 			Name: c.Input.Name,
 		}
-	} else {
+	default:
 		function = "QueryChaincodeDefinitions"
 		args = &lb.QueryChaincodeDefinitionsArgs{}
 	}
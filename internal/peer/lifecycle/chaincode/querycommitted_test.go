@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	lh "github.com/hyperledger/fabric/pkg/lifecycle/history"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -197,6 +198,82 @@ var _ = Describe("QueryCommitted", func() {
 			})
 		})
 
+		Context("when the definition history is requested", func() {
+			BeforeEach(func() {
+				input.Name = "test-cc"
+				input.History = true
+
+				mockResult := &lh.QueryChaincodeDefinitionHistoryResult{
+					ChaincodeDefinitions: []*lh.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+						{
+							Sequence:          1,
+							Version:           "a-version",
+							EndorsementPlugin: "e-plugin",
+							ValidationPlugin:  "v-plugin",
+						},
+						{
+							Sequence:          2,
+							Version:           "another-version",
+							EndorsementPlugin: "e-plugin",
+							ValidationPlugin:  "v-plugin",
+						},
+					},
+				}
+
+				mockResultBytes, err := proto.Marshal(mockResult)
+				Expect(err).NotTo(HaveOccurred())
+				mockProposalResponse = &pb.ProposalResponse{
+					Response: &pb.Response{
+						Status:  200,
+						Payload: mockResultBytes,
+					},
+				}
+
+				mockEndorserClient.ProcessProposalReturns(mockProposalResponse, nil)
+			})
+
+			It("queries the definition history and writes the output as human readable plain-text", func() {
+				err := committedQuerier.Query()
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(committedQuerier.Writer).Should(gbytes.Say("Committed chaincode definition history for chaincode 'test-cc' on channel 'test-channel':\n"))
+				Eventually(committedQuerier.Writer).Should(gbytes.Say("Version: a-version, Sequence: 1, Endorsement Plugin: e-plugin, Validation Plugin: v-plugin\n"))
+				Eventually(committedQuerier.Writer).Should(gbytes.Say("Version: another-version, Sequence: 2, Endorsement Plugin: e-plugin, Validation Plugin: v-plugin\n"))
+
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+				proposal := &pb.Proposal{}
+				Expect(proto.Unmarshal(signedProposal.ProposalBytes, proposal)).To(Succeed())
+				payload := &pb.ChaincodeProposalPayload{}
+				Expect(proto.Unmarshal(proposal.Payload, payload)).To(Succeed())
+				cis := &pb.ChaincodeInvocationSpec{}
+				Expect(proto.Unmarshal(payload.Input, cis)).To(Succeed())
+				Expect(cis.ChaincodeSpec.Input.Args[0]).To(Equal([]byte("QueryChaincodeDefinitionHistory")))
+			})
+
+			Context("when JSON-formatted output is requested", func() {
+				BeforeEach(func() {
+					committedQuerier.Input.OutputFormat = "json"
+				})
+
+				It("queries the definition history and writes the output as JSON", func() {
+					err := committedQuerier.Query()
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(committedQuerier.Writer).Should(gbytes.Say(`"chaincode_definitions"`))
+					Eventually(committedQuerier.Writer).Should(gbytes.Say(`"version": "another-version"`))
+				})
+			})
+
+			Context("when the chaincode name is not provided", func() {
+				BeforeEach(func() {
+					input.Name = ""
+				})
+
+				It("returns an error", func() {
+					err := committedQuerier.Query()
+					Expect(err).To(MatchError("chaincode name must be specified when querying the definition history"))
+				})
+			})
+		})
+
 		Context("when the channel is not provided", func() {
 			BeforeEach(func() {
 				committedQuerier.Input.ChannelID = ""
@@ -0,0 +1,329 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	lh "github.com/hyperledger/fabric/pkg/lifecycle/history"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Rollbacker holds the dependencies needed to roll back a
+// chaincode definition to a previously committed sequence
+type Rollbacker struct {
+	Certificate     tls.Certificate
+	Command         *cobra.Command
+	BroadcastClient common.BroadcastClient
+	DeliverClients  []pb.DeliverClient
+	EndorserClients []EndorserClient
+	Input           *RollbackInput
+	Signer          Signer
+}
+
+// RollbackInput holds all of the input parameters for rolling back a
+// chaincode definition. The rollback proposes the next sequence with the
+// parameters of the committed definition at RollbackSequence. When Commit
+// is false, the definition is approved for the organization; otherwise it
+// is committed.
+type RollbackInput struct {
+	ChannelID           string
+	Name                string
+	PackageID           string
+	RollbackSequence    int64
+	Commit              bool
+	PeerAddresses       []string
+	WaitForEvent        bool
+	WaitForEventTimeout time.Duration
+	TxID                string
+}
+
+// Validate the input for a rollback
+func (r *RollbackInput) Validate() error {
+	if r.ChannelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+
+	if r.Name == "" {
+		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
+	}
+
+	if r.RollbackSequence == 0 {
+		return errors.New("The required parameter 'rollback-sequence' is empty. Rerun the command with --rollback-sequence flag")
+	}
+
+	return nil
+}
+
+// RollbackCmd returns the cobra command for chaincode Rollback
+func RollbackCmd(r *Rollbacker, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	chaincodeRollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back the chaincode definition to a previously committed sequence.",
+		Long: "Roll back the chaincode definition to a previously committed sequence. " +
+			"The next sequence is proposed with the parameters of the chosen definition and must be approved " +
+			"and committed like any other definition. By default, the rollback definition is approved for my " +
+			"organization. Once enough organizations have approved it, rerun the command with --commit to commit it. " +
+			"The channel must have the V2_5 application capability enabled, since only then are the committed definitions retained.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if r == nil {
+				input := &RollbackInput{
+					ChannelID:           channelID,
+					Name:                chaincodeName,
+					PackageID:           packageID,
+					RollbackSequence:    int64(rollbackSequence),
+					Commit:              rollbackCommit,
+					PeerAddresses:       peerAddresses,
+					WaitForEvent:        waitForEvent,
+					WaitForEventTimeout: waitForEventTimeout,
+				}
+
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					OrdererRequired:       true,
+					ChannelID:             channelID,
+					PeerAddresses:         peerAddresses,
+					TLSRootCertFiles:      tlsRootCertFiles,
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+
+				endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+				for i, e := range cc.EndorserClients {
+					endorserClients[i] = e
+				}
+
+				r = &Rollbacker{
+					Command:         cmd,
+					Input:           input,
+					Certificate:     cc.Certificate,
+					BroadcastClient: cc.BroadcastClient,
+					DeliverClients:  cc.DeliverClients,
+					EndorserClients: endorserClients,
+					Signer:          cc.Signer,
+				}
+			}
+			return r.Rollback()
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"name",
+		"package-id",
+		"rollback-sequence",
+		"commit",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(chaincodeRollbackCmd, flagList)
+
+	return chaincodeRollbackCmd
+}
+
+// Rollback looks up the committed definition at the rollback sequence and
+// submits an ApproveChaincodeDefinitionForMyOrg or CommitChaincodeDefinition
+// proposal for the next sequence with the same parameters
+func (r *Rollbacker) Rollback() error {
+	err := r.Input.Validate()
+	if err != nil {
+		return err
+	}
+
+	if r.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		r.Command.SilenceUsage = true
+	}
+
+	if len(r.EndorserClients) == 0 {
+		// this should only be empty due to a programming bug
+		return errors.New("no endorser clients provided")
+	}
+
+	history := &lh.QueryChaincodeDefinitionHistoryResult{}
+	err = r.query(
+		"QueryChaincodeDefinitionHistory",
+		&lh.QueryChaincodeDefinitionHistoryArgs{Name: r.Input.Name},
+		history,
+	)
+	if err != nil {
+		return errors.WithMessage(err, "failed to query chaincode definition history")
+	}
+
+	definitions := history.ChaincodeDefinitions
+	if len(definitions) == 0 {
+		return errors.Errorf("no committed definitions found for chaincode '%s'", r.Input.Name)
+	}
+	current := definitions[len(definitions)-1]
+
+	var target *lh.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition
+	for _, definition := range definitions {
+		if definition.Sequence == r.Input.RollbackSequence {
+			target = definition
+			break
+		}
+	}
+	if target == nil {
+		return errors.Errorf("sequence %d is not in the definition history for chaincode '%s'", r.Input.RollbackSequence, r.Input.Name)
+	}
+	if target.Sequence == current.Sequence {
+		return errors.Errorf("sequence %d is the currently committed definition for chaincode '%s'", target.Sequence, r.Input.Name)
+	}
+
+	logger.Infof("Rolling back chaincode '%s' on channel '%s' to the definition committed at sequence %d as sequence %d", r.Input.Name, r.Input.ChannelID, target.Sequence, current.Sequence+1)
+
+	if r.Input.Commit {
+		committer := &Committer{
+			Certificate:     r.Certificate,
+			BroadcastClient: r.BroadcastClient,
+			DeliverClients:  r.DeliverClients,
+			EndorserClients: r.EndorserClients,
+			Signer:          r.Signer,
+			Input: &CommitInput{
+				ChannelID:                r.Input.ChannelID,
+				Name:                     r.Input.Name,
+				Version:                  target.Version,
+				Sequence:                 current.Sequence + 1,
+				EndorsementPlugin:        target.EndorsementPlugin,
+				ValidationPlugin:         target.ValidationPlugin,
+				ValidationParameterBytes: target.ValidationParameter,
+				CollectionConfigPackage:  target.Collections,
+				InitRequired:             target.InitRequired,
+				PeerAddresses:            r.Input.PeerAddresses,
+				WaitForEvent:             r.Input.WaitForEvent,
+				WaitForEventTimeout:      r.Input.WaitForEventTimeout,
+				TxID:                     r.Input.TxID,
+			},
+		}
+		return committer.Commit()
+	}
+
+	packageID := r.Input.PackageID
+	if packageID == "" {
+		packageID, err = r.approvedPackageID(target.Sequence)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to determine the package ID approved for sequence %d, rerun the command with --package-id flag", target.Sequence)
+		}
+	}
+
+	approver := &ApproverForMyOrg{
+		Certificate:     r.Certificate,
+		BroadcastClient: r.BroadcastClient,
+		DeliverClients:  r.DeliverClients,
+		EndorserClients: r.EndorserClients,
+		Signer:          r.Signer,
+		Input: &ApproveForMyOrgInput{
+			ChannelID:                r.Input.ChannelID,
+			Name:                     r.Input.Name,
+			Version:                  target.Version,
+			PackageID:                packageID,
+			Sequence:                 current.Sequence + 1,
+			EndorsementPlugin:        target.EndorsementPlugin,
+			ValidationPlugin:         target.ValidationPlugin,
+			ValidationParameterBytes: target.ValidationParameter,
+			CollectionConfigPackage:  target.Collections,
+			InitRequired:             target.InitRequired,
+			PeerAddresses:            r.Input.PeerAddresses,
+			WaitForEvent:             r.Input.WaitForEvent,
+			WaitForEventTimeout:      r.Input.WaitForEventTimeout,
+			TxID:                     r.Input.TxID,
+		},
+	}
+	return approver.Approve()
+}
+
+// approvedPackageID returns the package ID that this organization approved
+// for the sequence
+func (r *Rollbacker) approvedPackageID(sequence int64) (string, error) {
+	approved := &lb.QueryApprovedChaincodeDefinitionResult{}
+	err := r.query(
+		"QueryApprovedChaincodeDefinition",
+		&lb.QueryApprovedChaincodeDefinitionArgs{Name: r.Input.Name, Sequence: sequence},
+		approved,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if source, ok := approved.GetSource().GetType().(*lb.ChaincodeSource_LocalPackage); ok {
+		return source.LocalPackage.PackageId, nil
+	}
+	return "", nil
+}
+
+// query invokes a _lifecycle query function on the first endorser and
+// unmarshals the response payload into result
+func (r *Rollbacker) query(function string, args, result proto.Message) error {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal args")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(function), argsBytes}},
+		},
+	}
+
+	signerSerialized, err := r.Signer.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	proposal, _, err := protoutil.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, r.Input.ChannelID, cis, signerSerialized)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
+	}
+
+	signedProposal, err := signProposal(proposal, r.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed proposal")
+	}
+
+	proposalResponse, err := r.EndorserClients[0].ProcessProposal(context.Background(), signedProposal)
+	if err != nil {
+		return errors.WithMessage(err, "failed to endorse proposal")
+	}
+
+	if proposalResponse == nil {
+		return errors.New("received nil proposal response")
+	}
+
+	if proposalResponse.Response == nil {
+		return errors.New("received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("query failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+
+	if err := proto.Unmarshal(proposalResponse.Response.Payload, result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+
+	return nil
+}
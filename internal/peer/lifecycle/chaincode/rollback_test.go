@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	lh "github.com/hyperledger/fabric/pkg/lifecycle/history"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rollback", func() {
+	Describe("Rollbacker", func() {
+		var (
+			mockEndorserClient  *mock.EndorserClient
+			mockDeliverClient   *mock.PeerDeliverClient
+			mockSigner          *mock.Signer
+			mockBroadcastClient *mock.BroadcastClient
+			historyResult       *lh.QueryChaincodeDefinitionHistoryResult
+			approvedResult      *lb.QueryApprovedChaincodeDefinitionResult
+			input               *chaincode.RollbackInput
+			rollbacker          *chaincode.Rollbacker
+		)
+
+		invocationArgs := func(signedProposal *pb.SignedProposal) [][]byte {
+			proposal := &pb.Proposal{}
+			Expect(proto.Unmarshal(signedProposal.ProposalBytes, proposal)).To(Succeed())
+			payload := &pb.ChaincodeProposalPayload{}
+			Expect(proto.Unmarshal(proposal.Payload, payload)).To(Succeed())
+			cis := &pb.ChaincodeInvocationSpec{}
+			Expect(proto.Unmarshal(payload.Input, cis)).To(Succeed())
+			return cis.ChaincodeSpec.Input.Args
+		}
+
+		BeforeEach(func() {
+			historyResult = &lh.QueryChaincodeDefinitionHistoryResult{
+				ChaincodeDefinitions: []*lh.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+					{
+						Sequence:            1,
+						Version:             "1.0",
+						EndorsementPlugin:   "escc",
+						ValidationPlugin:    "vscc",
+						ValidationParameter: []byte("policy-1"),
+						InitRequired:        true,
+					},
+					{
+						Sequence:            2,
+						Version:             "2.0",
+						EndorsementPlugin:   "escc",
+						ValidationPlugin:    "vscc",
+						ValidationParameter: []byte("policy-2"),
+					},
+				},
+			}
+			approvedResult = &lb.QueryApprovedChaincodeDefinitionResult{
+				Sequence: 1,
+				Source: &lb.ChaincodeSource{
+					Type: &lb.ChaincodeSource_LocalPackage{
+						LocalPackage: &lb.ChaincodeSource_Local{
+							PackageId: "approved-package-id",
+						},
+					},
+				},
+			}
+
+			mockEndorserClient = &mock.EndorserClient{}
+			mockEndorserClient.ProcessProposalStub = func(ctx context.Context, signedProposal *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+				var payload []byte
+				switch string(invocationArgs(signedProposal)[0]) {
+				case "QueryChaincodeDefinitionHistory":
+					payload = protoutil.MarshalOrPanic(historyResult)
+				case "QueryApprovedChaincodeDefinition":
+					payload = protoutil.MarshalOrPanic(approvedResult)
+				}
+				return &pb.ProposalResponse{
+					Response: &pb.Response{
+						Status:  200,
+						Payload: payload,
+					},
+					Endorsement: &pb.Endorsement{},
+				}, nil
+			}
+
+			mockDeliverClient = &mock.PeerDeliverClient{}
+			mockSigner = &mock.Signer{}
+			mockBroadcastClient = &mock.BroadcastClient{}
+
+			input = &chaincode.RollbackInput{
+				ChannelID:        "testchannel",
+				Name:             "testcc",
+				RollbackSequence: 1,
+			}
+
+			rollbacker = &chaincode.Rollbacker{
+				Certificate:     tls.Certificate{},
+				BroadcastClient: mockBroadcastClient,
+				DeliverClients:  []pb.DeliverClient{mockDeliverClient},
+				EndorserClients: []chaincode.EndorserClient{mockEndorserClient},
+				Input:           input,
+				Signer:          mockSigner,
+			}
+		})
+
+		It("approves the rolled back definition as the next sequence", func() {
+			err := rollbacker.Rollback()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(3))
+			_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(1)
+			args := invocationArgs(signedProposal)
+			Expect(args[0]).To(Equal([]byte("QueryApprovedChaincodeDefinition")))
+			approvedArgs := &lb.QueryApprovedChaincodeDefinitionArgs{}
+			Expect(proto.Unmarshal(args[1], approvedArgs)).To(Succeed())
+			Expect(approvedArgs.Sequence).To(Equal(int64(1)))
+
+			_, signedProposal, _ = mockEndorserClient.ProcessProposalArgsForCall(2)
+			args = invocationArgs(signedProposal)
+			Expect(args[0]).To(Equal([]byte("ApproveChaincodeDefinitionForMyOrg")))
+			approveArgs := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+			Expect(proto.Unmarshal(args[1], approveArgs)).To(Succeed())
+			Expect(proto.Equal(approveArgs, &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+				Name:                "testcc",
+				Version:             "1.0",
+				Sequence:            3,
+				EndorsementPlugin:   "escc",
+				ValidationPlugin:    "vscc",
+				ValidationParameter: []byte("policy-1"),
+				InitRequired:        true,
+				Source: &lb.ChaincodeSource{
+					Type: &lb.ChaincodeSource_LocalPackage{
+						LocalPackage: &lb.ChaincodeSource_Local{
+							PackageId: "approved-package-id",
+						},
+					},
+				},
+			})).To(BeTrue())
+
+			Expect(mockBroadcastClient.SendCallCount()).To(Equal(1))
+		})
+
+		Context("when a package ID is provided", func() {
+			BeforeEach(func() {
+				input.PackageID = "provided-package-id"
+			})
+
+			It("approves the definition with the provided package ID", func() {
+				err := rollbacker.Rollback()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(2))
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(1)
+				approveArgs := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+				Expect(proto.Unmarshal(invocationArgs(signedProposal)[1], approveArgs)).To(Succeed())
+				Expect(approveArgs.Source.GetLocalPackage().PackageId).To(Equal("provided-package-id"))
+			})
+		})
+
+		Context("when the rollback is committed", func() {
+			BeforeEach(func() {
+				input.Commit = true
+			})
+
+			It("commits the rolled back definition as the next sequence", func() {
+				err := rollbacker.Rollback()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(2))
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(1)
+				args := invocationArgs(signedProposal)
+				Expect(args[0]).To(Equal([]byte("CommitChaincodeDefinition")))
+				commitArgs := &lb.CommitChaincodeDefinitionArgs{}
+				Expect(proto.Unmarshal(args[1], commitArgs)).To(Succeed())
+				Expect(proto.Equal(commitArgs, &lb.CommitChaincodeDefinitionArgs{
+					Name:                "testcc",
+					Version:             "1.0",
+					Sequence:            3,
+					EndorsementPlugin:   "escc",
+					ValidationPlugin:    "vscc",
+					ValidationParameter: []byte("policy-1"),
+					InitRequired:        true,
+				})).To(BeTrue())
+
+				Expect(mockBroadcastClient.SendCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the rollback sequence is not provided", func() {
+			BeforeEach(func() {
+				input.RollbackSequence = 0
+			})
+
+			It("returns an error", func() {
+				err := rollbacker.Rollback()
+				Expect(err).To(MatchError("The required parameter 'rollback-sequence' is empty. Rerun the command with --rollback-sequence flag"))
+			})
+		})
+
+		Context("when the rollback sequence is not in the history", func() {
+			BeforeEach(func() {
+				input.RollbackSequence = 7
+			})
+
+			It("returns an error", func() {
+				err := rollbacker.Rollback()
+				Expect(err).To(MatchError("sequence 7 is not in the definition history for chaincode 'testcc'"))
+			})
+		})
+
+		Context("when the rollback sequence is the current sequence", func() {
+			BeforeEach(func() {
+				input.RollbackSequence = 2
+			})
+
+			It("returns an error", func() {
+				err := rollbacker.Rollback()
+				Expect(err).To(MatchError("sequence 2 is the currently committed definition for chaincode 'testcc'"))
+			})
+		})
+
+		Context("when the history query fails", func() {
+			BeforeEach(func() {
+				mockEndorserClient.ProcessProposalStub = nil
+				mockEndorserClient.ProcessProposalReturns(&pb.ProposalResponse{
+					Response: &pb.Response{
+						Status:  int32(cb.Status_NOT_FOUND),
+						Message: "namespace testcc is not defined",
+					},
+				}, nil)
+			})
+
+			It("returns an error", func() {
+				err := rollbacker.Rollback()
+				Expect(err).To(MatchError("failed to query chaincode definition history: query failed with status: 404 - namespace testcc is not defined"))
+			})
+		})
+
+		Context("when the endorser fails", func() {
+			BeforeEach(func() {
+				mockEndorserClient.ProcessProposalStub = nil
+				mockEndorserClient.ProcessProposalReturns(nil, errors.New("latte"))
+			})
+
+			It("returns an error", func() {
+				err := rollbacker.Rollback()
+				Expect(err).To(MatchError("failed to query chaincode definition history: failed to endorse proposal: latte"))
+			})
+		})
+
+		Context("when the approved package ID cannot be determined", func() {
+			BeforeEach(func() {
+				stub := mockEndorserClient.ProcessProposalStub
+				mockEndorserClient.ProcessProposalStub = func(ctx context.Context, signedProposal *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+					if string(invocationArgs(signedProposal)[0]) == "QueryApprovedChaincodeDefinition" {
+						return nil, errors.New("mocha")
+					}
+					return stub(ctx, signedProposal, opts...)
+				}
+			})
+
+			It("returns an error", func() {
+				err := rollbacker.Rollback()
+				Expect(err).To(MatchError("failed to determine the package ID approved for sequence 1, rerun the command with --package-id flag: failed to endorse proposal: mocha"))
+			})
+		})
+	})
+
+	Describe("RollbackCmd", func() {
+		var rollbackCmd *cobra.Command
+
+		BeforeEach(func() {
+			cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+			Expect(err).To(BeNil())
+			rollbackCmd = chaincode.RollbackCmd(nil, cryptoProvider)
+			rollbackCmd.SilenceErrors = true
+			rollbackCmd.SilenceUsage = true
+			rollbackCmd.SetArgs([]string{
+				"--channelID=testchannel",
+				"--name=testcc",
+				"--rollback-sequence=1",
+				"--peerAddresses=rollbackpeer1",
+				"--tlsRootCertFiles=tls1",
+			})
+		})
+
+		AfterEach(func() {
+			chaincode.ResetFlags()
+		})
+
+		It("attempts to connect to the endorser", func() {
+			err := rollbackCmd.Execute()
+			Expect(err).To(MatchError(ContainSubstring("failed to retrieve endorser client")))
+		})
+	})
+})
@@ -7,11 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"encoding/hex"
+	"io/ioutil"
 	"path/filepath"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/viperutil"
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipgossip "github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -85,3 +96,84 @@ func ledgerConfig() *ledger.Config {
 	}
 	return conf
 }
+
+// pvtDataEncryptor returns the encryptor of the private data persisted by the peer,
+// or nil if the encryption of private data is not enabled
+func pvtDataEncryptor(csp bccsp.BCCSP) (ledger.PvtDataEncryptor, error) {
+	if !viper.GetBool("ledger.pvtdataEncryption.enabled") {
+		return nil, nil
+	}
+	csp, err := pvtDataEncryptionCSP(csp)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := hex.DecodeString(viper.GetString("ledger.pvtdataEncryption.keyID"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ledger.pvtdataEncryption.keyID")
+	}
+	encryptor, err := pvtdataencryption.New(csp, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return encryptor, nil
+}
+
+// pvtDataEncryptionCSP returns the BCCSP that holds the key encryption key of the private data.
+// The PKCS11 BCCSP does not store AES keys in the HSM: they are only kept in memory, and would be
+// lost when the peer restarts. When the PKCS11 BCCSP is configured, the key is therefore held by
+// a software BCCSP backed by the file keystore of the peer.
+func pvtDataEncryptionCSP(csp bccsp.BCCSP) (bccsp.BCCSP, error) {
+	if viper.GetString("peer.BCCSP.Default") != "PKCS11" {
+		return csp, nil
+	}
+	keyStorePath := coreconfig.GetPath("peer.BCCSP.SW.FileKeyStore.KeyStore")
+	if keyStorePath == "" {
+		keyStorePath = filepath.Join(coreconfig.GetPath("peer.mspConfigPath"), "keystore")
+	}
+	swCSP, err := sw.NewDefaultSecurityLevel(keyStorePath)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to open the keystore of the private data encryption key at %s", keyStorePath)
+	}
+	return swCSP, nil
+}
+
+// staticGossipPeer is the configuration of a peer of the static gossip membership
+type staticGossipPeer struct {
+	Endpoint string `yaml:"endpoint"`
+	MSPID    string `yaml:"mspID"`
+	CertFile string `yaml:"certFile"`
+}
+
+// staticGossipPeers returns the peers of the static gossip membership. The identity of a peer
+// is only set if both its MSP ID and the path to its certificate are configured.
+func staticGossipPeers() ([]gossipgossip.StaticPeer, error) {
+	var peers []staticGossipPeer
+	err := viper.UnmarshalKey("peer.gossip.staticMembership.peers", &peers, viper.DecodeHook(viperutil.YamlStringToStructHook(peers)))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid peer.gossip.staticMembership.peers")
+	}
+
+	var staticPeers []gossipgossip.StaticPeer
+	for _, peer := range peers {
+		if peer.Endpoint == "" {
+			return nil, errors.New("invalid static gossip peer configuration, endpoint attribute missing in one or more peers")
+		}
+		staticPeer := gossipgossip.StaticPeer{Endpoint: peer.Endpoint}
+		if (peer.MSPID == "") != (peer.CertFile == "") {
+			return nil, errors.Errorf("static gossip peer %s must have either both or none of the mspID and certFile attributes", peer.Endpoint)
+		}
+		if peer.CertFile != "" {
+			cert, err := ioutil.ReadFile(coreconfig.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), peer.CertFile))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed reading the certificate of static gossip peer %s", peer.Endpoint)
+			}
+			identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: peer.MSPID, IdBytes: cert})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed marshaling the identity of static gossip peer %s", peer.Endpoint)
+			}
+			staticPeer.Identity = api.PeerIdentityType(identity)
+		}
+		staticPeers = append(staticPeers, staticPeer)
+	}
+	return staticPeers, nil
+}
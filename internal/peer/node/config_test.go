@@ -7,10 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipgossip "github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -161,3 +170,96 @@ func TestLedgerConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestPvtDataEncryptor(t *testing.T) {
+	defer viper.Reset()
+	csp, err := sw.NewDefaultSecurityLevel(t.TempDir())
+	require.NoError(t, err)
+	keyID, err := pvtdataencryption.GenerateKey(csp)
+	require.NoError(t, err)
+
+	encryptor, err := pvtDataEncryptor(csp)
+	require.NoError(t, err)
+	require.Nil(t, encryptor)
+
+	viper.Set("ledger.pvtdataEncryption.enabled", true)
+	viper.Set("ledger.pvtdataEncryption.keyID", "not-hex")
+	_, err = pvtDataEncryptor(csp)
+	require.EqualError(t, err, "invalid ledger.pvtdataEncryption.keyID: encoding/hex: invalid byte: U+006E 'n'")
+
+	viper.Set("ledger.pvtdataEncryption.keyID", "")
+	_, err = pvtDataEncryptor(csp)
+	require.EqualError(t, err, "the identifier of the key encryption key is not set")
+
+	viper.Set("ledger.pvtdataEncryption.keyID", hex.EncodeToString(keyID))
+	encryptor, err = pvtDataEncryptor(csp)
+	require.NoError(t, err)
+	encrypted, err := encryptor.Encrypt([]byte("value"))
+	require.NoError(t, err)
+	require.True(t, pvtdataencryption.IsEncrypted(encrypted))
+
+	// with the PKCS11 BCCSP, the key is held by the file keystore of the peer
+	keystorePath := t.TempDir()
+	swCSP, err := sw.NewDefaultSecurityLevel(keystorePath)
+	require.NoError(t, err)
+	keyID, err = pvtdataencryption.GenerateKey(swCSP)
+	require.NoError(t, err)
+	viper.Set("peer.BCCSP.Default", "PKCS11")
+	viper.Set("peer.BCCSP.SW.FileKeyStore.KeyStore", keystorePath)
+	viper.Set("ledger.pvtdataEncryption.keyID", hex.EncodeToString(keyID))
+	encryptor, err = pvtDataEncryptor(csp)
+	require.NoError(t, err)
+	encrypted, err = encryptor.Encrypt([]byte("value"))
+	require.NoError(t, err)
+	require.True(t, pvtdataencryption.IsEncrypted(encrypted))
+
+	// the key is looked up in the keystore directory of the local MSP by default
+	mspConfigPath := t.TempDir()
+	viper.Set("peer.BCCSP.SW.FileKeyStore.KeyStore", "")
+	viper.Set("peer.mspConfigPath", mspConfigPath)
+	_, err = pvtDataEncryptor(csp)
+	require.ErrorContains(t, err, "not found in "+filepath.Join(mspConfigPath, "keystore"))
+	require.DirExists(t, filepath.Join(mspConfigPath, "keystore"))
+}
+
+func TestStaticGossipPeers(t *testing.T) {
+	defer viper.Reset()
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, []byte("cert"), 0o600))
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("cert")})
+	require.NoError(t, err)
+
+	peers, err := staticGossipPeers()
+	require.NoError(t, err)
+	require.Empty(t, peers)
+
+	viper.Set("peer.gossip.staticMembership.peers", []interface{}{
+		map[string]interface{}{"endpoint": "peer0:7051"},
+		map[string]interface{}{"endpoint": "peer1:7051", "mspID": "Org1MSP", "certFile": certFile},
+	})
+	peers, err = staticGossipPeers()
+	require.NoError(t, err)
+	require.Equal(t, []gossipgossip.StaticPeer{
+		{Endpoint: "peer0:7051"},
+		{Endpoint: "peer1:7051", Identity: api.PeerIdentityType(identity)},
+	}, peers)
+
+	viper.Set("peer.gossip.staticMembership.peers", []interface{}{
+		map[string]interface{}{"mspID": "Org1MSP"},
+	})
+	_, err = staticGossipPeers()
+	require.EqualError(t, err, "invalid static gossip peer configuration, endpoint attribute missing in one or more peers")
+
+	viper.Set("peer.gossip.staticMembership.peers", []interface{}{
+		map[string]interface{}{"endpoint": "peer1:7051", "mspID": "Org1MSP"},
+	})
+	_, err = staticGossipPeers()
+	require.EqualError(t, err, "static gossip peer peer1:7051 must have either both or none of the mspID and certFile attributes")
+
+	viper.Set("peer.gossip.staticMembership.peers", []interface{}{
+		map[string]interface{}{"endpoint": "peer1:7051", "mspID": "Org1MSP", "certFile": filepath.Join(t.TempDir(), "missing.pem")},
+	})
+	_, err = staticGossipPeers()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed reading the certificate of static gossip peer peer1:7051")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/spf13/cobra"
+)
+
+// generatePvtDataKeyCmd returns the command that generates the key encryption key of the private data.
+// If csp is nil, the BCCSP configured for the peer is used. If w is nil, the identifier of the key is
+// written to the standard output.
+func generatePvtDataKeyCmd(csp bccsp.BCCSP, w io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate-pvtdata-key",
+		Short: "Generates a key for the encryption of private data.",
+		Long: `Generates a key for the encryption of private data in the BCCSP configured for the peer, and prints its identifier. ` +
+			`Set ledger.pvtdataEncryption.keyID to this identifier to encrypt the private data persisted by the peer with the key. ` +
+			`When the command is executed, the peer must be offline. With the PKCS11 BCCSP, the key is stored in the file keystore of the peer ` +
+			`(peer.BCCSP.SW.FileKeyStore.KeyStore, or the keystore directory of the local MSP).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if csp == nil {
+				csp = factory.GetDefault()
+			}
+			csp, err := pvtDataEncryptionCSP(csp)
+			if err != nil {
+				return err
+			}
+			if w == nil {
+				w = os.Stdout
+			}
+			cmd.SilenceUsage = true
+
+			keyID, err := pvtdataencryption.GenerateKey(csp)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%x\n", keyID)
+			return nil
+		},
+	}
+}
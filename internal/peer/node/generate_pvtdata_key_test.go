@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/ledger/pvtdataencryption"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePvtDataKeyCmd(t *testing.T) {
+	keystorePath := t.TempDir()
+	csp, err := sw.NewDefaultSecurityLevel(keystorePath)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	cmd := generatePvtDataKeyCmd(csp, buf)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	keyID, err := hex.DecodeString(strings.TrimSpace(buf.String()))
+	require.NoError(t, err)
+
+	// the key is persisted in the keystore and can be used after a restart
+	csp, err = sw.NewDefaultSecurityLevel(keystorePath)
+	require.NoError(t, err)
+	_, err = pvtdataencryption.New(csp, keyID)
+	require.NoError(t, err)
+
+	t.Run("with arguments", func(t *testing.T) {
+		cmd := generatePvtDataKeyCmd(csp, buf)
+		cmd.SetArgs([]string{"extra"})
+		require.EqualError(t, cmd.Execute(), `unknown command "extra" for "generate-pvtdata-key"`)
+	})
+
+	t.Run("with the PKCS11 BCCSP", func(t *testing.T) {
+		defer viper.Reset()
+		mspConfigPath := t.TempDir()
+		viper.Set("peer.BCCSP.Default", "PKCS11")
+		viper.Set("peer.mspConfigPath", mspConfigPath)
+		buf.Reset()
+		cmd := generatePvtDataKeyCmd(csp, buf)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+
+		keyID, err := hex.DecodeString(strings.TrimSpace(buf.String()))
+		require.NoError(t, err)
+
+		// the key is persisted in the keystore directory of the local MSP
+		swCSP, err := sw.NewDefaultSecurityLevel(filepath.Join(mspConfigPath, "keystore"))
+		require.NoError(t, err)
+		_, err = pvtdataencryption.New(swCSP, keyID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// gossipInfoParameters are the flags of the gossip-info command
+type gossipInfoParameters struct {
+	channelID         string
+	operationsAddress string
+	tlsRootCertFile   string
+	tlsCertFile       string
+	tlsKeyFile        string
+	output            string
+}
+
+func gossipInfoCmd(w io.Writer) *cobra.Command {
+	p := &gossipInfoParameters{}
+	cmd := &cobra.Command{
+		Use:   "gossip-info",
+		Short: "Shows the gossip membership and topology of a running peer.",
+		Long: "Shows the gossip membership and topology of a running peer, as retrieved from the /gossip endpoint of its operations service:" +
+			" the alive and dead members, the connections to remote peers, and the membership, leader and anchor peers of each channel.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if w == nil {
+				w = os.Stdout
+			}
+			if p.output != "" && strings.ToLower(p.output) != "json" {
+				return errors.Errorf("invalid output format '%s', json is the only supported format", p.output)
+			}
+			if p.operationsAddress == "" {
+				p.operationsAddress = viper.GetString("operations.listenAddress")
+			}
+			tlsEnabled := viper.GetBool("operations.tls.enabled")
+			client, err := newOperationsClient(tlsEnabled, p)
+			if err != nil {
+				return err
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			report, err := fetchGossipReport(client, tlsEnabled, p)
+			if err != nil {
+				return err
+			}
+			if p.output != "" {
+				encoder := json.NewEncoder(w)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+			printGossipReport(w, report)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&p.channelID, "channelID", "c", "", "Restricts the channel membership to the given channel")
+	addOperationsClientFlags(flags, p)
+	flags.StringVarP(&p.output, "output", "O", "", "The output format. Default is human-readable plain-text. json is currently the only supported format.")
+
+	return cmd
+}
+
+// addOperationsClientFlags adds the flags used to connect to the operations service of the peer
+func addOperationsClientFlags(flags *pflag.FlagSet, p *gossipInfoParameters) {
+	flags.StringVarP(&p.operationsAddress, "operationsAddress", "", "", "The address of the operations service of the peer. Default is operations.listenAddress.")
+	flags.StringVarP(&p.tlsRootCertFile, "tlsRootCertFile", "", "",
+		"The path to the TLS root cert file of the operations service, required if TLS is enabled for the operations service and ignored otherwise.")
+	flags.StringVarP(&p.tlsCertFile, "tlsCertFile", "", "",
+		"The path to the client TLS cert file, required if TLS is enabled for the operations service and ignored otherwise.")
+	flags.StringVarP(&p.tlsKeyFile, "tlsKeyFile", "", "",
+		"The path to the client TLS key file, required if TLS is enabled for the operations service and ignored otherwise.")
+}
+
+func newOperationsClient(tlsEnabled bool, p *gossipInfoParameters) (*http.Client, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if !tlsEnabled {
+		return client, nil
+	}
+	if p.tlsRootCertFile == "" {
+		return nil, errors.New("the required parameter 'tlsRootCertFile' is empty. Rerun the command with --tlsRootCertFile flag")
+	}
+	if p.tlsCertFile == "" || p.tlsKeyFile == "" {
+		return nil, errors.New("the required parameters 'tlsCertFile' and 'tlsKeyFile' must be set. Rerun the command with --tlsCertFile and --tlsKeyFile flags")
+	}
+
+	tlsConfig := &tls.Config{RootCAs: x509.NewCertPool()}
+	caPEM, err := ioutil.ReadFile(p.tlsRootCertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read TLS root cert file")
+	}
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errors.Errorf("failed to add certificates from %s", p.tlsRootCertFile)
+	}
+	cert, err := tls.LoadX509KeyPair(p.tlsCertFile, p.tlsKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load client TLS key pair")
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return client, nil
+}
+
+// operationsURL returns the URL of the given path of the operations service of the peer
+func operationsURL(tlsEnabled bool, p *gossipInfoParameters, path string) *url.URL {
+	u := &url.URL{Scheme: "http", Host: p.operationsAddress, Path: path}
+	if tlsEnabled {
+		u.Scheme = "https"
+	}
+	return u
+}
+
+func fetchGossipReport(client *http.Client, tlsEnabled bool, p *gossipInfoParameters) (*introspection.Report, error) {
+	u := operationsURL(tlsEnabled, p, "/gossip")
+	if p.channelID != "" {
+		u.RawQuery = url.Values{"channel": []string{p.channelID}}.Encode()
+	}
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve the gossip information")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := &introspection.ErrorResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(errResp); err != nil || errResp.Error == "" {
+			return nil, errors.Errorf("failed to retrieve the gossip information: %s", resp.Status)
+		}
+		return nil, errors.Errorf("failed to retrieve the gossip information: %s", errResp.Error)
+	}
+
+	report := &introspection.Report{}
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the gossip information")
+	}
+	return report, nil
+}
+
+func printGossipReport(w io.Writer, report *introspection.Report) {
+	endpoints := map[string]string{report.Self.PKIID: report.Self.Endpoint}
+	for _, member := range report.AliveMembers {
+		endpoints[member.PKIID] = member.Endpoint
+	}
+	for _, member := range report.DeadMembers {
+		endpoints[member.PKIID] = member.Endpoint
+	}
+
+	fmt.Fprintf(w, "Self: %s\n", formatMember(report.Self))
+	fmt.Fprintf(w, "Bootstrap peers: %s\n", formatList(report.BootstrapPeers))
+	fmt.Fprintf(w, "Alive members (%d):\n", len(report.AliveMembers))
+	for _, member := range report.AliveMembers {
+		fmt.Fprintf(w, "  %s\n", formatMember(member))
+	}
+	fmt.Fprintf(w, "Dead members (%d):\n", len(report.DeadMembers))
+	for _, member := range report.DeadMembers {
+		fmt.Fprintf(w, "  %s\n", formatMember(member))
+	}
+	fmt.Fprintf(w, "Connections (%d):\n", len(report.Connections))
+	for _, conn := range report.Connections {
+		direction := "inbound"
+		if conn.Outbound {
+			direction = "outbound"
+		}
+		fmt.Fprintf(w, "  %s, %s, pending messages: %d, PKI-ID: %s\n", conn.Endpoint, direction, conn.PendingMessages, conn.PKIID)
+	}
+
+	for _, channel := range report.Channels {
+		fmt.Fprintf(w, "Channel %s:\n", channel.Name)
+		fmt.Fprintf(w, "  Leader: %s\n", formatLeader(channel, endpoints))
+		fmt.Fprintf(w, "  Anchor peers: %s\n", formatList(channel.AnchorPeers))
+		fmt.Fprintf(w, "  Peers (%d):\n", len(channel.Peers)+1)
+		fmt.Fprintf(w, "    %s (self)\n", formatChannelMember(channel.Self))
+		for _, member := range channel.Peers {
+			fmt.Fprintf(w, "    %s\n", formatChannelMember(member))
+		}
+	}
+}
+
+func formatMember(member introspection.Member) string {
+	s := member.Endpoint
+	if member.InternalEndpoint != "" && member.InternalEndpoint != member.Endpoint {
+		s = fmt.Sprintf("%s (internal: %s)", s, member.InternalEndpoint)
+	}
+	if member.MSPID != "" {
+		s = fmt.Sprintf("%s, MSP: %s", s, member.MSPID)
+	}
+	return fmt.Sprintf("%s, PKI-ID: %s", s, member.PKIID)
+}
+
+func formatChannelMember(member introspection.ChannelMember) string {
+	s := fmt.Sprintf("%s, ledger height: %d", formatMember(member.Member), member.LedgerHeight)
+	var chaincodes []string
+	for _, cc := range member.Chaincodes {
+		chaincodes = append(chaincodes, fmt.Sprintf("%s:%s", cc.Name, cc.Version))
+	}
+	s = fmt.Sprintf("%s, chaincodes: %s", s, formatList(chaincodes))
+	if member.LeftChannel {
+		s += ", left the channel"
+	}
+	return s
+}
+
+func formatLeader(channel introspection.Channel, endpoints map[string]string) string {
+	mode := "elected"
+	if channel.StaticLeader {
+		mode = "static"
+	}
+	switch {
+	case channel.IsLeader:
+		return fmt.Sprintf("self (%s)", mode)
+	case channel.Leader == "":
+		return fmt.Sprintf("unknown (%s)", mode)
+	case endpoints[channel.Leader] != "":
+		return fmt.Sprintf("%s, PKI-ID: %s (%s)", endpoints[channel.Leader], channel.Leader, mode)
+	default:
+		return fmt.Sprintf("PKI-ID: %s (%s)", channel.Leader, mode)
+	}
+}
+
+func formatList(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+	"github.com/hyperledger/fabric/gossip/introspection/mocks"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipInfoCmd(t *testing.T) {
+	defer viper.Reset()
+	newReport := func() *introspection.Report {
+		return &introspection.Report{
+			Self:           introspection.Member{Endpoint: "peer0:7051", PKIID: "0a", MSPID: "Org1MSP"},
+			BootstrapPeers: []string{"peer1:7051"},
+			AliveMembers:   []introspection.Member{{Endpoint: "peer1:7051", InternalEndpoint: "peer1.internal:7051", PKIID: "0b", MSPID: "Org1MSP"}},
+			DeadMembers:    []introspection.Member{},
+			Connections:    []introspection.Connection{{Endpoint: "peer1:7051", PKIID: "0b", Outbound: true, PendingMessages: 2}},
+			Channels: []introspection.Channel{
+				{
+					Name:        "ch1",
+					Self:        introspection.ChannelMember{Member: introspection.Member{Endpoint: "peer0:7051", PKIID: "0a", MSPID: "Org1MSP"}, LedgerHeight: 10},
+					Peers:       []introspection.ChannelMember{{Member: introspection.Member{Endpoint: "peer1:7051", PKIID: "0b", MSPID: "Org1MSP"}, LedgerHeight: 9, Chaincodes: []introspection.Chaincode{{Name: "mycc", Version: "1.0"}}}},
+					Leader:      "0b",
+					AnchorPeers: []string{"peer1:7051"},
+				},
+				{
+					Name:         "ch2",
+					Self:         introspection.ChannelMember{Member: introspection.Member{Endpoint: "peer0:7051", PKIID: "0a", MSPID: "Org1MSP"}, LedgerHeight: 3},
+					Peers:        []introspection.ChannelMember{},
+					Leader:       "0a",
+					IsLeader:     true,
+					StaticLeader: true,
+				},
+			},
+		}
+	}
+	introspector := &mocks.Introspector{}
+	introspector.IntrospectStub = newReport
+	server := httptest.NewServer(introspection.NewHandler(introspector))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("plain-text output", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		cmd := gossipInfoCmd(buf)
+		cmd.SetArgs([]string{"--operationsAddress", address})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, `Self: peer0:7051, MSP: Org1MSP, PKI-ID: 0a
+Bootstrap peers: peer1:7051
+Alive members (1):
+  peer1:7051 (internal: peer1.internal:7051), MSP: Org1MSP, PKI-ID: 0b
+Dead members (0):
+Connections (1):
+  peer1:7051, outbound, pending messages: 2, PKI-ID: 0b
+Channel ch1:
+  Leader: peer1:7051, PKI-ID: 0b (elected)
+  Anchor peers: peer1:7051
+  Peers (2):
+    peer0:7051, MSP: Org1MSP, PKI-ID: 0a, ledger height: 10, chaincodes: none (self)
+    peer1:7051, MSP: Org1MSP, PKI-ID: 0b, ledger height: 9, chaincodes: mycc:1.0
+Channel ch2:
+  Leader: self (static)
+  Anchor peers: none
+  Peers (1):
+    peer0:7051, MSP: Org1MSP, PKI-ID: 0a, ledger height: 3, chaincodes: none (self)
+`, buf.String())
+	})
+
+	t.Run("json output for a channel", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		cmd := gossipInfoCmd(buf)
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "ch2", "-O", "json"})
+		require.NoError(t, cmd.Execute())
+
+		report := &introspection.Report{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), report))
+		expected := newReport()
+		expected.Channels = expected.Channels[1:]
+		require.Equal(t, expected, report)
+	})
+
+	t.Run("default operations address", func(t *testing.T) {
+		viper.Set("operations.listenAddress", address)
+		defer viper.Set("operations.listenAddress", "")
+		buf := &bytes.Buffer{}
+		cmd := gossipInfoCmd(buf)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, buf.String(), "Self: peer0:7051")
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		cmd := gossipInfoCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "ch3"})
+		require.EqualError(t, cmd.Execute(), "failed to retrieve the gossip information: channel ch3 not found")
+	})
+
+	t.Run("invalid output format", func(t *testing.T) {
+		cmd := gossipInfoCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address, "-O", "yaml"})
+		require.EqualError(t, cmd.Execute(), "invalid output format 'yaml', json is the only supported format")
+	})
+
+	t.Run("TLS files required", func(t *testing.T) {
+		viper.Set("operations.tls.enabled", true)
+		defer viper.Set("operations.tls.enabled", false)
+		cmd := gossipInfoCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'tlsRootCertFile' is empty. Rerun the command with --tlsRootCertFile flag")
+
+		cmd = gossipInfoCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address, "--tlsRootCertFile", "ca.pem"})
+		require.EqualError(t, cmd.Execute(), "the required parameters 'tlsCertFile' and 'tlsKeyFile' must be set. Rerun the command with --tlsCertFile and --tlsKeyFile flags")
+	})
+
+	t.Run("peer not reachable", func(t *testing.T) {
+		cmd := gossipInfoCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", "127.0.0.1:0"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to retrieve the gossip information")
+	})
+}
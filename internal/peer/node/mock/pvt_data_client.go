@@ -0,0 +1,201 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/pvtdatagrpc"
+	"google.golang.org/grpc"
+)
+
+type PvtDataClient struct {
+	ExportStub        func(context.Context, *pvtdatagrpc.SignedPvtDataRequest, ...grpc.CallOption) (pvtdatagrpc.PvtData_ExportClient, error)
+	exportMutex       sync.RWMutex
+	exportArgsForCall []struct {
+		arg1 context.Context
+		arg2 *pvtdatagrpc.SignedPvtDataRequest
+		arg3 []grpc.CallOption
+	}
+	exportReturns struct {
+		result1 pvtdatagrpc.PvtData_ExportClient
+		result2 error
+	}
+	exportReturnsOnCall map[int]struct {
+		result1 pvtdatagrpc.PvtData_ExportClient
+		result2 error
+	}
+	ImportStub        func(context.Context, *pvtdatagrpc.SignedPvtDataRequest, ...grpc.CallOption) (*pvtdatagrpc.ImportResponse, error)
+	importMutex       sync.RWMutex
+	importArgsForCall []struct {
+		arg1 context.Context
+		arg2 *pvtdatagrpc.SignedPvtDataRequest
+		arg3 []grpc.CallOption
+	}
+	importReturns struct {
+		result1 *pvtdatagrpc.ImportResponse
+		result2 error
+	}
+	importReturnsOnCall map[int]struct {
+		result1 *pvtdatagrpc.ImportResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PvtDataClient) Export(arg1 context.Context, arg2 *pvtdatagrpc.SignedPvtDataRequest, arg3 ...grpc.CallOption) (pvtdatagrpc.PvtData_ExportClient, error) {
+	fake.exportMutex.Lock()
+	ret, specificReturn := fake.exportReturnsOnCall[len(fake.exportArgsForCall)]
+	fake.exportArgsForCall = append(fake.exportArgsForCall, struct {
+		arg1 context.Context
+		arg2 *pvtdatagrpc.SignedPvtDataRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Export", []interface{}{arg1, arg2, arg3})
+	fake.exportMutex.Unlock()
+	if fake.ExportStub != nil {
+		return fake.ExportStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.exportReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PvtDataClient) ExportCallCount() int {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return len(fake.exportArgsForCall)
+}
+
+func (fake *PvtDataClient) ExportCalls(stub func(context.Context, *pvtdatagrpc.SignedPvtDataRequest, ...grpc.CallOption) (pvtdatagrpc.PvtData_ExportClient, error)) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = stub
+}
+
+func (fake *PvtDataClient) ExportArgsForCall(i int) (context.Context, *pvtdatagrpc.SignedPvtDataRequest, []grpc.CallOption) {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	argsForCall := fake.exportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PvtDataClient) ExportReturns(result1 pvtdatagrpc.PvtData_ExportClient, result2 error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = nil
+	fake.exportReturns = struct {
+		result1 pvtdatagrpc.PvtData_ExportClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PvtDataClient) ExportReturnsOnCall(i int, result1 pvtdatagrpc.PvtData_ExportClient, result2 error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = nil
+	if fake.exportReturnsOnCall == nil {
+		fake.exportReturnsOnCall = make(map[int]struct {
+			result1 pvtdatagrpc.PvtData_ExportClient
+			result2 error
+		})
+	}
+	fake.exportReturnsOnCall[i] = struct {
+		result1 pvtdatagrpc.PvtData_ExportClient
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PvtDataClient) Import(arg1 context.Context, arg2 *pvtdatagrpc.SignedPvtDataRequest, arg3 ...grpc.CallOption) (*pvtdatagrpc.ImportResponse, error) {
+	fake.importMutex.Lock()
+	ret, specificReturn := fake.importReturnsOnCall[len(fake.importArgsForCall)]
+	fake.importArgsForCall = append(fake.importArgsForCall, struct {
+		arg1 context.Context
+		arg2 *pvtdatagrpc.SignedPvtDataRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Import", []interface{}{arg1, arg2, arg3})
+	fake.importMutex.Unlock()
+	if fake.ImportStub != nil {
+		return fake.ImportStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.importReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PvtDataClient) ImportCallCount() int {
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	return len(fake.importArgsForCall)
+}
+
+func (fake *PvtDataClient) ImportCalls(stub func(context.Context, *pvtdatagrpc.SignedPvtDataRequest, ...grpc.CallOption) (*pvtdatagrpc.ImportResponse, error)) {
+	fake.importMutex.Lock()
+	defer fake.importMutex.Unlock()
+	fake.ImportStub = stub
+}
+
+func (fake *PvtDataClient) ImportArgsForCall(i int) (context.Context, *pvtdatagrpc.SignedPvtDataRequest, []grpc.CallOption) {
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	argsForCall := fake.importArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PvtDataClient) ImportReturns(result1 *pvtdatagrpc.ImportResponse, result2 error) {
+	fake.importMutex.Lock()
+	defer fake.importMutex.Unlock()
+	fake.ImportStub = nil
+	fake.importReturns = struct {
+		result1 *pvtdatagrpc.ImportResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PvtDataClient) ImportReturnsOnCall(i int, result1 *pvtdatagrpc.ImportResponse, result2 error) {
+	fake.importMutex.Lock()
+	defer fake.importMutex.Unlock()
+	fake.ImportStub = nil
+	if fake.importReturnsOnCall == nil {
+		fake.importReturnsOnCall = make(map[int]struct {
+			result1 *pvtdatagrpc.ImportResponse
+			result2 error
+		})
+	}
+	fake.importReturnsOnCall[i] = struct {
+		result1 *pvtdatagrpc.ImportResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PvtDataClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	fake.importMutex.RLock()
+	defer fake.importMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PvtDataClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,366 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/gossip/privdata/reconcilegrpc"
+	"google.golang.org/grpc"
+)
+
+type ReconciliationClient struct {
+	PauseStub        func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*empty.Empty, error)
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}
+	pauseReturns struct {
+		result1 *empty.Empty
+		result2 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 *empty.Empty
+		result2 error
+	}
+	ReconcileStub        func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*empty.Empty, error)
+	reconcileMutex       sync.RWMutex
+	reconcileArgsForCall []struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}
+	reconcileReturns struct {
+		result1 *empty.Empty
+		result2 error
+	}
+	reconcileReturnsOnCall map[int]struct {
+		result1 *empty.Empty
+		result2 error
+	}
+	ResumeStub        func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*empty.Empty, error)
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}
+	resumeReturns struct {
+		result1 *empty.Empty
+		result2 error
+	}
+	resumeReturnsOnCall map[int]struct {
+		result1 *empty.Empty
+		result2 error
+	}
+	StatusStub        func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*reconcilegrpc.ReconciliationStatusResponse, error)
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}
+	statusReturns struct {
+		result1 *reconcilegrpc.ReconciliationStatusResponse
+		result2 error
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 *reconcilegrpc.ReconciliationStatusResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ReconciliationClient) Pause(arg1 context.Context, arg2 *reconcilegrpc.SignedReconciliationRequest, arg3 ...grpc.CallOption) (*empty.Empty, error) {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Pause", []interface{}{arg1, arg2, arg3})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pauseReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReconciliationClient) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *ReconciliationClient) PauseCalls(stub func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*empty.Empty, error)) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = stub
+}
+
+func (fake *ReconciliationClient) PauseArgsForCall(i int) (context.Context, *reconcilegrpc.SignedReconciliationRequest, []grpc.CallOption) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	argsForCall := fake.pauseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ReconciliationClient) PauseReturns(result1 *empty.Empty, result2 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 *empty.Empty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) PauseReturnsOnCall(i int, result1 *empty.Empty, result2 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 *empty.Empty
+			result2 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 *empty.Empty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) Reconcile(arg1 context.Context, arg2 *reconcilegrpc.SignedReconciliationRequest, arg3 ...grpc.CallOption) (*empty.Empty, error) {
+	fake.reconcileMutex.Lock()
+	ret, specificReturn := fake.reconcileReturnsOnCall[len(fake.reconcileArgsForCall)]
+	fake.reconcileArgsForCall = append(fake.reconcileArgsForCall, struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Reconcile", []interface{}{arg1, arg2, arg3})
+	fake.reconcileMutex.Unlock()
+	if fake.ReconcileStub != nil {
+		return fake.ReconcileStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.reconcileReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReconciliationClient) ReconcileCallCount() int {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	return len(fake.reconcileArgsForCall)
+}
+
+func (fake *ReconciliationClient) ReconcileCalls(stub func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*empty.Empty, error)) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = stub
+}
+
+func (fake *ReconciliationClient) ReconcileArgsForCall(i int) (context.Context, *reconcilegrpc.SignedReconciliationRequest, []grpc.CallOption) {
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	argsForCall := fake.reconcileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ReconciliationClient) ReconcileReturns(result1 *empty.Empty, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	fake.reconcileReturns = struct {
+		result1 *empty.Empty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) ReconcileReturnsOnCall(i int, result1 *empty.Empty, result2 error) {
+	fake.reconcileMutex.Lock()
+	defer fake.reconcileMutex.Unlock()
+	fake.ReconcileStub = nil
+	if fake.reconcileReturnsOnCall == nil {
+		fake.reconcileReturnsOnCall = make(map[int]struct {
+			result1 *empty.Empty
+			result2 error
+		})
+	}
+	fake.reconcileReturnsOnCall[i] = struct {
+		result1 *empty.Empty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) Resume(arg1 context.Context, arg2 *reconcilegrpc.SignedReconciliationRequest, arg3 ...grpc.CallOption) (*empty.Empty, error) {
+	fake.resumeMutex.Lock()
+	ret, specificReturn := fake.resumeReturnsOnCall[len(fake.resumeArgsForCall)]
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Resume", []interface{}{arg1, arg2, arg3})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resumeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReconciliationClient) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *ReconciliationClient) ResumeCalls(stub func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*empty.Empty, error)) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = stub
+}
+
+func (fake *ReconciliationClient) ResumeArgsForCall(i int) (context.Context, *reconcilegrpc.SignedReconciliationRequest, []grpc.CallOption) {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	argsForCall := fake.resumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ReconciliationClient) ResumeReturns(result1 *empty.Empty, result2 error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 *empty.Empty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) ResumeReturnsOnCall(i int, result1 *empty.Empty, result2 error) {
+	fake.resumeMutex.Lock()
+	defer fake.resumeMutex.Unlock()
+	fake.ResumeStub = nil
+	if fake.resumeReturnsOnCall == nil {
+		fake.resumeReturnsOnCall = make(map[int]struct {
+			result1 *empty.Empty
+			result2 error
+		})
+	}
+	fake.resumeReturnsOnCall[i] = struct {
+		result1 *empty.Empty
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) Status(arg1 context.Context, arg2 *reconcilegrpc.SignedReconciliationRequest, arg3 ...grpc.CallOption) (*reconcilegrpc.ReconciliationStatusResponse, error) {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+		arg1 context.Context
+		arg2 *reconcilegrpc.SignedReconciliationRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Status", []interface{}{arg1, arg2, arg3})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReconciliationClient) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *ReconciliationClient) StatusCalls(stub func(context.Context, *reconcilegrpc.SignedReconciliationRequest, ...grpc.CallOption) (*reconcilegrpc.ReconciliationStatusResponse, error)) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *ReconciliationClient) StatusArgsForCall(i int) (context.Context, *reconcilegrpc.SignedReconciliationRequest, []grpc.CallOption) {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	argsForCall := fake.statusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ReconciliationClient) StatusReturns(result1 *reconcilegrpc.ReconciliationStatusResponse, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 *reconcilegrpc.ReconciliationStatusResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) StatusReturnsOnCall(i int, result1 *reconcilegrpc.ReconciliationStatusResponse, result2 error) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 *reconcilegrpc.ReconciliationStatusResponse
+			result2 error
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 *reconcilegrpc.ReconciliationStatusResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReconciliationClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.reconcileMutex.RLock()
+	defer fake.reconcileMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ReconciliationClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
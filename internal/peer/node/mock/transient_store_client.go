@@ -0,0 +1,283 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/transientstore/transientstoregrpc"
+	"google.golang.org/grpc"
+)
+
+type TransientStoreClient struct {
+	ListStub        func(context.Context, *transientstoregrpc.SignedTransientStoreRequest, ...grpc.CallOption) (*transientstoregrpc.ListResponse, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		arg1 context.Context
+		arg2 *transientstoregrpc.SignedTransientStoreRequest
+		arg3 []grpc.CallOption
+	}
+	listReturns struct {
+		result1 *transientstoregrpc.ListResponse
+		result2 error
+	}
+	listReturnsOnCall map[int]struct {
+		result1 *transientstoregrpc.ListResponse
+		result2 error
+	}
+	PurgeStub        func(context.Context, *transientstoregrpc.SignedTransientStoreRequest, ...grpc.CallOption) (*transientstoregrpc.PurgeResponse, error)
+	purgeMutex       sync.RWMutex
+	purgeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *transientstoregrpc.SignedTransientStoreRequest
+		arg3 []grpc.CallOption
+	}
+	purgeReturns struct {
+		result1 *transientstoregrpc.PurgeResponse
+		result2 error
+	}
+	purgeReturnsOnCall map[int]struct {
+		result1 *transientstoregrpc.PurgeResponse
+		result2 error
+	}
+	StatsStub        func(context.Context, *transientstoregrpc.SignedTransientStoreRequest, ...grpc.CallOption) (*transientstoregrpc.StatsResponse, error)
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *transientstoregrpc.SignedTransientStoreRequest
+		arg3 []grpc.CallOption
+	}
+	statsReturns struct {
+		result1 *transientstoregrpc.StatsResponse
+		result2 error
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 *transientstoregrpc.StatsResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TransientStoreClient) List(arg1 context.Context, arg2 *transientstoregrpc.SignedTransientStoreRequest, arg3 ...grpc.CallOption) (*transientstoregrpc.ListResponse, error) {
+	fake.listMutex.Lock()
+	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		arg1 context.Context
+		arg2 *transientstoregrpc.SignedTransientStoreRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("List", []interface{}{arg1, arg2, arg3})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransientStoreClient) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *TransientStoreClient) ListCalls(stub func(context.Context, *transientstoregrpc.SignedTransientStoreRequest, ...grpc.CallOption) (*transientstoregrpc.ListResponse, error)) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = stub
+}
+
+func (fake *TransientStoreClient) ListArgsForCall(i int) (context.Context, *transientstoregrpc.SignedTransientStoreRequest, []grpc.CallOption) {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TransientStoreClient) ListReturns(result1 *transientstoregrpc.ListResponse, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 *transientstoregrpc.ListResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransientStoreClient) ListReturnsOnCall(i int, result1 *transientstoregrpc.ListResponse, result2 error) {
+	fake.listMutex.Lock()
+	defer fake.listMutex.Unlock()
+	fake.ListStub = nil
+	if fake.listReturnsOnCall == nil {
+		fake.listReturnsOnCall = make(map[int]struct {
+			result1 *transientstoregrpc.ListResponse
+			result2 error
+		})
+	}
+	fake.listReturnsOnCall[i] = struct {
+		result1 *transientstoregrpc.ListResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransientStoreClient) Purge(arg1 context.Context, arg2 *transientstoregrpc.SignedTransientStoreRequest, arg3 ...grpc.CallOption) (*transientstoregrpc.PurgeResponse, error) {
+	fake.purgeMutex.Lock()
+	ret, specificReturn := fake.purgeReturnsOnCall[len(fake.purgeArgsForCall)]
+	fake.purgeArgsForCall = append(fake.purgeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *transientstoregrpc.SignedTransientStoreRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Purge", []interface{}{arg1, arg2, arg3})
+	fake.purgeMutex.Unlock()
+	if fake.PurgeStub != nil {
+		return fake.PurgeStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.purgeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransientStoreClient) PurgeCallCount() int {
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	return len(fake.purgeArgsForCall)
+}
+
+func (fake *TransientStoreClient) PurgeCalls(stub func(context.Context, *transientstoregrpc.SignedTransientStoreRequest, ...grpc.CallOption) (*transientstoregrpc.PurgeResponse, error)) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = stub
+}
+
+func (fake *TransientStoreClient) PurgeArgsForCall(i int) (context.Context, *transientstoregrpc.SignedTransientStoreRequest, []grpc.CallOption) {
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	argsForCall := fake.purgeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TransientStoreClient) PurgeReturns(result1 *transientstoregrpc.PurgeResponse, result2 error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = nil
+	fake.purgeReturns = struct {
+		result1 *transientstoregrpc.PurgeResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransientStoreClient) PurgeReturnsOnCall(i int, result1 *transientstoregrpc.PurgeResponse, result2 error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = nil
+	if fake.purgeReturnsOnCall == nil {
+		fake.purgeReturnsOnCall = make(map[int]struct {
+			result1 *transientstoregrpc.PurgeResponse
+			result2 error
+		})
+	}
+	fake.purgeReturnsOnCall[i] = struct {
+		result1 *transientstoregrpc.PurgeResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransientStoreClient) Stats(arg1 context.Context, arg2 *transientstoregrpc.SignedTransientStoreRequest, arg3 ...grpc.CallOption) (*transientstoregrpc.StatsResponse, error) {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *transientstoregrpc.SignedTransientStoreRequest
+		arg3 []grpc.CallOption
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Stats", []interface{}{arg1, arg2, arg3})
+	fake.statsMutex.Unlock()
+	if fake.StatsStub != nil {
+		return fake.StatsStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TransientStoreClient) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *TransientStoreClient) StatsCalls(stub func(context.Context, *transientstoregrpc.SignedTransientStoreRequest, ...grpc.CallOption) (*transientstoregrpc.StatsResponse, error)) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *TransientStoreClient) StatsArgsForCall(i int) (context.Context, *transientstoregrpc.SignedTransientStoreRequest, []grpc.CallOption) {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	argsForCall := fake.statsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TransientStoreClient) StatsReturns(result1 *transientstoregrpc.StatsResponse, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 *transientstoregrpc.StatsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransientStoreClient) StatsReturnsOnCall(i int, result1 *transientstoregrpc.StatsResponse, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 *transientstoregrpc.StatsResponse
+			result2 error
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 *transientstoregrpc.StatsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TransientStoreClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TransientStoreClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
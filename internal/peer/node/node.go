@@ -15,7 +15,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|reset|rollback|pause|resume|rebuild-dbs|unjoin|upgrade-dbs."
+	nodeCmdDes   = "Operate a peer node: start|reset|rollback|pause|resume|rebuild-dbs|unjoin|upgrade-dbs|reconcile|pvtdata|transientstore|generate-pvtdata-key|gossip-info|yield-leadership."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -30,6 +30,12 @@ func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(rebuildDBsCmd())
 	nodeCmd.AddCommand(unjoinCmd())
 	nodeCmd.AddCommand(upgradeDBsCmd())
+	nodeCmd.AddCommand(reconcileCmd(nil))
+	nodeCmd.AddCommand(pvtDataCmd(nil))
+	nodeCmd.AddCommand(transientStoreCmd(nil))
+	nodeCmd.AddCommand(generatePvtDataKeyCmd(nil, nil))
+	nodeCmd.AddCommand(gossipInfoCmd(nil))
+	nodeCmd.AddCommand(yieldLeadershipCmd(nil))
 	return nodeCmd
 }
 
@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatagrpc"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// pvtDataClient holds client side dependencies for the pvtdata commands
+type pvtDataClient struct {
+	pvtDataClient pvtdatagrpc.PvtDataClient
+	signer        common.Signer
+	writer        io.Writer
+}
+
+// newPvtDataClient creates a client connected to the peer
+func newPvtDataClient(p *reconcileParameters) (*pvtDataClient, error) {
+	client, err := common.GetPvtDataClient(p.peerAddress, p.tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve private data client")
+	}
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve default signer")
+	}
+
+	return &pvtDataClient{
+		pvtDataClient: client,
+		signer:        signer,
+		writer:        os.Stdout,
+	}, nil
+}
+
+func pvtDataCmd(cl *pvtDataClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pvtdata",
+		Short: "Export or import private data: export|import.",
+		Long:  "Export the private data of a collection held by a running peer to a file, or import such a file into a running peer: export|import.",
+	}
+	cmd.AddCommand(pvtDataExportCmd(cl))
+	cmd.AddCommand(pvtDataImportCmd(cl))
+
+	return cmd
+}
+
+func pvtDataExportCmd(cl *pvtDataClient) *cobra.Command {
+	params := &reconcileParameters{}
+	header := &pvtdatagrpc.PvtDataExportHeader{}
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the private data of a collection to a file.",
+		Long: "Export the private data of a collection committed in a range of blocks to a file. Each record of the file holds" +
+			" the private write set of a transaction along with its block and transaction numbers, so that it can be checked" +
+			" against the hash committed in the block. Either the whole history or only the records that hold the current" +
+			" value of a key are exported.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if header.ChaincodeName == "" {
+				return errors.New("the required parameter 'chaincode' is empty. Rerun the command with --chaincode flag")
+			}
+			if header.CollectionName == "" {
+				return errors.New("the required parameter 'collection' is empty. Rerun the command with --collection flag")
+			}
+			if outputFile == "" {
+				return errors.New("the required parameter 'output' is empty. Rerun the command with --output flag")
+			}
+			if header.EndBlock != 0 && header.EndBlock < header.StartBlock {
+				return errors.New("the parameter 'endBlock' must not be lower than 'startBlock'")
+			}
+			if err := params.validate(); err != nil {
+				return err
+			}
+			header.ChannelId = params.channelID
+
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			cl, err := ensurePvtDataClient(cl, params)
+			if err != nil {
+				return err
+			}
+			signedRequest, err := signPvtDataRequest(cl.signer, &pvtdatagrpc.PvtDataRequest{
+				ChannelId:      header.ChannelId,
+				ChaincodeName:  header.ChaincodeName,
+				CollectionName: header.CollectionName,
+				StartBlock:     header.StartBlock,
+				EndBlock:       header.EndBlock,
+				StateOnly:      header.StateOnly,
+			})
+			if err != nil {
+				return err
+			}
+
+			stream, err := cl.pvtDataClient.Export(context.Background(), signedRequest)
+			if err != nil {
+				return errors.WithMessage(err, "failed to export private data")
+			}
+			return writeExportFile(cl.writer, outputFile, header, stream)
+		},
+	}
+	params.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringVarP(&header.ChaincodeName, "chaincode", "", "", "The name of the chaincode of the collection")
+	flags.StringVarP(&header.CollectionName, "collection", "", "", "The name of the collection")
+	flags.Uint64VarP(&header.StartBlock, "startBlock", "", 0, "The first block to export")
+	flags.Uint64VarP(&header.EndBlock, "endBlock", "", 0, "The last block to export. Defaults to the last committed block.")
+	flags.BoolVarP(&header.StateOnly, "stateOnly", "", false, "Only export the records that hold the current value of a key, instead of the whole history")
+	flags.StringVarP(&outputFile, "output", "", "", "The file to write the private data to. It must not exist.")
+
+	return cmd
+}
+
+func pvtDataImportCmd(cl *pvtDataClient) *cobra.Command {
+	params := &reconcileParameters{}
+	var inputFile string
+	var batchSize int
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import private data from an export file.",
+		Long: "Import the private data of an export file into a running peer. The peer checks each record against the hash" +
+			" committed in the block and commits the matching records as reconciled private data, while the other records are rejected.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputFile == "" {
+				return errors.New("the required parameter 'input' is empty. Rerun the command with --input flag")
+			}
+			if batchSize <= 0 {
+				return errors.New("the parameter 'batchSize' must be positive")
+			}
+			if err := params.validate(); err != nil {
+				return err
+			}
+
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			reader, header, err := pvtdatagrpc.OpenExportFile(inputFile)
+			if err != nil {
+				return errors.WithMessage(err, "failed to open export file")
+			}
+			defer reader.Close()
+			if header.ChannelId != params.channelID {
+				return errors.Errorf("the export file holds the private data of channel %s", header.ChannelId)
+			}
+
+			cl, err := ensurePvtDataClient(cl, params)
+			if err != nil {
+				return err
+			}
+			return importExportFile(cl, params.channelID, reader, batchSize)
+		},
+	}
+	params.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringVarP(&inputFile, "input", "", "", "The export file to import")
+	flags.IntVarP(&batchSize, "batchSize", "", 1000, "The maximum number of records sent to the peer in a single request")
+
+	return cmd
+}
+
+// ensurePvtDataClient creates a client if not provided
+func ensurePvtDataClient(cl *pvtDataClient, params *reconcileParameters) (*pvtDataClient, error) {
+	if cl != nil {
+		return cl, nil
+	}
+	return newPvtDataClient(params)
+}
+
+// writeExportFile writes the records received from the peer to the output file, which is removed on failure
+func writeExportFile(w io.Writer, outputFile string, header *pvtdatagrpc.PvtDataExportHeader, stream pvtdatagrpc.PvtData_ExportClient) (err error) {
+	fileWriter, err := pvtdatagrpc.CreateExportFile(outputFile, header)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create export file")
+	}
+	defer func() {
+		if err != nil {
+			fileWriter.Close()
+			os.Remove(outputFile)
+		}
+	}()
+
+	for {
+		record, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithMessage(err, "failed to export private data")
+		}
+		if err := fileWriter.Write(record); err != nil {
+			return errors.WithMessage(err, "failed to write export file")
+		}
+	}
+
+	hash, err := fileWriter.Done()
+	if err != nil {
+		return errors.WithMessage(err, "failed to write export file")
+	}
+	fmt.Fprintf(w, "Exported %d records to %s\n", fileWriter.Count(), outputFile)
+	fmt.Fprintf(w, "SHA256: %s\n", hex.EncodeToString(hash))
+	return nil
+}
+
+// importExportFile sends the records of the export file to the peer in batches, and reports the outcome
+func importExportFile(cl *pvtDataClient, channelID string, reader *pvtdatagrpc.ExportFileReader, batchSize int) error {
+	var imported uint64
+	var rejected []*pvtdatagrpc.RejectedRecord
+
+	importBatch := func(records []*pvtdatagrpc.PvtDataRecord) error {
+		signedRequest, err := signPvtDataRequest(cl.signer, &pvtdatagrpc.PvtDataRequest{
+			ChannelId: channelID,
+			Records:   records,
+		})
+		if err != nil {
+			return err
+		}
+		response, err := cl.pvtDataClient.Import(context.Background(), signedRequest)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to import private data, %d records were imported", imported)
+		}
+		imported += response.Imported
+		rejected = append(rejected, response.Rejected...)
+		return nil
+	}
+
+	var batch []*pvtdatagrpc.PvtDataRecord
+	for {
+		record, err := reader.Next()
+		if err != nil {
+			return errors.WithMessage(err, "failed to read export file")
+		}
+		if record == nil {
+			break
+		}
+		batch = append(batch, record)
+		if len(batch) == batchSize {
+			if err := importBatch(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		if err := importBatch(batch); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cl.writer, "Imported %d records\n", imported)
+	if len(rejected) > 0 {
+		fmt.Fprintf(cl.writer, "Rejected %d records:\n", len(rejected))
+		for _, r := range rejected {
+			fmt.Fprintf(cl.writer, "  block %d, transaction %d, collection %s:%s: %s\n", r.BlockNum, r.TxNum, r.ChaincodeName, r.CollectionName, r.Reason)
+		}
+	}
+	return nil
+}
+
+// signPvtDataRequest completes the request with a signature header and signs it
+func signPvtDataRequest(signer common.Signer, request *pvtdatagrpc.PvtDataRequest) (*pvtdatagrpc.SignedPvtDataRequest, error) {
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return nil, err
+	}
+	request.SignatureHeader = &cb.SignatureHeader{
+		Creator: creator,
+		Nonce:   nonce,
+	}
+
+	requestBytes := protoutil.MarshalOrPanic(request)
+	signature, err := signer.Sign(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pvtdatagrpc.SignedPvtDataRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	}, nil
+}
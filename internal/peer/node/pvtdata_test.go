@@ -0,0 +1,282 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatagrpc"
+	"github.com/hyperledger/fabric/internal/peer/node/mock"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/pvt_data_client.go -fake-name PvtDataClient . pvtDataGRPCClient
+
+type pvtDataGRPCClient interface {
+	pvtdatagrpc.PvtDataClient
+}
+
+//go:generate counterfeiter -o mock/pvt_data_export_client.go -fake-name PvtDataExportClient . pvtDataExportClient
+
+type pvtDataExportClient interface {
+	pvtdatagrpc.PvtData_ExportClient
+}
+
+func newTestPvtDataClient() (*pvtDataClient, *mock.PvtDataClient, *mock.Signer, *gbytes.Buffer) {
+	mockSigner := &mock.Signer{}
+	mockSigner.SerializeReturns([]byte("creator"), nil)
+	mockSigner.SignReturns([]byte("pvtdata-request-signature"), nil)
+	mockClient := &mock.PvtDataClient{}
+	buffer := gbytes.NewBuffer()
+	return &pvtDataClient{mockClient, mockSigner, buffer}, mockClient, mockSigner, buffer
+}
+
+func testRecords(count int) []*pvtdatagrpc.PvtDataRecord {
+	var records []*pvtdatagrpc.PvtDataRecord
+	for i := 0; i < count; i++ {
+		rwset := []byte(fmt.Sprintf("rwset-%d", i))
+		records = append(records, &pvtdatagrpc.PvtDataRecord{
+			BlockNum:       uint64(i + 1),
+			ChaincodeName:  "cc",
+			CollectionName: "coll",
+			Rwset:          rwset,
+			RwsetHash:      util.ComputeSHA256(rwset),
+		})
+	}
+	return records
+}
+
+func newExportStream(records []*pvtdatagrpc.PvtDataRecord, err error) *mock.PvtDataExportClient {
+	stream := &mock.PvtDataExportClient{}
+	for i, record := range records {
+		stream.RecvReturnsOnCall(i, record, nil)
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	stream.RecvReturnsOnCall(len(records), nil, err)
+	return stream
+}
+
+func TestPvtDataExportCmd(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "pvtdata")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	cl, mockClient, _, buffer := newTestPvtDataClient()
+	records := testRecords(3)
+	mockClient.ExportReturns(newExportStream(records, nil), nil)
+
+	outputFile := filepath.Join(testDir, "export")
+	cmd := pvtDataCmd(cl)
+	cmd.SetArgs([]string{
+		"export", "-c", "mychannel", "--chaincode", "cc", "--collection", "coll",
+		"--startBlock", "1", "--endBlock", "3", "--stateOnly", "--output", outputFile,
+	})
+	require.NoError(t, cmd.Execute())
+
+	content, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	hash := sha256.Sum256(content)
+	require.Equal(t, fmt.Sprintf("Exported 3 records to %s\nSHA256: %s\n", outputFile, hex.EncodeToString(hash[:])), string(buffer.Contents()))
+
+	require.Equal(t, 1, mockClient.ExportCallCount())
+	_, signedRequest, _ := mockClient.ExportArgsForCall(0)
+	require.Equal(t, []byte("pvtdata-request-signature"), signedRequest.Signature)
+	request := &pvtdatagrpc.PvtDataRequest{}
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, []byte("creator"), request.SignatureHeader.Creator)
+	require.Equal(t, "cc", request.ChaincodeName)
+	require.Equal(t, "coll", request.CollectionName)
+	require.EqualValues(t, 1, request.StartBlock)
+	require.EqualValues(t, 3, request.EndBlock)
+	require.True(t, request.StateOnly)
+
+	reader, header, err := pvtdatagrpc.OpenExportFile(outputFile)
+	require.NoError(t, err)
+	defer reader.Close()
+	require.True(t, proto.Equal(&pvtdatagrpc.PvtDataExportHeader{
+		ChannelId:      "mychannel",
+		ChaincodeName:  "cc",
+		CollectionName: "coll",
+		StartBlock:     1,
+		EndBlock:       3,
+		StateOnly:      true,
+	}, header))
+	for _, expected := range records {
+		record, err := reader.Next()
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, record))
+	}
+	record, err := reader.Next()
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// the file is removed if the export fails
+	failedFile := filepath.Join(testDir, "failed")
+	mockClient.ExportReturns(newExportStream(records, fmt.Errorf("fake-recv-error")), nil)
+	cmd = pvtDataCmd(cl)
+	cmd.SetArgs([]string{"export", "-c", "mychannel", "--chaincode", "cc", "--collection", "coll", "--output", failedFile})
+	require.EqualError(t, cmd.Execute(), "failed to export private data: fake-recv-error")
+	_, err = os.Stat(failedFile)
+	require.True(t, os.IsNotExist(err))
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "missing chaincode",
+			args:   []string{"export", "-c", "mychannel", "--collection", "coll", "--output", failedFile},
+			errMsg: "the required parameter 'chaincode' is empty. Rerun the command with --chaincode flag",
+		},
+		{
+			name:   "missing collection",
+			args:   []string{"export", "-c", "mychannel", "--chaincode", "cc", "--output", failedFile},
+			errMsg: "the required parameter 'collection' is empty. Rerun the command with --collection flag",
+		},
+		{
+			name:   "missing output",
+			args:   []string{"export", "-c", "mychannel", "--chaincode", "cc", "--collection", "coll"},
+			errMsg: "the required parameter 'output' is empty. Rerun the command with --output flag",
+		},
+		{
+			name:   "invalid block range",
+			args:   []string{"export", "-c", "mychannel", "--chaincode", "cc", "--collection", "coll", "--output", failedFile, "--startBlock", "20", "--endBlock", "10"},
+			errMsg: "the parameter 'endBlock' must not be lower than 'startBlock'",
+		},
+		{
+			name:   "missing channel",
+			args:   []string{"export", "--chaincode", "cc", "--collection", "coll", "--output", failedFile},
+			errMsg: "the required parameter 'channelID' is empty. Rerun the command with -c flag",
+		},
+		{
+			name:   "existing output",
+			args:   []string{"export", "-c", "mychannel", "--chaincode", "cc", "--collection", "coll", "--output", outputFile},
+			errMsg: fmt.Sprintf("failed to create export file: error while creating the snapshot file: %s: open %s: file exists", outputFile, outputFile),
+		},
+	}
+	mockClient.ExportReturns(newExportStream(nil, nil), nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := pvtDataCmd(cl)
+			cmd.SetArgs(tt.args)
+			require.EqualError(t, cmd.Execute(), tt.errMsg)
+		})
+	}
+
+	mockClient.ExportReturns(nil, fmt.Errorf("fake-export-error"))
+	cmd = pvtDataCmd(cl)
+	cmd.SetArgs([]string{"export", "-c", "mychannel", "--chaincode", "cc", "--collection", "coll", "--output", failedFile})
+	require.EqualError(t, cmd.Execute(), "failed to export private data: fake-export-error")
+}
+
+func TestPvtDataImportCmd(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "pvtdata")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	records := testRecords(5)
+	inputFile := filepath.Join(testDir, "export")
+	w, err := pvtdatagrpc.CreateExportFile(inputFile, &pvtdatagrpc.PvtDataExportHeader{ChannelId: "mychannel"})
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, w.Write(record))
+	}
+	_, err = w.Done()
+	require.NoError(t, err)
+
+	cl, mockClient, _, buffer := newTestPvtDataClient()
+	mockClient.ImportReturnsOnCall(0, &pvtdatagrpc.ImportResponse{Imported: 2}, nil)
+	mockClient.ImportReturnsOnCall(1, &pvtdatagrpc.ImportResponse{
+		Imported: 1,
+		Rejected: []*pvtdatagrpc.RejectedRecord{
+			{BlockNum: 4, ChaincodeName: "cc", CollectionName: "coll", Reason: "fake-reason"},
+		},
+	}, nil)
+	mockClient.ImportReturnsOnCall(2, &pvtdatagrpc.ImportResponse{Imported: 1}, nil)
+
+	cmd := pvtDataCmd(cl)
+	cmd.SetArgs([]string{"import", "-c", "mychannel", "--input", inputFile, "--batchSize", "2"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Imported 4 records\n"+
+		"Rejected 1 records:\n"+
+		"  block 4, transaction 0, collection cc:coll: fake-reason\n", string(buffer.Contents()))
+
+	require.Equal(t, 3, mockClient.ImportCallCount())
+	var imported []*pvtdatagrpc.PvtDataRecord
+	for i := 0; i < 3; i++ {
+		_, signedRequest, _ := mockClient.ImportArgsForCall(i)
+		require.Equal(t, []byte("pvtdata-request-signature"), signedRequest.Signature)
+		request := &pvtdatagrpc.PvtDataRequest{}
+		require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+		require.Equal(t, "mychannel", request.ChannelId)
+		require.Equal(t, []byte("creator"), request.SignatureHeader.Creator)
+		imported = append(imported, request.Records...)
+	}
+	require.Len(t, imported, len(records))
+	for i := range records {
+		require.True(t, proto.Equal(records[i], imported[i]))
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "missing input",
+			args:   []string{"import", "-c", "mychannel"},
+			errMsg: "the required parameter 'input' is empty. Rerun the command with --input flag",
+		},
+		{
+			name:   "invalid batch size",
+			args:   []string{"import", "-c", "mychannel", "--input", inputFile, "--batchSize", "0"},
+			errMsg: "the parameter 'batchSize' must be positive",
+		},
+		{
+			name:   "missing channel",
+			args:   []string{"import", "--input", inputFile},
+			errMsg: "the required parameter 'channelID' is empty. Rerun the command with -c flag",
+		},
+		{
+			name:   "other channel",
+			args:   []string{"import", "-c", "otherchannel", "--input", inputFile},
+			errMsg: "the export file holds the private data of channel mychannel",
+		},
+		{
+			name:   "missing file",
+			args:   []string{"import", "-c", "mychannel", "--input", filepath.Join(testDir, "missing")},
+			errMsg: fmt.Sprintf("failed to open export file: error while opening the snapshot file: %s: open %s: no such file or directory", filepath.Join(testDir, "missing"), filepath.Join(testDir, "missing")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := pvtDataCmd(cl)
+			cmd.SetArgs(tt.args)
+			require.EqualError(t, cmd.Execute(), tt.errMsg)
+		})
+	}
+
+	mockClient.ImportReturns(nil, fmt.Errorf("fake-import-error"))
+	mockClient.ImportReturnsOnCall(3, &pvtdatagrpc.ImportResponse{Imported: 2}, nil)
+	cmd = pvtDataCmd(cl)
+	cmd.SetArgs([]string{"import", "-c", "mychannel", "--input", inputFile, "--batchSize", "2"})
+	require.EqualError(t, cmd.Execute(), "failed to import private data, 2 records were imported: fake-import-error")
+}
@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/gossip/privdata/reconcilegrpc"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// reconcileClient holds client side dependencies for the reconcile commands
+type reconcileClient struct {
+	reconciliationClient reconcilegrpc.ReconciliationClient
+	signer               common.Signer
+	writer               io.Writer
+}
+
+// reconcileParameters are the flags shared by the reconcile commands
+type reconcileParameters struct {
+	channelID       string
+	peerAddress     string
+	tlsRootCertFile string
+}
+
+func (p *reconcileParameters) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&p.channelID, "channelID", "c", "", "The channel on which this command should be executed")
+	flags.StringVarP(&p.peerAddress, "peerAddress", "", "", "The address of the peer to connect to")
+	flags.StringVarP(&p.tlsRootCertFile, "tlsRootCertFile", "", "",
+		"The path to the TLS root cert file of the peer to connect to, required if TLS is enabled and ignored if TLS is disabled.")
+}
+
+func (p *reconcileParameters) validate() error {
+	if p.channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
+	switch viper.GetBool("peer.tls.enabled") {
+	case true:
+		if p.tlsRootCertFile == "" {
+			return errors.New("the required parameter 'tlsRootCertFile' is empty. Rerun the command with --tlsRootCertFile flag")
+		}
+	case false:
+		p.tlsRootCertFile = ""
+	}
+	return nil
+}
+
+// newReconcileClient creates a client connected to the peer
+func newReconcileClient(p *reconcileParameters) (*reconcileClient, error) {
+	reconciliationClient, err := common.GetReconciliationClient(p.peerAddress, p.tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve reconciliation client")
+	}
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve default signer")
+	}
+
+	return &reconcileClient{
+		reconciliationClient: reconciliationClient,
+		signer:               signer,
+		writer:               os.Stdout,
+	}, nil
+}
+
+func reconcileCmd(cl *reconcileClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Control the reconciliation of missing private data: trigger|pause|resume|status.",
+		Long:  "Control the reconciliation of missing private data on a channel of a running peer: trigger|pause|resume|status.",
+	}
+	cmd.AddCommand(reconcileTriggerCmd(cl))
+	cmd.AddCommand(reconcilePauseCmd(cl))
+	cmd.AddCommand(reconcileResumeCmd(cl))
+	cmd.AddCommand(reconcileStatusCmd(cl))
+
+	return cmd
+}
+
+func reconcileTriggerCmd(cl *reconcileClient) *cobra.Command {
+	params := &reconcileParameters{}
+	var collections []string
+	var startBlock, endBlock uint64
+
+	cmd := &cobra.Command{
+		Use:   "trigger",
+		Short: "Reconcile missing private data immediately.",
+		Long: "Reconcile the missing private data of a channel immediately, without waiting for the reconciliation interval." +
+			" The reconciliation can be restricted to some collections, and to a range of blocks.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var references []*reconcilegrpc.CollectionReference
+			for _, collection := range collections {
+				parts := strings.Split(collection, ":")
+				if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return errors.Errorf("invalid collection '%s', expected <chaincode name>:<collection name>", collection)
+				}
+				references = append(references, &reconcilegrpc.CollectionReference{ChaincodeName: parts[0], CollectionName: parts[1]})
+			}
+			if endBlock != 0 && endBlock < startBlock {
+				return errors.New("the parameter 'endBlock' must not be lower than 'startBlock'")
+			}
+
+			return callReconciliationService(cmd, cl, params, func(request *reconcilegrpc.ReconciliationRequest) {
+				request.Collections = references
+				request.StartBlock = startBlock
+				request.EndBlock = endBlock
+			}, func(cl *reconcileClient, signedRequest *reconcilegrpc.SignedReconciliationRequest) error {
+				if _, err := cl.reconciliationClient.Reconcile(context.Background(), signedRequest); err != nil {
+					return errors.WithMessage(err, "failed to trigger reconciliation")
+				}
+				fmt.Fprint(cl.writer, "Reconciliation triggered successfully\n")
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&collections, "collections", "", nil, "The collections to reconcile, as a comma separated list of <chaincode name>:<collection name>. All collections are reconciled if not provided.")
+	flags.Uint64VarP(&startBlock, "startBlock", "", 0, "The first block to reconcile")
+	flags.Uint64VarP(&endBlock, "endBlock", "", 0, "The last block to reconcile. There is no upper bound if not provided.")
+
+	return cmd
+}
+
+func reconcilePauseCmd(cl *reconcileClient) *cobra.Command {
+	params := &reconcileParameters{}
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause the reconciliation of missing private data.",
+		Long:  "Pause the reconciliation of missing private data on a channel until it is resumed. A reconciliation in progress stops after its current batch. The pause is not persisted: reconciliation runs again when the peer restarts.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callReconciliationService(cmd, cl, params, nil, func(cl *reconcileClient, signedRequest *reconcilegrpc.SignedReconciliationRequest) error {
+				if _, err := cl.reconciliationClient.Pause(context.Background(), signedRequest); err != nil {
+					return errors.WithMessage(err, "failed to pause reconciliation")
+				}
+				fmt.Fprint(cl.writer, "Reconciliation paused successfully\n")
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+
+	return cmd
+}
+
+func reconcileResumeCmd(cl *reconcileClient) *cobra.Command {
+	params := &reconcileParameters{}
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume the reconciliation of missing private data.",
+		Long:  "Resume a paused reconciliation of missing private data on a channel.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callReconciliationService(cmd, cl, params, nil, func(cl *reconcileClient, signedRequest *reconcilegrpc.SignedReconciliationRequest) error {
+				if _, err := cl.reconciliationClient.Resume(context.Background(), signedRequest); err != nil {
+					return errors.WithMessage(err, "failed to resume reconciliation")
+				}
+				fmt.Fprint(cl.writer, "Reconciliation resumed successfully\n")
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+
+	return cmd
+}
+
+func reconcileStatusCmd(cl *reconcileClient) *cobra.Command {
+	params := &reconcileParameters{}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the progress of the reconciliation of missing private data.",
+		Long:  "Show the progress of the reconciliation of missing private data on a channel.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callReconciliationService(cmd, cl, params, nil, func(cl *reconcileClient, signedRequest *reconcilegrpc.SignedReconciliationRequest) error {
+				status, err := cl.reconciliationClient.Status(context.Background(), signedRequest)
+				if err != nil {
+					return errors.WithMessage(err, "failed to get reconciliation status")
+				}
+				printReconciliationStatus(cl.writer, status)
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+
+	return cmd
+}
+
+// callReconciliationService validates the parameters, signs the request, completed by the given function if any,
+// and invokes the reconciliation service
+func callReconciliationService(
+	cmd *cobra.Command,
+	cl *reconcileClient,
+	params *reconcileParameters,
+	completeRequest func(*reconcilegrpc.ReconciliationRequest),
+	call func(*reconcileClient, *reconcilegrpc.SignedReconciliationRequest) error,
+) error {
+	if err := params.validate(); err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	// create a client if not provided
+	if cl == nil {
+		var err error
+		cl, err = newReconcileClient(params)
+		if err != nil {
+			return err
+		}
+	}
+
+	creator, err := cl.signer.Serialize()
+	if err != nil {
+		return err
+	}
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return err
+	}
+
+	request := &reconcilegrpc.ReconciliationRequest{
+		SignatureHeader: &cb.SignatureHeader{
+			Creator: creator,
+			Nonce:   nonce,
+		},
+		ChannelId: params.channelID,
+	}
+	if completeRequest != nil {
+		completeRequest(request)
+	}
+
+	requestBytes := protoutil.MarshalOrPanic(request)
+	signature, err := cl.signer.Sign(requestBytes)
+	if err != nil {
+		return err
+	}
+
+	return call(cl, &reconcilegrpc.SignedReconciliationRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	})
+}
+
+func printReconciliationStatus(w io.Writer, status *reconcilegrpc.ReconciliationStatusResponse) {
+	fmt.Fprintf(w, "Paused: %t\n", status.Paused)
+	fmt.Fprintf(w, "In progress: %t\n", status.InProgress)
+	fmt.Fprintf(w, "Total reconciled: %d\n", status.TotalReconciled)
+
+	cycle := status.Cycle
+	if cycle == nil {
+		fmt.Fprint(w, "No reconciliation cycle has run yet\n")
+		return
+	}
+
+	trigger := "scheduled"
+	if cycle.OnDemand {
+		trigger = "on demand"
+	}
+	fmt.Fprintf(w, "Last cycle (%s):\n", trigger)
+	fmt.Fprintf(w, "  Started: %s\n", formatTimestamp(cycle.StartTime))
+	fmt.Fprintf(w, "  Ended: %s\n", formatTimestamp(cycle.EndTime))
+	fmt.Fprintf(w, "  Missing: %d\n", cycle.Missing)
+	fmt.Fprintf(w, "  Reconciled: %d\n", cycle.Reconciled)
+	if cycle.Missing > 0 {
+		fmt.Fprintf(w, "  Blocks: [%d - %d]\n", cycle.MinBlock, cycle.MaxBlock)
+	}
+	if cycle.Error != "" {
+		fmt.Fprintf(w, "  Error: %s\n", cycle.Error)
+	}
+}
+
+func formatTimestamp(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
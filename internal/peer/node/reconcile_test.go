@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/gossip/privdata/reconcilegrpc"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/peer/node/mock"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/reconciliation_client.go -fake-name ReconciliationClient . reconciliationClient
+
+type reconciliationClient interface {
+	reconcilegrpc.ReconciliationClient
+}
+
+//go:generate counterfeiter -o mock/signer.go -fake-name Signer . signer
+
+type signer interface {
+	common.Signer
+}
+
+func newTestReconcileClient() (*reconcileClient, *mock.ReconciliationClient, *mock.Signer, *gbytes.Buffer) {
+	mockSigner := &mock.Signer{}
+	mockSigner.SerializeReturns([]byte("creator"), nil)
+	mockSigner.SignReturns([]byte("reconciliation-request-signature"), nil)
+	mockClient := &mock.ReconciliationClient{}
+	buffer := gbytes.NewBuffer()
+	return &reconcileClient{mockClient, mockSigner, buffer}, mockClient, mockSigner, buffer
+}
+
+func TestReconcileTriggerCmd(t *testing.T) {
+	cl, mockClient, mockSigner, buffer := newTestReconcileClient()
+	mockClient.ReconcileReturns(&empty.Empty{}, nil)
+
+	cmd := reconcileCmd(cl)
+	cmd.SetArgs([]string{"trigger", "-c", "mychannel", "--collections", "cc1:coll1,cc2:coll2", "--startBlock", "10", "--endBlock", "20"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, []byte("Reconciliation triggered successfully\n"), buffer.Contents())
+
+	require.Equal(t, 1, mockClient.ReconcileCallCount())
+	_, signedRequest, _ := mockClient.ReconcileArgsForCall(0)
+	require.Equal(t, []byte("reconciliation-request-signature"), signedRequest.Signature)
+	request := &reconcilegrpc.ReconciliationRequest{}
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, []byte("creator"), request.SignatureHeader.Creator)
+	require.Len(t, request.Collections, 2)
+	require.Equal(t, "cc1", request.Collections[0].ChaincodeName)
+	require.Equal(t, "coll1", request.Collections[0].CollectionName)
+	require.Equal(t, "cc2", request.Collections[1].ChaincodeName)
+	require.Equal(t, "coll2", request.Collections[1].CollectionName)
+	require.EqualValues(t, 10, request.StartBlock)
+	require.EqualValues(t, 20, request.EndBlock)
+
+	// error tests
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "missing channel",
+			args:   []string{"trigger"},
+			errMsg: "the required parameter 'channelID' is empty. Rerun the command with -c flag",
+		},
+		{
+			name:   "invalid collection",
+			args:   []string{"trigger", "-c", "mychannel", "--collections", "coll1"},
+			errMsg: "invalid collection 'coll1', expected <chaincode name>:<collection name>",
+		},
+		{
+			name:   "invalid block range",
+			args:   []string{"trigger", "-c", "mychannel", "--startBlock", "20", "--endBlock", "10"},
+			errMsg: "the parameter 'endBlock' must not be lower than 'startBlock'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := reconcileCmd(cl)
+			cmd.SetArgs(tt.args)
+			require.EqualError(t, cmd.Execute(), tt.errMsg)
+		})
+	}
+
+	cmd = reconcileCmd(cl)
+	cmd.SetArgs([]string{"trigger", "-c", "mychannel"})
+	mockClient.ReconcileReturns(nil, fmt.Errorf("fake-reconcile-error"))
+	require.EqualError(t, cmd.Execute(), "failed to trigger reconciliation: fake-reconcile-error")
+
+	mockSigner.SignReturns(nil, fmt.Errorf("fake-sign-error"))
+	require.EqualError(t, cmd.Execute(), "fake-sign-error")
+
+	mockSigner.SerializeReturns(nil, fmt.Errorf("fake-serialize-error"))
+	require.EqualError(t, cmd.Execute(), "fake-serialize-error")
+}
+
+func TestReconcilePauseAndResumeCmd(t *testing.T) {
+	cl, mockClient, _, buffer := newTestReconcileClient()
+	mockClient.PauseReturns(&empty.Empty{}, nil)
+	mockClient.ResumeReturns(&empty.Empty{}, nil)
+
+	cmd := reconcileCmd(cl)
+	cmd.SetArgs([]string{"pause", "-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, 1, mockClient.PauseCallCount())
+
+	cmd = reconcileCmd(cl)
+	cmd.SetArgs([]string{"resume", "-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, 1, mockClient.ResumeCallCount())
+	require.Equal(t, []byte("Reconciliation paused successfully\nReconciliation resumed successfully\n"), buffer.Contents())
+
+	mockClient.PauseReturns(nil, fmt.Errorf("fake-pause-error"))
+	cmd = reconcileCmd(cl)
+	cmd.SetArgs([]string{"pause", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "failed to pause reconciliation: fake-pause-error")
+
+	mockClient.ResumeReturns(nil, fmt.Errorf("fake-resume-error"))
+	cmd = reconcileCmd(cl)
+	cmd.SetArgs([]string{"resume", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "failed to resume reconciliation: fake-resume-error")
+}
+
+func TestReconcileStatusCmd(t *testing.T) {
+	cl, mockClient, _, buffer := newTestReconcileClient()
+	startTime, err := ptypes.TimestampProto(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	mockClient.StatusReturns(&reconcilegrpc.ReconciliationStatusResponse{
+		InProgress:      true,
+		TotalReconciled: 42,
+		Cycle: &reconcilegrpc.ReconciliationCycle{
+			OnDemand:   true,
+			StartTime:  startTime,
+			Missing:    10,
+			Reconciled: 7,
+			MinBlock:   5,
+			MaxBlock:   9,
+		},
+	}, nil)
+
+	cmd := reconcileCmd(cl)
+	cmd.SetArgs([]string{"status", "-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Paused: false\n"+
+		"In progress: true\n"+
+		"Total reconciled: 42\n"+
+		"Last cycle (on demand):\n"+
+		"  Started: 2021-06-01T10:00:00Z\n"+
+		"  Ended: -\n"+
+		"  Missing: 10\n"+
+		"  Reconciled: 7\n"+
+		"  Blocks: [5 - 9]\n", string(buffer.Contents()))
+
+	mockClient.StatusReturns(nil, fmt.Errorf("fake-status-error"))
+	require.EqualError(t, cmd.Execute(), "failed to get reconciliation status: fake-status-error")
+}
+
+func TestReconcileCmdRequiresTLSRootCert(t *testing.T) {
+	viper.Set("peer.tls.enabled", true)
+	defer viper.Set("peer.tls.enabled", false)
+
+	cmd := reconcileCmd(nil)
+	cmd.SetArgs([]string{"status", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "the required parameter 'tlsRootCertFile' is empty. Rerun the command with --tlsRootCertFile flag")
+}
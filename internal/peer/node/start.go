@@ -57,6 +57,7 @@ import (
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/endorser"
@@ -69,6 +70,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatagrpc"
 	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
@@ -78,6 +80,7 @@ import (
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/core/scc/qscc"
 	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/core/transientstore/transientstoregrpc"
 	"github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
 	discsupport "github.com/hyperledger/fabric/discovery/support"
@@ -87,15 +90,24 @@ import (
 	"github.com/hyperledger/fabric/discovery/support/gossip"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	gossipgossip "github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/introspection"
 	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
 	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/privdata/reconcilegrpc"
 	gossipservice "github.com/hyperledger/fabric/gossip/service"
 	peergossip "github.com/hyperledger/fabric/internal/peer/gossip"
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/checkpointstore"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/httpapi"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/pkg/gateway/blockevents"
+	"github.com/hyperledger/fabric/pkg/gateway/bulkstatus"
+	"github.com/hyperledger/fabric/pkg/gateway/checkpoint"
+	"github.com/hyperledger/fabric/pkg/gateway/interest"
+	"github.com/hyperledger/fabric/pkg/gateway/resubmit"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -106,6 +118,7 @@ import (
 const (
 	chaincodeListenAddrKey = "peer.chaincodeListenAddress"
 	defaultChaincodePort   = 7052
+	minBuildCacheKeyLength = 32
 )
 
 var chaincodeDevMode bool
@@ -275,8 +288,15 @@ func serve(args []string) error {
 		cs.SetClientCertificate(clientCert)
 	}
 
-	transientStoreProvider, err := transientstore.NewStoreProvider(
+	pvtDataEncryptor, err := pvtDataEncryptor(factory.GetDefault())
+	if err != nil {
+		return errors.WithMessage(err, "failed to initialize the encryption of private data")
+	}
+
+	transientStoreProvider, err := transientstore.NewStoreProviderWithMetrics(
 		filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "transientstore"),
+		pvtDataEncryptor,
+		metricsProvider,
 	)
 	if err != nil {
 		return errors.WithMessage(err, "failed to open transient store")
@@ -440,6 +460,7 @@ func serve(args []string) error {
 			Config:                          ledgerConfig(),
 			HashProvider:                    factory.GetDefault(),
 			EbMetadataProvider:              ebMetadataProvider,
+			PvtDataEncryptor:                pvtDataEncryptor,
 		},
 	)
 
@@ -467,6 +488,40 @@ func serve(args []string) error {
 
 	peerInstance.GossipService = gossipService
 
+	// swagger:operation GET /gossip operations gossip
+	// ---
+	// summary: Retrieves the gossip membership and topology of a peer.
+	//
+	// parameters:
+	// - name: channel
+	//   in: query
+	//   type: string
+	//   description: Restricts the channel membership to the given channel.
+	//   required: false
+	// responses:
+	//     '200':
+	//        description: Ok.
+	//     '404':
+	//        description: Channel not found.
+	opsSystem.RegisterHandler("/gossip", introspection.NewHandler(gossipService), coreConfig.OperationsTLSEnabled)
+
+	// swagger:operation POST /gossip/leadership/yield operations gossipLeadershipYield
+	// ---
+	// summary: Makes the peer yield its leadership in a channel.
+	//
+	// parameters:
+	// - name: channel
+	//   in: query
+	//   type: string
+	//   description: The channel in which the peer yields its leadership.
+	//   required: true
+	// responses:
+	//     '202':
+	//        description: Leadership yielded.
+	//     '400':
+	//        description: Bad request.
+	opsSystem.RegisterHandler("/gossip/leadership/yield", introspection.NewYieldHandler(gossipService), coreConfig.OperationsTLSEnabled)
+
 	if err := lifecycleCache.InitializeLocalChaincodes(); err != nil {
 		return errors.WithMessage(err, "could not initialize local chaincodes")
 	}
@@ -528,6 +583,9 @@ func serve(args []string) error {
 	}
 
 	chaincodeConfig := chaincode.GlobalConfig()
+	if err := chaincodeConfig.Validate(); err != nil {
+		logger.Panicf("invalid chaincode configuration: %s", err)
+	}
 
 	var dockerBuilder container.DockerBuilder
 	if coreConfig.VMEndpoint != "" {
@@ -574,10 +632,29 @@ func serve(args []string) error {
 		Builders:    externalbuilder.CreateBuilders(coreConfig.ExternalBuilders, mspID),
 		DurablePath: externalBuilderOutput,
 	}
+	if coreConfig.ExternalBuilderCachePath != "" {
+		buildCacheKey, err := ioutil.ReadFile(coreConfig.ExternalBuilderCacheKeyFile)
+		if err != nil {
+			logger.Panicf("Failed to read external builder cache key: %s", err)
+		}
+		if len(buildCacheKey) < minBuildCacheKeyLength {
+			logger.Panicf("External builder cache key in %s must be at least %d bytes long", coreConfig.ExternalBuilderCacheKeyFile, minBuildCacheKeyLength)
+		}
+		externalVM.BuildCache = &externalbuilder.DirectoryBuildCache{Path: coreConfig.ExternalBuilderCachePath}
+		externalVM.BuildCacheKey = buildCacheKey
+	}
+
+	inProcChaincodes := &inproccontroller.Registry{}
+	for _, cc := range coreConfig.InProcChaincodes {
+		if err := inProcChaincodes.RegisterPlugin(cc.PackageID, cc.Library); err != nil {
+			logger.Panicf("Failed to register in-process chaincode %s: %s", cc.PackageID, err)
+		}
+	}
 
 	buildRegistry := &container.BuildRegistry{}
 
 	containerRouter := &container.Router{
+		InProcBuilder:   inProcChaincodes,
 		DockerBuilder:   dockerBuilder,
 		ExternalBuilder: externalVMAdapter{externalVM},
 		PackageProvider: &persistence.FallbackPackageLocator{
@@ -646,6 +723,11 @@ func serve(args []string) error {
 		ACLProvider:            aclProvider,
 	}
 
+	connectionHandler := &inproccontroller.ConnectionHandler{
+		Registry:                  inProcChaincodes,
+		ExternalConnectionHandler: &extcc.ExternalChaincodeRuntime{},
+	}
+
 	chaincodeLauncher := &chaincode.RuntimeLauncher{
 		Metrics:           chaincode.NewLaunchMetrics(opsSystem.Provider),
 		Registry:          chaincodeHandlerRegistry,
@@ -654,7 +736,13 @@ func serve(args []string) error {
 		CertGenerator:     authenticator,
 		CACert:            ca.CertBytes(),
 		PeerAddress:       ccEndpoint,
-		ConnectionHandler: &extcc.ExternalChaincodeRuntime{},
+		ConnectionHandler: connectionHandler,
+		Restart:           chaincodeConfig.Restart,
+	}
+	if chaincodeConfig.HealthCheck {
+		if err := opsSystem.RegisterChecker("chaincode", chaincodeLauncher); err != nil {
+			logger.Panicf("failed to register chaincode health check: %s", err)
+		}
 	}
 
 	// Keep TestQueries working
@@ -671,8 +759,10 @@ func serve(args []string) error {
 		HandlerRegistry:        chaincodeHandlerRegistry,
 		HandlerMetrics:         chaincode.NewHandlerMetrics(opsSystem.Provider),
 		Keepalive:              chaincodeConfig.Keepalive,
+		KeepaliveTimeout:       chaincodeConfig.KeepaliveTimeout,
 		Launcher:               chaincodeLauncher,
 		Lifecycle:              chaincodeEndorsementInfo,
+		MaxBatchSize:           chaincodeConfig.MaxBatchSize,
 		Peer:                   peerInstance,
 		Runtime:                containerRuntime,
 		BuiltinSCCs:            builtinSCCs,
@@ -823,6 +913,22 @@ func serve(args []string) error {
 		if coreConfig.DiscoveryEnabled {
 			logger.Info("Starting peer with Gateway enabled")
 
+			var checkpointStore gateway.CheckpointStore
+			if coreConfig.GatewayOptions.Checkpoints.Enabled {
+				store, err := checkpointstore.NewStore(
+					filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "gatewayCheckpoints"),
+					checkpointstore.Options{
+						MaxPerIdentity: coreConfig.GatewayOptions.Checkpoints.MaxPerIdentity,
+						Expiry:         coreConfig.GatewayOptions.Checkpoints.Expiry,
+					},
+				)
+				if err != nil {
+					logger.Panicf("Failed to open gateway checkpoint store: %s", err)
+				}
+				defer store.Close()
+				checkpointStore = store
+			}
+
 			gatewayServer := gateway.CreateServer(
 				serverEndorser,
 				discoveryService,
@@ -831,8 +937,33 @@ func serve(args []string) error {
 				aclProvider,
 				coreConfig.LocalMSPID,
 				coreConfig.GatewayOptions,
+				checkpointStore,
+				metricsProvider,
 			)
 			gatewayprotos.RegisterGatewayServer(peerServer.Server(), gatewayServer)
+			blockevents.RegisterBlockEventsServer(peerServer.Server(), gatewayServer)
+			bulkstatus.RegisterBulkStatusServer(peerServer.Server(), gatewayServer)
+			interest.RegisterInterestServer(peerServer.Server(), gatewayServer)
+			if coreConfig.GatewayOptions.Resubmit.Enabled {
+				resubmit.RegisterResubmitServer(peerServer.Server(), gatewayServer)
+			}
+			if coreConfig.GatewayOptions.Checkpoints.Enabled {
+				checkpoint.RegisterCheckpointServer(peerServer.Server(), gatewayServer)
+			}
+
+			if coreConfig.GatewayOptions.HTTP.Enabled {
+				gatewayHTTPServer := newGatewayHTTPServer(coreConfig)
+				gatewayHTTPServer.RegisterHandler(
+					httpapi.PathPrefix,
+					httpapi.NewHandler(gatewayServer, coreConfig.GatewayOptions.HTTP.WriteTimeout),
+					coreConfig.PeerTLSEnabled && viper.GetBool("peer.tls.clientAuthRequired"),
+				)
+				if err := gatewayHTTPServer.Start(); err != nil {
+					logger.Panicf("Failed to start gateway HTTP server: %s", err)
+				}
+				defer gatewayHTTPServer.Stop()
+				logger.Infof("Gateway HTTP service listening on %s", gatewayHTTPServer.Addr())
+			}
 		} else {
 			logger.Warning("Discovery service must be enabled for embedded gateway")
 		}
@@ -901,6 +1032,15 @@ func serve(args []string) error {
 	// register the snapshot server
 	snapshotSvc := &snapshotgrpc.SnapshotService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
 	pb.RegisterSnapshotServer(peerServer.Server(), snapshotSvc)
+	// register the private data reconciliation server
+	reconciliationSvc := &reconcilegrpc.ReconciliationService{ReconcilerGetter: gossipService, ACLProvider: aclProvider}
+	reconcilegrpc.RegisterReconciliationServer(peerServer.Server(), reconciliationSvc)
+	// register the private data export server
+	pvtDataSvc := &pvtdatagrpc.PvtDataService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
+	pvtdatagrpc.RegisterPvtDataServer(peerServer.Server(), pvtDataSvc)
+	// register the transient store server
+	transientStoreSvc := &transientstoregrpc.TransientStoreService{StoreGetter: peerInstance, ACLProvider: aclProvider}
+	transientstoregrpc.RegisterTransientStoreServer(peerServer.Server(), transientStoreSvc)
 
 	go func() {
 		var grpcErr error
@@ -1229,6 +1369,12 @@ func initGossipService(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed obtaining gossip config")
 	}
+	if gossipConfig.StaticMembership {
+		gossipConfig.StaticPeers, err = staticGossipPeers()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed obtaining static gossip membership")
+		}
+	}
 
 	return gossipservice.New(
 		signer,
@@ -1272,6 +1418,28 @@ func newOperationsSystem(coreConfig *peer.Config) *operations.System {
 	})
 }
 
+// newGatewayHTTPServer creates the server for the HTTP/JSON gateway service,
+// which uses the same TLS configuration as the peer's gRPC services.
+func newGatewayHTTPServer(coreConfig *peer.Config) *fabhttp.Server {
+	var clientRootCAs []string
+	for _, file := range viper.GetStringSlice("peer.tls.clientRootCAs.files") {
+		clientRootCAs = append(clientRootCAs, coreconfig.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), file))
+	}
+
+	return fabhttp.NewServer(fabhttp.Options{
+		Logger:        flogging.MustGetLogger("gateway.http"),
+		ListenAddress: coreConfig.GatewayOptions.HTTP.ListenAddress,
+		WriteTimeout:  coreConfig.GatewayOptions.HTTP.WriteTimeout,
+		TLS: fabhttp.TLS{
+			Enabled:            coreConfig.PeerTLSEnabled,
+			CertFile:           coreconfig.GetPath("peer.tls.cert.file"),
+			KeyFile:            coreconfig.GetPath("peer.tls.key.file"),
+			ClientCertRequired: viper.GetBool("peer.tls.clientAuthRequired"),
+			ClientCACertFiles:  clientRootCAs,
+		},
+	})
+}
+
 func getDockerHostConfig() *docker.HostConfig {
 	dockerKey := func(key string) string { return "vm.docker.hostConfig." + key }
 	getInt64 := func(key string) int64 { return int64(viper.GetInt(dockerKey(key))) }
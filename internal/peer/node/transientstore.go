@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/transientstore/transientstoregrpc"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// transientStoreClient holds client side dependencies for the transientstore commands
+type transientStoreClient struct {
+	transientStoreClient transientstoregrpc.TransientStoreClient
+	signer               common.Signer
+	writer               io.Writer
+}
+
+// newTransientStoreClient creates a client connected to the peer
+func newTransientStoreClient(p *reconcileParameters) (*transientStoreClient, error) {
+	client, err := common.GetTransientStoreClient(p.peerAddress, p.tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve transient store client")
+	}
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve default signer")
+	}
+
+	return &transientStoreClient{
+		transientStoreClient: client,
+		signer:               signer,
+		writer:               os.Stdout,
+	}, nil
+}
+
+func transientStoreCmd(cl *transientStoreClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transientstore",
+		Short: "Inspect or purge the transient store: list|stats|purge.",
+		Long:  "Inspect or purge the private data held in the transient store of a channel of a running peer, pending the commit of the transactions it was endorsed for: list|stats|purge.",
+	}
+	cmd.AddCommand(transientStoreListCmd(cl))
+	cmd.AddCommand(transientStoreStatsCmd(cl))
+	cmd.AddCommand(transientStorePurgeCmd(cl))
+
+	return cmd
+}
+
+func transientStoreListCmd(cl *transientStoreClient) *cobra.Command {
+	params := &reconcileParameters{}
+	var txIDs []string
+	var startHeight, endHeight uint64
+	var limit uint32
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the private write sets held in the transient store.",
+		Long: "List the private write sets held in the transient store of a channel, either for some transactions or" +
+			" within a range of the block heights they were received at. The content of the private write sets is not shown.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if endHeight != 0 && endHeight < startHeight {
+				return errors.New("the parameter 'endHeight' must not be lower than 'startHeight'")
+			}
+
+			return callTransientStoreService(cmd, cl, params, func(request *transientstoregrpc.TransientStoreRequest) {
+				request.TxIds = txIDs
+				request.StartHeight = startHeight
+				request.EndHeight = endHeight
+				request.Limit = limit
+			}, func(cl *transientStoreClient, signedRequest *transientstoregrpc.SignedTransientStoreRequest) error {
+				response, err := cl.transientStoreClient.List(context.Background(), signedRequest)
+				if err != nil {
+					return errors.WithMessage(err, "failed to list transient store entries")
+				}
+				printTransientStoreEntries(cl.writer, response.Entries)
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&txIDs, "txIDs", "", nil, "The transactions whose private write sets are listed, as a comma separated list. The range of block heights is ignored if provided.")
+	flags.Uint64VarP(&startHeight, "startHeight", "", 0, "The lowest block height of the listed private write sets")
+	flags.Uint64VarP(&endHeight, "endHeight", "", 0, "The highest block height of the listed private write sets. There is no upper bound if not provided.")
+	flags.Uint32VarP(&limit, "limit", "", 100, "The maximum number of private write sets listed by block height. There is no limit if 0.")
+
+	return cmd
+}
+
+func transientStoreStatsCmd(cl *transientStoreClient) *cobra.Command {
+	params := &reconcileParameters{}
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize the private write sets held in the transient store.",
+		Long:  "Show the number and size of the private write sets held in the transient store of a channel, along with the range of block heights they were received at and the oldest persist time.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callTransientStoreService(cmd, cl, params, nil, func(cl *transientStoreClient, signedRequest *transientstoregrpc.SignedTransientStoreRequest) error {
+				stats, err := cl.transientStoreClient.Stats(context.Background(), signedRequest)
+				if err != nil {
+					return errors.WithMessage(err, "failed to get transient store statistics")
+				}
+				printTransientStoreStats(cl.writer, stats)
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+
+	return cmd
+}
+
+func transientStorePurgeCmd(cl *transientStoreClient) *cobra.Command {
+	params := &reconcileParameters{}
+	var txIDs []string
+	var olderThan time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Purge private write sets from the transient store.",
+		Long: "Purge the private write sets of some transactions, or the private write sets older than a given age, from the" +
+			" transient store of a channel. Purged private data can no longer be disseminated to other peers or committed by this peer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			olderThanSet := cmd.Flags().Changed("olderThan")
+			if len(txIDs) == 0 && !olderThanSet {
+				return errors.New("either the parameter 'txIDs' or 'olderThan' is required. Rerun the command with --txIDs or --olderThan flag")
+			}
+			if len(txIDs) > 0 && olderThanSet {
+				return errors.New("the parameters 'txIDs' and 'olderThan' cannot be both provided")
+			}
+			if olderThan < 0 {
+				return errors.New("the parameter 'olderThan' must not be negative")
+			}
+
+			return callTransientStoreService(cmd, cl, params, func(request *transientstoregrpc.TransientStoreRequest) {
+				request.TxIds = txIDs
+				if olderThanSet {
+					request.OlderThan = ptypes.DurationProto(olderThan)
+				}
+			}, func(cl *transientStoreClient, signedRequest *transientstoregrpc.SignedTransientStoreRequest) error {
+				response, err := cl.transientStoreClient.Purge(context.Background(), signedRequest)
+				if err != nil {
+					return errors.WithMessage(err, "failed to purge transient store")
+				}
+				fmt.Fprintf(cl.writer, "Purged %d private write sets\n", response.Purged)
+				return nil
+			})
+		},
+	}
+	params.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&txIDs, "txIDs", "", nil, "The transactions whose private write sets are purged, as a comma separated list")
+	flags.DurationVarP(&olderThan, "olderThan", "", 0, "The minimum age of the purged private write sets, e.g. 24h")
+
+	return cmd
+}
+
+// callTransientStoreService validates the parameters, signs the request, completed by the given function if any,
+// and invokes the transient store service
+func callTransientStoreService(
+	cmd *cobra.Command,
+	cl *transientStoreClient,
+	params *reconcileParameters,
+	completeRequest func(*transientstoregrpc.TransientStoreRequest),
+	call func(*transientStoreClient, *transientstoregrpc.SignedTransientStoreRequest) error,
+) error {
+	if err := params.validate(); err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	// create a client if not provided
+	if cl == nil {
+		var err error
+		cl, err = newTransientStoreClient(params)
+		if err != nil {
+			return err
+		}
+	}
+
+	creator, err := cl.signer.Serialize()
+	if err != nil {
+		return err
+	}
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return err
+	}
+
+	request := &transientstoregrpc.TransientStoreRequest{
+		SignatureHeader: &cb.SignatureHeader{
+			Creator: creator,
+			Nonce:   nonce,
+		},
+		ChannelId: params.channelID,
+	}
+	if completeRequest != nil {
+		completeRequest(request)
+	}
+
+	requestBytes := protoutil.MarshalOrPanic(request)
+	signature, err := cl.signer.Sign(requestBytes)
+	if err != nil {
+		return err
+	}
+
+	return call(cl, &transientstoregrpc.SignedTransientStoreRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	})
+}
+
+func printTransientStoreEntries(w io.Writer, entries []*transientstoregrpc.TransientStoreEntry) {
+	if len(entries) == 0 {
+		fmt.Fprint(w, "No private write sets found\n")
+		return
+	}
+	for _, entry := range entries {
+		var collections []string
+		for _, c := range entry.Collections {
+			collections = append(collections, c.ChaincodeName+":"+c.CollectionName)
+		}
+		fmt.Fprintf(w, "Transaction %s (%s):\n", entry.TxId, entry.Uuid)
+		fmt.Fprintf(w, "  Received at block height: %d\n", entry.ReceivedAtBlockHeight)
+		fmt.Fprintf(w, "  Persisted: %s\n", formatTimestamp(entry.PersistTime))
+		fmt.Fprintf(w, "  Size: %d bytes\n", entry.Size)
+		fmt.Fprintf(w, "  Collections: %s\n", strings.Join(collections, ", "))
+	}
+}
+
+func printTransientStoreStats(w io.Writer, stats *transientstoregrpc.StatsResponse) {
+	fmt.Fprintf(w, "Private write sets: %d\n", stats.Entries)
+	fmt.Fprintf(w, "Size: %d bytes\n", stats.Size)
+	if stats.Entries > 0 {
+		fmt.Fprintf(w, "Block heights: [%d - %d]\n", stats.MinBlockHeight, stats.MaxBlockHeight)
+		fmt.Fprintf(w, "Oldest persisted: %s\n", formatTimestamp(stats.OldestPersistTime))
+	}
+}
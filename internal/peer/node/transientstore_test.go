@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/core/transientstore/transientstoregrpc"
+	"github.com/hyperledger/fabric/internal/peer/node/mock"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/transient_store_client.go -fake-name TransientStoreClient . transientStoreGRPCClient
+
+type transientStoreGRPCClient interface {
+	transientstoregrpc.TransientStoreClient
+}
+
+func newTestTransientStoreClient() (*transientStoreClient, *mock.TransientStoreClient, *gbytes.Buffer) {
+	mockSigner := &mock.Signer{}
+	mockSigner.SerializeReturns([]byte("creator"), nil)
+	mockSigner.SignReturns([]byte("transientstore-request-signature"), nil)
+	mockClient := &mock.TransientStoreClient{}
+	buffer := gbytes.NewBuffer()
+	return &transientStoreClient{mockClient, mockSigner, buffer}, mockClient, buffer
+}
+
+func unmarshalTransientStoreRequest(t *testing.T, signedRequest *transientstoregrpc.SignedTransientStoreRequest) *transientstoregrpc.TransientStoreRequest {
+	require.Equal(t, []byte("transientstore-request-signature"), signedRequest.Signature)
+	request := &transientstoregrpc.TransientStoreRequest{}
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, []byte("creator"), request.SignatureHeader.Creator)
+	return request
+}
+
+func TestTransientStoreListCmd(t *testing.T) {
+	cl, mockClient, buffer := newTestTransientStoreClient()
+	persistTime := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	ts, err := ptypes.TimestampProto(persistTime)
+	require.NoError(t, err)
+	mockClient.ListReturns(&transientstoregrpc.ListResponse{
+		Entries: []*transientstoregrpc.TransientStoreEntry{
+			{
+				TxId:                  "tx1",
+				Uuid:                  "uuid1",
+				ReceivedAtBlockHeight: 10,
+				PersistTime:           ts,
+				Size:                  120,
+				Collections: []*transientstoregrpc.CollectionReference{
+					{ChaincodeName: "cc", CollectionName: "coll1"},
+					{ChaincodeName: "cc", CollectionName: "coll2"},
+				},
+			},
+			{
+				TxId:                  "tx2",
+				Uuid:                  "uuid2",
+				ReceivedAtBlockHeight: 11,
+				Size:                  60,
+				Collections:           []*transientstoregrpc.CollectionReference{{ChaincodeName: "cc", CollectionName: "coll1"}},
+			},
+		},
+	}, nil)
+
+	cmd := transientStoreCmd(cl)
+	cmd.SetArgs([]string{"list", "-c", "mychannel", "--startHeight", "10", "--endHeight", "20", "--limit", "5"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Transaction tx1 (uuid1):\n"+
+		"  Received at block height: 10\n"+
+		"  Persisted: 2021-03-04T05:06:07Z\n"+
+		"  Size: 120 bytes\n"+
+		"  Collections: cc:coll1, cc:coll2\n"+
+		"Transaction tx2 (uuid2):\n"+
+		"  Received at block height: 11\n"+
+		"  Persisted: -\n"+
+		"  Size: 60 bytes\n"+
+		"  Collections: cc:coll1\n", string(buffer.Contents()))
+
+	_, signedRequest, _ := mockClient.ListArgsForCall(0)
+	request := unmarshalTransientStoreRequest(t, signedRequest)
+	require.Empty(t, request.TxIds)
+	require.Equal(t, uint64(10), request.StartHeight)
+	require.Equal(t, uint64(20), request.EndHeight)
+	require.Equal(t, uint32(5), request.Limit)
+
+	cl, mockClient, buffer = newTestTransientStoreClient()
+	mockClient.ListReturns(&transientstoregrpc.ListResponse{}, nil)
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"list", "-c", "mychannel", "--txIDs", "tx1,tx2"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "No private write sets found\n", string(buffer.Contents()))
+	_, signedRequest, _ = mockClient.ListArgsForCall(0)
+	request = unmarshalTransientStoreRequest(t, signedRequest)
+	require.Equal(t, []string{"tx1", "tx2"}, request.TxIds)
+	require.Equal(t, uint32(100), request.Limit)
+
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"list", "-c", "mychannel", "--startHeight", "20", "--endHeight", "10"})
+	require.EqualError(t, cmd.Execute(), "the parameter 'endHeight' must not be lower than 'startHeight'")
+
+	mockClient.ListReturns(nil, fmt.Errorf("fake-list-error"))
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"list", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "failed to list transient store entries: fake-list-error")
+}
+
+func TestTransientStoreStatsCmd(t *testing.T) {
+	cl, mockClient, buffer := newTestTransientStoreClient()
+	ts, err := ptypes.TimestampProto(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	require.NoError(t, err)
+	mockClient.StatsReturns(&transientstoregrpc.StatsResponse{
+		Entries:           3,
+		Size:              300,
+		MinBlockHeight:    10,
+		MaxBlockHeight:    12,
+		OldestPersistTime: ts,
+	}, nil)
+
+	cmd := transientStoreCmd(cl)
+	cmd.SetArgs([]string{"stats", "-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Private write sets: 3\n"+
+		"Size: 300 bytes\n"+
+		"Block heights: [10 - 12]\n"+
+		"Oldest persisted: 2021-03-04T05:06:07Z\n", string(buffer.Contents()))
+	_, signedRequest, _ := mockClient.StatsArgsForCall(0)
+	unmarshalTransientStoreRequest(t, signedRequest)
+
+	cl, mockClient, buffer = newTestTransientStoreClient()
+	mockClient.StatsReturns(&transientstoregrpc.StatsResponse{}, nil)
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"stats", "-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Private write sets: 0\nSize: 0 bytes\n", string(buffer.Contents()))
+
+	mockClient.StatsReturns(nil, fmt.Errorf("fake-stats-error"))
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"stats", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "failed to get transient store statistics: fake-stats-error")
+
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"stats"})
+	require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+}
+
+func TestTransientStorePurgeCmd(t *testing.T) {
+	cl, mockClient, buffer := newTestTransientStoreClient()
+	mockClient.PurgeReturns(&transientstoregrpc.PurgeResponse{Purged: 2}, nil)
+
+	cmd := transientStoreCmd(cl)
+	cmd.SetArgs([]string{"purge", "-c", "mychannel", "--txIDs", "tx1,tx2"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Purged 2 private write sets\n", string(buffer.Contents()))
+	_, signedRequest, _ := mockClient.PurgeArgsForCall(0)
+	request := unmarshalTransientStoreRequest(t, signedRequest)
+	require.Equal(t, []string{"tx1", "tx2"}, request.TxIds)
+	require.Nil(t, request.OlderThan)
+
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"purge", "-c", "mychannel", "--olderThan", "24h"})
+	require.NoError(t, cmd.Execute())
+	_, signedRequest, _ = mockClient.PurgeArgsForCall(1)
+	request = unmarshalTransientStoreRequest(t, signedRequest)
+	require.Empty(t, request.TxIds)
+	olderThan, err := ptypes.Duration(request.OlderThan)
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, olderThan)
+
+	// an age of zero purges all the private write sets
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"purge", "-c", "mychannel", "--olderThan", "0s"})
+	require.NoError(t, cmd.Execute())
+	_, signedRequest, _ = mockClient.PurgeArgsForCall(2)
+	request = unmarshalTransientStoreRequest(t, signedRequest)
+	require.NotNil(t, request.OlderThan)
+
+	tests := []struct {
+		name   string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "nothing to purge",
+			args:   []string{"purge", "-c", "mychannel"},
+			errMsg: "either the parameter 'txIDs' or 'olderThan' is required. Rerun the command with --txIDs or --olderThan flag",
+		},
+		{
+			name:   "transactions and age",
+			args:   []string{"purge", "-c", "mychannel", "--txIDs", "tx1", "--olderThan", "1h"},
+			errMsg: "the parameters 'txIDs' and 'olderThan' cannot be both provided",
+		},
+		{
+			name:   "negative age",
+			args:   []string{"purge", "-c", "mychannel", "--olderThan", "-1h"},
+			errMsg: "the parameter 'olderThan' must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := transientStoreCmd(cl)
+			cmd.SetArgs(tt.args)
+			require.EqualError(t, cmd.Execute(), tt.errMsg)
+		})
+	}
+
+	mockClient.PurgeReturns(nil, fmt.Errorf("fake-purge-error"))
+	cmd = transientStoreCmd(cl)
+	cmd.SetArgs([]string{"purge", "-c", "mychannel", "--txIDs", "tx1"})
+	require.EqualError(t, cmd.Execute(), "failed to purge transient store: fake-purge-error")
+}
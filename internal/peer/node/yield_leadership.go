@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func yieldLeadershipCmd(w io.Writer) *cobra.Command {
+	p := &gossipInfoParameters{}
+	cmd := &cobra.Command{
+		Use:   "yield-leadership",
+		Short: "Makes a running peer yield its leadership in a channel.",
+		Long: "Makes a running peer relinquish its leadership in a channel through the /gossip/leadership/yield endpoint of its operations service," +
+			" so that another peer of its organization takes over pulling blocks from the ordering service, e.g. before planned maintenance." +
+			" The peer only competes for the leadership again once another leader was elected, or after the leader alive threshold elapsed several times.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if w == nil {
+				w = os.Stdout
+			}
+			if p.channelID == "" {
+				return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+			}
+			if p.operationsAddress == "" {
+				p.operationsAddress = viper.GetString("operations.listenAddress")
+			}
+			tlsEnabled := viper.GetBool("operations.tls.enabled")
+			client, err := newOperationsClient(tlsEnabled, p)
+			if err != nil {
+				return err
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			if err := yieldLeadership(client, tlsEnabled, p); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "Peer yielded its leadership in channel %s\n", p.channelID)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&p.channelID, "channelID", "c", "", "Channel in which the peer yields its leadership")
+	addOperationsClientFlags(flags, p)
+
+	return cmd
+}
+
+func yieldLeadership(client *http.Client, tlsEnabled bool, p *gossipInfoParameters) error {
+	u := operationsURL(tlsEnabled, p, "/gossip/leadership/yield")
+	u.RawQuery = url.Values{"channel": []string{p.channelID}}.Encode()
+
+	resp, err := client.Post(u.String(), "application/json", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to yield the leadership")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		errResp := &introspection.ErrorResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(errResp); err != nil || errResp.Error == "" {
+			return errors.Errorf("failed to yield the leadership: %s", resp.Status)
+		}
+		return errors.Errorf("failed to yield the leadership: %s", errResp.Error)
+	}
+	return nil
+}
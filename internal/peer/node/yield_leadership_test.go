@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/introspection"
+	"github.com/hyperledger/fabric/gossip/introspection/mocks"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYieldLeadershipCmd(t *testing.T) {
+	defer viper.Reset()
+	yielder := &mocks.LeadershipYielder{}
+	server := httptest.NewServer(introspection.NewYieldHandler(yielder))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("success", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		cmd := yieldLeadershipCmd(buf)
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "ch1"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "Peer yielded its leadership in channel ch1\n", buf.String())
+		require.Equal(t, 1, yielder.YieldLeadershipCallCount())
+		require.Equal(t, "ch1", yielder.YieldLeadershipArgsForCall(0))
+	})
+
+	t.Run("default operations address", func(t *testing.T) {
+		viper.Set("operations.listenAddress", address)
+		defer viper.Set("operations.listenAddress", "")
+		cmd := yieldLeadershipCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "ch1"})
+		require.NoError(t, cmd.Execute())
+	})
+
+	t.Run("not the leader", func(t *testing.T) {
+		yielder.YieldLeadershipReturns(errors.New("peer is not the leader of channel ch1"))
+		defer yielder.YieldLeadershipReturns(nil)
+		cmd := yieldLeadershipCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "ch1"})
+		require.EqualError(t, cmd.Execute(), "failed to yield the leadership: peer is not the leader of channel ch1")
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		cmd := yieldLeadershipCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	})
+
+	t.Run("TLS files required", func(t *testing.T) {
+		viper.Set("operations.tls.enabled", true)
+		defer viper.Set("operations.tls.enabled", false)
+		cmd := yieldLeadershipCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "ch1"})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'tlsRootCertFile' is empty. Rerun the command with --tlsRootCertFile flag")
+	})
+
+	t.Run("peer not reachable", func(t *testing.T) {
+		cmd := yieldLeadershipCmd(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--operationsAddress", "127.0.0.1:0", "-c", "ch1"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to yield the leadership")
+	})
+}
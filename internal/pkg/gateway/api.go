@@ -10,7 +10,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"math/rand"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -21,8 +20,10 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
+	corepeer "github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/event"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
+	"github.com/hyperledger/fabric/pkg/gateway/blockevents"
 	"github.com/hyperledger/fabric/protoutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -45,6 +46,10 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 		return nil, status.Errorf(codes.InvalidArgument, "failed to unpack transaction proposal: %s", err)
 	}
 
+	if err := gs.checkRateLimit("Evaluate", signedProposal, channel, chaincodeID); err != nil {
+		return nil, err
+	}
+
 	err = gs.registry.connectChannelPeers(channel, false)
 	if err != nil {
 		return nil, status.Errorf(codes.Unavailable, "%s", err)
@@ -76,8 +81,10 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 			defer close(done)
 			ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
 			defer cancel()
+			requestDone := gs.registry.startRequest(endorser)
 			pr, err := endorser.client.ProcessProposal(ctx, signedProposal)
 			code, message, retry, remove := responseStatus(pr, err)
+			requestDone(code)
 			if code == codes.OK {
 				response = pr.Response
 				// Prefer result from proposal response as Response.Payload is not required to be transaction result
@@ -125,53 +132,74 @@ func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*g
 // Endorse will collect endorsements by invoking the transaction function specified in the SignedProposal against
 // sufficient Peers to satisfy the endorsement policy.
 func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.EndorseResponse, error) {
+	response, _, err := gs.endorse(ctx, request, nil)
+	return response, err
+}
+
+// endorse collects endorsements for a proposal, and returns the prepared transaction along with the endorsement plan
+// whose completed layout satisfied the endorsement policy. If a chaincode interest is supplied, it is used to select
+// the endorsers instead of the interest returned by a first endorsement.
+func (gs *Server) endorse(ctx context.Context, request *gp.EndorseRequest, interest *peer.ChaincodeInterest) (*gp.EndorseResponse, *plan, error) {
 	if request == nil {
-		return nil, status.Error(codes.InvalidArgument, "an endorse request is required")
+		return nil, nil, status.Error(codes.InvalidArgument, "an endorse request is required")
 	}
 	signedProposal := request.GetProposedTransaction()
 	if signedProposal == nil {
-		return nil, status.Error(codes.InvalidArgument, "the proposed transaction must contain a signed proposal")
+		return nil, nil, status.Error(codes.InvalidArgument, "the proposed transaction must contain a signed proposal")
 	}
 	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	header, err := protoutil.UnmarshalHeader(proposal.Header)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	channelHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 	spec, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.Input)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	channel := channelHeader.ChannelId
 	chaincodeID := spec.GetChaincodeSpec().GetChaincodeId().GetName()
 	hasTransientData := len(payload.GetTransientMap()) > 0
 
+	if err := gs.checkRateLimit("Endorse", signedProposal, channel, chaincodeID); err != nil {
+		return nil, nil, err
+	}
+
 	logger := gs.logger.With("channel", channel, "chaincode", chaincodeID, "txID", request.TransactionId)
 
 	var plan *plan
 	var action *peer.ChaincodeEndorsedAction
-	if len(request.EndorsingOrganizations) > 0 {
+	if interest != nil {
+		// The client is specifying the chaincode interest from which discovery derives the endorsement layouts
+		if len(request.EndorsingOrganizations) > 0 {
+			return nil, nil, status.Error(codes.InvalidArgument, "endorsing organizations cannot be specified with a chaincode interest")
+		}
+		plan, err = gs.planFromInterest(channel, chaincodeID, hasTransientData, interest)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if len(request.EndorsingOrganizations) > 0 {
 		// The client is specifying the endorsing orgs and taking responsibility for ensuring it meets the signature policy
 		plan, err = gs.registry.planForOrgs(channel, chaincodeID, request.EndorsingOrganizations)
 		if err != nil {
-			return nil, status.Error(codes.Unavailable, err.Error())
+			return nil, nil, status.Error(codes.Unavailable, err.Error())
 		}
 	} else {
 		// The client is delegating choice of endorsers to the gateway.
 		plan, err = gs.planFromFirstEndorser(ctx, channel, chaincodeID, hasTransientData, signedProposal, logger)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -201,24 +229,24 @@ func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.
 				// Endorser completedLayout normally
 			case <-ctx.Done():
 				logger.Warnw("Endorse call timed out while collecting endorsements", "numEndorsers", len(endorsers))
-				return nil, newRpcError(codes.DeadlineExceeded, "endorsement timeout expired while collecting endorsements")
+				return nil, nil, newRpcError(codes.DeadlineExceeded, "endorsement timeout expired while collecting endorsements")
 			}
 		}
 
 	}
 
 	if plan.completedLayout == nil {
-		return nil, newRpcError(codes.Aborted, "failed to collect enough transaction endorsements, see attached details for more info", plan.errorDetails...)
+		return nil, nil, newRpcError(codes.Aborted, "failed to collect enough transaction endorsements, see attached details for more info", plan.errorDetails...)
 	}
 
 	action = &peer.ChaincodeEndorsedAction{ProposalResponsePayload: plan.responsePayload, Endorsements: uniqueEndorsements(plan.completedLayout.endorsements)}
 
 	preparedTransaction, err := prepareTransaction(header, payload, action)
 	if err != nil {
-		return nil, status.Errorf(codes.Aborted, "failed to assemble transaction: %s", err)
+		return nil, nil, status.Errorf(codes.Aborted, "failed to assemble transaction: %s", err)
 	}
 
-	return &gp.EndorseResponse{PreparedTransaction: preparedTransaction}, nil
+	return &gp.EndorseResponse{PreparedTransaction: preparedTransaction}, plan, nil
 }
 
 type ppResponse struct {
@@ -237,7 +265,10 @@ func (gs *Server) processProposal(ctx context.Context, plan *plan, endorser *end
 		logger.Debugw("Sending to endorser:", "MSPID", endorser.mspid, "endpoint", endorser.address)
 		ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout) // timeout of individual endorsement
 		defer cancel()
+		requestDone := gs.registry.startRequest(endorser)
 		response, err := endorser.client.ProcessProposal(ctx, signedProposal)
+		code, _, _, _ := responseStatus(response, err)
+		requestDone(code)
 		done <- &ppResponse{response: response, err: err}
 	}()
 	select {
@@ -305,8 +336,10 @@ func (gs *Server) planFromFirstEndorser(ctx context.Context, channel string, cha
 
 			ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
 			defer cancel()
+			requestDone := gs.registry.startRequest(firstEndorser)
 			firstResponse, err = firstEndorser.client.ProcessProposal(ctx, signedProposal)
 			code, message, _, remove := responseStatus(firstResponse, err)
+			requestDone(code)
 
 			if code != codes.OK {
 				logger.Warnw("Endorse call to endorser failed", "endorserAddress", firstEndorser.address, "endorserMspid", firstEndorser.mspid, "error", message)
@@ -419,28 +452,59 @@ func (gs *Server) Submit(ctx context.Context, request *gp.SubmitRequest) (*gp.Su
 		return nil, status.Errorf(codes.Unavailable, "no orderer nodes available")
 	}
 
-	// try each orderer in random order
+	// try the orderers in order of preference, sending to up to ParallelSubmit orderers at a time
+	orderers = gs.registry.submitOrder(orderers)
+	parallel := gs.options.Orderer.ParallelSubmit
+	if parallel < 1 {
+		parallel = 1
+	}
+
 	var errDetails []proto.Message
-	for _, index := range rand.Perm(len(orderers)) {
-		orderer := orderers[index]
-		logger.Infow("Sending transaction to orderer", "txID", request.TransactionId, "endpoint", orderer.address)
-		err := gs.broadcast(ctx, orderer, txn)
-		if err == nil {
-			return &gp.SubmitResponse{}, nil
+	for start := 0; start < len(orderers); start += parallel {
+		end := start + parallel
+		if end > len(orderers) {
+			end = len(orderers)
+		}
+		batch := orderers[start:end]
+
+		results := make(chan *submitResult, len(batch))
+		for _, o := range batch {
+			go func(o *orderer) {
+				logger.Infow("Sending transaction to orderer", "txID", request.TransactionId, "endpoint", o.address)
+				done := gs.registry.startSubmit(o)
+				err := gs.broadcast(ctx, o, txn)
+				done(err)
+				results <- &submitResult{orderer: o, err: err}
+			}(o)
 		}
 
-		logger.Warnw("Error sending transaction to orderer", "txID", request.TransactionId, "endpoint", orderer.address, "err", err)
-		errDetails = append(errDetails, errorDetail(orderer.endpointConfig, err.Error()))
+		var failure *status.Status
+		for range batch {
+			result := <-results
+			if result.err == nil {
+				return &gp.SubmitResponse{}, nil
+			}
 
-		errStatus := toRpcStatus(err)
-		if errStatus.Code() != codes.Unavailable {
-			return nil, newRpcError(errStatus.Code(), errStatus.Message(), errDetails...)
+			logger.Warnw("Error sending transaction to orderer", "txID", request.TransactionId, "endpoint", result.orderer.address, "err", result.err)
+			errDetails = append(errDetails, errorDetail(result.orderer.endpointConfig, result.err.Error()))
+
+			if errStatus := toRpcStatus(result.err); errStatus.Code() != codes.Unavailable && failure == nil {
+				failure = errStatus
+			}
+		}
+		if failure != nil {
+			return nil, newRpcError(failure.Code(), failure.Message(), errDetails...)
 		}
 	}
 
 	return nil, newRpcError(codes.Unavailable, "no orderers could successfully process transaction", errDetails...)
 }
 
+type submitResult struct {
+	orderer *orderer
+	err     error
+}
+
 func (gs *Server) broadcast(ctx context.Context, orderer *orderer, txn *common.Envelope) error {
 	broadcast, err := orderer.client.Broadcast(ctx)
 	if err != nil {
@@ -456,6 +520,10 @@ func (gs *Server) broadcast(ctx context.Context, orderer *orderer, txn *common.E
 		return err
 	}
 
+	if response.GetStatus() == common.Status_SERVICE_UNAVAILABLE {
+		// The orderer cannot currently process transactions, for example if it has no consensus leader
+		return status.Errorf(codes.Unavailable, "received unsuccessful response from orderer: %s", common.Status_name[int32(response.GetStatus())])
+	}
 	if response.GetStatus() != common.Status_SUCCESS {
 		return status.Errorf(codes.Aborted, "received unsuccessful response from orderer: %s", common.Status_name[int32(response.GetStatus())])
 	}
@@ -524,6 +592,11 @@ func (gs *Server) ChaincodeEvents(signedRequest *gp.SignedChaincodeEventsRequest
 		return status.Error(codes.PermissionDenied, err.Error())
 	}
 
+	return gs.chaincodeEvents(request, stream)
+}
+
+// chaincodeEvents streams the chaincode events matching an authorized request until the stream is closed.
+func (gs *Server) chaincodeEvents(request *gp.ChaincodeEventsRequest, stream gp.Gateway_ChaincodeEventsServer) error {
 	ledger, err := gs.ledgerProvider.Ledger(request.GetChannelId())
 	if err != nil {
 		return status.Error(codes.NotFound, err.Error())
@@ -625,3 +698,106 @@ func startBlockFromLedgerPosition(ledger ledger.Ledger, position *ab.SeekPositio
 
 	return ledgerInfo.GetHeight(), nil
 }
+
+// BlockEvents supplies a stream of responses, each containing a committed block. The streamed responses are ordered by
+// ascending block number.
+func (gs *Server) BlockEvents(signedRequest *blockevents.SignedBlockEventsRequest, stream blockevents.BlockEvents_BlockEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Gateway_BlockEvents, func(_ string, block *common.Block, _ *protoutil.SignedData) error {
+		return stream.Send(&blockevents.BlockEventsResponse{
+			Block: block,
+		})
+	})
+}
+
+// FilteredBlockEvents supplies a stream of responses, each containing the filtered representation of a committed
+// block. The streamed responses are ordered by ascending block number.
+func (gs *Server) FilteredBlockEvents(signedRequest *blockevents.SignedBlockEventsRequest, stream blockevents.BlockEvents_FilteredBlockEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Gateway_FilteredBlockEvents, func(_ string, block *common.Block, _ *protoutil.SignedData) error {
+		filteredBlock, err := corepeer.FilteredBlock(block)
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+
+		return stream.Send(&blockevents.FilteredBlockEventsResponse{
+			FilteredBlock: filteredBlock,
+		})
+	})
+}
+
+// BlockAndPrivateDataEvents supplies a stream of responses, each containing a committed block along with the private
+// data from the collections that the caller is eligible to read. The streamed responses are ordered by ascending block
+// number.
+func (gs *Server) BlockAndPrivateDataEvents(signedRequest *blockevents.SignedBlockEventsRequest, stream blockevents.BlockEvents_BlockAndPrivateDataEventsServer) error {
+	return gs.blockEvents(signedRequest, resources.Gateway_BlockAndPrivateDataEvents, func(channelID string, block *common.Block, signedData *protoutil.SignedData) error {
+		privateData, err := gs.privateDataFilter.PrivateData(channelID, block, signedData)
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+
+		return stream.Send(&blockevents.BlockAndPrivateDataEventsResponse{
+			BlockAndPrivateData: &peer.BlockAndPrivateData{
+				Block:          block,
+				PrivateDataMap: privateData,
+			},
+		})
+	})
+}
+
+// blockEvents checks the signed request against the ACL for the resource and passes each block read from the requested
+// start position to send, until the client closes the stream or an error occurs.
+func (gs *Server) blockEvents(
+	signedRequest *blockevents.SignedBlockEventsRequest,
+	resource string,
+	send func(channelID string, block *common.Block, signedData *protoutil.SignedData) error,
+) error {
+	if signedRequest == nil {
+		return status.Error(codes.InvalidArgument, "a block events request is required")
+	}
+
+	request := &blockevents.BlockEventsRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid block events request: %v", err)
+	}
+
+	signedData := &protoutil.SignedData{
+		Data:      signedRequest.Request,
+		Identity:  request.Identity,
+		Signature: signedRequest.Signature,
+	}
+	if err := gs.policy.CheckACL(resource, request.ChannelId, signedData); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	ledger, err := gs.ledgerProvider.Ledger(request.GetChannelId())
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	startBlock, err := startBlockFromLedgerPosition(ledger, request.GetStartPosition())
+	if err != nil {
+		return err
+	}
+
+	ledgerIter, err := ledger.GetBlocksIterator(startBlock)
+	if err != nil {
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	blockIter := event.NewBlockIterator(ledgerIter)
+	defer blockIter.Close()
+
+	for {
+		block, err := blockIter.Next()
+		if err != nil {
+			return status.Error(codes.Aborted, err.Error())
+		}
+
+		if err := send(request.GetChannelId(), block.Block(), signedData); err != nil {
+			if err == io.EOF {
+				// Stream closed by the client
+				return status.Error(codes.Canceled, err.Error())
+			}
+			return err
+		}
+	}
+}
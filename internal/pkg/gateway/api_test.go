@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,11 +20,15 @@ import (
 	dp "github.com/hyperledger/fabric-protos-go/discovery"
 	pb "github.com/hyperledger/fabric-protos-go/gateway"
 	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
@@ -33,6 +38,8 @@ import (
 	ledgermocks "github.com/hyperledger/fabric/internal/pkg/gateway/ledger/mocks"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
 	idmocks "github.com/hyperledger/fabric/internal/pkg/identity/mocks"
+	"github.com/hyperledger/fabric/pkg/gateway/blockevents"
+	"github.com/hyperledger/fabric/pkg/gateway/diagnostics"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -43,6 +50,7 @@ import (
 )
 
 // The following private interfaces are here purely to prevent counterfeiter creating an import cycle in the unit test
+//
 //go:generate counterfeiter -o mocks/endorserclient.go --fake-name EndorserClient . endorserClient
 type endorserClient interface {
 	peer.EndorserClient
@@ -70,6 +78,24 @@ type commitFinder interface {
 
 //go:generate counterfeiter -o mocks/chaincodeeventsserver.go --fake-name ChaincodeEventsServer github.com/hyperledger/fabric-protos-go/gateway.Gateway_ChaincodeEventsServer
 
+//go:generate counterfeiter -o mocks/blockeventsserver.go --fake-name BlockEventsServer github.com/hyperledger/fabric/pkg/gateway/blockevents.BlockEvents_BlockEventsServer
+
+//go:generate counterfeiter -o mocks/filteredblockeventsserver.go --fake-name FilteredBlockEventsServer github.com/hyperledger/fabric/pkg/gateway/blockevents.BlockEvents_FilteredBlockEventsServer
+
+//go:generate counterfeiter -o mocks/blockandprivatedataeventsserver.go --fake-name BlockAndPrivateDataEventsServer github.com/hyperledger/fabric/pkg/gateway/blockevents.BlockEvents_BlockAndPrivateDataEventsServer
+
+//go:generate counterfeiter -o mocks/submitwithretryserver.go --fake-name SubmitWithRetryServer github.com/hyperledger/fabric/pkg/gateway/resubmit.Resubmit_SubmitWithRetryServer
+
+//go:generate counterfeiter -o mocks/privatedatafilter.go --fake-name PrivateDataFilter . privateDataFilter
+type privateDataFilter interface {
+	PrivateDataFilter
+}
+
+//go:generate counterfeiter -o mocks/checkpointstore.go --fake-name CheckpointStore . checkpointStore
+type checkpointStore interface {
+	CheckpointStore
+}
+
 //go:generate counterfeiter -o mocks/aclchecker.go --fake-name ACLChecker . aclChecker
 type aclChecker interface {
 	ACLChecker
@@ -128,6 +154,7 @@ type testDef struct {
 	errString          string
 	errCode            codes.Code
 	errDetails         []*pb.ErrorDetail
+	errMismatches      []*diagnostics.EndorsementMismatch
 	endpointDefinition *endpointDef
 	endorsingOrgs      []string
 	postSetup          func(t *testing.T, def *preparedTest)
@@ -159,6 +186,11 @@ type preparedTest struct {
 	ledgerProvider *ledgermocks.Provider
 	ledger         *ledgermocks.Ledger
 	blockIterator  *mocks.ResultsIterator
+
+	blockEventsServer               *mocks.BlockEventsServer
+	filteredBlockEventsServer       *mocks.FilteredBlockEventsServer
+	blockAndPrivateDataEventsServer *mocks.BlockAndPrivateDataEventsServer
+	privateDataFilter               *mocks.PrivateDataFilter
 }
 
 type contextKey string
@@ -762,6 +794,22 @@ func TestEndorse(t *testing.T) {
 					Message: "ProposalResponsePayloads do not match",
 				},
 			},
+			errMismatches: []*diagnostics.EndorsementMismatch{
+				{
+					Address: "peer2:9051",
+					MspId:   "msp2",
+					Differences: []*diagnostics.Difference{
+						{
+							Type:              diagnostics.DifferenceType_RESPONSE,
+							Description:       `response with status 200 and message "" differs from expected status 200 and message ""`,
+							ExpectedValueHash: util.ComputeSHA256([]byte("different_response")),
+							ExpectedValueSize: 18,
+							ActualValueHash:   util.ComputeSHA256([]byte("mock_response")),
+							ActualValueSize:   13,
+						},
+					},
+				},
+			},
 		},
 		{
 			name: "discovery fails",
@@ -1169,6 +1217,154 @@ func TestSubmit(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "orderer service unavailable retry",
+			plan: endorsementPlan{
+				"g1": {{endorser: localhostMock}},
+			},
+			config: &dp.ConfigResult{
+				Orderers: map[string]*dp.Endpoints{
+					"msp1": {
+						Endpoint: []*dp.Endpoint{
+							{Host: "orderer1", Port: 7050},
+							{Host: "orderer2", Port: 7050},
+							{Host: "orderer3", Port: 7050},
+						},
+					},
+				},
+				Msps: map[string]*msp.FabricMSPConfig{
+					"msp1": {
+						TlsRootCerts: [][]byte{},
+					},
+				},
+			},
+			postSetup: func(t *testing.T, def *preparedTest) {
+				abc := &mocks.ABClient{}
+				abbc := &mocks.ABBClient{}
+				abbc.RecvReturnsOnCall(0, &ab.BroadcastResponse{Status: cp.Status_SERVICE_UNAVAILABLE}, nil)
+				abbc.RecvReturnsOnCall(1, &ab.BroadcastResponse{Status: cp.Status_SUCCESS}, nil)
+				abc.BroadcastReturns(abbc, nil)
+				def.server.registry.endpointFactory = &endpointFactory{
+					timeout: 5 * time.Second,
+					connectEndorser: func(conn *grpc.ClientConn) peer.EndorserClient {
+						return &mocks.EndorserClient{}
+					},
+					connectOrderer: func(_ *grpc.ClientConn) ab.AtomicBroadcastClient {
+						return abc
+					},
+					dialer: func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+						return nil, nil
+					},
+				}
+			},
+			postTest: func(t *testing.T, def *preparedTest) {
+				abbc, _ := def.server.registry.endpointFactory.connectOrderer(nil).Broadcast(def.ctx)
+				require.Equal(t, 2, abbc.(*mocks.ABBClient).SendCallCount())
+			},
+		},
+		{
+			name: "parallel submit to multiple orderers",
+			plan: endorsementPlan{
+				"g1": {{endorser: localhostMock}},
+			},
+			config: &dp.ConfigResult{
+				Orderers: map[string]*dp.Endpoints{
+					"msp1": {
+						Endpoint: []*dp.Endpoint{
+							{Host: "orderer1", Port: 7050},
+							{Host: "orderer2", Port: 7050},
+							{Host: "orderer3", Port: 7050},
+						},
+					},
+				},
+				Msps: map[string]*msp.FabricMSPConfig{
+					"msp1": {
+						TlsRootCerts: [][]byte{},
+					},
+				},
+			},
+			postSetup: func(t *testing.T, def *preparedTest) {
+				def.server.options.Orderer.ParallelSubmit = 3
+				abc := &mocks.ABClient{}
+				abbc := &mocks.ABBClient{}
+				var sendCount int32
+				abbc.SendCalls(func(*cp.Envelope) error {
+					if atomic.AddInt32(&sendCount, 1) < 3 {
+						return status.Error(codes.Unavailable, "Orderer not listening!")
+					}
+					return nil
+				})
+				abbc.RecvReturns(&ab.BroadcastResponse{Status: cp.Status_SUCCESS}, nil)
+				abc.BroadcastReturns(abbc, nil)
+				def.server.registry.endpointFactory = &endpointFactory{
+					timeout: 5 * time.Second,
+					connectEndorser: func(conn *grpc.ClientConn) peer.EndorserClient {
+						return &mocks.EndorserClient{}
+					},
+					connectOrderer: func(_ *grpc.ClientConn) ab.AtomicBroadcastClient {
+						return abc
+					},
+					dialer: func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+						return nil, nil
+					},
+				}
+			},
+			postTest: func(t *testing.T, def *preparedTest) {
+				abbc, _ := def.server.registry.endpointFactory.connectOrderer(nil).Broadcast(def.ctx)
+				require.Equal(t, 3, abbc.(*mocks.ABBClient).SendCallCount())
+			},
+		},
+		{
+			name: "failing orderer is excluded after repeated failures",
+			plan: endorsementPlan{
+				"g1": {{endorser: localhostMock}},
+			},
+			config: &dp.ConfigResult{
+				Orderers: map[string]*dp.Endpoints{
+					"msp1": {
+						Endpoint: []*dp.Endpoint{
+							{Host: "orderer1", Port: 7050},
+							{Host: "orderer2", Port: 7050},
+							{Host: "orderer3", Port: 7050},
+						},
+					},
+				},
+				Msps: map[string]*msp.FabricMSPConfig{
+					"msp1": {
+						TlsRootCerts: [][]byte{},
+					},
+				},
+			},
+			postSetup: func(t *testing.T, def *preparedTest) {
+				def.server.registry.ordererOptions = config.OrdererOptions{FailureThreshold: 1, ResetTimeout: time.Hour}
+				for _, address := range []string{"orderer1:7050", "orderer2:7050"} {
+					def.server.registry.ordererHealth[address] = &ordererHealth{failureThreshold: 1, resetTimeout: time.Hour, failures: 1, openUntil: time.Now().Add(time.Hour)}
+				}
+				abc := &mocks.ABClient{}
+				abbc := &mocks.ABBClient{}
+				abbc.RecvReturns(&ab.BroadcastResponse{Status: cp.Status_SUCCESS}, nil)
+				abc.BroadcastReturns(abbc, nil)
+				def.server.registry.endpointFactory = &endpointFactory{
+					timeout: 5 * time.Second,
+					connectEndorser: func(conn *grpc.ClientConn) peer.EndorserClient {
+						return &mocks.EndorserClient{}
+					},
+					connectOrderer: func(_ *grpc.ClientConn) ab.AtomicBroadcastClient {
+						return abc
+					},
+					dialer: func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+						return nil, nil
+					},
+				}
+			},
+			postTest: func(t *testing.T, def *preparedTest) {
+				// only the healthy orderer is used
+				abbc, _ := def.server.registry.endpointFactory.connectOrderer(nil).Broadcast(def.ctx)
+				require.Equal(t, 1, abbc.(*mocks.ABBClient).SendCallCount())
+				require.Equal(t, 0, def.server.registry.ordererHealth["orderer3:7050"].failures)
+				require.Equal(t, 1, def.server.registry.ordererHealth["orderer3:7050"].samples)
+			},
+		},
 		{
 			name: "multiple orderers all fail",
 			plan: endorsementPlan{
@@ -1238,6 +1434,10 @@ func TestSubmit(t *testing.T) {
 
 			require.NoError(t, err)
 			require.True(t, proto.Equal(&pb.SubmitResponse{}, submitResponse), "Incorrect response")
+
+			if tt.postTest != nil {
+				tt.postTest(t, test)
+			}
 		})
 	}
 }
@@ -1851,6 +2051,312 @@ func TestChaincodeEvents(t *testing.T) {
 	}
 }
 
+func TestBlockEvents(t *testing.T) {
+	newBlock := func(number uint64, transactionID string) *cp.Block {
+		transaction := &cp.Envelope{
+			Payload: protoutil.MarshalOrPanic(&cp.Payload{
+				Header: &cp.Header{
+					ChannelHeader: protoutil.MarshalOrPanic(&cp.ChannelHeader{
+						Type:      int32(cp.HeaderType_CONFIG),
+						ChannelId: testChannel,
+						TxId:      transactionID,
+					}),
+				},
+			}),
+		}
+		metadata := make([][]byte, 5)
+		metadata[cp.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(peer.TxValidationCode_VALID)}
+
+		return &cp.Block{
+			Header: &cp.BlockHeader{
+				Number: number,
+			},
+			Metadata: &cp.BlockMetadata{
+				Metadata: metadata,
+			},
+			Data: &cp.BlockData{
+				Data: [][]byte{protoutil.MarshalOrPanic(transaction)},
+			},
+		}
+	}
+
+	newFilteredBlock := func(number uint64, transactionID string) *peer.FilteredBlock {
+		return &peer.FilteredBlock{
+			ChannelId: testChannel,
+			Number:    number,
+			FilteredTransactions: []*peer.FilteredTransaction{
+				{
+					Txid:             transactionID,
+					Type:             cp.HeaderType_CONFIG,
+					TxValidationCode: peer.TxValidationCode_VALID,
+				},
+			},
+		}
+	}
+
+	block1 := newBlock(101, "TX_ID_1")
+	block2 := newBlock(102, "TX_ID_2")
+
+	privateData := map[uint64]*rwset.TxPvtReadWriteSet{
+		0: {
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{Namespace: testChaincode},
+			},
+		},
+	}
+
+	type eventsCall func(test *preparedTest, signedRequest *blockevents.SignedBlockEventsRequest) error
+
+	blockEvents := func(test *preparedTest, signedRequest *blockevents.SignedBlockEventsRequest) error {
+		return test.server.BlockEvents(signedRequest, test.blockEventsServer)
+	}
+	filteredBlockEvents := func(test *preparedTest, signedRequest *blockevents.SignedBlockEventsRequest) error {
+		return test.server.FilteredBlockEvents(signedRequest, test.filteredBlockEventsServer)
+	}
+	blockAndPrivateDataEvents := func(test *preparedTest, signedRequest *blockevents.SignedBlockEventsRequest) error {
+		return test.server.BlockAndPrivateDataEvents(signedRequest, test.blockAndPrivateDataEventsServer)
+	}
+
+	responses := func(test *preparedTest, call string) []proto.Message {
+		var responses []proto.Message
+		switch call {
+		case "BlockEvents":
+			for i := 0; i < test.blockEventsServer.SendCallCount(); i++ {
+				responses = append(responses, test.blockEventsServer.SendArgsForCall(i))
+			}
+		case "FilteredBlockEvents":
+			for i := 0; i < test.filteredBlockEventsServer.SendCallCount(); i++ {
+				responses = append(responses, test.filteredBlockEventsServer.SendArgsForCall(i))
+			}
+		case "BlockAndPrivateDataEvents":
+			for i := 0; i < test.blockAndPrivateDataEventsServer.SendCallCount(); i++ {
+				responses = append(responses, test.blockAndPrivateDataEventsServer.SendArgsForCall(i))
+			}
+		}
+		return responses
+	}
+
+	calls := []struct {
+		name              string
+		call              eventsCall
+		resource          string
+		expectedResponses []proto.Message
+		sendReturns       func(test *preparedTest, err error)
+	}{
+		{
+			name:     "BlockEvents",
+			call:     blockEvents,
+			resource: resources.Gateway_BlockEvents,
+			expectedResponses: []proto.Message{
+				&blockevents.BlockEventsResponse{Block: block1},
+				&blockevents.BlockEventsResponse{Block: block2},
+			},
+			sendReturns: func(test *preparedTest, err error) {
+				test.blockEventsServer.SendReturns(err)
+			},
+		},
+		{
+			name:     "FilteredBlockEvents",
+			call:     filteredBlockEvents,
+			resource: resources.Gateway_FilteredBlockEvents,
+			expectedResponses: []proto.Message{
+				&blockevents.FilteredBlockEventsResponse{FilteredBlock: newFilteredBlock(101, "TX_ID_1")},
+				&blockevents.FilteredBlockEventsResponse{FilteredBlock: newFilteredBlock(102, "TX_ID_2")},
+			},
+			sendReturns: func(test *preparedTest, err error) {
+				test.filteredBlockEventsServer.SendReturns(err)
+			},
+		},
+		{
+			name:     "BlockAndPrivateDataEvents",
+			call:     blockAndPrivateDataEvents,
+			resource: resources.Gateway_BlockAndPrivateDataEvents,
+			expectedResponses: []proto.Message{
+				&blockevents.BlockAndPrivateDataEventsResponse{
+					BlockAndPrivateData: &peer.BlockAndPrivateData{Block: block1, PrivateDataMap: privateData},
+				},
+				&blockevents.BlockAndPrivateDataEventsResponse{
+					BlockAndPrivateData: &peer.BlockAndPrivateData{Block: block2, PrivateDataMap: privateData},
+				},
+			},
+			sendReturns: func(test *preparedTest, err error) {
+				test.blockAndPrivateDataEventsServer.SendReturns(err)
+			},
+		},
+	}
+
+	for _, c := range calls {
+		c := c
+		tests := []testDef{
+			{
+				name: "returns blocks from the start position",
+				blocks: []*cp.Block{
+					block1,
+					block2,
+				},
+				errCode:           codes.Aborted,
+				errString:         "NO_MORE_BLOCKS",
+				expectedResponses: c.expectedResponses,
+				startPosition: &ab.SeekPosition{
+					Type: &ab.SeekPosition_Specified{
+						Specified: &ab.SeekSpecified{
+							Number: 101,
+						},
+					},
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledger.GetBlocksIteratorCallCount())
+					require.EqualValues(t, 101, test.ledger.GetBlocksIteratorArgsForCall(0))
+				},
+			},
+			{
+				name: "defaults to next commit if start position not specified",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 101}, nil)
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.ledger.GetBlocksIteratorCallCount())
+					require.EqualValues(t, 101, test.ledger.GetBlocksIteratorArgsForCall(0))
+				},
+			},
+			{
+				name: "returns error for unsupported start position type",
+				startPosition: &ab.SeekPosition{
+					Type: &ab.SeekPosition_Oldest{
+						Oldest: &ab.SeekOldest{},
+					},
+				},
+				errCode:   codes.InvalidArgument,
+				errString: "invalid start position type: *orderer.SeekPosition_Oldest",
+			},
+			{
+				name:      "returns error obtaining ledger",
+				errCode:   codes.NotFound,
+				errString: "LEDGER_PROVIDER_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.ledgerProvider.LedgerReturns(nil, errors.New("LEDGER_PROVIDER_ERROR"))
+				},
+			},
+			{
+				name:      "returns error obtaining ledger iterator",
+				errCode:   codes.Aborted,
+				errString: "LEDGER_ITERATOR_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.ledger.GetBlocksIteratorReturns(nil, errors.New("LEDGER_ITERATOR_ERROR"))
+				},
+			},
+			{
+				name: "returns canceled status error when client closes stream",
+				blocks: []*cp.Block{
+					block1,
+				},
+				errCode: codes.Canceled,
+				postSetup: func(t *testing.T, test *preparedTest) {
+					c.sendReturns(test, io.EOF)
+				},
+			},
+			{
+				name: "returns status error from send to client",
+				blocks: []*cp.Block{
+					block1,
+				},
+				errCode:   codes.Aborted,
+				errString: "SEND_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					c.sendReturns(test, status.Error(codes.Aborted, "SEND_ERROR"))
+				},
+			},
+			{
+				name:      "failed policy or signature check",
+				policyErr: errors.New("POLICY_ERROR"),
+				errCode:   codes.PermissionDenied,
+				errString: "POLICY_ERROR",
+			},
+			{
+				name:     "passes resource, channel name and identity to policy checker",
+				identity: []byte("IDENTITY"),
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.policy.CheckACLCallCount())
+					resource, channelName, data := test.policy.CheckACLArgsForCall(0)
+					require.Equal(t, c.resource, resource)
+					require.Equal(t, testChannel, channelName)
+					require.IsType(t, &protoutil.SignedData{}, data)
+					require.Equal(t, []byte("IDENTITY"), data.(*protoutil.SignedData).Identity)
+				},
+			},
+		}
+		if c.name == "BlockAndPrivateDataEvents" {
+			tests = append(tests, testDef{
+				name: "returns error obtaining private data",
+				blocks: []*cp.Block{
+					block1,
+				},
+				errCode:   codes.Aborted,
+				errString: "PRIVATE_DATA_ERROR",
+				postSetup: func(t *testing.T, test *preparedTest) {
+					test.privateDataFilter.PrivateDataReturns(nil, errors.New("PRIVATE_DATA_ERROR"))
+				},
+			}, testDef{
+				name:     "passes channel name, block and identity to private data filter",
+				identity: []byte("IDENTITY"),
+				blocks: []*cp.Block{
+					block1,
+				},
+				postTest: func(t *testing.T, test *preparedTest) {
+					require.Equal(t, 1, test.privateDataFilter.PrivateDataCallCount())
+					channelName, block, signedData := test.privateDataFilter.PrivateDataArgsForCall(0)
+					require.Equal(t, testChannel, channelName)
+					require.True(t, proto.Equal(block1, block), "block mismatch")
+					require.Equal(t, []byte("IDENTITY"), signedData.Identity)
+				},
+			})
+		}
+
+		for _, tt := range tests {
+			t.Run(c.name+" "+tt.name, func(t *testing.T) {
+				postSetup := tt.postSetup
+				tt.postSetup = func(t *testing.T, test *preparedTest) {
+					test.privateDataFilter.PrivateDataReturns(privateData, nil)
+					if postSetup != nil {
+						postSetup(t, test)
+					}
+				}
+				test := prepareTest(t, &tt)
+
+				request := &blockevents.BlockEventsRequest{
+					ChannelId:     testChannel,
+					Identity:      tt.identity,
+					StartPosition: tt.startPosition,
+				}
+				requestBytes, err := proto.Marshal(request)
+				require.NoError(t, err)
+
+				signedRequest := &blockevents.SignedBlockEventsRequest{
+					Request:   requestBytes,
+					Signature: []byte{},
+				}
+
+				err = c.call(test, signedRequest)
+
+				actualResponses := responses(test, c.name)
+				for i, expectedResponse := range tt.expectedResponses {
+					require.Greater(t, len(actualResponses), i, "missing response[%d]", i)
+					require.True(t, proto.Equal(expectedResponse, actualResponses[i]), "response[%d] mismatch: %v", i, actualResponses[i])
+				}
+
+				if checkError(t, &tt, err) {
+					return
+				}
+
+				if tt.postTest != nil {
+					tt.postTest(t, test)
+				}
+			})
+		}
+	}
+}
+
 func TestNilArgs(t *testing.T) {
 	server := newServer(
 		&mocks.EndorserClient{},
@@ -1865,6 +2371,7 @@ func TestNilArgs(t *testing.T) {
 		"msp1",
 		&comm.SecureOptions{},
 		config.GetOptions(viper.New()),
+		NewMetrics(&disabled.Provider{}),
 	)
 	ctx := context.Background()
 
@@ -1888,6 +2395,15 @@ func TestNilArgs(t *testing.T) {
 
 	_, err = server.CommitStatus(ctx, nil)
 	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a commit status request is required"))
+
+	err = server.BlockEvents(nil, &mocks.BlockEventsServer{})
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a block events request is required"))
+
+	err = server.FilteredBlockEvents(nil, &mocks.FilteredBlockEventsServer{})
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a block events request is required"))
+
+	err = server.BlockAndPrivateDataEvents(nil, &mocks.BlockAndPrivateDataEventsServer{})
+	require.ErrorIs(t, err, status.Error(codes.InvalidArgument, "a block events request is required"))
 }
 
 func TestRpcErrorWithBadDetails(t *testing.T) {
@@ -2007,7 +2523,10 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 		Endpoint: "localhost:7051",
 	}
 
-	server := newServer(localEndorser, disc, mockFinder, mockPolicy, mockLedgerProvider, member, "msp1", &comm.SecureOptions{}, options)
+	server := newServer(localEndorser, disc, mockFinder, mockPolicy, mockLedgerProvider, member, "msp1", &comm.SecureOptions{}, options, NewMetrics(&disabled.Provider{}))
+
+	privateDataFilter := &mocks.PrivateDataFilter{}
+	server.privateDataFilter = privateDataFilter
 
 	dialer := &mocks.Dialer{}
 	dialer.Returns(nil, nil)
@@ -2028,6 +2547,11 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 		ledgerProvider: mockLedgerProvider,
 		ledger:         mockLedger,
 		blockIterator:  mockBlockIterator,
+
+		blockEventsServer:               &mocks.BlockEventsServer{},
+		filteredBlockEventsServer:       &mocks.FilteredBlockEventsServer{},
+		blockAndPrivateDataEventsServer: &mocks.BlockAndPrivateDataEventsServer{},
+		privateDataFilter:               privateDataFilter,
 	}
 	if tt.postSetup != nil {
 		tt.postSetup(t, pt)
@@ -2038,7 +2562,7 @@ func prepareTest(t *testing.T, tt *testDef) *preparedTest {
 func checkError(t *testing.T, tt *testDef, err error) (checked bool) {
 	stringCheck := tt.errString != ""
 	codeCheck := tt.errCode != codes.OK
-	detailsCheck := len(tt.errDetails) > 0
+	detailsCheck := len(tt.errDetails) > 0 || len(tt.errMismatches) > 0
 
 	checked = stringCheck || codeCheck || detailsCheck
 	if !checked {
@@ -2061,10 +2585,19 @@ func checkError(t *testing.T, tt *testDef, err error) (checked bool) {
 	}
 
 	if detailsCheck {
-		require.Len(t, s.Details(), len(tt.errDetails))
+		require.Len(t, s.Details(), len(tt.errDetails)+len(tt.errMismatches))
+		var mismatches []*diagnostics.EndorsementMismatch
 		for _, detail := range s.Details() {
+			if mismatch, ok := detail.(*diagnostics.EndorsementMismatch); ok {
+				mismatches = append(mismatches, mismatch)
+				continue
+			}
 			require.Contains(t, tt.errDetails, detail, "error details, expected: %v", tt.errDetails)
 		}
+		require.Len(t, mismatches, len(tt.errMismatches))
+		for i, mismatch := range mismatches {
+			require.True(t, proto.Equal(tt.errMismatches[i], mismatch), "endorsement mismatch, expected: %v, actual: %v", tt.errMismatches[i], mismatch)
+		}
 	}
 
 	return
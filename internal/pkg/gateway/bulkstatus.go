@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/pkg/gateway/bulkstatus"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBulkStatusTransactions is the maximum number of transactions in a single bulk commit status request.
+const maxBulkStatusTransactions = 10000
+
+// BulkCommitStatus returns the commit status of each of the requested transactions, without waiting for transactions
+// to commit.
+func (gs *Server) BulkCommitStatus(_ context.Context, signedRequest *bulkstatus.SignedBulkCommitStatusRequest) (*bulkstatus.BulkCommitStatusResponse, error) {
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a bulk commit status request is required")
+	}
+
+	request := &bulkstatus.BulkCommitStatusRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bulk status request: %v", err)
+	}
+	if len(request.TransactionIds) > maxBulkStatusTransactions {
+		return nil, status.Errorf(codes.InvalidArgument, "too many transaction IDs: %d requested, maximum is %d", len(request.TransactionIds), maxBulkStatusTransactions)
+	}
+
+	signedData := &protoutil.SignedData{
+		Data:      signedRequest.Request,
+		Identity:  request.Identity,
+		Signature: signedRequest.Signature,
+	}
+	if err := gs.policy.CheckACL(resources.Gateway_CommitStatus, request.ChannelId, signedData); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	txStatuses, err := gs.commitFinder.TransactionStatuses(request.ChannelId, request.TransactionIds)
+	if err != nil {
+		return nil, toRpcError(err, codes.Aborted)
+	}
+
+	response := &bulkstatus.BulkCommitStatusResponse{
+		Statuses: make([]*bulkstatus.TransactionStatus, len(request.TransactionIds)),
+	}
+	for i, transactionID := range request.TransactionIds {
+		response.Statuses[i] = &bulkstatus.TransactionStatus{TransactionId: transactionID}
+		if txStatus := txStatuses[i]; txStatus != nil {
+			response.Statuses[i].Committed = true
+			response.Statuses[i].Result = txStatus.Code
+			response.Statuses[i].BlockNumber = txStatus.BlockNumber
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
+	"github.com/hyperledger/fabric/pkg/gateway/bulkstatus"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBulkCommitStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		transactionIDs []string
+		finderStatuses []*commit.Status
+		finderErr      error
+		policyErr      error
+		errCode        codes.Code
+		errString      string
+		expected       []*bulkstatus.TransactionStatus
+	}{
+		{
+			name:           "returns status of committed and unknown transactions",
+			transactionIDs: []string{"TX_1", "TX_2", "TX_3"},
+			finderStatuses: []*commit.Status{
+				{TransactionID: "TX_1", Code: peer.TxValidationCode_VALID, BlockNumber: 101},
+				nil,
+				{TransactionID: "TX_3", Code: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 102},
+			},
+			expected: []*bulkstatus.TransactionStatus{
+				{TransactionId: "TX_1", Committed: true, Result: peer.TxValidationCode_VALID, BlockNumber: 101},
+				{TransactionId: "TX_2"},
+				{TransactionId: "TX_3", Committed: true, Result: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 102},
+			},
+		},
+		{
+			name:           "no transactions",
+			transactionIDs: nil,
+			finderStatuses: nil,
+			expected:       []*bulkstatus.TransactionStatus{},
+		},
+		{
+			name:           "too many transactions",
+			transactionIDs: make([]string, maxBulkStatusTransactions+1),
+			errCode:        codes.InvalidArgument,
+			errString:      fmt.Sprintf("too many transaction IDs: %d requested, maximum is %d", maxBulkStatusTransactions+1, maxBulkStatusTransactions),
+		},
+		{
+			name:           "access denied",
+			transactionIDs: []string{"TX_1"},
+			policyErr:      errors.New("BOOM"),
+			errCode:        codes.PermissionDenied,
+			errString:      "BOOM",
+		},
+		{
+			name:           "returns finder error",
+			transactionIDs: []string{"TX_1"},
+			finderErr:      errors.New("LEDGER_ERROR"),
+			errCode:        codes.Aborted,
+			errString:      "LEDGER_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &testDef{policyErr: tt.policyErr})
+			test.finder.TransactionStatusesReturns(tt.finderStatuses, tt.finderErr)
+
+			request := &bulkstatus.BulkCommitStatusRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				TransactionIds: tt.transactionIDs,
+			}
+			requestBytes := protoutil.MarshalOrPanic(request)
+			signedRequest := &bulkstatus.SignedBulkCommitStatusRequest{
+				Request:   requestBytes,
+				Signature: []byte("SIGNATURE"),
+			}
+
+			response, err := test.server.BulkCommitStatus(test.ctx, signedRequest)
+
+			if tt.errCode != codes.OK {
+				require.Equal(t, tt.errCode, status.Code(err))
+				require.Equal(t, tt.errString, status.Convert(err).Message())
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(&bulkstatus.BulkCommitStatusResponse{Statuses: tt.expected}, response), "response mismatch: %v", response)
+
+			resource, channel, data := test.policy.CheckACLArgsForCall(0)
+			require.Equal(t, resources.Gateway_CommitStatus, resource)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, &protoutil.SignedData{Data: requestBytes, Identity: []byte("IDENTITY"), Signature: []byte("SIGNATURE")}, data)
+
+			channel, transactionIDs := test.finder.TransactionStatusesArgsForCall(0)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, tt.transactionIDs, transactionIDs)
+			require.Equal(t, 0, test.finder.TransactionStatusCallCount(), "does not wait for commit")
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/checkpointstore"
+	"github.com/hyperledger/fabric/pkg/gateway/checkpoint"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckpointedChaincodeEvents supplies a stream of responses in the same way as ChaincodeEvents. If the named
+// checkpoint of the requesting identity exists, events are resumed from the checkpoint position.
+func (gs *Server) CheckpointedChaincodeEvents(signedRequest *checkpoint.SignedCheckpointedChaincodeEventsRequest, stream checkpoint.Checkpoint_CheckpointedChaincodeEventsServer) error {
+	if gs.checkpoints == nil {
+		return status.Error(codes.Unimplemented, "checkpoints are not enabled")
+	}
+	if signedRequest == nil {
+		return status.Error(codes.InvalidArgument, "a checkpointed chaincode events request is required")
+	}
+
+	request := &checkpoint.CheckpointedChaincodeEventsRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid checkpointed chaincode events request: %v", err)
+	}
+	eventsRequest := request.GetEventsRequest()
+	if eventsRequest == nil {
+		return status.Error(codes.InvalidArgument, "a chaincode events request is required")
+	}
+	if request.CheckpointName == "" {
+		return status.Error(codes.InvalidArgument, "a checkpoint name is required")
+	}
+
+	if err := gs.checkCheckpointACL(eventsRequest.ChannelId, eventsRequest.Identity, signedRequest.Request, signedRequest.Signature); err != nil {
+		return err
+	}
+
+	position, err := gs.checkpoints.Get(eventsRequest.ChannelId, eventsRequest.Identity, request.CheckpointName)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if position != nil {
+		logger.Debugw("Resuming chaincode events from checkpoint", "channel", eventsRequest.ChannelId, "checkpoint", request.CheckpointName, "block", position.BlockNumber, "txID", position.TransactionId)
+		eventsRequest.StartPosition = &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{
+				Specified: &ab.SeekSpecified{Number: position.BlockNumber},
+			},
+		}
+		eventsRequest.AfterTransactionId = position.TransactionId
+	}
+
+	return gs.chaincodeEvents(eventsRequest, stream)
+}
+
+// SaveCheckpoint records the event processing position of a named checkpoint of the requesting identity.
+func (gs *Server) SaveCheckpoint(_ context.Context, signedRequest *checkpoint.SignedSaveCheckpointRequest) (*checkpoint.SaveCheckpointResponse, error) {
+	if gs.checkpoints == nil {
+		return nil, status.Error(codes.Unimplemented, "checkpoints are not enabled")
+	}
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a save checkpoint request is required")
+	}
+
+	request := &checkpoint.SaveCheckpointRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid save checkpoint request: %v", err)
+	}
+	if request.CheckpointName == "" {
+		return nil, status.Error(codes.InvalidArgument, "a checkpoint name is required")
+	}
+	if request.Position == nil {
+		return nil, status.Error(codes.InvalidArgument, "a checkpoint position is required")
+	}
+
+	if err := gs.checkCheckpointACL(request.ChannelId, request.Identity, signedRequest.Request, signedRequest.Signature); err != nil {
+		return nil, err
+	}
+
+	if err := gs.checkpoints.Put(request.ChannelId, request.Identity, request.CheckpointName, request.Position); err != nil {
+		if errors.Is(err, checkpointstore.ErrLimitExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &checkpoint.SaveCheckpointResponse{}, nil
+}
+
+// DeleteCheckpoint removes a named checkpoint of the requesting identity.
+func (gs *Server) DeleteCheckpoint(_ context.Context, signedRequest *checkpoint.SignedDeleteCheckpointRequest) (*checkpoint.DeleteCheckpointResponse, error) {
+	if gs.checkpoints == nil {
+		return nil, status.Error(codes.Unimplemented, "checkpoints are not enabled")
+	}
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a delete checkpoint request is required")
+	}
+
+	request := &checkpoint.DeleteCheckpointRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid delete checkpoint request: %v", err)
+	}
+	if request.CheckpointName == "" {
+		return nil, status.Error(codes.InvalidArgument, "a checkpoint name is required")
+	}
+
+	if err := gs.checkCheckpointACL(request.ChannelId, request.Identity, signedRequest.Request, signedRequest.Signature); err != nil {
+		return nil, err
+	}
+
+	if err := gs.checkpoints.Delete(request.ChannelId, request.Identity, request.CheckpointName); err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &checkpoint.DeleteCheckpointResponse{}, nil
+}
+
+// checkCheckpointACL checks that the identity that signed a checkpoint request is allowed to receive chaincode events
+// on the channel. Checkpoints track chaincode event processing, so they share its access control.
+func (gs *Server) checkCheckpointACL(channelName string, identity []byte, request []byte, signature []byte) error {
+	signedData := &protoutil.SignedData{
+		Data:      request,
+		Identity:  identity,
+		Signature: signature,
+	}
+	if err := gs.policy.CheckACL(resources.Gateway_ChaincodeEvents, channelName, signedData); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/checkpointstore"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	"github.com/hyperledger/fabric/pkg/gateway/checkpoint"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testCheckpoint = "CHECKPOINT"
+
+func TestCheckpointedChaincodeEvents(t *testing.T) {
+	tests := []struct {
+		name             string
+		position         *checkpoint.CheckpointPosition
+		storeErr         error
+		policyErr        error
+		noCheckpointName bool
+		disabled         bool
+		errCode          codes.Code
+		errString        string
+		startBlock       uint64
+		afterTxID        string
+	}{
+		{
+			name:       "resumes from checkpoint",
+			position:   &checkpoint.CheckpointPosition{BlockNumber: 99, TransactionId: "CHECKPOINT_TX_ID"},
+			errCode:    codes.Aborted,
+			errString:  "NO_MORE_BLOCKS",
+			startBlock: 99,
+			afterTxID:  "CHECKPOINT_TX_ID",
+		},
+		{
+			name:       "uses request start position without checkpoint",
+			errCode:    codes.Aborted,
+			errString:  "NO_MORE_BLOCKS",
+			startBlock: 10,
+			afterTxID:  "REQUEST_TX_ID",
+		},
+		{
+			name:      "returns error reading checkpoint",
+			storeErr:  errors.New("STORE_ERROR"),
+			errCode:   codes.Unavailable,
+			errString: "STORE_ERROR",
+		},
+		{
+			name:      "access denied",
+			policyErr: fmt.Errorf("BOOM"),
+			errCode:   codes.PermissionDenied,
+			errString: "BOOM",
+		},
+		{
+			name:             "missing checkpoint name",
+			noCheckpointName: true,
+			errCode:          codes.InvalidArgument,
+			errString:        "a checkpoint name is required",
+		},
+		{
+			name:      "checkpoints not enabled",
+			disabled:  true,
+			errCode:   codes.Unimplemented,
+			errString: "checkpoints are not enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &testDef{policyErr: tt.policyErr})
+			store := &mocks.CheckpointStore{}
+			store.GetReturns(tt.position, tt.storeErr)
+			if !tt.disabled {
+				test.server.checkpoints = store
+			}
+			test.ledger.GetBlockByTxIDReturns(nil, errors.New("NOT_FOUND"))
+
+			checkpointName := testCheckpoint
+			if tt.noCheckpointName {
+				checkpointName = ""
+			}
+			request := &checkpoint.CheckpointedChaincodeEventsRequest{
+				EventsRequest: &pb.ChaincodeEventsRequest{
+					ChannelId:   testChannel,
+					Identity:    []byte("IDENTITY"),
+					ChaincodeId: testChaincode,
+					StartPosition: &ab.SeekPosition{
+						Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 10}},
+					},
+					AfterTransactionId: "REQUEST_TX_ID",
+				},
+				CheckpointName: checkpointName,
+			}
+			signedRequest := &checkpoint.SignedCheckpointedChaincodeEventsRequest{
+				Request:   protoutil.MarshalOrPanic(request),
+				Signature: []byte("SIGNATURE"),
+			}
+
+			err := test.server.CheckpointedChaincodeEvents(signedRequest, test.eventsServer)
+
+			require.Equal(t, tt.errCode, status.Code(err))
+			require.Contains(t, status.Convert(err).Message(), tt.errString)
+			if tt.startBlock == 0 {
+				return
+			}
+
+			resource, channel, data := test.policy.CheckACLArgsForCall(0)
+			require.Equal(t, resources.Gateway_ChaincodeEvents, resource)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, []byte("IDENTITY"), data.(*protoutil.SignedData).Identity)
+			require.Equal(t, []byte("SIGNATURE"), data.(*protoutil.SignedData).Signature)
+
+			channel, identity, name := store.GetArgsForCall(0)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, []byte("IDENTITY"), identity)
+			require.Equal(t, testCheckpoint, name)
+
+			require.EqualValues(t, tt.startBlock, test.ledger.GetBlocksIteratorArgsForCall(0))
+			require.Equal(t, tt.afterTxID, test.ledger.GetBlockByTxIDArgsForCall(0))
+		})
+	}
+}
+
+func TestSaveCheckpoint(t *testing.T) {
+	position := &checkpoint.CheckpointPosition{BlockNumber: 101, TransactionId: "TX_ID"}
+
+	tests := []struct {
+		name      string
+		request   *checkpoint.SaveCheckpointRequest
+		storeErr  error
+		policyErr error
+		disabled  bool
+		errCode   codes.Code
+		errString string
+	}{
+		{
+			name: "saves checkpoint",
+			request: &checkpoint.SaveCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+				Position:       position,
+			},
+		},
+		{
+			name: "returns error writing checkpoint",
+			request: &checkpoint.SaveCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+				Position:       position,
+			},
+			storeErr:  errors.New("STORE_ERROR"),
+			errCode:   codes.Unavailable,
+			errString: "STORE_ERROR",
+		},
+		{
+			name: "checkpoint limit exceeded",
+			request: &checkpoint.SaveCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+				Position:       position,
+			},
+			storeErr:  errors.WithMessage(checkpointstore.ErrLimitExceeded, "failed to write checkpoint"),
+			errCode:   codes.ResourceExhausted,
+			errString: "checkpoint limit exceeded",
+		},
+		{
+			name: "access denied",
+			request: &checkpoint.SaveCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+				Position:       position,
+			},
+			policyErr: fmt.Errorf("BOOM"),
+			errCode:   codes.PermissionDenied,
+			errString: "BOOM",
+		},
+		{
+			name: "missing checkpoint name",
+			request: &checkpoint.SaveCheckpointRequest{
+				ChannelId: testChannel,
+				Identity:  []byte("IDENTITY"),
+				Position:  position,
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "a checkpoint name is required",
+		},
+		{
+			name: "missing position",
+			request: &checkpoint.SaveCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "a checkpoint position is required",
+		},
+		{
+			name:      "checkpoints not enabled",
+			request:   &checkpoint.SaveCheckpointRequest{},
+			disabled:  true,
+			errCode:   codes.Unimplemented,
+			errString: "checkpoints are not enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &testDef{policyErr: tt.policyErr})
+			store := &mocks.CheckpointStore{}
+			store.PutReturns(tt.storeErr)
+			if !tt.disabled {
+				test.server.checkpoints = store
+			}
+
+			requestBytes := protoutil.MarshalOrPanic(tt.request)
+			signedRequest := &checkpoint.SignedSaveCheckpointRequest{
+				Request:   requestBytes,
+				Signature: []byte("SIGNATURE"),
+			}
+
+			response, err := test.server.SaveCheckpoint(test.ctx, signedRequest)
+
+			if tt.errCode != codes.OK {
+				require.Equal(t, tt.errCode, status.Code(err))
+				require.Contains(t, status.Convert(err).Message(), tt.errString)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, response)
+
+			resource, channel, data := test.policy.CheckACLArgsForCall(0)
+			require.Equal(t, resources.Gateway_ChaincodeEvents, resource)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, &protoutil.SignedData{Data: requestBytes, Identity: []byte("IDENTITY"), Signature: []byte("SIGNATURE")}, data)
+
+			channel, identity, name, actual := store.PutArgsForCall(0)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, []byte("IDENTITY"), identity)
+			require.Equal(t, testCheckpoint, name)
+			require.True(t, proto.Equal(position, actual))
+		})
+	}
+}
+
+func TestDeleteCheckpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   *checkpoint.DeleteCheckpointRequest
+		storeErr  error
+		policyErr error
+		errCode   codes.Code
+		errString string
+	}{
+		{
+			name: "deletes checkpoint",
+			request: &checkpoint.DeleteCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+			},
+		},
+		{
+			name: "returns error deleting checkpoint",
+			request: &checkpoint.DeleteCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+			},
+			storeErr:  errors.New("STORE_ERROR"),
+			errCode:   codes.Unavailable,
+			errString: "STORE_ERROR",
+		},
+		{
+			name: "access denied",
+			request: &checkpoint.DeleteCheckpointRequest{
+				ChannelId:      testChannel,
+				Identity:       []byte("IDENTITY"),
+				CheckpointName: testCheckpoint,
+			},
+			policyErr: fmt.Errorf("BOOM"),
+			errCode:   codes.PermissionDenied,
+			errString: "BOOM",
+		},
+		{
+			name: "missing checkpoint name",
+			request: &checkpoint.DeleteCheckpointRequest{
+				ChannelId: testChannel,
+				Identity:  []byte("IDENTITY"),
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "a checkpoint name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &testDef{policyErr: tt.policyErr})
+			store := &mocks.CheckpointStore{}
+			store.DeleteReturns(tt.storeErr)
+			test.server.checkpoints = store
+
+			signedRequest := &checkpoint.SignedDeleteCheckpointRequest{
+				Request:   protoutil.MarshalOrPanic(tt.request),
+				Signature: []byte("SIGNATURE"),
+			}
+
+			response, err := test.server.DeleteCheckpoint(test.ctx, signedRequest)
+
+			if tt.errCode != codes.OK {
+				require.Equal(t, tt.errCode, status.Code(err))
+				require.Contains(t, status.Convert(err).Message(), tt.errString)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, response)
+
+			channel, identity, name := store.DeleteArgsForCall(0)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, []byte("IDENTITY"), identity)
+			require.Equal(t, testCheckpoint, name)
+		})
+	}
+}
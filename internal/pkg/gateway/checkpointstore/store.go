@@ -0,0 +1,271 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checkpointstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/pkg/gateway/checkpoint"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("gateway.checkpointstore")
+
+const (
+	dbName = "checkpoints"
+
+	// timestampLength is the length of the last updated time that prefixes each stored checkpoint position.
+	timestampLength = 8
+
+	// maxSweepInterval is the longest time between removals of expired checkpoints.
+	maxSweepInterval = time.Hour
+)
+
+// ErrLimitExceeded is returned by Put when saving a new checkpoint would exceed the number of checkpoints allowed
+// for the identity on the channel.
+var ErrLimitExceeded = errors.New("checkpoint limit exceeded")
+
+// Options is used to configure limits on the checkpoints held by the store.
+type Options struct {
+	// MaxPerIdentity is the number of checkpoints each identity can hold on each channel. A value of zero allows an
+	// unlimited number.
+	MaxPerIdentity int
+	// Expiry is the time after which a checkpoint that has not been saved is removed. A value of zero keeps
+	// checkpoints until they are deleted.
+	Expiry time.Duration
+}
+
+// Store persists named chaincode event checkpoints in a peer-local database. Checkpoints are keyed by channel, the
+// hash of the client identity that owns them, and name.
+type Store struct {
+	provider *leveldbhelper.Provider
+	db       *leveldbhelper.DBHandle
+	options  Options
+	now      func() time.Time
+
+	mutex sync.Mutex // serializes the count and write of new checkpoints
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewStore opens, or creates, the checkpoint store in the given directory. If checkpoints expire, expired checkpoints
+// are removed periodically until the store is closed.
+func NewStore(path string, options Options) (*Store, error) {
+	provider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not open checkpoint store in folder [%s]", path)
+	}
+
+	s := &Store{
+		provider: provider,
+		db:       provider.GetDBHandle(dbName),
+		options:  options,
+		now:      time.Now,
+		done:     make(chan struct{}),
+	}
+
+	if options.Expiry > 0 {
+		if err := s.RemoveExpired(); err != nil {
+			logger.Warningf("Failed to remove expired checkpoints: %s", err)
+		}
+		s.wg.Add(1)
+		go s.sweep(sweepInterval(options.Expiry))
+	}
+
+	return s, nil
+}
+
+func sweepInterval(expiry time.Duration) time.Duration {
+	if expiry < maxSweepInterval {
+		return expiry
+	}
+	return maxSweepInterval
+}
+
+// Get returns the position of the named checkpoint, or nil if the checkpoint does not exist or has expired.
+func (s *Store) Get(channel string, identity []byte, name string) (*checkpoint.CheckpointPosition, error) {
+	value, err := s.db.Get(checkpointKey(channel, identity, name))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read checkpoint %s", name)
+	}
+	if value == nil || s.expired(value) {
+		return nil, nil
+	}
+
+	position := &checkpoint.CheckpointPosition{}
+	if err := proto.Unmarshal(value[timestampLength:], position); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal checkpoint %s", name)
+	}
+	return position, nil
+}
+
+// Put records the position of the named checkpoint. ErrLimitExceeded is returned if the checkpoint does not already
+// exist and the identity already holds the maximum number of checkpoints on the channel.
+func (s *Store) Put(channel string, identity []byte, name string, position *checkpoint.CheckpointPosition) error {
+	data, err := proto.Marshal(position)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal checkpoint %s", name)
+	}
+
+	value := make([]byte, timestampLength, timestampLength+len(data))
+	binary.BigEndian.PutUint64(value, uint64(s.now().UnixNano()))
+	value = append(value, data...)
+
+	key := checkpointKey(channel, identity, name)
+
+	if s.options.MaxPerIdentity > 0 {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if err := s.checkLimit(identityPrefix(channel, identity), key); err != nil {
+			return errors.WithMessagef(err, "failed to write checkpoint %s", name)
+		}
+	}
+
+	if err := s.db.Put(key, value, true); err != nil {
+		return errors.WithMessagef(err, "failed to write checkpoint %s", name)
+	}
+	return nil
+}
+
+// checkLimit returns ErrLimitExceeded if writing the given key would create a checkpoint in excess of the limit for
+// the identity prefix. Overwriting an existing, unexpired checkpoint is always allowed.
+func (s *Store) checkLimit(prefix []byte, key []byte) error {
+	itr, err := s.db.GetIterator(prefix, prefixEnd(prefix))
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	count := 0
+	for itr.Next() {
+		if s.expired(itr.Value()) {
+			continue
+		}
+		if bytes.Equal(itr.Key(), key) {
+			return nil
+		}
+		count++
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "failed to count checkpoints")
+	}
+
+	if count >= s.options.MaxPerIdentity {
+		return ErrLimitExceeded
+	}
+	return nil
+}
+
+// Delete removes the named checkpoint.
+func (s *Store) Delete(channel string, identity []byte, name string) error {
+	if err := s.db.Delete(checkpointKey(channel, identity, name), true); err != nil {
+		return errors.WithMessagef(err, "failed to delete checkpoint %s", name)
+	}
+	return nil
+}
+
+// RemoveExpired removes all checkpoints that have not been saved within the expiry time.
+func (s *Store) RemoveExpired() error {
+	if s.options.Expiry <= 0 {
+		return nil
+	}
+
+	itr, err := s.db.GetIterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	batch := s.db.NewUpdateBatch()
+	for itr.Next() {
+		if s.expired(itr.Value()) {
+			batch.Delete(itr.Key())
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "failed to read checkpoints")
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+	logger.Debugf("Removing %d expired checkpoints", batch.Len())
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return errors.WithMessage(err, "failed to remove expired checkpoints")
+	}
+	return nil
+}
+
+func (s *Store) sweep(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.RemoveExpired(); err != nil {
+				logger.Warningf("Failed to remove expired checkpoints: %s", err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Store) expired(value []byte) bool {
+	if len(value) < timestampLength {
+		return true
+	}
+	if s.options.Expiry <= 0 {
+		return false
+	}
+	updated := time.Unix(0, int64(binary.BigEndian.Uint64(value[:timestampLength])))
+	return s.now().Sub(updated) > s.options.Expiry
+}
+
+// Close stops the removal of expired checkpoints and closes the checkpoint store.
+func (s *Store) Close() {
+	close(s.done)
+	s.wg.Wait()
+	s.provider.Close()
+}
+
+func identityPrefix(channel string, identity []byte) []byte {
+	identityHash := sha256.Sum256(identity)
+
+	prefix := make([]byte, 0, len(channel)+1+len(identityHash))
+	prefix = append(prefix, channel...)
+	prefix = append(prefix, 0)
+	prefix = append(prefix, identityHash[:]...)
+	return prefix
+}
+
+func checkpointKey(channel string, identity []byte, name string) []byte {
+	return append(identityPrefix(channel, identity), name...)
+}
+
+// prefixEnd returns the first key after all keys that start with the given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checkpointstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/pkg/gateway/checkpoint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Options{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	position := &checkpoint.CheckpointPosition{BlockNumber: 101, TransactionId: "TX_ID"}
+
+	actual, err := store.Get("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+	require.Nil(t, actual, "missing checkpoint")
+
+	err = store.Put("channel", []byte("identity"), "checkpoint", position)
+	require.NoError(t, err)
+
+	actual, err = store.Get("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(position, actual))
+
+	for _, key := range []struct{ channel, identity, name string }{
+		{"other", "identity", "checkpoint"},
+		{"channel", "other", "checkpoint"},
+		{"channel", "identity", "other"},
+	} {
+		actual, err = store.Get(key.channel, []byte(key.identity), key.name)
+		require.NoError(t, err)
+		require.Nil(t, actual, "checkpoint %v", key)
+	}
+
+	err = store.Delete("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+
+	actual, err = store.Get("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+	require.Nil(t, actual, "deleted checkpoint")
+}
+
+func TestStoreReopen(t *testing.T) {
+	path := t.TempDir()
+	position := &checkpoint.CheckpointPosition{BlockNumber: 101, TransactionId: "TX_ID"}
+
+	store, err := NewStore(path, Options{})
+	require.NoError(t, err)
+	require.NoError(t, store.Put("channel", []byte("identity"), "checkpoint", position))
+	store.Close()
+
+	store, err = NewStore(path, Options{})
+	require.NoError(t, err)
+	defer store.Close()
+
+	actual, err := store.Get("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(position, actual))
+}
+
+func TestStoreMaxPerIdentity(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Options{MaxPerIdentity: 2})
+	require.NoError(t, err)
+	defer store.Close()
+
+	position := &checkpoint.CheckpointPosition{BlockNumber: 101, TransactionId: "TX_ID"}
+
+	require.NoError(t, store.Put("channel", []byte("identity"), "first", position))
+	require.NoError(t, store.Put("channel", []byte("identity"), "second", position))
+
+	err = store.Put("channel", []byte("identity"), "third", position)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	require.NoError(t, store.Put("channel", []byte("identity"), "second", position), "overwrite existing checkpoint")
+	require.NoError(t, store.Put("channel", []byte("other"), "third", position), "other identity")
+	require.NoError(t, store.Put("other", []byte("identity"), "third", position), "other channel")
+
+	require.NoError(t, store.Delete("channel", []byte("identity"), "first"))
+	require.NoError(t, store.Put("channel", []byte("identity"), "third", position), "after delete")
+}
+
+func TestStoreExpiry(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Options{MaxPerIdentity: 1, Expiry: time.Hour})
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	position := &checkpoint.CheckpointPosition{BlockNumber: 101, TransactionId: "TX_ID"}
+	require.NoError(t, store.Put("channel", []byte("identity"), "checkpoint", position))
+
+	now = now.Add(time.Hour)
+	actual, err := store.Get("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(position, actual), "checkpoint within expiry")
+
+	now = now.Add(time.Second)
+	actual, err = store.Get("channel", []byte("identity"), "checkpoint")
+	require.NoError(t, err)
+	require.Nil(t, actual, "expired checkpoint")
+
+	require.NoError(t, store.Put("channel", []byte("identity"), "other", position), "expired checkpoint not counted")
+}
+
+func TestStoreRemoveExpired(t *testing.T) {
+	store, err := NewStore(t.TempDir(), Options{Expiry: time.Hour})
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	position := &checkpoint.CheckpointPosition{BlockNumber: 101, TransactionId: "TX_ID"}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Put("channel", []byte(fmt.Sprintf("identity%d", i)), "checkpoint", position))
+	}
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, store.Put("channel", []byte("current"), "checkpoint", position))
+
+	require.NoError(t, store.RemoveExpired())
+
+	for i := 0; i < 3; i++ {
+		value, err := store.db.Get(checkpointKey("channel", []byte(fmt.Sprintf("identity%d", i)), "checkpoint"))
+		require.NoError(t, err)
+		require.Nil(t, value, "identity%d", i)
+	}
+	actual, err := store.Get("channel", []byte("current"), "checkpoint")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(position, actual))
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
 
 	"github.com/pkg/errors"
@@ -72,3 +73,33 @@ func (finder *Finder) TransactionStatus(ctx context.Context, channelName string,
 		return status, nil
 	}
 }
+
+// TransactionStatuses provides the status of each of the specified transactions on a given channel, in the order
+// they were specified. Unlike TransactionStatus, this call does not wait for transactions to commit. The status of a
+// transaction that is not in the ledger index is nil. Any other failure to read a transaction status is returned as
+// an error, so that a transaction is not reported as uncommitted because the ledger could not be read.
+func (finder *Finder) TransactionStatuses(channelName string, transactionIDs []string) ([]*Status, error) {
+	ledger, err := finder.provider.Ledger(channelName)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		code, blockNumber, err := ledger.GetTxValidationCodeByTxID(transactionID)
+		if err != nil {
+			var notFound commonledger.NotFoundInIndexErr
+			if errors.As(err, &notFound) {
+				continue
+			}
+			return nil, errors.WithMessagef(err, "failed to read status of transaction %s", transactionID)
+		}
+		statuses[i] = &Status{
+			BlockNumber:   blockNumber,
+			TransactionID: transactionID,
+			Code:          code,
+		}
+	}
+
+	return statuses, nil
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger/mocks"
 	"github.com/pkg/errors"
@@ -139,3 +140,49 @@ func TestFinder(t *testing.T) {
 		require.ErrorContains(t, err, "unexpected close of commit notification channel")
 	})
 }
+
+func TestFinderTransactionStatuses(t *testing.T) {
+	t.Run("returns status of committed transactions", func(t *testing.T) {
+		provider, ledger := newLedgerMocks()
+		ledger.GetTxValidationCodeByTxIDStub = func(transactionID string) (peer.TxValidationCode, uint64, error) {
+			switch transactionID {
+			case "TX_1":
+				return peer.TxValidationCode_VALID, 101, nil
+			case "TX_3":
+				return peer.TxValidationCode_MVCC_READ_CONFLICT, 102, nil
+			default:
+				return 0, 0, commonledger.NotFoundInIndexErr("NOT_FOUND")
+			}
+		}
+		finder := NewFinder(provider, newTestNotifier(nil))
+
+		actual, err := finder.TransactionStatuses("CHANNEL", []string{"TX_1", "TX_2", "TX_3"})
+		require.NoError(t, err)
+
+		expected := []*Status{
+			{Code: peer.TxValidationCode_VALID, BlockNumber: 101, TransactionID: "TX_1"},
+			nil,
+			{Code: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 102, TransactionID: "TX_3"},
+		}
+		require.Equal(t, expected, actual)
+		require.Equal(t, "CHANNEL", provider.LedgerArgsForCall(0))
+	})
+
+	t.Run("returns error from ledger provider", func(t *testing.T) {
+		provider, _ := newLedgerMocks()
+		provider.LedgerReturns(nil, errors.New("NO_LEDGER"))
+		finder := NewFinder(provider, newTestNotifier(nil))
+
+		_, err := finder.TransactionStatuses("CHANNEL", []string{"TX_ID"})
+		require.ErrorContains(t, err, "NO_LEDGER")
+	})
+	t.Run("returns error reading transaction status", func(t *testing.T) {
+		provider, ledger := newLedgerMocks()
+		ledger.GetTxValidationCodeByTxIDReturns(0, 0, errors.New("READ_ERROR"))
+		finder := NewFinder(provider, newTestNotifier(nil))
+
+		_, err := finder.TransactionStatuses("CHANNEL", []string{"TX_ID"})
+		require.ErrorContains(t, err, "READ_ERROR")
+		require.ErrorContains(t, err, "TX_ID")
+	})
+}
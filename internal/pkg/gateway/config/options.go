@@ -9,6 +9,7 @@ package config
 import (
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -20,12 +21,132 @@ type Options struct {
 	EndorsementTimeout time.Duration
 	// DialTimeout is used to specify the maximum time to wait for connecting to external peers and orderer nodes.
 	DialTimeout time.Duration
+	// EndorserSelection is used to specify the strategy for ordering candidate endorsers.
+	EndorserSelection string
+	// EndorserHeightTolerance is used to specify the number of blocks an endorser's ledger height may lag the highest
+	// candidate endorser and still be ordered by the leastLatency and weightedRandom strategies. Endorsers that lag
+	// further are only used after them, in order of ledger height.
+	EndorserHeightTolerance int
+	// HTTP is used to configure the HTTP/JSON gateway service.
+	HTTP HTTPOptions
+	// Resubmit is used to configure the automatic resubmission of transactions invalidated by read conflicts.
+	Resubmit ResubmitOptions
+	// Orderer is used to configure the selection of orderer nodes for submitted transactions.
+	Orderer OrdererOptions
+	// Checkpoints is used to configure the server-side storage of chaincode event checkpoints.
+	Checkpoints CheckpointOptions
+	// RateLimit is used to configure the rate limiting of Evaluate and Endorse requests.
+	RateLimit RateLimitOptions
 }
 
+// HTTPOptions is used to configure the HTTP/JSON gateway service.
+type HTTPOptions struct {
+	// Enabled is used to enable the HTTP/JSON gateway service.
+	Enabled bool
+	// ListenAddress is used to specify the host and port the HTTP/JSON gateway service listens on.
+	ListenAddress string
+	// WriteTimeout is used to specify the maximum duration of a response, or of writing each event of a chaincode
+	// event stream.
+	WriteTimeout time.Duration
+}
+
+// ResubmitOptions is used to configure the automatic resubmission of transactions invalidated by read conflicts.
+type ResubmitOptions struct {
+	// Enabled is used to enable the Resubmit service.
+	Enabled bool
+	// MaxAttempts is used to specify the maximum number of attempts, including the first, for each transaction. It must
+	// be between 0 and MaxResubmitAttempts; a value of 0 or 1 makes a single attempt.
+	MaxAttempts int
+}
+
+// OrdererOptions is used to configure the selection of orderer nodes for submitted transactions.
+type OrdererOptions struct {
+	// ParallelSubmit is used to specify the number of orderer nodes a transaction is sent to concurrently.
+	ParallelSubmit int
+	// FailureThreshold is used to specify the number of consecutive failures after which an orderer node is
+	// temporarily excluded from selection. A value of zero disables circuit breaking.
+	FailureThreshold int
+	// ResetTimeout is used to specify the time after which an excluded orderer node is tried again.
+	ResetTimeout time.Duration
+}
+
+// CheckpointOptions is used to configure the server-side storage of chaincode event checkpoints.
+type CheckpointOptions struct {
+	// Enabled is used to enable the Checkpoint service.
+	Enabled bool
+	// MaxPerIdentity is used to specify the number of checkpoints each client identity can store on each channel.
+	// A value of zero allows an unlimited number.
+	MaxPerIdentity int
+	// Expiry is used to specify the time after which a checkpoint that has not been saved is removed. A value of
+	// zero keeps checkpoints until they are deleted.
+	Expiry time.Duration
+}
+
+// RateLimitOptions is used to configure the rate limiting of Evaluate and Endorse requests. Each request must be
+// allowed by all of the configured limits.
+type RateLimitOptions struct {
+	// MSP is used to limit the requests from all client identities in each MSP.
+	MSP RateLimit
+	// Identity is used to limit the requests from each client identity.
+	Identity RateLimit
+	// Chaincode is used to limit the requests to each chaincode on each channel.
+	Chaincode RateLimit
+}
+
+// RateLimit is used to configure a token bucket rate limit.
+type RateLimit struct {
+	// Rate is used to specify the sustained number of requests allowed per second. A value of zero disables the limit.
+	Rate float64
+	// Burst is used to specify the number of requests allowed in excess of one second of requests at the sustained
+	// rate.
+	Burst int
+}
+
+// MaxResubmitAttempts is the largest supported value of ResubmitOptions.MaxAttempts. Each attempt holds the client
+// stream open while the transaction is endorsed, ordered and committed.
+const MaxResubmitAttempts = 20
+
+// Endorser selection strategies.
+const (
+	// HeightFirst prefers endorsers with the highest ledger height.
+	HeightFirst = "heightFirst"
+	// LeastLatency prefers endorsers with the lowest expected latency, based on their observed response times,
+	// error rate and in-flight requests.
+	LeastLatency = "leastLatency"
+	// WeightedRandom spreads requests randomly across endorsers, weighted by the inverse of their expected latency.
+	WeightedRandom = "weightedRandom"
+)
+
 var defaultOptions = Options{
-	Enabled:            true,
-	EndorsementTimeout: 10 * time.Second,
-	DialTimeout:        30 * time.Second,
+	Enabled:                 true,
+	EndorsementTimeout:      10 * time.Second,
+	DialTimeout:             30 * time.Second,
+	EndorserSelection:       HeightFirst,
+	EndorserHeightTolerance: 2,
+	HTTP: HTTPOptions{
+		Enabled:       false,
+		ListenAddress: "0.0.0.0:7080",
+		WriteTimeout:  2 * time.Minute,
+	},
+	Resubmit: ResubmitOptions{
+		Enabled:     false,
+		MaxAttempts: 5,
+	},
+	Orderer: OrdererOptions{
+		ParallelSubmit:   1,
+		FailureThreshold: 3,
+		ResetTimeout:     30 * time.Second,
+	},
+	Checkpoints: CheckpointOptions{
+		Enabled:        false,
+		MaxPerIdentity: 100,
+		Expiry:         30 * 24 * time.Hour,
+	},
+	RateLimit: RateLimitOptions{
+		MSP:       RateLimit{Rate: 0, Burst: 0},
+		Identity:  RateLimit{Rate: 0, Burst: 0},
+		Chaincode: RateLimit{Rate: 0, Burst: 0},
+	},
 }
 
 // DefaultOptions gets the default Gateway configuration Options
@@ -40,6 +161,81 @@ func GetOptions(v *viper.Viper) Options {
 	if v.IsSet("peer.gateway.dialTimeout") {
 		options.DialTimeout = v.GetDuration("peer.gateway.dialTimeout")
 	}
+	if v.IsSet("peer.gateway.endorserSelection") {
+		options.EndorserSelection = v.GetString("peer.gateway.endorserSelection")
+	}
+	if v.IsSet("peer.gateway.endorserHeightTolerance") {
+		options.EndorserHeightTolerance = v.GetInt("peer.gateway.endorserHeightTolerance")
+	}
+	if v.IsSet("peer.gateway.http.enabled") {
+		options.HTTP.Enabled = v.GetBool("peer.gateway.http.enabled")
+	}
+	if v.IsSet("peer.gateway.http.listenAddress") {
+		options.HTTP.ListenAddress = v.GetString("peer.gateway.http.listenAddress")
+	}
+	if v.IsSet("peer.gateway.http.writeTimeout") {
+		options.HTTP.WriteTimeout = v.GetDuration("peer.gateway.http.writeTimeout")
+	}
+	if v.IsSet("peer.gateway.resubmit.enabled") {
+		options.Resubmit.Enabled = v.GetBool("peer.gateway.resubmit.enabled")
+	}
+	if v.IsSet("peer.gateway.resubmit.maxAttempts") {
+		options.Resubmit.MaxAttempts = v.GetInt("peer.gateway.resubmit.maxAttempts")
+	}
+	if v.IsSet("peer.gateway.orderer.parallelSubmit") {
+		options.Orderer.ParallelSubmit = v.GetInt("peer.gateway.orderer.parallelSubmit")
+	}
+	if v.IsSet("peer.gateway.orderer.failureThreshold") {
+		options.Orderer.FailureThreshold = v.GetInt("peer.gateway.orderer.failureThreshold")
+	}
+	if v.IsSet("peer.gateway.orderer.resetTimeout") {
+		options.Orderer.ResetTimeout = v.GetDuration("peer.gateway.orderer.resetTimeout")
+	}
+	if v.IsSet("peer.gateway.checkpoints.enabled") {
+		options.Checkpoints.Enabled = v.GetBool("peer.gateway.checkpoints.enabled")
+	}
+	if v.IsSet("peer.gateway.checkpoints.maxPerIdentity") {
+		options.Checkpoints.MaxPerIdentity = v.GetInt("peer.gateway.checkpoints.maxPerIdentity")
+	}
+	if v.IsSet("peer.gateway.checkpoints.expiry") {
+		options.Checkpoints.Expiry = v.GetDuration("peer.gateway.checkpoints.expiry")
+	}
+	options.RateLimit.MSP = getRateLimit(v, "peer.gateway.rateLimit.msp", options.RateLimit.MSP)
+	options.RateLimit.Identity = getRateLimit(v, "peer.gateway.rateLimit.identity", options.RateLimit.Identity)
+	options.RateLimit.Chaincode = getRateLimit(v, "peer.gateway.rateLimit.chaincode", options.RateLimit.Chaincode)
 
 	return options
 }
+
+// Validate checks that the options are supported, so that a misconfigured gateway fails at startup rather than
+// behaving differently to the configuration.
+func (o Options) Validate() error {
+	switch o.EndorserSelection {
+	case "", HeightFirst, LeastLatency, WeightedRandom:
+	default:
+		return errors.Errorf("unknown endorser selection strategy: %s", o.EndorserSelection)
+	}
+	if o.EndorserHeightTolerance < 0 {
+		return errors.Errorf("endorser height tolerance must not be negative: %d", o.EndorserHeightTolerance)
+	}
+	if o.Resubmit.MaxAttempts < 0 || o.Resubmit.MaxAttempts > MaxResubmitAttempts {
+		return errors.Errorf("resubmit max attempts must be between 0 and %d: %d", MaxResubmitAttempts, o.Resubmit.MaxAttempts)
+	}
+	if o.Checkpoints.MaxPerIdentity < 0 {
+		return errors.Errorf("checkpoint max per identity must not be negative: %d", o.Checkpoints.MaxPerIdentity)
+	}
+	if o.Checkpoints.Expiry < 0 {
+		return errors.Errorf("checkpoint expiry must not be negative: %s", o.Checkpoints.Expiry)
+	}
+	return nil
+}
+
+func getRateLimit(v *viper.Viper, key string, limit RateLimit) RateLimit {
+	if v.IsSet(key + ".rate") {
+		limit.Rate = v.GetFloat64(key + ".rate")
+	}
+	if v.IsSet(key + ".burst") {
+		limit.Burst = v.GetInt(key + ".burst")
+	}
+	return limit
+}
@@ -21,6 +21,29 @@ peer:
     enabled: true
     endorsementTimeout: 30s
     dialTimeout: 2m
+    endorserSelection: leastLatency
+    endorserHeightTolerance: 5
+    http:
+      enabled: true
+      listenAddress: 127.0.0.1:8080
+      writeTimeout: 1h
+    resubmit:
+      enabled: true
+      maxAttempts: 3
+    orderer:
+      parallelSubmit: 2
+      failureThreshold: 5
+      resetTimeout: 1m
+    checkpoints:
+      enabled: true
+      maxPerIdentity: 10
+      expiry: 24h
+    rateLimit:
+      msp:
+        rate: 100
+      identity:
+        rate: 2.5
+        burst: 10
 `)
 
 var testConfigOff = []byte(`
@@ -42,9 +65,35 @@ func TestOverriddenOptions(t *testing.T) {
 	options := GetOptions(v)
 
 	expectedOptions := Options{
-		Enabled:            true,
-		EndorsementTimeout: 30 * time.Second,
-		DialTimeout:        2 * time.Minute,
+		Enabled:                 true,
+		EndorsementTimeout:      30 * time.Second,
+		DialTimeout:             2 * time.Minute,
+		EndorserSelection:       LeastLatency,
+		EndorserHeightTolerance: 5,
+		HTTP: HTTPOptions{
+			Enabled:       true,
+			ListenAddress: "127.0.0.1:8080",
+			WriteTimeout:  time.Hour,
+		},
+		Resubmit: ResubmitOptions{
+			Enabled:     true,
+			MaxAttempts: 3,
+		},
+		Orderer: OrdererOptions{
+			ParallelSubmit:   2,
+			FailureThreshold: 5,
+			ResetTimeout:     time.Minute,
+		},
+		Checkpoints: CheckpointOptions{
+			Enabled:        true,
+			MaxPerIdentity: 10,
+			Expiry:         24 * time.Hour,
+		},
+		RateLimit: RateLimitOptions{
+			MSP:       RateLimit{Rate: 100, Burst: 0},
+			Identity:  RateLimit{Rate: 2.5, Burst: 10},
+			Chaincode: RateLimit{Rate: 0, Burst: 0},
+		},
 	}
 	require.Equal(t, expectedOptions, options)
 }
@@ -56,9 +105,71 @@ func TestDisabledGatewayOption(t *testing.T) {
 	options := GetOptions(v)
 
 	expectedOptions := Options{
-		Enabled:            false,
-		EndorsementTimeout: 10 * time.Second,
-		DialTimeout:        30 * time.Second,
+		Enabled:                 false,
+		EndorsementTimeout:      10 * time.Second,
+		DialTimeout:             30 * time.Second,
+		EndorserSelection:       HeightFirst,
+		EndorserHeightTolerance: 2,
+		HTTP: HTTPOptions{
+			Enabled:       false,
+			ListenAddress: "0.0.0.0:7080",
+			WriteTimeout:  2 * time.Minute,
+		},
+		Resubmit: ResubmitOptions{
+			Enabled:     false,
+			MaxAttempts: 5,
+		},
+		Orderer: OrdererOptions{
+			ParallelSubmit:   1,
+			FailureThreshold: 3,
+			ResetTimeout:     30 * time.Second,
+		},
+		Checkpoints: CheckpointOptions{
+			Enabled:        false,
+			MaxPerIdentity: 100,
+			Expiry:         30 * 24 * time.Hour,
+		},
+		RateLimit: RateLimitOptions{
+			MSP:       RateLimit{Rate: 0, Burst: 0},
+			Identity:  RateLimit{Rate: 0, Burst: 0},
+			Chaincode: RateLimit{Rate: 0, Burst: 0},
+		},
 	}
 	require.Equal(t, expectedOptions, options)
 }
+
+func TestValidateOptions(t *testing.T) {
+	options := defaultOptions
+	require.NoError(t, options.Validate())
+
+	for _, strategy := range []string{HeightFirst, LeastLatency, WeightedRandom} {
+		options.EndorserSelection = strategy
+		require.NoError(t, options.Validate())
+	}
+
+	options.EndorserSelection = "fastest"
+	require.EqualError(t, options.Validate(), "unknown endorser selection strategy: fastest")
+
+	options = defaultOptions
+	options.EndorserHeightTolerance = -1
+	require.EqualError(t, options.Validate(), "endorser height tolerance must not be negative: -1")
+
+	for _, maxAttempts := range []int{0, 1, MaxResubmitAttempts} {
+		options = defaultOptions
+		options.Resubmit.MaxAttempts = maxAttempts
+		require.NoError(t, options.Validate())
+	}
+
+	options.Resubmit.MaxAttempts = -1
+	require.EqualError(t, options.Validate(), "resubmit max attempts must be between 0 and 20: -1")
+	options.Resubmit.MaxAttempts = MaxResubmitAttempts + 1
+	require.EqualError(t, options.Validate(), "resubmit max attempts must be between 0 and 20: 21")
+
+	options = defaultOptions
+	options.Checkpoints.MaxPerIdentity = -1
+	require.EqualError(t, options.Validate(), "checkpoint max per identity must not be negative: -1")
+
+	options = defaultOptions
+	options.Checkpoints.Expiry = -time.Second
+	require.EqualError(t, options.Validate(), "checkpoint expiry must not be negative: -1s")
+}
@@ -13,11 +13,13 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"go.uber.org/zap/zapcore"
 )
 
 type layout struct {
 	required     map[string]int // group -> quantity
 	endorsements []*peer.Endorsement
+	endorsers    []*endorser
 }
 
 // The plan structure is initialised with an endorsement plan from discovery. It is used to manage the
@@ -113,8 +115,15 @@ func (p *plan) processEndorsement(endorser *endorser, response *peer.ProposalRes
 		p.responsePayload = response.GetPayload()
 	} else {
 		if !bytes.Equal(p.responsePayload, response.GetPayload()) {
-			logger.Warnw("ProposalResponsePayloads do not match (base64)", "payload1", b64.StdEncoding.EncodeToString(p.responsePayload), "payload2", b64.StdEncoding.EncodeToString(response.GetPayload()))
-			p.errorDetails = append(p.errorDetails, errorDetail(endorser.endpointConfig, "ProposalResponsePayloads do not match"))
+			mismatch := endorsementMismatch(endorser.endpointConfig, p.responsePayload, response.GetPayload())
+			logger.Warnw("ProposalResponsePayloads do not match", "address", mismatch.Address, "mspid", mismatch.MspId, "differences", len(mismatch.Differences))
+			if logger.IsEnabledFor(zapcore.DebugLevel) {
+				for _, difference := range mismatch.Differences {
+					logger.Debugw("ProposalResponsePayload difference", "address", mismatch.Address, "type", difference.Type, "description", difference.Description)
+				}
+				logger.Debugw("ProposalResponsePayloads do not match (base64)", "payload1", b64.StdEncoding.EncodeToString(p.responsePayload), "payload2", b64.StdEncoding.EncodeToString(response.GetPayload()))
+			}
+			p.errorDetails = append(p.errorDetails, errorDetail(endorser.endpointConfig, "ProposalResponsePayloads do not match"), mismatch)
 			return false
 		}
 	}
@@ -127,6 +136,7 @@ func (p *plan) processEndorsement(endorser *endorser, response *peer.ProposalRes
 		if quantity, ok := layout.required[group]; ok {
 			layout.required[group] = quantity - 1
 			layout.endorsements = append(layout.endorsements, response.Endorsement)
+			layout.endorsers = append(layout.endorsers, endorser)
 			if layout.required[group] == 0 {
 				// this group for this layout is complete - remove from map
 				delete(layout.required, group)
@@ -8,14 +8,19 @@ package gateway
 import (
 	"context"
 
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	peerproto "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/peer"
 	gdiscovery "github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
+	"github.com/hyperledger/fabric/pkg/gateway/checkpoint"
+	"github.com/hyperledger/fabric/protoutil"
 	"google.golang.org/grpc"
 )
 
@@ -29,6 +34,10 @@ type Server struct {
 	options        config.Options
 	logger         *flogging.FabricLogger
 	ledgerProvider ledger.Provider
+	checkpoints    CheckpointStore
+	rateLimiter    *rateLimiter
+
+	privateDataFilter PrivateDataFilter
 }
 
 type EndorserServerAdapter struct {
@@ -42,14 +51,28 @@ func (e *EndorserServerAdapter) ProcessProposal(ctx context.Context, req *peerpr
 
 type CommitFinder interface {
 	TransactionStatus(ctx context.Context, channelName string, transactionID string) (*commit.Status, error)
+	TransactionStatuses(channelName string, transactionIDs []string) ([]*commit.Status, error)
 }
 
 type ACLChecker interface {
 	CheckACL(policyName string, channelName string, data interface{}) error
 }
 
+// PrivateDataFilter provides the private data of a committed block that the
+// creator of the signed data is eligible to read.
+type PrivateDataFilter interface {
+	PrivateData(channelID string, block *common.Block, signedData *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error)
+}
+
+// CheckpointStore persists named chaincode event checkpoints, which are private to the client identity that owns them.
+type CheckpointStore interface {
+	Get(channelName string, identity []byte, name string) (*checkpoint.CheckpointPosition, error)
+	Put(channelName string, identity []byte, name string, position *checkpoint.CheckpointPosition) error
+	Delete(channelName string, identity []byte, name string) error
+}
+
 // CreateServer creates an embedded instance of the Gateway.
-func CreateServer(localEndorser peerproto.EndorserServer, discovery Discovery, peerInstance *peer.Peer, secureOptions *comm.SecureOptions, policy ACLChecker, localMSPID string, options config.Options) *Server {
+func CreateServer(localEndorser peerproto.EndorserServer, discovery Discovery, peerInstance *peer.Peer, secureOptions *comm.SecureOptions, policy ACLChecker, localMSPID string, options config.Options, checkpoints CheckpointStore, metricsProvider metrics.Provider) *Server {
 	adapter := &ledger.PeerAdapter{
 		Peer: peerInstance,
 	}
@@ -67,14 +90,18 @@ func CreateServer(localEndorser peerproto.EndorserServer, discovery Discovery, p
 		localMSPID,
 		secureOptions,
 		options,
+		NewMetrics(metricsProvider),
 	)
 
+	server.privateDataFilter = peer.NewPrivateDataFilter(peerInstance)
+	server.checkpoints = checkpoints
+
 	peerInstance.AddConfigCallbacks(server.registry.configUpdate)
 
 	return server
 }
 
-func newServer(localEndorser peerproto.EndorserClient, discovery Discovery, finder CommitFinder, policy ACLChecker, ledgerProvider ledger.Provider, localInfo gdiscovery.NetworkMember, localMSPID string, secureOptions *comm.SecureOptions, options config.Options) *Server {
+func newServer(localEndorser peerproto.EndorserClient, discovery Discovery, finder CommitFinder, policy ACLChecker, ledgerProvider ledger.Provider, localInfo gdiscovery.NetworkMember, localMSPID string, secureOptions *comm.SecureOptions, options config.Options, metrics *Metrics) *Server {
 	return &Server{
 		registry: &registry{
 			localEndorser:      &endorser{client: localEndorser, endpointConfig: &endpointConfig{pkiid: localInfo.PKIid, address: localInfo.Endpoint, mspid: localMSPID}},
@@ -83,11 +110,17 @@ func newServer(localEndorser peerproto.EndorserClient, discovery Discovery, find
 			endpointFactory:    &endpointFactory{timeout: options.DialTimeout, clientCert: secureOptions.Certificate, clientKey: secureOptions.Key},
 			remoteEndorsers:    map[string]*endorser{},
 			channelInitialized: map[string]bool{},
+			selector:           newEndorserSelector(options.EndorserSelection, uint64(options.EndorserHeightTolerance)),
+			metrics:            metrics,
+			endorserStats:      map[string]*endorserStats{},
+			ordererOptions:     options.Orderer,
+			ordererHealth:      map[string]*ordererHealth{},
 		},
 		commitFinder:   finder,
 		policy:         policy,
 		options:        options,
 		logger:         logger,
 		ledgerProvider: ledgerProvider,
+		rateLimiter:    newRateLimiter(options.RateLimit),
 	}
 }
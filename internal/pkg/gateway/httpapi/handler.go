@@ -0,0 +1,310 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric/common/fabhttp"
+	"github.com/hyperledger/fabric/common/flogging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate counterfeiter -o mocks/gatewayserver.go --fake-name GatewayServer . gatewayServer
+type gatewayServer interface {
+	gp.GatewayServer
+}
+
+// PathPrefix is the path under which the gateway operations are served.
+const PathPrefix = "/v1/gateway/"
+
+// maxRequestBytes limits the size of request bodies to the default maximum
+// message size of the gRPC gateway service.
+const maxRequestBytes = 100 * 1024 * 1024
+
+// ErrorResponse is returned in the body of failed requests. Details carries
+// the JSON encoded gateway ErrorDetail messages of the failure.
+type ErrorResponse struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// Handler serves the Gateway operations over HTTP. Each operation is invoked
+// by a POST to PathPrefix followed by the operation name, with the JSON
+// encoding of the gRPC request message as the body. Responses are the JSON
+// encoding of the gRPC response message, except for chaincode-events, which
+// streams each response message as a server-sent event. The write timeout
+// applies to each event of the stream rather than to the whole response, so
+// that event streams remain open for as long as the client reads them.
+type Handler struct {
+	Server       gp.GatewayServer
+	Logger       *flogging.FabricLogger
+	WriteTimeout time.Duration
+	marshaler    *jsonpb.Marshaler
+}
+
+// NewHandler creates a Handler for the gateway server.
+func NewHandler(server gp.GatewayServer, writeTimeout time.Duration) *Handler {
+	return &Handler{
+		Server:       server,
+		Logger:       flogging.MustGetLogger("gateway.httpapi"),
+		WriteTimeout: writeTimeout,
+		marshaler:    &jsonpb.Marshaler{},
+	}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.Header().Set("Allow", http.MethodPost)
+		h.writeError(resp, http.StatusMethodNotAllowed, status.Newf(codes.Unimplemented, "invalid request method: %s", req.Method))
+		return
+	}
+
+	req.Body = http.MaxBytesReader(resp, req.Body, maxRequestBytes)
+	defer req.Body.Close()
+
+	ctx := req.Context()
+	operation := strings.TrimPrefix(req.URL.Path, PathPrefix)
+
+	switch operation {
+	case "evaluate":
+		request := &gp.EvaluateRequest{}
+		if h.decode(resp, req, request) {
+			response, err := h.Server.Evaluate(ctx, request)
+			h.sendResponse(resp, response, err)
+		}
+	case "endorse":
+		request := &gp.EndorseRequest{}
+		if h.decode(resp, req, request) {
+			response, err := h.Server.Endorse(ctx, request)
+			h.sendResponse(resp, response, err)
+		}
+	case "submit":
+		request := &gp.SubmitRequest{}
+		if h.decode(resp, req, request) {
+			response, err := h.Server.Submit(ctx, request)
+			h.sendResponse(resp, response, err)
+		}
+	case "commit-status":
+		request := &gp.SignedCommitStatusRequest{}
+		if h.decode(resp, req, request) {
+			response, err := h.Server.CommitStatus(ctx, request)
+			h.sendResponse(resp, response, err)
+		}
+	case "chaincode-events":
+		request := &gp.SignedChaincodeEventsRequest{}
+		if h.decode(resp, req, request) {
+			h.chaincodeEvents(req, resp, request)
+		}
+	default:
+		h.sendError(resp, status.Errorf(codes.NotFound, "unknown gateway operation: %s", operation))
+	}
+}
+
+func (h *Handler) decode(resp http.ResponseWriter, req *http.Request, request proto.Message) bool {
+	if err := jsonpb.Unmarshal(req.Body, request); err != nil {
+		h.sendError(resp, status.Errorf(codes.InvalidArgument, "invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func (h *Handler) sendResponse(resp http.ResponseWriter, response proto.Message, err error) {
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+	if err := h.marshaler.Marshal(resp, response); err != nil {
+		h.Logger.Errorw("failed to encode response", "error", err)
+	}
+}
+
+func (h *Handler) sendError(resp http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	h.writeError(resp, httpStatus(s.Code()), s)
+}
+
+func (h *Handler) writeError(resp http.ResponseWriter, code int, s *status.Status) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(h.errorResponse(s)); err != nil {
+		h.Logger.Errorw("failed to encode error response", "error", err)
+	}
+}
+
+func (h *Handler) errorResponse(s *status.Status) *ErrorResponse {
+	errorResponse := &ErrorResponse{
+		Code:    s.Code().String(),
+		Message: s.Message(),
+	}
+	for _, detail := range s.Details() {
+		message, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+		encoded, err := h.marshaler.MarshalToString(message)
+		if err != nil {
+			h.Logger.Warnw("failed to encode error detail", "error", err)
+			continue
+		}
+		errorResponse.Details = append(errorResponse.Details, json.RawMessage(encoded))
+	}
+	return errorResponse
+}
+
+// chaincodeEvents streams the chaincode events responses as server-sent
+// events. Errors that occur before the first response are returned as a
+// regular error response; later errors are sent as an error event.
+func (h *Handler) chaincodeEvents(req *http.Request, resp http.ResponseWriter, request *gp.SignedChaincodeEventsRequest) {
+	stream := &eventStream{
+		ctx:          req.Context(),
+		req:          req,
+		resp:         resp,
+		writeTimeout: h.WriteTimeout,
+		marshaler:    h.marshaler,
+	}
+
+	err := h.Server.ChaincodeEvents(request, stream)
+	if status.Code(err) == codes.Canceled {
+		// Stream closed by the client
+		return
+	}
+	if !stream.started {
+		h.sendError(resp, err)
+		return
+	}
+
+	encoded, encodeErr := json.Marshal(h.errorResponse(status.Convert(err)))
+	if encodeErr != nil {
+		h.Logger.Errorw("failed to encode error event", "error", encodeErr)
+		return
+	}
+	if writeErr := stream.writeEvent("error", encoded); writeErr != nil {
+		h.Logger.Debugw("failed to send error event", "error", writeErr)
+	}
+}
+
+// eventStream adapts an HTTP response to the chaincode events server stream,
+// writing each response as a server-sent event.
+type eventStream struct {
+	ctx          context.Context
+	req          *http.Request
+	resp         http.ResponseWriter
+	writeTimeout time.Duration
+	marshaler    *jsonpb.Marshaler
+	started      bool
+}
+
+func (s *eventStream) Send(response *gp.ChaincodeEventsResponse) error {
+	buffer := &bytes.Buffer{}
+	if err := s.marshaler.Marshal(buffer, response); err != nil {
+		return status.Errorf(codes.Internal, "failed to encode chaincode events response: %s", err)
+	}
+	return s.writeEvent("", buffer.Bytes())
+}
+
+func (s *eventStream) writeEvent(event string, data []byte) error {
+	if err := s.ctx.Err(); err != nil {
+		// Request cancelled or client disconnected
+		return io.EOF
+	}
+
+	if s.writeTimeout > 0 {
+		fabhttp.SetWriteDeadline(s.req, time.Now().Add(s.writeTimeout))
+	}
+
+	if !s.started {
+		s.resp.Header().Set("Content-Type", "text/event-stream")
+		s.resp.Header().Set("Cache-Control", "no-cache")
+		s.resp.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	var err error
+	if event != "" {
+		_, err = fmt.Fprintf(s.resp, "event: %s\n", event)
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(s.resp, "data: %s\n\n", data)
+	}
+	if err != nil {
+		return io.EOF
+	}
+
+	if flusher, ok := s.resp.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func (s *eventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *eventStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *eventStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *eventStream) SetTrailer(metadata.MD) {}
+
+func (s *eventStream) SendMsg(interface{}) error {
+	return status.Error(codes.Unimplemented, "SendMsg is not supported")
+}
+
+func (s *eventStream) RecvMsg(interface{}) error {
+	return status.Error(codes.Unimplemented, "RecvMsg is not supported")
+}
+
+// httpStatus maps gRPC status codes to HTTP status codes.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpapi_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/httpapi"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/httpapi/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newRequest(t *testing.T, method, operation string, message proto.Message) *http.Request {
+	body, err := (&jsonpb.Marshaler{}).MarshalToString(message)
+	require.NoError(t, err)
+	return httptest.NewRequest(method, httpapi.PathPrefix+operation, strings.NewReader(body))
+}
+
+func decodeResponse(t *testing.T, recorder *httptest.ResponseRecorder, message proto.Message) {
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.NoError(t, jsonpb.Unmarshal(recorder.Body, message))
+}
+
+func decodeError(t *testing.T, recorder *httptest.ResponseRecorder) *httpapi.ErrorResponse {
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	errorResponse := &httpapi.ErrorResponse{}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(errorResponse))
+	return errorResponse
+}
+
+func TestUnaryOperations(t *testing.T) {
+	proposal := &peer.SignedProposal{ProposalBytes: []byte("PROPOSAL"), Signature: []byte("SIGNATURE")}
+	envelope := &cp.Envelope{Payload: []byte("PAYLOAD"), Signature: []byte("SIGNATURE")}
+
+	tests := []struct {
+		operation        string
+		request          proto.Message
+		response         proto.Message
+		setup            func(server *mocks.GatewayServer, response proto.Message, err error)
+		receivedRequest  func(server *mocks.GatewayServer) proto.Message
+		expectedResponse proto.Message
+	}{
+		{
+			operation: "evaluate",
+			request:   &gp.EvaluateRequest{TransactionId: "TX_ID", ChannelId: "CHANNEL", ProposedTransaction: proposal},
+			response:  &gp.EvaluateResponse{Result: &peer.Response{Status: 200, Payload: []byte("RESULT")}},
+			setup: func(server *mocks.GatewayServer, response proto.Message, err error) {
+				r, _ := response.(*gp.EvaluateResponse)
+				server.EvaluateReturns(r, err)
+			},
+			receivedRequest: func(server *mocks.GatewayServer) proto.Message {
+				_, request := server.EvaluateArgsForCall(0)
+				return request
+			},
+			expectedResponse: &gp.EvaluateResponse{},
+		},
+		{
+			operation: "endorse",
+			request:   &gp.EndorseRequest{TransactionId: "TX_ID", ChannelId: "CHANNEL", ProposedTransaction: proposal, EndorsingOrganizations: []string{"Org1MSP"}},
+			response:  &gp.EndorseResponse{PreparedTransaction: envelope},
+			setup: func(server *mocks.GatewayServer, response proto.Message, err error) {
+				r, _ := response.(*gp.EndorseResponse)
+				server.EndorseReturns(r, err)
+			},
+			receivedRequest: func(server *mocks.GatewayServer) proto.Message {
+				_, request := server.EndorseArgsForCall(0)
+				return request
+			},
+			expectedResponse: &gp.EndorseResponse{},
+		},
+		{
+			operation: "submit",
+			request:   &gp.SubmitRequest{TransactionId: "TX_ID", ChannelId: "CHANNEL", PreparedTransaction: envelope},
+			response:  &gp.SubmitResponse{},
+			setup: func(server *mocks.GatewayServer, response proto.Message, err error) {
+				r, _ := response.(*gp.SubmitResponse)
+				server.SubmitReturns(r, err)
+			},
+			receivedRequest: func(server *mocks.GatewayServer) proto.Message {
+				_, request := server.SubmitArgsForCall(0)
+				return request
+			},
+			expectedResponse: &gp.SubmitResponse{},
+		},
+		{
+			operation: "commit-status",
+			request:   &gp.SignedCommitStatusRequest{Request: []byte("REQUEST"), Signature: []byte("SIGNATURE")},
+			response:  &gp.CommitStatusResponse{Result: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 101},
+			setup: func(server *mocks.GatewayServer, response proto.Message, err error) {
+				r, _ := response.(*gp.CommitStatusResponse)
+				server.CommitStatusReturns(r, err)
+			},
+			receivedRequest: func(server *mocks.GatewayServer) proto.Message {
+				_, request := server.CommitStatusArgsForCall(0)
+				return request
+			},
+			expectedResponse: &gp.CommitStatusResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation+" returns response", func(t *testing.T) {
+			server := &mocks.GatewayServer{}
+			tt.setup(server, tt.response, nil)
+			recorder := httptest.NewRecorder()
+
+			httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, newRequest(t, http.MethodPost, tt.operation, tt.request))
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.True(t, proto.Equal(tt.request, tt.receivedRequest(server)), "request mismatch")
+			decodeResponse(t, recorder, tt.expectedResponse)
+			require.True(t, proto.Equal(tt.response, tt.expectedResponse), "response mismatch")
+		})
+
+		t.Run(tt.operation+" returns error", func(t *testing.T) {
+			server := &mocks.GatewayServer{}
+			detail := &gp.ErrorDetail{Address: "peer0:7051", MspId: "Org1MSP", Message: "ENDORSER_ERROR"}
+			s, err := status.New(codes.Aborted, "GATEWAY_ERROR").WithDetails(detail)
+			require.NoError(t, err)
+			tt.setup(server, nil, s.Err())
+			recorder := httptest.NewRecorder()
+
+			httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, newRequest(t, http.MethodPost, tt.operation, tt.request))
+
+			require.Equal(t, http.StatusConflict, recorder.Code)
+			errorResponse := decodeError(t, recorder)
+			require.Equal(t, "Aborted", errorResponse.Code)
+			require.Equal(t, "GATEWAY_ERROR", errorResponse.Message)
+			require.Len(t, errorResponse.Details, 1)
+			actualDetail := &gp.ErrorDetail{}
+			require.NoError(t, jsonpb.UnmarshalString(string(errorResponse.Details[0]), actualDetail))
+			require.True(t, proto.Equal(detail, actualDetail), "detail mismatch")
+		})
+	}
+}
+
+func TestErrorStatusCodes(t *testing.T) {
+	tests := []struct {
+		code     codes.Code
+		expected int
+	}{
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.FailedPrecondition, http.StatusBadRequest},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Unknown, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			server := &mocks.GatewayServer{}
+			server.EvaluateReturns(nil, status.Error(tt.code, "ERROR"))
+			recorder := httptest.NewRecorder()
+
+			httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, newRequest(t, http.MethodPost, "evaluate", &gp.EvaluateRequest{}))
+
+			require.Equal(t, tt.expected, recorder.Code)
+			require.Equal(t, tt.code.String(), decodeError(t, recorder).Code)
+		})
+	}
+}
+
+func TestInvalidRequests(t *testing.T) {
+	t.Run("invalid method", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		httpapi.NewHandler(&mocks.GatewayServer{}, time.Minute).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, httpapi.PathPrefix+"evaluate", nil))
+
+		require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		require.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+		require.Equal(t, "invalid request method: GET", decodeError(t, recorder).Message)
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		httpapi.NewHandler(&mocks.GatewayServer{}, time.Minute).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, httpapi.PathPrefix+"unknown", strings.NewReader("{}")))
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+		require.Equal(t, "unknown gateway operation: unknown", decodeError(t, recorder).Message)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		server := &mocks.GatewayServer{}
+		recorder := httptest.NewRecorder()
+		httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, httpapi.PathPrefix+"evaluate", strings.NewReader(`{"transactionId":`)))
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+		errorResponse := decodeError(t, recorder)
+		require.Equal(t, "InvalidArgument", errorResponse.Code)
+		require.Contains(t, errorResponse.Message, "invalid request body")
+		require.Equal(t, 0, server.EvaluateCallCount())
+	})
+}
+
+func TestChaincodeEvents(t *testing.T) {
+	request := &gp.SignedChaincodeEventsRequest{Request: []byte("REQUEST"), Signature: []byte("SIGNATURE")}
+	responses := []*gp.ChaincodeEventsResponse{
+		{BlockNumber: 101, Events: []*peer.ChaincodeEvent{{ChaincodeId: "CHAINCODE", TxId: "TX_ID_1", EventName: "EVENT"}}},
+		{BlockNumber: 102, Events: []*peer.ChaincodeEvent{{ChaincodeId: "CHAINCODE", TxId: "TX_ID_2", EventName: "EVENT"}}},
+	}
+
+	type event struct {
+		name string
+		data string
+	}
+	readEvents := func(t *testing.T, recorder *httptest.ResponseRecorder) []event {
+		var events []event
+		current := event{}
+		scanner := bufio.NewScanner(recorder.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				current.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				current.data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				events = append(events, current)
+				current = event{}
+			}
+		}
+		return events
+	}
+
+	t.Run("streams responses as server-sent events", func(t *testing.T) {
+		server := &mocks.GatewayServer{}
+		server.ChaincodeEventsStub = func(_ *gp.SignedChaincodeEventsRequest, stream gp.Gateway_ChaincodeEventsServer) error {
+			for _, response := range responses {
+				if err := stream.Send(response); err != nil {
+					return err
+				}
+			}
+			return status.Error(codes.Aborted, "LEDGER_ERROR")
+		}
+		recorder := httptest.NewRecorder()
+
+		httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, newRequest(t, http.MethodPost, "chaincode-events", request))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+		receivedRequest, _ := server.ChaincodeEventsArgsForCall(0)
+		require.True(t, proto.Equal(request, receivedRequest), "request mismatch")
+
+		events := readEvents(t, recorder)
+		require.Len(t, events, 3)
+		for i, response := range responses {
+			require.Equal(t, "", events[i].name)
+			actual := &gp.ChaincodeEventsResponse{}
+			require.NoError(t, jsonpb.UnmarshalString(events[i].data, actual))
+			require.True(t, proto.Equal(response, actual), "response[%d] mismatch", i)
+		}
+		require.Equal(t, "error", events[2].name)
+		errorResponse := &httpapi.ErrorResponse{}
+		require.NoError(t, json.Unmarshal([]byte(events[2].data), errorResponse))
+		require.Equal(t, &httpapi.ErrorResponse{Code: "Aborted", Message: "LEDGER_ERROR"}, errorResponse)
+	})
+
+	t.Run("returns error response if no events sent", func(t *testing.T) {
+		server := &mocks.GatewayServer{}
+		server.ChaincodeEventsReturns(status.Error(codes.PermissionDenied, "POLICY_ERROR"))
+		recorder := httptest.NewRecorder()
+
+		httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, newRequest(t, http.MethodPost, "chaincode-events", request))
+
+		require.Equal(t, http.StatusForbidden, recorder.Code)
+		require.Equal(t, "POLICY_ERROR", decodeError(t, recorder).Message)
+	})
+
+	t.Run("stops streaming when the client disconnects", func(t *testing.T) {
+		var sendErr error
+		server := &mocks.GatewayServer{}
+		ctx, cancel := context.WithCancel(context.Background())
+		server.ChaincodeEventsStub = func(_ *gp.SignedChaincodeEventsRequest, stream gp.Gateway_ChaincodeEventsServer) error {
+			require.NoError(t, stream.Send(responses[0]))
+			cancel()
+			sendErr = stream.Send(responses[1])
+			return status.Error(codes.Canceled, sendErr.Error())
+		}
+		recorder := httptest.NewRecorder()
+
+		httpapi.NewHandler(server, time.Minute).ServeHTTP(recorder, newRequest(t, http.MethodPost, "chaincode-events", request).WithContext(ctx))
+
+		require.EqualError(t, sendErr, "EOF")
+		require.Len(t, readEvents(t, recorder), 1)
+	})
+}
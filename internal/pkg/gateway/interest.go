@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/pkg/gateway/interest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EndorseWithInterest collects endorsements from peers selected by discovery using the chaincode interest supplied by
+// the client, without first endorsing the proposal on a single peer. The response includes the layout of endorsing
+// peers that satisfied the endorsement policy.
+func (gs *Server) EndorseWithInterest(ctx context.Context, request *interest.EndorseWithInterestRequest) (*interest.EndorseWithInterestResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "an endorse with interest request is required")
+	}
+	if len(request.GetChaincodeInterest().GetChaincodes()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a chaincode interest is required")
+	}
+
+	response, plan, err := gs.endorse(ctx, request.EndorseRequest, request.ChaincodeInterest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interest.EndorseWithInterestResponse{
+		EndorseResponse: response,
+		Layout:          plan.endorsementLayout(),
+	}, nil
+}
+
+// planFromInterest builds an endorsement plan from a chaincode interest supplied by the client. Layouts containing
+// the gateway's organization are preferred.
+func (gs *Server) planFromInterest(channel string, chaincodeID string, hasTransientData bool, chaincodeInterest *peer.ChaincodeInterest) (*plan, error) {
+	invoked := false
+	for _, call := range chaincodeInterest.GetChaincodes() {
+		if call.GetName() == chaincodeID {
+			invoked = true
+			break
+		}
+	}
+	if !invoked {
+		return nil, status.Errorf(codes.InvalidArgument, "the chaincode interest must include the invoked chaincode %s", chaincodeID)
+	}
+
+	// As for the interest returned by the first endorser, ensure that discovery only returns orgs which own the
+	// collections involved if transient data is involved.
+	if hasTransientData {
+		chaincodeInterest = proto.Clone(chaincodeInterest).(*peer.ChaincodeInterest)
+		for _, call := range chaincodeInterest.GetChaincodes() {
+			call.NoPrivateReads = false
+		}
+	}
+
+	plan, err := gs.registry.endorsementPlan(channel, chaincodeInterest, gs.registry.localEndorser)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return plan, nil
+}
+
+// endorsementLayout describes the endorsers of the completed layout, grouped and sorted by group name and address.
+func (p *plan) endorsementLayout() *interest.EndorsementLayout {
+	p.planLock.Lock()
+	defer p.planLock.Unlock()
+
+	groups := map[string]*interest.LayoutGroup{}
+	var names []string
+	for _, endorser := range p.completedLayout.endorsers {
+		name := p.groupIds[endorser.pkiid.String()]
+		group, ok := groups[name]
+		if !ok {
+			group = &interest.LayoutGroup{Name: name}
+			groups[name] = group
+			names = append(names, name)
+		}
+		group.Endorsers = append(group.Endorsers, &interest.LayoutEndorser{Address: endorser.address, MspId: endorser.mspid})
+	}
+	sort.Strings(names)
+
+	layout := &interest.EndorsementLayout{}
+	for _, name := range names {
+		group := groups[name]
+		sort.Slice(group.Endorsers, func(i, j int) bool {
+			return group.Endorsers[i].Address < group.Endorsers[j].Address
+		})
+		layout.Groups = append(layout.Groups, group)
+	}
+	return layout
+}
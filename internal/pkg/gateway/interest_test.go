@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/pkg/gateway/interest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEndorseWithInterest(t *testing.T) {
+	crossChaincodeInterest := &peer.ChaincodeInterest{
+		Chaincodes: []*peer.ChaincodeCall{
+			{Name: testChaincode, CollectionNames: []string{"collection1"}, NoPrivateReads: true},
+			{Name: "other_chaincode"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		def               testDef
+		interest          *peer.ChaincodeInterest
+		endorsingOrgs     []string
+		errCode           codes.Code
+		errString         string
+		expectedEndorsers []string
+		expectedInterest  *peer.ChaincodeInterest
+		expectedLayout    *interest.EndorsementLayout
+	}{
+		{
+			name: "endorses without first endorsement",
+			def: testDef{
+				plan: endorsementPlan{
+					"g1": {{endorser: peer1Mock, height: 3}}, // msp1
+					"g2": {{endorser: peer2Mock, height: 3}}, // msp2
+				},
+			},
+			interest:          crossChaincodeInterest,
+			expectedEndorsers: []string{"peer1:8051", "peer2:9051"},
+			expectedInterest:  crossChaincodeInterest,
+			expectedLayout: &interest.EndorsementLayout{
+				Groups: []*interest.LayoutGroup{
+					{Name: "g1", Endorsers: []*interest.LayoutEndorser{{Address: "peer1:8051", MspId: "msp1"}}},
+					{Name: "g2", Endorsers: []*interest.LayoutEndorser{{Address: "peer2:9051", MspId: "msp2"}}},
+				},
+			},
+		},
+		{
+			name: "reports layout with multiple endorsers from a group",
+			def: testDef{
+				plan: endorsementPlan{
+					"g1": {{endorser: peer2Mock, height: 3}, {endorser: peer3Mock, height: 3}}, // msp2
+					"g2": {{endorser: peer4Mock, height: 3}},                                   // msp3
+				},
+				layouts: []endorsementLayout{
+					{"g1": 2, "g2": 1},
+				},
+			},
+			interest:          crossChaincodeInterest,
+			expectedEndorsers: []string{"peer2:9051", "peer3:10051", "peer4:11051"},
+			expectedInterest:  crossChaincodeInterest,
+			expectedLayout: &interest.EndorsementLayout{
+				Groups: []*interest.LayoutGroup{
+					{Name: "g1", Endorsers: []*interest.LayoutEndorser{{Address: "peer2:9051", MspId: "msp2"}, {Address: "peer3:10051", MspId: "msp2"}}},
+					{Name: "g2", Endorsers: []*interest.LayoutEndorser{{Address: "peer4:11051", MspId: "msp3"}}},
+				},
+			},
+		},
+		{
+			name: "ignores no private reads hints with transient data",
+			def: testDef{
+				plan: endorsementPlan{
+					"g1": {{endorser: peer2Mock, height: 3}}, // msp2
+				},
+				transientData: map[string][]byte{"transient-key": []byte("transient-value")},
+			},
+			interest:          crossChaincodeInterest,
+			expectedEndorsers: []string{"peer2:9051"},
+			expectedInterest: &peer.ChaincodeInterest{
+				Chaincodes: []*peer.ChaincodeCall{
+					{Name: testChaincode, CollectionNames: []string{"collection1"}, NoPrivateReads: false},
+					{Name: "other_chaincode"},
+				},
+			},
+			expectedLayout: &interest.EndorsementLayout{
+				Groups: []*interest.LayoutGroup{
+					{Name: "g1", Endorsers: []*interest.LayoutEndorser{{Address: "peer2:9051", MspId: "msp2"}}},
+				},
+			},
+		},
+		{
+			name:      "missing chaincode interest",
+			interest:  &peer.ChaincodeInterest{},
+			errCode:   codes.InvalidArgument,
+			errString: "a chaincode interest is required",
+		},
+		{
+			name: "chaincode interest does not include invoked chaincode",
+			interest: &peer.ChaincodeInterest{
+				Chaincodes: []*peer.ChaincodeCall{{Name: "other_chaincode"}},
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "the chaincode interest must include the invoked chaincode test_chaincode",
+		},
+		{
+			name:          "endorsing organizations specified",
+			interest:      crossChaincodeInterest,
+			endorsingOrgs: []string{"msp1"},
+			errCode:       codes.InvalidArgument,
+			errString:     "endorsing organizations cannot be specified with a chaincode interest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &tt.def)
+
+			request := &interest.EndorseWithInterestRequest{
+				EndorseRequest: &pb.EndorseRequest{
+					ProposedTransaction:    test.signedProposal,
+					EndorsingOrganizations: tt.endorsingOrgs,
+				},
+				ChaincodeInterest: tt.interest,
+			}
+			response, err := test.server.EndorseWithInterest(test.ctx, request)
+
+			if tt.errCode != codes.OK {
+				require.Equal(t, tt.errCode, status.Code(err))
+				require.Equal(t, tt.errString, status.Convert(err).Message())
+				require.Nil(t, response)
+				return
+			}
+			require.NoError(t, err)
+
+			checkTransaction(t, tt.expectedEndorsers, response.GetEndorseResponse().GetPreparedTransaction())
+			checkEndorsers(t, tt.expectedEndorsers, test)
+			require.Equal(t, 0, test.localEndorser.ProcessProposalCallCount(), "no first endorsement")
+
+			require.Equal(t, 1, test.discovery.PeersForEndorsementCallCount())
+			_, actualInterest := test.discovery.PeersForEndorsementArgsForCall(0)
+			require.True(t, proto.Equal(tt.expectedInterest, actualInterest), "interest mismatch: %v", actualInterest)
+			require.True(t, crossChaincodeInterest.Chaincodes[0].NoPrivateReads, "request interest not modified")
+
+			require.True(t, proto.Equal(tt.expectedLayout, response.Layout), "layout mismatch: %v", response.Layout)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	endorserLatency = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_latency",
+		Help:         "The rolling average time in seconds for an endorser to respond to proposals sent by the gateway.",
+		LabelNames:   []string{"endorser", "mspid"},
+		StatsdFormat: "%{#fqname}.%{endorser}.%{mspid}",
+	}
+	endorserErrorRate = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_error_rate",
+		Help:         "The rolling fraction of proposals sent by the gateway to an endorser that failed.",
+		LabelNames:   []string{"endorser", "mspid"},
+		StatsdFormat: "%{#fqname}.%{endorser}.%{mspid}",
+	}
+	endorserInFlight = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "endorser_inflight_requests",
+		Help:         "The number of proposals sent by the gateway to an endorser that are awaiting a response.",
+		LabelNames:   []string{"endorser", "mspid"},
+		StatsdFormat: "%{#fqname}.%{endorser}.%{mspid}",
+	}
+	ordererSubmitDuration = metrics.HistogramOpts{
+		Namespace:    "gateway",
+		Name:         "orderer_submit_duration",
+		Help:         "The time in seconds for an orderer to respond to transactions submitted by the gateway.",
+		LabelNames:   []string{"orderer", "mspid", "status"},
+		StatsdFormat: "%{#fqname}.%{orderer}.%{mspid}.%{status}",
+	}
+	ordererCircuitState = metrics.GaugeOpts{
+		Namespace:    "gateway",
+		Name:         "orderer_circuit_state",
+		Help:         "The circuit breaker state of an orderer: 0 closed, 1 half-open, or 2 open.",
+		LabelNames:   []string{"orderer", "mspid"},
+		StatsdFormat: "%{#fqname}.%{orderer}.%{mspid}",
+	}
+	throttledRequests = metrics.CounterOpts{
+		Namespace:    "gateway",
+		Name:         "throttled_requests",
+		Help:         "The number of requests rejected by the gateway because they exceeded a rate limit.",
+		LabelNames:   []string{"method", "limit", "mspid"},
+		StatsdFormat: "%{#fqname}.%{method}.%{limit}.%{mspid}",
+	}
+)
+
+type Metrics struct {
+	EndorserLatency   metrics.Gauge
+	EndorserErrorRate metrics.Gauge
+	EndorserInFlight  metrics.Gauge
+
+	OrdererSubmitDuration metrics.Histogram
+	OrdererCircuitState   metrics.Gauge
+
+	ThrottledRequests metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		EndorserLatency:   p.NewGauge(endorserLatency),
+		EndorserErrorRate: p.NewGauge(endorserErrorRate),
+		EndorserInFlight:  p.NewGauge(endorserInFlight),
+
+		OrdererSubmitDuration: p.NewHistogram(ordererSubmitDuration),
+		OrdererCircuitState:   p.NewGauge(ordererCircuitState),
+
+		ThrottledRequests: p.NewCounter(throttledRequests),
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/pkg/gateway/diagnostics"
+)
+
+// endorsementMismatch describes how the proposal response payload returned by
+// an endorser differs from the expected payload.
+func endorsementMismatch(e *endpointConfig, expected, actual []byte) *diagnostics.EndorsementMismatch {
+	return &diagnostics.EndorsementMismatch{
+		Address:     e.address,
+		MspId:       e.mspid,
+		Differences: payloadDifferences(expected, actual),
+	}
+}
+
+// payloadDifferences decodes two serialized ProposalResponsePayload messages
+// and returns the differences between their content. If the payloads cannot
+// be decoded, or differ only in their encoding, a single PAYLOAD difference is
+// returned.
+func payloadDifferences(expected, actual []byte) []*diagnostics.Difference {
+	expectedPayload := &peer.ProposalResponsePayload{}
+	actualPayload := &peer.ProposalResponsePayload{}
+	if err := unmarshalBoth(expected, actual, expectedPayload, actualPayload); err != nil {
+		return []*diagnostics.Difference{payloadDifference(fmt.Sprintf("failed to decode proposal response payload: %s", err))}
+	}
+
+	var differences []*diagnostics.Difference
+	if !bytes.Equal(expectedPayload.ProposalHash, actualPayload.ProposalHash) {
+		differences = append(differences, withValues(&diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_PROPOSAL_HASH,
+			Description: "proposal hash differs",
+		}, expectedPayload.ProposalHash, actualPayload.ProposalHash))
+	}
+
+	expectedAction := &peer.ChaincodeAction{}
+	actualAction := &peer.ChaincodeAction{}
+	if err := unmarshalBoth(expectedPayload.Extension, actualPayload.Extension, expectedAction, actualAction); err != nil {
+		return append(differences, payloadDifference(fmt.Sprintf("failed to decode chaincode action: %s", err)))
+	}
+
+	differences = append(differences, chaincodeActionDifferences(expectedAction, actualAction)...)
+	if len(differences) == 0 {
+		differences = append(differences, payloadDifference("payloads differ, but no difference was found in the decoded content"))
+	}
+	return differences
+}
+
+func chaincodeActionDifferences(expected, actual *peer.ChaincodeAction) []*diagnostics.Difference {
+	var differences []*diagnostics.Difference
+
+	if !proto.Equal(expected.ChaincodeId, actual.ChaincodeId) {
+		differences = append(differences, withValues(&diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_CHAINCODE_ID,
+			Description: fmt.Sprintf("chaincode %s differs from expected %s", chaincodeIDString(actual.ChaincodeId), chaincodeIDString(expected.ChaincodeId)),
+		}, []byte(chaincodeIDString(expected.ChaincodeId)), []byte(chaincodeIDString(actual.ChaincodeId))))
+	}
+
+	if !proto.Equal(expected.Response, actual.Response) {
+		differences = append(differences, withValues(&diagnostics.Difference{
+			Type: diagnostics.DifferenceType_RESPONSE,
+			Description: fmt.Sprintf("response with status %d and message %q differs from expected status %d and message %q",
+				actual.Response.GetStatus(), actual.Response.GetMessage(), expected.Response.GetStatus(), expected.Response.GetMessage()),
+		}, expected.Response.GetPayload(), actual.Response.GetPayload()))
+	}
+
+	if !bytes.Equal(expected.Events, actual.Events) {
+		differences = append(differences, eventDifference(expected.Events, actual.Events))
+	}
+
+	if !bytes.Equal(expected.Results, actual.Results) {
+		expectedResults := &rwset.TxReadWriteSet{}
+		actualResults := &rwset.TxReadWriteSet{}
+		if err := unmarshalBoth(expected.Results, actual.Results, expectedResults, actualResults); err != nil {
+			return append(differences, payloadDifference(fmt.Sprintf("failed to decode read-write set: %s", err)))
+		}
+		differences = append(differences, rwsetDifferences(expectedResults, actualResults)...)
+	}
+
+	return differences
+}
+
+func eventDifference(expected, actual []byte) *diagnostics.Difference {
+	expectedEvent := &peer.ChaincodeEvent{}
+	actualEvent := &peer.ChaincodeEvent{}
+	if err := unmarshalBoth(expected, actual, expectedEvent, actualEvent); err != nil {
+		return withValues(&diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_EVENT,
+			Description: fmt.Sprintf("chaincode event differs and could not be decoded: %s", err),
+		}, expected, actual)
+	}
+
+	return withValues(&diagnostics.Difference{
+		Type:        diagnostics.DifferenceType_EVENT,
+		Namespace:   actualEvent.ChaincodeId,
+		Description: fmt.Sprintf("chaincode event %q differs from expected event %q", actualEvent.EventName, expectedEvent.EventName),
+	}, expectedEvent.Payload, actualEvent.Payload)
+}
+
+func rwsetDifferences(expected, actual *rwset.TxReadWriteSet) []*diagnostics.Difference {
+	expectedNamespaces := map[string]*rwset.NsReadWriteSet{}
+	for _, ns := range expected.NsRwset {
+		expectedNamespaces[ns.Namespace] = ns
+	}
+	actualNamespaces := map[string]*rwset.NsReadWriteSet{}
+	for _, ns := range actual.NsRwset {
+		actualNamespaces[ns.Namespace] = ns
+	}
+
+	var differences []*diagnostics.Difference
+	for _, namespace := range sortedKeys(expectedNamespaces, actualNamespaces) {
+		expectedNs := expectedNamespaces[namespace]
+		actualNs := actualNamespaces[namespace]
+
+		expectedKVs := &kvrwset.KVRWSet{}
+		actualKVs := &kvrwset.KVRWSet{}
+		if err := unmarshalBoth(expectedNs.GetRwset(), actualNs.GetRwset(), expectedKVs, actualKVs); err != nil {
+			differences = append(differences, &diagnostics.Difference{
+				Type:        diagnostics.DifferenceType_PAYLOAD,
+				Namespace:   namespace,
+				Description: fmt.Sprintf("failed to decode read-write set for namespace %s: %s", namespace, err),
+			})
+			continue
+		}
+		differences = append(differences, kvDifferences(namespace, expectedKVs, actualKVs)...)
+		differences = append(differences, collectionDifferences(namespace, expectedNs.GetCollectionHashedRwset(), actualNs.GetCollectionHashedRwset())...)
+	}
+	return differences
+}
+
+func kvDifferences(namespace string, expected, actual *kvrwset.KVRWSet) []*diagnostics.Difference {
+	var differences []*diagnostics.Difference
+
+	expectedReads := map[string]*kvrwset.Version{}
+	for _, read := range expected.Reads {
+		expectedReads[read.Key] = read.Version
+	}
+	actualReads := map[string]*kvrwset.Version{}
+	for _, read := range actual.Reads {
+		actualReads[read.Key] = read.Version
+	}
+	for _, key := range sortedKeys(expectedReads, actualReads) {
+		expectedVersion, expectedOK := expectedReads[key]
+		actualVersion, actualOK := actualReads[key]
+		if expectedOK == actualOK && proto.Equal(expectedVersion, actualVersion) {
+			continue
+		}
+		differences = append(differences, &diagnostics.Difference{
+			Type:            diagnostics.DifferenceType_READ,
+			Namespace:       namespace,
+			Key:             []byte(key),
+			Description:     fmt.Sprintf("key %q in namespace %s read at version %s, expected %s", key, namespace, versionString(actualVersion, actualOK), versionString(expectedVersion, expectedOK)),
+			ExpectedVersion: expectedVersion,
+			ActualVersion:   actualVersion,
+		})
+	}
+
+	expectedWrites := map[string]*kvrwset.KVWrite{}
+	for _, write := range expected.Writes {
+		expectedWrites[write.Key] = write
+	}
+	actualWrites := map[string]*kvrwset.KVWrite{}
+	for _, write := range actual.Writes {
+		actualWrites[write.Key] = write
+	}
+	for _, key := range sortedKeys(expectedWrites, actualWrites) {
+		expectedWrite := expectedWrites[key]
+		actualWrite := actualWrites[key]
+		if proto.Equal(expectedWrite, actualWrite) {
+			continue
+		}
+		differences = append(differences, withValues(&diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_WRITE,
+			Namespace:   namespace,
+			Key:         []byte(key),
+			Description: fmt.Sprintf("key %q in namespace %s %s, expected %s", key, namespace, writeString(actualWrite != nil, actualWrite.GetIsDelete()), writeString(expectedWrite != nil, expectedWrite.GetIsDelete())),
+		}, expectedWrite.GetValue(), actualWrite.GetValue()))
+	}
+
+	expectedMetadata := map[string]*kvrwset.KVMetadataWrite{}
+	for _, write := range expected.MetadataWrites {
+		expectedMetadata[write.Key] = write
+	}
+	actualMetadata := map[string]*kvrwset.KVMetadataWrite{}
+	for _, write := range actual.MetadataWrites {
+		actualMetadata[write.Key] = write
+	}
+	for _, key := range sortedKeys(expectedMetadata, actualMetadata) {
+		if proto.Equal(expectedMetadata[key], actualMetadata[key]) {
+			continue
+		}
+		differences = append(differences, &diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_METADATA_WRITE,
+			Namespace:   namespace,
+			Key:         []byte(key),
+			Description: fmt.Sprintf("metadata written for key %q in namespace %s differs", key, namespace),
+		})
+	}
+
+	if !rangeQueriesEqual(expected.RangeQueriesInfo, actual.RangeQueriesInfo) {
+		differences = append(differences, &diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_RANGE_QUERY,
+			Namespace:   namespace,
+			Description: fmt.Sprintf("%d range queries in namespace %s differ from %d expected range queries", len(actual.RangeQueriesInfo), namespace, len(expected.RangeQueriesInfo)),
+		})
+	}
+
+	return differences
+}
+
+func collectionDifferences(namespace string, expected, actual []*rwset.CollectionHashedReadWriteSet) []*diagnostics.Difference {
+	expectedCollections := map[string]*rwset.CollectionHashedReadWriteSet{}
+	for _, collection := range expected {
+		expectedCollections[collection.CollectionName] = collection
+	}
+	actualCollections := map[string]*rwset.CollectionHashedReadWriteSet{}
+	for _, collection := range actual {
+		actualCollections[collection.CollectionName] = collection
+	}
+
+	var differences []*diagnostics.Difference
+	for _, collection := range sortedKeys(expectedCollections, actualCollections) {
+		expectedHashes := &kvrwset.HashedRWSet{}
+		actualHashes := &kvrwset.HashedRWSet{}
+		if err := unmarshalBoth(expectedCollections[collection].GetHashedRwset(), actualCollections[collection].GetHashedRwset(), expectedHashes, actualHashes); err != nil {
+			differences = append(differences, &diagnostics.Difference{
+				Type:        diagnostics.DifferenceType_PAYLOAD,
+				Namespace:   namespace,
+				Collection:  collection,
+				Description: fmt.Sprintf("failed to decode hashed read-write set for collection %s in namespace %s: %s", collection, namespace, err),
+			})
+			continue
+		}
+		differences = append(differences, hashedDifferences(namespace, collection, expectedHashes, actualHashes)...)
+	}
+	return differences
+}
+
+func hashedDifferences(namespace, collection string, expected, actual *kvrwset.HashedRWSet) []*diagnostics.Difference {
+	var differences []*diagnostics.Difference
+
+	expectedReads := map[string]*kvrwset.Version{}
+	for _, read := range expected.HashedReads {
+		expectedReads[string(read.KeyHash)] = read.Version
+	}
+	actualReads := map[string]*kvrwset.Version{}
+	for _, read := range actual.HashedReads {
+		actualReads[string(read.KeyHash)] = read.Version
+	}
+	for _, keyHash := range sortedKeys(expectedReads, actualReads) {
+		expectedVersion, expectedOK := expectedReads[keyHash]
+		actualVersion, actualOK := actualReads[keyHash]
+		if expectedOK == actualOK && proto.Equal(expectedVersion, actualVersion) {
+			continue
+		}
+		differences = append(differences, &diagnostics.Difference{
+			Type:            diagnostics.DifferenceType_PRIVATE_READ,
+			Namespace:       namespace,
+			Collection:      collection,
+			Key:             []byte(keyHash),
+			Description:     fmt.Sprintf("key hash %x in collection %s of namespace %s read at version %s, expected %s", keyHash, collection, namespace, versionString(actualVersion, actualOK), versionString(expectedVersion, expectedOK)),
+			ExpectedVersion: expectedVersion,
+			ActualVersion:   actualVersion,
+		})
+	}
+
+	expectedWrites := map[string]*kvrwset.KVWriteHash{}
+	for _, write := range expected.HashedWrites {
+		expectedWrites[string(write.KeyHash)] = write
+	}
+	actualWrites := map[string]*kvrwset.KVWriteHash{}
+	for _, write := range actual.HashedWrites {
+		actualWrites[string(write.KeyHash)] = write
+	}
+	for _, keyHash := range sortedKeys(expectedWrites, actualWrites) {
+		expectedWrite := expectedWrites[keyHash]
+		actualWrite := actualWrites[keyHash]
+		if proto.Equal(expectedWrite, actualWrite) {
+			continue
+		}
+		differences = append(differences, &diagnostics.Difference{
+			Type:              diagnostics.DifferenceType_PRIVATE_WRITE,
+			Namespace:         namespace,
+			Collection:        collection,
+			Key:               []byte(keyHash),
+			Description:       fmt.Sprintf("key hash %x in collection %s of namespace %s %s, expected %s", keyHash, collection, namespace, writeString(actualWrite != nil, actualWrite.GetIsDelete()), writeString(expectedWrite != nil, expectedWrite.GetIsDelete())),
+			ExpectedValueHash: expectedWrite.GetValueHash(),
+			ActualValueHash:   actualWrite.GetValueHash(),
+		})
+	}
+
+	expectedMetadata := map[string]*kvrwset.KVMetadataWriteHash{}
+	for _, write := range expected.MetadataWrites {
+		expectedMetadata[string(write.KeyHash)] = write
+	}
+	actualMetadata := map[string]*kvrwset.KVMetadataWriteHash{}
+	for _, write := range actual.MetadataWrites {
+		actualMetadata[string(write.KeyHash)] = write
+	}
+	for _, keyHash := range sortedKeys(expectedMetadata, actualMetadata) {
+		if proto.Equal(expectedMetadata[keyHash], actualMetadata[keyHash]) {
+			continue
+		}
+		differences = append(differences, &diagnostics.Difference{
+			Type:        diagnostics.DifferenceType_METADATA_WRITE,
+			Namespace:   namespace,
+			Collection:  collection,
+			Key:         []byte(keyHash),
+			Description: fmt.Sprintf("metadata written for key hash %x in collection %s of namespace %s differs", keyHash, collection, namespace),
+		})
+	}
+
+	return differences
+}
+
+// withValues identifies the expected and actual values of the difference by
+// their hash and size. The values themselves are not returned, since they may
+// be large or contain data the client is not entitled to see.
+func withValues(difference *diagnostics.Difference, expected, actual []byte) *diagnostics.Difference {
+	difference.ExpectedValueHash, difference.ExpectedValueSize = valueHash(expected)
+	difference.ActualValueHash, difference.ActualValueSize = valueHash(actual)
+	return difference
+}
+
+func valueHash(value []byte) ([]byte, uint64) {
+	if value == nil {
+		return nil, 0
+	}
+	hash := sha256.Sum256(value)
+	return hash[:], uint64(len(value))
+}
+
+func payloadDifference(description string) *diagnostics.Difference {
+	return &diagnostics.Difference{
+		Type:        diagnostics.DifferenceType_PAYLOAD,
+		Description: description,
+	}
+}
+
+// unmarshalBoth decodes the expected and actual serialized messages, returning
+// the first error encountered.
+func unmarshalBoth(expected, actual []byte, expectedMsg, actualMsg proto.Message) error {
+	if err := proto.Unmarshal(expected, expectedMsg); err != nil {
+		return err
+	}
+	return proto.Unmarshal(actual, actualMsg)
+}
+
+func rangeQueriesEqual(expected, actual []*kvrwset.RangeQueryInfo) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		if !proto.Equal(expected[i], actual[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func chaincodeIDString(id *peer.ChaincodeID) string {
+	if id == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s:%s", id.Name, id.Version)
+}
+
+func versionString(version *kvrwset.Version, read bool) string {
+	switch {
+	case !read:
+		return "<not read>"
+	case version == nil:
+		return "<nonexistent>"
+	default:
+		return fmt.Sprintf("%d:%d", version.BlockNum, version.TxNum)
+	}
+}
+
+func writeString(written, isDelete bool) string {
+	switch {
+	case !written:
+		return "not written"
+	case isDelete:
+		return "deleted"
+	default:
+		return "written"
+	}
+}
+
+// sortedKeys returns the union of the string keys of the given maps, in
+// sorted order.
+func sortedKeys(maps ...interface{}) []string {
+	keySet := map[string]struct{}{}
+	for _, m := range maps {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			keySet[key.String()] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
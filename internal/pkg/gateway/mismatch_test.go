@@ -0,0 +1,270 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/pkg/gateway/diagnostics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadDifferences(t *testing.T) {
+	baseKVs := &kvrwset.KVRWSet{
+		Reads: []*kvrwset.KVRead{
+			{Key: "read1", Version: &kvrwset.Version{BlockNum: 3, TxNum: 1}},
+			{Key: "read2", Version: nil},
+		},
+		Writes: []*kvrwset.KVWrite{
+			{Key: "write1", Value: []byte("value1")},
+			{Key: "write2", IsDelete: true},
+		},
+	}
+	baseHashes := &kvrwset.HashedRWSet{
+		HashedReads: []*kvrwset.KVReadHash{
+			{KeyHash: []byte{0x01}, Version: &kvrwset.Version{BlockNum: 2, TxNum: 0}},
+		},
+		HashedWrites: []*kvrwset.KVWriteHash{
+			{KeyHash: []byte{0x02}, ValueHash: []byte{0xaa}},
+		},
+	}
+	baseEvent := &peer.ChaincodeEvent{ChaincodeId: "basic", EventName: "created", Payload: []byte("event-payload")}
+
+	newAction := func(t *testing.T, kvs *kvrwset.KVRWSet, hashes *kvrwset.HashedRWSet, event *peer.ChaincodeEvent) *peer.ChaincodeAction {
+		results := &rwset.TxReadWriteSet{
+			NsRwset: []*rwset.NsReadWriteSet{
+				{
+					Namespace: "basic",
+					Rwset:     marshal(kvs, t),
+					CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{
+						{CollectionName: "private", HashedRwset: marshal(hashes, t)},
+					},
+				},
+			},
+		}
+		return &peer.ChaincodeAction{
+			Results:     marshal(results, t),
+			Events:      marshal(event, t),
+			Response:    &peer.Response{Status: 200, Payload: []byte("result")},
+			ChaincodeId: &peer.ChaincodeID{Name: "basic", Version: "1.0"},
+		}
+	}
+	newPayload := func(t *testing.T, action *peer.ChaincodeAction) []byte {
+		return marshal(&peer.ProposalResponsePayload{ProposalHash: []byte("hash"), Extension: marshal(action, t)}, t)
+	}
+	expected := newPayload(t, newAction(t, baseKVs, baseHashes, baseEvent))
+
+	tests := []struct {
+		name     string
+		actual   func(t *testing.T) []byte
+		expected []*diagnostics.Difference
+	}{
+		{
+			name: "read version differs",
+			actual: func(t *testing.T) []byte {
+				kvs := proto.Clone(baseKVs).(*kvrwset.KVRWSet)
+				kvs.Reads[0].Version = &kvrwset.Version{BlockNum: 4, TxNum: 0}
+				return newPayload(t, newAction(t, kvs, baseHashes, baseEvent))
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:            diagnostics.DifferenceType_READ,
+					Namespace:       "basic",
+					Key:             []byte("read1"),
+					Description:     `key "read1" in namespace basic read at version 4:0, expected 3:1`,
+					ExpectedVersion: &kvrwset.Version{BlockNum: 3, TxNum: 1},
+					ActualVersion:   &kvrwset.Version{BlockNum: 4, TxNum: 0},
+				},
+			},
+		},
+		{
+			name: "key not read",
+			actual: func(t *testing.T) []byte {
+				kvs := proto.Clone(baseKVs).(*kvrwset.KVRWSet)
+				kvs.Reads = kvs.Reads[:1]
+				return newPayload(t, newAction(t, kvs, baseHashes, baseEvent))
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:        diagnostics.DifferenceType_READ,
+					Namespace:   "basic",
+					Key:         []byte("read2"),
+					Description: `key "read2" in namespace basic read at version <not read>, expected <nonexistent>`,
+				},
+			},
+		},
+		{
+			name: "write values differ",
+			actual: func(t *testing.T) []byte {
+				kvs := proto.Clone(baseKVs).(*kvrwset.KVRWSet)
+				kvs.Writes[0].Value = []byte("value2")
+				kvs.Writes[1] = &kvrwset.KVWrite{Key: "write3", Value: []byte("value3")}
+				return newPayload(t, newAction(t, kvs, baseHashes, baseEvent))
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:              diagnostics.DifferenceType_WRITE,
+					Namespace:         "basic",
+					Key:               []byte("write1"),
+					Description:       `key "write1" in namespace basic written, expected written`,
+					ExpectedValueHash: util.ComputeSHA256([]byte("value1")),
+					ExpectedValueSize: 6,
+					ActualValueHash:   util.ComputeSHA256([]byte("value2")),
+					ActualValueSize:   6,
+				},
+				{
+					Type:        diagnostics.DifferenceType_WRITE,
+					Namespace:   "basic",
+					Key:         []byte("write2"),
+					Description: `key "write2" in namespace basic not written, expected deleted`,
+				},
+				{
+					Type:            diagnostics.DifferenceType_WRITE,
+					Namespace:       "basic",
+					Key:             []byte("write3"),
+					Description:     `key "write3" in namespace basic written, expected not written`,
+					ActualValueHash: util.ComputeSHA256([]byte("value3")),
+					ActualValueSize: 6,
+				},
+			},
+		},
+		{
+			name: "private data differs",
+			actual: func(t *testing.T) []byte {
+				hashes := proto.Clone(baseHashes).(*kvrwset.HashedRWSet)
+				hashes.HashedReads[0].Version = &kvrwset.Version{BlockNum: 5, TxNum: 2}
+				hashes.HashedWrites[0].ValueHash = []byte{0xbb}
+				return newPayload(t, newAction(t, baseKVs, hashes, baseEvent))
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:            diagnostics.DifferenceType_PRIVATE_READ,
+					Namespace:       "basic",
+					Collection:      "private",
+					Key:             []byte{0x01},
+					Description:     "key hash 01 in collection private of namespace basic read at version 5:2, expected 2:0",
+					ExpectedVersion: &kvrwset.Version{BlockNum: 2, TxNum: 0},
+					ActualVersion:   &kvrwset.Version{BlockNum: 5, TxNum: 2},
+				},
+				{
+					Type:              diagnostics.DifferenceType_PRIVATE_WRITE,
+					Namespace:         "basic",
+					Collection:        "private",
+					Key:               []byte{0x02},
+					Description:       "key hash 02 in collection private of namespace basic written, expected written",
+					ExpectedValueHash: []byte{0xaa},
+					ActualValueHash:   []byte{0xbb},
+				},
+			},
+		},
+		{
+			name: "event differs",
+			actual: func(t *testing.T) []byte {
+				event := &peer.ChaincodeEvent{ChaincodeId: "basic", EventName: "updated", Payload: []byte("other-payload")}
+				return newPayload(t, newAction(t, baseKVs, baseHashes, event))
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:              diagnostics.DifferenceType_EVENT,
+					Namespace:         "basic",
+					Description:       `chaincode event "updated" differs from expected event "created"`,
+					ExpectedValueHash: util.ComputeSHA256([]byte("event-payload")),
+					ExpectedValueSize: 13,
+					ActualValueHash:   util.ComputeSHA256([]byte("other-payload")),
+					ActualValueSize:   13,
+				},
+			},
+		},
+		{
+			name: "response and chaincode differ",
+			actual: func(t *testing.T) []byte {
+				action := newAction(t, baseKVs, baseHashes, baseEvent)
+				action.Response = &peer.Response{Status: 200, Payload: []byte("other-result")}
+				action.ChaincodeId = &peer.ChaincodeID{Name: "basic", Version: "2.0"}
+				return newPayload(t, action)
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:              diagnostics.DifferenceType_CHAINCODE_ID,
+					Description:       "chaincode basic:2.0 differs from expected basic:1.0",
+					ExpectedValueHash: util.ComputeSHA256([]byte("basic:1.0")),
+					ExpectedValueSize: 9,
+					ActualValueHash:   util.ComputeSHA256([]byte("basic:2.0")),
+					ActualValueSize:   9,
+				},
+				{
+					Type:              diagnostics.DifferenceType_RESPONSE,
+					Description:       `response with status 200 and message "" differs from expected status 200 and message ""`,
+					ExpectedValueHash: util.ComputeSHA256([]byte("result")),
+					ExpectedValueSize: 6,
+					ActualValueHash:   util.ComputeSHA256([]byte("other-result")),
+					ActualValueSize:   12,
+				},
+			},
+		},
+		{
+			name: "proposal hash differs",
+			actual: func(t *testing.T) []byte {
+				return marshal(&peer.ProposalResponsePayload{
+					ProposalHash: []byte("other"),
+					Extension:    marshal(newAction(t, baseKVs, baseHashes, baseEvent), t),
+				}, t)
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:              diagnostics.DifferenceType_PROPOSAL_HASH,
+					Description:       "proposal hash differs",
+					ExpectedValueHash: util.ComputeSHA256([]byte("hash")),
+					ExpectedValueSize: 4,
+					ActualValueHash:   util.ComputeSHA256([]byte("other")),
+					ActualValueSize:   5,
+				},
+			},
+		},
+		{
+			name: "undecodable payload",
+			actual: func(t *testing.T) []byte {
+				return []byte("garbage")
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:        diagnostics.DifferenceType_PAYLOAD,
+					Description: "failed to decode proposal response payload: proto: can't skip unknown wire type 7",
+				},
+			},
+		},
+		{
+			name: "no decoded difference",
+			actual: func(t *testing.T) []byte {
+				kvs := proto.Clone(baseKVs).(*kvrwset.KVRWSet)
+				kvs.Reads[0], kvs.Reads[1] = kvs.Reads[1], kvs.Reads[0]
+				return newPayload(t, newAction(t, kvs, baseHashes, baseEvent))
+			},
+			expected: []*diagnostics.Difference{
+				{
+					Type:        diagnostics.DifferenceType_PAYLOAD,
+					Description: "payloads differ, but no difference was found in the decoded content",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			differences := payloadDifferences(expected, tt.actual(t))
+			require.Len(t, differences, len(tt.expected))
+			for i, difference := range differences {
+				require.True(t, proto.Equal(tt.expected[i], difference), "difference[%d], expected: %v, actual: %v", i, tt.expected[i], difference)
+			}
+		})
+	}
+}
@@ -24,6 +24,20 @@ type CommitFinder struct {
 		result1 *commit.Status
 		result2 error
 	}
+	TransactionStatusesStub        func(string, []string) ([]*commit.Status, error)
+	transactionStatusesMutex       sync.RWMutex
+	transactionStatusesArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	transactionStatusesReturns struct {
+		result1 []*commit.Status
+		result2 error
+	}
+	transactionStatusesReturnsOnCall map[int]struct {
+		result1 []*commit.Status
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -36,16 +50,15 @@ func (fake *CommitFinder) TransactionStatus(arg1 context.Context, arg2 string, a
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
-	stub := fake.TransactionStatusStub
-	fakeReturns := fake.transactionStatusReturns
 	fake.recordInvocation("TransactionStatus", []interface{}{arg1, arg2, arg3})
 	fake.transactionStatusMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2, arg3)
+	if fake.TransactionStatusStub != nil {
+		return fake.TransactionStatusStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
+	fakeReturns := fake.transactionStatusReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -94,11 +107,82 @@ func (fake *CommitFinder) TransactionStatusReturnsOnCall(i int, result1 *commit.
 	}{result1, result2}
 }
 
+func (fake *CommitFinder) TransactionStatuses(arg1 string, arg2 []string) ([]*commit.Status, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.transactionStatusesMutex.Lock()
+	ret, specificReturn := fake.transactionStatusesReturnsOnCall[len(fake.transactionStatusesArgsForCall)]
+	fake.transactionStatusesArgsForCall = append(fake.transactionStatusesArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("TransactionStatuses", []interface{}{arg1, arg2Copy})
+	fake.transactionStatusesMutex.Unlock()
+	if fake.TransactionStatusesStub != nil {
+		return fake.TransactionStatusesStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.transactionStatusesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CommitFinder) TransactionStatusesCallCount() int {
+	fake.transactionStatusesMutex.RLock()
+	defer fake.transactionStatusesMutex.RUnlock()
+	return len(fake.transactionStatusesArgsForCall)
+}
+
+func (fake *CommitFinder) TransactionStatusesCalls(stub func(string, []string) ([]*commit.Status, error)) {
+	fake.transactionStatusesMutex.Lock()
+	defer fake.transactionStatusesMutex.Unlock()
+	fake.TransactionStatusesStub = stub
+}
+
+func (fake *CommitFinder) TransactionStatusesArgsForCall(i int) (string, []string) {
+	fake.transactionStatusesMutex.RLock()
+	defer fake.transactionStatusesMutex.RUnlock()
+	argsForCall := fake.transactionStatusesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CommitFinder) TransactionStatusesReturns(result1 []*commit.Status, result2 error) {
+	fake.transactionStatusesMutex.Lock()
+	defer fake.transactionStatusesMutex.Unlock()
+	fake.TransactionStatusesStub = nil
+	fake.transactionStatusesReturns = struct {
+		result1 []*commit.Status
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CommitFinder) TransactionStatusesReturnsOnCall(i int, result1 []*commit.Status, result2 error) {
+	fake.transactionStatusesMutex.Lock()
+	defer fake.transactionStatusesMutex.Unlock()
+	fake.TransactionStatusesStub = nil
+	if fake.transactionStatusesReturnsOnCall == nil {
+		fake.transactionStatusesReturnsOnCall = make(map[int]struct {
+			result1 []*commit.Status
+			result2 error
+		})
+	}
+	fake.transactionStatusesReturnsOnCall[i] = struct {
+		result1 []*commit.Status
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CommitFinder) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.transactionStatusMutex.RLock()
 	defer fake.transactionStatusMutex.RUnlock()
+	fake.transactionStatusesMutex.RLock()
+	defer fake.transactionStatusesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
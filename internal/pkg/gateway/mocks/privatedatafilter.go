@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+type PrivateDataFilter struct {
+	PrivateDataStub        func(string, *common.Block, *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error)
+	privateDataMutex       sync.RWMutex
+	privateDataArgsForCall []struct {
+		arg1 string
+		arg2 *common.Block
+		arg3 *protoutil.SignedData
+	}
+	privateDataReturns struct {
+		result1 map[uint64]*rwset.TxPvtReadWriteSet
+		result2 error
+	}
+	privateDataReturnsOnCall map[int]struct {
+		result1 map[uint64]*rwset.TxPvtReadWriteSet
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PrivateDataFilter) PrivateData(arg1 string, arg2 *common.Block, arg3 *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	fake.privateDataMutex.Lock()
+	ret, specificReturn := fake.privateDataReturnsOnCall[len(fake.privateDataArgsForCall)]
+	fake.privateDataArgsForCall = append(fake.privateDataArgsForCall, struct {
+		arg1 string
+		arg2 *common.Block
+		arg3 *protoutil.SignedData
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("PrivateData", []interface{}{arg1, arg2, arg3})
+	fake.privateDataMutex.Unlock()
+	if fake.PrivateDataStub != nil {
+		return fake.PrivateDataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.privateDataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PrivateDataFilter) PrivateDataCallCount() int {
+	fake.privateDataMutex.RLock()
+	defer fake.privateDataMutex.RUnlock()
+	return len(fake.privateDataArgsForCall)
+}
+
+func (fake *PrivateDataFilter) PrivateDataCalls(stub func(string, *common.Block, *protoutil.SignedData) (map[uint64]*rwset.TxPvtReadWriteSet, error)) {
+	fake.privateDataMutex.Lock()
+	defer fake.privateDataMutex.Unlock()
+	fake.PrivateDataStub = stub
+}
+
+func (fake *PrivateDataFilter) PrivateDataArgsForCall(i int) (string, *common.Block, *protoutil.SignedData) {
+	fake.privateDataMutex.RLock()
+	defer fake.privateDataMutex.RUnlock()
+	argsForCall := fake.privateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PrivateDataFilter) PrivateDataReturns(result1 map[uint64]*rwset.TxPvtReadWriteSet, result2 error) {
+	fake.privateDataMutex.Lock()
+	defer fake.privateDataMutex.Unlock()
+	fake.PrivateDataStub = nil
+	fake.privateDataReturns = struct {
+		result1 map[uint64]*rwset.TxPvtReadWriteSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PrivateDataFilter) PrivateDataReturnsOnCall(i int, result1 map[uint64]*rwset.TxPvtReadWriteSet, result2 error) {
+	fake.privateDataMutex.Lock()
+	defer fake.privateDataMutex.Unlock()
+	fake.PrivateDataStub = nil
+	if fake.privateDataReturnsOnCall == nil {
+		fake.privateDataReturnsOnCall = make(map[int]struct {
+			result1 map[uint64]*rwset.TxPvtReadWriteSet
+			result2 error
+		})
+	}
+	fake.privateDataReturnsOnCall[i] = struct {
+		result1 map[uint64]*rwset.TxPvtReadWriteSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PrivateDataFilter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.privateDataMutex.RLock()
+	defer fake.privateDataMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PrivateDataFilter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
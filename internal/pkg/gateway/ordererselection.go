@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// circuitState is the state of the circuit breaker for an orderer.
+type circuitState int
+
+const (
+	// circuitClosed orderers are selected normally.
+	circuitClosed circuitState = iota
+	// circuitHalfOpen orderers have failed repeatedly, but their reset timeout has expired so they are selected
+	// after all closed orderers. A success closes the circuit, and a failure opens it again.
+	circuitHalfOpen
+	// circuitOpen orderers have failed repeatedly and are not selected until their reset timeout expires.
+	circuitOpen
+)
+
+// ordererHealth tracks the rolling submit latency and the consecutive submit failures of an orderer.
+type ordererHealth struct {
+	lock             sync.Mutex
+	failureThreshold int
+	resetTimeout     time.Duration
+	samples          int
+	latency          time.Duration
+	failures         int
+	openUntil        time.Time
+}
+
+// ordererSnapshot is a point in time copy of the orderer health.
+type ordererSnapshot struct {
+	state     circuitState
+	latency   time.Duration
+	openUntil time.Time
+}
+
+func (h *ordererHealth) end(latency time.Duration, failed bool, now time.Time) ordererSnapshot {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if failed {
+		h.failures++
+		if h.failureThreshold > 0 && h.failures >= h.failureThreshold {
+			h.openUntil = now.Add(h.resetTimeout)
+		}
+		return h.snapshotLocked(now)
+	}
+
+	h.failures = 0
+	if h.samples == 0 {
+		h.latency = latency
+	} else {
+		h.latency = time.Duration(statsSmoothing*float64(latency) + (1-statsSmoothing)*float64(h.latency))
+	}
+	h.samples++
+
+	return h.snapshotLocked(now)
+}
+
+func (h *ordererHealth) snapshot(now time.Time) ordererSnapshot {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.snapshotLocked(now)
+}
+
+func (h *ordererHealth) snapshotLocked(now time.Time) ordererSnapshot {
+	state := circuitClosed
+	if h.failureThreshold > 0 && h.failures >= h.failureThreshold {
+		state = circuitHalfOpen
+		if now.Before(h.openUntil) {
+			state = circuitOpen
+		}
+	}
+
+	return ordererSnapshot{
+		state:     state,
+		latency:   h.latency,
+		openUntil: h.openUntil,
+	}
+}
+
+// sortOrderers orders orderers for a submit, most preferred first. Orderers with a closed circuit are preferred,
+// followed by those with a half-open circuit, and orderers with an open circuit are omitted. Orderers in the same
+// state are ordered by their rolling submit latency, with orderers not yet used preferred so that their latency is
+// learned, and ties broken randomly to spread load. If the circuits of all orderers are open, all orderers are
+// returned in the order in which their circuits are due to reset, rather than failing the submit.
+//
+// The Raft leader is not preferred, since the gateway has no way to learn which orderer is the leader: the Broadcast
+// service does not report it, and followers forward transactions to the leader themselves.
+func sortOrderers(orderers []*orderer, snapshots map[*orderer]ordererSnapshot) []*orderer {
+	candidates := make([]*orderer, 0, len(orderers))
+	for _, index := range rand.Perm(len(orderers)) {
+		if snapshots[orderers[index]].state != circuitOpen {
+			candidates = append(candidates, orderers[index])
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = append(candidates, orderers...)
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return snapshots[candidates[i]].openUntil.Before(snapshots[candidates[j]].openUntil)
+		})
+		return candidates
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si, sj := snapshots[candidates[i]], snapshots[candidates[j]]
+		if si.state != sj.state {
+			return si.state < sj.state
+		}
+		return si.latency < sj.latency
+	})
+	return candidates
+}
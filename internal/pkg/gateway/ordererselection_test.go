@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	orderer1Mock = &orderer{endpointConfig: &endpointConfig{address: "orderer1:7050", mspid: "msp1"}}
+	orderer2Mock = &orderer{endpointConfig: &endpointConfig{address: "orderer2:7050", mspid: "msp1"}}
+	orderer3Mock = &orderer{endpointConfig: &endpointConfig{address: "orderer3:7050", mspid: "msp2"}}
+)
+
+func TestOrdererHealth(t *testing.T) {
+	now := time.Now()
+	health := &ordererHealth{failureThreshold: 2, resetTimeout: time.Minute}
+
+	snapshot := health.end(100*time.Millisecond, false, now)
+	require.Equal(t, ordererSnapshot{state: circuitClosed, latency: 100 * time.Millisecond}, snapshot)
+
+	snapshot = health.end(200*time.Millisecond, false, now)
+	require.Equal(t, 120*time.Millisecond, snapshot.latency)
+
+	snapshot = health.end(time.Second, true, now)
+	require.Equal(t, circuitClosed, snapshot.state)
+	require.Equal(t, 120*time.Millisecond, snapshot.latency, "failures do not update latency")
+
+	snapshot = health.end(time.Second, true, now)
+	require.Equal(t, circuitOpen, snapshot.state)
+	require.Equal(t, now.Add(time.Minute), snapshot.openUntil)
+
+	require.Equal(t, circuitOpen, health.snapshot(now.Add(59*time.Second)).state)
+	require.Equal(t, circuitHalfOpen, health.snapshot(now.Add(time.Minute)).state)
+
+	// a failure while half-open re-opens the circuit
+	later := now.Add(2 * time.Minute)
+	snapshot = health.end(time.Second, true, later)
+	require.Equal(t, circuitOpen, snapshot.state)
+	require.Equal(t, later.Add(time.Minute), snapshot.openUntil)
+
+	// a success closes the circuit
+	snapshot = health.end(100*time.Millisecond, false, later.Add(time.Minute))
+	require.Equal(t, circuitClosed, snapshot.state)
+}
+
+func TestOrdererHealthWithoutCircuitBreaking(t *testing.T) {
+	health := &ordererHealth{failureThreshold: 0, resetTimeout: time.Minute}
+	for i := 0; i < 10; i++ {
+		health.end(time.Second, true, time.Now())
+	}
+	require.Equal(t, circuitClosed, health.snapshot(time.Now()).state)
+}
+
+func TestSortOrderers(t *testing.T) {
+	now := time.Now()
+	orderers := []*orderer{orderer1Mock, orderer2Mock, orderer3Mock}
+
+	t.Run("prefers closed circuits then lowest latency", func(t *testing.T) {
+		snapshots := map[*orderer]ordererSnapshot{
+			orderer1Mock: {state: circuitHalfOpen, latency: time.Millisecond},
+			orderer2Mock: {state: circuitClosed, latency: 300 * time.Millisecond},
+			orderer3Mock: {state: circuitClosed, latency: 100 * time.Millisecond},
+		}
+		require.Equal(t, []*orderer{orderer3Mock, orderer2Mock, orderer1Mock}, sortOrderers(orderers, snapshots))
+	})
+
+	t.Run("omits open circuits", func(t *testing.T) {
+		snapshots := map[*orderer]ordererSnapshot{
+			orderer1Mock: {state: circuitOpen, openUntil: now.Add(time.Minute)},
+			orderer2Mock: {state: circuitClosed, latency: 300 * time.Millisecond},
+			orderer3Mock: {state: circuitClosed},
+		}
+		require.Equal(t, []*orderer{orderer3Mock, orderer2Mock}, sortOrderers(orderers, snapshots))
+	})
+
+	t.Run("all circuits open", func(t *testing.T) {
+		snapshots := map[*orderer]ordererSnapshot{
+			orderer1Mock: {state: circuitOpen, openUntil: now.Add(3 * time.Minute)},
+			orderer2Mock: {state: circuitOpen, openUntil: now.Add(time.Minute)},
+			orderer3Mock: {state: circuitOpen, openUntil: now.Add(2 * time.Minute)},
+		}
+		require.Equal(t, []*orderer{orderer2Mock, orderer3Mock, orderer1Mock}, sortOrderers(orderers, snapshots))
+	})
+
+	t.Run("unused orderers are spread randomly", func(t *testing.T) {
+		first := map[*orderer]bool{}
+		for i := 0; i < 100; i++ {
+			first[sortOrderers(orderers, map[*orderer]ordererSnapshot{})[0]] = true
+		}
+		require.Len(t, first, 3)
+	})
+}
+
+func TestStartSubmit(t *testing.T) {
+	duration := &metricsfakes.Histogram{}
+	duration.WithReturns(duration)
+	circuitState := &metricsfakes.Gauge{}
+	circuitState.WithReturns(circuitState)
+
+	reg := &registry{
+		logger:         logger,
+		ordererOptions: config.OrdererOptions{FailureThreshold: 1, ResetTimeout: time.Minute},
+		ordererHealth:  map[string]*ordererHealth{},
+		metrics: &Metrics{
+			OrdererSubmitDuration: duration,
+			OrdererCircuitState:   circuitState,
+		},
+	}
+
+	reg.startSubmit(orderer1Mock)(nil)
+	require.Equal(t, 1, duration.ObserveCallCount())
+	require.Equal(t, []string{"orderer", "orderer1:7050", "mspid", "msp1", "status", "OK"}, duration.WithArgsForCall(0))
+	require.Equal(t, []string{"orderer", "orderer1:7050", "mspid", "msp1"}, circuitState.WithArgsForCall(0))
+	require.Equal(t, float64(circuitClosed), circuitState.SetArgsForCall(0))
+
+	// non-connectivity errors do not count as orderer failures
+	reg.startSubmit(orderer2Mock)(status.Error(codes.Aborted, "BAD_REQUEST"))
+	require.Equal(t, []string{"orderer", "orderer2:7050", "mspid", "msp1", "status", "Aborted"}, duration.WithArgsForCall(1))
+	require.Equal(t, float64(circuitClosed), circuitState.SetArgsForCall(1))
+
+	// cancelled submits are ignored
+	reg.startSubmit(orderer2Mock)(context.Canceled)
+	require.Equal(t, float64(circuitClosed), circuitState.SetArgsForCall(2))
+
+	reg.startSubmit(orderer2Mock)(status.Error(codes.Unavailable, "connection refused"))
+	require.Equal(t, []string{"orderer", "orderer2:7050", "mspid", "msp1", "status", "Unavailable"}, duration.WithArgsForCall(3))
+	require.Equal(t, float64(circuitOpen), circuitState.SetArgsForCall(3))
+
+	require.Equal(t, []*orderer{orderer3Mock, orderer1Mock}, reg.submitOrder([]*orderer{orderer1Mock, orderer2Mock, orderer3Mock}))
+}
@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rate limit names, used as metric labels.
+const (
+	mspLimit       = "msp"
+	identityLimit  = "identity"
+	chaincodeLimit = "chaincode"
+)
+
+// bucketSweepInterval is the minimum time between removals of idle token buckets.
+const bucketSweepInterval = time.Minute
+
+// tokenBucket allows a sustained rate of requests, with bursts up to its capacity. The capacity is one second of
+// requests at the sustained rate plus the configured burst.
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(limit config.RateLimit, now time.Time) *tokenBucket {
+	capacity := math.Max(1, math.Ceil(limit.Rate)) + math.Max(0, float64(limit.Burst))
+	return &tokenBucket{
+		rate:     limit.Rate,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// wait returns the time until a token is available, or zero if one is available now.
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+type bucketKey struct {
+	limit string
+	key   string
+}
+
+// rateLimiter applies the configured token bucket limits to client requests. Buckets are created on first use and
+// removed once they have been idle long enough to refill.
+type rateLimiter struct {
+	lock      sync.Mutex
+	options   config.RateLimitOptions
+	buckets   map[bucketKey]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(options config.RateLimitOptions) *rateLimiter {
+	return &rateLimiter{
+		options: options,
+		buckets: map[bucketKey]*tokenBucket{},
+	}
+}
+
+func (rl *rateLimiter) enabled() bool {
+	return rl.options.MSP.Rate > 0 || rl.options.Identity.Rate > 0 || rl.options.Chaincode.Rate > 0
+}
+
+func (rl *rateLimiter) limitFor(name string) config.RateLimit {
+	switch name {
+	case mspLimit:
+		return rl.options.MSP
+	case identityLimit:
+		return rl.options.Identity
+	default:
+		return rl.options.Chaincode
+	}
+}
+
+// allow takes a token from the bucket of each key if all of them have one available. Otherwise no tokens are taken,
+// and the most restrictive limit is returned along with the time until it will allow the request.
+func (rl *rateLimiter) allow(keys []bucketKey, now time.Time) (string, time.Duration) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	rl.sweep(now)
+
+	var exceeded string
+	var wait time.Duration
+	buckets := make([]*tokenBucket, 0, len(keys))
+	for _, key := range keys {
+		limit := rl.limitFor(key.limit)
+		if limit.Rate <= 0 {
+			continue
+		}
+		bucket, ok := rl.buckets[key]
+		if !ok {
+			bucket = newTokenBucket(limit, now)
+			rl.buckets[key] = bucket
+		}
+		bucket.refill(now)
+		if w := bucket.wait(); w > wait {
+			exceeded, wait = key.limit, w
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	if wait > 0 {
+		return exceeded, wait
+	}
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+	return "", 0
+}
+
+// sweep removes buckets that have refilled, which are indistinguishable from new buckets.
+func (rl *rateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < bucketSweepInterval {
+		return
+	}
+	rl.lastSweep = now
+
+	for key, bucket := range rl.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= bucket.capacity {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// checkRateLimit returns a ResourceExhausted error, with the time after which the client should retry, if a proposal
+// exceeds the rate limit for its client MSP, its client identity or the target chaincode. The proposal signature and
+// creator are verified against the channel's Peer_Propose policy before any limit is charged, so that a client cannot
+// exhaust the limits of another identity or MSP by naming it as the creator of an unsigned proposal.
+func (gs *Server) checkRateLimit(method string, signedProposal *peer.SignedProposal, channel string, chaincodeID string) error {
+	if !gs.rateLimiter.enabled() {
+		return nil
+	}
+
+	if err := gs.policy.CheckACL(resources.Peer_Propose, channel, signedProposal); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	creator, err := proposalCreator(signedProposal)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unpack transaction proposal: %s", err)
+	}
+	identity, err := protoutil.UnmarshalSerializedIdentity(creator)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unpack proposal creator: %s", err)
+	}
+	identityHash := sha256.Sum256(creator)
+
+	keys := []bucketKey{
+		{limit: mspLimit, key: identity.Mspid},
+		{limit: identityLimit, key: string(identityHash[:])},
+		{limit: chaincodeLimit, key: channel + "/" + chaincodeID},
+	}
+	limit, wait := gs.rateLimiter.allow(keys, time.Now())
+	if wait == 0 {
+		return nil
+	}
+
+	gs.registry.metrics.ThrottledRequests.With("method", method, "limit", limit, "mspid", identity.Mspid).Add(1)
+
+	var message string
+	switch limit {
+	case mspLimit:
+		message = fmt.Sprintf("rate limit exceeded for client MSP %s", identity.Mspid)
+	case identityLimit:
+		message = "rate limit exceeded for client identity"
+	default:
+		message = fmt.Sprintf("rate limit exceeded for chaincode %s on channel %s", chaincodeID, channel)
+	}
+	logger.Debugw("Request throttled", "method", method, "limit", limit, "mspid", identity.Mspid, "channel", channel, "chaincode", chaincodeID, "retryDelay", wait)
+
+	return newRpcError(codes.ResourceExhausted, message, &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(wait)})
+}
+
+func proposalCreator(signedProposal *peer.SignedProposal) ([]byte, error) {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.GetProposalBytes())
+	if err != nil {
+		return nil, err
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	return signatureHeader.Creator, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	msp1 := []bucketKey{{limit: mspLimit, key: "msp1"}, {limit: identityLimit, key: "id1"}}
+	msp1Other := []bucketKey{{limit: mspLimit, key: "msp1"}, {limit: identityLimit, key: "id2"}}
+	msp2 := []bucketKey{{limit: mspLimit, key: "msp2"}, {limit: identityLimit, key: "id3"}}
+
+	t.Run("allows bursts in excess of the sustained rate", func(t *testing.T) {
+		rl := newRateLimiter(config.RateLimitOptions{MSP: config.RateLimit{Rate: 2, Burst: 3}})
+		for i := 0; i < 5; i++ {
+			limit, wait := rl.allow(msp1, now)
+			require.Empty(t, limit)
+			require.Zero(t, wait)
+		}
+
+		limit, wait := rl.allow(msp1, now)
+		require.Equal(t, mspLimit, limit)
+		require.Equal(t, 500*time.Millisecond, wait)
+
+		_, wait = rl.allow(msp1Other, now)
+		require.Equal(t, 500*time.Millisecond, wait, "MSP limit is shared by its identities")
+
+		_, wait = rl.allow(msp2, now)
+		require.Zero(t, wait, "MSPs are limited independently")
+
+		_, wait = rl.allow(msp1, now.Add(500*time.Millisecond))
+		require.Zero(t, wait)
+		_, wait = rl.allow(msp1, now.Add(500*time.Millisecond))
+		require.Equal(t, 500*time.Millisecond, wait)
+	})
+
+	t.Run("defaults burst to one second of requests", func(t *testing.T) {
+		rl := newRateLimiter(config.RateLimitOptions{Identity: config.RateLimit{Rate: 0.5}})
+		_, wait := rl.allow(msp1, now)
+		require.Zero(t, wait)
+		_, wait = rl.allow(msp1, now)
+		require.Equal(t, 2*time.Second, wait)
+	})
+
+	t.Run("takes no tokens unless all limits allow the request", func(t *testing.T) {
+		rl := newRateLimiter(config.RateLimitOptions{
+			MSP:      config.RateLimit{Rate: 1, Burst: 1},
+			Identity: config.RateLimit{Rate: 1},
+		})
+		_, wait := rl.allow(msp1, now)
+		require.Zero(t, wait)
+
+		limit, wait := rl.allow(msp1, now)
+		require.Equal(t, identityLimit, limit)
+		require.Equal(t, time.Second, wait)
+
+		_, wait = rl.allow(msp1Other, now)
+		require.Zero(t, wait, "rejected request did not consume MSP token")
+	})
+
+	t.Run("ignores disabled limits", func(t *testing.T) {
+		rl := newRateLimiter(config.RateLimitOptions{})
+		require.False(t, rl.enabled())
+		for i := 0; i < 100; i++ {
+			_, wait := rl.allow(msp1, now)
+			require.Zero(t, wait)
+		}
+		require.Empty(t, rl.buckets)
+	})
+
+	t.Run("removes idle buckets", func(t *testing.T) {
+		rl := newRateLimiter(config.RateLimitOptions{Identity: config.RateLimit{Rate: 0.01, Burst: 10}})
+		rl.allow(msp1, now)
+		rl.allow(msp2, now)
+		require.Len(t, rl.buckets, 2)
+
+		rl.allow(msp1, now.Add(bucketSweepInterval))
+		require.Len(t, rl.buckets, 2, "buckets not yet refilled")
+
+		rl.allow(msp1, now.Add(2*bucketSweepInterval))
+		require.Len(t, rl.buckets, 1, "refilled bucket removed")
+		require.Contains(t, rl.buckets, msp1[1])
+	})
+}
+
+func TestEvaluateRateLimit(t *testing.T) {
+	test := prepareTest(t, &testDef{
+		members: []networkMember{
+			{"id1", "localhost:7051", "msp1", 5},
+		},
+	})
+	throttled := &metricsfakes.Counter{}
+	throttled.WithReturns(throttled)
+	test.server.registry.metrics.ThrottledRequests = throttled
+	test.server.rateLimiter = newRateLimiter(config.RateLimitOptions{Chaincode: config.RateLimit{Rate: 0.1}})
+
+	request := &pb.EvaluateRequest{ProposedTransaction: test.signedProposal}
+	_, err := test.server.Evaluate(test.ctx, request)
+	require.NoError(t, err)
+
+	_, err = test.server.Evaluate(test.ctx, request)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, "rate limit exceeded for chaincode "+testChaincode+" on channel "+testChannel, status.Convert(err).Message())
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	retryDelay, err := ptypes.Duration(retryInfo.RetryDelay)
+	require.NoError(t, err)
+	require.InDelta(t, 10*time.Second, retryDelay, float64(time.Second))
+
+	require.Equal(t, 1, throttled.AddCallCount())
+	require.Equal(t, []string{"method", "Evaluate", "limit", chaincodeLimit, "mspid", ""}, throttled.WithArgsForCall(0))
+	require.Equal(t, 1, test.localEndorser.ProcessProposalCallCount(), "throttled request not sent to endorser")
+
+	resource, channel, data := test.policy.CheckACLArgsForCall(0)
+	require.Equal(t, resources.Peer_Propose, resource)
+	require.Equal(t, testChannel, channel)
+	require.Equal(t, test.signedProposal, data)
+}
+
+func TestEvaluateRateLimitUnauthorized(t *testing.T) {
+	test := prepareTest(t, &testDef{
+		members: []networkMember{
+			{"id1", "localhost:7051", "msp1", 5},
+		},
+		policyErr: errors.New("signature verification failed"),
+	})
+	test.server.rateLimiter = newRateLimiter(config.RateLimitOptions{Identity: config.RateLimit{Rate: 0.1}})
+
+	request := &pb.EvaluateRequest{ProposedTransaction: test.signedProposal}
+	for i := 0; i < 2; i++ {
+		_, err := test.server.Evaluate(test.ctx, request)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+		require.Equal(t, "signature verification failed", status.Convert(err).Message())
+	}
+
+	require.Empty(t, test.server.rateLimiter.buckets, "unauthorized requests not charged to any limit")
+	require.Zero(t, test.localEndorser.ProcessProposalCallCount())
+}
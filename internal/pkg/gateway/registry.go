@@ -10,9 +10,9 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
-	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	dp "github.com/hyperledger/fabric-protos-go/discovery"
@@ -23,6 +23,8 @@ import (
 	gossipapi "github.com/hyperledger/fabric/gossip/api"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	gossipdiscovery "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"google.golang.org/grpc/codes"
 )
 
 type Discovery interface {
@@ -42,12 +44,19 @@ type registry struct {
 	channelInitialized map[string]bool
 	configLock         sync.RWMutex
 	channelOrderers    sync.Map // channel (string) -> orderer addresses (endpointConfig)
+	selector           endorserSelector
+	metrics            *Metrics
+	endorserStats      map[string]*endorserStats // endorser address -> stats
+	ordererOptions     config.OrdererOptions
+	ordererHealth      map[string]*ordererHealth // orderer address -> health
+	statsLock          sync.Mutex
 }
 
 type endorserState struct {
 	peer     *dp.Peer
 	endorser *endorser
 	height   uint64
+	stats    endorserSnapshot
 }
 
 // Returns an endorsementPlan for the given chaincode on a channel.
@@ -98,8 +107,7 @@ func (reg *registry) endorsementPlan(channel string, interest *peer.ChaincodeInt
 			}
 			groupPeers = append(groupPeers, &endorserState{peer: peer, endorser: endorser, height: height})
 		}
-		// sort by decreasing height
-		sort.Slice(groupPeers, sorter(groupPeers, reg.localEndorser.address))
+		reg.sort(groupPeers, reg.localEndorser.address)
 
 		if len(groupPeers) > 0 {
 			var endorsers []*endorser
@@ -192,10 +200,9 @@ func (reg *registry) endorsersByOrg(channel string, chaincode string) map[string
 				endorsersByOrg[endorser.mspid] = append(endorsersByOrg[endorser.mspid], &endorserState{endorser: endorser, height: member.Properties.GetLedgerHeight()})
 			}
 		}
-		for _, es := range endorsersByOrg {
-			// sort by decreasing height in each org
-			sort.Slice(es, sorter(es, reg.localEndorser.address))
-		}
+	}
+	for _, es := range endorsersByOrg {
+		reg.sort(es, reg.localEndorser.address)
 	}
 	return endorsersByOrg
 }
@@ -223,8 +230,8 @@ func (reg *registry) evaluator(channel string, chaincode string, targetOrgs []st
 			}
 		}
 	}
-	// sort all the 'other orgs' endorsers by decreasing block height
-	sort.Slice(otherOrgEndorsers, sorter(otherOrgEndorsers, ""))
+	// sort all the 'other orgs' endorsers
+	reg.sort(otherOrgEndorsers, "")
 
 	var allEndorsers []*endorser
 	for _, e := range append(localOrgEndorsers, otherOrgEndorsers...) {
@@ -238,6 +245,103 @@ func (reg *registry) evaluator(channel string, chaincode string, targetOrgs []st
 	return nil, fmt.Errorf("no peers available to evaluate chaincode %s in channel %s", chaincode, channel)
 }
 
+// sort orders the endorsers using the configured selection strategy, most preferred first.
+func (reg *registry) sort(endorsers []*endorserState, host string) {
+	for _, e := range endorsers {
+		e.stats = reg.statsFor(e.endorser).snapshot()
+	}
+	reg.selector.sort(endorsers, host)
+}
+
+func (reg *registry) statsFor(endorser *endorser) *endorserStats {
+	reg.statsLock.Lock()
+	defer reg.statsLock.Unlock()
+
+	stats, ok := reg.endorserStats[endorser.address]
+	if !ok {
+		stats = &endorserStats{}
+		reg.endorserStats[endorser.address] = stats
+	}
+	return stats
+}
+
+// startRequest records a proposal being sent to the endorser. The returned function must be called with the response
+// status code when the endorser responds, to update the rolling stats of the endorser. As for orderers, only
+// Unavailable and DeadlineExceeded errors count as failures of the endorser, since other errors are caused by the
+// proposal or chaincode, while cancelled requests are ignored.
+func (reg *registry) startRequest(endorser *endorser) func(code codes.Code) {
+	stats := reg.statsFor(endorser)
+	start := time.Now()
+
+	snapshot := stats.begin()
+	reg.metrics.EndorserInFlight.With("endorser", endorser.address, "mspid", endorser.mspid).Set(float64(snapshot.inFlight))
+
+	return func(code codes.Code) {
+		var snapshot endorserSnapshot
+		if code == codes.Canceled {
+			snapshot = stats.abandon()
+		} else {
+			snapshot = stats.end(time.Since(start), code == codes.Unavailable || code == codes.DeadlineExceeded)
+		}
+		labels := []string{"endorser", endorser.address, "mspid", endorser.mspid}
+		reg.metrics.EndorserInFlight.With(labels...).Set(float64(snapshot.inFlight))
+		reg.metrics.EndorserLatency.With(labels...).Set(snapshot.latency.Seconds())
+		reg.metrics.EndorserErrorRate.With(labels...).Set(snapshot.errorRate)
+	}
+}
+
+func (reg *registry) healthFor(orderer *orderer) *ordererHealth {
+	reg.statsLock.Lock()
+	defer reg.statsLock.Unlock()
+
+	health, ok := reg.ordererHealth[orderer.address]
+	if !ok {
+		health = &ordererHealth{
+			failureThreshold: reg.ordererOptions.FailureThreshold,
+			resetTimeout:     reg.ordererOptions.ResetTimeout,
+		}
+		reg.ordererHealth[orderer.address] = health
+	}
+	return health
+}
+
+// submitOrder returns the given orderers in order of preference for submitting a transaction, based on their health.
+func (reg *registry) submitOrder(orderers []*orderer) []*orderer {
+	now := time.Now()
+	snapshots := make(map[*orderer]ordererSnapshot, len(orderers))
+	for _, o := range orderers {
+		snapshots[o] = reg.healthFor(o).snapshot(now)
+	}
+	return sortOrderers(orderers, snapshots)
+}
+
+// startSubmit records a transaction being sent to the orderer. The returned function must be called with the result
+// of the submit, to update the health of the orderer. Unavailable and DeadlineExceeded errors count as failures of the
+// orderer, while cancelled submits are ignored.
+func (reg *registry) startSubmit(orderer *orderer) func(err error) {
+	health := reg.healthFor(orderer)
+	start := time.Now()
+
+	return func(err error) {
+		elapsed := time.Since(start)
+		code := toRpcStatus(err).Code()
+		failed := code == codes.Unavailable || code == codes.DeadlineExceeded
+
+		var snapshot ordererSnapshot
+		if code == codes.Canceled {
+			snapshot = health.snapshot(time.Now())
+		} else {
+			snapshot = health.end(elapsed, failed, time.Now())
+		}
+
+		reg.metrics.OrdererSubmitDuration.With("orderer", orderer.address, "mspid", orderer.mspid, "status", code.String()).Observe(elapsed.Seconds())
+		reg.metrics.OrdererCircuitState.With("orderer", orderer.address, "mspid", orderer.mspid).Set(float64(snapshot.state))
+		if failed && snapshot.state == circuitOpen {
+			reg.logger.Warnw("Excluding orderer from selection after repeated failures", "address", orderer.address, "mspid", orderer.mspid, "until", snapshot.openUntil)
+		}
+	}
+}
+
 func sorter(e []*endorserState, host string) func(i, j int) bool {
 	return func(i, j int) bool {
 		if e[i].height == e[j].height {
@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-protos-go/common"
+	gp "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/pkg/gateway/resubmit"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SubmitWithRetry endorses, submits and waits for the commit of a transaction, repeating the cycle with a regenerated
+// proposal if the transaction is invalidated by an MVCC or phantom read conflict. The client is asked to sign each
+// regenerated proposal and each transaction over the stream, so the gateway never signs on behalf of the client.
+func (gs *Server) SubmitWithRetry(stream resubmit.Resubmit_SubmitWithRetryServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := request.GetStart()
+	if start == nil {
+		return status.Error(codes.InvalidArgument, "the first message must be a start message")
+	}
+	endorseRequest := start.GetEndorseRequest()
+	if endorseRequest.GetProposedTransaction() == nil {
+		return status.Error(codes.InvalidArgument, "the start message must contain an endorse request with a signed proposal")
+	}
+
+	// The channel and transaction ID are taken from the signed proposal, rather than trusted from the request
+	channelHeader, err := proposalChannelHeader(endorseRequest.ProposedTransaction.ProposalBytes)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unpack proposal: %s", err)
+	}
+	channel := channelHeader.ChannelId
+	if endorseRequest.ChannelId != channel {
+		return status.Errorf(codes.InvalidArgument, "channel %s of the request does not match channel %s of the proposal", endorseRequest.ChannelId, channel)
+	}
+	if endorseRequest.TransactionId != channelHeader.TxId {
+		return status.Errorf(codes.InvalidArgument, "transaction ID %s of the request does not match transaction ID %s of the proposal", endorseRequest.TransactionId, channelHeader.TxId)
+	}
+
+	if err := gs.policy.CheckACL(resources.Gateway_SubmitWithRetry, channel, endorseRequest.ProposedTransaction); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	maxAttempts := uint32(gs.options.Resubmit.MaxAttempts)
+	if start.MaxAttempts > 0 && start.MaxAttempts < maxAttempts {
+		maxAttempts = start.MaxAttempts
+	}
+
+	ctx := stream.Context()
+	signedProposal := endorseRequest.ProposedTransaction
+	txID := channelHeader.TxId
+
+	for attempt := uint32(1); ; attempt++ {
+		if attempt > 1 {
+			proposalBytes, nextTxID, err := regenerateProposal(signedProposal.ProposalBytes)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "failed to regenerate proposal: %s", err)
+			}
+			signature, err := requestSignature(stream, attempt, nextTxID, resubmit.SignatureType_PROPOSAL, proposalBytes)
+			if err != nil {
+				return err
+			}
+			signedProposal = &peer.SignedProposal{ProposalBytes: proposalBytes, Signature: signature}
+			txID = nextTxID
+		}
+
+		logger.Debugw("Submitting transaction", "channel", channel, "txID", txID, "attempt", attempt, "maxAttempts", maxAttempts)
+
+		endorseResponse, err := gs.Endorse(ctx, &gp.EndorseRequest{
+			TransactionId:          txID,
+			ChannelId:              channel,
+			ProposedTransaction:    signedProposal,
+			EndorsingOrganizations: endorseRequest.EndorsingOrganizations,
+		})
+		if err != nil {
+			return err
+		}
+
+		transaction := endorseResponse.PreparedTransaction
+		transaction.Signature, err = requestSignature(stream, attempt, txID, resubmit.SignatureType_TRANSACTION, transaction.Payload)
+		if err != nil {
+			return err
+		}
+
+		if _, err := gs.Submit(ctx, &gp.SubmitRequest{TransactionId: txID, ChannelId: channel, PreparedTransaction: transaction}); err != nil {
+			return err
+		}
+
+		txStatus, err := gs.commitFinder.TransactionStatus(ctx, channel, txID)
+		if err != nil {
+			return toRpcError(err, codes.Aborted)
+		}
+
+		result := &resubmit.AttemptResult{
+			Attempt:       attempt,
+			TransactionId: txID,
+			Result:        txStatus.Code,
+			BlockNumber:   txStatus.BlockNumber,
+		}
+		if err := stream.Send(&resubmit.SubmitWithRetryResponse{Message: &resubmit.SubmitWithRetryResponse_AttemptResult{AttemptResult: result}}); err != nil {
+			return err
+		}
+
+		if !isReadConflict(txStatus.Code) {
+			return nil
+		}
+		if attempt >= maxAttempts {
+			logger.Warnw("Transaction invalidated by read conflict after maximum attempts", "channel", channel, "txID", txID, "attempts", attempt, "code", txStatus.Code)
+			return nil
+		}
+		logger.Infow("Transaction invalidated by read conflict, resubmitting", "channel", channel, "txID", txID, "attempt", attempt, "code", txStatus.Code)
+	}
+}
+
+// requestSignature asks the client to sign the given message, and waits for the signature.
+func requestSignature(stream resubmit.Resubmit_SubmitWithRetryServer, attempt uint32, txID string, signatureType resubmit.SignatureType, message []byte) ([]byte, error) {
+	signatureRequest := &resubmit.SignatureRequest{
+		Attempt:       attempt,
+		TransactionId: txID,
+		Type:          signatureType,
+		Message:       message,
+	}
+	if err := stream.Send(&resubmit.SubmitWithRetryResponse{Message: &resubmit.SubmitWithRetryResponse_SignatureRequest{SignatureRequest: signatureRequest}}); err != nil {
+		return nil, err
+	}
+
+	response, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	signature := response.GetSignature().GetSignature()
+	if len(signature) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "expected a signature for the %s of transaction %s", signatureType, txID)
+	}
+	return signature, nil
+}
+
+// proposalChannelHeader returns the channel header of the serialized proposal.
+func proposalChannelHeader(proposalBytes []byte) (*common.ChannelHeader, error) {
+	proposal, err := protoutil.UnmarshalProposal(proposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	return protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+}
+
+// regenerateProposal returns a copy of the serialized proposal with a new nonce, transaction ID and timestamp, along
+// with the new transaction ID. The chaincode invocation and transient data of the proposal are unchanged.
+func regenerateProposal(proposalBytes []byte) ([]byte, string, error) {
+	proposal, err := protoutil.UnmarshalProposal(proposalBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, "", err
+	}
+	channelHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	signatureHeader, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return nil, "", err
+	}
+	signatureHeader.Nonce = nonce
+	channelHeader.TxId = protoutil.ComputeTxID(nonce, signatureHeader.Creator)
+	channelHeader.Timestamp = ptypes.TimestampNow()
+
+	if header.ChannelHeader, err = proto.Marshal(channelHeader); err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal channel header")
+	}
+	if header.SignatureHeader, err = proto.Marshal(signatureHeader); err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal signature header")
+	}
+	if proposal.Header, err = proto.Marshal(header); err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal header")
+	}
+	if proposalBytes, err = proto.Marshal(proposal); err != nil {
+		return nil, "", errors.Wrap(err, "failed to marshal proposal")
+	}
+	return proposalBytes, channelHeader.TxId, nil
+}
+
+func isReadConflict(code peer.TxValidationCode) bool {
+	return code == peer.TxValidationCode_MVCC_READ_CONFLICT || code == peer.TxValidationCode_PHANTOM_READ_CONFLICT
+}
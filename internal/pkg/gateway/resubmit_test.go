@@ -0,0 +1,291 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/gateway"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	"github.com/hyperledger/fabric/pkg/gateway/resubmit"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubmitWithRetry(t *testing.T) {
+	tests := []struct {
+		name              string
+		serverMaxAttempts int
+		clientMaxAttempts uint32
+		results           []peer.TxValidationCode
+		policyErr         error
+		firstMessage      func(request *pb.EndorseRequest) *resubmit.SubmitWithRetryRequest
+		signature         []byte
+		errCode           codes.Code
+		errString         string
+		expectedResults   []peer.TxValidationCode
+	}{
+		{
+			name:              "valid on first attempt",
+			serverMaxAttempts: 5,
+			results:           []peer.TxValidationCode{peer.TxValidationCode_VALID},
+			expectedResults:   []peer.TxValidationCode{peer.TxValidationCode_VALID},
+		},
+		{
+			name:              "valid after read conflicts",
+			serverMaxAttempts: 5,
+			results: []peer.TxValidationCode{
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_PHANTOM_READ_CONFLICT,
+				peer.TxValidationCode_VALID,
+			},
+			expectedResults: []peer.TxValidationCode{
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_PHANTOM_READ_CONFLICT,
+				peer.TxValidationCode_VALID,
+			},
+		},
+		{
+			name:              "client limits attempts",
+			serverMaxAttempts: 5,
+			clientMaxAttempts: 2,
+			results: []peer.TxValidationCode{
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_VALID,
+			},
+			expectedResults: []peer.TxValidationCode{
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+			},
+		},
+		{
+			name:              "server limits attempts",
+			serverMaxAttempts: 2,
+			clientMaxAttempts: 10,
+			results: []peer.TxValidationCode{
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_VALID,
+			},
+			expectedResults: []peer.TxValidationCode{
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+				peer.TxValidationCode_MVCC_READ_CONFLICT,
+			},
+		},
+		{
+			name:              "other validation codes are not retried",
+			serverMaxAttempts: 5,
+			results: []peer.TxValidationCode{
+				peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+				peer.TxValidationCode_VALID,
+			},
+			expectedResults: []peer.TxValidationCode{peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE},
+		},
+		{
+			name:              "access denied",
+			serverMaxAttempts: 5,
+			policyErr:         fmt.Errorf("BOOM"),
+			errCode:           codes.PermissionDenied,
+			errString:         "BOOM",
+		},
+		{
+			name:              "first message is not a start message",
+			serverMaxAttempts: 5,
+			firstMessage: func(_ *pb.EndorseRequest) *resubmit.SubmitWithRetryRequest {
+				return signatureResponse([]byte("signature"))
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "the first message must be a start message",
+		},
+		{
+			name:              "missing signed proposal",
+			serverMaxAttempts: 5,
+			firstMessage: func(request *pb.EndorseRequest) *resubmit.SubmitWithRetryRequest {
+				request.ProposedTransaction = nil
+				return startRequest(request, 0)
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "the start message must contain an endorse request with a signed proposal",
+		},
+		{
+			name:              "channel does not match the proposal",
+			serverMaxAttempts: 5,
+			firstMessage: func(request *pb.EndorseRequest) *resubmit.SubmitWithRetryRequest {
+				request.ChannelId = "other-channel"
+				return startRequest(request, 0)
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "channel other-channel of the request does not match channel " + testChannel + " of the proposal",
+		},
+		{
+			name:              "transaction ID does not match the proposal",
+			serverMaxAttempts: 5,
+			firstMessage: func(request *pb.EndorseRequest) *resubmit.SubmitWithRetryRequest {
+				request.TransactionId = "OTHER_TX_ID"
+				return startRequest(request, 0)
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "transaction ID OTHER_TX_ID of the request does not match transaction ID",
+		},
+		{
+			name:              "proposal cannot be unpacked",
+			serverMaxAttempts: 5,
+			firstMessage: func(request *pb.EndorseRequest) *resubmit.SubmitWithRetryRequest {
+				request.ProposedTransaction = &peer.SignedProposal{ProposalBytes: []byte("garbage")}
+				return startRequest(request, 0)
+			},
+			errCode:   codes.InvalidArgument,
+			errString: "failed to unpack proposal",
+		},
+		{
+			name:              "missing signature",
+			serverMaxAttempts: 5,
+			signature:         []byte{},
+			errCode:           codes.InvalidArgument,
+			errString:         "expected a signature for the TRANSACTION of transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := prepareTest(t, &testDef{
+				plan:      endorsementPlan{"g1": {{endorser: localhostMock}}},
+				policyErr: tt.policyErr,
+			})
+			test.server.options.Resubmit.MaxAttempts = tt.serverMaxAttempts
+
+			for i, code := range tt.results {
+				test.finder.TransactionStatusReturnsOnCall(i, &commit.Status{Code: code, BlockNumber: uint64(i + 1)}, nil)
+			}
+
+			txID := proposalTxID(t, test.signedProposal)
+			endorseRequest := &pb.EndorseRequest{
+				TransactionId:       txID,
+				ChannelId:           testChannel,
+				ProposedTransaction: test.signedProposal,
+			}
+			firstMessage := startRequest(endorseRequest, tt.clientMaxAttempts)
+			if tt.firstMessage != nil {
+				firstMessage = tt.firstMessage(endorseRequest)
+			}
+			signature := []byte("client-signature")
+			if tt.signature != nil {
+				signature = tt.signature
+			}
+
+			stream := &mocks.SubmitWithRetryServer{}
+			stream.ContextReturns(test.ctx)
+			stream.RecvReturns(signatureResponse(signature), nil)
+			stream.RecvReturnsOnCall(0, firstMessage, nil)
+			var signatureRequests []*resubmit.SignatureRequest
+			var attemptResults []*resubmit.AttemptResult
+			stream.SendCalls(func(response *resubmit.SubmitWithRetryResponse) error {
+				if request := response.GetSignatureRequest(); request != nil {
+					signatureRequests = append(signatureRequests, request)
+				}
+				if result := response.GetAttemptResult(); result != nil {
+					attemptResults = append(attemptResults, result)
+				}
+				return nil
+			})
+
+			err := test.server.SubmitWithRetry(stream)
+
+			if tt.errCode != codes.OK {
+				require.Equal(t, tt.errCode, status.Code(err))
+				require.Contains(t, status.Convert(err).Message(), tt.errString)
+				return
+			}
+			require.NoError(t, err)
+
+			resource, channel, data := test.policy.CheckACLArgsForCall(0)
+			require.Equal(t, resources.Gateway_SubmitWithRetry, resource)
+			require.Equal(t, testChannel, channel)
+			require.Equal(t, test.signedProposal, data)
+
+			require.Len(t, attemptResults, len(tt.expectedResults))
+			txIDs := map[string]bool{}
+			for i, result := range attemptResults {
+				require.Equal(t, uint32(i+1), result.Attempt)
+				require.Equal(t, tt.expectedResults[i], result.Result)
+				require.Equal(t, uint64(i+1), result.BlockNumber)
+				txIDs[result.TransactionId] = true
+
+				_, channel, txID := test.finder.TransactionStatusArgsForCall(i)
+				require.Equal(t, testChannel, channel)
+				require.Equal(t, result.TransactionId, txID)
+			}
+			require.Len(t, txIDs, len(attemptResults), "transaction IDs must be unique")
+			require.Equal(t, txID, attemptResults[0].TransactionId)
+
+			// One transaction signature for each attempt, and one proposal signature for each retry
+			require.Len(t, signatureRequests, 2*len(attemptResults)-1)
+			for _, request := range signatureRequests {
+				if request.Type != resubmit.SignatureType_PROPOSAL {
+					continue
+				}
+				proposal, err := protoutil.UnmarshalProposal(request.Message)
+				require.NoError(t, err)
+				header, err := protoutil.UnmarshalHeader(proposal.Header)
+				require.NoError(t, err)
+				channelHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+				require.NoError(t, err)
+				signatureHeader, err := protoutil.UnmarshalSignatureHeader(header.SignatureHeader)
+				require.NoError(t, err)
+				require.Equal(t, request.TransactionId, channelHeader.TxId)
+				require.Equal(t, protoutil.ComputeTxID(signatureHeader.Nonce, signatureHeader.Creator), channelHeader.TxId)
+
+				originalProposal, err := protoutil.UnmarshalProposal(test.signedProposal.ProposalBytes)
+				require.NoError(t, err)
+				require.Equal(t, originalProposal.Payload, proposal.Payload)
+			}
+
+			// Each retry is endorsed with the client signed regenerated proposal
+			require.Equal(t, len(attemptResults), test.localEndorser.ProcessProposalCallCount())
+			for i := 1; i < len(attemptResults); i++ {
+				_, signedProposal, _ := test.localEndorser.ProcessProposalArgsForCall(i)
+				require.Equal(t, signature, signedProposal.Signature)
+			}
+		})
+	}
+}
+
+func proposalTxID(t *testing.T, signedProposal *peer.SignedProposal) string {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+	require.NoError(t, err)
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	require.NoError(t, err)
+	channelHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	require.NoError(t, err)
+	return channelHeader.TxId
+}
+
+func startRequest(endorseRequest *pb.EndorseRequest, maxAttempts uint32) *resubmit.SubmitWithRetryRequest {
+	return &resubmit.SubmitWithRetryRequest{
+		Message: &resubmit.SubmitWithRetryRequest_Start{
+			Start: &resubmit.SubmitWithRetryStart{
+				EndorseRequest: endorseRequest,
+				MaxAttempts:    maxAttempts,
+			},
+		},
+	}
+}
+
+func signatureResponse(signature []byte) *resubmit.SubmitWithRetryRequest {
+	return &resubmit.SubmitWithRetryRequest{
+		Message: &resubmit.SubmitWithRetryRequest_Signature{
+			Signature: &resubmit.SignatureResponse{Signature: signature},
+		},
+	}
+}
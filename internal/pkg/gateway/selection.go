@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+)
+
+// statsSmoothing is the weight given to the latest sample when updating the
+// rolling latency and error rate of an endorser.
+const statsSmoothing = 0.2
+
+// endorserStats tracks the rolling latency, error rate and in-flight
+// requests of proposals sent to an endorser.
+type endorserStats struct {
+	lock      sync.Mutex
+	samples   int
+	latency   time.Duration
+	errorRate float64
+	inFlight  int
+}
+
+// endorserSnapshot is a point in time copy of the endorser stats.
+type endorserSnapshot struct {
+	samples   int
+	latency   time.Duration
+	errorRate float64
+	inFlight  int
+}
+
+func (s *endorserStats) begin() endorserSnapshot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.inFlight++
+	return s.snapshotLocked()
+}
+
+func (s *endorserStats) end(latency time.Duration, failed bool) endorserSnapshot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.inFlight--
+
+	var failure float64
+	if failed {
+		failure = 1
+	}
+	if s.samples == 0 {
+		s.latency = latency
+		s.errorRate = failure
+	} else {
+		s.latency = time.Duration(statsSmoothing*float64(latency) + (1-statsSmoothing)*float64(s.latency))
+		s.errorRate = statsSmoothing*failure + (1-statsSmoothing)*s.errorRate
+	}
+	s.samples++
+
+	return s.snapshotLocked()
+}
+
+// abandon records the end of a request that was cancelled by the client, without taking a latency or error sample.
+func (s *endorserStats) abandon() endorserSnapshot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.inFlight--
+	return s.snapshotLocked()
+}
+
+func (s *endorserStats) snapshot() endorserSnapshot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.snapshotLocked()
+}
+
+func (s *endorserStats) snapshotLocked() endorserSnapshot {
+	return endorserSnapshot{
+		samples:   s.samples,
+		latency:   s.latency,
+		errorRate: s.errorRate,
+		inFlight:  s.inFlight,
+	}
+}
+
+// expectedLatency estimates the time for the endorser to respond to another
+// request, given the requests already in flight and the chance of failure.
+func (s endorserSnapshot) expectedLatency() float64 {
+	successRate := math.Max(1-s.errorRate, 0.05)
+	return float64(s.latency) * float64(1+s.inFlight) / successRate
+}
+
+// endorserSelector orders candidate endorsers, most preferred first.
+type endorserSelector interface {
+	sort(endorsers []*endorserState, host string)
+}
+
+// newEndorserSelector returns the selector for the given strategy, which must already have been validated.
+func newEndorserSelector(strategy string, heightTolerance uint64) endorserSelector {
+	switch strategy {
+	case config.LeastLatency:
+		return &leastLatencySelector{heightTolerance: heightTolerance}
+	case config.WeightedRandom:
+		return &weightedRandomSelector{heightTolerance: heightTolerance}
+	default:
+		return &heightFirstSelector{}
+	}
+}
+
+// current orders the endorsers by ledger height, and returns the leading endorsers whose height is within tolerance
+// blocks of the highest.
+func current(endorsers []*endorserState, host string, tolerance uint64) []*endorserState {
+	sort.Slice(endorsers, sorter(endorsers, host))
+	for i, e := range endorsers {
+		if e.height+tolerance < endorsers[0].height {
+			return endorsers[:i]
+		}
+	}
+	return endorsers
+}
+
+// heightFirstSelector prefers endorsers with the highest ledger height, and
+// the host peer amongst endorsers of the same height.
+type heightFirstSelector struct{}
+
+func (*heightFirstSelector) sort(endorsers []*endorserState, host string) {
+	sort.Slice(endorsers, sorter(endorsers, host))
+}
+
+// leastLatencySelector prefers endorsers with the lowest expected latency,
+// amongst those whose ledger height is within the height tolerance of the
+// highest. Endorsers with the same expected latency, including those with no
+// observed requests, are ordered by ledger height. Endorsers outside the
+// height tolerance follow, in order of ledger height.
+type leastLatencySelector struct {
+	heightTolerance uint64
+}
+
+func (s *leastLatencySelector) sort(endorsers []*endorserState, host string) {
+	endorsers = current(endorsers, host, s.heightTolerance)
+	byHeight := sorter(endorsers, host)
+	sort.SliceStable(endorsers, func(i, j int) bool {
+		li, lj := endorsers[i].stats.expectedLatency(), endorsers[j].stats.expectedLatency()
+		if li == lj {
+			return byHeight(i, j)
+		}
+		return li < lj
+	})
+}
+
+// weightedRandomSelector orders endorsers randomly, weighting the chance of
+// each endorser being preferred by the inverse of its expected latency so
+// that load is spread across endorsers in proportion to their performance.
+// Only endorsers whose ledger height is within the height tolerance of the
+// highest are ordered randomly; the others follow, in order of ledger height.
+type weightedRandomSelector struct {
+	heightTolerance uint64
+}
+
+func (s *weightedRandomSelector) sort(endorsers []*endorserState, host string) {
+	endorsers = current(endorsers, host, s.heightTolerance)
+	// Weighted random permutation: each endorser is keyed by log(u)/weight for
+	// a uniform random u, and sorted by descending key.
+	keys := make(map[*endorserState]float64, len(endorsers))
+	for _, e := range endorsers {
+		weight := 1 / ((e.stats.expectedLatency() + float64(time.Millisecond)) / float64(time.Second))
+		keys[e] = math.Log(rand.Float64()) / weight
+	}
+	sort.Slice(endorsers, func(i, j int) bool {
+		return keys[endorsers[i]] > keys[endorsers[j]]
+	})
+}
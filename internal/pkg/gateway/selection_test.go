@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestEndorserStats(t *testing.T) {
+	stats := &endorserStats{}
+
+	snapshot := stats.begin()
+	require.Equal(t, 1, snapshot.inFlight)
+	stats.begin()
+
+	snapshot = stats.end(100*time.Millisecond, false)
+	require.Equal(t, endorserSnapshot{samples: 1, latency: 100 * time.Millisecond, inFlight: 1}, snapshot)
+
+	snapshot = stats.end(200*time.Millisecond, true)
+	require.Equal(t, 2, snapshot.samples)
+	require.Equal(t, 0, snapshot.inFlight)
+	require.Equal(t, 120*time.Millisecond, snapshot.latency)
+	require.InDelta(t, 0.2, snapshot.errorRate, 0.0001)
+
+	require.Equal(t, snapshot, stats.snapshot())
+
+	stats.begin()
+	snapshot = stats.abandon()
+	require.Equal(t, 0, snapshot.inFlight)
+	require.Equal(t, 2, snapshot.samples)
+}
+
+func TestNewEndorserSelector(t *testing.T) {
+	require.IsType(t, &heightFirstSelector{}, newEndorserSelector("", 2))
+	require.IsType(t, &heightFirstSelector{}, newEndorserSelector(config.HeightFirst, 2))
+	require.Equal(t, &leastLatencySelector{heightTolerance: 2}, newEndorserSelector(config.LeastLatency, 2))
+	require.Equal(t, &weightedRandomSelector{heightTolerance: 3}, newEndorserSelector(config.WeightedRandom, 3))
+}
+
+func TestHeightFirstSelector(t *testing.T) {
+	endorsers := []*endorserState{
+		{endorser: peer1Mock, height: 4, stats: endorserSnapshot{samples: 1, latency: time.Millisecond}},
+		{endorser: peer2Mock, height: 5, stats: endorserSnapshot{samples: 1, latency: time.Second}},
+		{endorser: localhostMock, height: 4, stats: endorserSnapshot{samples: 1, latency: time.Second}},
+	}
+
+	(&heightFirstSelector{}).sort(endorsers, localhostMock.address)
+	require.Equal(t, []*endorser{peer2Mock, localhostMock, peer1Mock}, endorsersOf(endorsers))
+}
+
+func TestLeastLatencySelector(t *testing.T) {
+	endorsers := []*endorserState{
+		{endorser: peer1Mock, height: 5, stats: endorserSnapshot{samples: 1, latency: 50 * time.Millisecond}},
+		{endorser: peer2Mock, height: 5, stats: endorserSnapshot{samples: 1, latency: 20 * time.Millisecond, inFlight: 2}},
+		{endorser: peer3Mock, height: 5, stats: endorserSnapshot{samples: 1, latency: 10 * time.Millisecond, errorRate: 0.9}},
+		{endorser: peer4Mock, height: 6, stats: endorserSnapshot{samples: 1, latency: 40 * time.Millisecond}},
+		{endorser: localhostMock, height: 4},
+	}
+
+	(&leastLatencySelector{heightTolerance: 2}).sort(endorsers, "")
+	require.Equal(t, []*endorser{localhostMock, peer4Mock, peer1Mock, peer2Mock, peer3Mock}, endorsersOf(endorsers))
+}
+
+func TestLeastLatencySelectorExcludesLaggingEndorsers(t *testing.T) {
+	endorsers := []*endorserState{
+		{endorser: peer1Mock, height: 5, stats: endorserSnapshot{samples: 1, latency: 50 * time.Millisecond}},
+		{endorser: peer2Mock, height: 3, stats: endorserSnapshot{samples: 1, latency: time.Millisecond}},
+		{endorser: peer3Mock, height: 2},
+		{endorser: peer4Mock, height: 6, stats: endorserSnapshot{samples: 1, latency: 40 * time.Millisecond}},
+		{endorser: localhostMock, height: 4},
+	}
+
+	(&leastLatencySelector{heightTolerance: 2}).sort(endorsers, "")
+	require.Equal(t, []*endorser{localhostMock, peer4Mock, peer1Mock, peer2Mock, peer3Mock}, endorsersOf(endorsers))
+
+	(&leastLatencySelector{}).sort(endorsers, "")
+	require.Equal(t, []*endorser{peer4Mock, peer1Mock, localhostMock, peer2Mock, peer3Mock}, endorsersOf(endorsers))
+}
+
+func TestLeastLatencySelectorPrefersHeightWithoutStats(t *testing.T) {
+	endorsers := []*endorserState{
+		{endorser: peer1Mock, height: 4},
+		{endorser: peer2Mock, height: 5},
+		{endorser: localhostMock, height: 5},
+	}
+
+	(&leastLatencySelector{heightTolerance: 2}).sort(endorsers, localhostMock.address)
+	require.Equal(t, []*endorser{localhostMock, peer2Mock, peer1Mock}, endorsersOf(endorsers))
+}
+
+func TestWeightedRandomSelector(t *testing.T) {
+	fastFirst := 0
+	for i := 0; i < 1000; i++ {
+		endorsers := []*endorserState{
+			{endorser: peer1Mock, stats: endorserSnapshot{samples: 1, latency: 500 * time.Millisecond}},
+			{endorser: peer2Mock, stats: endorserSnapshot{samples: 1, latency: 10 * time.Millisecond}},
+			{endorser: peer3Mock, stats: endorserSnapshot{samples: 1, latency: 500 * time.Millisecond}},
+		}
+
+		(&weightedRandomSelector{}).sort(endorsers, "")
+		require.ElementsMatch(t, []*endorser{peer1Mock, peer2Mock, peer3Mock}, endorsersOf(endorsers))
+		if endorsers[0].endorser == peer2Mock {
+			fastFirst++
+		}
+	}
+
+	// the fast endorser has 50 times the weight of each slow endorser
+	require.Greater(t, fastFirst, 900)
+	require.Less(t, fastFirst, 1000)
+}
+
+func TestWeightedRandomSelectorExcludesLaggingEndorsers(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		endorsers := []*endorserState{
+			{endorser: peer1Mock, height: 10, stats: endorserSnapshot{samples: 1, latency: 500 * time.Millisecond}},
+			{endorser: peer2Mock, height: 5, stats: endorserSnapshot{samples: 1, latency: time.Millisecond}},
+			{endorser: peer3Mock, height: 9, stats: endorserSnapshot{samples: 1, latency: 500 * time.Millisecond}},
+		}
+
+		(&weightedRandomSelector{heightTolerance: 2}).sort(endorsers, "")
+		require.ElementsMatch(t, []*endorser{peer1Mock, peer3Mock}, endorsersOf(endorsers[:2]))
+		require.Equal(t, peer2Mock, endorsers[2].endorser)
+	}
+}
+
+func TestStartRequest(t *testing.T) {
+	inFlight := &metricsfakes.Gauge{}
+	inFlight.WithReturns(inFlight)
+	latency := &metricsfakes.Gauge{}
+	latency.WithReturns(latency)
+	errorRate := &metricsfakes.Gauge{}
+	errorRate.WithReturns(errorRate)
+
+	reg := &registry{
+		selector:      &leastLatencySelector{heightTolerance: 2},
+		endorserStats: map[string]*endorserStats{},
+		metrics: &Metrics{
+			EndorserInFlight:  inFlight,
+			EndorserLatency:   latency,
+			EndorserErrorRate: errorRate,
+		},
+	}
+
+	done := reg.startRequest(peer1Mock)
+	require.Equal(t, 1, inFlight.SetCallCount())
+	require.Equal(t, float64(1), inFlight.SetArgsForCall(0))
+	require.Equal(t, []string{"endorser", "peer1:8051", "mspid", "msp1"}, inFlight.WithArgsForCall(0))
+
+	done(codes.Unavailable)
+	require.Equal(t, 2, inFlight.SetCallCount())
+	require.Equal(t, float64(0), inFlight.SetArgsForCall(1))
+	require.Equal(t, 1, latency.SetCallCount())
+	require.Equal(t, []string{"endorser", "peer1:8051", "mspid", "msp1"}, latency.WithArgsForCall(0))
+	require.Equal(t, 1, errorRate.SetCallCount())
+	require.Equal(t, float64(1), errorRate.SetArgsForCall(0))
+
+	endorsers := []*endorserState{
+		{endorser: peer1Mock},
+		{endorser: peer2Mock},
+	}
+	reg.sort(endorsers, "")
+	require.Equal(t, 1, endorsers[1].stats.samples)
+	require.Equal(t, float64(1), endorsers[1].stats.errorRate)
+	require.Equal(t, []*endorser{peer2Mock, peer1Mock}, endorsersOf(endorsers))
+}
+
+func TestStartRequestFailures(t *testing.T) {
+	gauge := &metricsfakes.Gauge{}
+	gauge.WithReturns(gauge)
+
+	for _, tt := range []struct {
+		code    codes.Code
+		samples int
+		failed  bool
+	}{
+		{code: codes.OK, samples: 1},
+		{code: codes.Aborted, samples: 1},
+		{code: codes.Unknown, samples: 1},
+		{code: codes.Unavailable, samples: 1, failed: true},
+		{code: codes.DeadlineExceeded, samples: 1, failed: true},
+		{code: codes.Canceled, samples: 0},
+	} {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			reg := &registry{
+				endorserStats: map[string]*endorserStats{},
+				metrics: &Metrics{
+					EndorserInFlight:  gauge,
+					EndorserLatency:   gauge,
+					EndorserErrorRate: gauge,
+				},
+			}
+
+			reg.startRequest(peer1Mock)(tt.code)
+			snapshot := reg.statsFor(peer1Mock).snapshot()
+			require.Equal(t, tt.samples, snapshot.samples)
+			require.Equal(t, 0, snapshot.inFlight)
+			require.Equal(t, tt.failed, snapshot.errorRate == 1)
+		})
+	}
+}
+
+func endorsersOf(states []*endorserState) []*endorser {
+	var endorsers []*endorser
+	for _, s := range states {
+		endorsers = append(endorsers, s.endorser)
+	}
+	return endorsers
+}
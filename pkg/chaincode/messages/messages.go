@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package messages defines chaincode stream messages which extend the
+// ChaincodeMessage protocol. The extensions are only used by shims after the
+// peer has advertised support for them in the REGISTERED message, so that
+// shims and peers which do not know about them keep using the base protocol.
+// The package is public so that shims can import the message definitions.
+package messages
+
+import (
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// Message types for batched state access. The values are chosen outside of the
+// range used by pb.ChaincodeMessage_Type so they cannot collide with messages
+// defined by the base protocol.
+const (
+	// GetStateMultipleType requests the values of multiple keys. The payload is a
+	// GetStateMultiple message and the response payload is a
+	// GetStateMultipleResult message.
+	GetStateMultipleType pb.ChaincodeMessage_Type = 100
+	// PutStateMultipleType writes the values of multiple keys. The payload is a
+	// PutStateMultiple message.
+	PutStateMultipleType pb.ChaincodeMessage_Type = 101
+	// DelStateMultipleType deletes multiple keys. The payload is a
+	// DelStateMultiple message.
+	DelStateMultipleType pb.ChaincodeMessage_Type = 102
+)
+
+// TypeName returns the name of a chaincode message type, including the
+// types defined by this package.
+func TypeName(t pb.ChaincodeMessage_Type) string {
+	switch t {
+	case GetStateMultipleType:
+		return "GET_STATE_MULTIPLE"
+	case PutStateMultipleType:
+		return "PUT_STATE_MULTIPLE"
+	case DelStateMultipleType:
+		return "DEL_STATE_MULTIPLE"
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,343 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/chaincode/messages/messages.proto
+
+package messages
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// PeerCapabilities is the payload of the REGISTERED message. It advertises the
+// protocol extensions supported by the peer. Shims which do not understand
+// the payload ignore it and continue to use the original protocol.
+type PeerCapabilities struct {
+	// batch_state is set when the peer accepts batched state messages.
+	BatchState bool `protobuf:"varint,1,opt,name=batch_state,json=batchState,proto3" json:"batch_state,omitempty"`
+	// max_batch_size is the maximum number of keys in a batched state message.
+	MaxBatchSize         uint32   `protobuf:"varint,2,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerCapabilities) Reset()         { *m = PeerCapabilities{} }
+func (m *PeerCapabilities) String() string { return proto.CompactTextString(m) }
+func (*PeerCapabilities) ProtoMessage()    {}
+func (*PeerCapabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f83838ca2909237c, []int{0}
+}
+
+func (m *PeerCapabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerCapabilities.Unmarshal(m, b)
+}
+func (m *PeerCapabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerCapabilities.Marshal(b, m, deterministic)
+}
+func (m *PeerCapabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerCapabilities.Merge(m, src)
+}
+func (m *PeerCapabilities) XXX_Size() int {
+	return xxx_messageInfo_PeerCapabilities.Size(m)
+}
+func (m *PeerCapabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerCapabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerCapabilities proto.InternalMessageInfo
+
+func (m *PeerCapabilities) GetBatchState() bool {
+	if m != nil {
+		return m.BatchState
+	}
+	return false
+}
+
+func (m *PeerCapabilities) GetMaxBatchSize() uint32 {
+	if m != nil {
+		return m.MaxBatchSize
+	}
+	return 0
+}
+
+// GetStateMultiple is the payload of the GET_STATE_MULTIPLE message.
+type GetStateMultiple struct {
+	Keys                 []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateMultiple) Reset()         { *m = GetStateMultiple{} }
+func (m *GetStateMultiple) String() string { return proto.CompactTextString(m) }
+func (*GetStateMultiple) ProtoMessage()    {}
+func (*GetStateMultiple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f83838ca2909237c, []int{1}
+}
+
+func (m *GetStateMultiple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateMultiple.Unmarshal(m, b)
+}
+func (m *GetStateMultiple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateMultiple.Marshal(b, m, deterministic)
+}
+func (m *GetStateMultiple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateMultiple.Merge(m, src)
+}
+func (m *GetStateMultiple) XXX_Size() int {
+	return xxx_messageInfo_GetStateMultiple.Size(m)
+}
+func (m *GetStateMultiple) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateMultiple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateMultiple proto.InternalMessageInfo
+
+func (m *GetStateMultiple) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetStateMultiple) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// GetStateMultipleResult is the payload of the RESPONSE message for a
+// GET_STATE_MULTIPLE request. Values are in the order of the requested keys;
+// the value of a key which does not exist is empty.
+type GetStateMultipleResult struct {
+	Values               [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateMultipleResult) Reset()         { *m = GetStateMultipleResult{} }
+func (m *GetStateMultipleResult) String() string { return proto.CompactTextString(m) }
+func (*GetStateMultipleResult) ProtoMessage()    {}
+func (*GetStateMultipleResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f83838ca2909237c, []int{2}
+}
+
+func (m *GetStateMultipleResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateMultipleResult.Unmarshal(m, b)
+}
+func (m *GetStateMultipleResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateMultipleResult.Marshal(b, m, deterministic)
+}
+func (m *GetStateMultipleResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateMultipleResult.Merge(m, src)
+}
+func (m *GetStateMultipleResult) XXX_Size() int {
+	return xxx_messageInfo_GetStateMultipleResult.Size(m)
+}
+func (m *GetStateMultipleResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateMultipleResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateMultipleResult proto.InternalMessageInfo
+
+func (m *GetStateMultipleResult) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// PutStateMultiple is the payload of the PUT_STATE_MULTIPLE message.
+type PutStateMultiple struct {
+	Kvs                  []*KV    `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutStateMultiple) Reset()         { *m = PutStateMultiple{} }
+func (m *PutStateMultiple) String() string { return proto.CompactTextString(m) }
+func (*PutStateMultiple) ProtoMessage()    {}
+func (*PutStateMultiple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f83838ca2909237c, []int{3}
+}
+
+func (m *PutStateMultiple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutStateMultiple.Unmarshal(m, b)
+}
+func (m *PutStateMultiple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutStateMultiple.Marshal(b, m, deterministic)
+}
+func (m *PutStateMultiple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutStateMultiple.Merge(m, src)
+}
+func (m *PutStateMultiple) XXX_Size() int {
+	return xxx_messageInfo_PutStateMultiple.Size(m)
+}
+func (m *PutStateMultiple) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutStateMultiple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutStateMultiple proto.InternalMessageInfo
+
+func (m *PutStateMultiple) GetKvs() []*KV {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
+func (m *PutStateMultiple) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+type KV struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KV) Reset()         { *m = KV{} }
+func (m *KV) String() string { return proto.CompactTextString(m) }
+func (*KV) ProtoMessage()    {}
+func (*KV) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f83838ca2909237c, []int{4}
+}
+
+func (m *KV) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KV.Unmarshal(m, b)
+}
+func (m *KV) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KV.Marshal(b, m, deterministic)
+}
+func (m *KV) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KV.Merge(m, src)
+}
+func (m *KV) XXX_Size() int {
+	return xxx_messageInfo_KV.Size(m)
+}
+func (m *KV) XXX_DiscardUnknown() {
+	xxx_messageInfo_KV.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KV proto.InternalMessageInfo
+
+func (m *KV) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KV) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// DelStateMultiple is the payload of the DEL_STATE_MULTIPLE message.
+type DelStateMultiple struct {
+	Keys                 []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DelStateMultiple) Reset()         { *m = DelStateMultiple{} }
+func (m *DelStateMultiple) String() string { return proto.CompactTextString(m) }
+func (*DelStateMultiple) ProtoMessage()    {}
+func (*DelStateMultiple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f83838ca2909237c, []int{5}
+}
+
+func (m *DelStateMultiple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DelStateMultiple.Unmarshal(m, b)
+}
+func (m *DelStateMultiple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DelStateMultiple.Marshal(b, m, deterministic)
+}
+func (m *DelStateMultiple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelStateMultiple.Merge(m, src)
+}
+func (m *DelStateMultiple) XXX_Size() int {
+	return xxx_messageInfo_DelStateMultiple.Size(m)
+}
+func (m *DelStateMultiple) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelStateMultiple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelStateMultiple proto.InternalMessageInfo
+
+func (m *DelStateMultiple) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *DelStateMultiple) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*PeerCapabilities)(nil), "messages.PeerCapabilities")
+	proto.RegisterType((*GetStateMultiple)(nil), "messages.GetStateMultiple")
+	proto.RegisterType((*GetStateMultipleResult)(nil), "messages.GetStateMultipleResult")
+	proto.RegisterType((*PutStateMultiple)(nil), "messages.PutStateMultiple")
+	proto.RegisterType((*KV)(nil), "messages.KV")
+	proto.RegisterType((*DelStateMultiple)(nil), "messages.DelStateMultiple")
+}
+
+func init() {
+	proto.RegisterFile("pkg/chaincode/messages/messages.proto", fileDescriptor_f83838ca2909237c)
+}
+
+var fileDescriptor_f83838ca2909237c = []byte{
+	// 308 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x51, 0x41, 0x4b, 0xf3, 0x40,
+	0x10, 0x25, 0xed, 0xf7, 0x95, 0x76, 0x1a, 0x25, 0x2c, 0x52, 0x7a, 0xaa, 0x25, 0x28, 0xf4, 0x20,
+	0x89, 0xa8, 0xf8, 0x03, 0xaa, 0xe8, 0xa1, 0x08, 0x65, 0x85, 0x82, 0x5e, 0xca, 0x66, 0x3b, 0x26,
+	0x4b, 0x36, 0xdd, 0x90, 0xdd, 0x94, 0xa6, 0xbf, 0x5e, 0xb2, 0xa9, 0x45, 0x8a, 0xe0, 0xc1, 0xdb,
+	0x7b, 0x6f, 0xdf, 0xbc, 0xd9, 0x99, 0x81, 0xcb, 0x3c, 0x8d, 0x43, 0x9e, 0x30, 0xb1, 0xe6, 0x6a,
+	0x85, 0x61, 0x86, 0x5a, 0xb3, 0x18, 0xf5, 0x01, 0x04, 0x79, 0xa1, 0x8c, 0x22, 0xdd, 0x2f, 0xee,
+	0xbf, 0x81, 0x37, 0x47, 0x2c, 0x1e, 0x58, 0xce, 0x22, 0x21, 0x85, 0x11, 0xa8, 0xc9, 0x39, 0xf4,
+	0x23, 0x66, 0x78, 0xb2, 0xd4, 0x86, 0x19, 0x1c, 0x3a, 0x63, 0x67, 0xd2, 0xa5, 0x60, 0xa5, 0xd7,
+	0x5a, 0x21, 0x17, 0x70, 0x9a, 0xb1, 0xed, 0x72, 0x6f, 0x12, 0x3b, 0x1c, 0xb6, 0xc6, 0xce, 0xe4,
+	0x84, 0xba, 0x19, 0xdb, 0x4e, 0xad, 0x4d, 0xec, 0xd0, 0x7f, 0x02, 0xef, 0x19, 0x8d, 0xad, 0x78,
+	0x29, 0xa5, 0x11, 0xb9, 0x44, 0x42, 0xe0, 0x5f, 0x8a, 0x95, 0x1e, 0x3a, 0xe3, 0xf6, 0xa4, 0x47,
+	0x2d, 0x26, 0x23, 0x00, 0xae, 0xa4, 0x44, 0x6e, 0x84, 0x5a, 0xdb, 0xa4, 0x1e, 0xfd, 0xa6, 0xf8,
+	0xd7, 0x30, 0x38, 0xce, 0xa1, 0xa8, 0x4b, 0x69, 0xc8, 0x00, 0x3a, 0x1b, 0x26, 0x4b, 0x6c, 0xf2,
+	0x5c, 0xba, 0x67, 0x3e, 0x05, 0x6f, 0x5e, 0x1e, 0x75, 0x1e, 0x41, 0x3b, 0xdd, 0x34, 0xc6, 0xfe,
+	0x8d, 0x1b, 0x1c, 0x16, 0x32, 0x5b, 0xd0, 0xfa, 0xe1, 0xd7, 0x5f, 0x5c, 0x41, 0x6b, 0xb6, 0x20,
+	0x1e, 0xb4, 0x53, 0xac, 0xec, 0x4a, 0x7a, 0xb4, 0x86, 0xe4, 0x0c, 0xfe, 0xdb, 0xae, 0xb6, 0xc4,
+	0xa5, 0x0d, 0xa9, 0x67, 0x7f, 0x44, 0xf9, 0xe7, 0xd9, 0xa7, 0xf7, 0xef, 0x77, 0xb1, 0x30, 0x49,
+	0x19, 0x05, 0x5c, 0x65, 0x61, 0x52, 0xe5, 0x58, 0x48, 0x5c, 0xc5, 0x58, 0x84, 0x1f, 0x2c, 0x2a,
+	0x04, 0x0f, 0x7f, 0xbe, 0x77, 0xd4, 0xb1, 0x77, 0xbe, 0xfd, 0x1c, 0x00, 0xbe, 0x0b, 0x90, 0xc7,
+	0x10, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,561 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/gateway/blockevents/blockevents.proto
+
+package blockevents
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	orderer "github.com/hyperledger/fabric-protos-go/orderer"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedBlockEventsRequest contains a serialized BlockEventsRequest message,
+// and a digital signature for the serialized request message.
+type SignedBlockEventsRequest struct {
+	// Serialized BlockEventsRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedBlockEventsRequest) Reset()         { *m = SignedBlockEventsRequest{} }
+func (m *SignedBlockEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedBlockEventsRequest) ProtoMessage()    {}
+func (*SignedBlockEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_686d1e3a5e2b8474, []int{0}
+}
+
+func (m *SignedBlockEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedBlockEventsRequest.Unmarshal(m, b)
+}
+func (m *SignedBlockEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedBlockEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedBlockEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedBlockEventsRequest.Merge(m, src)
+}
+func (m *SignedBlockEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedBlockEventsRequest.Size(m)
+}
+func (m *SignedBlockEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedBlockEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedBlockEventsRequest proto.InternalMessageInfo
+
+func (m *SignedBlockEventsRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedBlockEventsRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// BlockEventsRequest contains details of the block events that the caller
+// wants to receive.
+type BlockEventsRequest struct {
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Client requestor identity.
+	Identity []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Position within the ledger at which to start reading blocks.
+	StartPosition        *orderer.SeekPosition `protobuf:"bytes,3,opt,name=start_position,json=startPosition,proto3" json:"start_position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BlockEventsRequest) Reset()         { *m = BlockEventsRequest{} }
+func (m *BlockEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockEventsRequest) ProtoMessage()    {}
+func (*BlockEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_686d1e3a5e2b8474, []int{1}
+}
+
+func (m *BlockEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockEventsRequest.Unmarshal(m, b)
+}
+func (m *BlockEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *BlockEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockEventsRequest.Merge(m, src)
+}
+func (m *BlockEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_BlockEventsRequest.Size(m)
+}
+func (m *BlockEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockEventsRequest proto.InternalMessageInfo
+
+func (m *BlockEventsRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *BlockEventsRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *BlockEventsRequest) GetStartPosition() *orderer.SeekPosition {
+	if m != nil {
+		return m.StartPosition
+	}
+	return nil
+}
+
+// BlockEventsResponse returns a committed block.
+type BlockEventsResponse struct {
+	// The committed block.
+	Block                *common.Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlockEventsResponse) Reset()         { *m = BlockEventsResponse{} }
+func (m *BlockEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockEventsResponse) ProtoMessage()    {}
+func (*BlockEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_686d1e3a5e2b8474, []int{2}
+}
+
+func (m *BlockEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockEventsResponse.Unmarshal(m, b)
+}
+func (m *BlockEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockEventsResponse.Merge(m, src)
+}
+func (m *BlockEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockEventsResponse.Size(m)
+}
+func (m *BlockEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockEventsResponse proto.InternalMessageInfo
+
+func (m *BlockEventsResponse) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// FilteredBlockEventsResponse returns the filtered representation of a
+// committed block.
+type FilteredBlockEventsResponse struct {
+	// The filtered block.
+	FilteredBlock        *peer.FilteredBlock `protobuf:"bytes,1,opt,name=filtered_block,json=filteredBlock,proto3" json:"filtered_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *FilteredBlockEventsResponse) Reset()         { *m = FilteredBlockEventsResponse{} }
+func (m *FilteredBlockEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*FilteredBlockEventsResponse) ProtoMessage()    {}
+func (*FilteredBlockEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_686d1e3a5e2b8474, []int{3}
+}
+
+func (m *FilteredBlockEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilteredBlockEventsResponse.Unmarshal(m, b)
+}
+func (m *FilteredBlockEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilteredBlockEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *FilteredBlockEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilteredBlockEventsResponse.Merge(m, src)
+}
+func (m *FilteredBlockEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_FilteredBlockEventsResponse.Size(m)
+}
+func (m *FilteredBlockEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilteredBlockEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilteredBlockEventsResponse proto.InternalMessageInfo
+
+func (m *FilteredBlockEventsResponse) GetFilteredBlock() *peer.FilteredBlock {
+	if m != nil {
+		return m.FilteredBlock
+	}
+	return nil
+}
+
+// BlockAndPrivateDataEventsResponse returns a committed block along with the
+// private data the caller is eligible to read.
+type BlockAndPrivateDataEventsResponse struct {
+	// The committed block and the private data for its transactions.
+	BlockAndPrivateData  *peer.BlockAndPrivateData `protobuf:"bytes,1,opt,name=block_and_private_data,json=blockAndPrivateData,proto3" json:"block_and_private_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *BlockAndPrivateDataEventsResponse) Reset()         { *m = BlockAndPrivateDataEventsResponse{} }
+func (m *BlockAndPrivateDataEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockAndPrivateDataEventsResponse) ProtoMessage()    {}
+func (*BlockAndPrivateDataEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_686d1e3a5e2b8474, []int{4}
+}
+
+func (m *BlockAndPrivateDataEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockAndPrivateDataEventsResponse.Unmarshal(m, b)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockAndPrivateDataEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockAndPrivateDataEventsResponse.Merge(m, src)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockAndPrivateDataEventsResponse.Size(m)
+}
+func (m *BlockAndPrivateDataEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockAndPrivateDataEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockAndPrivateDataEventsResponse proto.InternalMessageInfo
+
+func (m *BlockAndPrivateDataEventsResponse) GetBlockAndPrivateData() *peer.BlockAndPrivateData {
+	if m != nil {
+		return m.BlockAndPrivateData
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SignedBlockEventsRequest)(nil), "blockevents.SignedBlockEventsRequest")
+	proto.RegisterType((*BlockEventsRequest)(nil), "blockevents.BlockEventsRequest")
+	proto.RegisterType((*BlockEventsResponse)(nil), "blockevents.BlockEventsResponse")
+	proto.RegisterType((*FilteredBlockEventsResponse)(nil), "blockevents.FilteredBlockEventsResponse")
+	proto.RegisterType((*BlockAndPrivateDataEventsResponse)(nil), "blockevents.BlockAndPrivateDataEventsResponse")
+}
+
+func init() {
+	proto.RegisterFile("pkg/gateway/blockevents/blockevents.proto", fileDescriptor_686d1e3a5e2b8474)
+}
+
+var fileDescriptor_686d1e3a5e2b8474 = []byte{
+	// 433 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5d, 0x8b, 0xd3, 0x40,
+	0x14, 0x25, 0x2b, 0x7e, 0xf4, 0xd6, 0x2e, 0x3a, 0x65, 0x25, 0x76, 0x15, 0x6a, 0x44, 0xa8, 0x2f,
+	0x49, 0xa9, 0x88, 0x20, 0xfb, 0xe2, 0xa2, 0x82, 0x6f, 0x25, 0xfb, 0x22, 0xfa, 0x10, 0x26, 0x9d,
+	0xdb, 0x74, 0x68, 0x77, 0x26, 0xce, 0xdc, 0xac, 0xf4, 0x47, 0xf8, 0xbb, 0xfc, 0x5b, 0xb2, 0x93,
+	0xa9, 0x26, 0x6e, 0xba, 0xf4, 0xa9, 0xf7, 0xe3, 0xf4, 0x9c, 0xb9, 0xe7, 0xde, 0xc0, 0xeb, 0x72,
+	0x5d, 0x24, 0x05, 0x27, 0xfc, 0xc9, 0xb7, 0x49, 0xbe, 0xd1, 0x8b, 0x35, 0x5e, 0xa1, 0x22, 0xdb,
+	0x8c, 0xe3, 0xd2, 0x68, 0xd2, 0xac, 0xdf, 0x28, 0x8d, 0x86, 0x0b, 0x7d, 0x79, 0xa9, 0x55, 0x52,
+	0xff, 0xd4, 0x88, 0xd1, 0x23, 0x6d, 0x04, 0x1a, 0x34, 0x09, 0xcf, 0x7d, 0xe5, 0x71, 0x89, 0x68,
+	0x92, 0x26, 0x4d, 0x94, 0x42, 0x78, 0x21, 0x0b, 0x85, 0xe2, 0xfc, 0x9a, 0xee, 0x93, 0x6b, 0xa5,
+	0xf8, 0xa3, 0x42, 0x4b, 0x2c, 0x84, 0xfb, 0xa6, 0x0e, 0xc3, 0x60, 0x1c, 0x4c, 0x1e, 0xa6, 0xbb,
+	0x94, 0x3d, 0x83, 0x9e, 0x95, 0x85, 0xe2, 0x54, 0x19, 0x0c, 0x8f, 0x5c, 0xef, 0x5f, 0x21, 0xfa,
+	0x15, 0x00, 0xeb, 0xa0, 0x7b, 0x0e, 0xb0, 0x58, 0x71, 0xa5, 0x70, 0x93, 0x49, 0xe1, 0x18, 0x7b,
+	0x69, 0xcf, 0x57, 0xbe, 0x08, 0x36, 0x82, 0x07, 0x52, 0xa0, 0x22, 0x49, 0x5b, 0x4f, 0xf9, 0x37,
+	0x67, 0x67, 0x70, 0x6c, 0x89, 0x1b, 0xca, 0x4a, 0x6d, 0x25, 0x49, 0xad, 0xc2, 0x3b, 0xe3, 0x60,
+	0xd2, 0x9f, 0x9d, 0xc4, 0x7e, 0xc6, 0xf8, 0x02, 0x71, 0x3d, 0xf7, 0xcd, 0x74, 0xe0, 0xc0, 0xbb,
+	0x34, 0x7a, 0x0f, 0xc3, 0xd6, 0x73, 0x6c, 0xa9, 0x95, 0x45, 0xf6, 0x12, 0xee, 0x3a, 0x0f, 0xdd,
+	0x53, 0xfa, 0xb3, 0x41, 0xec, 0xdd, 0x73, 0xd8, 0xb4, 0xee, 0x45, 0xdf, 0xe1, 0xf4, 0xb3, 0xdc,
+	0x10, 0x1a, 0x14, 0x5d, 0x1c, 0x67, 0x70, 0xbc, 0xf4, 0xed, 0xac, 0x49, 0x76, 0x52, 0xdb, 0x6b,
+	0xe3, 0xd6, 0x9f, 0xd3, 0xc1, 0xb2, 0x99, 0x46, 0x15, 0xbc, 0x70, 0xc1, 0x07, 0x25, 0xe6, 0x46,
+	0x5e, 0x71, 0xc2, 0x8f, 0x9c, 0xf8, 0x7f, 0x12, 0x73, 0x78, 0xe2, 0x98, 0x33, 0xae, 0x44, 0x56,
+	0xd6, 0xb0, 0x4c, 0x70, 0xe2, 0x5e, 0xea, 0x74, 0x27, 0xd5, 0x41, 0x95, 0x0e, 0xf3, 0x9b, 0xc5,
+	0xd9, 0xef, 0x23, 0xe8, 0x37, 0x86, 0x61, 0x5f, 0xdb, 0xe9, 0xab, 0xb8, 0x79, 0x6d, 0xfb, 0xae,
+	0x63, 0x34, 0x6e, 0xc1, 0x3a, 0xcc, 0x99, 0x06, 0x6c, 0x05, 0xc3, 0x0e, 0xf7, 0x0e, 0x55, 0x98,
+	0xb4, 0x60, 0xb7, 0xac, 0x61, 0x1a, 0x30, 0x82, 0xa7, 0x7b, 0xad, 0x3c, 0x54, 0x2f, 0xbe, 0x39,
+	0xd1, 0x6d, 0x9b, 0x99, 0x06, 0xe7, 0xef, 0xbe, 0xbd, 0x2d, 0x24, 0xad, 0xaa, 0xfc, 0xfa, 0x76,
+	0x92, 0xd5, 0xb6, 0x44, 0xb3, 0x41, 0x51, 0xa0, 0x49, 0x96, 0x3c, 0x37, 0x72, 0x91, 0xec, 0xf9,
+	0x9e, 0xf3, 0x7b, 0x6e, 0x67, 0x6f, 0xfe, 0x0c, 0x00, 0xc9, 0x61, 0x04, 0x04, 0xf1, 0x03, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BlockEventsClient is the client API for BlockEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockEventsClient interface {
+	// The BlockEvents service supplies a stream of responses, each containing
+	// a committed block. The streamed responses are ordered by ascending
+	// block number.
+	BlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockEventsClient, error)
+	// The FilteredBlockEvents service supplies a stream of responses, each
+	// containing the filtered representation of a committed block. The
+	// streamed responses are ordered by ascending block number.
+	FilteredBlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_FilteredBlockEventsClient, error)
+	// The BlockAndPrivateDataEvents service supplies a stream of responses,
+	// each containing a committed block along with the private data the
+	// caller is eligible to read. The streamed responses are ordered by
+	// ascending block number.
+	BlockAndPrivateDataEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockAndPrivateDataEventsClient, error)
+}
+
+type blockEventsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockEventsClient(cc grpc.ClientConnInterface) BlockEventsClient {
+	return &blockEventsClient{cc}
+}
+
+func (c *blockEventsClient) BlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockEvents_serviceDesc.Streams[0], "/blockevents.BlockEvents/BlockEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockEventsBlockEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockEvents_BlockEventsClient interface {
+	Recv() (*BlockEventsResponse, error)
+	grpc.ClientStream
+}
+
+type blockEventsBlockEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockEventsBlockEventsClient) Recv() (*BlockEventsResponse, error) {
+	m := new(BlockEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockEventsClient) FilteredBlockEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_FilteredBlockEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockEvents_serviceDesc.Streams[1], "/blockevents.BlockEvents/FilteredBlockEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockEventsFilteredBlockEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockEvents_FilteredBlockEventsClient interface {
+	Recv() (*FilteredBlockEventsResponse, error)
+	grpc.ClientStream
+}
+
+type blockEventsFilteredBlockEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockEventsFilteredBlockEventsClient) Recv() (*FilteredBlockEventsResponse, error) {
+	m := new(FilteredBlockEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockEventsClient) BlockAndPrivateDataEvents(ctx context.Context, in *SignedBlockEventsRequest, opts ...grpc.CallOption) (BlockEvents_BlockAndPrivateDataEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockEvents_serviceDesc.Streams[2], "/blockevents.BlockEvents/BlockAndPrivateDataEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockEventsBlockAndPrivateDataEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockEvents_BlockAndPrivateDataEventsClient interface {
+	Recv() (*BlockAndPrivateDataEventsResponse, error)
+	grpc.ClientStream
+}
+
+type blockEventsBlockAndPrivateDataEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockEventsBlockAndPrivateDataEventsClient) Recv() (*BlockAndPrivateDataEventsResponse, error) {
+	m := new(BlockAndPrivateDataEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockEventsServer is the server API for BlockEvents service.
+type BlockEventsServer interface {
+	// The BlockEvents service supplies a stream of responses, each containing
+	// a committed block. The streamed responses are ordered by ascending
+	// block number.
+	BlockEvents(*SignedBlockEventsRequest, BlockEvents_BlockEventsServer) error
+	// The FilteredBlockEvents service supplies a stream of responses, each
+	// containing the filtered representation of a committed block. The
+	// streamed responses are ordered by ascending block number.
+	FilteredBlockEvents(*SignedBlockEventsRequest, BlockEvents_FilteredBlockEventsServer) error
+	// The BlockAndPrivateDataEvents service supplies a stream of responses,
+	// each containing a committed block along with the private data the
+	// caller is eligible to read. The streamed responses are ordered by
+	// ascending block number.
+	BlockAndPrivateDataEvents(*SignedBlockEventsRequest, BlockEvents_BlockAndPrivateDataEventsServer) error
+}
+
+// UnimplementedBlockEventsServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockEventsServer struct {
+}
+
+func (*UnimplementedBlockEventsServer) BlockEvents(req *SignedBlockEventsRequest, srv BlockEvents_BlockEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BlockEvents not implemented")
+}
+func (*UnimplementedBlockEventsServer) FilteredBlockEvents(req *SignedBlockEventsRequest, srv BlockEvents_FilteredBlockEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method FilteredBlockEvents not implemented")
+}
+func (*UnimplementedBlockEventsServer) BlockAndPrivateDataEvents(req *SignedBlockEventsRequest, srv BlockEvents_BlockAndPrivateDataEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BlockAndPrivateDataEvents not implemented")
+}
+
+func RegisterBlockEventsServer(s *grpc.Server, srv BlockEventsServer) {
+	s.RegisterService(&_BlockEvents_serviceDesc, srv)
+}
+
+func _BlockEvents_BlockEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedBlockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockEventsServer).BlockEvents(m, &blockEventsBlockEventsServer{stream})
+}
+
+type BlockEvents_BlockEventsServer interface {
+	Send(*BlockEventsResponse) error
+	grpc.ServerStream
+}
+
+type blockEventsBlockEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockEventsBlockEventsServer) Send(m *BlockEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockEvents_FilteredBlockEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedBlockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockEventsServer).FilteredBlockEvents(m, &blockEventsFilteredBlockEventsServer{stream})
+}
+
+type BlockEvents_FilteredBlockEventsServer interface {
+	Send(*FilteredBlockEventsResponse) error
+	grpc.ServerStream
+}
+
+type blockEventsFilteredBlockEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockEventsFilteredBlockEventsServer) Send(m *FilteredBlockEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockEvents_BlockAndPrivateDataEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedBlockEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockEventsServer).BlockAndPrivateDataEvents(m, &blockEventsBlockAndPrivateDataEventsServer{stream})
+}
+
+type BlockEvents_BlockAndPrivateDataEventsServer interface {
+	Send(*BlockAndPrivateDataEventsResponse) error
+	grpc.ServerStream
+}
+
+type blockEventsBlockAndPrivateDataEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockEventsBlockAndPrivateDataEventsServer) Send(m *BlockAndPrivateDataEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _BlockEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blockevents.BlockEvents",
+	HandlerType: (*BlockEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BlockEvents",
+			Handler:       _BlockEvents_BlockEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FilteredBlockEvents",
+			Handler:       _BlockEvents_FilteredBlockEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BlockAndPrivateDataEvents",
+			Handler:       _BlockEvents_BlockAndPrivateDataEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/gateway/blockevents/blockevents.proto",
+}
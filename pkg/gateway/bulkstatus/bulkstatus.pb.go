@@ -0,0 +1,379 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/gateway/bulkstatus/bulkstatus.proto
+
+package bulkstatus
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedBulkCommitStatusRequest contains a serialized BulkCommitStatusRequest
+// message, and a digital signature for the serialized request message.
+type SignedBulkCommitStatusRequest struct {
+	// Serialized BulkCommitStatusRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedBulkCommitStatusRequest) Reset()         { *m = SignedBulkCommitStatusRequest{} }
+func (m *SignedBulkCommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedBulkCommitStatusRequest) ProtoMessage()    {}
+func (*SignedBulkCommitStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_489a3593a15a9d37, []int{0}
+}
+
+func (m *SignedBulkCommitStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedBulkCommitStatusRequest.Unmarshal(m, b)
+}
+func (m *SignedBulkCommitStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedBulkCommitStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedBulkCommitStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedBulkCommitStatusRequest.Merge(m, src)
+}
+func (m *SignedBulkCommitStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedBulkCommitStatusRequest.Size(m)
+}
+func (m *SignedBulkCommitStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedBulkCommitStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedBulkCommitStatusRequest proto.InternalMessageInfo
+
+func (m *SignedBulkCommitStatusRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedBulkCommitStatusRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// BulkCommitStatusRequest contains the details required to check whether
+// transactions have been committed.
+type BulkCommitStatusRequest struct {
+	// Identifier of the channel the transactions were submitted to.
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Client requestor identity.
+	Identity []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Identifiers of the transactions to check.
+	TransactionIds       []string `protobuf:"bytes,3,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkCommitStatusRequest) Reset()         { *m = BulkCommitStatusRequest{} }
+func (m *BulkCommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkCommitStatusRequest) ProtoMessage()    {}
+func (*BulkCommitStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_489a3593a15a9d37, []int{1}
+}
+
+func (m *BulkCommitStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkCommitStatusRequest.Unmarshal(m, b)
+}
+func (m *BulkCommitStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkCommitStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkCommitStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkCommitStatusRequest.Merge(m, src)
+}
+func (m *BulkCommitStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkCommitStatusRequest.Size(m)
+}
+func (m *BulkCommitStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkCommitStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkCommitStatusRequest proto.InternalMessageInfo
+
+func (m *BulkCommitStatusRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *BulkCommitStatusRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *BulkCommitStatusRequest) GetTransactionIds() []string {
+	if m != nil {
+		return m.TransactionIds
+	}
+	return nil
+}
+
+// BulkCommitStatusResponse returns the status of each requested transaction,
+// in the order the transactions were requested.
+type BulkCommitStatusResponse struct {
+	// The status of each requested transaction.
+	Statuses             []*TransactionStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BulkCommitStatusResponse) Reset()         { *m = BulkCommitStatusResponse{} }
+func (m *BulkCommitStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkCommitStatusResponse) ProtoMessage()    {}
+func (*BulkCommitStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_489a3593a15a9d37, []int{2}
+}
+
+func (m *BulkCommitStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkCommitStatusResponse.Unmarshal(m, b)
+}
+func (m *BulkCommitStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkCommitStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkCommitStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkCommitStatusResponse.Merge(m, src)
+}
+func (m *BulkCommitStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkCommitStatusResponse.Size(m)
+}
+func (m *BulkCommitStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkCommitStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkCommitStatusResponse proto.InternalMessageInfo
+
+func (m *BulkCommitStatusResponse) GetStatuses() []*TransactionStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+// TransactionStatus is the commit status of a transaction.
+type TransactionStatus struct {
+	// Identifier of the transaction.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// True if the transaction has been committed to the ledger. If false, the
+	// transaction is unknown to the gateway peer and the other fields are not
+	// set.
+	Committed bool `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	// The result of the transaction commit, as defined in peer/transaction.proto.
+	Result peer.TxValidationCode `protobuf:"varint,3,opt,name=result,proto3,enum=protos.TxValidationCode" json:"result,omitempty"`
+	// Block number that contains the transaction.
+	BlockNumber          uint64   `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionStatus) Reset()         { *m = TransactionStatus{} }
+func (m *TransactionStatus) String() string { return proto.CompactTextString(m) }
+func (*TransactionStatus) ProtoMessage()    {}
+func (*TransactionStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_489a3593a15a9d37, []int{3}
+}
+
+func (m *TransactionStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionStatus.Unmarshal(m, b)
+}
+func (m *TransactionStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionStatus.Marshal(b, m, deterministic)
+}
+func (m *TransactionStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionStatus.Merge(m, src)
+}
+func (m *TransactionStatus) XXX_Size() int {
+	return xxx_messageInfo_TransactionStatus.Size(m)
+}
+func (m *TransactionStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionStatus proto.InternalMessageInfo
+
+func (m *TransactionStatus) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *TransactionStatus) GetCommitted() bool {
+	if m != nil {
+		return m.Committed
+	}
+	return false
+}
+
+func (m *TransactionStatus) GetResult() peer.TxValidationCode {
+	if m != nil {
+		return m.Result
+	}
+	return peer.TxValidationCode_VALID
+}
+
+func (m *TransactionStatus) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SignedBulkCommitStatusRequest)(nil), "bulkstatus.SignedBulkCommitStatusRequest")
+	proto.RegisterType((*BulkCommitStatusRequest)(nil), "bulkstatus.BulkCommitStatusRequest")
+	proto.RegisterType((*BulkCommitStatusResponse)(nil), "bulkstatus.BulkCommitStatusResponse")
+	proto.RegisterType((*TransactionStatus)(nil), "bulkstatus.TransactionStatus")
+}
+
+func init() {
+	proto.RegisterFile("pkg/gateway/bulkstatus/bulkstatus.proto", fileDescriptor_489a3593a15a9d37)
+}
+
+var fileDescriptor_489a3593a15a9d37 = []byte{
+	// 392 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x4d, 0x6f, 0xd4, 0x30,
+	0x14, 0x54, 0xd8, 0xaa, 0x6c, 0x5e, 0xcb, 0x02, 0x3e, 0x80, 0xb5, 0x62, 0xa5, 0x10, 0x81, 0x1a,
+	0x2e, 0x09, 0x5a, 0x10, 0x12, 0xd7, 0xf6, 0xd4, 0x0b, 0x87, 0xb4, 0x80, 0xc4, 0x65, 0xe5, 0xc4,
+	0x8f, 0xac, 0x95, 0xc4, 0x4e, 0xfd, 0x21, 0xd8, 0x03, 0x3f, 0x89, 0xff, 0x88, 0xd6, 0x09, 0x4d,
+	0x4a, 0x69, 0x6f, 0xf6, 0x78, 0xde, 0x64, 0x66, 0xf2, 0xe0, 0xa4, 0xab, 0xab, 0xac, 0x62, 0x16,
+	0x7f, 0xb0, 0x5d, 0x56, 0xb8, 0xa6, 0x36, 0x96, 0x59, 0x67, 0x26, 0xc7, 0xb4, 0xd3, 0xca, 0x2a,
+	0x02, 0x23, 0xb2, 0x7c, 0xd6, 0x21, 0xea, 0xcc, 0x6a, 0x26, 0x0d, 0x2b, 0xad, 0x50, 0xb2, 0xe7,
+	0xc4, 0x5f, 0x61, 0x75, 0x21, 0x2a, 0x89, 0xfc, 0xd4, 0x35, 0xf5, 0x99, 0x6a, 0x5b, 0x61, 0x2f,
+	0xfc, 0x44, 0x8e, 0x57, 0x0e, 0x8d, 0x25, 0x14, 0x1e, 0xea, 0xfe, 0x48, 0x83, 0x28, 0x48, 0x8e,
+	0xf3, 0xbf, 0x57, 0xf2, 0x02, 0x42, 0x23, 0x2a, 0xc9, 0xac, 0xd3, 0x48, 0x1f, 0xf8, 0xb7, 0x11,
+	0x88, 0x7f, 0xc1, 0xf3, 0xbb, 0x24, 0x57, 0x00, 0xe5, 0x96, 0x49, 0x89, 0xcd, 0x46, 0x70, 0xaf,
+	0x1a, 0xe6, 0xe1, 0x80, 0x9c, 0x73, 0xb2, 0x84, 0xb9, 0xe0, 0x28, 0xad, 0xb0, 0xbb, 0x41, 0xf6,
+	0xfa, 0x4e, 0x4e, 0xe0, 0xf1, 0x24, 0xc3, 0x46, 0x70, 0x43, 0x67, 0xd1, 0x2c, 0x09, 0xf3, 0xc5,
+	0x04, 0x3e, 0xe7, 0x26, 0xfe, 0x0c, 0xf4, 0xf6, 0xe7, 0x4d, 0xa7, 0xa4, 0x41, 0xf2, 0x11, 0xe6,
+	0x7d, 0x2b, 0x68, 0x68, 0x10, 0xcd, 0x92, 0xa3, 0xf5, 0x2a, 0x9d, 0x94, 0x77, 0x39, 0x2a, 0x0d,
+	0x83, 0xd7, 0xf4, 0xf8, 0x77, 0x00, 0x4f, 0x6f, 0xbd, 0x93, 0xd7, 0xb0, 0xb8, 0xe9, 0x6a, 0x08,
+	0xf5, 0xe8, 0x86, 0xa9, 0x7d, 0x61, 0xa5, 0xf7, 0x63, 0x91, 0xfb, 0x64, 0xf3, 0x7c, 0x04, 0xc8,
+	0x5b, 0x38, 0xd4, 0x68, 0x5c, 0x63, 0xe9, 0x2c, 0x0a, 0x92, 0xc5, 0x9a, 0xf6, 0x7f, 0xc8, 0xa4,
+	0x97, 0x3f, 0xbf, 0xb0, 0x46, 0x70, 0xb6, 0x57, 0x39, 0x53, 0x1c, 0xf3, 0x81, 0x47, 0x5e, 0xc2,
+	0x71, 0xd1, 0xa8, 0xb2, 0xde, 0x48, 0xd7, 0x16, 0xa8, 0xe9, 0x41, 0x14, 0x24, 0x07, 0xf9, 0x91,
+	0xc7, 0x3e, 0x79, 0x68, 0x7d, 0x05, 0xb0, 0xaf, 0x61, 0xf0, 0x59, 0xc2, 0x93, 0x7f, 0x4b, 0x21,
+	0x6f, 0xa6, 0xd1, 0xef, 0x5d, 0x85, 0xe5, 0xab, 0x29, 0xf5, 0xae, 0x76, 0x4f, 0x3f, 0x7c, 0x7b,
+	0x5f, 0x09, 0xbb, 0x75, 0x45, 0x5a, 0xaa, 0x36, 0xdb, 0xee, 0x3a, 0xd4, 0x0d, 0xf2, 0x0a, 0x75,
+	0xf6, 0x9d, 0x15, 0x5a, 0x94, 0xd9, 0xff, 0xd7, 0xb7, 0x38, 0xf4, 0x71, 0xdf, 0xfd, 0x19, 0x00,
+	0xe3, 0xe4, 0x47, 0xe8, 0xdf, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BulkStatusClient is the client API for BulkStatus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BulkStatusClient interface {
+	// The BulkCommitStatus service returns the commit status of each of the
+	// requested transactions on a channel. Unlike the Gateway CommitStatus
+	// service, it does not wait for transactions to commit. Transactions that
+	// have not been committed to the gateway peer's ledger are reported as
+	// not committed.
+	BulkCommitStatus(ctx context.Context, in *SignedBulkCommitStatusRequest, opts ...grpc.CallOption) (*BulkCommitStatusResponse, error)
+}
+
+type bulkStatusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBulkStatusClient(cc grpc.ClientConnInterface) BulkStatusClient {
+	return &bulkStatusClient{cc}
+}
+
+func (c *bulkStatusClient) BulkCommitStatus(ctx context.Context, in *SignedBulkCommitStatusRequest, opts ...grpc.CallOption) (*BulkCommitStatusResponse, error) {
+	out := new(BulkCommitStatusResponse)
+	err := c.cc.Invoke(ctx, "/bulkstatus.BulkStatus/BulkCommitStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BulkStatusServer is the server API for BulkStatus service.
+type BulkStatusServer interface {
+	// The BulkCommitStatus service returns the commit status of each of the
+	// requested transactions on a channel. Unlike the Gateway CommitStatus
+	// service, it does not wait for transactions to commit. Transactions that
+	// have not been committed to the gateway peer's ledger are reported as
+	// not committed.
+	BulkCommitStatus(context.Context, *SignedBulkCommitStatusRequest) (*BulkCommitStatusResponse, error)
+}
+
+// UnimplementedBulkStatusServer can be embedded to have forward compatible implementations.
+type UnimplementedBulkStatusServer struct {
+}
+
+func (*UnimplementedBulkStatusServer) BulkCommitStatus(ctx context.Context, req *SignedBulkCommitStatusRequest) (*BulkCommitStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCommitStatus not implemented")
+}
+
+func RegisterBulkStatusServer(s *grpc.Server, srv BulkStatusServer) {
+	s.RegisterService(&_BulkStatus_serviceDesc, srv)
+}
+
+func _BulkStatus_BulkCommitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedBulkCommitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BulkStatusServer).BulkCommitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bulkstatus.BulkStatus/BulkCommitStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BulkStatusServer).BulkCommitStatus(ctx, req.(*SignedBulkCommitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BulkStatus_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "bulkstatus.BulkStatus",
+	HandlerType: (*BulkStatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BulkCommitStatus",
+			Handler:    _BulkStatus_BulkCommitStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/gateway/bulkstatus/bulkstatus.proto",
+}
@@ -0,0 +1,723 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/gateway/checkpoint/checkpoint.proto
+
+package checkpoint
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	gateway "github.com/hyperledger/fabric-protos-go/gateway"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignedCheckpointedChaincodeEventsRequest contains a serialized
+// CheckpointedChaincodeEventsRequest message, and a digital signature for the
+// serialized request message.
+type SignedCheckpointedChaincodeEventsRequest struct {
+	// Serialized CheckpointedChaincodeEventsRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedCheckpointedChaincodeEventsRequest) Reset() {
+	*m = SignedCheckpointedChaincodeEventsRequest{}
+}
+func (m *SignedCheckpointedChaincodeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedCheckpointedChaincodeEventsRequest) ProtoMessage()    {}
+func (*SignedCheckpointedChaincodeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{0}
+}
+
+func (m *SignedCheckpointedChaincodeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedCheckpointedChaincodeEventsRequest.Unmarshal(m, b)
+}
+func (m *SignedCheckpointedChaincodeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedCheckpointedChaincodeEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedCheckpointedChaincodeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedCheckpointedChaincodeEventsRequest.Merge(m, src)
+}
+func (m *SignedCheckpointedChaincodeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedCheckpointedChaincodeEventsRequest.Size(m)
+}
+func (m *SignedCheckpointedChaincodeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedCheckpointedChaincodeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedCheckpointedChaincodeEventsRequest proto.InternalMessageInfo
+
+func (m *SignedCheckpointedChaincodeEventsRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedCheckpointedChaincodeEventsRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// CheckpointedChaincodeEventsRequest contains the details required to obtain
+// chaincode events, resuming from a named checkpoint.
+type CheckpointedChaincodeEventsRequest struct {
+	// The chaincode events request. The start position and after transaction
+	// ID are used only if the named checkpoint does not exist.
+	EventsRequest *gateway.ChaincodeEventsRequest `protobuf:"bytes,1,opt,name=events_request,json=eventsRequest,proto3" json:"events_request,omitempty"`
+	// Name of the checkpoint from which to resume.
+	CheckpointName       string   `protobuf:"bytes,2,opt,name=checkpoint_name,json=checkpointName,proto3" json:"checkpoint_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointedChaincodeEventsRequest) Reset()         { *m = CheckpointedChaincodeEventsRequest{} }
+func (m *CheckpointedChaincodeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointedChaincodeEventsRequest) ProtoMessage()    {}
+func (*CheckpointedChaincodeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{1}
+}
+
+func (m *CheckpointedChaincodeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointedChaincodeEventsRequest.Unmarshal(m, b)
+}
+func (m *CheckpointedChaincodeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointedChaincodeEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointedChaincodeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointedChaincodeEventsRequest.Merge(m, src)
+}
+func (m *CheckpointedChaincodeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointedChaincodeEventsRequest.Size(m)
+}
+func (m *CheckpointedChaincodeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointedChaincodeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointedChaincodeEventsRequest proto.InternalMessageInfo
+
+func (m *CheckpointedChaincodeEventsRequest) GetEventsRequest() *gateway.ChaincodeEventsRequest {
+	if m != nil {
+		return m.EventsRequest
+	}
+	return nil
+}
+
+func (m *CheckpointedChaincodeEventsRequest) GetCheckpointName() string {
+	if m != nil {
+		return m.CheckpointName
+	}
+	return ""
+}
+
+// SignedSaveCheckpointRequest contains a serialized SaveCheckpointRequest
+// message, and a digital signature for the serialized request message.
+type SignedSaveCheckpointRequest struct {
+	// Serialized SaveCheckpointRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedSaveCheckpointRequest) Reset()         { *m = SignedSaveCheckpointRequest{} }
+func (m *SignedSaveCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedSaveCheckpointRequest) ProtoMessage()    {}
+func (*SignedSaveCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{2}
+}
+
+func (m *SignedSaveCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedSaveCheckpointRequest.Unmarshal(m, b)
+}
+func (m *SignedSaveCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedSaveCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedSaveCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedSaveCheckpointRequest.Merge(m, src)
+}
+func (m *SignedSaveCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedSaveCheckpointRequest.Size(m)
+}
+func (m *SignedSaveCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedSaveCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedSaveCheckpointRequest proto.InternalMessageInfo
+
+func (m *SignedSaveCheckpointRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedSaveCheckpointRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// SaveCheckpointRequest acknowledges that chaincode events have been
+// processed up to a position in the ledger.
+type SaveCheckpointRequest struct {
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Client requestor identity.
+	Identity []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Name of the checkpoint.
+	CheckpointName string `protobuf:"bytes,3,opt,name=checkpoint_name,json=checkpointName,proto3" json:"checkpoint_name,omitempty"`
+	// The processing position to record.
+	Position             *CheckpointPosition `protobuf:"bytes,4,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SaveCheckpointRequest) Reset()         { *m = SaveCheckpointRequest{} }
+func (m *SaveCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveCheckpointRequest) ProtoMessage()    {}
+func (*SaveCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{3}
+}
+
+func (m *SaveCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveCheckpointRequest.Unmarshal(m, b)
+}
+func (m *SaveCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *SaveCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveCheckpointRequest.Merge(m, src)
+}
+func (m *SaveCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_SaveCheckpointRequest.Size(m)
+}
+func (m *SaveCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveCheckpointRequest proto.InternalMessageInfo
+
+func (m *SaveCheckpointRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *SaveCheckpointRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *SaveCheckpointRequest) GetCheckpointName() string {
+	if m != nil {
+		return m.CheckpointName
+	}
+	return ""
+}
+
+func (m *SaveCheckpointRequest) GetPosition() *CheckpointPosition {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+// SaveCheckpointResponse is returned when a checkpoint has been saved.
+type SaveCheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SaveCheckpointResponse) Reset()         { *m = SaveCheckpointResponse{} }
+func (m *SaveCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*SaveCheckpointResponse) ProtoMessage()    {}
+func (*SaveCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{4}
+}
+
+func (m *SaveCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveCheckpointResponse.Unmarshal(m, b)
+}
+func (m *SaveCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *SaveCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveCheckpointResponse.Merge(m, src)
+}
+func (m *SaveCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_SaveCheckpointResponse.Size(m)
+}
+func (m *SaveCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveCheckpointResponse proto.InternalMessageInfo
+
+// SignedDeleteCheckpointRequest contains a serialized DeleteCheckpointRequest
+// message, and a digital signature for the serialized request message.
+type SignedDeleteCheckpointRequest struct {
+	// Serialized DeleteCheckpointRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedDeleteCheckpointRequest) Reset()         { *m = SignedDeleteCheckpointRequest{} }
+func (m *SignedDeleteCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedDeleteCheckpointRequest) ProtoMessage()    {}
+func (*SignedDeleteCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{5}
+}
+
+func (m *SignedDeleteCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedDeleteCheckpointRequest.Unmarshal(m, b)
+}
+func (m *SignedDeleteCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedDeleteCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedDeleteCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedDeleteCheckpointRequest.Merge(m, src)
+}
+func (m *SignedDeleteCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedDeleteCheckpointRequest.Size(m)
+}
+func (m *SignedDeleteCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedDeleteCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedDeleteCheckpointRequest proto.InternalMessageInfo
+
+func (m *SignedDeleteCheckpointRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedDeleteCheckpointRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// DeleteCheckpointRequest identifies a checkpoint to remove.
+type DeleteCheckpointRequest struct {
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Client requestor identity.
+	Identity []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Name of the checkpoint.
+	CheckpointName       string   `protobuf:"bytes,3,opt,name=checkpoint_name,json=checkpointName,proto3" json:"checkpoint_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCheckpointRequest) Reset()         { *m = DeleteCheckpointRequest{} }
+func (m *DeleteCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCheckpointRequest) ProtoMessage()    {}
+func (*DeleteCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{6}
+}
+
+func (m *DeleteCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCheckpointRequest.Unmarshal(m, b)
+}
+func (m *DeleteCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCheckpointRequest.Merge(m, src)
+}
+func (m *DeleteCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCheckpointRequest.Size(m)
+}
+func (m *DeleteCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCheckpointRequest proto.InternalMessageInfo
+
+func (m *DeleteCheckpointRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *DeleteCheckpointRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *DeleteCheckpointRequest) GetCheckpointName() string {
+	if m != nil {
+		return m.CheckpointName
+	}
+	return ""
+}
+
+// DeleteCheckpointResponse is returned when a checkpoint has been removed.
+type DeleteCheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCheckpointResponse) Reset()         { *m = DeleteCheckpointResponse{} }
+func (m *DeleteCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCheckpointResponse) ProtoMessage()    {}
+func (*DeleteCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{7}
+}
+
+func (m *DeleteCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCheckpointResponse.Unmarshal(m, b)
+}
+func (m *DeleteCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCheckpointResponse.Merge(m, src)
+}
+func (m *DeleteCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCheckpointResponse.Size(m)
+}
+func (m *DeleteCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCheckpointResponse proto.InternalMessageInfo
+
+// CheckpointPosition is the event processing position recorded by a
+// checkpoint.
+type CheckpointPosition struct {
+	// The block number from which to resume.
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// The ID of the last processed transaction in the block, or empty if no
+	// events in the block have been processed. This must be the transaction
+	// ID of a received chaincode event.
+	TransactionId        string   `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointPosition) Reset()         { *m = CheckpointPosition{} }
+func (m *CheckpointPosition) String() string { return proto.CompactTextString(m) }
+func (*CheckpointPosition) ProtoMessage()    {}
+func (*CheckpointPosition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7d2c46b705ec209a, []int{8}
+}
+
+func (m *CheckpointPosition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointPosition.Unmarshal(m, b)
+}
+func (m *CheckpointPosition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointPosition.Marshal(b, m, deterministic)
+}
+func (m *CheckpointPosition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointPosition.Merge(m, src)
+}
+func (m *CheckpointPosition) XXX_Size() int {
+	return xxx_messageInfo_CheckpointPosition.Size(m)
+}
+func (m *CheckpointPosition) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointPosition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointPosition proto.InternalMessageInfo
+
+func (m *CheckpointPosition) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *CheckpointPosition) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SignedCheckpointedChaincodeEventsRequest)(nil), "checkpoint.SignedCheckpointedChaincodeEventsRequest")
+	proto.RegisterType((*CheckpointedChaincodeEventsRequest)(nil), "checkpoint.CheckpointedChaincodeEventsRequest")
+	proto.RegisterType((*SignedSaveCheckpointRequest)(nil), "checkpoint.SignedSaveCheckpointRequest")
+	proto.RegisterType((*SaveCheckpointRequest)(nil), "checkpoint.SaveCheckpointRequest")
+	proto.RegisterType((*SaveCheckpointResponse)(nil), "checkpoint.SaveCheckpointResponse")
+	proto.RegisterType((*SignedDeleteCheckpointRequest)(nil), "checkpoint.SignedDeleteCheckpointRequest")
+	proto.RegisterType((*DeleteCheckpointRequest)(nil), "checkpoint.DeleteCheckpointRequest")
+	proto.RegisterType((*DeleteCheckpointResponse)(nil), "checkpoint.DeleteCheckpointResponse")
+	proto.RegisterType((*CheckpointPosition)(nil), "checkpoint.CheckpointPosition")
+}
+
+func init() {
+	proto.RegisterFile("pkg/gateway/checkpoint/checkpoint.proto", fileDescriptor_7d2c46b705ec209a)
+}
+
+var fileDescriptor_7d2c46b705ec209a = []byte{
+	// 471 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0xdd, 0x6a, 0xd4, 0x40,
+	0x14, 0x26, 0xb5, 0x68, 0xf7, 0xb4, 0x5d, 0x65, 0xa0, 0x1a, 0x52, 0xab, 0x75, 0x50, 0xb6, 0xde,
+	0x6c, 0xa4, 0x16, 0x2f, 0xbc, 0xf4, 0x0f, 0x7a, 0x53, 0x24, 0x45, 0x04, 0x41, 0x97, 0xc9, 0xe4,
+	0x98, 0x1d, 0x36, 0x99, 0x89, 0x93, 0x49, 0xcb, 0x5e, 0xf8, 0x18, 0x3e, 0x8b, 0xcf, 0xe0, 0x5b,
+	0xc9, 0x4e, 0xb2, 0x9b, 0x6c, 0x93, 0x48, 0x41, 0xf1, 0x2a, 0x33, 0x5f, 0xce, 0x9c, 0xf3, 0x7d,
+	0xdf, 0x9c, 0x33, 0x30, 0xca, 0x66, 0xb1, 0x1f, 0x33, 0x83, 0x97, 0x6c, 0xee, 0xf3, 0x29, 0xf2,
+	0x59, 0xa6, 0x84, 0x34, 0x8d, 0xe5, 0x38, 0xd3, 0xca, 0x28, 0x02, 0x35, 0xe2, 0xed, 0x2d, 0x0f,
+	0x54, 0xdf, 0x32, 0x84, 0x86, 0x70, 0x74, 0x2e, 0x62, 0x89, 0xd1, 0xeb, 0x55, 0xe8, 0x62, 0xcd,
+	0x84, 0xe4, 0x2a, 0xc2, 0xb7, 0x17, 0x28, 0x4d, 0x1e, 0xe0, 0xb7, 0x02, 0x73, 0x43, 0x5c, 0xb8,
+	0xa5, 0xcb, 0xa5, 0xeb, 0x1c, 0x3a, 0x47, 0x3b, 0xc1, 0x72, 0x4b, 0xee, 0xc3, 0x20, 0x17, 0xb1,
+	0x64, 0xa6, 0xd0, 0xe8, 0x6e, 0xd8, 0x7f, 0x35, 0x40, 0x7f, 0x38, 0x40, 0xaf, 0x91, 0xfe, 0x1d,
+	0x0c, 0xd1, 0x02, 0x93, 0x66, 0x95, 0xed, 0xe3, 0x87, 0xe3, 0x25, 0xe5, 0xee, 0x83, 0xc1, 0x2e,
+	0xae, 0xe5, 0x19, 0xc1, 0xed, 0x5a, 0xf7, 0x44, 0xb2, 0xb4, 0xa4, 0x34, 0x08, 0x86, 0x35, 0x7c,
+	0xc6, 0x52, 0xa4, 0x1f, 0x60, 0xbf, 0xd4, 0x7e, 0xce, 0x2e, 0xb0, 0x26, 0xf8, 0xb7, 0x72, 0x7f,
+	0x3a, 0xb0, 0xd7, 0x9d, 0xf1, 0x00, 0x80, 0x4f, 0x99, 0x94, 0x98, 0x4c, 0x44, 0x64, 0x93, 0x0e,
+	0x82, 0x41, 0x85, 0x9c, 0x46, 0xc4, 0x83, 0x2d, 0x11, 0xa1, 0x34, 0xc2, 0xcc, 0xab, 0xac, 0xab,
+	0x7d, 0x97, 0xa8, 0x1b, 0x5d, 0xa2, 0xc8, 0x4b, 0xd8, 0xca, 0x54, 0x2e, 0x8c, 0x50, 0xd2, 0xdd,
+	0xb4, 0xfe, 0x3d, 0x18, 0x37, 0x1a, 0xa3, 0x26, 0xf5, 0xbe, 0x8a, 0x0a, 0x56, 0xf1, 0xd4, 0x85,
+	0xbb, 0x57, 0x89, 0xe7, 0x99, 0x92, 0x39, 0xd2, 0x8f, 0x70, 0x50, 0x5a, 0xf5, 0x06, 0x13, 0x34,
+	0xff, 0xd0, 0xac, 0xef, 0x70, 0xaf, 0x2f, 0xe5, 0x7f, 0x70, 0x8b, 0x7a, 0xe0, 0xb6, 0xcb, 0x57,
+	0x9a, 0xbf, 0x00, 0x69, 0xbb, 0x45, 0x1e, 0xc1, 0x4e, 0x98, 0x28, 0x3e, 0x9b, 0xc8, 0x22, 0x0d,
+	0x51, 0x5b, 0x5e, 0x9b, 0xc1, 0xb6, 0xc5, 0xce, 0x2c, 0x44, 0x9e, 0xc0, 0xd0, 0x68, 0x26, 0x73,
+	0xc6, 0x17, 0x27, 0x16, 0xe4, 0xcb, 0xfe, 0xdb, 0x6d, 0xa0, 0xa7, 0xd1, 0xf1, 0xaf, 0x0d, 0x80,
+	0xba, 0x00, 0xb9, 0x84, 0xfd, 0x3f, 0x0c, 0x09, 0x39, 0x69, 0xde, 0xe2, 0x75, 0x47, 0xd6, 0x3b,
+	0xec, 0x9f, 0x9d, 0x52, 0xe5, 0x33, 0x87, 0x7c, 0x86, 0xe1, 0xfa, 0xad, 0x93, 0x51, 0xbb, 0x56,
+	0x67, 0x43, 0x7b, 0x74, 0x2d, 0xb0, 0xb3, 0x75, 0x08, 0x87, 0x3b, 0x57, 0x2d, 0x26, 0x4f, 0xdb,
+	0x05, 0x7a, 0xba, 0xc0, 0x7b, 0xdc, 0x0c, 0xed, 0xbb, 0xab, 0x57, 0x2f, 0x3e, 0x9d, 0xc4, 0xc2,
+	0x4c, 0x8b, 0x70, 0xcc, 0x55, 0xea, 0x4f, 0xe7, 0x19, 0xea, 0x04, 0xa3, 0x18, 0xb5, 0xff, 0x95,
+	0x85, 0x5a, 0x70, 0xbf, 0xfb, 0xc9, 0x0c, 0x6f, 0xda, 0x57, 0xf0, 0xf9, 0xef, 0x01, 0x00, 0x6c,
+	0xbb, 0x0c, 0x33, 0x53, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CheckpointClient is the client API for Checkpoint service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CheckpointClient interface {
+	// The CheckpointedChaincodeEvents service supplies a stream of responses
+	// in the same way as the Gateway ChaincodeEvents service. If the named
+	// checkpoint exists, events are resumed from the checkpoint position, and
+	// the start position and after transaction ID of the request are ignored.
+	CheckpointedChaincodeEvents(ctx context.Context, in *SignedCheckpointedChaincodeEventsRequest, opts ...grpc.CallOption) (Checkpoint_CheckpointedChaincodeEventsClient, error)
+	// The SaveCheckpoint service records the event processing position of a
+	// named checkpoint, creating the checkpoint if it does not exist.
+	SaveCheckpoint(ctx context.Context, in *SignedSaveCheckpointRequest, opts ...grpc.CallOption) (*SaveCheckpointResponse, error)
+	// The DeleteCheckpoint service removes a named checkpoint.
+	DeleteCheckpoint(ctx context.Context, in *SignedDeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error)
+}
+
+type checkpointClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCheckpointClient(cc grpc.ClientConnInterface) CheckpointClient {
+	return &checkpointClient{cc}
+}
+
+func (c *checkpointClient) CheckpointedChaincodeEvents(ctx context.Context, in *SignedCheckpointedChaincodeEventsRequest, opts ...grpc.CallOption) (Checkpoint_CheckpointedChaincodeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Checkpoint_serviceDesc.Streams[0], "/checkpoint.Checkpoint/CheckpointedChaincodeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &checkpointCheckpointedChaincodeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Checkpoint_CheckpointedChaincodeEventsClient interface {
+	Recv() (*gateway.ChaincodeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type checkpointCheckpointedChaincodeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *checkpointCheckpointedChaincodeEventsClient) Recv() (*gateway.ChaincodeEventsResponse, error) {
+	m := new(gateway.ChaincodeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *checkpointClient) SaveCheckpoint(ctx context.Context, in *SignedSaveCheckpointRequest, opts ...grpc.CallOption) (*SaveCheckpointResponse, error) {
+	out := new(SaveCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/checkpoint.Checkpoint/SaveCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointClient) DeleteCheckpoint(ctx context.Context, in *SignedDeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error) {
+	out := new(DeleteCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/checkpoint.Checkpoint/DeleteCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CheckpointServer is the server API for Checkpoint service.
+type CheckpointServer interface {
+	// The CheckpointedChaincodeEvents service supplies a stream of responses
+	// in the same way as the Gateway ChaincodeEvents service. If the named
+	// checkpoint exists, events are resumed from the checkpoint position, and
+	// the start position and after transaction ID of the request are ignored.
+	CheckpointedChaincodeEvents(*SignedCheckpointedChaincodeEventsRequest, Checkpoint_CheckpointedChaincodeEventsServer) error
+	// The SaveCheckpoint service records the event processing position of a
+	// named checkpoint, creating the checkpoint if it does not exist.
+	SaveCheckpoint(context.Context, *SignedSaveCheckpointRequest) (*SaveCheckpointResponse, error)
+	// The DeleteCheckpoint service removes a named checkpoint.
+	DeleteCheckpoint(context.Context, *SignedDeleteCheckpointRequest) (*DeleteCheckpointResponse, error)
+}
+
+// UnimplementedCheckpointServer can be embedded to have forward compatible implementations.
+type UnimplementedCheckpointServer struct {
+}
+
+func (*UnimplementedCheckpointServer) CheckpointedChaincodeEvents(req *SignedCheckpointedChaincodeEventsRequest, srv Checkpoint_CheckpointedChaincodeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CheckpointedChaincodeEvents not implemented")
+}
+func (*UnimplementedCheckpointServer) SaveCheckpoint(ctx context.Context, req *SignedSaveCheckpointRequest) (*SaveCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveCheckpoint not implemented")
+}
+func (*UnimplementedCheckpointServer) DeleteCheckpoint(ctx context.Context, req *SignedDeleteCheckpointRequest) (*DeleteCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCheckpoint not implemented")
+}
+
+func RegisterCheckpointServer(s *grpc.Server, srv CheckpointServer) {
+	s.RegisterService(&_Checkpoint_serviceDesc, srv)
+}
+
+func _Checkpoint_CheckpointedChaincodeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedCheckpointedChaincodeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CheckpointServer).CheckpointedChaincodeEvents(m, &checkpointCheckpointedChaincodeEventsServer{stream})
+}
+
+type Checkpoint_CheckpointedChaincodeEventsServer interface {
+	Send(*gateway.ChaincodeEventsResponse) error
+	grpc.ServerStream
+}
+
+type checkpointCheckpointedChaincodeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *checkpointCheckpointedChaincodeEventsServer) Send(m *gateway.ChaincodeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Checkpoint_SaveCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedSaveCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServer).SaveCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/checkpoint.Checkpoint/SaveCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServer).SaveCheckpoint(ctx, req.(*SignedSaveCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Checkpoint_DeleteCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedDeleteCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServer).DeleteCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/checkpoint.Checkpoint/DeleteCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServer).DeleteCheckpoint(ctx, req.(*SignedDeleteCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Checkpoint_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "checkpoint.Checkpoint",
+	HandlerType: (*CheckpointServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SaveCheckpoint",
+			Handler:    _Checkpoint_SaveCheckpoint_Handler,
+		},
+		{
+			MethodName: "DeleteCheckpoint",
+			Handler:    _Checkpoint_DeleteCheckpoint_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CheckpointedChaincodeEvents",
+			Handler:       _Checkpoint_CheckpointedChaincodeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/gateway/checkpoint/checkpoint.proto",
+}
@@ -0,0 +1,340 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/gateway/diagnostics/diagnostics.proto
+
+package diagnostics
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	kvrwset "github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// DifferenceType identifies the part of a proposal response payload that
+// differs between endorsements.
+type DifferenceType int32
+
+const (
+	// The payloads differ in a way that could not be decoded further.
+	DifferenceType_PAYLOAD DifferenceType = 0
+	// The proposal hash differs, indicating the endorsers received
+	// different proposals.
+	DifferenceType_PROPOSAL_HASH DifferenceType = 1
+	// The chaincode ID, including the chaincode version, differs.
+	DifferenceType_CHAINCODE_ID DifferenceType = 2
+	// The chaincode response status, message or payload differs.
+	DifferenceType_RESPONSE DifferenceType = 3
+	// The chaincode event differs.
+	DifferenceType_EVENT DifferenceType = 4
+	// A key was read at a different version, or read by only one endorser.
+	DifferenceType_READ DifferenceType = 5
+	// A key was written with a different value, or written by only one
+	// endorser.
+	DifferenceType_WRITE DifferenceType = 6
+	// Key metadata was written with different values.
+	DifferenceType_METADATA_WRITE DifferenceType = 7
+	// The range queries performed by the chaincode differ.
+	DifferenceType_RANGE_QUERY DifferenceType = 8
+	// A private data key was read at a different version.
+	DifferenceType_PRIVATE_READ DifferenceType = 9
+	// A private data key was written with a different value.
+	DifferenceType_PRIVATE_WRITE DifferenceType = 10
+)
+
+var DifferenceType_name = map[int32]string{
+	0:  "PAYLOAD",
+	1:  "PROPOSAL_HASH",
+	2:  "CHAINCODE_ID",
+	3:  "RESPONSE",
+	4:  "EVENT",
+	5:  "READ",
+	6:  "WRITE",
+	7:  "METADATA_WRITE",
+	8:  "RANGE_QUERY",
+	9:  "PRIVATE_READ",
+	10: "PRIVATE_WRITE",
+}
+
+var DifferenceType_value = map[string]int32{
+	"PAYLOAD":        0,
+	"PROPOSAL_HASH":  1,
+	"CHAINCODE_ID":   2,
+	"RESPONSE":       3,
+	"EVENT":          4,
+	"READ":           5,
+	"WRITE":          6,
+	"METADATA_WRITE": 7,
+	"RANGE_QUERY":    8,
+	"PRIVATE_READ":   9,
+	"PRIVATE_WRITE":  10,
+}
+
+func (x DifferenceType) String() string {
+	return proto.EnumName(DifferenceType_name, int32(x))
+}
+
+func (DifferenceType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_340c93a0eb34ddc8, []int{0}
+}
+
+// EndorsementMismatch is attached to the details of a gateway error when an
+// endorser returns a proposal response payload that differs from the payload
+// returned by the other endorsers of the transaction.
+type EndorsementMismatch struct {
+	// Network address of the endorsing peer.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// MSP ID of the endorsing peer.
+	MspId string `protobuf:"bytes,2,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	// The differences between the proposal response payload of this endorser
+	// and the payload of the first endorsement collected by the gateway.
+	Differences          []*Difference `protobuf:"bytes,3,rep,name=differences,proto3" json:"differences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *EndorsementMismatch) Reset()         { *m = EndorsementMismatch{} }
+func (m *EndorsementMismatch) String() string { return proto.CompactTextString(m) }
+func (*EndorsementMismatch) ProtoMessage()    {}
+func (*EndorsementMismatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_340c93a0eb34ddc8, []int{0}
+}
+
+func (m *EndorsementMismatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorsementMismatch.Unmarshal(m, b)
+}
+func (m *EndorsementMismatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorsementMismatch.Marshal(b, m, deterministic)
+}
+func (m *EndorsementMismatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorsementMismatch.Merge(m, src)
+}
+func (m *EndorsementMismatch) XXX_Size() int {
+	return xxx_messageInfo_EndorsementMismatch.Size(m)
+}
+func (m *EndorsementMismatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorsementMismatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorsementMismatch proto.InternalMessageInfo
+
+func (m *EndorsementMismatch) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *EndorsementMismatch) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *EndorsementMismatch) GetDifferences() []*Difference {
+	if m != nil {
+		return m.Differences
+	}
+	return nil
+}
+
+// Difference describes a single difference between two proposal response
+// payloads. Expected values are taken from the first endorsement collected by
+// the gateway, and actual values from the mismatched endorsement. A missing
+// version or value indicates that the key was not read or written by that
+// endorser.
+type Difference struct {
+	// The part of the proposal response payload that differs.
+	Type DifferenceType `protobuf:"varint,1,opt,name=type,proto3,enum=diagnostics.DifferenceType" json:"type,omitempty"`
+	// Chaincode namespace of the read-write set, for read and write differences.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Private data collection name, for private read and write differences.
+	Collection string `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	// The key that differs. For private data this is the key hash.
+	Key []byte `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	// Human readable description of the difference.
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Expected read version, for read differences.
+	ExpectedVersion *kvrwset.Version `protobuf:"bytes,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Actual read version, for read differences.
+	ActualVersion *kvrwset.Version `protobuf:"bytes,7,opt,name=actual_version,json=actualVersion,proto3" json:"actual_version,omitempty"`
+	// SHA-256 hash of the expected value, for write, event and response
+	// differences. Values are not returned in full, since they may be large
+	// or contain data the client is not entitled to see. For private data
+	// this is the value hash from the hashed read-write set.
+	ExpectedValueHash []byte `protobuf:"bytes,8,opt,name=expected_value_hash,json=expectedValueHash,proto3" json:"expected_value_hash,omitempty"`
+	// SHA-256 hash of the actual value, for write, event and response
+	// differences. For private data this is the value hash from the hashed
+	// read-write set.
+	ActualValueHash []byte `protobuf:"bytes,9,opt,name=actual_value_hash,json=actualValueHash,proto3" json:"actual_value_hash,omitempty"`
+	// Size in bytes of the expected value. Not set for private data.
+	ExpectedValueSize uint64 `protobuf:"varint,10,opt,name=expected_value_size,json=expectedValueSize,proto3" json:"expected_value_size,omitempty"`
+	// Size in bytes of the actual value. Not set for private data.
+	ActualValueSize      uint64   `protobuf:"varint,11,opt,name=actual_value_size,json=actualValueSize,proto3" json:"actual_value_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Difference) Reset()         { *m = Difference{} }
+func (m *Difference) String() string { return proto.CompactTextString(m) }
+func (*Difference) ProtoMessage()    {}
+func (*Difference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_340c93a0eb34ddc8, []int{1}
+}
+
+func (m *Difference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Difference.Unmarshal(m, b)
+}
+func (m *Difference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Difference.Marshal(b, m, deterministic)
+}
+func (m *Difference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Difference.Merge(m, src)
+}
+func (m *Difference) XXX_Size() int {
+	return xxx_messageInfo_Difference.Size(m)
+}
+func (m *Difference) XXX_DiscardUnknown() {
+	xxx_messageInfo_Difference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Difference proto.InternalMessageInfo
+
+func (m *Difference) GetType() DifferenceType {
+	if m != nil {
+		return m.Type
+	}
+	return DifferenceType_PAYLOAD
+}
+
+func (m *Difference) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Difference) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *Difference) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *Difference) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Difference) GetExpectedVersion() *kvrwset.Version {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return nil
+}
+
+func (m *Difference) GetActualVersion() *kvrwset.Version {
+	if m != nil {
+		return m.ActualVersion
+	}
+	return nil
+}
+
+func (m *Difference) GetExpectedValueHash() []byte {
+	if m != nil {
+		return m.ExpectedValueHash
+	}
+	return nil
+}
+
+func (m *Difference) GetActualValueHash() []byte {
+	if m != nil {
+		return m.ActualValueHash
+	}
+	return nil
+}
+
+func (m *Difference) GetExpectedValueSize() uint64 {
+	if m != nil {
+		return m.ExpectedValueSize
+	}
+	return 0
+}
+
+func (m *Difference) GetActualValueSize() uint64 {
+	if m != nil {
+		return m.ActualValueSize
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("diagnostics.DifferenceType", DifferenceType_name, DifferenceType_value)
+	proto.RegisterType((*EndorsementMismatch)(nil), "diagnostics.EndorsementMismatch")
+	proto.RegisterType((*Difference)(nil), "diagnostics.Difference")
+}
+
+func init() {
+	proto.RegisterFile("pkg/gateway/diagnostics/diagnostics.proto", fileDescriptor_340c93a0eb34ddc8)
+}
+
+var fileDescriptor_340c93a0eb34ddc8 = []byte{
+	// 550 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x86, 0x71, 0xf3, 0x3d, 0x6e, 0xd3, 0xed, 0x56, 0x08, 0x0b, 0x10, 0x8a, 0xca, 0x25, 0xf4,
+	0x10, 0x4b, 0x45, 0xa8, 0x42, 0x9c, 0x4c, 0xb3, 0x22, 0x91, 0xda, 0x24, 0x6c, 0x4c, 0x51, 0xb9,
+	0x58, 0x5b, 0x7b, 0x1a, 0x5b, 0x8d, 0x3f, 0xe4, 0x75, 0x5b, 0xd2, 0x1b, 0x7f, 0x8b, 0xff, 0xc2,
+	0x7f, 0x41, 0x5e, 0xe7, 0xc3, 0x50, 0xe5, 0xe4, 0xf1, 0x3b, 0xcf, 0xfb, 0xce, 0xc8, 0xd6, 0xc0,
+	0xbb, 0xe4, 0x76, 0x66, 0xce, 0x44, 0x86, 0x0f, 0x62, 0x61, 0x7a, 0x81, 0x98, 0x45, 0xb1, 0xcc,
+	0x02, 0x57, 0x96, 0xeb, 0x5e, 0x92, 0xc6, 0x59, 0x4c, 0xf5, 0x92, 0xf4, 0xf2, 0xed, 0x1c, 0xbd,
+	0x19, 0xa6, 0x66, 0xfa, 0x20, 0x31, 0x33, 0x6f, 0xef, 0x57, 0x4f, 0x47, 0x15, 0x85, 0xe3, 0xe8,
+	0x97, 0x06, 0x87, 0x2c, 0xf2, 0xe2, 0x54, 0x62, 0x88, 0x51, 0x76, 0x11, 0xc8, 0x50, 0x64, 0xae,
+	0x4f, 0x0d, 0x68, 0x08, 0xcf, 0x4b, 0x51, 0x4a, 0x43, 0xeb, 0x68, 0xdd, 0x16, 0x5f, 0xbd, 0xd2,
+	0xe7, 0x50, 0x0f, 0x65, 0xe2, 0x04, 0x9e, 0xb1, 0xa3, 0x1a, 0xb5, 0x50, 0x26, 0x43, 0x8f, 0x7e,
+	0x04, 0xdd, 0x0b, 0x6e, 0x6e, 0x30, 0xc5, 0xc8, 0x45, 0x69, 0x54, 0x3a, 0x95, 0xae, 0x7e, 0xf2,
+	0xa2, 0x57, 0xde, 0xb1, 0xbf, 0xee, 0xf3, 0x32, 0x7b, 0xf4, 0xa7, 0x02, 0xb0, 0xe9, 0x51, 0x13,
+	0xaa, 0xd9, 0x22, 0x41, 0x35, 0xb7, 0x7d, 0xf2, 0x6a, 0x4b, 0x84, 0xbd, 0x48, 0x90, 0x2b, 0x90,
+	0xbe, 0x86, 0x56, 0x24, 0x42, 0x94, 0x89, 0x70, 0x71, 0xb9, 0xd4, 0x46, 0xa0, 0x6f, 0x00, 0xdc,
+	0x78, 0x3e, 0x47, 0x37, 0x0b, 0xe2, 0xc8, 0xa8, 0xa8, 0x76, 0x49, 0xa1, 0x04, 0x2a, 0xb7, 0xb8,
+	0x30, 0xaa, 0x1d, 0xad, 0xbb, 0xcb, 0xf3, 0x92, 0x76, 0x40, 0xf7, 0x50, 0xba, 0x69, 0x90, 0x28,
+	0x4b, 0x4d, 0x59, 0xca, 0x12, 0xfd, 0x04, 0x04, 0x7f, 0x26, 0xe8, 0x66, 0xe8, 0x39, 0xf7, 0x98,
+	0xca, 0x1c, 0xab, 0x77, 0xb4, 0xae, 0x7e, 0x42, 0x7a, 0xcb, 0x0f, 0xdd, 0xbb, 0x2c, 0x74, 0xbe,
+	0xbf, 0x22, 0x97, 0x02, 0x3d, 0x85, 0xb6, 0x70, 0xb3, 0x3b, 0x31, 0x5f, 0x5b, 0x1b, 0x5b, 0xac,
+	0x7b, 0x05, 0xb7, 0x32, 0xf6, 0xe0, 0x70, 0x33, 0x55, 0xcc, 0xef, 0xd0, 0xf1, 0x85, 0xf4, 0x8d,
+	0xa6, 0xda, 0xfc, 0x60, 0x3d, 0x26, 0xef, 0x0c, 0x84, 0xf4, 0xe9, 0x31, 0x1c, 0xac, 0x06, 0x6d,
+	0xe8, 0x96, 0xa2, 0xf7, 0x97, 0xc9, 0x6b, 0xf6, 0x69, 0xb6, 0x0c, 0x1e, 0xd1, 0x80, 0x8e, 0xd6,
+	0xad, 0xfe, 0x97, 0x3d, 0x0d, 0x1e, 0xf1, 0x49, 0xb6, 0xa2, 0x75, 0x45, 0x97, 0xb3, 0x73, 0xf6,
+	0xf8, 0xb7, 0x06, 0xed, 0x7f, 0x7f, 0x1c, 0xd5, 0xa1, 0x31, 0xb1, 0xae, 0xce, 0xc7, 0x56, 0x9f,
+	0x3c, 0xa3, 0x07, 0xb0, 0x37, 0xe1, 0xe3, 0xc9, 0x78, 0x6a, 0x9d, 0x3b, 0x03, 0x6b, 0x3a, 0x20,
+	0x1a, 0x25, 0xb0, 0x7b, 0x36, 0xb0, 0x86, 0xa3, 0xb3, 0x71, 0x9f, 0x39, 0xc3, 0x3e, 0xd9, 0xa1,
+	0xbb, 0xd0, 0xe4, 0x6c, 0x3a, 0x19, 0x8f, 0xa6, 0x8c, 0x54, 0x68, 0x0b, 0x6a, 0xec, 0x92, 0x8d,
+	0x6c, 0x52, 0xa5, 0x4d, 0xa8, 0x72, 0x66, 0xf5, 0x49, 0x2d, 0x17, 0xbf, 0xf3, 0xa1, 0xcd, 0x48,
+	0x9d, 0x52, 0x68, 0x5f, 0x30, 0xdb, 0xea, 0x5b, 0xb6, 0xe5, 0x14, 0x5a, 0x83, 0xee, 0x83, 0xce,
+	0xad, 0xd1, 0x17, 0xe6, 0x7c, 0xfd, 0xc6, 0xf8, 0x15, 0x69, 0xe6, 0x43, 0x26, 0x7c, 0x78, 0x69,
+	0xd9, 0xcc, 0x51, 0x09, 0xad, 0x62, 0x93, 0x42, 0x29, 0x5c, 0xf0, 0xf9, 0xf4, 0xc7, 0x87, 0x59,
+	0x90, 0xf9, 0x77, 0xd7, 0x3d, 0x37, 0x0e, 0x4d, 0x7f, 0x91, 0x60, 0xba, 0xbc, 0xab, 0x1b, 0x71,
+	0x9d, 0x06, 0xae, 0xb9, 0xe5, 0x3a, 0xaf, 0xeb, 0xea, 0xc0, 0xde, 0xff, 0x1d, 0x00, 0x67, 0xe9,
+	0x2b, 0x98, 0xbf, 0x03, 0x00, 0x00,
+}
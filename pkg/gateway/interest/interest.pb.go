@@ -0,0 +1,406 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/gateway/interest/interest.proto
+
+package interest
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	gateway "github.com/hyperledger/fabric-protos-go/gateway"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// EndorseWithInterestRequest contains the details required to endorse a
+// transaction proposal using an explicit chaincode interest.
+type EndorseWithInterestRequest struct {
+	// The endorse request. Endorsing organizations must not be specified.
+	EndorseRequest *gateway.EndorseRequest `protobuf:"bytes,1,opt,name=endorse_request,json=endorseRequest,proto3" json:"endorse_request,omitempty"`
+	// The chaincodes and collections that the transaction invokes, including
+	// chaincode-to-chaincode calls. It must include the chaincode invoked by
+	// the proposal. If the proposal contains transient data, the no private
+	// reads hints are ignored, so that only organizations that are members of
+	// the collections are selected.
+	ChaincodeInterest    *peer.ChaincodeInterest `protobuf:"bytes,2,opt,name=chaincode_interest,json=chaincodeInterest,proto3" json:"chaincode_interest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *EndorseWithInterestRequest) Reset()         { *m = EndorseWithInterestRequest{} }
+func (m *EndorseWithInterestRequest) String() string { return proto.CompactTextString(m) }
+func (*EndorseWithInterestRequest) ProtoMessage()    {}
+func (*EndorseWithInterestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7da67551a7d3855, []int{0}
+}
+
+func (m *EndorseWithInterestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorseWithInterestRequest.Unmarshal(m, b)
+}
+func (m *EndorseWithInterestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorseWithInterestRequest.Marshal(b, m, deterministic)
+}
+func (m *EndorseWithInterestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorseWithInterestRequest.Merge(m, src)
+}
+func (m *EndorseWithInterestRequest) XXX_Size() int {
+	return xxx_messageInfo_EndorseWithInterestRequest.Size(m)
+}
+func (m *EndorseWithInterestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorseWithInterestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorseWithInterestRequest proto.InternalMessageInfo
+
+func (m *EndorseWithInterestRequest) GetEndorseRequest() *gateway.EndorseRequest {
+	if m != nil {
+		return m.EndorseRequest
+	}
+	return nil
+}
+
+func (m *EndorseWithInterestRequest) GetChaincodeInterest() *peer.ChaincodeInterest {
+	if m != nil {
+		return m.ChaincodeInterest
+	}
+	return nil
+}
+
+// EndorseWithInterestResponse returns the result of endorsing a transaction.
+type EndorseWithInterestResponse struct {
+	// The endorse response.
+	EndorseResponse *gateway.EndorseResponse `protobuf:"bytes,1,opt,name=endorse_response,json=endorseResponse,proto3" json:"endorse_response,omitempty"`
+	// The layout of endorsing peers that satisfied the endorsement policy.
+	Layout               *EndorsementLayout `protobuf:"bytes,2,opt,name=layout,proto3" json:"layout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *EndorseWithInterestResponse) Reset()         { *m = EndorseWithInterestResponse{} }
+func (m *EndorseWithInterestResponse) String() string { return proto.CompactTextString(m) }
+func (*EndorseWithInterestResponse) ProtoMessage()    {}
+func (*EndorseWithInterestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7da67551a7d3855, []int{1}
+}
+
+func (m *EndorseWithInterestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorseWithInterestResponse.Unmarshal(m, b)
+}
+func (m *EndorseWithInterestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorseWithInterestResponse.Marshal(b, m, deterministic)
+}
+func (m *EndorseWithInterestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorseWithInterestResponse.Merge(m, src)
+}
+func (m *EndorseWithInterestResponse) XXX_Size() int {
+	return xxx_messageInfo_EndorseWithInterestResponse.Size(m)
+}
+func (m *EndorseWithInterestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorseWithInterestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorseWithInterestResponse proto.InternalMessageInfo
+
+func (m *EndorseWithInterestResponse) GetEndorseResponse() *gateway.EndorseResponse {
+	if m != nil {
+		return m.EndorseResponse
+	}
+	return nil
+}
+
+func (m *EndorseWithInterestResponse) GetLayout() *EndorsementLayout {
+	if m != nil {
+		return m.Layout
+	}
+	return nil
+}
+
+// EndorsementLayout describes a combination of endorsing peers, grouped as
+// returned by the discovery service, that satisfied the endorsement policy.
+type EndorsementLayout struct {
+	// The groups of endorsing peers in the layout.
+	Groups               []*LayoutGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *EndorsementLayout) Reset()         { *m = EndorsementLayout{} }
+func (m *EndorsementLayout) String() string { return proto.CompactTextString(m) }
+func (*EndorsementLayout) ProtoMessage()    {}
+func (*EndorsementLayout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7da67551a7d3855, []int{2}
+}
+
+func (m *EndorsementLayout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorsementLayout.Unmarshal(m, b)
+}
+func (m *EndorsementLayout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorsementLayout.Marshal(b, m, deterministic)
+}
+func (m *EndorsementLayout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorsementLayout.Merge(m, src)
+}
+func (m *EndorsementLayout) XXX_Size() int {
+	return xxx_messageInfo_EndorsementLayout.Size(m)
+}
+func (m *EndorsementLayout) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorsementLayout.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorsementLayout proto.InternalMessageInfo
+
+func (m *EndorsementLayout) GetGroups() []*LayoutGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// LayoutGroup is a group of endorsing peers within a layout. The number of
+// endorsers is the quantity required from the group.
+type LayoutGroup struct {
+	// The name of the group assigned by the discovery service.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The endorsing peers from this group.
+	Endorsers            []*LayoutEndorser `protobuf:"bytes,2,rep,name=endorsers,proto3" json:"endorsers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LayoutGroup) Reset()         { *m = LayoutGroup{} }
+func (m *LayoutGroup) String() string { return proto.CompactTextString(m) }
+func (*LayoutGroup) ProtoMessage()    {}
+func (*LayoutGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7da67551a7d3855, []int{3}
+}
+
+func (m *LayoutGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LayoutGroup.Unmarshal(m, b)
+}
+func (m *LayoutGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LayoutGroup.Marshal(b, m, deterministic)
+}
+func (m *LayoutGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LayoutGroup.Merge(m, src)
+}
+func (m *LayoutGroup) XXX_Size() int {
+	return xxx_messageInfo_LayoutGroup.Size(m)
+}
+func (m *LayoutGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_LayoutGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LayoutGroup proto.InternalMessageInfo
+
+func (m *LayoutGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LayoutGroup) GetEndorsers() []*LayoutEndorser {
+	if m != nil {
+		return m.Endorsers
+	}
+	return nil
+}
+
+// LayoutEndorser identifies an endorsing peer within a layout.
+type LayoutEndorser struct {
+	// The address of the endorsing peer.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The MSP ID of the endorsing peer's organization.
+	MspId                string   `protobuf:"bytes,2,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LayoutEndorser) Reset()         { *m = LayoutEndorser{} }
+func (m *LayoutEndorser) String() string { return proto.CompactTextString(m) }
+func (*LayoutEndorser) ProtoMessage()    {}
+func (*LayoutEndorser) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d7da67551a7d3855, []int{4}
+}
+
+func (m *LayoutEndorser) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LayoutEndorser.Unmarshal(m, b)
+}
+func (m *LayoutEndorser) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LayoutEndorser.Marshal(b, m, deterministic)
+}
+func (m *LayoutEndorser) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LayoutEndorser.Merge(m, src)
+}
+func (m *LayoutEndorser) XXX_Size() int {
+	return xxx_messageInfo_LayoutEndorser.Size(m)
+}
+func (m *LayoutEndorser) XXX_DiscardUnknown() {
+	xxx_messageInfo_LayoutEndorser.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LayoutEndorser proto.InternalMessageInfo
+
+func (m *LayoutEndorser) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LayoutEndorser) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*EndorseWithInterestRequest)(nil), "interest.EndorseWithInterestRequest")
+	proto.RegisterType((*EndorseWithInterestResponse)(nil), "interest.EndorseWithInterestResponse")
+	proto.RegisterType((*EndorsementLayout)(nil), "interest.EndorsementLayout")
+	proto.RegisterType((*LayoutGroup)(nil), "interest.LayoutGroup")
+	proto.RegisterType((*LayoutEndorser)(nil), "interest.LayoutEndorser")
+}
+
+func init() {
+	proto.RegisterFile("pkg/gateway/interest/interest.proto", fileDescriptor_d7da67551a7d3855)
+}
+
+var fileDescriptor_d7da67551a7d3855 = []byte{
+	// 397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xc1, 0x8e, 0xd3, 0x30,
+	0x10, 0x55, 0x16, 0x08, 0xdb, 0xa9, 0xb4, 0xcb, 0x1a, 0x55, 0x84, 0x94, 0x43, 0x15, 0x40, 0xea,
+	0x85, 0x44, 0x4a, 0x11, 0x67, 0x68, 0x85, 0xa0, 0x12, 0x27, 0x5f, 0x10, 0x5c, 0x22, 0x27, 0x19,
+	0x92, 0x88, 0x26, 0x36, 0xb6, 0x23, 0xd4, 0x2f, 0xe1, 0x17, 0xf8, 0x4c, 0x44, 0x6c, 0xb7, 0xb4,
+	0x8d, 0xc4, 0x29, 0x9e, 0x79, 0x6f, 0xde, 0xbc, 0x19, 0x3b, 0xf0, 0x5c, 0x7c, 0xaf, 0x92, 0x8a,
+	0x69, 0xfc, 0xc9, 0xf6, 0x49, 0xd3, 0x69, 0x94, 0xa8, 0xf4, 0xe1, 0x10, 0x0b, 0xc9, 0x35, 0x27,
+	0xd7, 0x2e, 0x0e, 0x67, 0x8e, 0x6a, 0xbf, 0x86, 0x10, 0x3e, 0x13, 0x88, 0x32, 0x11, 0x92, 0x0b,
+	0xae, 0xd8, 0x2e, 0x93, 0xa8, 0x04, 0xef, 0x14, 0x1a, 0x34, 0xfa, 0xed, 0x41, 0xf8, 0xbe, 0x2b,
+	0xb9, 0x54, 0xf8, 0xb9, 0xd1, 0xf5, 0xd6, 0x8a, 0x51, 0xfc, 0xd1, 0xa3, 0xd2, 0xe4, 0x2d, 0xdc,
+	0xa2, 0x41, 0x33, 0x69, 0x52, 0x81, 0xb7, 0xf0, 0x96, 0xd3, 0xf4, 0x49, 0xec, 0xba, 0xd8, 0x6a,
+	0x5b, 0x41, 0x6f, 0xf0, 0x24, 0x26, 0x1f, 0x81, 0x14, 0x35, 0x6b, 0xba, 0x82, 0x97, 0x98, 0x39,
+	0xaf, 0xc1, 0xd5, 0x20, 0xf2, 0xd4, 0x98, 0x50, 0xf1, 0xc6, 0x31, 0x0e, 0xfd, 0xef, 0x8a, 0xf3,
+	0x54, 0xf4, 0xcb, 0x83, 0xf9, 0xa8, 0x55, 0x33, 0x10, 0xd9, 0xc0, 0xa3, 0xa3, 0x57, 0x93, 0xb3,
+	0x66, 0x83, 0x4b, 0xb3, 0x06, 0xa7, 0xb7, 0x78, 0x9a, 0x20, 0x2b, 0xf0, 0x77, 0x6c, 0xcf, 0x7b,
+	0x67, 0x71, 0x1e, 0x1f, 0xf6, 0x6d, 0x6b, 0x5b, 0xec, 0xf4, 0xa7, 0x81, 0x42, 0x2d, 0x35, 0x5a,
+	0xc3, 0xdd, 0x05, 0x48, 0x5e, 0x81, 0x5f, 0x49, 0xde, 0x0b, 0x15, 0x78, 0x8b, 0x7b, 0xcb, 0x69,
+	0x3a, 0x3b, 0x2a, 0x19, 0xc6, 0x87, 0xbf, 0x28, 0xb5, 0xa4, 0xe8, 0x0b, 0x4c, 0xff, 0x49, 0x13,
+	0x02, 0xf7, 0x3b, 0xd6, 0x9a, 0x01, 0x26, 0x74, 0x38, 0x93, 0x37, 0x30, 0xb1, 0x76, 0xa5, 0x0a,
+	0xae, 0x06, 0xd1, 0xe0, 0x5c, 0xd4, 0xfa, 0x90, 0xf4, 0x48, 0x8d, 0xde, 0xc1, 0xcd, 0x29, 0x48,
+	0x02, 0x78, 0xc8, 0xca, 0x52, 0xa2, 0x52, 0xb6, 0x81, 0x0b, 0xc9, 0x0c, 0xfc, 0x56, 0x89, 0xac,
+	0x29, 0x87, 0xf9, 0x27, 0xf4, 0x41, 0xab, 0xc4, 0xb6, 0x4c, 0x3b, 0xb8, 0x76, 0xfb, 0x26, 0x39,
+	0x3c, 0x1e, 0xb9, 0x06, 0xf2, 0xe2, 0x62, 0x53, 0x23, 0x0f, 0x2a, 0x7c, 0xf9, 0x1f, 0x96, 0xb9,
+	0x86, 0xf5, 0xeb, 0xaf, 0x69, 0xd5, 0xe8, 0xba, 0xcf, 0xe3, 0x82, 0xb7, 0x49, 0xbd, 0x17, 0x28,
+	0x77, 0x58, 0x56, 0x28, 0x93, 0x6f, 0x2c, 0x97, 0x4d, 0x91, 0x8c, 0xfd, 0x1a, 0xb9, 0x3f, 0x3c,
+	0xa7, 0xd5, 0x9f, 0x01, 0x00, 0xad, 0x59, 0x1a, 0x18, 0x39, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// InterestClient is the client API for Interest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InterestClient interface {
+	// The EndorseWithInterest service endorses a transaction proposal in the
+	// same way as the Gateway Endorse service, except that the endorsing
+	// peers are selected by the discovery service using the chaincode
+	// interest supplied in the request. The response reports the layout of
+	// endorsing peers that satisfied the endorsement policy.
+	EndorseWithInterest(ctx context.Context, in *EndorseWithInterestRequest, opts ...grpc.CallOption) (*EndorseWithInterestResponse, error)
+}
+
+type interestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInterestClient(cc grpc.ClientConnInterface) InterestClient {
+	return &interestClient{cc}
+}
+
+func (c *interestClient) EndorseWithInterest(ctx context.Context, in *EndorseWithInterestRequest, opts ...grpc.CallOption) (*EndorseWithInterestResponse, error) {
+	out := new(EndorseWithInterestResponse)
+	err := c.cc.Invoke(ctx, "/interest.Interest/EndorseWithInterest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InterestServer is the server API for Interest service.
+type InterestServer interface {
+	// The EndorseWithInterest service endorses a transaction proposal in the
+	// same way as the Gateway Endorse service, except that the endorsing
+	// peers are selected by the discovery service using the chaincode
+	// interest supplied in the request. The response reports the layout of
+	// endorsing peers that satisfied the endorsement policy.
+	EndorseWithInterest(context.Context, *EndorseWithInterestRequest) (*EndorseWithInterestResponse, error)
+}
+
+// UnimplementedInterestServer can be embedded to have forward compatible implementations.
+type UnimplementedInterestServer struct {
+}
+
+func (*UnimplementedInterestServer) EndorseWithInterest(ctx context.Context, req *EndorseWithInterestRequest) (*EndorseWithInterestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndorseWithInterest not implemented")
+}
+
+func RegisterInterestServer(s *grpc.Server, srv InterestServer) {
+	s.RegisterService(&_Interest_serviceDesc, srv)
+}
+
+func _Interest_EndorseWithInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndorseWithInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InterestServer).EndorseWithInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/interest.Interest/EndorseWithInterest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InterestServer).EndorseWithInterest(ctx, req.(*EndorseWithInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Interest_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "interest.Interest",
+	HandlerType: (*InterestServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EndorseWithInterest",
+			Handler:    _Interest_EndorseWithInterest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/gateway/interest/interest.proto",
+}
@@ -0,0 +1,635 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/gateway/resubmit/resubmit.proto
+
+package resubmit
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	gateway "github.com/hyperledger/fabric-protos-go/gateway"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SignatureType identifies the message in a SignatureRequest.
+type SignatureType int32
+
+const (
+	// A serialized Proposal, to be used as the proposal bytes of a
+	// SignedProposal.
+	SignatureType_PROPOSAL SignatureType = 0
+	// A serialized transaction Payload, to be used as the payload of an
+	// Envelope.
+	SignatureType_TRANSACTION SignatureType = 1
+)
+
+var SignatureType_name = map[int32]string{
+	0: "PROPOSAL",
+	1: "TRANSACTION",
+}
+
+var SignatureType_value = map[string]int32{
+	"PROPOSAL":    0,
+	"TRANSACTION": 1,
+}
+
+func (x SignatureType) String() string {
+	return proto.EnumName(SignatureType_name, int32(x))
+}
+
+func (SignatureType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{0}
+}
+
+// SubmitWithRetryRequest is sent by the client application.
+type SubmitWithRetryRequest struct {
+	// Types that are valid to be assigned to Message:
+	//	*SubmitWithRetryRequest_Start
+	//	*SubmitWithRetryRequest_Signature
+	Message              isSubmitWithRetryRequest_Message `protobuf_oneof:"message"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *SubmitWithRetryRequest) Reset()         { *m = SubmitWithRetryRequest{} }
+func (m *SubmitWithRetryRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitWithRetryRequest) ProtoMessage()    {}
+func (*SubmitWithRetryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{0}
+}
+
+func (m *SubmitWithRetryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitWithRetryRequest.Unmarshal(m, b)
+}
+func (m *SubmitWithRetryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitWithRetryRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitWithRetryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitWithRetryRequest.Merge(m, src)
+}
+func (m *SubmitWithRetryRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitWithRetryRequest.Size(m)
+}
+func (m *SubmitWithRetryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitWithRetryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitWithRetryRequest proto.InternalMessageInfo
+
+type isSubmitWithRetryRequest_Message interface {
+	isSubmitWithRetryRequest_Message()
+}
+
+type SubmitWithRetryRequest_Start struct {
+	Start *SubmitWithRetryStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type SubmitWithRetryRequest_Signature struct {
+	Signature *SignatureResponse `protobuf:"bytes,2,opt,name=signature,proto3,oneof"`
+}
+
+func (*SubmitWithRetryRequest_Start) isSubmitWithRetryRequest_Message() {}
+
+func (*SubmitWithRetryRequest_Signature) isSubmitWithRetryRequest_Message() {}
+
+func (m *SubmitWithRetryRequest) GetMessage() isSubmitWithRetryRequest_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *SubmitWithRetryRequest) GetStart() *SubmitWithRetryStart {
+	if x, ok := m.GetMessage().(*SubmitWithRetryRequest_Start); ok {
+		return x.Start
+	}
+	return nil
+}
+
+func (m *SubmitWithRetryRequest) GetSignature() *SignatureResponse {
+	if x, ok := m.GetMessage().(*SubmitWithRetryRequest_Signature); ok {
+		return x.Signature
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SubmitWithRetryRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SubmitWithRetryRequest_Start)(nil),
+		(*SubmitWithRetryRequest_Signature)(nil),
+	}
+}
+
+// SubmitWithRetryStart begins a SubmitWithRetry invocation.
+type SubmitWithRetryStart struct {
+	// The endorse request for the first attempt. The signed proposal it
+	// contains is used as the template for the proposals of later attempts.
+	EndorseRequest *gateway.EndorseRequest `protobuf:"bytes,1,opt,name=endorse_request,json=endorseRequest,proto3" json:"endorse_request,omitempty"`
+	// The maximum number of attempts, including the first. Values of zero, or
+	// greater than the maximum configured on the gateway peer, are replaced
+	// by the configured maximum.
+	MaxAttempts          uint32   `protobuf:"varint,2,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitWithRetryStart) Reset()         { *m = SubmitWithRetryStart{} }
+func (m *SubmitWithRetryStart) String() string { return proto.CompactTextString(m) }
+func (*SubmitWithRetryStart) ProtoMessage()    {}
+func (*SubmitWithRetryStart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{1}
+}
+
+func (m *SubmitWithRetryStart) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitWithRetryStart.Unmarshal(m, b)
+}
+func (m *SubmitWithRetryStart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitWithRetryStart.Marshal(b, m, deterministic)
+}
+func (m *SubmitWithRetryStart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitWithRetryStart.Merge(m, src)
+}
+func (m *SubmitWithRetryStart) XXX_Size() int {
+	return xxx_messageInfo_SubmitWithRetryStart.Size(m)
+}
+func (m *SubmitWithRetryStart) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitWithRetryStart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitWithRetryStart proto.InternalMessageInfo
+
+func (m *SubmitWithRetryStart) GetEndorseRequest() *gateway.EndorseRequest {
+	if m != nil {
+		return m.EndorseRequest
+	}
+	return nil
+}
+
+func (m *SubmitWithRetryStart) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+// SignatureResponse contains the client's signature of the message in the
+// preceding SignatureRequest.
+type SignatureResponse struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignatureResponse) Reset()         { *m = SignatureResponse{} }
+func (m *SignatureResponse) String() string { return proto.CompactTextString(m) }
+func (*SignatureResponse) ProtoMessage()    {}
+func (*SignatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{2}
+}
+
+func (m *SignatureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignatureResponse.Unmarshal(m, b)
+}
+func (m *SignatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignatureResponse.Marshal(b, m, deterministic)
+}
+func (m *SignatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignatureResponse.Merge(m, src)
+}
+func (m *SignatureResponse) XXX_Size() int {
+	return xxx_messageInfo_SignatureResponse.Size(m)
+}
+func (m *SignatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignatureResponse proto.InternalMessageInfo
+
+func (m *SignatureResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// SubmitWithRetryResponse is sent by the gateway.
+type SubmitWithRetryResponse struct {
+	// Types that are valid to be assigned to Message:
+	//	*SubmitWithRetryResponse_SignatureRequest
+	//	*SubmitWithRetryResponse_AttemptResult
+	Message              isSubmitWithRetryResponse_Message `protobuf_oneof:"message"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *SubmitWithRetryResponse) Reset()         { *m = SubmitWithRetryResponse{} }
+func (m *SubmitWithRetryResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitWithRetryResponse) ProtoMessage()    {}
+func (*SubmitWithRetryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{3}
+}
+
+func (m *SubmitWithRetryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitWithRetryResponse.Unmarshal(m, b)
+}
+func (m *SubmitWithRetryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitWithRetryResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitWithRetryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitWithRetryResponse.Merge(m, src)
+}
+func (m *SubmitWithRetryResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitWithRetryResponse.Size(m)
+}
+func (m *SubmitWithRetryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitWithRetryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitWithRetryResponse proto.InternalMessageInfo
+
+type isSubmitWithRetryResponse_Message interface {
+	isSubmitWithRetryResponse_Message()
+}
+
+type SubmitWithRetryResponse_SignatureRequest struct {
+	SignatureRequest *SignatureRequest `protobuf:"bytes,1,opt,name=signature_request,json=signatureRequest,proto3,oneof"`
+}
+
+type SubmitWithRetryResponse_AttemptResult struct {
+	AttemptResult *AttemptResult `protobuf:"bytes,2,opt,name=attempt_result,json=attemptResult,proto3,oneof"`
+}
+
+func (*SubmitWithRetryResponse_SignatureRequest) isSubmitWithRetryResponse_Message() {}
+
+func (*SubmitWithRetryResponse_AttemptResult) isSubmitWithRetryResponse_Message() {}
+
+func (m *SubmitWithRetryResponse) GetMessage() isSubmitWithRetryResponse_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *SubmitWithRetryResponse) GetSignatureRequest() *SignatureRequest {
+	if x, ok := m.GetMessage().(*SubmitWithRetryResponse_SignatureRequest); ok {
+		return x.SignatureRequest
+	}
+	return nil
+}
+
+func (m *SubmitWithRetryResponse) GetAttemptResult() *AttemptResult {
+	if x, ok := m.GetMessage().(*SubmitWithRetryResponse_AttemptResult); ok {
+		return x.AttemptResult
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SubmitWithRetryResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SubmitWithRetryResponse_SignatureRequest)(nil),
+		(*SubmitWithRetryResponse_AttemptResult)(nil),
+	}
+}
+
+// SignatureRequest asks the client to sign a proposal or transaction for an
+// attempt. Clients should check that a proposal differs from the template
+// proposal only in its transaction ID, nonce and timestamp before signing it.
+type SignatureRequest struct {
+	// The attempt number, starting at one.
+	Attempt uint32 `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// The transaction ID of the attempt.
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// The type of message to sign.
+	Type SignatureType `protobuf:"varint,3,opt,name=type,proto3,enum=resubmit.SignatureType" json:"type,omitempty"`
+	// The serialized Proposal or transaction Payload to sign.
+	Message              []byte   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignatureRequest) Reset()         { *m = SignatureRequest{} }
+func (m *SignatureRequest) String() string { return proto.CompactTextString(m) }
+func (*SignatureRequest) ProtoMessage()    {}
+func (*SignatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{4}
+}
+
+func (m *SignatureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignatureRequest.Unmarshal(m, b)
+}
+func (m *SignatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignatureRequest.Marshal(b, m, deterministic)
+}
+func (m *SignatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignatureRequest.Merge(m, src)
+}
+func (m *SignatureRequest) XXX_Size() int {
+	return xxx_messageInfo_SignatureRequest.Size(m)
+}
+func (m *SignatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignatureRequest proto.InternalMessageInfo
+
+func (m *SignatureRequest) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *SignatureRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *SignatureRequest) GetType() SignatureType {
+	if m != nil {
+		return m.Type
+	}
+	return SignatureType_PROPOSAL
+}
+
+func (m *SignatureRequest) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+// AttemptResult contains the commit status of an attempt.
+type AttemptResult struct {
+	// The attempt number, starting at one.
+	Attempt uint32 `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// The transaction ID of the attempt.
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// The validation code of the committed transaction.
+	Result peer.TxValidationCode `protobuf:"varint,3,opt,name=result,proto3,enum=protos.TxValidationCode" json:"result,omitempty"`
+	// Block number that contains the transaction.
+	BlockNumber          uint64   `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttemptResult) Reset()         { *m = AttemptResult{} }
+func (m *AttemptResult) String() string { return proto.CompactTextString(m) }
+func (*AttemptResult) ProtoMessage()    {}
+func (*AttemptResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c68748d3e80ad567, []int{5}
+}
+
+func (m *AttemptResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttemptResult.Unmarshal(m, b)
+}
+func (m *AttemptResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttemptResult.Marshal(b, m, deterministic)
+}
+func (m *AttemptResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttemptResult.Merge(m, src)
+}
+func (m *AttemptResult) XXX_Size() int {
+	return xxx_messageInfo_AttemptResult.Size(m)
+}
+func (m *AttemptResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttemptResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttemptResult proto.InternalMessageInfo
+
+func (m *AttemptResult) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *AttemptResult) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *AttemptResult) GetResult() peer.TxValidationCode {
+	if m != nil {
+		return m.Result
+	}
+	return peer.TxValidationCode_VALID
+}
+
+func (m *AttemptResult) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("resubmit.SignatureType", SignatureType_name, SignatureType_value)
+	proto.RegisterType((*SubmitWithRetryRequest)(nil), "resubmit.SubmitWithRetryRequest")
+	proto.RegisterType((*SubmitWithRetryStart)(nil), "resubmit.SubmitWithRetryStart")
+	proto.RegisterType((*SignatureResponse)(nil), "resubmit.SignatureResponse")
+	proto.RegisterType((*SubmitWithRetryResponse)(nil), "resubmit.SubmitWithRetryResponse")
+	proto.RegisterType((*SignatureRequest)(nil), "resubmit.SignatureRequest")
+	proto.RegisterType((*AttemptResult)(nil), "resubmit.AttemptResult")
+}
+
+func init() {
+	proto.RegisterFile("pkg/gateway/resubmit/resubmit.proto", fileDescriptor_c68748d3e80ad567)
+}
+
+var fileDescriptor_c68748d3e80ad567 = []byte{
+	// 537 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xdf, 0x6e, 0x12, 0x41,
+	0x14, 0xc6, 0x59, 0xad, 0xfd, 0x73, 0x60, 0x81, 0x4e, 0xb4, 0x6c, 0xd0, 0x18, 0x8a, 0x31, 0x21,
+	0x9a, 0xec, 0x56, 0x34, 0xde, 0x78, 0x53, 0xda, 0x98, 0x40, 0x62, 0xa0, 0x19, 0x88, 0x26, 0xbd,
+	0x21, 0xb3, 0xec, 0xe9, 0xb2, 0x29, 0xfb, 0xc7, 0x99, 0x21, 0x42, 0x7c, 0x11, 0xe3, 0xbd, 0x0f,
+	0xe0, 0x1b, 0x1a, 0x66, 0x87, 0xed, 0x42, 0xd1, 0x1b, 0xaf, 0x60, 0xce, 0x7c, 0x1f, 0xe7, 0x77,
+	0x3e, 0xce, 0x2e, 0xbc, 0x48, 0x6e, 0x7d, 0xc7, 0x67, 0x12, 0xbf, 0xb1, 0xa5, 0xc3, 0x51, 0xcc,
+	0xdd, 0x30, 0x90, 0xd9, 0x17, 0x3b, 0xe1, 0xb1, 0x8c, 0xc9, 0xe1, 0xfa, 0x5c, 0x7f, 0xb2, 0x96,
+	0xea, 0xcf, 0x54, 0x50, 0x3f, 0x49, 0x10, 0xb9, 0x23, 0x39, 0x8b, 0x04, 0x9b, 0xc8, 0x20, 0x8e,
+	0xd2, 0x7a, 0xf3, 0x87, 0x01, 0x27, 0x43, 0xe5, 0xfc, 0x12, 0xc8, 0x29, 0x45, 0xc9, 0x97, 0x14,
+	0xbf, 0xce, 0x51, 0x48, 0xf2, 0x1e, 0x1e, 0x09, 0xc9, 0xb8, 0xb4, 0x8c, 0x86, 0xd1, 0x2a, 0xb6,
+	0x9f, 0xdb, 0x59, 0xcf, 0x2d, 0xc3, 0x70, 0xa5, 0xea, 0x16, 0x68, 0x2a, 0x27, 0x1f, 0xe0, 0x48,
+	0x04, 0x7e, 0xc4, 0xe4, 0x9c, 0xa3, 0xf5, 0x40, 0x79, 0x9f, 0xe6, 0xbc, 0xeb, 0x2b, 0x8a, 0x22,
+	0x89, 0x23, 0x81, 0xdd, 0x02, 0xbd, 0xd3, 0x5f, 0x1c, 0xc1, 0x41, 0x88, 0x42, 0x30, 0x1f, 0x9b,
+	0xdf, 0xe1, 0xf1, 0xae, 0x46, 0xe4, 0x1c, 0x2a, 0x18, 0x79, 0x31, 0x17, 0x38, 0xe6, 0x29, 0xaa,
+	0x26, 0xac, 0xd9, 0xeb, 0x99, 0x3f, 0xa6, 0xf7, 0x7a, 0x12, 0x5a, 0xc6, 0x8d, 0x33, 0x39, 0x85,
+	0x52, 0xc8, 0x16, 0x63, 0x26, 0x25, 0x86, 0x89, 0x14, 0x0a, 0xd2, 0xa4, 0xc5, 0x90, 0x2d, 0x3a,
+	0xba, 0xd4, 0x7c, 0x03, 0xc7, 0xf7, 0x48, 0xc9, 0xb3, 0xfc, 0x64, 0xab, 0x9e, 0xa5, 0x1c, 0x7a,
+	0xf3, 0xb7, 0x01, 0xb5, 0x7b, 0x51, 0x6a, 0x67, 0x0f, 0x8e, 0x33, 0xe1, 0x16, 0x75, 0x7d, 0x67,
+	0x36, 0x4a, 0xd1, 0x2d, 0xd0, 0xaa, 0xd8, 0xaa, 0x91, 0x73, 0x28, 0x6b, 0xf0, 0xf1, 0xca, 0x38,
+	0x93, 0x3a, 0xe3, 0xda, 0xdd, 0xef, 0xe8, 0x29, 0xa8, 0xba, 0xee, 0x16, 0xa8, 0xc9, 0xf2, 0x85,
+	0x7c, 0xc6, 0x3f, 0x0d, 0xa8, 0x6e, 0x77, 0x25, 0x16, 0x1c, 0x68, 0x83, 0x42, 0x34, 0xe9, 0xfa,
+	0x48, 0x5e, 0x42, 0x39, 0xb7, 0x42, 0xe3, 0xc0, 0x53, 0xbd, 0x8f, 0xa8, 0x99, 0xab, 0xf6, 0x3c,
+	0xf2, 0x1a, 0xf6, 0xe4, 0x32, 0x41, 0xeb, 0x61, 0xc3, 0x68, 0x95, 0xf3, 0x60, 0x59, 0xab, 0xd1,
+	0x32, 0x41, 0xaa, 0x44, 0xc4, 0xca, 0x68, 0xac, 0x3d, 0x15, 0x69, 0x06, 0xf7, 0xcb, 0x00, 0x73,
+	0x63, 0x94, 0xff, 0x27, 0x3b, 0x83, 0x7d, 0x1d, 0x5a, 0xca, 0x66, 0xa5, 0x8f, 0x81, 0xb0, 0x47,
+	0x8b, 0xcf, 0x6c, 0x16, 0x78, 0x6c, 0xa5, 0xbb, 0x8c, 0x3d, 0xa4, 0x5a, 0xb7, 0xda, 0x15, 0x77,
+	0x16, 0x4f, 0x6e, 0xc7, 0xd1, 0x3c, 0x74, 0x91, 0x2b, 0xc6, 0x3d, 0x5a, 0x54, 0xb5, 0xbe, 0x2a,
+	0xbd, 0xb2, 0xc1, 0xdc, 0x18, 0x8c, 0x94, 0xe0, 0xf0, 0x8a, 0x0e, 0xae, 0x06, 0xc3, 0xce, 0xa7,
+	0x6a, 0x81, 0x54, 0xa0, 0x38, 0xa2, 0x9d, 0xfe, 0xb0, 0x73, 0x39, 0xea, 0x0d, 0xfa, 0x55, 0xa3,
+	0x7d, 0x03, 0x87, 0x54, 0x27, 0x42, 0xae, 0xa1, 0xb2, 0xb5, 0x33, 0xa4, 0xf1, 0xd7, 0x07, 0x4d,
+	0xff, 0x41, 0xf5, 0xd3, 0x7f, 0x28, 0xd2, 0x85, 0x6b, 0x19, 0x67, 0xc6, 0xc5, 0xbb, 0xeb, 0xb6,
+	0x1f, 0xc8, 0xe9, 0xdc, 0xb5, 0x27, 0x71, 0xe8, 0x4c, 0x97, 0x09, 0xf2, 0x19, 0x7a, 0x3e, 0x72,
+	0xe7, 0x86, 0xb9, 0x3c, 0x98, 0x38, 0xbb, 0xde, 0x2c, 0xee, 0xbe, 0x4a, 0xe4, 0xed, 0x9f, 0x01,
+	0x00, 0x3a, 0xb4, 0x01, 0x65, 0x78, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ResubmitClient is the client API for Resubmit service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ResubmitClient interface {
+	// The SubmitWithRetry service endorses, submits and waits for the commit
+	// of a transaction. If the transaction is invalidated with an
+	// MVCC_READ_CONFLICT or PHANTOM_READ_CONFLICT validation code, the
+	// transaction proposal is regenerated with a new transaction ID and the
+	// cycle is repeated, up to the requested maximum number of attempts.
+	//
+	// The client sends a SubmitWithRetryStart message, and then responds to
+	// each SignatureRequest message from the gateway with a
+	// SignatureResponse. The gateway sends an AttemptResult message after each
+	// attempt commits, and closes the stream after the final attempt.
+	SubmitWithRetry(ctx context.Context, opts ...grpc.CallOption) (Resubmit_SubmitWithRetryClient, error)
+}
+
+type resubmitClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResubmitClient(cc grpc.ClientConnInterface) ResubmitClient {
+	return &resubmitClient{cc}
+}
+
+func (c *resubmitClient) SubmitWithRetry(ctx context.Context, opts ...grpc.CallOption) (Resubmit_SubmitWithRetryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Resubmit_serviceDesc.Streams[0], "/resubmit.Resubmit/SubmitWithRetry", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resubmitSubmitWithRetryClient{stream}
+	return x, nil
+}
+
+type Resubmit_SubmitWithRetryClient interface {
+	Send(*SubmitWithRetryRequest) error
+	Recv() (*SubmitWithRetryResponse, error)
+	grpc.ClientStream
+}
+
+type resubmitSubmitWithRetryClient struct {
+	grpc.ClientStream
+}
+
+func (x *resubmitSubmitWithRetryClient) Send(m *SubmitWithRetryRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *resubmitSubmitWithRetryClient) Recv() (*SubmitWithRetryResponse, error) {
+	m := new(SubmitWithRetryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ResubmitServer is the server API for Resubmit service.
+type ResubmitServer interface {
+	// The SubmitWithRetry service endorses, submits and waits for the commit
+	// of a transaction. If the transaction is invalidated with an
+	// MVCC_READ_CONFLICT or PHANTOM_READ_CONFLICT validation code, the
+	// transaction proposal is regenerated with a new transaction ID and the
+	// cycle is repeated, up to the requested maximum number of attempts.
+	//
+	// The client sends a SubmitWithRetryStart message, and then responds to
+	// each SignatureRequest message from the gateway with a
+	// SignatureResponse. The gateway sends an AttemptResult message after each
+	// attempt commits, and closes the stream after the final attempt.
+	SubmitWithRetry(Resubmit_SubmitWithRetryServer) error
+}
+
+// UnimplementedResubmitServer can be embedded to have forward compatible implementations.
+type UnimplementedResubmitServer struct {
+}
+
+func (*UnimplementedResubmitServer) SubmitWithRetry(srv Resubmit_SubmitWithRetryServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubmitWithRetry not implemented")
+}
+
+func RegisterResubmitServer(s *grpc.Server, srv ResubmitServer) {
+	s.RegisterService(&_Resubmit_serviceDesc, srv)
+}
+
+func _Resubmit_SubmitWithRetry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ResubmitServer).SubmitWithRetry(&resubmitSubmitWithRetryServer{stream})
+}
+
+type Resubmit_SubmitWithRetryServer interface {
+	Send(*SubmitWithRetryResponse) error
+	Recv() (*SubmitWithRetryRequest, error)
+	grpc.ServerStream
+}
+
+type resubmitSubmitWithRetryServer struct {
+	grpc.ServerStream
+}
+
+func (x *resubmitSubmitWithRetryServer) Send(m *SubmitWithRetryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *resubmitSubmitWithRetryServer) Recv() (*SubmitWithRetryRequest, error) {
+	m := new(SubmitWithRetryRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Resubmit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "resubmit.Resubmit",
+	HandlerType: (*ResubmitServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubmitWithRetry",
+			Handler:       _Resubmit_SubmitWithRetry_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/gateway/resubmit/resubmit.proto",
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pkg/lifecycle/history/history.proto
+
+package history
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// QueryChaincodeDefinitionHistoryArgs is the message used as arguments to
+// `_lifecycle.QueryChaincodeDefinitionHistory`.
+type QueryChaincodeDefinitionHistoryArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionHistoryArgs) Reset()         { *m = QueryChaincodeDefinitionHistoryArgs{} }
+func (m *QueryChaincodeDefinitionHistoryArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionHistoryArgs) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionHistoryArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86ac18253eed44bb, []int{0}
+}
+
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Marshal(b, m, deterministic)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Merge(m, src)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Size(m)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionHistoryArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionHistoryResult is the message returned by
+// `_lifecycle.QueryChaincodeDefinitionHistory`. It contains the chaincode
+// definitions committed for the chaincode in ascending sequence order. The
+// last definition is the currently committed one.
+type QueryChaincodeDefinitionHistoryResult struct {
+	ChaincodeDefinitions []*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition `protobuf:"bytes,1,rep,name=chaincode_definitions,json=chaincodeDefinitions,proto3" json:"chaincode_definitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                                     `json:"-"`
+	XXX_unrecognized     []byte                                                       `json:"-"`
+	XXX_sizecache        int32                                                        `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult) Reset()         { *m = QueryChaincodeDefinitionHistoryResult{} }
+func (m *QueryChaincodeDefinitionHistoryResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionHistoryResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionHistoryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86ac18253eed44bb, []int{1}
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Marshal(b, m, deterministic)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Merge(m, src)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Size(m)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionHistoryResult proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionHistoryResult) GetChaincodeDefinitions() []*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition {
+	if m != nil {
+		return m.ChaincodeDefinitions
+	}
+	return nil
+}
+
+type QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition struct {
+	Sequence             int64                         `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                        `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                        `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                        `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                        `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *peer.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                          `protobuf:"varint,7,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) Reset() {
+	*m = QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{}
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) ProtoMessage() {}
+func (*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_86ac18253eed44bb, []int{1, 0}
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Marshal(b, m, deterministic)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Merge(m, src)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Size(m)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetCollections() *peer.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*QueryChaincodeDefinitionHistoryArgs)(nil), "history.QueryChaincodeDefinitionHistoryArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionHistoryResult)(nil), "history.QueryChaincodeDefinitionHistoryResult")
+	proto.RegisterType((*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition)(nil), "history.QueryChaincodeDefinitionHistoryResult.ChaincodeDefinition")
+}
+
+func init() {
+	proto.RegisterFile("pkg/lifecycle/history/history.proto", fileDescriptor_86ac18253eed44bb)
+}
+
+var fileDescriptor_86ac18253eed44bb = []byte{
+	// 373 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xc1, 0x8a, 0xdb, 0x30,
+	0x10, 0x40, 0xf1, 0x7a, 0xbb, 0xd9, 0x2a, 0x5b, 0xe8, 0x6a, 0x13, 0x10, 0xb9, 0xd4, 0x24, 0x14,
+	0x0c, 0xa5, 0x36, 0x4d, 0xe8, 0xa1, 0xc7, 0x24, 0x3d, 0xf4, 0x98, 0xea, 0xd8, 0x4b, 0x50, 0xe4,
+	0xb1, 0x2d, 0x22, 0x4b, 0x8e, 0x24, 0xa7, 0xf8, 0xf7, 0xfa, 0x2f, 0xfd, 0x8f, 0x12, 0x3b, 0x4e,
+	0x0c, 0x0d, 0xb4, 0x27, 0x7b, 0xe6, 0xbd, 0x91, 0x66, 0x18, 0xa1, 0x59, 0xb9, 0xcf, 0x62, 0x29,
+	0x52, 0xe0, 0x35, 0x97, 0x10, 0xe7, 0xc2, 0x3a, 0x6d, 0xea, 0xee, 0x1b, 0x95, 0x46, 0x3b, 0x8d,
+	0x07, 0xe7, 0x70, 0x32, 0x2e, 0x01, 0x4c, 0xcc, 0xb5, 0x94, 0xc0, 0x9d, 0xd0, 0xaa, 0xe5, 0xd3,
+	0x2f, 0x68, 0xf6, 0xbd, 0x02, 0x53, 0xaf, 0x73, 0x26, 0x14, 0xd7, 0x09, 0x7c, 0x85, 0x54, 0x28,
+	0x71, 0x32, 0xbe, 0xb5, 0x95, 0x4b, 0x93, 0x59, 0x8c, 0xd1, 0xbd, 0x62, 0x05, 0x10, 0x2f, 0xf0,
+	0xc2, 0xd7, 0xb4, 0xf9, 0x9f, 0xfe, 0xf6, 0xd1, 0xfb, 0x7f, 0xd4, 0x52, 0xb0, 0x95, 0x74, 0xf8,
+	0x27, 0x1a, 0xf3, 0xce, 0xd9, 0x26, 0x17, 0xc9, 0x12, 0x2f, 0xf0, 0xc3, 0xe1, 0x7c, 0x15, 0x75,
+	0x3d, 0xff, 0xd7, 0x71, 0xd1, 0x0d, 0x81, 0x8e, 0xf8, 0xdf, 0x49, 0x3b, 0xf9, 0x75, 0x87, 0x5e,
+	0x6e, 0xd8, 0x78, 0x82, 0x1e, 0x2d, 0x1c, 0x2a, 0x50, 0xbc, 0x1d, 0xc9, 0xa7, 0x97, 0x18, 0x13,
+	0x34, 0x38, 0x82, 0xb1, 0x42, 0x2b, 0x72, 0xd7, 0x4c, 0xdb, 0x85, 0xf8, 0x23, 0xc2, 0xa0, 0x12,
+	0x6d, 0x2c, 0x14, 0xa0, 0xdc, 0xb6, 0x94, 0x55, 0x26, 0x14, 0xf1, 0x1b, 0xe9, 0xb9, 0x47, 0x36,
+	0x0d, 0xc0, 0x1f, 0xd0, 0xf3, 0x91, 0x49, 0x91, 0xb0, 0xd3, 0x95, 0x9d, 0x7d, 0xdf, 0xd8, 0x6f,
+	0xaf, 0xe0, 0x2c, 0x7f, 0x42, 0xa3, 0xbe, 0xcc, 0x0c, 0x2b, 0xc0, 0x81, 0x21, 0xaf, 0x02, 0x2f,
+	0x7c, 0xa2, 0x2f, 0x3d, 0xbf, 0x43, 0x78, 0x89, 0x86, 0xd7, 0x75, 0x5a, 0xf2, 0x10, 0x78, 0xe1,
+	0x70, 0xfe, 0xae, 0xdd, 0xab, 0x8d, 0xd6, 0x17, 0xb4, 0xd6, 0x2a, 0x15, 0xd9, 0x86, 0xf1, 0x3d,
+	0xcb, 0x80, 0xf6, 0x6b, 0xf0, 0x0c, 0xbd, 0x11, 0x4a, 0xb8, 0xad, 0x81, 0x43, 0x25, 0x0c, 0x24,
+	0x64, 0x10, 0x78, 0xe1, 0x23, 0x7d, 0x3a, 0x25, 0xe9, 0x39, 0xb7, 0xfa, 0xfc, 0x63, 0x91, 0x09,
+	0x97, 0x57, 0xbb, 0x88, 0xeb, 0x22, 0xce, 0xeb, 0x12, 0x8c, 0x84, 0x24, 0x03, 0x13, 0xa7, 0x6c,
+	0x67, 0x04, 0x8f, 0x6f, 0xbe, 0xc3, 0xdd, 0x43, 0xd3, 0xc8, 0xe2, 0xcf, 0x00, 0x74, 0xa4, 0x51,
+	0xa4, 0xa7, 0x02, 0x00, 0x00,
+}